@@ -0,0 +1,86 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/atdevten/peace/internal/application/usecases"
+	"github.com/atdevten/peace/internal/domain/value_objects"
+	"github.com/atdevten/peace/internal/infrastructure/config"
+	"github.com/atdevten/peace/internal/infrastructure/database"
+	"github.com/atdevten/peace/internal/infrastructure/database/postgres/repository"
+	"github.com/atdevten/peace/internal/infrastructure/filestore"
+)
+
+// One pass of the account retention job, for running from cron instead of inside the server
+func main() {
+	configPath := flag.String("config", "configs/config.env", "The path to the config file")
+	dryRun := flag.Bool("dry-run", false, "If true, only log what would be purged or anonymized")
+	mode := flag.String("mode", "", "purge or anonymize, overriding RETENTION_MODE")
+	flag.Parse()
+
+	// Load configuration
+	cfg, err := config.LoadWithPath(*configPath)
+	if err != nil {
+		log.Fatalf("Failed to load config: %v", err)
+	}
+	if *mode == "" {
+		*mode = cfg.Retention.Mode
+	}
+	action, err := value_objects.NewRetentionAction(*mode)
+	if err != nil {
+		log.Fatalf("Invalid mode: %v", err)
+	}
+
+	// Connect to database
+	dbManager, err := database.NewDatabaseManager(cfg)
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+	defer dbManager.Close()
+
+	exportStore, err := filestore.NewLocalStore(cfg.Export.Dir)
+	if err != nil {
+		log.Fatalf("Failed to open export directory: %v", err)
+	}
+
+	retentionUC := usecases.NewRetentionUseCase(
+		repository.NewPostgreSQLRetentionRepository(dbManager.Postgres),
+		repository.NewPostgreSQLDataExportRepository(dbManager.Postgres),
+		exportStore,
+		usecases.RetentionOptions{
+			GracePeriod: cfg.Retention.GracePeriod,
+			Action:      *action,
+			DryRun:      *dryRun || cfg.Retention.DryRun,
+			BatchSize:   cfg.Retention.BatchSize,
+		},
+	)
+
+	// Stop between accounts on Ctrl+C
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	log.Printf("Processing accounts deleted more than %s ago (%s)", cfg.Retention.GracePeriod, action)
+	report, err := retentionUC.RunOnce(ctx)
+
+	// Final summary
+	log.Printf("=== FINAL SUMMARY ===")
+	log.Printf("Accounts: %d", report.Accounts)
+	log.Printf("Failed: %d", report.Failed)
+	log.Printf("Records: %d", report.Records)
+	log.Printf("Sessions: %d", report.Sessions)
+	log.Printf("Exports: %d", report.Exports)
+
+	if err != nil {
+		log.Fatalf("Retention pass failed: %v", err)
+	}
+	if report.DryRun {
+		log.Println("DRY RUN completed - no data was changed")
+	} else {
+		log.Println("Retention pass completed successfully!")
+	}
+}
@@ -0,0 +1,55 @@
+package commands
+
+import (
+	"strings"
+
+	"github.com/atdevten/peace/internal/domain/entities"
+	"github.com/atdevten/peace/internal/pkg/pagination"
+)
+
+// AuditEventFilters narrows the audit log listing; nil fields are ignored
+type AuditEventFilters struct {
+	ActorID    *string
+	Action     *string
+	TargetType *string
+	TargetID   *string
+	From       *string // RFC3339, inclusive
+	To         *string // RFC3339, exclusive
+}
+
+type ListAuditEventsCommand struct {
+	Filters AuditEventFilters
+	Limit   int
+	Cursor  *string // opaque next_cursor from a previous page
+}
+
+func NewListAuditEventsCommand(filters AuditEventFilters, limit *int, cursor *string) (*ListAuditEventsCommand, error) {
+	pageSize, err := pagination.NormalizeLimit(limit)
+	if err != nil {
+		return nil, err
+	}
+
+	// Blank filters are ignored rather than matching nothing
+	for _, field := range []**string{&filters.ActorID, &filters.Action, &filters.TargetType, &filters.TargetID, &filters.From, &filters.To} {
+		if *field == nil {
+			continue
+		}
+		value := strings.TrimSpace(**field)
+		if value == "" {
+			*field = nil
+		} else {
+			*field = &value
+		}
+	}
+
+	return &ListAuditEventsCommand{
+		Filters: filters,
+		Limit:   pageSize,
+		Cursor:  cursor,
+	}, nil
+}
+
+type AuditEventsPage struct {
+	Events     []*entities.AuditEvent
+	NextCursor *string // nil on the last page
+}
@@ -2,6 +2,9 @@ package commands
 
 import (
 	"errors"
+	"time"
+
+	"github.com/atdevten/peace/internal/domain/entities"
 )
 
 type RegisterCommand struct {
@@ -34,12 +37,19 @@ func NewRegisterCommand(email string, username string, password string, firstNam
 	}, nil
 }
 
+// ClientInfo describes the client a login comes from; it is recorded on the session
+type ClientInfo struct {
+	UserAgent string
+	IPAddress string
+}
+
 type LoginCommand struct {
 	Email    string
 	Password string
+	Client   ClientInfo
 }
 
-func NewLoginCommand(email, password string) (LoginCommand, error) {
+func NewLoginCommand(email, password string, client ClientInfo) (LoginCommand, error) {
 	if email == "" {
 		return LoginCommand{}, errors.New("email is required")
 	}
@@ -51,5 +61,188 @@ func NewLoginCommand(email, password string) (LoginCommand, error) {
 	return LoginCommand{
 		Email:    email,
 		Password: password,
+		Client:   client,
+	}, nil
+}
+
+// OAuthLoginCommand redeems the authorization code a provider redirected back with
+type OAuthLoginCommand struct {
+	Provider   string
+	Code       string
+	State      string // state the provider redirected back with
+	LoginState string // state of the login this browser started, kept in its login cookie
+	Verifier   string // PKCE verifier of that login
+	Client     ClientInfo
+}
+
+func NewOAuthLoginCommand(provider, code, state, loginState, verifier string, client ClientInfo) (OAuthLoginCommand, error) {
+	if provider == "" {
+		return OAuthLoginCommand{}, errors.New("provider is required")
+	}
+
+	if code == "" {
+		return OAuthLoginCommand{}, errors.New("authorization code is required")
+	}
+
+	if state == "" {
+		return OAuthLoginCommand{}, errors.New("state is required")
+	}
+
+	return OAuthLoginCommand{
+		Provider:   provider,
+		Code:       code,
+		State:      state,
+		LoginState: loginState,
+		Verifier:   verifier,
+		Client:     client,
+	}, nil
+}
+
+// LoginResult is the outcome of a login: a token pair, an MFA challenge when
+// the account has two-factor authentication enabled, a link challenge when an
+// external login matches the email of an account it is not linked to yet, or a
+// restore token when the account was deleted and is still in its grace period
+type LoginResult struct {
+	User          *entities.User
+	AccessToken   string
+	RefreshToken  string
+	MFAToken      string     // set instead of the token pair when a second factor is required
+	LinkToken     string     // set instead of the token pair when the account password must confirm a link
+	Provider      string     // provider of the account a link challenge is for
+	RestoreToken  string     // set instead of the token pair when the account is deleted but can be restored
+	RestoreBefore *time.Time // end of the grace period of a deleted account
+}
+
+func (r *LoginResult) MFARequired() bool {
+	return r.MFAToken != ""
+}
+
+func (r *LoginResult) LinkRequired() bool {
+	return r.LinkToken != ""
+}
+
+func (r *LoginResult) RestoreRequired() bool {
+	return r.RestoreToken != ""
+}
+
+type VerifyMFACommand struct {
+	MFAToken string
+	Code     string // TOTP code or recovery code
+	Client   ClientInfo
+}
+
+func NewVerifyMFACommand(mfaToken, code string, client ClientInfo) (VerifyMFACommand, error) {
+	if mfaToken == "" {
+		return VerifyMFACommand{}, errors.New("mfa token is required")
+	}
+
+	if code == "" {
+		return VerifyMFACommand{}, errors.New("code is required")
+	}
+
+	return VerifyMFACommand{
+		MFAToken: mfaToken,
+		Code:     code,
+		Client:   client,
+	}, nil
+}
+
+type RestoreAccountCommand struct {
+	RestoreToken string
+	Client       ClientInfo
+}
+
+func NewRestoreAccountCommand(restoreToken string, client ClientInfo) (RestoreAccountCommand, error) {
+	if restoreToken == "" {
+		return RestoreAccountCommand{}, errors.New("restore token is required")
+	}
+
+	return RestoreAccountCommand{
+		RestoreToken: restoreToken,
+		Client:       client,
+	}, nil
+}
+
+type ConfirmLinkCommand struct {
+	LinkToken string
+	Password  string // password of the existing account
+	Client    ClientInfo
+}
+
+func NewConfirmLinkCommand(linkToken, password string, client ClientInfo) (ConfirmLinkCommand, error) {
+	if linkToken == "" {
+		return ConfirmLinkCommand{}, errors.New("link token is required")
+	}
+
+	if password == "" {
+		return ConfirmLinkCommand{}, errors.New("password is required")
+	}
+
+	return ConfirmLinkCommand{
+		LinkToken: linkToken,
+		Password:  password,
+		Client:    client,
+	}, nil
+}
+
+type ForgotPasswordCommand struct {
+	Email string
+}
+
+func NewForgotPasswordCommand(email string) (ForgotPasswordCommand, error) {
+	if email == "" {
+		return ForgotPasswordCommand{}, errors.New("email is required")
+	}
+
+	return ForgotPasswordCommand{
+		Email: email,
+	}, nil
+}
+
+type ResetPasswordCommand struct {
+	Token       string
+	NewPassword string
+}
+
+func NewResetPasswordCommand(token, newPassword string) (ResetPasswordCommand, error) {
+	if token == "" {
+		return ResetPasswordCommand{}, errors.New("token is required")
+	}
+
+	if newPassword == "" {
+		return ResetPasswordCommand{}, errors.New("new password is required")
+	}
+
+	return ResetPasswordCommand{
+		Token:       token,
+		NewPassword: newPassword,
+	}, nil
+}
+
+type VerifyEmailCommand struct {
+	Token string
+}
+
+func NewVerifyEmailCommand(token string) (VerifyEmailCommand, error) {
+	if token == "" {
+		return VerifyEmailCommand{}, errors.New("token is required")
+	}
+
+	return VerifyEmailCommand{
+		Token: token,
+	}, nil
+}
+
+type ResendVerificationEmailCommand struct {
+	Email string
+}
+
+func NewResendVerificationEmailCommand(email string) (ResendVerificationEmailCommand, error) {
+	if email == "" {
+		return ResendVerificationEmailCommand{}, errors.New("email is required")
+	}
+
+	return ResendVerificationEmailCommand{
+		Email: email,
 	}, nil
 }
@@ -0,0 +1,10 @@
+package commands
+
+import "time"
+
+// DataExportDownloadLink authorises downloading one export archive, without other credentials, until it expires
+type DataExportDownloadLink struct {
+	ExportID  string
+	ExpiresAt time.Time
+	Signature string
+}
@@ -0,0 +1,41 @@
+package commands
+
+import (
+	"time"
+
+	"github.com/atdevten/peace/internal/pkg/pagination"
+)
+
+type GetPublicFeedCommand struct {
+	Limit  int
+	Cursor *string // opaque next_cursor from a previous page
+}
+
+func NewGetPublicFeedCommand(limit *int, cursor *string) (*GetPublicFeedCommand, error) {
+	pageSize, err := pagination.NormalizeLimit(limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GetPublicFeedCommand{
+		Limit:  pageSize,
+		Cursor: cursor,
+	}, nil
+}
+
+// FeedEntry is a public record as shown to other users; it never carries the author's ID
+type FeedEntry struct {
+	ID          string
+	HappyLevel  int
+	EnergyLevel int
+	Notes       *string
+	Tags        []string
+	CreatedAt   time.Time
+	Username    string
+	AvatarURL   *string
+}
+
+type PublicFeedPage struct {
+	Entries    []FeedEntry
+	NextCursor *string // nil on the last page
+}
@@ -2,6 +2,10 @@ package commands
 
 import (
 	"errors"
+	"strings"
+
+	"github.com/atdevten/peace/internal/domain/entities"
+	"github.com/atdevten/peace/internal/pkg/pagination"
 )
 
 type CreateMentalHealthRecordCommand struct {
@@ -10,9 +14,10 @@ type CreateMentalHealthRecordCommand struct {
 	EnergyLevel int
 	Notes       *string
 	Status      string
+	TagNames    []string
 }
 
-func NewCreateMentalHealthRecordCommand(userID string, happyLevel int, energyLevel int, notes *string, status string) (CreateMentalHealthRecordCommand, error) {
+func NewCreateMentalHealthRecordCommand(userID string, happyLevel int, energyLevel int, notes *string, status string, tagNames []string) (CreateMentalHealthRecordCommand, error) {
 	if userID == "" {
 		return CreateMentalHealthRecordCommand{}, errors.New("user_id is required")
 	}
@@ -23,6 +28,7 @@ func NewCreateMentalHealthRecordCommand(userID string, happyLevel int, energyLev
 		EnergyLevel: energyLevel,
 		Notes:       notes,
 		Status:      status,
+		TagNames:    tagNames,
 	}, nil
 }
 
@@ -33,9 +39,10 @@ type UpdateMentalHealthRecordCommand struct {
 	EnergyLevel int
 	Notes       *string
 	Status      string
+	TagNames    []string // nil keeps the current tags, empty clears them
 }
 
-func NewUpdateMentalHealthRecordCommand(id string, userID string, happyLevel int, energyLevel int, notes *string, status string) (UpdateMentalHealthRecordCommand, error) {
+func NewUpdateMentalHealthRecordCommand(id string, userID string, happyLevel int, energyLevel int, notes *string, status string, tagNames []string) (UpdateMentalHealthRecordCommand, error) {
 	if id == "" {
 		return UpdateMentalHealthRecordCommand{}, errors.New("id is required")
 	}
@@ -51,6 +58,7 @@ func NewUpdateMentalHealthRecordCommand(id string, userID string, happyLevel int
 		EnergyLevel: energyLevel,
 		Notes:       notes,
 		Status:      status,
+		TagNames:    tagNames,
 	}, nil
 }
 
@@ -74,17 +82,94 @@ func NewDeleteMentalHealthRecordCommand(id string, userID string) (DeleteMentalH
 	}, nil
 }
 
+// MentalHealthRecordListFilters narrows the records listing; nil fields are ignored
+type MentalHealthRecordListFilters struct {
+	MinHappyLevel  *int
+	MaxHappyLevel  *int
+	MinEnergyLevel *int
+	MaxEnergyLevel *int
+	Status         *string
+	Search         *string
+	TagNames       []string
+}
+
+type GetMentalHealthRecordsCommand struct {
+	UserID    string
+	StartedAt *string
+	EndedAt   *string
+	Limit     int
+	Cursor    *string // opaque next_cursor from a previous page
+	OrderDesc bool
+	Filters   MentalHealthRecordListFilters
+}
+
+func NewGetMentalHealthRecordsCommand(userID string, startedAt *string, endedAt *string, limit *int, cursor *string, sort string, filters MentalHealthRecordListFilters) (*GetMentalHealthRecordsCommand, error) {
+	if userID == "" {
+		return nil, errors.New("user_id is required")
+	}
+
+	pageSize, err := pagination.NormalizeLimit(limit)
+	if err != nil {
+		return nil, err
+	}
+
+	// Newest first unless the client asks for ascending order
+	var orderDesc bool
+	switch sort {
+	case "", "desc":
+		orderDesc = true
+	case "asc":
+		orderDesc = false
+	default:
+		return nil, errors.New("sort must be asc or desc")
+	}
+
+	if filters.MinHappyLevel != nil && filters.MaxHappyLevel != nil && *filters.MinHappyLevel > *filters.MaxHappyLevel {
+		return nil, errors.New("min_happy must not exceed max_happy")
+	}
+
+	if filters.MinEnergyLevel != nil && filters.MaxEnergyLevel != nil && *filters.MinEnergyLevel > *filters.MaxEnergyLevel {
+		return nil, errors.New("min_energy must not exceed max_energy")
+	}
+
+	if filters.Search != nil {
+		search := strings.TrimSpace(*filters.Search)
+		if search == "" {
+			filters.Search = nil
+		} else {
+			filters.Search = &search
+		}
+	}
+
+	return &GetMentalHealthRecordsCommand{
+		UserID:    userID,
+		StartedAt: startedAt,
+		EndedAt:   endedAt,
+		Limit:     pageSize,
+		Cursor:    cursor,
+		OrderDesc: orderDesc,
+		Filters:   filters,
+	}, nil
+}
+
+type MentalHealthRecordsPage struct {
+	Records    []*entities.MentalHealthRecord
+	NextCursor *string // nil on the last page
+}
+
 type GetMentalHealthHeatmapCommand struct {
 	UserID    string
 	StartedAt *string
 	EndedAt   *string
+	Timezone  *string // optional IANA override; defaults to the user's timezone
 }
 
-func NewGetMentalHealthHeatmapCommand(userID string, startedAt *string, endedAt *string) *GetMentalHealthHeatmapCommand {
+func NewGetMentalHealthHeatmapCommand(userID string, startedAt *string, endedAt *string, timezone *string) *GetMentalHealthHeatmapCommand {
 	return &GetMentalHealthHeatmapCommand{
 		UserID:    userID,
 		StartedAt: startedAt,
 		EndedAt:   endedAt,
+		Timezone:  timezone,
 	}
 }
 
@@ -104,19 +189,102 @@ type MentalHealthHeatmapResult struct {
 	Data         map[string]HeatmapDataPoint
 	TotalRecords int
 	DateRange    DateRange
+	Timezone     string
 }
 
 type GetMentalHealthStreakCommand struct {
-	UserID string
+	UserID   string
+	Timezone *string // optional IANA override; defaults to the user's timezone
 }
 
-func NewGetMentalHealthStreakCommand(userID string) *GetMentalHealthStreakCommand {
+func NewGetMentalHealthStreakCommand(userID string, timezone *string) *GetMentalHealthStreakCommand {
 	return &GetMentalHealthStreakCommand{
-		UserID: userID,
+		UserID:   userID,
+		Timezone: timezone,
 	}
 }
 
 type MentalHealthStreakResult struct {
 	Streak        int     `json:"streak"`
 	LastEntryDate *string `json:"last_entry_date,omitempty"`
+	Timezone      string  `json:"timezone"`
+}
+
+type GetMentalHealthAnalyticsCommand struct {
+	UserID    string
+	StartedAt *string // defaults to 30 days before EndedAt
+	EndedAt   *string // defaults to now
+	Timezone  *string // optional IANA override; defaults to the user's timezone
+}
+
+func NewGetMentalHealthAnalyticsCommand(userID string, startedAt *string, endedAt *string, timezone *string) *GetMentalHealthAnalyticsCommand {
+	return &GetMentalHealthAnalyticsCommand{
+		UserID:    userID,
+		StartedAt: startedAt,
+		EndedAt:   endedAt,
+		Timezone:  timezone,
+	}
+}
+
+type LevelStats struct {
+	Average    float64
+	Min        int
+	Max        int
+	Stddev     float64
+	TrendSlope float64 // change in level per day over the range
+}
+
+type AnalyticsDailyPoint struct {
+	Date            string
+	Count           int
+	HappyAverage    float64
+	EnergyAverage   float64
+	HappyRolling7   float64
+	EnergyRolling7  float64
+	HappyRolling30  float64
+	EnergyRolling30 float64
+}
+
+type AnalyticsBucketPoint struct {
+	Bucket        int
+	Count         int
+	HappyAverage  float64
+	EnergyAverage float64
+}
+
+type MentalHealthAnalyticsResult struct {
+	TotalRecords int
+	Happy        LevelStats
+	Energy       LevelStats
+	Daily        []AnalyticsDailyPoint
+	Weekdays     []AnalyticsBucketPoint
+	Hours        []AnalyticsBucketPoint
+	DateRange    DateRange
+	Timezone     string
+}
+
+type GetMentalHealthTagReportCommand struct {
+	UserID    string
+	StartedAt *string
+	EndedAt   *string
+}
+
+func NewGetMentalHealthTagReportCommand(userID string, startedAt *string, endedAt *string) *GetMentalHealthTagReportCommand {
+	return &GetMentalHealthTagReportCommand{
+		UserID:    userID,
+		StartedAt: startedAt,
+		EndedAt:   endedAt,
+	}
+}
+
+type TagMoodPoint struct {
+	TagName       string
+	Count         int
+	HappyAverage  float64
+	EnergyAverage float64
+}
+
+type MentalHealthTagReportResult struct {
+	Tags      []TagMoodPoint
+	DateRange DateRange
 }
@@ -0,0 +1,13 @@
+package commands
+
+// TOTPEnrollment is what an authenticator app needs to register a pending factor
+type TOTPEnrollment struct {
+	Secret string // base32 secret for manual entry
+	URI    string // otpauth:// URI, usually rendered as a QR code
+}
+
+// MFAStatus summarises a user's two-factor setup
+type MFAStatus struct {
+	Enabled                bool
+	RemainingRecoveryCodes int
+}
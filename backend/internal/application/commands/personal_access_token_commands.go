@@ -0,0 +1,50 @@
+package commands
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	// defaultPersonalAccessTokenDays applies when no expiry is asked for
+	defaultPersonalAccessTokenDays = 90
+	// maxPersonalAccessTokenDays bounds how long a token can live
+	maxPersonalAccessTokenDays = 365
+)
+
+type CreatePersonalAccessTokenCommand struct {
+	UserID    string
+	Name      string
+	Scopes    []string
+	ExpiresIn time.Duration
+}
+
+// NewCreatePersonalAccessTokenCommand builds the command; expiresInDays defaults to 90 and is at most 365
+func NewCreatePersonalAccessTokenCommand(userID string, name string, scopes []string, expiresInDays *int) (CreatePersonalAccessTokenCommand, error) {
+	if userID == "" {
+		return CreatePersonalAccessTokenCommand{}, errors.New("user ID is required")
+	}
+
+	if name == "" {
+		return CreatePersonalAccessTokenCommand{}, errors.New("name is required")
+	}
+
+	if len(scopes) == 0 {
+		return CreatePersonalAccessTokenCommand{}, errors.New("at least one scope is required")
+	}
+
+	days := defaultPersonalAccessTokenDays
+	if expiresInDays != nil {
+		days = *expiresInDays
+	}
+	if days < 1 || days > maxPersonalAccessTokenDays {
+		return CreatePersonalAccessTokenCommand{}, errors.New("expires_in_days must be between 1 and 365")
+	}
+
+	return CreatePersonalAccessTokenCommand{
+		UserID:    userID,
+		Name:      name,
+		Scopes:    scopes,
+		ExpiresIn: time.Duration(days) * 24 * time.Hour,
+	}, nil
+}
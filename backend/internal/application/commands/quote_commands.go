@@ -2,6 +2,12 @@ package commands
 
 import (
 	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/atdevten/peace/internal/domain/entities"
+	"github.com/atdevten/peace/internal/pkg/pagination"
 )
 
 type CreateQuoteCommand struct {
@@ -63,3 +69,153 @@ func NewDeleteQuoteCommand(id string) (DeleteQuoteCommand, error) {
 		ID: id,
 	}, nil
 }
+
+// QuoteListOptions narrows and orders the quote listing; nil fields use the defaults
+type QuoteListOptions struct {
+	Author  *string // case-insensitive substring
+	Content *string // case-insensitive substring
+	Sort    *string // created_at (default), author or random
+	Order   *string // asc or desc; created_at defaults to desc, the others to asc
+	Seed    *int64  // order of sort=random; a new one is picked when missing
+}
+
+type ListQuotesCommand struct {
+	Options QuoteListOptions
+	Limit   int
+	Offset  int
+	Cursor  *string // opaque next_cursor from a previous page of the same sort, order and seed
+}
+
+func NewListQuotesCommand(options QuoteListOptions, limit *int, offset *int, cursor *string) (*ListQuotesCommand, error) {
+	pageSize, err := pagination.NormalizeLimit(limit)
+	if err != nil {
+		return nil, err
+	}
+
+	// Blank options are ignored rather than matching nothing
+	for _, field := range []**string{&options.Author, &options.Content, &options.Sort, &options.Order} {
+		if *field == nil {
+			continue
+		}
+		value := strings.TrimSpace(**field)
+		if value == "" {
+			*field = nil
+		} else {
+			*field = &value
+		}
+	}
+
+	if options.Order != nil && *options.Order != "asc" && *options.Order != "desc" {
+		return nil, errors.New("order must be asc or desc")
+	}
+
+	if options.Seed != nil && *options.Seed < 0 {
+		return nil, errors.New("seed cannot be negative")
+	}
+
+	command := &ListQuotesCommand{
+		Options: options,
+		Limit:   pageSize,
+		Cursor:  cursor,
+	}
+
+	if offset != nil {
+		if *offset < 0 {
+			return nil, errors.New("offset cannot be negative")
+		}
+		if cursor != nil {
+			return nil, errors.New("offset cannot be combined with cursor")
+		}
+		command.Offset = *offset
+	}
+
+	return command, nil
+}
+
+type QuotesPage struct {
+	Quotes     []*entities.Quote
+	Total      int64   // matching quotes across all pages
+	NextCursor *string // nil on the last page
+	Seed       *int64  // order of sort=random, to request the following pages with
+}
+
+// MaxSearchTextLength bounds the text of a quote search
+const MaxSearchTextLength = 200
+
+type SearchQuotesCommand struct {
+	Text   string
+	Author *string // exact author facet
+	Tag    *string // tag name facet
+	Limit  int
+	Offset int
+}
+
+func NewSearchQuotesCommand(text string, author *string, tag *string, limit *int, offset *int) (*SearchQuotesCommand, error) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return nil, errors.New("q is required")
+	}
+	if len(text) > MaxSearchTextLength {
+		return nil, fmt.Errorf("q cannot exceed %d characters", MaxSearchTextLength)
+	}
+
+	pageSize, err := pagination.NormalizeLimit(limit)
+	if err != nil {
+		return nil, err
+	}
+
+	// Blank facets are ignored rather than matching nothing
+	for _, field := range []**string{&author, &tag} {
+		if *field == nil {
+			continue
+		}
+		value := strings.TrimSpace(**field)
+		if value == "" {
+			*field = nil
+		} else {
+			*field = &value
+		}
+	}
+
+	command := &SearchQuotesCommand{
+		Text:   text,
+		Author: author,
+		Tag:    tag,
+		Limit:  pageSize,
+	}
+
+	if offset != nil {
+		if *offset < 0 {
+			return nil, errors.New("offset cannot be negative")
+		}
+		command.Offset = *offset
+	}
+
+	return command, nil
+}
+
+type QuoteSearchHit struct {
+	Quote   *entities.Quote
+	Rank    float64
+	Snippet string // HTML-escaped content passage with the matched terms in <mark>
+}
+
+// QuoteFacet counts the matching quotes sharing a value
+type QuoteFacet struct {
+	Value string
+	Count int64
+}
+
+type QuoteSearchPage struct {
+	Hits    []QuoteSearchHit
+	Total   int64 // matching quotes across all pages
+	Authors []QuoteFacet
+	Tags    []QuoteFacet
+}
+
+// DailyQuote is the quote of the day of a user, or of anonymous visitors
+type DailyQuote struct {
+	Quote    *entities.Quote
+	Day      time.Time // local date, as midnight UTC
+	Timezone string    // timezone the day runs in
+}
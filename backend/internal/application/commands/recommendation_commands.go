@@ -0,0 +1,85 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/atdevten/peace/internal/domain/entities"
+)
+
+const (
+	// defaultRecommendationLimit applies when no number of quotes is asked for
+	defaultRecommendationLimit = 5
+	// maxRecommendationLimit bounds the quotes recommended at once
+	maxRecommendationLimit = 20
+)
+
+type RecommendQuotesCommand struct {
+	UserID string
+	Limit  int
+}
+
+// NewRecommendQuotesCommand builds the command; limit defaults to 5 and is at most 20
+func NewRecommendQuotesCommand(userID string, limit *int) (*RecommendQuotesCommand, error) {
+	if userID == "" {
+		return nil, errors.New("user ID is required")
+	}
+
+	count := defaultRecommendationLimit
+	if limit != nil {
+		count = *limit
+	}
+	if count < 1 || count > maxRecommendationLimit {
+		return nil, fmt.Errorf("limit must be between 1 and %d", maxRecommendationLimit)
+	}
+
+	return &RecommendQuotesCommand{
+		UserID: userID,
+		Limit:  count,
+	}, nil
+}
+
+// Bases of a recommendation: what the tags of the recommended quotes came from
+const (
+	RecommendationBasisMood   = "mood"   // the latest mental health record
+	RecommendationBasisLikes  = "likes"  // the tags of recently liked quotes
+	RecommendationBasisRandom = "random" // nothing to go on, any quote
+)
+
+// QuoteRecommendations are the quotes picked for a user and what they were picked by
+type QuoteRecommendations struct {
+	Quotes []*entities.Quote
+	Basis  string
+	Tags   []string                     // tags the quotes were picked by, empty for random picks
+	Record *entities.MentalHealthRecord // record the mood was read from, nil without records
+}
+
+type QuoteFeedbackCommand struct {
+	UserID  string
+	QuoteID string
+	Signal  string
+}
+
+// NewQuoteFeedbackCommand builds the command; signal is like or skip
+func NewQuoteFeedbackCommand(userID string, quoteID string, signal string) (*QuoteFeedbackCommand, error) {
+	if userID == "" {
+		return nil, errors.New("user ID is required")
+	}
+
+	if _, err := strconv.Atoi(quoteID); err != nil {
+		return nil, errors.New("quote ID must be a number")
+	}
+
+	signal = strings.TrimSpace(signal)
+	if signal != "like" && signal != "skip" {
+		return nil, errors.New("signal must be like or skip")
+	}
+
+	return &QuoteFeedbackCommand{
+		UserID:  userID,
+		QuoteID: quoteID,
+		Signal:  signal,
+	}, nil
+}
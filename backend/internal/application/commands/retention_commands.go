@@ -0,0 +1,12 @@
+package commands
+
+// RetentionReport summarises one pass of the retention job over deleted accounts
+type RetentionReport struct {
+	Action   string
+	DryRun   bool  // nothing was changed; the counts are what would have been removed
+	Accounts int   // accounts purged or anonymized
+	Failed   int   // accounts left for the next pass after an error
+	Records  int64 // mental health records deleted, or stripped of their notes
+	Sessions int64
+	Exports  int64
+}
@@ -0,0 +1,27 @@
+package audit
+
+import (
+	"context"
+
+	"github.com/atdevten/peace/internal/domain/value_objects"
+)
+
+// Actor is who makes a request: the authenticated user, if any, and the client it comes from
+type Actor struct {
+	UserID    *value_objects.UserID // nil for anonymous callers
+	IPAddress string
+	UserAgent string
+}
+
+type actorKey struct{}
+
+// WithActor returns a copy of ctx carrying the actor of the request, for the audit log
+func WithActor(ctx context.Context, actor Actor) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+// ActorFromContext returns the actor stored by WithActor; without one the caller is anonymous and its client unknown
+func ActorFromContext(ctx context.Context) Actor {
+	actor, _ := ctx.Value(actorKey{}).(Actor)
+	return actor
+}
@@ -0,0 +1,15 @@
+package cache
+
+import (
+	"context"
+	"time"
+)
+
+// Cache keeps short-lived values shared by every API instance. It only saves work: callers
+// compute the value again on a miss or an error.
+type Cache interface {
+	// Get returns the value of key, with found false when it is missing or expired
+	Get(ctx context.Context, key string) (value string, found bool, err error)
+	// Set stores value under key for ttl
+	Set(ctx context.Context, key string, value string, ttl time.Duration) error
+}
@@ -0,0 +1,20 @@
+package filestore
+
+import (
+	"context"
+	"errors"
+	"io"
+)
+
+// ErrNotFound is returned when no file is stored under a key
+var ErrNotFound = errors.New("file not found")
+
+// Store keeps generated files, such as data export archives, under flat keys
+type Store interface {
+	// Create returns a writer for the file under key. The file becomes visible once the writer
+	// is closed without error, replacing any previous file under the same key.
+	Create(ctx context.Context, key string) (io.WriteCloser, error)
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+	// Delete removes the file under key; deleting a missing file is not an error
+	Delete(ctx context.Context, key string) error
+}
@@ -0,0 +1,19 @@
+package jwt
+
+// JSONWebKey is the public half of a verification key as published in a JWKS (RFC 7517)
+type JSONWebKey struct {
+	KeyType   string `json:"kty"`
+	KeyID     string `json:"kid"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+	N         string `json:"n,omitempty"`   // RSA modulus
+	E         string `json:"e,omitempty"`   // RSA exponent
+	Curve     string `json:"crv,omitempty"` // OKP curve
+	X         string `json:"x,omitempty"`   // OKP public key
+}
+
+// KeyPublisher exposes the keys other services need to verify our tokens.
+// Shared secrets are never published.
+type KeyPublisher interface {
+	PublicKeys() []JSONWebKey
+}
@@ -6,16 +6,35 @@ import (
 
 // Service defines a technology-agnostic token service for auth
 type Service interface {
-	GenerateAccessToken(userID value_objects.UserID, email value_objects.Email) (string, error)
-	GenerateRefreshToken(userID value_objects.UserID, email value_objects.Email) (string, error)
+	// GenerateAccessToken embeds sessionID as the sid claim so revoked sessions can be rejected,
+	// and the user's role so authorization checks need no database lookup
+	GenerateAccessToken(userID value_objects.UserID, email value_objects.Email, role value_objects.Role, sessionID value_objects.TokenID) (string, error)
+	// GenerateRefreshToken embeds tokenID as the jti so the token can be tracked server-side
+	GenerateRefreshToken(userID value_objects.UserID, email value_objects.Email, tokenID value_objects.TokenID) (string, error)
+	// GenerateMFAToken issues a short-lived challenge proving the first step of a two-factor login through provider
+	GenerateMFAToken(userID value_objects.UserID, email value_objects.Email, provider string) (string, error)
+	// GenerateLinkToken issues a short-lived proof that the holder signed in to externalID at provider,
+	// to be exchanged together with the password of the account it should be linked to
+	GenerateLinkToken(userID value_objects.UserID, email value_objects.Email, provider string, externalID string) (string, error)
+	// GenerateRestoreToken issues a short-lived token for a deleted account logging in through provider
+	// during its grace period; it is only accepted to restore the account
+	GenerateRestoreToken(userID value_objects.UserID, email value_objects.Email, provider string) (string, error)
 	ValidateAccessToken(tokenString string) (*Claims, error)
 	ValidateRefreshToken(tokenString string) (*Claims, error)
+	ValidateMFAToken(tokenString string) (*Claims, error)
+	ValidateLinkToken(tokenString string) (*Claims, error)
+	ValidateRestoreToken(tokenString string) (*Claims, error)
 }
 
 // Claims are normalized token claims used across the application
 type Claims struct {
-	UserID string
-	Email  string
-	Type   string
+	UserID     string
+	Email      string
+	Type       string
+	TokenID    string // jti, set on refresh and MFA tokens
+	SessionID  string // sid, set on access tokens
+	Role       string // set on access tokens
+	Provider   string // login provider, set on MFA, link and restore tokens
+	ExternalID string // provider account ID, set on link tokens
 	// Note: expiration and issued-at are validated inside the service implementation
 }
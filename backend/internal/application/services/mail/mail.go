@@ -0,0 +1,15 @@
+package mail
+
+import "context"
+
+// Sender defines a transport-agnostic way to deliver emails
+type Sender interface {
+	Send(ctx context.Context, message Message) error
+}
+
+// Message is a plain-text email
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
@@ -0,0 +1,47 @@
+package oauth
+
+import (
+	"context"
+	"errors"
+)
+
+var (
+	// ErrUnknownProvider is returned for provider names that are not configured
+	ErrUnknownProvider = errors.New("unknown login provider")
+	// ErrInvalidState is returned when the state parameter is forged, expired or issued for another provider
+	ErrInvalidState = errors.New("invalid or expired login state")
+)
+
+// Service defines a provider-agnostic authorization code flow with external identity providers
+type Service interface {
+	// Providers lists the configured provider names
+	Providers() []string
+	// AuthorizationURL starts a login; the caller must keep its PendingLogin and hand it back with the code
+	AuthorizationURL(ctx context.Context, provider string) (*Authorization, error)
+	// Exchange checks that state is the one of the pending login, redeems code and returns the account it was issued for
+	Exchange(ctx context.Context, provider string, code string, state string, pending PendingLogin) (*UserInfo, error)
+}
+
+// Authorization is the provider page the user is redirected to
+type Authorization struct {
+	URL string
+	PendingLogin
+}
+
+// PendingLogin is what a started login leaves with the browser that started it, out of reach of
+// scripts, until the provider redirects back
+type PendingLogin struct {
+	State    string
+	Verifier string // PKCE code verifier
+}
+
+// UserInfo is the normalized account information reported by a provider
+type UserInfo struct {
+	Provider      string
+	Subject       string // stable account ID at the provider
+	Email         string
+	EmailVerified bool
+	FirstName     string
+	LastName      string
+	Picture       string
+}
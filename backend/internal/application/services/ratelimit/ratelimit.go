@@ -0,0 +1,19 @@
+package ratelimit
+
+import (
+	"context"
+	"time"
+)
+
+// Store keeps the short-lived counters and locks used to throttle callers, shared by every API instance
+type Store interface {
+	// Hit counts one hit of key in a window that opens with its first hit, and returns the hits so far
+	// and the time left until the window closes
+	Hit(ctx context.Context, key string, window time.Duration) (int64, time.Duration, error)
+	// Lock blocks key for ttl, replacing any lock it already has
+	Lock(ctx context.Context, key string, ttl time.Duration) error
+	// LockedFor returns the time left on the lock of key, zero when it is not locked
+	LockedFor(ctx context.Context, key string) (time.Duration, error)
+	// Reset forgets the counters and locks of keys
+	Reset(ctx context.Context, keys ...string) error
+}
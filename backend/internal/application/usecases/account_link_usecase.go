@@ -0,0 +1,163 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/atdevten/peace/internal/application/services/oauth"
+	"github.com/atdevten/peace/internal/domain/entities"
+	"github.com/atdevten/peace/internal/domain/repositories"
+	"github.com/atdevten/peace/internal/domain/value_objects"
+)
+
+var (
+	// ErrProviderAlreadyLinked is returned when linking a provider the user already has an account of connected
+	ErrProviderAlreadyLinked = errors.New("an account of this provider is already linked")
+	// ErrProviderNotLinked is returned when unlinking a provider without a connected account
+	ErrProviderNotLinked = errors.New("no account of this provider is linked")
+	// ErrLastLoginMethod is returned when unlinking would leave the account without a way to log in
+	ErrLastLoginMethod = errors.New("set a password or link another provider first, this is your only login method")
+)
+
+// AccountLinkUseCase connects and disconnects external login providers of a logged-in user
+type AccountLinkUseCase interface {
+	ListIdentities(ctx context.Context, userID string) ([]*entities.UserIdentity, error)
+	// Link connects the provider account an authorization code was issued for; its email may differ from the user's
+	Link(ctx context.Context, userID string, provider string, code string, state string, pending oauth.PendingLogin) (*entities.UserIdentity, error)
+	Unlink(ctx context.Context, userID string, provider string) error
+}
+
+type AccountLinkUseCaseImpl struct {
+	userRepo     repositories.UserRepository
+	identityRepo repositories.UserIdentityRepository
+	oauthService oauth.Service
+	auditTrail   auditTrail
+}
+
+func NewAccountLinkUseCase(
+	userRepo repositories.UserRepository,
+	identityRepo repositories.UserIdentityRepository,
+	oauthService oauth.Service,
+	auditRepo repositories.AuditEventRepository,
+) AccountLinkUseCase {
+	return &AccountLinkUseCaseImpl{
+		userRepo:     userRepo,
+		identityRepo: identityRepo,
+		oauthService: oauthService,
+		auditTrail:   auditTrail{repo: auditRepo},
+	}
+}
+
+func (uc *AccountLinkUseCaseImpl) ListIdentities(ctx context.Context, userID string) ([]*entities.UserIdentity, error) {
+	userIDVO, err := value_objects.NewUserIDFromString(userID)
+	if err != nil {
+		return nil, fmt.Errorf("value_objects.NewUserIDFromString: %w", err)
+	}
+
+	identities, err := uc.identityRepo.ListByUserID(ctx, userIDVO)
+	if err != nil {
+		return nil, fmt.Errorf("uc.identityRepo.ListByUserID: %w", err)
+	}
+
+	return identities, nil
+}
+
+func (uc *AccountLinkUseCaseImpl) Link(ctx context.Context, userID string, provider string, code string, state string, pending oauth.PendingLogin) (*entities.UserIdentity, error) {
+	user, err := uc.getUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	identities, err := uc.identityRepo.ListByUserID(ctx, user.ID())
+	if err != nil {
+		return nil, fmt.Errorf("uc.identityRepo.ListByUserID: %w", err)
+	}
+	for _, identity := range identities {
+		if identity.Provider() == provider {
+			return nil, ErrProviderAlreadyLinked
+		}
+	}
+
+	info, err := uc.oauthService.Exchange(ctx, provider, code, state, pending)
+	if err != nil {
+		return nil, fmt.Errorf("uc.oauthService.Exchange: %w", err)
+	}
+
+	identity, err := entities.NewUserIdentity(user.ID(), info.Provider, info.Subject, info.Email)
+	if err != nil {
+		return nil, fmt.Errorf("entities.NewUserIdentity: %w", err)
+	}
+
+	if err := uc.identityRepo.Create(ctx, identity); err != nil {
+		if errors.Is(err, repositories.ErrUserIdentityExists) {
+			return nil, ErrExternalAccountInUse
+		}
+		return nil, fmt.Errorf("uc.identityRepo.Create: %w", err)
+	}
+
+	uc.recordIdentityChange(ctx, value_objects.AuditActionIdentityLinked, user.ID(), info.Provider)
+
+	return identity, nil
+}
+
+func (uc *AccountLinkUseCaseImpl) Unlink(ctx context.Context, userID string, provider string) error {
+	user, err := uc.getUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	identities, err := uc.identityRepo.ListByUserID(ctx, user.ID())
+	if err != nil {
+		return fmt.Errorf("uc.identityRepo.ListByUserID: %w", err)
+	}
+
+	linked := false
+	for _, identity := range identities {
+		if identity.Provider() == provider {
+			linked = true
+		}
+	}
+	if !linked {
+		return ErrProviderNotLinked
+	}
+	if !user.HasPassword() && len(identities) == 1 {
+		return ErrLastLoginMethod
+	}
+
+	if err := uc.identityRepo.Delete(ctx, user.ID(), provider); err != nil {
+		if errors.Is(err, repositories.ErrUserIdentityNotFound) {
+			return ErrProviderNotLinked
+		}
+		return fmt.Errorf("uc.identityRepo.Delete: %w", err)
+	}
+
+	uc.recordIdentityChange(ctx, value_objects.AuditActionIdentityUnlinked, user.ID(), provider)
+
+	return nil
+}
+
+// recordIdentityChange audits a provider account being connected to or disconnected from the user
+func (uc *AccountLinkUseCaseImpl) recordIdentityChange(ctx context.Context, action value_objects.AuditAction, userID *value_objects.UserID, provider string) {
+	uc.auditTrail.record(ctx, auditEntry{
+		action:     action,
+		actorID:    userID,
+		targetType: entities.AuditTargetUser,
+		targetID:   userID.String(),
+		metadata:   map[string]interface{}{"provider": provider},
+	})
+}
+
+func (uc *AccountLinkUseCaseImpl) getUser(ctx context.Context, userID string) (*entities.User, error) {
+	userIDVO, err := value_objects.NewUserIDFromString(userID)
+	if err != nil {
+		return nil, fmt.Errorf("value_objects.NewUserIDFromString: %w", err)
+	}
+
+	user, err := uc.userRepo.GetByID(ctx, userIDVO)
+	if err != nil {
+		return nil, fmt.Errorf("uc.userRepo.GetByID: %w", err)
+	}
+
+	return user, nil
+}
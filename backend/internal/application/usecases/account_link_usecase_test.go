@@ -0,0 +1,192 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+
+	"github.com/atdevten/peace/internal/application/services/oauth"
+	"github.com/atdevten/peace/internal/domain/entities"
+	domainrepositories "github.com/atdevten/peace/internal/domain/repositories"
+	"github.com/atdevten/peace/internal/domain/value_objects"
+	"github.com/atdevten/peace/testutils/helpers"
+	repositories "github.com/atdevten/peace/testutils/mocks/repositories"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+const accountLinkTestUserID = "550e8400-e29b-41d4-a716-446655440000"
+
+// identitiesOf returns one linked identity of user per provider
+func identitiesOf(t *testing.T, user *entities.User, providers ...string) []*entities.UserIdentity {
+	identities := make([]*entities.UserIdentity, 0, len(providers))
+	for _, provider := range providers {
+		identity, err := entities.NewUserIdentity(user.ID(), provider, provider+"-subject", user.Email().String())
+		require.NoError(t, err)
+		identities = append(identities, identity)
+	}
+	return identities
+}
+
+func TestAccountLinkUseCaseImpl_Link(t *testing.T) {
+	tests := []struct {
+		name        string
+		linked      []string
+		exchangeErr error
+		createErr   error
+		wantErr     bool
+		expectedErr string
+	}{
+		{
+			name: "link a provider to a local account",
+		},
+		{
+			name:   "link a second provider",
+			linked: []string{"github"},
+		},
+		{
+			name:        "provider already linked",
+			linked:      []string{"google"},
+			wantErr:     true,
+			expectedErr: ErrProviderAlreadyLinked.Error(),
+		},
+		{
+			name:        "provider account linked to another user",
+			createErr:   domainrepositories.ErrUserIdentityExists,
+			wantErr:     true,
+			expectedErr: ErrExternalAccountInUse.Error(),
+		},
+		{
+			name:        "invalid state",
+			exchangeErr: oauth.ErrInvalidState,
+			wantErr:     true,
+			expectedErr: oauth.ErrInvalidState.Error(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			user := helpers.CreateTestUser()
+
+			mockRepo := repositories.NewMockUserRepository(ctrl)
+			mockRepo.EXPECT().GetByID(gomock.Any(), gomock.Any()).Return(user, nil)
+
+			mockIdentityRepo := repositories.NewMockUserIdentityRepository(ctrl)
+			mockIdentityRepo.EXPECT().ListByUserID(gomock.Any(), user.ID()).Return(identitiesOf(t, user, tt.linked...), nil)
+
+			mockOAuth := &MockOAuthService{ctrl: ctrl}
+			if tt.exchangeErr != nil {
+				mockOAuth.ExchangeFunc = func(ctx context.Context, provider string, code string, state string) (*oauth.UserInfo, error) {
+					return nil, tt.exchangeErr
+				}
+			}
+
+			if tt.exchangeErr == nil && tt.expectedErr != ErrProviderAlreadyLinked.Error() {
+				mockIdentityRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(tt.createErr)
+			}
+
+			var recorded *entities.AuditEvent
+			mockAuditRepo := repositories.NewMockAuditEventRepository(ctrl)
+			if !tt.wantErr {
+				mockAuditRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, event *entities.AuditEvent) error {
+						recorded = event
+						return nil
+					})
+			}
+
+			useCase := NewAccountLinkUseCase(mockRepo, mockIdentityRepo, mockOAuth, mockAuditRepo)
+			identity, err := useCase.Link(context.Background(), accountLinkTestUserID, "google", "code", "state", oauth.PendingLogin{State: "state", Verifier: "verifier"})
+
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedErr)
+				assert.Nil(t, identity)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, "google", identity.Provider())
+				assert.Equal(t, "google123", identity.Subject())
+				assert.Equal(t, user.ID().String(), identity.UserID().String())
+
+				require.NotNil(t, recorded)
+				assert.Equal(t, value_objects.AuditActionIdentityLinked, recorded.Action())
+				assert.Equal(t, "google", recorded.Metadata()["provider"])
+			}
+		})
+	}
+}
+
+func TestAccountLinkUseCaseImpl_Unlink(t *testing.T) {
+	tests := []struct {
+		name        string
+		user        *entities.User
+		linked      []string
+		wantErr     bool
+		expectedErr string
+	}{
+		{
+			name:   "unlink from an account with a password",
+			user:   helpers.CreateTestUser(),
+			linked: []string{"google"},
+		},
+		{
+			name:   "unlink while another provider remains",
+			user:   helpers.CreateTestGoogleUser(),
+			linked: []string{"github", "google"},
+		},
+		{
+			name:        "the provider is the only login method",
+			user:        helpers.CreateTestGoogleUser(),
+			linked:      []string{"google"},
+			wantErr:     true,
+			expectedErr: ErrLastLoginMethod.Error(),
+		},
+		{
+			name:        "nothing linked",
+			user:        helpers.CreateTestUser(),
+			linked:      []string{"github"},
+			wantErr:     true,
+			expectedErr: ErrProviderNotLinked.Error(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := repositories.NewMockUserRepository(ctrl)
+			mockRepo.EXPECT().GetByID(gomock.Any(), gomock.Any()).Return(tt.user, nil)
+
+			mockIdentityRepo := repositories.NewMockUserIdentityRepository(ctrl)
+			mockIdentityRepo.EXPECT().ListByUserID(gomock.Any(), tt.user.ID()).Return(identitiesOf(t, tt.user, tt.linked...), nil)
+
+			var recorded *entities.AuditEvent
+			mockAuditRepo := repositories.NewMockAuditEventRepository(ctrl)
+			if !tt.wantErr {
+				mockIdentityRepo.EXPECT().Delete(gomock.Any(), tt.user.ID(), "google").Return(nil)
+				mockAuditRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, event *entities.AuditEvent) error {
+						recorded = event
+						return nil
+					})
+			}
+
+			useCase := NewAccountLinkUseCase(mockRepo, mockIdentityRepo, &MockOAuthService{ctrl: ctrl}, mockAuditRepo)
+			err := useCase.Unlink(context.Background(), accountLinkTestUserID, "google")
+
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedErr)
+			} else {
+				require.NoError(t, err)
+				require.NotNil(t, recorded)
+				assert.Equal(t, value_objects.AuditActionIdentityUnlinked, recorded.Action())
+				assert.Equal(t, tt.user.ID().String(), recorded.TargetID())
+			}
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package usecases
+
+import (
+	"context"
+	"log"
+
+	"github.com/atdevten/peace/internal/application/commands"
+	"github.com/atdevten/peace/internal/application/services/audit"
+	"github.com/atdevten/peace/internal/domain/entities"
+	"github.com/atdevten/peace/internal/domain/repositories"
+	"github.com/atdevten/peace/internal/domain/value_objects"
+)
+
+// auditEntry describes one event for the audit trail
+type auditEntry struct {
+	action     value_objects.AuditAction
+	actorID    *value_objects.UserID // defaults to the authenticated user of the request
+	targetType string
+	targetID   string
+	client     *commands.ClientInfo // defaults to the client of the request
+	metadata   map[string]interface{}
+}
+
+// auditTrail appends events to the audit log. Recording is best effort: a failure is logged and
+// never fails the operation being audited.
+type auditTrail struct {
+	repo repositories.AuditEventRepository // nil records nothing
+}
+
+func (t auditTrail) record(ctx context.Context, entry auditEntry) {
+	if t.repo == nil {
+		return
+	}
+
+	actor := audit.ActorFromContext(ctx)
+	if entry.actorID != nil {
+		actor.UserID = entry.actorID
+	}
+	if entry.client != nil {
+		actor.IPAddress = entry.client.IPAddress
+		actor.UserAgent = entry.client.UserAgent
+	}
+
+	event, err := entities.NewAuditEvent(actor.UserID, entry.action, entry.targetType, entry.targetID, actor.IPAddress, actor.UserAgent, entry.metadata)
+	if err != nil {
+		log.Printf("Failed to build audit event %s: %v", entry.action, err)
+		return
+	}
+
+	if err := t.repo.Create(ctx, event); err != nil {
+		log.Printf("Failed to record audit event %s: %v", entry.action, err)
+	}
+}
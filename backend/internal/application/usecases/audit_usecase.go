@@ -0,0 +1,95 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/atdevten/peace/internal/application/commands"
+	"github.com/atdevten/peace/internal/domain/repositories"
+	"github.com/atdevten/peace/internal/domain/value_objects"
+	"github.com/atdevten/peace/internal/pkg/pagination"
+	"github.com/atdevten/peace/internal/pkg/timeutil"
+)
+
+type AuditUseCase interface {
+	// ListEvents pages through the audit log, newest first
+	ListEvents(ctx context.Context, command *commands.ListAuditEventsCommand) (*commands.AuditEventsPage, error)
+}
+
+type AuditUseCaseImpl struct {
+	auditRepo repositories.AuditEventRepository
+}
+
+func NewAuditUseCase(auditRepo repositories.AuditEventRepository) AuditUseCase {
+	return &AuditUseCaseImpl{
+		auditRepo: auditRepo,
+	}
+}
+
+func (uc *AuditUseCaseImpl) ListEvents(ctx context.Context, command *commands.ListAuditEventsCommand) (*commands.AuditEventsPage, error) {
+	// Fetch one extra event to know whether another page exists
+	filter := &repositories.AuditEventFilter{
+		TargetType: command.Filters.TargetType,
+		TargetID:   command.Filters.TargetID,
+		Limit:      command.Limit + 1,
+	}
+
+	var err error
+	if command.Filters.ActorID != nil {
+		filter.ActorID, err = value_objects.NewUserIDFromString(*command.Filters.ActorID)
+		if err != nil {
+			return nil, fmt.Errorf("value_objects.NewUserIDFromString: %w", err)
+		}
+	}
+	if command.Filters.Action != nil {
+		filter.Action, err = value_objects.NewAuditAction(*command.Filters.Action)
+		if err != nil {
+			return nil, fmt.Errorf("value_objects.NewAuditAction: %w", err)
+		}
+	}
+	filter.From, err = timeutil.ParseTimePointer(command.Filters.From)
+	if err != nil {
+		return nil, fmt.Errorf("timeutil.ParseTimePointer: %w", err)
+	}
+	filter.To, err = timeutil.ParseTimePointer(command.Filters.To)
+	if err != nil {
+		return nil, fmt.Errorf("timeutil.ParseTimePointer: %w", err)
+	}
+	if filter.From != nil && filter.To != nil && !filter.From.Before(*filter.To) {
+		return nil, errors.New("from must be before to")
+	}
+
+	// Resume after the previous page
+	if command.Cursor != nil {
+		cursor, err := pagination.DecodeCursor(*command.Cursor)
+		if err != nil {
+			return nil, fmt.Errorf("pagination.DecodeCursor: %w", err)
+		}
+		filter.Cursor = &repositories.AuditEventCursor{
+			CreatedAt: cursor.CreatedAt,
+			ID:        cursor.ID,
+		}
+	}
+
+	events, err := uc.auditRepo.List(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("uc.auditRepo.List: %w", err)
+	}
+
+	page := &commands.AuditEventsPage{}
+
+	// Trim the look-ahead event and point the cursor at the last returned one
+	if len(events) > command.Limit {
+		events = events[:command.Limit]
+		last := events[len(events)-1]
+		nextCursor := pagination.EncodeCursor(pagination.Cursor{
+			CreatedAt: last.CreatedAt(),
+			ID:        last.ID().String(),
+		})
+		page.NextCursor = &nextCursor
+	}
+	page.Events = events
+
+	return page, nil
+}
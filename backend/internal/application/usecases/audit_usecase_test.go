@@ -0,0 +1,165 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/atdevten/peace/internal/application/commands"
+	"github.com/atdevten/peace/internal/domain/entities"
+	domainrepositories "github.com/atdevten/peace/internal/domain/repositories"
+	"github.com/atdevten/peace/internal/domain/value_objects"
+	"github.com/atdevten/peace/internal/pkg/pagination"
+	"github.com/atdevten/peace/testutils/helpers"
+	repositories "github.com/atdevten/peace/testutils/mocks/repositories"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func newAuditEvent(createdAt time.Time, action value_objects.AuditAction) *entities.AuditEvent {
+	return entities.NewAuditEventFromRepository(
+		value_objects.NewTokenID(),
+		nil,
+		action,
+		entities.AuditTargetQuote,
+		"42",
+		"203.0.113.7",
+		"test-agent",
+		map[string]interface{}{},
+		createdAt,
+	)
+}
+
+func TestAuditUseCaseImpl_ListEvents(t *testing.T) {
+	base := time.Date(2023, 12, 1, 10, 0, 0, 0, time.UTC)
+	events := []*entities.AuditEvent{
+		newAuditEvent(base.Add(2*time.Hour), value_objects.AuditActionQuoteDeleted),
+		newAuditEvent(base.Add(time.Hour), value_objects.AuditActionQuoteUpdated),
+		newAuditEvent(base, value_objects.AuditActionQuoteCreated),
+	}
+
+	tests := []struct {
+		name        string
+		limit       int
+		filters     commands.AuditEventFilters
+		cursor      *string
+		mockEvents  []*entities.AuditEvent
+		mockError   error
+		expectRepo  bool
+		wantLen     int
+		wantCursor  bool
+		wantErr     bool
+		expectedErr string
+	}{
+		{
+			name:  "full page with next cursor and filters",
+			limit: 2,
+			filters: commands.AuditEventFilters{
+				ActorID:    helpers.StringPtr("550e8400-e29b-41d4-a716-446655440000"),
+				Action:     helpers.StringPtr("quote.deleted"),
+				TargetType: helpers.StringPtr("quote"),
+				From:       helpers.StringPtr("2023-12-01T00:00:00Z"),
+				To:         helpers.StringPtr("2023-12-02T00:00:00Z"),
+			},
+			mockEvents: events,
+			expectRepo: true,
+			wantLen:    2,
+			wantCursor: true,
+		},
+		{
+			name:       "last page",
+			limit:      5,
+			mockEvents: events,
+			expectRepo: true,
+			wantLen:    3,
+		},
+		{
+			name:        "unknown action",
+			limit:       2,
+			filters:     commands.AuditEventFilters{Action: helpers.StringPtr("quote.stolen")},
+			wantErr:     true,
+			expectedErr: "invalid audit action: quote.stolen",
+		},
+		{
+			name:        "invalid actor ID",
+			limit:       2,
+			filters:     commands.AuditEventFilters{ActorID: helpers.StringPtr("not-a-uuid")},
+			wantErr:     true,
+			expectedErr: "value_objects.NewUserIDFromString",
+		},
+		{
+			name:        "inverted time range",
+			limit:       2,
+			filters:     commands.AuditEventFilters{From: helpers.StringPtr("2023-12-02T00:00:00Z"), To: helpers.StringPtr("2023-12-01T00:00:00Z")},
+			wantErr:     true,
+			expectedErr: "from must be before to",
+		},
+		{
+			name:        "invalid cursor",
+			limit:       2,
+			cursor:      helpers.StringPtr("garbage"),
+			wantErr:     true,
+			expectedErr: "invalid cursor",
+		},
+		{
+			name:        "repository error",
+			limit:       2,
+			mockError:   errors.New("database error"),
+			expectRepo:  true,
+			wantErr:     true,
+			expectedErr: "database error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockAuditRepo := repositories.NewMockAuditEventRepository(ctrl)
+			if tt.expectRepo {
+				mockAuditRepo.EXPECT().
+					List(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, filter *domainrepositories.AuditEventFilter) ([]*entities.AuditEvent, error) {
+						assert.Equal(t, tt.limit+1, filter.Limit)
+						if tt.filters.Action != nil {
+							require.NotNil(t, filter.Action)
+							assert.Equal(t, value_objects.AuditActionQuoteDeleted, *filter.Action)
+							assert.Equal(t, *tt.filters.ActorID, filter.ActorID.String())
+							assert.Equal(t, "quote", *filter.TargetType)
+							assert.True(t, filter.From.Before(*filter.To))
+						}
+						return tt.mockEvents, tt.mockError
+					})
+			}
+
+			command, err := commands.NewListAuditEventsCommand(tt.filters, &tt.limit, tt.cursor)
+			require.NoError(t, err)
+
+			useCase := NewAuditUseCase(mockAuditRepo)
+			page, err := useCase.ListEvents(context.Background(), command)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedErr)
+				assert.Nil(t, page)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Len(t, page.Events, tt.wantLen)
+			assert.Equal(t, events[0].ID().String(), page.Events[0].ID().String())
+
+			if tt.wantCursor {
+				require.NotNil(t, page.NextCursor)
+				cursor, err := pagination.DecodeCursor(*page.NextCursor)
+				require.NoError(t, err)
+				assert.Equal(t, events[1].ID().String(), cursor.ID)
+			} else {
+				assert.Nil(t, page.NextCursor)
+			}
+		})
+	}
+}
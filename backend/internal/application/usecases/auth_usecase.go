@@ -4,10 +4,16 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"log"
+	"net/url"
+	"sync"
+	"time"
 
 	"github.com/atdevten/peace/internal/application/commands"
-	"github.com/atdevten/peace/internal/application/services/google"
 	appjwt "github.com/atdevten/peace/internal/application/services/jwt"
+	"github.com/atdevten/peace/internal/application/services/mail"
+	"github.com/atdevten/peace/internal/application/services/oauth"
+	"github.com/atdevten/peace/internal/application/services/ratelimit"
 	"github.com/atdevten/peace/internal/domain/entities"
 	"github.com/atdevten/peace/internal/domain/repositories"
 	"github.com/atdevten/peace/internal/domain/value_objects"
@@ -15,22 +21,120 @@ import (
 
 type AuthUseCase interface {
 	Register(ctx context.Context, command commands.RegisterCommand) (*entities.User, error)
-	Login(ctx context.Context, command commands.LoginCommand) (*entities.User, string, string, error) // user, access, refresh, error
-	LoginWithGoogle(ctx context.Context, code string) (*entities.User, string, string, error)         // user, access, refresh, error
-	Refresh(ctx context.Context, accessToken string, refreshToken string) (string, string, error)     // new access, new refresh, error
+	Login(ctx context.Context, command commands.LoginCommand) (*commands.LoginResult, error)
+	OAuthProviders() []string
+	// OAuthAuthorizationURL returns the page of provider the user logs in on, and the state to send back with its code
+	OAuthAuthorizationURL(ctx context.Context, provider string) (*oauth.Authorization, error)
+	LoginWithProvider(ctx context.Context, command commands.OAuthLoginCommand) (*commands.LoginResult, error)
+	// VerifyMFA completes a login that was answered with an MFA challenge
+	VerifyMFA(ctx context.Context, command commands.VerifyMFACommand) (*commands.LoginResult, error)
+	// ConfirmLink links the provider account of a link challenge once the account password is confirmed, then logs in
+	ConfirmLink(ctx context.Context, command commands.ConfirmLinkCommand) (*commands.LoginResult, error)
+	// RestoreAccount undoes the deletion of the account a restore token was issued for, then logs in
+	RestoreAccount(ctx context.Context, command commands.RestoreAccountCommand) (*commands.LoginResult, error)
+	Refresh(ctx context.Context, accessToken string, refreshToken string) (string, string, error) // new access, new refresh, error
+	Logout(ctx context.Context, refreshToken string) error
+	LogoutAll(ctx context.Context, userID string) error
+	ForgotPassword(ctx context.Context, command commands.ForgotPasswordCommand) error
+	ResetPassword(ctx context.Context, command commands.ResetPasswordCommand) error
+	VerifyEmail(ctx context.Context, command commands.VerifyEmailCommand) error
+	ResendVerificationEmail(ctx context.Context, command commands.ResendVerificationEmailCommand) error
 }
 
+// AuthOptions carries the tunables of the auth flows
+type AuthOptions struct {
+	RefreshTokenTTL time.Duration // must match the refresh JWT expiry
+
+	PasswordResetTTL time.Duration
+	PasswordResetURL string // frontend page that receives the token as ?token=
+
+	RequireVerifiedEmail            bool // refuse login for unverified local accounts
+	EmailVerificationTTL            time.Duration
+	EmailVerificationURL            string // frontend page that receives the token as ?token=
+	EmailVerificationResendInterval time.Duration
+
+	LoginLockout LoginLockoutOptions
+
+	DeletionGracePeriod time.Duration // deleted accounts logging in during this period may restore themselves
+}
+
+// ErrRefreshTokenReused is returned when an already rotated refresh token is presented; its family is revoked
+var ErrRefreshTokenReused = errors.New("refresh token reuse detected, please log in again")
+
+var (
+	// ErrProviderEmailNotVerified is returned when the provider has not verified the email an account would be matched on
+	ErrProviderEmailNotVerified = errors.New("the login provider has not verified this email")
+	// ErrExternalAccountMismatch is returned when the email belongs to an account that cannot confirm a link during login
+	ErrExternalAccountMismatch = errors.New("an account with this email already exists, log in with its current method and link this provider from your settings")
+	// ErrExternalAccountInUse is returned when the provider account is already linked to another user
+	ErrExternalAccountInUse = errors.New("this provider account is linked to another user")
+)
+
+// ErrAccountNotRestorable is returned when a deleted account's grace period is over or it was restored already
+var ErrAccountNotRestorable = errors.New("this account can no longer be restored")
+
+// Token errors are deliberately vague so callers cannot probe token state
+var (
+	errInvalidRefreshToken      = errors.New("invalid refresh token")
+	errInvalidResetToken        = errors.New("invalid or expired reset token")
+	errInvalidVerificationToken = errors.New("invalid or expired verification token")
+	errInvalidMFAToken          = errors.New("invalid or expired mfa token")
+	errInvalidLinkToken         = errors.New("invalid or expired link token")
+	errInvalidRestoreToken      = errors.New("invalid or expired restore token")
+)
+
 type AuthUseCaseImpl struct {
-	userRepo      repositories.UserRepository
-	jwtService    appjwt.Service
-	googleService google.Service
+	userRepo              repositories.UserRepository
+	sessionRepo           repositories.SessionRepository
+	refreshTokenRepo      repositories.RefreshTokenRepository
+	sessionRevoker        sessionRevoker
+	resetTokenRepo        repositories.PasswordResetTokenRepository
+	verificationTokenRepo repositories.EmailVerificationTokenRepository
+	secondFactor          secondFactor
+	identityRepo          repositories.UserIdentityRepository
+	retentionRepo         repositories.RetentionRepository
+	auditTrail            auditTrail
+	jwtService            appjwt.Service
+	oauthService          oauth.Service
+	loginThrottle         loginThrottle
+	mailSender            mail.Sender
+	options               AuthOptions
+	background            sync.WaitGroup // work left running after its request returned
 }
 
-func NewAuthUseCase(userRepo repositories.UserRepository, jwtService appjwt.Service, googleService google.Service) AuthUseCase {
+func NewAuthUseCase(
+	userRepo repositories.UserRepository,
+	sessionRepo repositories.SessionRepository,
+	refreshTokenRepo repositories.RefreshTokenRepository,
+	resetTokenRepo repositories.PasswordResetTokenRepository,
+	verificationTokenRepo repositories.EmailVerificationTokenRepository,
+	totpRepo repositories.TOTPFactorRepository,
+	recoveryCodeRepo repositories.RecoveryCodeRepository,
+	identityRepo repositories.UserIdentityRepository,
+	retentionRepo repositories.RetentionRepository,
+	auditRepo repositories.AuditEventRepository,
+	jwtService appjwt.Service,
+	oauthService oauth.Service,
+	loginAttempts ratelimit.Store,
+	mailSender mail.Sender,
+	options AuthOptions,
+) AuthUseCase {
 	return &AuthUseCaseImpl{
-		userRepo:      userRepo,
-		jwtService:    jwtService,
-		googleService: googleService,
+		userRepo:              userRepo,
+		sessionRepo:           sessionRepo,
+		refreshTokenRepo:      refreshTokenRepo,
+		sessionRevoker:        sessionRevoker{sessionRepo: sessionRepo, refreshTokenRepo: refreshTokenRepo},
+		resetTokenRepo:        resetTokenRepo,
+		verificationTokenRepo: verificationTokenRepo,
+		secondFactor:          secondFactor{totpRepo: totpRepo, recoveryCodeRepo: recoveryCodeRepo},
+		identityRepo:          identityRepo,
+		retentionRepo:         retentionRepo,
+		auditTrail:            auditTrail{repo: auditRepo},
+		jwtService:            jwtService,
+		oauthService:          oauthService,
+		loginThrottle:         loginThrottle{store: loginAttempts, options: options.LoginLockout},
+		mailSender:            mailSender,
+		options:               options,
 	}
 }
 
@@ -61,119 +165,982 @@ func (uc *AuthUseCaseImpl) Register(ctx context.Context, command commands.Regist
 		return nil, fmt.Errorf("uc.userRepo.Create: %w", err)
 	}
 
+	uc.auditTrail.record(ctx, auditEntry{
+		action:     value_objects.AuditActionUserRegistered,
+		actorID:    user.ID(),
+		targetType: entities.AuditTargetUser,
+		targetID:   user.ID().String(),
+		metadata:   map[string]interface{}{"provider": "local"},
+	})
+
+	// A delivery failure must not undo the registration; the user can ask for a resend
+	_ = uc.sendVerificationEmail(ctx, user)
+
 	return user, nil
 }
 
-func (uc *AuthUseCaseImpl) Login(ctx context.Context, command commands.LoginCommand) (*entities.User, string, string, error) {
+func (uc *AuthUseCaseImpl) Login(ctx context.Context, command commands.LoginCommand) (*commands.LoginResult, error) {
 	// Find user by email
 	emailVO, err := value_objects.NewEmail(command.Email)
 	if err != nil {
-		return nil, "", "", fmt.Errorf("value_objects.NewEmail: %w", err)
+		return nil, fmt.Errorf("value_objects.NewEmail: %w", err)
+	}
+
+	// Refuse locked out emails and addresses before looking at the password
+	email, ip := emailVO.String(), command.Client.IPAddress
+	if err := uc.loginThrottle.check(ctx, email, ip); err != nil {
+		uc.recordLoginFailed(ctx, nil, email, "locked_out", command.Client)
+		return nil, fmt.Errorf("uc.loginThrottle.check: %w", err)
 	}
 
 	user, err := uc.userRepo.GetByFilter(ctx, repositories.NewUserFilter(nil, emailVO, nil))
 	if err != nil {
-		return nil, "", "", errors.New("invalid email or password")
+		uc.recordLoginFailed(ctx, nil, email, "unknown_email", command.Client)
+		if err := uc.loginThrottle.fail(ctx, email, ip); err != nil {
+			return nil, fmt.Errorf("uc.loginThrottle.fail: %w", err)
+		}
+		return nil, errors.New("invalid email or password")
+	}
+
+	// Deleted accounts still in their grace period may log in, but only to restore themselves
+	if user.IsRestorable(uc.options.DeletionGracePeriod, time.Now()) {
+		if err := user.VerifyDeletedPassword(command.Password); err != nil {
+			uc.recordLoginFailed(ctx, user.ID(), email, "wrong_password", command.Client)
+			if err := uc.loginThrottle.fail(ctx, email, ip); err != nil {
+				return nil, fmt.Errorf("uc.loginThrottle.fail: %w", err)
+			}
+			return nil, errors.New("invalid email or password")
+		}
+		if err := uc.loginThrottle.succeed(ctx, email); err != nil {
+			return nil, fmt.Errorf("uc.loginThrottle.succeed: %w", err)
+		}
+		return uc.restoreChallenge(user, "local")
+	}
+
+	// The password comes first: until it is proven, the state of the account is nobody's business
+	if err = user.CheckPassword(command.Password); err != nil {
+		uc.recordLoginFailed(ctx, user.ID(), email, "wrong_password", command.Client)
+		if err := uc.loginThrottle.fail(ctx, email, ip); err != nil {
+			return nil, fmt.Errorf("uc.loginThrottle.fail: %w", err)
+		}
+		return nil, errors.New("invalid email or password")
+	}
+
+	if err = user.CanLogin(uc.options.RequireVerifiedEmail); err != nil {
+		uc.recordLoginFailed(ctx, user.ID(), email, "account_unavailable", command.Client)
+		return nil, fmt.Errorf("user.CanLogin: %w", err)
+	}
+
+	if err := uc.loginThrottle.succeed(ctx, email); err != nil {
+		return nil, fmt.Errorf("uc.loginThrottle.succeed: %w", err)
+	}
+
+	result, err := uc.completeLogin(ctx, user, "local", command.Client)
+	if err != nil {
+		return nil, fmt.Errorf("uc.completeLogin: %w", err)
+	}
+
+	return result, nil
+}
+
+func (uc *AuthUseCaseImpl) VerifyMFA(ctx context.Context, command commands.VerifyMFACommand) (*commands.LoginResult, error) {
+	claims, err := uc.jwtService.ValidateMFAToken(command.MFAToken)
+	if err != nil {
+		return nil, errInvalidMFAToken
+	}
+
+	userID, err := value_objects.NewUserIDFromString(claims.UserID)
+	if err != nil {
+		return nil, errInvalidMFAToken
+	}
+
+	// Challenges are single-use tokens with an ID; older ones without an ID cannot be counted
+	if claims.TokenID == "" {
+		return nil, errInvalidMFAToken
+	}
+	spent, err := uc.loginThrottle.checkChallenge(ctx, claims.TokenID)
+	if err != nil {
+		return nil, fmt.Errorf("uc.loginThrottle.checkChallenge: %w", err)
+	}
+	if spent {
+		return nil, errInvalidMFAToken
+	}
+
+	user, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, errInvalidMFAToken
+	}
+	if err := user.CanLogin(uc.options.RequireVerifiedEmail); err != nil {
+		return nil, fmt.Errorf("user.CanLogin: %w", err)
+	}
+
+	// Wrong codes count against the account like wrong passwords, so guessing codes locks it out too
+	email, ip := user.Email().String(), command.Client.IPAddress
+	if err := uc.loginThrottle.check(ctx, email, ip); err != nil {
+		uc.recordLoginFailed(ctx, user.ID(), email, "locked_out", command.Client)
+		return nil, fmt.Errorf("uc.loginThrottle.check: %w", err)
+	}
+
+	factor, err := uc.secondFactor.confirmedFactor(ctx, user.ID())
+	if err != nil {
+		return nil, fmt.Errorf("uc.secondFactor.confirmedFactor: %w", err)
+	}
+	if factor == nil {
+		// Two-factor login was turned off after the challenge was issued
+		return nil, errInvalidMFAToken
 	}
 
-	// Check if user can login
-	if err = user.CanLogin(); err != nil {
-		return nil, "", "", fmt.Errorf("user.CanLogin: %w", err)
+	if err := uc.secondFactor.verify(ctx, factor, command.Code); err != nil {
+		uc.recordLoginFailed(ctx, user.ID(), email, "invalid_mfa_code", command.Client)
+		if err := uc.loginThrottle.failChallenge(ctx, claims.TokenID); err != nil {
+			return nil, fmt.Errorf("uc.loginThrottle.failChallenge: %w", err)
+		}
+		if err := uc.loginThrottle.fail(ctx, email, ip); err != nil {
+			return nil, fmt.Errorf("uc.loginThrottle.fail: %w", err)
+		}
+		return nil, err
 	}
 
-	// Verify password
-	if err = user.VerifyPassword(command.Password); err != nil {
-		return nil, "", "", errors.New("invalid email or password")
+	if err := uc.loginThrottle.succeed(ctx, email); err != nil {
+		return nil, fmt.Errorf("uc.loginThrottle.succeed: %w", err)
 	}
 
-	// Generate tokens
-	access, err := uc.jwtService.GenerateAccessToken(*user.ID(), *user.Email())
+	session, err := uc.startSession(ctx, user, claims.Provider, command.Client)
 	if err != nil {
-		return nil, "", "", fmt.Errorf("uc.jwtService.GenerateAccessToken: %w", err)
+		return nil, fmt.Errorf("uc.startSession: %w", err)
+	}
+	if err := uc.loginThrottle.spendChallenge(ctx, claims.TokenID); err != nil {
+		return nil, fmt.Errorf("uc.loginThrottle.spendChallenge: %w", err)
 	}
 
-	refresh, err := uc.jwtService.GenerateRefreshToken(*user.ID(), *user.Email())
+	access, refresh, err := uc.issueTokens(ctx, user, session)
 	if err != nil {
-		return nil, "", "", fmt.Errorf("uc.jwtService.GenerateRefreshToken: %w", err)
+		return nil, fmt.Errorf("uc.issueTokens: %w", err)
 	}
 
-	return user, access, refresh, nil
+	return &commands.LoginResult{User: user, AccessToken: access, RefreshToken: refresh}, nil
+}
+
+func (uc *AuthUseCaseImpl) RestoreAccount(ctx context.Context, command commands.RestoreAccountCommand) (*commands.LoginResult, error) {
+	claims, err := uc.jwtService.ValidateRestoreToken(command.RestoreToken)
+	if err != nil {
+		return nil, errInvalidRestoreToken
+	}
+
+	userID, err := value_objects.NewUserIDFromString(claims.UserID)
+	if err != nil {
+		return nil, errInvalidRestoreToken
+	}
+
+	user, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, errInvalidRestoreToken
+	}
+
+	// The grace period may have run out since the token was issued
+	now := time.Now()
+	if !user.IsRestorable(uc.options.DeletionGracePeriod, now) {
+		return nil, ErrAccountNotRestorable
+	}
+
+	restoration := &repositories.AccountRestoration{
+		UserID:        user.ID(),
+		UserDeletedAt: *user.DeletedAt(),
+		RestoredAt:    now,
+		IPAddress:     command.Client.IPAddress,
+		UserAgent:     command.Client.UserAgent,
+	}
+	if err := uc.retentionRepo.RestoreUser(ctx, restoration); err != nil {
+		if errors.Is(err, repositories.ErrUserNotFound) {
+			return nil, ErrAccountNotRestorable
+		}
+		return nil, fmt.Errorf("uc.retentionRepo.RestoreUser: %w", err)
+	}
+	if err := user.Restore(); err != nil {
+		return nil, fmt.Errorf("user.Restore: %w", err)
+	}
+
+	uc.auditTrail.record(ctx, auditEntry{
+		action:     value_objects.AuditActionUserRestored,
+		actorID:    user.ID(),
+		targetType: entities.AuditTargetUser,
+		targetID:   user.ID().String(),
+		client:     &command.Client,
+		metadata:   map[string]interface{}{"deleted_at": restoration.UserDeletedAt},
+	})
+
+	if err := user.CanLogin(uc.options.RequireVerifiedEmail); err != nil {
+		return nil, fmt.Errorf("user.CanLogin: %w", err)
+	}
+
+	// The restore token never skipped the second factor: it is asked for now
+	result, err := uc.completeLogin(ctx, user, claims.Provider, command.Client)
+	if err != nil {
+		return nil, fmt.Errorf("uc.completeLogin: %w", err)
+	}
+
+	return result, nil
 }
 
 func (uc *AuthUseCaseImpl) Refresh(ctx context.Context, accessToken string, refreshToken string) (string, string, error) {
 	// Validate refresh token first
 	refreshClaims, err := uc.jwtService.ValidateRefreshToken(refreshToken)
 	if err != nil {
-		return "", "", errors.New("invalid refresh token")
+		return "", "", errInvalidRefreshToken
 	}
 
-	// Optionally: check access token expired/near expiry via ValidateAccessToken
-	// For simplicity, issue new tokens based on refresh claims
-	userID, err := value_objects.NewUserIDFromString(refreshClaims.UserID)
+	// Tokens issued before rotation existed carry no jti and are rejected
+	tokenID, err := value_objects.NewTokenIDFromString(refreshClaims.TokenID)
 	if err != nil {
-		return "", "", err
+		return "", "", errInvalidRefreshToken
 	}
-	email, err := value_objects.NewEmail(refreshClaims.Email)
+
+	stored, err := uc.refreshTokenRepo.GetByID(ctx, tokenID)
 	if err != nil {
-		return "", "", err
+		if errors.Is(err, repositories.ErrRefreshTokenNotFound) {
+			return "", "", errInvalidRefreshToken
+		}
+		return "", "", fmt.Errorf("uc.refreshTokenRepo.GetByID: %w", err)
 	}
 
-	newAccess, err := uc.jwtService.GenerateAccessToken(*userID, *email)
+	// A rotated token showing up again means it leaked: end the whole session
+	if stored.IsUsed() && !stored.IsRevoked() {
+		if err := uc.revokeSession(ctx, stored.FamilyID()); err != nil {
+			return "", "", fmt.Errorf("uc.revokeSession: %w", err)
+		}
+		return "", "", ErrRefreshTokenReused
+	}
+
+	if err := stored.Use(time.Now()); err != nil {
+		return "", "", errInvalidRefreshToken
+	}
+
+	user, err := uc.userRepo.GetByID(ctx, stored.UserID())
 	if err != nil {
-		return "", "", err
+		return "", "", errInvalidRefreshToken
+	}
+	if err := user.CanLogin(uc.options.RequireVerifiedEmail); err != nil {
+		return "", "", fmt.Errorf("user.CanLogin: %w", err)
+	}
+
+	// The refresh token family is the session of the login it came from
+	session, err := uc.sessionRepo.GetByID(ctx, stored.FamilyID())
+	if err != nil {
+		if errors.Is(err, repositories.ErrSessionNotFound) {
+			return "", "", errInvalidRefreshToken
+		}
+		return "", "", fmt.Errorf("uc.sessionRepo.GetByID: %w", err)
+	}
+	if session.IsRevoked() {
+		return "", "", errInvalidRefreshToken
 	}
-	newRefresh, err := uc.jwtService.GenerateRefreshToken(*userID, *email)
+
+	if err := uc.refreshTokenRepo.MarkUsed(ctx, stored); err != nil {
+		if errors.Is(err, repositories.ErrRefreshTokenUsed) {
+			// Lost a race against another refresh with the same token: treat it as reuse
+			if err := uc.revokeSession(ctx, stored.FamilyID()); err != nil {
+				return "", "", fmt.Errorf("uc.revokeSession: %w", err)
+			}
+			return "", "", ErrRefreshTokenReused
+		}
+		return "", "", fmt.Errorf("uc.refreshTokenRepo.MarkUsed: %w", err)
+	}
+
+	now := time.Now()
+	session.Touch(now)
+	if err := uc.sessionRepo.UpdateLastUsedAt(ctx, session.ID(), now); err != nil {
+		return "", "", fmt.Errorf("uc.sessionRepo.UpdateLastUsedAt: %w", err)
+	}
+
+	newAccess, newRefresh, err := uc.issueTokens(ctx, user, session)
 	if err != nil {
-		return "", "", err
+		return "", "", fmt.Errorf("uc.issueTokens: %w", err)
 	}
 	return newAccess, newRefresh, nil
 }
 
-func (uc *AuthUseCaseImpl) LoginWithGoogle(ctx context.Context, code string) (*entities.User, string, string, error) {
-	// Exchange code for user info
-	googleUser, err := uc.googleService.ExchangeCodeForToken(ctx, code)
+func (uc *AuthUseCaseImpl) Logout(ctx context.Context, refreshToken string) error {
+	refreshClaims, err := uc.jwtService.ValidateRefreshToken(refreshToken)
+	if err != nil {
+		return errInvalidRefreshToken
+	}
+
+	tokenID, err := value_objects.NewTokenIDFromString(refreshClaims.TokenID)
+	if err != nil {
+		return errInvalidRefreshToken
+	}
+
+	stored, err := uc.refreshTokenRepo.GetByID(ctx, tokenID)
 	if err != nil {
-		return nil, "", "", fmt.Errorf("uc.googleService.ExchangeCodeForToken: %w", err)
+		if errors.Is(err, repositories.ErrRefreshTokenNotFound) {
+			return errInvalidRefreshToken
+		}
+		return fmt.Errorf("uc.refreshTokenRepo.GetByID: %w", err)
+	}
+
+	if err := uc.revokeSession(ctx, stored.FamilyID()); err != nil {
+		return fmt.Errorf("uc.revokeSession: %w", err)
 	}
 
-	// Create email value object
-	emailVO, err := value_objects.NewEmail(googleUser.Email)
+	uc.auditTrail.record(ctx, auditEntry{
+		action:     value_objects.AuditActionLogout,
+		actorID:    stored.UserID(),
+		targetType: entities.AuditTargetSession,
+		targetID:   stored.FamilyID().String(),
+	})
+
+	return nil
+}
+
+func (uc *AuthUseCaseImpl) LogoutAll(ctx context.Context, userID string) error {
+	userIDVO, err := value_objects.NewUserIDFromString(userID)
 	if err != nil {
-		return nil, "", "", fmt.Errorf("value_objects.NewEmail: %w", err)
+		return fmt.Errorf("value_objects.NewUserIDFromString: %w", err)
+	}
+
+	if err := uc.sessionRevoker.revokeAllSessions(ctx, userIDVO); err != nil {
+		return fmt.Errorf("uc.sessionRevoker.revokeAllSessions: %w", err)
+	}
+
+	uc.auditTrail.record(ctx, auditEntry{
+		action:     value_objects.AuditActionLogoutAll,
+		actorID:    userIDVO,
+		targetType: entities.AuditTargetUser,
+		targetID:   userIDVO.String(),
+	})
+
+	return nil
+}
+
+// OAuthProviders lists the configured external login providers
+func (uc *AuthUseCaseImpl) OAuthProviders() []string {
+	return uc.oauthService.Providers()
+}
+
+// OAuthAuthorizationURL starts a login with an external provider
+func (uc *AuthUseCaseImpl) OAuthAuthorizationURL(ctx context.Context, provider string) (*oauth.Authorization, error) {
+	authorization, err := uc.oauthService.AuthorizationURL(ctx, provider)
+	if err != nil {
+		return nil, fmt.Errorf("uc.oauthService.AuthorizationURL: %w", err)
+	}
+
+	return authorization, nil
+}
+
+// LoginWithProvider logs in the user the provider account is linked to. Unknown provider accounts
+// sign up, unless their email belongs to an existing account: that account's password must then
+// confirm the link, so an external login can never take over a local account silently.
+func (uc *AuthUseCaseImpl) LoginWithProvider(ctx context.Context, command commands.OAuthLoginCommand) (*commands.LoginResult, error) {
+	pending := oauth.PendingLogin{State: command.LoginState, Verifier: command.Verifier}
+	info, err := uc.oauthService.Exchange(ctx, command.Provider, command.Code, command.State, pending)
+	if err != nil {
+		return nil, fmt.Errorf("uc.oauthService.Exchange: %w", err)
+	}
+
+	// Linked accounts are matched on the provider's subject, whatever their email
+	identity, err := uc.identityRepo.GetByProviderSubject(ctx, info.Provider, info.Subject)
+	if err == nil {
+		user, err := uc.userRepo.GetByID(ctx, identity.UserID())
+		if err != nil {
+			return nil, fmt.Errorf("uc.userRepo.GetByID: %w", err)
+		}
+		return uc.completeProviderLogin(ctx, user, info.Provider, command.Client)
+	}
+	if !errors.Is(err, repositories.ErrUserIdentityNotFound) {
+		return nil, fmt.Errorf("uc.identityRepo.GetByProviderSubject: %w", err)
+	}
+
+	// Matching on email is only safe once the provider has verified it
+	if !info.EmailVerified {
+		return nil, ErrProviderEmailNotVerified
+	}
+
+	emailVO, err := value_objects.NewEmail(info.Email)
+	if err != nil {
+		return nil, fmt.Errorf("value_objects.NewEmail: %w", err)
 	}
 
-	// Try to find existing user by email
 	existingUser, err := uc.userRepo.GetByFilter(ctx, repositories.NewUserFilter(nil, emailVO, nil))
 	if err != nil {
-		// If user not found, create new one
-		newUser, err := entities.NewGoogleUser(
-			googleUser.Email,
-			&googleUser.FirstName,
-			&googleUser.LastName,
-			googleUser.ID,
-			&googleUser.Picture,
-		)
+		if !errors.Is(err, repositories.ErrUserNotFound) {
+			return nil, fmt.Errorf("uc.userRepo.GetByFilter: %w", err)
+		}
+		return uc.signUpWithProvider(ctx, info, command.Client)
+	}
+
+	// Accounts without a password, or already linked to another account of this provider,
+	// have no way to confirm the link here; their owner can link from the account settings
+	if !existingUser.HasPassword() {
+		return nil, ErrExternalAccountMismatch
+	}
+	if _, err := uc.linkedIdentity(ctx, existingUser.ID(), info.Provider); err == nil {
+		return nil, ErrExternalAccountMismatch
+	} else if !errors.Is(err, repositories.ErrUserIdentityNotFound) {
+		return nil, err
+	}
+	if err := existingUser.CanLogin(false); err != nil {
+		return nil, fmt.Errorf("user.CanLogin: %w", err)
+	}
+
+	linkToken, err := uc.jwtService.GenerateLinkToken(*existingUser.ID(), *existingUser.Email(), info.Provider, info.Subject)
+	if err != nil {
+		return nil, fmt.Errorf("uc.jwtService.GenerateLinkToken: %w", err)
+	}
+
+	return &commands.LoginResult{User: existingUser, LinkToken: linkToken, Provider: info.Provider}, nil
+}
+
+func (uc *AuthUseCaseImpl) ConfirmLink(ctx context.Context, command commands.ConfirmLinkCommand) (*commands.LoginResult, error) {
+	claims, err := uc.jwtService.ValidateLinkToken(command.LinkToken)
+	if err != nil || claims.Provider == "" || claims.ExternalID == "" {
+		return nil, errInvalidLinkToken
+	}
+
+	userID, err := value_objects.NewUserIDFromString(claims.UserID)
+	if err != nil {
+		return nil, errInvalidLinkToken
+	}
+
+	user, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, errInvalidLinkToken
+	}
+	if err := user.CanLogin(uc.options.RequireVerifiedEmail); err != nil {
+		return nil, fmt.Errorf("user.CanLogin: %w", err)
+	}
+
+	// The password is guessed against the same lockouts as Login, so link challenges are no way around them
+	email, ip := user.Email().String(), command.Client.IPAddress
+	if err := uc.loginThrottle.check(ctx, email, ip); err != nil {
+		uc.recordLoginFailed(ctx, user.ID(), email, "locked_out", command.Client)
+		return nil, fmt.Errorf("uc.loginThrottle.check: %w", err)
+	}
+
+	if err := user.VerifyPassword(command.Password); err != nil {
+		uc.recordLoginFailed(ctx, user.ID(), email, "wrong_password", command.Client)
+		if err := uc.loginThrottle.fail(ctx, email, ip); err != nil {
+			return nil, fmt.Errorf("uc.loginThrottle.fail: %w", err)
+		}
+		return nil, errors.New("invalid password")
+	}
+
+	if err := uc.loginThrottle.succeed(ctx, email); err != nil {
+		return nil, fmt.Errorf("uc.loginThrottle.succeed: %w", err)
+	}
+
+	identity, err := entities.NewUserIdentity(user.ID(), claims.Provider, claims.ExternalID, user.Email().String())
+	if err != nil {
+		return nil, fmt.Errorf("entities.NewUserIdentity: %w", err)
+	}
+
+	// The provider account, or this user's slot for the provider, may have been taken since the challenge was issued
+	if err := uc.identityRepo.Create(ctx, identity); err != nil {
+		if errors.Is(err, repositories.ErrUserIdentityExists) {
+			return nil, ErrExternalAccountInUse
+		}
+		return nil, fmt.Errorf("uc.identityRepo.Create: %w", err)
+	}
+
+	uc.auditTrail.record(ctx, auditEntry{
+		action:     value_objects.AuditActionIdentityLinked,
+		actorID:    user.ID(),
+		targetType: entities.AuditTargetUser,
+		targetID:   user.ID().String(),
+		client:     &command.Client,
+		metadata:   map[string]interface{}{"provider": claims.Provider},
+	})
+
+	result, err := uc.completeLogin(ctx, user, claims.Provider, command.Client)
+	if err != nil {
+		return nil, fmt.Errorf("uc.completeLogin: %w", err)
+	}
+
+	return result, nil
+}
+
+// signUpWithProvider creates a password-less account for a new provider account and logs it in
+func (uc *AuthUseCaseImpl) signUpWithProvider(ctx context.Context, info *oauth.UserInfo, client commands.ClientInfo) (*commands.LoginResult, error) {
+	user, err := entities.NewExternalUser(
+		info.Email,
+		&info.FirstName,
+		&info.LastName,
+		info.Provider,
+		&info.Picture,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("entities.NewExternalUser: %w", err)
+	}
+
+	identity, err := entities.NewUserIdentity(user.ID(), info.Provider, info.Subject, info.Email)
+	if err != nil {
+		return nil, fmt.Errorf("entities.NewUserIdentity: %w", err)
+	}
+
+	if err := uc.userRepo.Create(ctx, user); err != nil {
+		return nil, fmt.Errorf("uc.userRepo.Create: %w", err)
+	}
+
+	if err := uc.identityRepo.Create(ctx, identity); err != nil {
+		if errors.Is(err, repositories.ErrUserIdentityExists) {
+			return nil, ErrExternalAccountInUse
+		}
+		return nil, fmt.Errorf("uc.identityRepo.Create: %w", err)
+	}
+
+	uc.auditTrail.record(ctx, auditEntry{
+		action:     value_objects.AuditActionUserRegistered,
+		actorID:    user.ID(),
+		targetType: entities.AuditTargetUser,
+		targetID:   user.ID().String(),
+		client:     &client,
+		metadata:   map[string]interface{}{"provider": info.Provider},
+	})
+
+	return uc.completeProviderLogin(ctx, user, info.Provider, client)
+}
+
+// linkedIdentity returns the user's identity at provider, or ErrUserIdentityNotFound
+func (uc *AuthUseCaseImpl) linkedIdentity(ctx context.Context, userID *value_objects.UserID, provider string) (*entities.UserIdentity, error) {
+	identities, err := uc.identityRepo.ListByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("uc.identityRepo.ListByUserID: %w", err)
+	}
+
+	for _, identity := range identities {
+		if identity.Provider() == provider {
+			return identity, nil
+		}
+	}
+
+	return nil, repositories.ErrUserIdentityNotFound
+}
+
+// completeProviderLogin checks the account may log in and finishes an external login
+func (uc *AuthUseCaseImpl) completeProviderLogin(ctx context.Context, user *entities.User, provider string, client commands.ClientInfo) (*commands.LoginResult, error) {
+	if user.IsRestorable(uc.options.DeletionGracePeriod, time.Now()) {
+		return uc.restoreChallenge(user, provider)
+	}
+
+	if err := user.CanLogin(uc.options.RequireVerifiedEmail); err != nil {
+		return nil, fmt.Errorf("user.CanLogin: %w", err)
+	}
+
+	result, err := uc.completeLogin(ctx, user, provider, client)
+	if err != nil {
+		return nil, fmt.Errorf("uc.completeLogin: %w", err)
+	}
+
+	return result, nil
+}
+
+func (uc *AuthUseCaseImpl) ForgotPassword(ctx context.Context, command commands.ForgotPasswordCommand) error {
+	emailVO, err := value_objects.NewEmail(command.Email)
+	if err != nil {
+		return fmt.Errorf("value_objects.NewEmail: %w", err)
+	}
+
+	// Addresses are throttled whether or not they belong to an account, so the limit reveals nothing
+	allowed, err := uc.loginThrottle.allowEmail(ctx, "reset", emailVO.String())
+	if err != nil {
+		return fmt.Errorf("uc.loginThrottle.allowEmail: %w", err)
+	}
+	if !allowed {
+		return nil
+	}
+
+	uc.inBackground(ctx, func(ctx context.Context) {
+		// Unknown or disabled accounts are skipped silently so the endpoint cannot be used to enumerate emails
+		user, err := uc.userRepo.GetByFilter(ctx, repositories.NewUserFilter(nil, emailVO, nil))
 		if err != nil {
-			return nil, "", "", fmt.Errorf("entities.NewGoogleUser: %w", err)
+			return
+		}
+		// Resetting proves ownership of the email, so unverified accounts may use it
+		if err = user.CanLogin(false); err != nil {
+			return
+		}
+
+		if err := uc.sendPasswordReset(ctx, user); err != nil {
+			log.Printf("Failed to send password reset email: %v", err)
+		}
+	})
+	return nil
+}
+
+// inBackground runs work once the request has returned, keeping the values of ctx but not its cancellation.
+// Work whose cost depends on whether an account exists goes here, so response times do not give it away.
+func (uc *AuthUseCaseImpl) inBackground(ctx context.Context, work func(ctx context.Context)) {
+	ctx = context.WithoutCancel(ctx)
+	uc.background.Add(1)
+	go func() {
+		defer uc.background.Done()
+		work(ctx)
+	}()
+}
+
+func (uc *AuthUseCaseImpl) sendPasswordReset(ctx context.Context, user *entities.User) error {
+	// Only the most recent link stays valid
+	if err := uc.resetTokenRepo.InvalidateByUserID(ctx, user.ID()); err != nil {
+		return fmt.Errorf("uc.resetTokenRepo.InvalidateByUserID: %w", err)
+	}
+
+	secret, err := value_objects.NewSecretToken()
+	if err != nil {
+		return fmt.Errorf("value_objects.NewSecretToken: %w", err)
+	}
+
+	token, err := entities.NewPasswordResetToken(user.ID(), secret, uc.options.PasswordResetTTL)
+	if err != nil {
+		return fmt.Errorf("entities.NewPasswordResetToken: %w", err)
+	}
+
+	if err := uc.resetTokenRepo.Create(ctx, token); err != nil {
+		return fmt.Errorf("uc.resetTokenRepo.Create: %w", err)
+	}
+
+	uc.auditTrail.record(ctx, auditEntry{
+		action:     value_objects.AuditActionPasswordResetRequested,
+		targetType: entities.AuditTargetUser,
+		targetID:   user.ID().String(),
+	})
+
+	link, err := tokenLink(uc.options.PasswordResetURL, secret)
+	if err != nil {
+		return fmt.Errorf("tokenLink: %w", err)
+	}
+
+	message := mail.Message{
+		To:      user.Email().String(),
+		Subject: "Reset your Peace password",
+		Body: fmt.Sprintf(
+			"We received a request to reset your password.\n\nOpen the link below to choose a new one:\n%s\n\nThe link expires in %s and can only be used once. If you did not ask for this, you can ignore this email.\n",
+			link,
+			uc.options.PasswordResetTTL,
+		),
+	}
+	if err := uc.mailSender.Send(ctx, message); err != nil {
+		return fmt.Errorf("uc.mailSender.Send: %w", err)
+	}
+
+	return nil
+}
+
+func (uc *AuthUseCaseImpl) ResetPassword(ctx context.Context, command commands.ResetPasswordCommand) error {
+	secret, err := value_objects.NewSecretTokenFromString(command.Token)
+	if err != nil {
+		return errInvalidResetToken
+	}
+
+	token, err := uc.resetTokenRepo.GetByTokenHash(ctx, secret.Hash())
+	if err != nil {
+		if errors.Is(err, repositories.ErrPasswordResetTokenNotFound) {
+			return errInvalidResetToken
 		}
+		return fmt.Errorf("uc.resetTokenRepo.GetByTokenHash: %w", err)
+	}
+
+	if err := token.Use(time.Now()); err != nil {
+		return errInvalidResetToken
+	}
+
+	user, err := uc.userRepo.GetByID(ctx, token.UserID())
+	if err != nil {
+		return errInvalidResetToken
+	}
+	if err := user.CanLogin(false); err != nil {
+		return fmt.Errorf("user.CanLogin: %w", err)
+	}
 
-		// Create user in database
-		if err := uc.userRepo.Create(ctx, newUser); err != nil {
-			return nil, "", "", fmt.Errorf("uc.userRepo.Create: %w", err)
+	// Validate the new password before burning the token so a typo does not cost the user their link
+	if err := user.UpdatePassword(command.NewPassword); err != nil {
+		return fmt.Errorf("user.UpdatePassword: %w", err)
+	}
+
+	if err := uc.resetTokenRepo.MarkUsed(ctx, token); err != nil {
+		if errors.Is(err, repositories.ErrPasswordResetTokenUsed) {
+			return errInvalidResetToken
 		}
-		existingUser = newUser
+		return fmt.Errorf("uc.resetTokenRepo.MarkUsed: %w", err)
+	}
+
+	if err := uc.userRepo.Update(ctx, user); err != nil {
+		return fmt.Errorf("uc.userRepo.Update: %w", err)
+	}
+
+	// Any other outstanding links die with this reset
+	if err := uc.resetTokenRepo.InvalidateByUserID(ctx, user.ID()); err != nil {
+		return fmt.Errorf("uc.resetTokenRepo.InvalidateByUserID: %w", err)
+	}
+
+	// Whoever knew the old password must not stay signed in
+	if err := uc.sessionRevoker.revokeAllSessions(ctx, user.ID()); err != nil {
+		return fmt.Errorf("uc.sessionRevoker.revokeAllSessions: %w", err)
 	}
 
-	// Generate tokens
-	access, err := uc.jwtService.GenerateAccessToken(*existingUser.ID(), *existingUser.Email())
+	uc.auditTrail.record(ctx, auditEntry{
+		action:     value_objects.AuditActionPasswordReset,
+		actorID:    user.ID(),
+		targetType: entities.AuditTargetUser,
+		targetID:   user.ID().String(),
+	})
+
+	return nil
+}
+
+func (uc *AuthUseCaseImpl) VerifyEmail(ctx context.Context, command commands.VerifyEmailCommand) error {
+	secret, err := value_objects.NewSecretTokenFromString(command.Token)
 	if err != nil {
-		return nil, "", "", fmt.Errorf("uc.jwtService.GenerateAccessToken: %w", err)
+		return errInvalidVerificationToken
 	}
 
-	refresh, err := uc.jwtService.GenerateRefreshToken(*existingUser.ID(), *existingUser.Email())
+	token, err := uc.verificationTokenRepo.GetByTokenHash(ctx, secret.Hash())
+	if err != nil {
+		if errors.Is(err, repositories.ErrEmailVerificationTokenNotFound) {
+			return errInvalidVerificationToken
+		}
+		return fmt.Errorf("uc.verificationTokenRepo.GetByTokenHash: %w", err)
+	}
+
+	if err := token.Use(time.Now()); err != nil {
+		return errInvalidVerificationToken
+	}
+
+	user, err := uc.userRepo.GetByID(ctx, token.UserID())
+	if err != nil {
+		return errInvalidVerificationToken
+	}
+
+	if err := uc.verificationTokenRepo.MarkUsed(ctx, token); err != nil {
+		if errors.Is(err, repositories.ErrEmailVerificationTokenUsed) {
+			return errInvalidVerificationToken
+		}
+		return fmt.Errorf("uc.verificationTokenRepo.MarkUsed: %w", err)
+	}
+
+	if !user.EmailVerified() {
+		user.VerifyEmail()
+		if err := uc.userRepo.Update(ctx, user); err != nil {
+			return fmt.Errorf("uc.userRepo.Update: %w", err)
+		}
+		uc.auditTrail.record(ctx, auditEntry{
+			action:     value_objects.AuditActionEmailVerified,
+			actorID:    user.ID(),
+			targetType: entities.AuditTargetUser,
+			targetID:   user.ID().String(),
+		})
+	}
+
+	if err := uc.verificationTokenRepo.InvalidateByUserID(ctx, user.ID()); err != nil {
+		return fmt.Errorf("uc.verificationTokenRepo.InvalidateByUserID: %w", err)
+	}
+
+	return nil
+}
+
+func (uc *AuthUseCaseImpl) ResendVerificationEmail(ctx context.Context, command commands.ResendVerificationEmailCommand) error {
+	emailVO, err := value_objects.NewEmail(command.Email)
+	if err != nil {
+		return fmt.Errorf("value_objects.NewEmail: %w", err)
+	}
+
+	// Addresses are throttled whether or not they belong to an account, so the limit reveals nothing
+	allowed, err := uc.loginThrottle.allowEmail(ctx, "verification", emailVO.String())
+	if err != nil {
+		return fmt.Errorf("uc.loginThrottle.allowEmail: %w", err)
+	}
+	if !allowed {
+		return nil
+	}
+
+	uc.inBackground(ctx, func(ctx context.Context) {
+		// Unknown, disabled or already verified accounts are skipped silently so emails cannot be enumerated
+		user, err := uc.userRepo.GetByFilter(ctx, repositories.NewUserFilter(nil, emailVO, nil))
+		if err != nil {
+			return
+		}
+		if user.EmailVerified() || user.CanLogin(false) != nil {
+			return
+		}
+
+		latest, err := uc.verificationTokenRepo.GetLatestByUserID(ctx, user.ID())
+		if err != nil && !errors.Is(err, repositories.ErrEmailVerificationTokenNotFound) {
+			log.Printf("Failed to look up the latest verification email: %v", err)
+			return
+		}
+		if latest != nil && time.Since(latest.CreatedAt()) < uc.options.EmailVerificationResendInterval {
+			return
+		}
+
+		if err := uc.sendVerificationEmail(ctx, user); err != nil {
+			log.Printf("Failed to send verification email: %v", err)
+		}
+	})
+	return nil
+}
+
+// sendVerificationEmail issues a fresh verification token, revoking older ones, and mails it
+func (uc *AuthUseCaseImpl) sendVerificationEmail(ctx context.Context, user *entities.User) error {
+	if err := uc.verificationTokenRepo.InvalidateByUserID(ctx, user.ID()); err != nil {
+		return fmt.Errorf("uc.verificationTokenRepo.InvalidateByUserID: %w", err)
+	}
+
+	secret, err := value_objects.NewSecretToken()
+	if err != nil {
+		return fmt.Errorf("value_objects.NewSecretToken: %w", err)
+	}
+
+	token, err := entities.NewEmailVerificationToken(user.ID(), secret, uc.options.EmailVerificationTTL)
+	if err != nil {
+		return fmt.Errorf("entities.NewEmailVerificationToken: %w", err)
+	}
+
+	if err := uc.verificationTokenRepo.Create(ctx, token); err != nil {
+		return fmt.Errorf("uc.verificationTokenRepo.Create: %w", err)
+	}
+
+	link, err := tokenLink(uc.options.EmailVerificationURL, secret)
+	if err != nil {
+		return fmt.Errorf("tokenLink: %w", err)
+	}
+
+	message := mail.Message{
+		To:      user.Email().String(),
+		Subject: "Verify your Peace email address",
+		Body: fmt.Sprintf(
+			"Welcome to Peace!\n\nPlease confirm your email address by opening the link below:\n%s\n\nThe link expires in %s. If you did not create an account, you can ignore this email.\n",
+			link,
+			uc.options.EmailVerificationTTL,
+		),
+	}
+	if err := uc.mailSender.Send(ctx, message); err != nil {
+		return fmt.Errorf("uc.mailSender.Send: %w", err)
+	}
+
+	return nil
+}
+
+// completeLogin issues the token pair of a new session, or an MFA challenge when the user has a second factor
+func (uc *AuthUseCaseImpl) completeLogin(ctx context.Context, user *entities.User, provider string, client commands.ClientInfo) (*commands.LoginResult, error) {
+	factor, err := uc.secondFactor.confirmedFactor(ctx, user.ID())
+	if err != nil {
+		return nil, fmt.Errorf("uc.secondFactor.confirmedFactor: %w", err)
+	}
+
+	if factor != nil {
+		mfaToken, err := uc.jwtService.GenerateMFAToken(*user.ID(), *user.Email(), provider)
+		if err != nil {
+			return nil, fmt.Errorf("uc.jwtService.GenerateMFAToken: %w", err)
+		}
+		return &commands.LoginResult{User: user, MFAToken: mfaToken}, nil
+	}
+
+	session, err := uc.startSession(ctx, user, provider, client)
+	if err != nil {
+		return nil, fmt.Errorf("uc.startSession: %w", err)
+	}
+
+	access, refresh, err := uc.issueTokens(ctx, user, session)
+	if err != nil {
+		return nil, fmt.Errorf("uc.issueTokens: %w", err)
+	}
+
+	return &commands.LoginResult{User: user, AccessToken: access, RefreshToken: refresh}, nil
+}
+
+// restoreChallenge answers the login of a deleted account with a token that can only restore it
+func (uc *AuthUseCaseImpl) restoreChallenge(user *entities.User, provider string) (*commands.LoginResult, error) {
+	restoreToken, err := uc.jwtService.GenerateRestoreToken(*user.ID(), *user.Email(), provider)
+	if err != nil {
+		return nil, fmt.Errorf("uc.jwtService.GenerateRestoreToken: %w", err)
+	}
+
+	restoreBefore := user.DeletedAt().Add(uc.options.DeletionGracePeriod)
+	return &commands.LoginResult{User: user, RestoreToken: restoreToken, RestoreBefore: &restoreBefore}, nil
+}
+
+// startSession records a new login of the user through the given provider
+func (uc *AuthUseCaseImpl) startSession(ctx context.Context, user *entities.User, provider string, client commands.ClientInfo) (*entities.Session, error) {
+	session, err := entities.NewSession(user.ID(), client.UserAgent, client.IPAddress, provider)
+	if err != nil {
+		return nil, fmt.Errorf("entities.NewSession: %w", err)
+	}
+
+	if err := uc.sessionRepo.Create(ctx, session); err != nil {
+		return nil, fmt.Errorf("uc.sessionRepo.Create: %w", err)
+	}
+
+	// Every successful login starts a session, whichever flow it went through
+	uc.auditTrail.record(ctx, auditEntry{
+		action:     value_objects.AuditActionLogin,
+		actorID:    user.ID(),
+		targetType: entities.AuditTargetSession,
+		targetID:   session.ID().String(),
+		client:     &client,
+		metadata:   map[string]interface{}{"provider": provider},
+	})
+
+	return session, nil
+}
+
+// recordLoginFailed audits a refused login; userID is nil when the email matches no account
+func (uc *AuthUseCaseImpl) recordLoginFailed(ctx context.Context, userID *value_objects.UserID, email string, reason string, client commands.ClientInfo) {
+	entry := auditEntry{
+		action:   value_objects.AuditActionLoginFailed,
+		client:   &client,
+		metadata: map[string]interface{}{"email": email, "reason": reason},
+	}
+	if userID != nil {
+		entry.targetType = entities.AuditTargetUser
+		entry.targetID = userID.String()
+	}
+	uc.auditTrail.record(ctx, entry)
+}
+
+// issueTokens creates an access token bound to the session and the next refresh token of its family
+func (uc *AuthUseCaseImpl) issueTokens(ctx context.Context, user *entities.User, session *entities.Session) (string, string, error) {
+	access, err := uc.jwtService.GenerateAccessToken(*user.ID(), *user.Email(), user.Role(), *session.ID())
+	if err != nil {
+		return "", "", fmt.Errorf("uc.jwtService.GenerateAccessToken: %w", err)
+	}
+
+	token, err := entities.NewRefreshToken(user.ID(), session.ID(), session.UserAgent(), uc.options.RefreshTokenTTL)
+	if err != nil {
+		return "", "", fmt.Errorf("entities.NewRefreshToken: %w", err)
+	}
+
+	if err := uc.refreshTokenRepo.Create(ctx, token); err != nil {
+		return "", "", fmt.Errorf("uc.refreshTokenRepo.Create: %w", err)
+	}
+
+	refresh, err := uc.jwtService.GenerateRefreshToken(*user.ID(), *user.Email(), *token.ID())
+	if err != nil {
+		return "", "", fmt.Errorf("uc.jwtService.GenerateRefreshToken: %w", err)
+	}
+
+	return access, refresh, nil
+}
+
+// revokeSession ends one session together with its refresh token family
+func (uc *AuthUseCaseImpl) revokeSession(ctx context.Context, sessionID *value_objects.TokenID) error {
+	if err := uc.refreshTokenRepo.RevokeFamily(ctx, sessionID); err != nil {
+		return fmt.Errorf("uc.refreshTokenRepo.RevokeFamily: %w", err)
+	}
+
+	if err := uc.sessionRepo.Revoke(ctx, sessionID); err != nil {
+		return fmt.Errorf("uc.sessionRepo.Revoke: %w", err)
+	}
+
+	return nil
+}
+
+// tokenLink appends the secret token to a frontend URL as the token query parameter
+func tokenLink(baseURL string, secret *value_objects.SecretToken) (string, error) {
+	link, err := url.Parse(baseURL)
 	if err != nil {
-		return nil, "", "", fmt.Errorf("uc.jwtService.GenerateRefreshToken: %w", err)
+		return "", err
 	}
 
-	return existingUser, access, refresh, nil
+	query := link.Query()
+	query.Set("token", secret.String())
+	link.RawQuery = query.Encode()
+	return link.String(), nil
 }
@@ -3,13 +3,18 @@ package usecases
 import (
 	"context"
 	"errors"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/atdevten/peace/internal/application/commands"
-	"github.com/atdevten/peace/internal/application/services/google"
 	appjwt "github.com/atdevten/peace/internal/application/services/jwt"
+	"github.com/atdevten/peace/internal/application/services/mail"
+	"github.com/atdevten/peace/internal/application/services/oauth"
 	"github.com/atdevten/peace/internal/domain/entities"
+	domainrepositories "github.com/atdevten/peace/internal/domain/repositories"
 	"github.com/atdevten/peace/internal/domain/value_objects"
+	"github.com/atdevten/peace/internal/pkg/totp"
 	"github.com/atdevten/peace/testutils/helpers"
 	repositories "github.com/atdevten/peace/testutils/mocks/repositories"
 	"github.com/stretchr/testify/assert"
@@ -21,16 +26,31 @@ import (
 type MockJWTService struct {
 	ctrl                     *gomock.Controller
 	ValidateRefreshTokenFunc func(token string) (*appjwt.Claims, error)
+	ValidateMFATokenFunc     func(token string) (*appjwt.Claims, error)
+	ValidateLinkTokenFunc    func(token string) (*appjwt.Claims, error)
+	ValidateRestoreTokenFunc func(token string) (*appjwt.Claims, error)
 }
 
-func (m *MockJWTService) GenerateAccessToken(userID value_objects.UserID, email value_objects.Email) (string, error) {
+func (m *MockJWTService) GenerateAccessToken(userID value_objects.UserID, email value_objects.Email, role value_objects.Role, sessionID value_objects.TokenID) (string, error) {
 	return "mock-access-token", nil
 }
 
-func (m *MockJWTService) GenerateRefreshToken(userID value_objects.UserID, email value_objects.Email) (string, error) {
+func (m *MockJWTService) GenerateRefreshToken(userID value_objects.UserID, email value_objects.Email, tokenID value_objects.TokenID) (string, error) {
 	return "mock-refresh-token", nil
 }
 
+func (m *MockJWTService) GenerateMFAToken(userID value_objects.UserID, email value_objects.Email, provider string) (string, error) {
+	return "mock-mfa-token", nil
+}
+
+func (m *MockJWTService) GenerateLinkToken(userID value_objects.UserID, email value_objects.Email, provider string, externalID string) (string, error) {
+	return "mock-link-token", nil
+}
+
+func (m *MockJWTService) GenerateRestoreToken(userID value_objects.UserID, email value_objects.Email, provider string) (string, error) {
+	return "mock-restore-token", nil
+}
+
 func (m *MockJWTService) ValidateAccessToken(token string) (*appjwt.Claims, error) {
 	return &appjwt.Claims{
 		UserID: "550e8400-e29b-41d4-a716-446655440000",
@@ -48,28 +68,115 @@ func (m *MockJWTService) ValidateRefreshToken(token string) (*appjwt.Claims, err
 	}, nil
 }
 
-type MockGoogleService struct {
-	ctrl                     *gomock.Controller
-	ExchangeCodeForTokenFunc func(ctx context.Context, code string) (*google.GoogleUserInfo, error)
+func (m *MockJWTService) ValidateMFAToken(token string) (*appjwt.Claims, error) {
+	if m.ValidateMFATokenFunc != nil {
+		return m.ValidateMFATokenFunc(token)
+	}
+	return &appjwt.Claims{
+		UserID:   "550e8400-e29b-41d4-a716-446655440000",
+		Email:    "test@example.com",
+		Provider: "local",
+	}, nil
+}
+
+func (m *MockJWTService) ValidateLinkToken(token string) (*appjwt.Claims, error) {
+	if m.ValidateLinkTokenFunc != nil {
+		return m.ValidateLinkTokenFunc(token)
+	}
+	return &appjwt.Claims{
+		UserID:     "550e8400-e29b-41d4-a716-446655440000",
+		Email:      "test@example.com",
+		Provider:   "google",
+		ExternalID: "google123",
+	}, nil
+}
+
+func (m *MockJWTService) ValidateRestoreToken(token string) (*appjwt.Claims, error) {
+	if m.ValidateRestoreTokenFunc != nil {
+		return m.ValidateRestoreTokenFunc(token)
+	}
+	return &appjwt.Claims{
+		UserID:   "550e8400-e29b-41d4-a716-446655440000",
+		Email:    "test@example.com",
+		Provider: "local",
+	}, nil
+}
+
+type MockOAuthService struct {
+	ctrl         *gomock.Controller
+	ExchangeFunc func(ctx context.Context, provider string, code string, state string) (*oauth.UserInfo, error)
+}
+
+func (m *MockOAuthService) Providers() []string {
+	return []string{"google"}
 }
 
-func (m *MockGoogleService) GetAuthURL() string {
-	return "https://accounts.google.com/o/oauth2/v2/auth?mock=true"
+func (m *MockOAuthService) AuthorizationURL(ctx context.Context, provider string) (*oauth.Authorization, error) {
+	return &oauth.Authorization{URL: "https://accounts.google.com/o/oauth2/v2/auth?mock=true", PendingLogin: oauth.PendingLogin{State: "mock-state", Verifier: "mock-verifier"}}, nil
 }
 
-func (m *MockGoogleService) ExchangeCodeForToken(ctx context.Context, code string) (*google.GoogleUserInfo, error) {
-	if m.ExchangeCodeForTokenFunc != nil {
-		return m.ExchangeCodeForTokenFunc(ctx, code)
+func (m *MockOAuthService) Exchange(ctx context.Context, provider string, code string, state string, pending oauth.PendingLogin) (*oauth.UserInfo, error) {
+	if m.ExchangeFunc != nil {
+		return m.ExchangeFunc(ctx, provider, code, state)
 	}
-	return &google.GoogleUserInfo{
-		ID:        "google123",
-		Email:     "google@example.com",
-		FirstName: "Google",
-		LastName:  "User",
-		Picture:   "https://example.com/avatar.jpg",
+	return &oauth.UserInfo{
+		Provider:      provider,
+		Subject:       "google123",
+		Email:         "google@example.com",
+		FirstName:     "Google",
+		LastName:      "User",
+		Picture:       "https://example.com/avatar.jpg",
+		EmailVerified: true,
 	}, nil
 }
 
+type MockMailSender struct {
+	SendFunc func(ctx context.Context, message mail.Message) error
+	Sent     []mail.Message
+}
+
+func (m *MockMailSender) Send(ctx context.Context, message mail.Message) error {
+	if m.SendFunc != nil {
+		if err := m.SendFunc(ctx, message); err != nil {
+			return err
+		}
+	}
+	m.Sent = append(m.Sent, message)
+	return nil
+}
+
+// MockAttemptStore counts hits without windows and records lock durations instead of timing them
+type MockAttemptStore struct {
+	Counts map[string]int64
+	Locks  map[string]time.Duration
+}
+
+func NewMockAttemptStore() *MockAttemptStore {
+	return &MockAttemptStore{Counts: map[string]int64{}, Locks: map[string]time.Duration{}}
+}
+
+func (m *MockAttemptStore) Hit(ctx context.Context, key string, window time.Duration) (int64, time.Duration, error) {
+	m.Counts[key]++
+	return m.Counts[key], window, nil
+}
+
+func (m *MockAttemptStore) Lock(ctx context.Context, key string, ttl time.Duration) error {
+	m.Locks[key] = ttl
+	return nil
+}
+
+func (m *MockAttemptStore) LockedFor(ctx context.Context, key string) (time.Duration, error) {
+	return m.Locks[key], nil
+}
+
+func (m *MockAttemptStore) Reset(ctx context.Context, keys ...string) error {
+	for _, key := range keys {
+		delete(m.Counts, key)
+		delete(m.Locks, key)
+	}
+	return nil
+}
+
 func TestAuthUseCaseImpl_Register(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -77,6 +184,7 @@ func TestAuthUseCaseImpl_Register(t *testing.T) {
 		mockUser    *entities.User
 		emailExists bool
 		mockError   error
+		mailError   error
 		wantErr     bool
 		expectedErr string
 	}{
@@ -93,6 +201,20 @@ func TestAuthUseCaseImpl_Register(t *testing.T) {
 			emailExists: false,
 			wantErr:     false,
 		},
+		{
+			name: "mail failure does not fail registration",
+			command: commands.RegisterCommand{
+				Email:     "test@example.com",
+				Username:  "testuser",
+				FirstName: helpers.StringPtr("John"),
+				LastName:  helpers.StringPtr("Doe"),
+				Password:  "Password123",
+			},
+			mockUser:    helpers.CreateTestUser(),
+			emailExists: false,
+			mailError:   errors.New("smtp down"),
+			wantErr:     false,
+		},
 		{
 			name: "email already exists",
 			command: commands.RegisterCommand{
@@ -141,18 +263,31 @@ func TestAuthUseCaseImpl_Register(t *testing.T) {
 
 			// Setup mock repository
 			mockRepo := repositories.NewMockUserRepository(ctrl)
+			mockVerificationRepo := repositories.NewMockEmailVerificationTokenRepository(ctrl)
 			if tt.command.Email != "invalid-email" && tt.command.Password != "weak" {
 				mockRepo.EXPECT().EmailExists(gomock.Any(), gomock.Any()).Return(tt.emailExists, tt.mockError)
 				if !tt.emailExists {
 					mockRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+					mockVerificationRepo.EXPECT().InvalidateByUserID(gomock.Any(), gomock.Any()).Return(nil)
+					mockVerificationRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
 				}
 			}
 
 			// Setup mock services
 			mockJWT := &MockJWTService{ctrl: ctrl}
-			mockGoogle := &MockGoogleService{ctrl: ctrl}
+			mockOAuth := &MockOAuthService{ctrl: ctrl}
+			mockMail := &MockMailSender{}
+			if tt.mailError != nil {
+				mockMail.SendFunc = func(ctx context.Context, message mail.Message) error {
+					return tt.mailError
+				}
+			}
 
-			useCase := NewAuthUseCase(mockRepo, mockJWT, mockGoogle)
+			options := AuthOptions{
+				EmailVerificationTTL: 24 * time.Hour,
+				EmailVerificationURL: "http://localhost:3000/verify-email",
+			}
+			useCase := NewAuthUseCase(mockRepo, nil, nil, nil, mockVerificationRepo, nil, nil, nil, nil, nil, mockJWT, mockOAuth, nil, mockMail, options)
 			user, err := useCase.Register(context.Background(), tt.command)
 
 			if tt.wantErr {
@@ -163,29 +298,93 @@ func TestAuthUseCaseImpl_Register(t *testing.T) {
 				require.NoError(t, err)
 				assert.NotNil(t, user)
 				assert.Equal(t, tt.command.Email, user.Email().String())
+				assert.False(t, user.EmailVerified())
+				if tt.mailError == nil {
+					require.Len(t, mockMail.Sent, 1)
+					assert.Equal(t, tt.command.Email, mockMail.Sent[0].To)
+					assert.Contains(t, mockMail.Sent[0].Body, "http://localhost:3000/verify-email?token=")
+				}
 			}
 		})
 	}
 }
 
 func TestAuthUseCaseImpl_Login(t *testing.T) {
+	deactivated := helpers.CreateTestUser()
+	require.NoError(t, deactivated.Deactivate())
+
 	tests := []struct {
-		name        string
-		command     commands.LoginCommand
-		mockUser    *entities.User
-		mockError   error
-		wantErr     bool
-		expectedErr string
+		name            string
+		command         commands.LoginCommand
+		mockUser        *entities.User
+		mockError       error
+		requireVerified bool
+		mfaEnabled      bool
+		wantErr         bool
+		expectedErr     string
 	}{
 		{
 			name: "successful login",
 			command: commands.LoginCommand{
 				Email:    "test@example.com",
 				Password: "Password123",
+				Client:   commands.ClientInfo{UserAgent: "test-agent", IPAddress: "203.0.113.7"},
 			},
 			mockUser: helpers.CreateTestUser(),
 			wantErr:  false,
 		},
+		{
+			name: "two-factor account gets a challenge",
+			command: commands.LoginCommand{
+				Email:    "test@example.com",
+				Password: "Password123",
+			},
+			mockUser:   helpers.CreateTestUser(),
+			mfaEnabled: true,
+			wantErr:    false,
+		},
+		{
+			name: "unverified email when verification is required",
+			command: commands.LoginCommand{
+				Email:    "test@example.com",
+				Password: "Password123",
+			},
+			mockUser:        helpers.CreateTestUser(),
+			requireVerified: true,
+			wantErr:         true,
+			expectedErr:     "email address is not verified",
+		},
+		{
+			name: "unverified email is not revealed without the password",
+			command: commands.LoginCommand{
+				Email:    "test@example.com",
+				Password: "WrongPassword",
+			},
+			mockUser:        helpers.CreateTestUser(),
+			requireVerified: true,
+			wantErr:         true,
+			expectedErr:     "invalid email or password",
+		},
+		{
+			name: "deactivated account with the right password",
+			command: commands.LoginCommand{
+				Email:    "test@example.com",
+				Password: "Password123",
+			},
+			mockUser:    deactivated,
+			wantErr:     true,
+			expectedErr: "account is deactivated",
+		},
+		{
+			name: "deactivated account is not revealed without the password",
+			command: commands.LoginCommand{
+				Email:    "test@example.com",
+				Password: "WrongPassword",
+			},
+			mockUser:    deactivated,
+			wantErr:     true,
+			expectedErr: "invalid email or password",
+		},
 		{
 			name: "invalid email format",
 			command: commands.LoginCommand{
@@ -228,52 +427,355 @@ func TestAuthUseCaseImpl_Login(t *testing.T) {
 			if tt.command.Email != "invalid-email" {
 				mockRepo.EXPECT().GetByFilter(gomock.Any(), gomock.Any()).Return(tt.mockUser, tt.mockError)
 			}
+			mockSessionRepo := repositories.NewMockSessionRepository(ctrl)
+			mockRefreshRepo := repositories.NewMockRefreshTokenRepository(ctrl)
+			mockTOTPRepo := repositories.NewMockTOTPFactorRepository(ctrl)
+			var session *entities.Session
+			var stored *entities.RefreshToken
+			if !tt.wantErr {
+				if tt.mfaEnabled {
+					mockTOTPRepo.EXPECT().GetByUserID(gomock.Any(), gomock.Any()).Return(newConfirmedTOTPFactor(tt.mockUser.ID()), nil)
+				} else {
+					mockTOTPRepo.EXPECT().GetByUserID(gomock.Any(), gomock.Any()).Return(nil, domainrepositories.ErrTOTPFactorNotFound)
+				}
+			}
+			if !tt.wantErr && !tt.mfaEnabled {
+				mockSessionRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, s *entities.Session) error {
+						session = s
+						return nil
+					})
+				mockRefreshRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, token *entities.RefreshToken) error {
+						stored = token
+						return nil
+					})
+			}
 
 			// Setup mock services
 			mockJWT := &MockJWTService{ctrl: ctrl}
-			mockGoogle := &MockGoogleService{ctrl: ctrl}
+			mockOAuth := &MockOAuthService{ctrl: ctrl}
 
-			useCase := NewAuthUseCase(mockRepo, mockJWT, mockGoogle)
-			user, access, refresh, err := useCase.Login(context.Background(), tt.command)
+			options := AuthOptions{RefreshTokenTTL: time.Hour, RequireVerifiedEmail: tt.requireVerified}
+			useCase := NewAuthUseCase(mockRepo, mockSessionRepo, mockRefreshRepo, nil, nil, mockTOTPRepo, nil, nil, nil, nil, mockJWT, mockOAuth, nil, nil, options)
+			result, err := useCase.Login(context.Background(), tt.command)
 
 			if tt.wantErr {
 				require.Error(t, err)
 				assert.Contains(t, err.Error(), tt.expectedErr)
-				assert.Nil(t, user)
-				assert.Empty(t, access)
-				assert.Empty(t, refresh)
+				assert.Nil(t, result)
+			} else if tt.mfaEnabled {
+				// No session or tokens until the second factor is presented
+				require.NoError(t, err)
+				assert.True(t, result.MFARequired())
+				assert.Equal(t, "mock-mfa-token", result.MFAToken)
+				assert.Empty(t, result.AccessToken)
+				assert.Empty(t, result.RefreshToken)
 			} else {
 				require.NoError(t, err)
-				assert.NotNil(t, user)
-				assert.Equal(t, "mock-access-token", access)
-				assert.Equal(t, "mock-refresh-token", refresh)
+				assert.NotNil(t, result.User)
+				assert.False(t, result.MFARequired())
+				assert.Equal(t, "mock-access-token", result.AccessToken)
+				assert.Equal(t, "mock-refresh-token", result.RefreshToken)
+
+				// Each login records a session whose ID names the refresh token family
+				require.NotNil(t, session)
+				require.NotNil(t, stored)
+				assert.Equal(t, session.ID().String(), stored.FamilyID().String())
+				assert.Equal(t, "local", session.Provider())
+				assert.Equal(t, tt.command.Client.UserAgent, session.UserAgent())
+				assert.Equal(t, tt.command.Client.IPAddress, session.IPAddress())
+			}
+		})
+	}
+}
+
+func TestAuthUseCaseImpl_LoginAudit(t *testing.T) {
+	client := commands.ClientInfo{UserAgent: "test-agent", IPAddress: "203.0.113.7"}
+
+	tests := []struct {
+		name       string
+		password   string
+		userError  error
+		wantAction value_objects.AuditAction
+		wantTarget string
+		wantReason string
+	}{
+		{
+			name:       "successful login",
+			password:   "Password123",
+			wantAction: value_objects.AuditActionLogin,
+			wantTarget: entities.AuditTargetSession,
+		},
+		{
+			name:       "wrong password",
+			password:   "WrongPassword123",
+			wantAction: value_objects.AuditActionLoginFailed,
+			wantTarget: entities.AuditTargetUser,
+			wantReason: "wrong_password",
+		},
+		{
+			name:       "unknown email",
+			password:   "Password123",
+			userError:  domainrepositories.ErrUserNotFound,
+			wantAction: value_objects.AuditActionLoginFailed,
+			wantReason: "unknown_email",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			user := helpers.CreateTestUser()
+			mockRepo := repositories.NewMockUserRepository(ctrl)
+			if tt.userError != nil {
+				mockRepo.EXPECT().GetByFilter(gomock.Any(), gomock.Any()).Return(nil, tt.userError)
+			} else {
+				mockRepo.EXPECT().GetByFilter(gomock.Any(), gomock.Any()).Return(user, nil)
+			}
+
+			mockSessionRepo := repositories.NewMockSessionRepository(ctrl)
+			mockRefreshRepo := repositories.NewMockRefreshTokenRepository(ctrl)
+			mockTOTPRepo := repositories.NewMockTOTPFactorRepository(ctrl)
+			if tt.wantAction == value_objects.AuditActionLogin {
+				mockTOTPRepo.EXPECT().GetByUserID(gomock.Any(), gomock.Any()).Return(nil, domainrepositories.ErrTOTPFactorNotFound)
+				mockSessionRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+				mockRefreshRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+			}
+
+			var recorded *entities.AuditEvent
+			mockAuditRepo := repositories.NewMockAuditEventRepository(ctrl)
+			mockAuditRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(ctx context.Context, event *entities.AuditEvent) error {
+					recorded = event
+					return nil
+				})
+
+			useCase := NewAuthUseCase(mockRepo, mockSessionRepo, mockRefreshRepo, nil, nil, mockTOTPRepo, nil, nil, nil, mockAuditRepo, &MockJWTService{ctrl: ctrl}, &MockOAuthService{ctrl: ctrl}, nil, nil, AuthOptions{RefreshTokenTTL: time.Hour})
+			_, _ = useCase.Login(context.Background(), commands.LoginCommand{Email: "test@example.com", Password: tt.password, Client: client})
+
+			require.NotNil(t, recorded)
+			assert.Equal(t, tt.wantAction, recorded.Action())
+			assert.Equal(t, tt.wantTarget, recorded.TargetType())
+			assert.Equal(t, client.IPAddress, recorded.IPAddress())
+			assert.Equal(t, client.UserAgent, recorded.UserAgent())
+			if tt.wantReason != "" {
+				assert.Equal(t, tt.wantReason, recorded.Metadata()["reason"])
+				assert.Equal(t, "test@example.com", recorded.Metadata()["email"])
+			}
+
+			// Failed logins are anonymous; the account they were aimed at is the target
+			if tt.wantAction == value_objects.AuditActionLogin {
+				assert.Equal(t, user.ID().String(), recorded.ActorID().String())
+			} else {
+				assert.Nil(t, recorded.ActorID())
 			}
 		})
 	}
 }
 
+func TestAuthUseCaseImpl_LoginLockout(t *testing.T) {
+	const (
+		emailLock = "login:lock:email:test@example.com"
+		ipLock    = "login:lock:ip:203.0.113.7"
+	)
+	lockout := LoginLockoutOptions{
+		EmailThreshold: 3,
+		IPThreshold:    5,
+		BaseDelay:      30 * time.Second,
+		MaxDelay:       2 * time.Minute,
+		Window:         time.Hour,
+	}
+
+	newUseCase := func(t *testing.T, store *MockAttemptStore) AuthUseCase {
+		ctrl := gomock.NewController(t)
+		user := helpers.CreateTestUser()
+
+		mockRepo := repositories.NewMockUserRepository(ctrl)
+		mockRepo.EXPECT().GetByFilter(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, filter *domainrepositories.UserFilter) (*entities.User, error) {
+				if filter.Email.String() != user.Email().String() {
+					return nil, errors.New("user not found")
+				}
+				return user, nil
+			}).AnyTimes()
+		mockSessionRepo := repositories.NewMockSessionRepository(ctrl)
+		mockSessionRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+		mockRefreshRepo := repositories.NewMockRefreshTokenRepository(ctrl)
+		mockRefreshRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+		mockTOTPRepo := repositories.NewMockTOTPFactorRepository(ctrl)
+		mockTOTPRepo.EXPECT().GetByUserID(gomock.Any(), gomock.Any()).Return(nil, domainrepositories.ErrTOTPFactorNotFound).AnyTimes()
+
+		options := AuthOptions{RefreshTokenTTL: time.Hour, LoginLockout: lockout}
+		return NewAuthUseCase(mockRepo, mockSessionRepo, mockRefreshRepo, nil, nil, mockTOTPRepo, nil, nil, nil, nil, &MockJWTService{ctrl: ctrl}, &MockOAuthService{ctrl: ctrl}, store, nil, options)
+	}
+	login := func(useCase AuthUseCase, email string, password string, ip string) error {
+		_, err := useCase.Login(context.Background(), commands.LoginCommand{
+			Email:    email,
+			Password: password,
+			Client:   commands.ClientInfo{IPAddress: ip},
+		})
+		return err
+	}
+
+	t.Run("email is locked out with exponential backoff", func(t *testing.T) {
+		store := NewMockAttemptStore()
+		useCase := newUseCase(t, store)
+
+		for i := 0; i < 3; i++ {
+			err := login(useCase, "test@example.com", "WrongPassword", "203.0.113.7")
+			require.EqualError(t, err, "invalid email or password")
+		}
+		assert.Equal(t, 30*time.Second, store.Locks[emailLock])
+
+		// Even the right password is refused while locked, with the time to wait
+		err := login(useCase, "Test@Example.com", "Password123", "198.51.100.1")
+		var locked *LoginLockedError
+		require.ErrorAs(t, err, &locked)
+		assert.Equal(t, 30*time.Second, locked.RetryAfter)
+
+		// Every failure after a lockout doubles it, up to the maximum
+		for _, want := range []time.Duration{time.Minute, 2 * time.Minute, 2 * time.Minute} {
+			delete(store.Locks, emailLock)
+			require.EqualError(t, login(useCase, "test@example.com", "WrongPassword", "198.51.100.1"), "invalid email or password")
+			assert.Equal(t, want, store.Locks[emailLock])
+		}
+	})
+
+	t.Run("ip address is locked out across emails", func(t *testing.T) {
+		store := NewMockAttemptStore()
+		useCase := newUseCase(t, store)
+
+		// Unknown emails count too, so lockouts do not reveal which accounts exist
+		for _, email := range []string{"a@example.com", "b@example.com", "c@example.com", "d@example.com", "e@example.com"} {
+			require.EqualError(t, login(useCase, email, "Password123", "203.0.113.7"), "invalid email or password")
+		}
+		assert.Equal(t, 30*time.Second, store.Locks[ipLock])
+
+		var locked *LoginLockedError
+		require.ErrorAs(t, login(useCase, "test@example.com", "Password123", "203.0.113.7"), &locked)
+
+		// Other addresses are unaffected
+		require.NoError(t, login(useCase, "test@example.com", "Password123", "198.51.100.1"))
+	})
+
+	t.Run("successful login forgets failures of the email", func(t *testing.T) {
+		store := NewMockAttemptStore()
+		useCase := newUseCase(t, store)
+
+		for i := 0; i < 2; i++ {
+			require.Error(t, login(useCase, "test@example.com", "WrongPassword", "203.0.113.7"))
+		}
+		require.NoError(t, login(useCase, "test@example.com", "Password123", "203.0.113.7"))
+		for i := 0; i < 2; i++ {
+			require.Error(t, login(useCase, "test@example.com", "WrongPassword", "203.0.113.7"))
+		}
+
+		assert.Empty(t, store.Locks)
+		assert.Equal(t, int64(4), store.Counts["login:fail:ip:203.0.113.7"])
+	})
+}
+
 func TestAuthUseCaseImpl_Refresh(t *testing.T) {
+	familyID := value_objects.NewTokenID()
+	newStored := func(usedAt *time.Time, revokedAt *time.Time, expiresAt time.Time) *entities.RefreshToken {
+		return entities.NewRefreshTokenFromRepository(
+			value_objects.NewTokenID(),
+			familyID,
+			helpers.CreateTestUserID(),
+			"test-agent",
+			expiresAt,
+			usedAt,
+			revokedAt,
+			time.Now().Add(-time.Minute),
+		)
+	}
+	past := helpers.TimePtr(time.Now().Add(-time.Minute))
+	newSession := func(revokedAt *time.Time) *entities.Session {
+		return entities.NewSessionFromRepository(
+			familyID,
+			helpers.CreateTestUserID(),
+			"test-agent",
+			"203.0.113.7",
+			"local",
+			time.Now().Add(-time.Hour),
+			time.Now().Add(-time.Hour),
+			revokedAt,
+		)
+	}
+
 	tests := []struct {
 		name         string
-		accessToken  string
-		refreshToken string
-		mockError    error
+		claimsError  error
+		noTokenID    bool
+		stored       *entities.RefreshToken
+		storedErr    error
+		session      *entities.Session
+		markUsedErr  error
+		expectRevoke bool
 		wantErr      bool
 		expectedErr  string
 	}{
 		{
-			name:         "successful refresh",
-			accessToken:  "valid-access-token",
-			refreshToken: "valid-refresh-token",
-			wantErr:      false,
+			name:    "successful rotation",
+			stored:  newStored(nil, nil, time.Now().Add(time.Hour)),
+			session: newSession(nil),
+			wantErr: false,
+		},
+		{
+			name:        "revoked session",
+			stored:      newStored(nil, nil, time.Now().Add(time.Hour)),
+			session:     newSession(past),
+			wantErr:     true,
+			expectedErr: "invalid refresh token",
+		},
+		{
+			name:        "invalid refresh token",
+			claimsError: errors.New("invalid token"),
+			wantErr:     true,
+			expectedErr: "invalid refresh token",
+		},
+		{
+			name:        "legacy token without jti",
+			noTokenID:   true,
+			wantErr:     true,
+			expectedErr: "invalid refresh token",
+		},
+		{
+			name:        "unknown token",
+			storedErr:   domainrepositories.ErrRefreshTokenNotFound,
+			wantErr:     true,
+			expectedErr: "invalid refresh token",
+		},
+		{
+			name:         "reused token revokes family",
+			stored:       newStored(past, nil, time.Now().Add(time.Hour)),
+			expectRevoke: true,
+			wantErr:      true,
+			expectedErr:  "refresh token reuse detected",
 		},
 		{
-			name:         "invalid refresh token",
-			accessToken:  "valid-access-token",
-			refreshToken: "invalid-refresh-token",
-			mockError:    errors.New("invalid token"),
+			name:        "revoked token",
+			stored:      newStored(nil, past, time.Now().Add(time.Hour)),
+			wantErr:     true,
+			expectedErr: "invalid refresh token",
+		},
+		{
+			name:        "expired token",
+			stored:      newStored(nil, nil, time.Now().Add(-time.Second)),
+			wantErr:     true,
+			expectedErr: "invalid refresh token",
+		},
+		{
+			name:         "concurrent rotation revokes family",
+			stored:       newStored(nil, nil, time.Now().Add(time.Hour)),
+			session:      newSession(nil),
+			markUsedErr:  domainrepositories.ErrRefreshTokenUsed,
+			expectRevoke: true,
 			wantErr:      true,
-			expectedErr:  "invalid refresh token",
+			expectedErr:  "refresh token reuse detected",
 		},
 	}
 
@@ -283,20 +785,54 @@ func TestAuthUseCaseImpl_Refresh(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			defer ctrl.Finish()
 
-			// Setup mock repository
+			// Setup mock repositories
 			mockRepo := repositories.NewMockUserRepository(ctrl)
+			mockSessionRepo := repositories.NewMockSessionRepository(ctrl)
+			mockRefreshRepo := repositories.NewMockRefreshTokenRepository(ctrl)
+
+			if tt.claimsError == nil && !tt.noTokenID {
+				mockRefreshRepo.EXPECT().GetByID(gomock.Any(), gomock.Any()).Return(tt.stored, tt.storedErr)
+			}
+			if tt.expectRevoke {
+				mockRefreshRepo.EXPECT().RevokeFamily(gomock.Any(), familyID).Return(nil)
+				mockSessionRepo.EXPECT().Revoke(gomock.Any(), familyID).Return(nil)
+			}
+			if tt.session != nil {
+				mockRepo.EXPECT().GetByID(gomock.Any(), tt.stored.UserID()).Return(helpers.CreateTestUser(), nil)
+				mockSessionRepo.EXPECT().GetByID(gomock.Any(), familyID).Return(tt.session, nil)
+				if !tt.session.IsRevoked() {
+					mockRefreshRepo.EXPECT().MarkUsed(gomock.Any(), tt.stored).Return(tt.markUsedErr)
+				}
+			}
+			if !tt.wantErr {
+				mockSessionRepo.EXPECT().UpdateLastUsedAt(gomock.Any(), familyID, gomock.Any()).Return(nil)
+			}
+
+			var rotated *entities.RefreshToken
+			if !tt.wantErr {
+				mockRefreshRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, token *entities.RefreshToken) error {
+						rotated = token
+						return nil
+					})
+			}
 
 			// Setup mock services
 			mockJWT := &MockJWTService{ctrl: ctrl}
-			if tt.mockError != nil {
-				mockJWT.ValidateRefreshTokenFunc = func(token string) (*appjwt.Claims, error) {
-					return nil, tt.mockError
+			mockJWT.ValidateRefreshTokenFunc = func(token string) (*appjwt.Claims, error) {
+				if tt.claimsError != nil {
+					return nil, tt.claimsError
+				}
+				claims := &appjwt.Claims{UserID: "550e8400-e29b-41d4-a716-446655440000", Email: "test@example.com", Type: "refresh"}
+				if !tt.noTokenID {
+					claims.TokenID = value_objects.NewTokenID().String()
 				}
+				return claims, nil
 			}
-			mockGoogle := &MockGoogleService{ctrl: ctrl}
+			mockOAuth := &MockOAuthService{ctrl: ctrl}
 
-			useCase := NewAuthUseCase(mockRepo, mockJWT, mockGoogle)
-			newAccess, newRefresh, err := useCase.Refresh(context.Background(), tt.accessToken, tt.refreshToken)
+			useCase := NewAuthUseCase(mockRepo, mockSessionRepo, mockRefreshRepo, nil, nil, nil, nil, nil, nil, nil, mockJWT, mockOAuth, nil, nil, AuthOptions{RefreshTokenTTL: time.Hour})
+			newAccess, newRefresh, err := useCase.Refresh(context.Background(), "valid-access-token", "refresh-token")
 
 			if tt.wantErr {
 				require.Error(t, err)
@@ -307,36 +843,131 @@ func TestAuthUseCaseImpl_Refresh(t *testing.T) {
 				require.NoError(t, err)
 				assert.Equal(t, "mock-access-token", newAccess)
 				assert.Equal(t, "mock-refresh-token", newRefresh)
+
+				// The successor stays in the session's family and the old token is spent
+				require.NotNil(t, rotated)
+				assert.Equal(t, familyID.String(), rotated.FamilyID().String())
+				assert.Equal(t, "test-agent", rotated.Device())
+				assert.True(t, tt.stored.IsUsed())
 			}
 		})
 	}
 }
 
-func TestAuthUseCaseImpl_LoginWithGoogle(t *testing.T) {
+func TestAuthUseCaseImpl_Logout(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	stored, err := entities.NewRefreshToken(helpers.CreateTestUserID(), nil, "test-agent", time.Hour)
+	require.NoError(t, err)
+
+	mockRefreshRepo := repositories.NewMockRefreshTokenRepository(ctrl)
+	mockRefreshRepo.EXPECT().GetByID(gomock.Any(), gomock.Any()).Return(stored, nil)
+	mockRefreshRepo.EXPECT().RevokeFamily(gomock.Any(), stored.FamilyID()).Return(nil)
+	mockSessionRepo := repositories.NewMockSessionRepository(ctrl)
+	mockSessionRepo.EXPECT().Revoke(gomock.Any(), stored.FamilyID()).Return(nil)
+
+	mockJWT := &MockJWTService{ctrl: ctrl}
+	mockJWT.ValidateRefreshTokenFunc = func(token string) (*appjwt.Claims, error) {
+		return &appjwt.Claims{UserID: stored.UserID().String(), Email: "test@example.com", TokenID: stored.ID().String()}, nil
+	}
+
+	useCase := NewAuthUseCase(repositories.NewMockUserRepository(ctrl), mockSessionRepo, mockRefreshRepo, nil, nil, nil, nil, nil, nil, nil, mockJWT, &MockOAuthService{ctrl: ctrl}, nil, nil, AuthOptions{})
+	require.NoError(t, useCase.Logout(context.Background(), "refresh-token"))
+}
+
+func TestAuthUseCaseImpl_LogoutAll(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userID := helpers.CreateTestUserID()
+	mockRefreshRepo := repositories.NewMockRefreshTokenRepository(ctrl)
+	mockRefreshRepo.EXPECT().RevokeByUserID(gomock.Any(), userID).Return(nil)
+	mockSessionRepo := repositories.NewMockSessionRepository(ctrl)
+	mockSessionRepo.EXPECT().RevokeByUserID(gomock.Any(), userID).Return(nil)
+
+	useCase := NewAuthUseCase(repositories.NewMockUserRepository(ctrl), mockSessionRepo, mockRefreshRepo, nil, nil, nil, nil, nil, nil, nil, &MockJWTService{ctrl: ctrl}, &MockOAuthService{ctrl: ctrl}, nil, nil, AuthOptions{})
+	require.NoError(t, useCase.LogoutAll(context.Background(), userID.String()))
+
+	err := useCase.LogoutAll(context.Background(), "not-a-uuid")
+	require.Error(t, err)
+}
+
+func TestAuthUseCaseImpl_LoginWithProvider(t *testing.T) {
+	linkedUser := helpers.CreateTestGoogleUser()
+	passwordless := helpers.CreateTestGoogleUser()
+
 	tests := []struct {
-		name        string
-		code        string
-		mockUser    *entities.User
-		mockError   error
-		wantErr     bool
-		expectedErr string
+		name            string
+		code            string
+		emailVerified   bool
+		linkedTo        *entities.User
+		byEmail         *entities.User
+		byEmailLinked   bool // the email's account already has an identity at the provider
+		expectCreate    bool
+		subjectTakenNow bool
+		wantLogin       bool
+		wantLink        bool
+		wantErr         bool
+		expectedErr     string
 	}{
 		{
-			name:     "successful Google login - existing user",
-			code:     "valid-google-code",
-			mockUser: helpers.CreateTestGoogleUser(),
-			wantErr:  false,
+			name:          "linked account matched on the provider subject",
+			code:          "valid-code",
+			emailVerified: true,
+			linkedTo:      linkedUser,
+			wantLogin:     true,
 		},
 		{
-			name:      "successful Google login - new user",
-			code:      "valid-google-code",
-			mockError: errors.New("user not found"),
-			wantErr:   false,
+			name:          "new user signs up",
+			code:          "valid-code",
+			emailVerified: true,
+			expectCreate:  true,
+			wantLogin:     true,
+		},
+		{
+			name:            "provider account linked concurrently during sign up",
+			code:            "valid-code",
+			emailVerified:   true,
+			expectCreate:    true,
+			subjectTakenNow: true,
+			wantErr:         true,
+			expectedErr:     ErrExternalAccountInUse.Error(),
+		},
+		{
+			name:          "email of an unlinked local account requires the password",
+			code:          "valid-code",
+			emailVerified: true,
+			byEmail:       helpers.CreateTestUser(),
+			wantLink:      true,
+		},
+		{
+			name:          "email of an account linked to another account of the provider",
+			code:          "valid-code",
+			emailVerified: true,
+			byEmail:       helpers.CreateTestUser(),
+			byEmailLinked: true,
+			wantErr:       true,
+			expectedErr:   ErrExternalAccountMismatch.Error(),
+		},
+		{
+			name:          "email of an account without a password",
+			code:          "valid-code",
+			emailVerified: true,
+			byEmail:       passwordless,
+			wantErr:       true,
+			expectedErr:   ErrExternalAccountMismatch.Error(),
+		},
+		{
+			name:          "unverified provider email",
+			code:          "valid-code",
+			emailVerified: false,
+			wantErr:       true,
+			expectedErr:   ErrProviderEmailNotVerified.Error(),
 		},
 		{
-			name:        "invalid Google code",
-			code:        "invalid-google-code",
-			mockError:   errors.New("invalid code"),
+			name:        "invalid code",
+			code:        "invalid-code",
 			wantErr:     true,
 			expectedErr: "invalid code",
 		},
@@ -348,39 +979,1136 @@ func TestAuthUseCaseImpl_LoginWithGoogle(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			defer ctrl.Finish()
 
-			// Setup mock repository
+			// Setup mock repositories
 			mockRepo := repositories.NewMockUserRepository(ctrl)
-			if tt.code == "valid-google-code" {
-				mockRepo.EXPECT().GetByFilter(gomock.Any(), gomock.Any()).Return(tt.mockUser, tt.mockError)
-				if tt.mockError != nil {
-					// New user case - expect Create call
-					mockRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+			mockIdentityRepo := repositories.NewMockUserIdentityRepository(ctrl)
+			if tt.code == "valid-code" {
+				if tt.linkedTo != nil {
+					identity, err := entities.NewUserIdentity(tt.linkedTo.ID(), "keycloak", "subject-1", "google@example.com")
+					require.NoError(t, err)
+					mockIdentityRepo.EXPECT().GetByProviderSubject(gomock.Any(), "keycloak", "subject-1").Return(identity, nil)
+					mockRepo.EXPECT().GetByID(gomock.Any(), tt.linkedTo.ID()).Return(tt.linkedTo, nil)
+				} else {
+					mockIdentityRepo.EXPECT().GetByProviderSubject(gomock.Any(), "keycloak", "subject-1").Return(nil, domainrepositories.ErrUserIdentityNotFound)
+					if tt.emailVerified {
+						if tt.byEmail != nil {
+							mockRepo.EXPECT().GetByFilter(gomock.Any(), gomock.Any()).Return(tt.byEmail, nil)
+						} else {
+							mockRepo.EXPECT().GetByFilter(gomock.Any(), gomock.Any()).Return(nil, domainrepositories.ErrUserNotFound)
+						}
+					}
+				}
+			}
+			if tt.byEmail != nil && tt.byEmail.HasPassword() {
+				var identities []*entities.UserIdentity
+				if tt.byEmailLinked {
+					identity, err := entities.NewUserIdentity(tt.byEmail.ID(), "keycloak", "subject-2", "google@example.com")
+					require.NoError(t, err)
+					identities = append(identities, identity)
 				}
+				mockIdentityRepo.EXPECT().ListByUserID(gomock.Any(), tt.byEmail.ID()).Return(identities, nil)
+			}
+			if tt.expectCreate {
+				mockRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, user *entities.User) error {
+						assert.Equal(t, "keycloak", user.AuthProvider())
+						assert.False(t, user.HasPassword())
+						return nil
+					})
+				mockIdentityRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, identity *entities.UserIdentity) error {
+						assert.Equal(t, "keycloak", identity.Provider())
+						assert.Equal(t, "subject-1", identity.Subject())
+						if tt.subjectTakenNow {
+							return domainrepositories.ErrUserIdentityExists
+						}
+						return nil
+					})
 			}
 
 			// Setup mock services
 			mockJWT := &MockJWTService{ctrl: ctrl}
-			mockGoogle := &MockGoogleService{ctrl: ctrl}
-			if tt.mockError != nil && tt.code == "invalid-google-code" {
-				mockGoogle.ExchangeCodeForTokenFunc = func(ctx context.Context, code string) (*google.GoogleUserInfo, error) {
-					return nil, tt.mockError
+			mockOAuth := &MockOAuthService{ctrl: ctrl}
+			mockOAuth.ExchangeFunc = func(ctx context.Context, provider string, code string, state string) (*oauth.UserInfo, error) {
+				assert.Equal(t, "state-1", state)
+				if code == "invalid-code" {
+					return nil, errors.New("invalid code")
 				}
+				return &oauth.UserInfo{
+					Provider:      provider,
+					Subject:       "subject-1",
+					Email:         "google@example.com",
+					FirstName:     "Google",
+					LastName:      "User",
+					EmailVerified: tt.emailVerified,
+				}, nil
+			}
+
+			mockSessionRepo := repositories.NewMockSessionRepository(ctrl)
+			mockRefreshRepo := repositories.NewMockRefreshTokenRepository(ctrl)
+			mockTOTPRepo := repositories.NewMockTOTPFactorRepository(ctrl)
+			if tt.wantLogin {
+				mockTOTPRepo.EXPECT().GetByUserID(gomock.Any(), gomock.Any()).Return(nil, domainrepositories.ErrTOTPFactorNotFound)
+				mockSessionRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, session *entities.Session) error {
+						assert.Equal(t, "keycloak", session.Provider())
+						return nil
+					})
+				mockRefreshRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
 			}
 
-			useCase := NewAuthUseCase(mockRepo, mockJWT, mockGoogle)
-			user, access, refresh, err := useCase.LoginWithGoogle(context.Background(), tt.code)
+			useCase := NewAuthUseCase(mockRepo, mockSessionRepo, mockRefreshRepo, nil, nil, mockTOTPRepo, nil, mockIdentityRepo, nil, nil, mockJWT, mockOAuth, nil, nil, AuthOptions{RefreshTokenTTL: time.Hour})
+			result, err := useCase.LoginWithProvider(context.Background(), commands.OAuthLoginCommand{
+				Provider: "keycloak",
+				Code:     tt.code,
+				State:    "state-1",
+				Client:   commands.ClientInfo{UserAgent: "test-agent"},
+			})
 
 			if tt.wantErr {
 				require.Error(t, err)
 				assert.Contains(t, err.Error(), tt.expectedErr)
-				assert.Nil(t, user)
-				assert.Empty(t, access)
-				assert.Empty(t, refresh)
+				assert.Nil(t, result)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.NotNil(t, result.User)
+			if tt.wantLink {
+				// No identity is created before the password is confirmed
+				assert.True(t, result.LinkRequired())
+				assert.Equal(t, "mock-link-token", result.LinkToken)
+				assert.Equal(t, "keycloak", result.Provider)
+				assert.Empty(t, result.AccessToken)
 			} else {
-				require.NoError(t, err)
-				assert.NotNil(t, user)
-				assert.Equal(t, "mock-access-token", access)
-				assert.Equal(t, "mock-refresh-token", refresh)
+				assert.Equal(t, "mock-access-token", result.AccessToken)
+				assert.Equal(t, "mock-refresh-token", result.RefreshToken)
+			}
+		})
+	}
+}
+
+func TestAuthUseCaseImpl_ConfirmLink(t *testing.T) {
+	tests := []struct {
+		name        string
+		password    string
+		claimsError error
+		identityErr error
+		wantErr     bool
+		expectedErr string
+	}{
+		{
+			name:     "password confirms the link",
+			password: "Password123",
+			wantErr:  false,
+		},
+		{
+			name:        "wrong password",
+			password:    "WrongPassword123",
+			wantErr:     true,
+			expectedErr: "invalid password",
+		},
+		{
+			name:        "provider account linked elsewhere meanwhile",
+			password:    "Password123",
+			identityErr: domainrepositories.ErrUserIdentityExists,
+			wantErr:     true,
+			expectedErr: ErrExternalAccountInUse.Error(),
+		},
+		{
+			name:        "invalid link token",
+			password:    "Password123",
+			claimsError: errors.New("token expired"),
+			wantErr:     true,
+			expectedErr: "invalid or expired link token",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			user := helpers.CreateTestUser()
+
+			mockRepo := repositories.NewMockUserRepository(ctrl)
+			mockIdentityRepo := repositories.NewMockUserIdentityRepository(ctrl)
+			mockSessionRepo := repositories.NewMockSessionRepository(ctrl)
+			mockRefreshRepo := repositories.NewMockRefreshTokenRepository(ctrl)
+			mockTOTPRepo := repositories.NewMockTOTPFactorRepository(ctrl)
+
+			if tt.claimsError == nil {
+				mockRepo.EXPECT().GetByID(gomock.Any(), gomock.Any()).Return(user, nil)
+			}
+			if tt.claimsError == nil && tt.password == "Password123" {
+				mockIdentityRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, identity *entities.UserIdentity) error {
+						assert.Equal(t, user.ID().String(), identity.UserID().String())
+						assert.Equal(t, "google", identity.Provider())
+						assert.Equal(t, "google123", identity.Subject())
+						return tt.identityErr
+					})
+			}
+			if !tt.wantErr {
+				mockTOTPRepo.EXPECT().GetByUserID(gomock.Any(), gomock.Any()).Return(nil, domainrepositories.ErrTOTPFactorNotFound)
+				mockSessionRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, session *entities.Session) error {
+						assert.Equal(t, "google", session.Provider())
+						return nil
+					})
+				mockRefreshRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+			}
+
+			mockJWT := &MockJWTService{ctrl: ctrl}
+			if tt.claimsError != nil {
+				mockJWT.ValidateLinkTokenFunc = func(token string) (*appjwt.Claims, error) {
+					return nil, tt.claimsError
+				}
+			}
+
+			useCase := NewAuthUseCase(mockRepo, mockSessionRepo, mockRefreshRepo, nil, nil, mockTOTPRepo, nil, mockIdentityRepo, nil, nil, mockJWT, &MockOAuthService{ctrl: ctrl}, nil, nil, AuthOptions{RefreshTokenTTL: time.Hour})
+			result, err := useCase.ConfirmLink(context.Background(), commands.ConfirmLinkCommand{
+				LinkToken: "link-token",
+				Password:  tt.password,
+				Client:    commands.ClientInfo{UserAgent: "test-agent"},
+			})
+
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedErr)
+				assert.Nil(t, result)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, "mock-access-token", result.AccessToken)
+			}
+		})
+	}
+}
+
+func TestAuthUseCaseImpl_ConfirmLinkLockout(t *testing.T) {
+	const emailLock = "login:lock:email:test@example.com"
+	lockout := LoginLockoutOptions{
+		EmailThreshold: 3,
+		IPThreshold:    20,
+		BaseDelay:      30 * time.Second,
+		MaxDelay:       2 * time.Minute,
+		Window:         time.Hour,
+	}
+
+	newUseCase := func(t *testing.T, store *MockAttemptStore) AuthUseCase {
+		ctrl := gomock.NewController(t)
+		user := helpers.CreateTestUser()
+
+		mockRepo := repositories.NewMockUserRepository(ctrl)
+		mockRepo.EXPECT().GetByID(gomock.Any(), gomock.Any()).Return(user, nil).AnyTimes()
+		mockIdentityRepo := repositories.NewMockUserIdentityRepository(ctrl)
+		mockIdentityRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+		mockSessionRepo := repositories.NewMockSessionRepository(ctrl)
+		mockSessionRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+		mockRefreshRepo := repositories.NewMockRefreshTokenRepository(ctrl)
+		mockRefreshRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+		mockTOTPRepo := repositories.NewMockTOTPFactorRepository(ctrl)
+		mockTOTPRepo.EXPECT().GetByUserID(gomock.Any(), gomock.Any()).Return(nil, domainrepositories.ErrTOTPFactorNotFound).AnyTimes()
+
+		options := AuthOptions{RefreshTokenTTL: time.Hour, LoginLockout: lockout}
+		return NewAuthUseCase(mockRepo, mockSessionRepo, mockRefreshRepo, nil, nil, mockTOTPRepo, nil, mockIdentityRepo, nil, nil, &MockJWTService{ctrl: ctrl}, &MockOAuthService{ctrl: ctrl}, store, nil, options)
+	}
+	confirm := func(useCase AuthUseCase, password string) error {
+		_, err := useCase.ConfirmLink(context.Background(), commands.ConfirmLinkCommand{
+			LinkToken: "link-token",
+			Password:  password,
+			Client:    commands.ClientInfo{IPAddress: "203.0.113.7"},
+		})
+		return err
+	}
+
+	tests := []struct {
+		name          string
+		failures      int
+		password      string
+		wantLocked    bool
+		expectedCount int64
+	}{
+		{
+			name:       "wrong passwords lock the account like failed logins",
+			failures:   3,
+			password:   "Password123",
+			wantLocked: true,
+		},
+		{
+			name:          "confirmed link forgets failures of the email",
+			failures:      2,
+			password:      "Password123",
+			expectedCount: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := NewMockAttemptStore()
+			useCase := newUseCase(t, store)
+
+			for i := 0; i < tt.failures; i++ {
+				require.EqualError(t, confirm(useCase, "WrongPassword"), "invalid password")
+			}
+
+			err := confirm(useCase, tt.password)
+			if tt.wantLocked {
+				// Even the right password is refused while locked
+				var locked *LoginLockedError
+				require.ErrorAs(t, err, &locked)
+				assert.Contains(t, store.Locks, emailLock)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.expectedCount, store.Counts["login:fail:email:test@example.com"])
+			}
+		})
+	}
+}
+
+func TestAuthUseCaseImpl_VerifyMFA(t *testing.T) {
+	user := helpers.CreateTestUser()
+	factor := newConfirmedTOTPFactor(user.ID())
+	currentCode, err := totp.CodeAt(factor.Secret(), totp.Step(time.Now()))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name           string
+		code           string
+		claimsError    error
+		useStepErr     error
+		expectRecovery bool
+		recoveryErr    error
+		wantErr        bool
+		expectedErr    string
+	}{
+		{
+			name:    "valid TOTP code",
+			code:    currentCode,
+			wantErr: false,
+		},
+		{
+			name:           "valid recovery code",
+			code:           "abcd-efgh",
+			expectRecovery: true,
+			wantErr:        false,
+		},
+		{
+			name:        "replayed TOTP code",
+			code:        currentCode,
+			useStepErr:  domainrepositories.ErrTOTPCodeReplayed,
+			wantErr:     true,
+			expectedErr: "invalid two-factor code",
+		},
+		{
+			name:           "unknown recovery code",
+			code:           "zzzz-zzzz",
+			expectRecovery: true,
+			recoveryErr:    domainrepositories.ErrRecoveryCodeNotFound,
+			wantErr:        true,
+			expectedErr:    "invalid two-factor code",
+		},
+		{
+			name:        "expired challenge",
+			code:        currentCode,
+			claimsError: errors.New("token is expired"),
+			wantErr:     true,
+			expectedErr: "invalid or expired mfa token",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup mock controller
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			// Setup mock repositories
+			mockRepo := repositories.NewMockUserRepository(ctrl)
+			mockSessionRepo := repositories.NewMockSessionRepository(ctrl)
+			mockRefreshRepo := repositories.NewMockRefreshTokenRepository(ctrl)
+			mockTOTPRepo := repositories.NewMockTOTPFactorRepository(ctrl)
+			mockRecoveryRepo := repositories.NewMockRecoveryCodeRepository(ctrl)
+
+			if tt.claimsError == nil {
+				mockRepo.EXPECT().GetByID(gomock.Any(), gomock.Any()).Return(user, nil)
+				mockTOTPRepo.EXPECT().GetByUserID(gomock.Any(), gomock.Any()).Return(newConfirmedTOTPFactor(user.ID()), nil)
+				if tt.expectRecovery {
+					recoveryCode, _ := value_objects.NewRecoveryCodeFromString(tt.code)
+					mockRecoveryRepo.EXPECT().Use(gomock.Any(), user.ID(), recoveryCode.Hash()).Return(tt.recoveryErr)
+				} else {
+					mockTOTPRepo.EXPECT().UseStep(gomock.Any(), gomock.Any()).Return(tt.useStepErr)
+				}
+			}
+
+			var session *entities.Session
+			if !tt.wantErr {
+				mockSessionRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, s *entities.Session) error {
+						session = s
+						return nil
+					})
+				mockRefreshRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+			}
+
+			// Setup mock services
+			mockJWT := &MockJWTService{ctrl: ctrl}
+			mockJWT.ValidateMFATokenFunc = func(token string) (*appjwt.Claims, error) {
+				if tt.claimsError != nil {
+					return nil, tt.claimsError
+				}
+				return &appjwt.Claims{UserID: user.ID().String(), Email: user.Email().String(), Type: "mfa", Provider: "google", TokenID: "challenge-1"}, nil
+			}
+
+			store := NewMockAttemptStore()
+			useCase := NewAuthUseCase(mockRepo, mockSessionRepo, mockRefreshRepo, nil, nil, mockTOTPRepo, mockRecoveryRepo, nil, nil, nil, mockJWT, &MockOAuthService{ctrl: ctrl}, store, nil, AuthOptions{RefreshTokenTTL: time.Hour})
+			result, err := useCase.VerifyMFA(context.Background(), commands.VerifyMFACommand{MFAToken: "mfa-token", Code: tt.code})
+
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedErr)
+				assert.Nil(t, result)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, "mock-access-token", result.AccessToken)
+				assert.Equal(t, "mock-refresh-token", result.RefreshToken)
+
+				// The session keeps the provider of the first login step
+				require.NotNil(t, session)
+				assert.Equal(t, "google", session.Provider())
+
+				// The challenge is spent, so it cannot log in a second time
+				assert.Contains(t, store.Locks, "login:mfa:lock:challenge-1")
+				_, err = useCase.VerifyMFA(context.Background(), commands.VerifyMFACommand{MFAToken: "mfa-token", Code: tt.code})
+				assert.ErrorIs(t, err, errInvalidMFAToken)
+			}
+		})
+	}
+}
+
+func TestAuthUseCaseImpl_VerifyMFALockout(t *testing.T) {
+	const (
+		challengeLock = "login:mfa:lock:challenge-1"
+		emailLock     = "login:lock:email:test@example.com"
+	)
+	lockout := LoginLockoutOptions{
+		EmailThreshold: 3,
+		IPThreshold:    20,
+		BaseDelay:      30 * time.Second,
+		MaxDelay:       2 * time.Minute,
+		Window:         time.Hour,
+	}
+
+	newUseCase := func(t *testing.T, store *MockAttemptStore, tokenID string) AuthUseCase {
+		ctrl := gomock.NewController(t)
+		user := helpers.CreateTestUser()
+
+		mockRepo := repositories.NewMockUserRepository(ctrl)
+		mockRepo.EXPECT().GetByID(gomock.Any(), gomock.Any()).Return(user, nil).AnyTimes()
+		mockTOTPRepo := repositories.NewMockTOTPFactorRepository(ctrl)
+		mockTOTPRepo.EXPECT().GetByUserID(gomock.Any(), gomock.Any()).Return(newConfirmedTOTPFactor(user.ID()), nil).AnyTimes()
+		mockRecoveryRepo := repositories.NewMockRecoveryCodeRepository(ctrl)
+		mockRecoveryRepo.EXPECT().Use(gomock.Any(), gomock.Any(), gomock.Any()).Return(domainrepositories.ErrRecoveryCodeNotFound).AnyTimes()
+
+		mockJWT := &MockJWTService{ctrl: ctrl}
+		mockJWT.ValidateMFATokenFunc = func(token string) (*appjwt.Claims, error) {
+			return &appjwt.Claims{UserID: user.ID().String(), Email: user.Email().String(), Type: "mfa", TokenID: tokenID}, nil
+		}
+
+		options := AuthOptions{RefreshTokenTTL: time.Hour, LoginLockout: lockout}
+		return NewAuthUseCase(mockRepo, nil, nil, nil, nil, mockTOTPRepo, mockRecoveryRepo, nil, nil, nil, mockJWT, &MockOAuthService{ctrl: ctrl}, store, nil, options)
+	}
+	verify := func(useCase AuthUseCase) error {
+		_, err := useCase.VerifyMFA(context.Background(), commands.VerifyMFACommand{
+			MFAToken: "mfa-token",
+			Code:     "zzzz-zzzz",
+			Client:   commands.ClientInfo{IPAddress: "203.0.113.7"},
+		})
+		return err
+	}
+
+	tests := []struct {
+		name          string
+		tokenID       string
+		lockout       bool
+		failures      int
+		expectedErr   string
+		expectedLocks []string
+	}{
+		{
+			name:          "wrong codes lock the account like wrong passwords",
+			tokenID:       "challenge-1",
+			failures:      3,
+			expectedErr:   "uc.loginThrottle.check",
+			expectedLocks: []string{emailLock},
+		},
+		{
+			name:          "challenge is discarded after too many wrong codes",
+			tokenID:       "challenge-1",
+			lockout:       true,
+			failures:      maxMFAChallengeFailures,
+			expectedErr:   "invalid or expired mfa token",
+			expectedLocks: []string{challengeLock},
+		},
+		{
+			name:        "challenge without an ID is refused",
+			tokenID:     "",
+			expectedErr: "invalid or expired mfa token",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := NewMockAttemptStore()
+			useCase := newUseCase(t, store, tt.tokenID)
+
+			for i := 0; i < tt.failures; i++ {
+				require.EqualError(t, verify(useCase), "invalid two-factor code")
+				if tt.lockout {
+					// Keep the account unlocked so only the challenge counter is exercised
+					delete(store.Locks, emailLock)
+				}
+			}
+
+			err := verify(useCase)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.expectedErr)
+			for _, key := range tt.expectedLocks {
+				assert.Contains(t, store.Locks, key)
+			}
+		})
+	}
+}
+
+func TestAuthUseCaseImpl_VerifyMFAWithoutAttemptStore(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	user := helpers.CreateTestUser()
+	mockJWT := &MockJWTService{ctrl: ctrl}
+	mockJWT.ValidateMFATokenFunc = func(token string) (*appjwt.Claims, error) {
+		return &appjwt.Claims{UserID: user.ID().String(), Email: user.Email().String(), Type: "mfa", TokenID: "challenge-1"}, nil
+	}
+
+	// Without a store the challenge could be replayed, so it is refused before the code is looked at
+	useCase := NewAuthUseCase(repositories.NewMockUserRepository(ctrl), nil, nil, nil, nil, nil, nil, nil, nil, nil, mockJWT, &MockOAuthService{ctrl: ctrl}, nil, nil, AuthOptions{RefreshTokenTTL: time.Hour})
+	result, err := useCase.VerifyMFA(context.Background(), commands.VerifyMFACommand{MFAToken: "mfa-token", Code: "123456"})
+
+	require.Error(t, err)
+	assert.ErrorIs(t, err, errChallengeUntracked)
+	assert.Nil(t, result)
+}
+
+func TestAuthUseCaseImpl_LoginDeletedAccount(t *testing.T) {
+	tests := []struct {
+		name        string
+		password    string
+		gracePeriod time.Duration
+		wantRestore bool
+		expectedErr string
+	}{
+		{
+			name:        "within the grace period gets a restore token",
+			password:    "Password123",
+			gracePeriod: 30 * 24 * time.Hour,
+			wantRestore: true,
+		},
+		{
+			name:        "wrong password",
+			password:    "WrongPassword",
+			gracePeriod: 30 * 24 * time.Hour,
+			expectedErr: "invalid email or password",
+		},
+		{
+			name:        "after the grace period",
+			password:    "Password123",
+			gracePeriod: 0,
+			expectedErr: "user.CanLogin",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			user := helpers.CreateTestUser()
+			require.NoError(t, user.SoftDelete())
+
+			// No session is started for a deleted account
+			mockRepo := repositories.NewMockUserRepository(ctrl)
+			mockRepo.EXPECT().GetByFilter(gomock.Any(), gomock.Any()).Return(user, nil)
+
+			options := AuthOptions{RefreshTokenTTL: time.Hour, DeletionGracePeriod: tt.gracePeriod}
+			useCase := NewAuthUseCase(mockRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, &MockJWTService{ctrl: ctrl}, &MockOAuthService{ctrl: ctrl}, nil, nil, options)
+			result, err := useCase.Login(context.Background(), commands.LoginCommand{Email: "test@example.com", Password: tt.password})
+
+			if !tt.wantRestore {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedErr)
+				assert.Nil(t, result)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.True(t, result.RestoreRequired())
+			assert.Equal(t, "mock-restore-token", result.RestoreToken)
+			assert.Empty(t, result.AccessToken)
+			require.NotNil(t, result.RestoreBefore)
+			assert.Equal(t, user.DeletedAt().Add(tt.gracePeriod), *result.RestoreBefore)
+		})
+	}
+}
+
+func TestAuthUseCaseImpl_RestoreAccount(t *testing.T) {
+	gracePeriod := 30 * 24 * time.Hour
+	client := commands.ClientInfo{UserAgent: "test-agent", IPAddress: "203.0.113.7"}
+
+	tests := []struct {
+		name        string
+		deleted     bool
+		claimsError error
+		restoreErr  error
+		wantErr     bool
+		expectedErr string
+	}{
+		{
+			name:    "restores and logs in",
+			deleted: true,
+			wantErr: false,
+		},
+		{
+			name:        "expired restore token",
+			deleted:     true,
+			claimsError: errors.New("token is expired"),
+			wantErr:     true,
+			expectedErr: "invalid or expired restore token",
+		},
+		{
+			name:        "already restored",
+			deleted:     false,
+			wantErr:     true,
+			expectedErr: "this account can no longer be restored",
+		},
+		{
+			name:        "purged or anonymized meanwhile",
+			deleted:     true,
+			restoreErr:  domainrepositories.ErrUserNotFound,
+			wantErr:     true,
+			expectedErr: "this account can no longer be restored",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			user := helpers.CreateTestUser()
+			if tt.deleted {
+				require.NoError(t, user.SoftDelete())
+			}
+
+			mockRepo := repositories.NewMockUserRepository(ctrl)
+			mockRetentionRepo := repositories.NewMockRetentionRepository(ctrl)
+			mockSessionRepo := repositories.NewMockSessionRepository(ctrl)
+			mockRefreshRepo := repositories.NewMockRefreshTokenRepository(ctrl)
+			mockTOTPRepo := repositories.NewMockTOTPFactorRepository(ctrl)
+
+			if tt.claimsError == nil {
+				mockRepo.EXPECT().GetByID(gomock.Any(), gomock.Any()).Return(user, nil)
+			}
+			if tt.claimsError == nil && tt.deleted {
+				deletedAt := *user.DeletedAt()
+				mockRetentionRepo.EXPECT().RestoreUser(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, restoration *domainrepositories.AccountRestoration) error {
+						assert.Equal(t, user.ID().String(), restoration.UserID.String())
+						assert.Equal(t, deletedAt, restoration.UserDeletedAt)
+						assert.Equal(t, client.IPAddress, restoration.IPAddress)
+						return tt.restoreErr
+					})
+			}
+			if !tt.wantErr {
+				mockTOTPRepo.EXPECT().GetByUserID(gomock.Any(), gomock.Any()).Return(nil, domainrepositories.ErrTOTPFactorNotFound)
+				mockSessionRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+				mockRefreshRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+			}
+
+			mockJWT := &MockJWTService{ctrl: ctrl}
+			mockJWT.ValidateRestoreTokenFunc = func(token string) (*appjwt.Claims, error) {
+				if tt.claimsError != nil {
+					return nil, tt.claimsError
+				}
+				return &appjwt.Claims{UserID: user.ID().String(), Email: user.Email().String(), Type: "restore", Provider: "local"}, nil
+			}
+
+			options := AuthOptions{RefreshTokenTTL: time.Hour, DeletionGracePeriod: gracePeriod}
+			useCase := NewAuthUseCase(mockRepo, mockSessionRepo, mockRefreshRepo, nil, nil, mockTOTPRepo, nil, nil, mockRetentionRepo, nil, mockJWT, &MockOAuthService{ctrl: ctrl}, nil, nil, options)
+			result, err := useCase.RestoreAccount(context.Background(), commands.RestoreAccountCommand{RestoreToken: "restore-token", Client: client})
+
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedErr)
+				assert.Nil(t, result)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, "mock-access-token", result.AccessToken)
+				assert.Nil(t, result.User.DeletedAt())
+				assert.True(t, result.User.IsActive())
+			}
+		})
+	}
+}
+
+func TestAuthUseCaseImpl_ForgotPassword(t *testing.T) {
+	options := AuthOptions{
+		PasswordResetTTL: time.Hour,
+		PasswordResetURL: "http://localhost:3000/reset-password",
+	}
+
+	deactivatedUser := helpers.CreateTestUser()
+	require.NoError(t, deactivatedUser.Deactivate())
+
+	tests := []struct {
+		name        string
+		email       string
+		mockUser    *entities.User
+		mockError   error
+		tokenError  error
+		mailError   error
+		sentBefore  int64
+		wantMail    bool
+		wantErr     bool
+		expectedErr string
+	}{
+		{
+			name:     "sends reset link to existing user",
+			email:    "test@example.com",
+			mockUser: helpers.CreateTestUser(),
+			wantMail: true,
+			wantErr:  false,
+		},
+		{
+			name:      "unknown email succeeds silently",
+			email:     "notfound@example.com",
+			mockError: errors.New("user not found"),
+			wantMail:  false,
+			wantErr:   false,
+		},
+		{
+			name:     "deactivated user succeeds silently",
+			email:    "test@example.com",
+			mockUser: deactivatedUser,
+			wantMail: false,
+			wantErr:  false,
+		},
+		{
+			name:       "address over its email limit succeeds silently",
+			email:      "test@example.com",
+			sentBefore: maxAccountEmails,
+			wantMail:   false,
+			wantErr:    false,
+		},
+		{
+			name:        "invalid email format",
+			email:       "invalid-email",
+			wantErr:     true,
+			expectedErr: "invalid email format",
+		},
+		{
+			name:      "mail delivery failure answers like an unknown email",
+			email:     "test@example.com",
+			mockUser:  helpers.CreateTestUser(),
+			mailError: errors.New("smtp down"),
+			wantMail:  false,
+			wantErr:   false,
+		},
+		{
+			name:       "token save failure answers like an unknown email",
+			email:      "test@example.com",
+			mockUser:   helpers.CreateTestUser(),
+			tokenError: errors.New("connection refused"),
+			wantMail:   false,
+			wantErr:    false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := repositories.NewMockUserRepository(ctrl)
+			mockTokenRepo := repositories.NewMockPasswordResetTokenRepository(ctrl)
+			mockMail := &MockMailSender{}
+			if tt.mailError != nil {
+				mockMail.SendFunc = func(ctx context.Context, message mail.Message) error {
+					return tt.mailError
+				}
+			}
+
+			store := NewMockAttemptStore()
+			store.Counts["mail:reset:"+tt.email] = tt.sentBefore
+
+			var stored *entities.PasswordResetToken
+			if tt.email != "invalid-email" && tt.sentBefore < maxAccountEmails {
+				mockRepo.EXPECT().GetByFilter(gomock.Any(), gomock.Any()).Return(tt.mockUser, tt.mockError)
+			}
+			if tt.mockUser != nil && tt.mockUser.IsActive() {
+				mockTokenRepo.EXPECT().InvalidateByUserID(gomock.Any(), tt.mockUser.ID()).Return(nil)
+				mockTokenRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, token *entities.PasswordResetToken) error {
+						stored = token
+						return tt.tokenError
+					})
+			}
+
+			useCase := NewAuthUseCase(mockRepo, nil, nil, mockTokenRepo, nil, nil, nil, nil, nil, nil, &MockJWTService{ctrl: ctrl}, &MockOAuthService{ctrl: ctrl}, store, mockMail, options).(*AuthUseCaseImpl)
+			err := useCase.ForgotPassword(context.Background(), commands.ForgotPasswordCommand{Email: tt.email})
+			// The account is looked up and mailed after the call returns
+			useCase.background.Wait()
+
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+			if !tt.wantMail {
+				assert.Empty(t, mockMail.Sent)
+				return
+			}
+
+			require.Len(t, mockMail.Sent, 1)
+			assert.Equal(t, "test@example.com", mockMail.Sent[0].To)
+
+			// The emailed token must hash to the stored one, and the raw token is never stored
+			require.NotNil(t, stored)
+			body := mockMail.Sent[0].Body
+			start := strings.Index(body, "token=")
+			require.NotEqual(t, -1, start)
+			raw := strings.Fields(body[start+len("token="):])[0]
+			secret, err := value_objects.NewSecretTokenFromString(raw)
+			require.NoError(t, err)
+			assert.Equal(t, stored.TokenHash(), secret.Hash())
+			assert.NotContains(t, stored.TokenHash(), raw)
+			assert.WithinDuration(t, time.Now().Add(time.Hour), stored.ExpiresAt(), time.Minute)
+		})
+	}
+}
+
+func TestAuthUseCaseImpl_ResetPassword(t *testing.T) {
+	secret, err := value_objects.NewSecretTokenFromString("valid-reset-token")
+	require.NoError(t, err)
+
+	newToken := func(expiresAt time.Time, usedAt *time.Time) *entities.PasswordResetToken {
+		return entities.NewPasswordResetTokenFromRepository(
+			value_objects.NewTokenID(),
+			helpers.CreateTestUserID(),
+			secret.Hash(),
+			expiresAt,
+			usedAt,
+			time.Now().Add(-time.Minute),
+		)
+	}
+
+	tests := []struct {
+		name         string
+		command      commands.ResetPasswordCommand
+		mockToken    *entities.PasswordResetToken
+		mockTokenErr error
+		mockUser     *entities.User
+		markUsedErr  error
+		expectUpdate bool
+		wantErr      bool
+		expectedErr  string
+	}{
+		{
+			name:         "successful reset",
+			command:      commands.ResetPasswordCommand{Token: "valid-reset-token", NewPassword: "NewPassword123"},
+			mockToken:    newToken(time.Now().Add(time.Hour), nil),
+			mockUser:     helpers.CreateTestUser(),
+			expectUpdate: true,
+			wantErr:      false,
+		},
+		{
+			name:         "unknown token",
+			command:      commands.ResetPasswordCommand{Token: "valid-reset-token", NewPassword: "NewPassword123"},
+			mockTokenErr: domainrepositories.ErrPasswordResetTokenNotFound,
+			wantErr:      true,
+			expectedErr:  "invalid or expired reset token",
+		},
+		{
+			name:        "expired token",
+			command:     commands.ResetPasswordCommand{Token: "valid-reset-token", NewPassword: "NewPassword123"},
+			mockToken:   newToken(time.Now().Add(-time.Minute), nil),
+			wantErr:     true,
+			expectedErr: "invalid or expired reset token",
+		},
+		{
+			name:        "already used token",
+			command:     commands.ResetPasswordCommand{Token: "valid-reset-token", NewPassword: "NewPassword123"},
+			mockToken:   newToken(time.Now().Add(time.Hour), helpers.TimePtr(time.Now().Add(-time.Minute))),
+			wantErr:     true,
+			expectedErr: "invalid or expired reset token",
+		},
+		{
+			name:        "weak password keeps token usable",
+			command:     commands.ResetPasswordCommand{Token: "valid-reset-token", NewPassword: "weak"},
+			mockToken:   newToken(time.Now().Add(time.Hour), nil),
+			mockUser:    helpers.CreateTestUser(),
+			wantErr:     true,
+			expectedErr: "password must be at least 8 characters",
+		},
+		{
+			name:        "token consumed concurrently",
+			command:     commands.ResetPasswordCommand{Token: "valid-reset-token", NewPassword: "NewPassword123"},
+			mockToken:   newToken(time.Now().Add(time.Hour), nil),
+			mockUser:    helpers.CreateTestUser(),
+			markUsedErr: domainrepositories.ErrPasswordResetTokenUsed,
+			wantErr:     true,
+			expectedErr: "invalid or expired reset token",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := repositories.NewMockUserRepository(ctrl)
+			mockTokenRepo := repositories.NewMockPasswordResetTokenRepository(ctrl)
+			mockSessionRepo := repositories.NewMockSessionRepository(ctrl)
+			mockRefreshRepo := repositories.NewMockRefreshTokenRepository(ctrl)
+
+			mockTokenRepo.EXPECT().GetByTokenHash(gomock.Any(), secret.Hash()).Return(tt.mockToken, tt.mockTokenErr)
+			if tt.mockUser != nil {
+				mockRepo.EXPECT().GetByID(gomock.Any(), gomock.Any()).Return(tt.mockUser, nil)
+				if tt.command.NewPassword != "weak" {
+					mockTokenRepo.EXPECT().MarkUsed(gomock.Any(), tt.mockToken).Return(tt.markUsedErr)
+				}
+			}
+			if tt.expectUpdate {
+				mockRepo.EXPECT().Update(gomock.Any(), tt.mockUser).Return(nil)
+				mockTokenRepo.EXPECT().InvalidateByUserID(gomock.Any(), tt.mockUser.ID()).Return(nil)
+				mockRefreshRepo.EXPECT().RevokeByUserID(gomock.Any(), tt.mockUser.ID()).Return(nil)
+				mockSessionRepo.EXPECT().RevokeByUserID(gomock.Any(), tt.mockUser.ID()).Return(nil)
+			}
+
+			useCase := NewAuthUseCase(mockRepo, mockSessionRepo, mockRefreshRepo, mockTokenRepo, nil, nil, nil, nil, nil, nil, &MockJWTService{ctrl: ctrl}, &MockOAuthService{ctrl: ctrl}, nil, &MockMailSender{}, AuthOptions{})
+			err := useCase.ResetPassword(context.Background(), tt.command)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.NoError(t, tt.mockUser.VerifyPassword("NewPassword123"))
+			assert.NotNil(t, tt.mockToken.UsedAt())
+		})
+	}
+}
+
+func TestAuthUseCaseImpl_VerifyEmail(t *testing.T) {
+	secret, err := value_objects.NewSecretTokenFromString("valid-verification-token")
+	require.NoError(t, err)
+
+	newToken := func(expiresAt time.Time, usedAt *time.Time) *entities.EmailVerificationToken {
+		return entities.NewEmailVerificationTokenFromRepository(
+			value_objects.NewTokenID(),
+			helpers.CreateTestUserID(),
+			secret.Hash(),
+			expiresAt,
+			usedAt,
+			time.Now().Add(-time.Minute),
+		)
+	}
+
+	tests := []struct {
+		name         string
+		mockToken    *entities.EmailVerificationToken
+		mockTokenErr error
+		mockUser     *entities.User
+		markUsedErr  error
+		wantErr      bool
+		expectedErr  string
+	}{
+		{
+			name:      "successful verification",
+			mockToken: newToken(time.Now().Add(time.Hour), nil),
+			mockUser:  helpers.CreateTestUser(),
+			wantErr:   false,
+		},
+		{
+			name:         "unknown token",
+			mockTokenErr: domainrepositories.ErrEmailVerificationTokenNotFound,
+			wantErr:      true,
+			expectedErr:  "invalid or expired verification token",
+		},
+		{
+			name:        "expired token",
+			mockToken:   newToken(time.Now().Add(-time.Minute), nil),
+			wantErr:     true,
+			expectedErr: "invalid or expired verification token",
+		},
+		{
+			name:        "already used token",
+			mockToken:   newToken(time.Now().Add(time.Hour), helpers.TimePtr(time.Now().Add(-time.Minute))),
+			wantErr:     true,
+			expectedErr: "invalid or expired verification token",
+		},
+		{
+			name:        "token consumed concurrently",
+			mockToken:   newToken(time.Now().Add(time.Hour), nil),
+			mockUser:    helpers.CreateTestUser(),
+			markUsedErr: domainrepositories.ErrEmailVerificationTokenUsed,
+			wantErr:     true,
+			expectedErr: "invalid or expired verification token",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := repositories.NewMockUserRepository(ctrl)
+			mockVerificationRepo := repositories.NewMockEmailVerificationTokenRepository(ctrl)
+
+			mockVerificationRepo.EXPECT().GetByTokenHash(gomock.Any(), secret.Hash()).Return(tt.mockToken, tt.mockTokenErr)
+			if tt.mockUser != nil {
+				mockRepo.EXPECT().GetByID(gomock.Any(), gomock.Any()).Return(tt.mockUser, nil)
+				mockVerificationRepo.EXPECT().MarkUsed(gomock.Any(), tt.mockToken).Return(tt.markUsedErr)
+				if tt.markUsedErr == nil {
+					mockRepo.EXPECT().Update(gomock.Any(), tt.mockUser).Return(nil)
+					mockVerificationRepo.EXPECT().InvalidateByUserID(gomock.Any(), tt.mockUser.ID()).Return(nil)
+				}
+			}
+
+			useCase := NewAuthUseCase(mockRepo, nil, nil, nil, mockVerificationRepo, nil, nil, nil, nil, nil, &MockJWTService{ctrl: ctrl}, &MockOAuthService{ctrl: ctrl}, nil, &MockMailSender{}, AuthOptions{})
+			err := useCase.VerifyEmail(context.Background(), commands.VerifyEmailCommand{Token: "valid-verification-token"})
+
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.True(t, tt.mockUser.EmailVerified())
+			assert.NoError(t, tt.mockUser.CanLogin(true))
+		})
+	}
+}
+
+func TestAuthUseCaseImpl_ResendVerificationEmail(t *testing.T) {
+	options := AuthOptions{
+		EmailVerificationTTL:            24 * time.Hour,
+		EmailVerificationURL:            "http://localhost:3000/verify-email",
+		EmailVerificationResendInterval: time.Minute,
+	}
+
+	newLatest := func(createdAt time.Time) *entities.EmailVerificationToken {
+		return entities.NewEmailVerificationTokenFromRepository(
+			value_objects.NewTokenID(),
+			helpers.CreateTestUserID(),
+			"hash",
+			createdAt.Add(24*time.Hour),
+			nil,
+			createdAt,
+		)
+	}
+
+	verifiedUser := helpers.CreateTestUser()
+	verifiedUser.VerifyEmail()
+
+	tests := []struct {
+		name       string
+		mockUser   *entities.User
+		mockError  error
+		latest     *entities.EmailVerificationToken
+		latestErr  error
+		sentBefore int64
+		wantMail   bool
+	}{
+		{
+			name:      "first resend",
+			mockUser:  helpers.CreateTestUser(),
+			latestErr: domainrepositories.ErrEmailVerificationTokenNotFound,
+			wantMail:  true,
+		},
+		{
+			name:     "resend after interval",
+			mockUser: helpers.CreateTestUser(),
+			latest:   newLatest(time.Now().Add(-2 * time.Minute)),
+			wantMail: true,
+		},
+		{
+			name:     "throttled resend succeeds silently",
+			mockUser: helpers.CreateTestUser(),
+			latest:   newLatest(time.Now().Add(-10 * time.Second)),
+			wantMail: false,
+		},
+		{
+			name:      "lookup failure succeeds silently",
+			mockUser:  helpers.CreateTestUser(),
+			latestErr: errors.New("connection refused"),
+			wantMail:  false,
+		},
+		{
+			name:     "already verified succeeds silently",
+			mockUser: verifiedUser,
+			wantMail: false,
+		},
+		{
+			name:      "unknown email succeeds silently",
+			mockError: errors.New("user not found"),
+			wantMail:  false,
+		},
+		{
+			name:       "address over its email limit succeeds silently",
+			sentBefore: maxAccountEmails,
+			wantMail:   false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := repositories.NewMockUserRepository(ctrl)
+			mockVerificationRepo := repositories.NewMockEmailVerificationTokenRepository(ctrl)
+			mockMail := &MockMailSender{}
+
+			store := NewMockAttemptStore()
+			store.Counts["mail:verification:test@example.com"] = tt.sentBefore
+
+			if tt.sentBefore < maxAccountEmails {
+				mockRepo.EXPECT().GetByFilter(gomock.Any(), gomock.Any()).Return(tt.mockUser, tt.mockError)
+			}
+			if tt.mockUser != nil && !tt.mockUser.EmailVerified() {
+				mockVerificationRepo.EXPECT().GetLatestByUserID(gomock.Any(), tt.mockUser.ID()).Return(tt.latest, tt.latestErr)
+			}
+			if tt.wantMail {
+				mockVerificationRepo.EXPECT().InvalidateByUserID(gomock.Any(), tt.mockUser.ID()).Return(nil)
+				mockVerificationRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+			}
+
+			useCase := NewAuthUseCase(mockRepo, nil, nil, nil, mockVerificationRepo, nil, nil, nil, nil, nil, &MockJWTService{ctrl: ctrl}, &MockOAuthService{ctrl: ctrl}, store, mockMail, options).(*AuthUseCaseImpl)
+			err := useCase.ResendVerificationEmail(context.Background(), commands.ResendVerificationEmailCommand{Email: "test@example.com"})
+			// The account is looked up and mailed after the call returns
+			useCase.background.Wait()
+
+			require.NoError(t, err)
+			if tt.wantMail {
+				require.Len(t, mockMail.Sent, 1)
+				assert.Equal(t, "test@example.com", mockMail.Sent[0].To)
+			} else {
+				assert.Empty(t, mockMail.Sent)
 			}
 		})
 	}
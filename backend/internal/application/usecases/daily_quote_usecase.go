@@ -0,0 +1,225 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"log"
+	"time"
+
+	"github.com/atdevten/peace/internal/application/commands"
+	"github.com/atdevten/peace/internal/application/services/cache"
+	"github.com/atdevten/peace/internal/domain/entities"
+	"github.com/atdevten/peace/internal/domain/repositories"
+	"github.com/atdevten/peace/internal/domain/value_objects"
+	"github.com/atdevten/peace/internal/pkg/timeutil"
+)
+
+type DailyQuoteUseCase interface {
+	// GetDailyQuote returns the quote of the day of the user in their timezone, or the global
+	// quote of the UTC day when userID is nil. The quote stays the same for the whole day.
+	GetDailyQuote(ctx context.Context, userID *string) (*commands.DailyQuote, error)
+}
+
+// DailyQuoteOptions configures the picking of quotes of the day
+type DailyQuoteOptions struct {
+	RepeatWindow int // days before the same quote may be picked again for a user, 0 allows repeats
+}
+
+type DailyQuoteUseCaseImpl struct {
+	quoteRepo      repositories.QuoteRepository
+	dailyQuoteRepo repositories.DailyQuoteRepository
+	userRepo       repositories.UserRepository
+	cache          cache.Cache // nil disables caching
+	options        DailyQuoteOptions
+	now            func() time.Time
+}
+
+func NewDailyQuoteUseCase(
+	quoteRepo repositories.QuoteRepository,
+	dailyQuoteRepo repositories.DailyQuoteRepository,
+	userRepo repositories.UserRepository,
+	cache cache.Cache,
+	options DailyQuoteOptions,
+) DailyQuoteUseCase {
+	return &DailyQuoteUseCaseImpl{
+		quoteRepo:      quoteRepo,
+		dailyQuoteRepo: dailyQuoteRepo,
+		userRepo:       userRepo,
+		cache:          cache,
+		options:        options,
+		now:            time.Now,
+	}
+}
+
+func (uc *DailyQuoteUseCaseImpl) GetDailyQuote(ctx context.Context, userID *string) (*commands.DailyQuote, error) {
+	// The day of a user runs in their timezone, the global one in UTC
+	var owner *value_objects.UserID
+	timezone := value_objects.NewDefaultTimezone()
+	if userID != nil {
+		var err error
+		owner, err = value_objects.NewUserIDFromString(*userID)
+		if err != nil {
+			return nil, fmt.Errorf("value_objects.NewUserIDFromString: %w", err)
+		}
+		user, err := uc.userRepo.GetByID(ctx, owner)
+		if err != nil {
+			return nil, fmt.Errorf("uc.userRepo.GetByID: %w", err)
+		}
+		timezone = user.Timezone()
+	}
+
+	now := uc.now().In(timezone.Location())
+	day := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	result := &commands.DailyQuote{Day: day, Timezone: timezone.String()}
+
+	// Serve the pick of the day from the cache, then from the history
+	cacheKey := dailyQuoteCacheKey(owner, day)
+	if quote := uc.cachedQuote(ctx, cacheKey); quote != nil {
+		result.Quote = quote
+		return result, nil
+	}
+
+	quote, err := uc.recordedQuote(ctx, owner, day)
+	if err != nil {
+		return nil, err
+	}
+	if quote == nil {
+		quote, err = uc.pickQuote(ctx, owner, day)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Keep the pick until the local day ends
+	nextDay := time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, now.Location())
+	uc.cacheQuote(ctx, cacheKey, quote, nextDay.Sub(now))
+
+	result.Quote = quote
+	return result, nil
+}
+
+// recordedQuote returns the quote already picked for the day, nil when there is none yet or
+// it has been deleted since
+func (uc *DailyQuoteUseCaseImpl) recordedQuote(ctx context.Context, owner *value_objects.UserID, day time.Time) (*entities.Quote, error) {
+	dailyQuote, err := uc.dailyQuoteRepo.GetByDay(ctx, owner, day)
+	if errors.Is(err, repositories.ErrDailyQuoteNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("uc.dailyQuoteRepo.GetByDay: %w", err)
+	}
+
+	quote, err := uc.quoteRepo.GetByID(ctx, dailyQuote.QuoteID())
+	if err != nil {
+		if err.Error() == "quote not found" {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("uc.quoteRepo.GetByID: %w", err)
+	}
+	return quote, nil
+}
+
+// pickQuote draws the quote of the day from a shuffle seeded by the owner and the day, leaving
+// out the quotes of the repeat window, and records it. Concurrent callers draw the same quote.
+func (uc *DailyQuoteUseCaseImpl) pickQuote(ctx context.Context, owner *value_objects.UserID, day time.Time) (*entities.Quote, error) {
+	var recent []*value_objects.QuoteID
+	if uc.options.RepeatWindow > 0 {
+		var err error
+		recent, err = uc.dailyQuoteRepo.ListQuoteIDsSince(ctx, owner, day.AddDate(0, 0, -uc.options.RepeatWindow))
+		if err != nil {
+			return nil, fmt.Errorf("uc.dailyQuoteRepo.ListQuoteIDsSince: %w", err)
+		}
+	}
+
+	filter := &repositories.QuoteFilter{
+		ExcludeIDs: recent,
+		Sort:       value_objects.QuoteSortRandom,
+		Seed:       dailyQuoteSeed(owner, day),
+		Limit:      1,
+	}
+	quotes, err := uc.quoteRepo.GetByFilter(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("uc.quoteRepo.GetByFilter: %w", err)
+	}
+
+	// With fewer quotes than days in the window, repeats are unavoidable
+	if len(quotes) == 0 && len(recent) > 0 {
+		filter.ExcludeIDs = nil
+		quotes, err = uc.quoteRepo.GetByFilter(ctx, filter)
+		if err != nil {
+			return nil, fmt.Errorf("uc.quoteRepo.GetByFilter: %w", err)
+		}
+	}
+	if len(quotes) == 0 {
+		return nil, fmt.Errorf("no quotes found")
+	}
+	quote := quotes[0]
+
+	dailyQuote, err := entities.NewDailyQuote(owner, day, quote.ID())
+	if err != nil {
+		return nil, fmt.Errorf("entities.NewDailyQuote: %w", err)
+	}
+	if err := uc.dailyQuoteRepo.Save(ctx, dailyQuote); err != nil {
+		return nil, fmt.Errorf("uc.dailyQuoteRepo.Save: %w", err)
+	}
+
+	return quote, nil
+}
+
+// cachedQuote returns the cached quote of the day, nil on a miss. Cache failures are logged
+// and fall back to the database.
+func (uc *DailyQuoteUseCaseImpl) cachedQuote(ctx context.Context, key string) *entities.Quote {
+	if uc.cache == nil {
+		return nil
+	}
+
+	value, found, err := uc.cache.Get(ctx, key)
+	if err != nil {
+		log.Printf("Failed to read daily quote cache %s: %v", key, err)
+		return nil
+	}
+	if !found {
+		return nil
+	}
+
+	quoteID, err := value_objects.NewQuoteIDFromString(value)
+	if err != nil {
+		return nil
+	}
+	quote, err := uc.quoteRepo.GetByID(ctx, quoteID)
+	if err != nil {
+		return nil
+	}
+	return quote
+}
+
+func (uc *DailyQuoteUseCaseImpl) cacheQuote(ctx context.Context, key string, quote *entities.Quote, ttl time.Duration) {
+	if uc.cache == nil {
+		return
+	}
+
+	if err := uc.cache.Set(ctx, key, quote.ID().String(), ttl); err != nil {
+		log.Printf("Failed to write daily quote cache %s: %v", key, err)
+	}
+}
+
+// dailyQuoteOwner names the owner of a quote of the day in cache keys and seeds
+func dailyQuoteOwner(owner *value_objects.UserID) string {
+	if owner == nil {
+		return "global"
+	}
+	return owner.String()
+}
+
+func dailyQuoteCacheKey(owner *value_objects.UserID, day time.Time) string {
+	return "quote:daily:" + dailyQuoteOwner(owner) + ":" + day.Format(timeutil.DateFormat)
+}
+
+// dailyQuoteSeed derives the shuffle of the day from the owner and the date
+func dailyQuoteSeed(owner *value_objects.UserID, day time.Time) int64 {
+	hash := fnv.New64a()
+	hash.Write([]byte(dailyQuoteOwner(owner) + "|" + day.Format(timeutil.DateFormat)))
+	return int64(hash.Sum64() & 0x7fffffff)
+}
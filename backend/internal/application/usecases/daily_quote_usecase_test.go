@@ -0,0 +1,182 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/atdevten/peace/internal/domain/entities"
+	domainrepositories "github.com/atdevten/peace/internal/domain/repositories"
+	"github.com/atdevten/peace/internal/domain/value_objects"
+	"github.com/atdevten/peace/testutils/helpers"
+	repositories "github.com/atdevten/peace/testutils/mocks/repositories"
+	services "github.com/atdevten/peace/testutils/mocks/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func newTestQuoteWithID(id int) *entities.Quote {
+	quote := helpers.CreateTestQuote()
+	return entities.NewQuoteFromExisting(value_objects.NewQuoteIDFromInt(id), quote.Content(), quote.Author(), quote.CreatedAt(), quote.UpdatedAt(), nil)
+}
+
+func TestDailyQuoteUseCaseImpl_GetDailyQuote(t *testing.T) {
+	// 23:30 UTC on March 14th is already March 15th in Tokyo
+	now := time.Date(2025, 3, 14, 23, 30, 0, 0, time.UTC)
+	tokyoDay := time.Date(2025, 3, 15, 0, 0, 0, 0, time.UTC)
+	utcDay := time.Date(2025, 3, 14, 0, 0, 0, 0, time.UTC)
+
+	user := helpers.CreateTestUser()
+	require.NoError(t, user.UpdateTimezone("Asia/Tokyo"))
+	userID := user.ID().String()
+
+	recent := []*value_objects.QuoteID{value_objects.NewQuoteIDFromInt(3)}
+	picked := newTestQuoteWithID(7)
+	cached := newTestQuoteWithID(9)
+	recorded := newTestQuoteWithID(4)
+	repeated := newTestQuoteWithID(1)
+	recordedPick, err := entities.NewDailyQuote(nil, utcDay, recorded.ID())
+	require.NoError(t, err)
+
+	tests := []struct {
+		name         string
+		userID       *string
+		options      DailyQuoteOptions
+		setupMocks   func(quoteRepo *repositories.MockQuoteRepository, dailyQuoteRepo *repositories.MockDailyQuoteRepository, userRepo *repositories.MockUserRepository, cache *services.MockCache)
+		wantQuote    *entities.Quote
+		wantDay      time.Time
+		wantTimezone string
+		wantErr      string
+	}{
+		{
+			name:    "picks a new quote of the local day outside the repeat window",
+			userID:  &userID,
+			options: DailyQuoteOptions{RepeatWindow: 30},
+			setupMocks: func(quoteRepo *repositories.MockQuoteRepository, dailyQuoteRepo *repositories.MockDailyQuoteRepository, userRepo *repositories.MockUserRepository, cache *services.MockCache) {
+				key := dailyQuoteCacheKey(user.ID(), tokyoDay)
+				userRepo.EXPECT().GetByID(gomock.Any(), gomock.Any()).Return(user, nil)
+				cache.EXPECT().Get(gomock.Any(), key).Return("", false, nil)
+				dailyQuoteRepo.EXPECT().GetByDay(gomock.Any(), user.ID(), tokyoDay).Return(nil, domainrepositories.ErrDailyQuoteNotFound)
+				dailyQuoteRepo.EXPECT().ListQuoteIDsSince(gomock.Any(), user.ID(), tokyoDay.AddDate(0, 0, -30)).Return(recent, nil)
+				quoteRepo.EXPECT().GetByFilter(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, filter *domainrepositories.QuoteFilter) ([]*entities.Quote, error) {
+						assert.Equal(t, recent, filter.ExcludeIDs)
+						assert.Equal(t, value_objects.QuoteSortRandom, filter.Sort)
+						assert.Equal(t, dailyQuoteSeed(user.ID(), tokyoDay), filter.Seed)
+						assert.Equal(t, 1, filter.Limit)
+						return []*entities.Quote{picked}, nil
+					})
+				dailyQuoteRepo.EXPECT().Save(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, dailyQuote *entities.DailyQuote) error {
+						assert.Equal(t, user.ID().String(), dailyQuote.UserID().String())
+						assert.Equal(t, tokyoDay, dailyQuote.Day())
+						assert.Equal(t, 7, dailyQuote.QuoteID().Value())
+						return nil
+					})
+				// Cached until midnight in Tokyo
+				cache.EXPECT().Set(gomock.Any(), key, "7", 30*time.Minute+15*time.Hour).Return(nil)
+			},
+			wantQuote:    picked,
+			wantDay:      tokyoDay,
+			wantTimezone: "Asia/Tokyo",
+		},
+		{
+			name:    "serves the cached quote",
+			options: DailyQuoteOptions{RepeatWindow: 30},
+			setupMocks: func(quoteRepo *repositories.MockQuoteRepository, dailyQuoteRepo *repositories.MockDailyQuoteRepository, userRepo *repositories.MockUserRepository, cache *services.MockCache) {
+				cache.EXPECT().Get(gomock.Any(), dailyQuoteCacheKey(nil, utcDay)).Return("9", true, nil)
+				quoteRepo.EXPECT().GetByID(gomock.Any(), value_objects.NewQuoteIDFromInt(9)).Return(cached, nil)
+			},
+			wantQuote:    cached,
+			wantDay:      utcDay,
+			wantTimezone: "UTC",
+		},
+		{
+			name:    "returns the recorded pick when the cache is unavailable",
+			options: DailyQuoteOptions{RepeatWindow: 30},
+			setupMocks: func(quoteRepo *repositories.MockQuoteRepository, dailyQuoteRepo *repositories.MockDailyQuoteRepository, userRepo *repositories.MockUserRepository, cache *services.MockCache) {
+				cache.EXPECT().Get(gomock.Any(), gomock.Any()).Return("", false, errors.New("connection refused"))
+				dailyQuoteRepo.EXPECT().GetByDay(gomock.Any(), nil, utcDay).Return(recordedPick, nil)
+				quoteRepo.EXPECT().GetByID(gomock.Any(), recordedPick.QuoteID()).Return(recorded, nil)
+				cache.EXPECT().Set(gomock.Any(), gomock.Any(), "4", gomock.Any()).Return(errors.New("connection refused"))
+			},
+			wantQuote:    recorded,
+			wantDay:      utcDay,
+			wantTimezone: "UTC",
+		},
+		{
+			name:    "repeats quotes when all of them are recent",
+			options: DailyQuoteOptions{RepeatWindow: 30},
+			setupMocks: func(quoteRepo *repositories.MockQuoteRepository, dailyQuoteRepo *repositories.MockDailyQuoteRepository, userRepo *repositories.MockUserRepository, cache *services.MockCache) {
+				cache.EXPECT().Get(gomock.Any(), gomock.Any()).Return("", false, nil)
+				dailyQuoteRepo.EXPECT().GetByDay(gomock.Any(), nil, gomock.Any()).Return(nil, domainrepositories.ErrDailyQuoteNotFound)
+				dailyQuoteRepo.EXPECT().ListQuoteIDsSince(gomock.Any(), nil, gomock.Any()).Return(recent, nil)
+				gomock.InOrder(
+					quoteRepo.EXPECT().GetByFilter(gomock.Any(), gomock.Any()).Return([]*entities.Quote{}, nil),
+					quoteRepo.EXPECT().GetByFilter(gomock.Any(), gomock.Any()).DoAndReturn(
+						func(ctx context.Context, filter *domainrepositories.QuoteFilter) ([]*entities.Quote, error) {
+							assert.Nil(t, filter.ExcludeIDs)
+							return []*entities.Quote{repeated}, nil
+						}),
+				)
+				dailyQuoteRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Return(nil)
+				cache.EXPECT().Set(gomock.Any(), gomock.Any(), "1", gomock.Any()).Return(nil)
+			},
+			wantQuote:    repeated,
+			wantDay:      utcDay,
+			wantTimezone: "UTC",
+		},
+		{
+			name: "no quotes",
+			setupMocks: func(quoteRepo *repositories.MockQuoteRepository, dailyQuoteRepo *repositories.MockDailyQuoteRepository, userRepo *repositories.MockUserRepository, cache *services.MockCache) {
+				cache.EXPECT().Get(gomock.Any(), gomock.Any()).Return("", false, nil)
+				dailyQuoteRepo.EXPECT().GetByDay(gomock.Any(), nil, gomock.Any()).Return(nil, domainrepositories.ErrDailyQuoteNotFound)
+				quoteRepo.EXPECT().GetByFilter(gomock.Any(), gomock.Any()).Return([]*entities.Quote{}, nil)
+			},
+			wantErr: "no quotes found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockQuoteRepo := repositories.NewMockQuoteRepository(ctrl)
+			mockDailyQuoteRepo := repositories.NewMockDailyQuoteRepository(ctrl)
+			mockUserRepo := repositories.NewMockUserRepository(ctrl)
+			mockCache := services.NewMockCache(ctrl)
+			tt.setupMocks(mockQuoteRepo, mockDailyQuoteRepo, mockUserRepo, mockCache)
+
+			useCase := NewDailyQuoteUseCase(mockQuoteRepo, mockDailyQuoteRepo, mockUserRepo, mockCache, tt.options).(*DailyQuoteUseCaseImpl)
+			useCase.now = func() time.Time { return now }
+			result, err := useCase.GetDailyQuote(context.Background(), tt.userID)
+
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Equal(t, tt.wantErr, err.Error())
+				assert.Nil(t, result)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantQuote, result.Quote)
+			assert.Equal(t, tt.wantDay, result.Day)
+			assert.Equal(t, tt.wantTimezone, result.Timezone)
+		})
+	}
+}
+
+func TestDailyQuoteSeed(t *testing.T) {
+	userID := helpers.CreateTestUserID()
+	otherID := helpers.CreateTestUserID()
+	day := time.Date(2025, 3, 14, 0, 0, 0, 0, time.UTC)
+
+	// Stable for a user and a day, different across users and days
+	assert.Equal(t, dailyQuoteSeed(userID, day), dailyQuoteSeed(userID, day))
+	assert.NotEqual(t, dailyQuoteSeed(userID, day), dailyQuoteSeed(otherID, day))
+	assert.NotEqual(t, dailyQuoteSeed(userID, day), dailyQuoteSeed(userID, day.AddDate(0, 0, 1)))
+	assert.NotEqual(t, dailyQuoteSeed(userID, day), dailyQuoteSeed(nil, day))
+}
@@ -0,0 +1,361 @@
+package usecases
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/atdevten/peace/internal/domain/entities"
+	"github.com/atdevten/peace/internal/domain/repositories"
+	"github.com/atdevten/peace/internal/domain/value_objects"
+)
+
+// dataExportPageSize bounds how many rows an export holds in memory at once
+const dataExportPageSize = 500
+
+type exportedProfile struct {
+	ID            string    `json:"id"`
+	Email         string    `json:"email"`
+	Username      string    `json:"username"`
+	FirstName     string    `json:"first_name"`
+	LastName      string    `json:"last_name"`
+	PictureURL    *string   `json:"picture_url"`
+	AuthProvider  string    `json:"auth_provider"`
+	Role          string    `json:"role"`
+	Timezone      string    `json:"timezone"`
+	FeedOptOut    bool      `json:"feed_opt_out"`
+	EmailVerified bool      `json:"email_verified"`
+	IsActive      bool      `json:"is_active"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+type exportedRecord struct {
+	ID          string    `json:"id"`
+	HappyLevel  int       `json:"happy_level"`
+	EnergyLevel int       `json:"energy_level"`
+	Notes       *string   `json:"notes"`
+	Status      string    `json:"status"`
+	Tags        []string  `json:"tags"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+type exportedTag struct {
+	Name        string  `json:"name"`
+	RecordCount int     `json:"record_count"`
+	HappyAvg    float64 `json:"happy_avg"`
+	EnergyAvg   float64 `json:"energy_avg"`
+}
+
+type exportedSession struct {
+	ID         string     `json:"id"`
+	Provider   string     `json:"provider"`
+	UserAgent  string     `json:"user_agent"`
+	IPAddress  string     `json:"ip_address"`
+	CreatedAt  time.Time  `json:"created_at"`
+	LastUsedAt time.Time  `json:"last_used_at"`
+	RevokedAt  *time.Time `json:"revoked_at"`
+}
+
+var exportedRecordCSVHeader = []string{"id", "created_at", "updated_at", "happy_level", "energy_level", "status", "notes", "tags"}
+
+// writeArchive writes the ZIP of everything stored about the user. Records and sessions are read
+// page by page, so memory use does not grow with the size of the account.
+func (uc *DataExportUseCaseImpl) writeArchive(ctx context.Context, userID *value_objects.UserID, w io.Writer) error {
+	user, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("uc.userRepo.GetByID: %w", err)
+	}
+
+	files := []struct {
+		name  string
+		write func(io.Writer) error
+	}{
+		{"profile.json", func(w io.Writer) error { return writeExportJSON(w, toExportedProfile(user)) }},
+		{"mental_health_records.json", func(w io.Writer) error { return uc.writeRecordsJSON(ctx, userID, w) }},
+		{"mental_health_records.csv", func(w io.Writer) error { return uc.writeRecordsCSV(ctx, userID, w) }},
+		{"tags.json", func(w io.Writer) error { return uc.writeTagsJSON(ctx, userID, w) }},
+		{"sessions.json", func(w io.Writer) error { return uc.writeSessionsJSON(ctx, userID, w) }},
+	}
+
+	archive := zip.NewWriter(w)
+	modified := uc.now()
+	for _, file := range files {
+		entry, err := archive.CreateHeader(&zip.FileHeader{
+			Name:     file.name,
+			Method:   zip.Deflate,
+			Modified: modified,
+		})
+		if err != nil {
+			return fmt.Errorf("archive.CreateHeader: %w", err)
+		}
+		if err := file.write(entry); err != nil {
+			return fmt.Errorf("%s: %w", file.name, err)
+		}
+	}
+
+	if err := archive.Close(); err != nil {
+		return fmt.Errorf("archive.Close: %w", err)
+	}
+	return nil
+}
+
+func (uc *DataExportUseCaseImpl) writeRecordsJSON(ctx context.Context, userID *value_objects.UserID, w io.Writer) error {
+	array := newJSONArrayWriter(w)
+	err := uc.eachRecordPage(ctx, userID, func(records []*entities.MentalHealthRecord) error {
+		for _, record := range records {
+			if err := array.Write(toExportedRecord(record)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	return array.Close()
+}
+
+func (uc *DataExportUseCaseImpl) writeRecordsCSV(ctx context.Context, userID *value_objects.UserID, w io.Writer) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(exportedRecordCSVHeader); err != nil {
+		return fmt.Errorf("writer.Write: %w", err)
+	}
+
+	err := uc.eachRecordPage(ctx, userID, func(records []*entities.MentalHealthRecord) error {
+		for _, record := range records {
+			exported := toExportedRecord(record)
+			notes := ""
+			if exported.Notes != nil {
+				notes = *exported.Notes
+			}
+			row := []string{
+				exported.ID,
+				exported.CreatedAt.Format(time.RFC3339),
+				exported.UpdatedAt.Format(time.RFC3339),
+				strconv.Itoa(exported.HappyLevel),
+				strconv.Itoa(exported.EnergyLevel),
+				exported.Status,
+				notes,
+				strings.Join(exported.Tags, ";"),
+			}
+			if err := writer.Write(row); err != nil {
+				return fmt.Errorf("writer.Write: %w", err)
+			}
+		}
+		writer.Flush()
+		return writer.Error()
+	})
+	if err != nil {
+		return err
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+func (uc *DataExportUseCaseImpl) writeTagsJSON(ctx context.Context, userID *value_objects.UserID, w io.Writer) error {
+	averages, err := uc.recordRepo.GetTagMoodAverages(ctx, &repositories.MentalHealthRecordTagReportFilter{
+		UserID: userID,
+	})
+	if err != nil {
+		return fmt.Errorf("uc.recordRepo.GetTagMoodAverages: %w", err)
+	}
+
+	tags := make([]exportedTag, 0, len(averages))
+	for _, average := range averages {
+		tags = append(tags, exportedTag{
+			Name:        average.TagName,
+			RecordCount: average.Count,
+			HappyAvg:    average.HappyAvg,
+			EnergyAvg:   average.EnergyAvg,
+		})
+	}
+
+	return writeExportJSON(w, tags)
+}
+
+func (uc *DataExportUseCaseImpl) writeSessionsJSON(ctx context.Context, userID *value_objects.UserID, w io.Writer) error {
+	array := newJSONArrayWriter(w)
+	filter := &repositories.SessionHistoryFilter{
+		UserID: userID,
+		Limit:  dataExportPageSize,
+	}
+
+	for {
+		sessions, err := uc.sessionRepo.ListHistory(ctx, filter)
+		if err != nil {
+			return fmt.Errorf("uc.sessionRepo.ListHistory: %w", err)
+		}
+
+		for _, session := range sessions {
+			err := array.Write(exportedSession{
+				ID:         session.ID().String(),
+				Provider:   session.Provider(),
+				UserAgent:  session.UserAgent(),
+				IPAddress:  session.IPAddress(),
+				CreatedAt:  session.CreatedAt(),
+				LastUsedAt: session.LastUsedAt(),
+				RevokedAt:  session.RevokedAt(),
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		if len(sessions) < dataExportPageSize {
+			break
+		}
+		last := sessions[len(sessions)-1]
+		filter.Cursor = &repositories.SessionCursor{
+			CreatedAt: last.CreatedAt(),
+			ID:        last.ID().String(),
+		}
+	}
+
+	return array.Close()
+}
+
+// eachRecordPage walks the user's records oldest first, one page at a time, with their tags loaded
+func (uc *DataExportUseCaseImpl) eachRecordPage(ctx context.Context, userID *value_objects.UserID, fn func([]*entities.MentalHealthRecord) error) error {
+	limit := dataExportPageSize
+	filter := &repositories.MentalHealthRecordFilter{
+		UserID: userID,
+		Limit:  &limit,
+	}
+
+	for {
+		records, err := uc.recordRepo.GetByFilter(ctx, filter)
+		if err != nil {
+			return fmt.Errorf("uc.recordRepo.GetByFilter: %w", err)
+		}
+		if len(records) == 0 {
+			return nil
+		}
+
+		recordIDs := make([]*value_objects.MentalHealthRecordID, len(records))
+		for i, record := range records {
+			recordIDs[i] = record.ID()
+		}
+		tagsByRecord, err := uc.recordRepo.GetTagsByRecordIDs(ctx, recordIDs)
+		if err != nil {
+			return fmt.Errorf("uc.recordRepo.GetTagsByRecordIDs: %w", err)
+		}
+		for _, record := range records {
+			record.SetTags(tagsByRecord[record.ID().String()])
+		}
+
+		if err := fn(records); err != nil {
+			return err
+		}
+
+		if len(records) < limit {
+			return nil
+		}
+		last := records[len(records)-1]
+		filter.Cursor = &repositories.MentalHealthRecordCursor{
+			CreatedAt: last.CreatedAt(),
+			ID:        last.ID().String(),
+		}
+	}
+}
+
+func toExportedProfile(user *entities.User) exportedProfile {
+	profile := exportedProfile{
+		ID:            user.ID().String(),
+		Email:         user.Email().String(),
+		Username:      user.Username().String(),
+		PictureURL:    user.PictureURL(),
+		AuthProvider:  user.AuthProvider(),
+		Role:          user.Role().String(),
+		FeedOptOut:    user.FeedOptOut(),
+		EmailVerified: user.EmailVerified(),
+		IsActive:      user.IsActive(),
+		CreatedAt:     user.CreatedAt(),
+		UpdatedAt:     user.UpdatedAt(),
+	}
+	if user.FirstName() != nil {
+		profile.FirstName = user.FirstName().String()
+	}
+	if user.LastName() != nil {
+		profile.LastName = user.LastName().String()
+	}
+	if user.Timezone() != nil {
+		profile.Timezone = user.Timezone().String()
+	}
+	return profile
+}
+
+func toExportedRecord(record *entities.MentalHealthRecord) exportedRecord {
+	tags := record.Tags()
+	if tags == nil {
+		tags = []string{}
+	}
+
+	return exportedRecord{
+		ID:          record.ID().String(),
+		HappyLevel:  record.HappyLevel().Value(),
+		EnergyLevel: record.EnergyLevel().Value(),
+		Notes:       record.Notes(),
+		Status:      record.Status().String(),
+		Tags:        tags,
+		CreatedAt:   record.CreatedAt(),
+		UpdatedAt:   record.UpdatedAt(),
+	}
+}
+
+func writeExportJSON(w io.Writer, value interface{}) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(value); err != nil {
+		return fmt.Errorf("encoder.Encode: %w", err)
+	}
+	return nil
+}
+
+// jsonArrayWriter writes a JSON array one element at a time
+type jsonArrayWriter struct {
+	w     io.Writer
+	count int
+}
+
+func newJSONArrayWriter(w io.Writer) *jsonArrayWriter {
+	return &jsonArrayWriter{w: w}
+}
+
+func (a *jsonArrayWriter) Write(value interface{}) error {
+	element, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("json.Marshal: %w", err)
+	}
+
+	separator := ",\n  "
+	if a.count == 0 {
+		separator = "[\n  "
+	}
+	if _, err := io.WriteString(a.w, separator); err != nil {
+		return err
+	}
+	if _, err := a.w.Write(element); err != nil {
+		return err
+	}
+	a.count++
+	return nil
+}
+
+// Close ends the array, writing an empty one if no element was written
+func (a *jsonArrayWriter) Close() error {
+	closing := "\n]\n"
+	if a.count == 0 {
+		closing = "[]\n"
+	}
+	_, err := io.WriteString(a.w, closing)
+	return err
+}
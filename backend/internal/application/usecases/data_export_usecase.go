@@ -0,0 +1,344 @@
+package usecases
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/atdevten/peace/internal/application/commands"
+	"github.com/atdevten/peace/internal/application/services/filestore"
+	"github.com/atdevten/peace/internal/domain/entities"
+	"github.com/atdevten/peace/internal/domain/repositories"
+	"github.com/atdevten/peace/internal/domain/value_objects"
+)
+
+type DataExportUseCase interface {
+	// RequestExport queues an archive of all the user's data, built in the background
+	RequestExport(ctx context.Context, userID string) (*entities.DataExport, error)
+	ListExports(ctx context.Context, userID string) ([]*entities.DataExport, error)
+	GetExport(ctx context.Context, userID string, exportID string) (*entities.DataExport, error)
+	// DownloadLink signs a short-lived link to a ready export
+	DownloadLink(export *entities.DataExport) (*commands.DataExportDownloadLink, error)
+	// OpenDownload checks a signed link and opens the archive it points to
+	OpenDownload(ctx context.Context, link commands.DataExportDownloadLink) (*entities.DataExport, io.ReadCloser, error)
+	// ProcessNext builds the oldest queued export, reporting false when none is waiting
+	ProcessNext(ctx context.Context) (bool, error)
+	// Run processes queued exports until ctx is cancelled
+	Run(ctx context.Context)
+}
+
+var (
+	ErrDataExportInProgress = errors.New("a data export is already in progress")
+	ErrDataExportNotReady   = errors.New("data export is not ready")
+	ErrDataExportExpired    = errors.New("data export has expired")
+	ErrInvalidDownloadLink  = errors.New("invalid or expired download link")
+)
+
+const (
+	defaultDataExportPollInterval = 30 * time.Second
+	defaultDataExportStaleAfter   = time.Hour
+)
+
+// DataExportOptions configures export archives and their download links
+type DataExportOptions struct {
+	LinkSecret   string        // signs download links
+	LinkTTL      time.Duration // lifetime of a download link
+	ArchiveTTL   time.Duration // how long a finished archive stays downloadable
+	PollInterval time.Duration // how often Run looks for exports queued by other instances
+	StaleAfter   time.Duration // processing exports older than this are presumed abandoned and rebuilt
+}
+
+type DataExportUseCaseImpl struct {
+	exportRepo  repositories.DataExportRepository
+	userRepo    repositories.UserRepository
+	recordRepo  repositories.MentalHealthRecordRepository
+	sessionRepo repositories.SessionRepository
+	fileStore   filestore.Store
+	auditTrail  auditTrail
+	options     DataExportOptions
+	wake        chan struct{}
+	now         func() time.Time
+}
+
+func NewDataExportUseCase(
+	exportRepo repositories.DataExportRepository,
+	userRepo repositories.UserRepository,
+	recordRepo repositories.MentalHealthRecordRepository,
+	sessionRepo repositories.SessionRepository,
+	fileStore filestore.Store,
+	auditRepo repositories.AuditEventRepository,
+	options DataExportOptions,
+) DataExportUseCase {
+	if options.PollInterval <= 0 {
+		options.PollInterval = defaultDataExportPollInterval
+	}
+	if options.StaleAfter <= 0 {
+		options.StaleAfter = defaultDataExportStaleAfter
+	}
+
+	return &DataExportUseCaseImpl{
+		exportRepo:  exportRepo,
+		userRepo:    userRepo,
+		recordRepo:  recordRepo,
+		sessionRepo: sessionRepo,
+		fileStore:   fileStore,
+		auditTrail:  auditTrail{repo: auditRepo},
+		options:     options,
+		wake:        make(chan struct{}, 1),
+		now:         time.Now,
+	}
+}
+
+func (uc *DataExportUseCaseImpl) RequestExport(ctx context.Context, userID string) (*entities.DataExport, error) {
+	userIDVO, err := value_objects.NewUserIDFromString(userID)
+	if err != nil {
+		return nil, fmt.Errorf("value_objects.NewUserIDFromString: %w", err)
+	}
+
+	if _, err := uc.userRepo.GetByID(ctx, userIDVO); err != nil {
+		return nil, fmt.Errorf("uc.userRepo.GetByID: %w", err)
+	}
+
+	// One archive at a time per user
+	exports, err := uc.exportRepo.ListByUserID(ctx, userIDVO)
+	if err != nil {
+		return nil, fmt.Errorf("uc.exportRepo.ListByUserID: %w", err)
+	}
+	for _, export := range exports {
+		if export.IsInProgress() {
+			return nil, ErrDataExportInProgress
+		}
+	}
+
+	export, err := entities.NewDataExport(userIDVO)
+	if err != nil {
+		return nil, fmt.Errorf("entities.NewDataExport: %w", err)
+	}
+
+	if err := uc.exportRepo.Create(ctx, export); err != nil {
+		return nil, fmt.Errorf("uc.exportRepo.Create: %w", err)
+	}
+
+	uc.auditTrail.record(ctx, auditEntry{
+		action:     value_objects.AuditActionDataExportRequested,
+		actorID:    userIDVO,
+		targetType: entities.AuditTargetDataExport,
+		targetID:   export.ID().String(),
+	})
+
+	// Let this instance's worker start right away instead of at its next poll
+	select {
+	case uc.wake <- struct{}{}:
+	default:
+	}
+
+	return export, nil
+}
+
+func (uc *DataExportUseCaseImpl) ListExports(ctx context.Context, userID string) ([]*entities.DataExport, error) {
+	userIDVO, err := value_objects.NewUserIDFromString(userID)
+	if err != nil {
+		return nil, fmt.Errorf("value_objects.NewUserIDFromString: %w", err)
+	}
+
+	exports, err := uc.exportRepo.ListByUserID(ctx, userIDVO)
+	if err != nil {
+		return nil, fmt.Errorf("uc.exportRepo.ListByUserID: %w", err)
+	}
+
+	return exports, nil
+}
+
+func (uc *DataExportUseCaseImpl) GetExport(ctx context.Context, userID string, exportID string) (*entities.DataExport, error) {
+	userIDVO, err := value_objects.NewUserIDFromString(userID)
+	if err != nil {
+		return nil, fmt.Errorf("value_objects.NewUserIDFromString: %w", err)
+	}
+
+	id, err := value_objects.NewTokenIDFromString(exportID)
+	if err != nil {
+		return nil, repositories.ErrDataExportNotFound
+	}
+
+	export, err := uc.exportRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("uc.exportRepo.GetByID: %w", err)
+	}
+
+	// Other users' exports are reported as missing
+	if !export.BelongsTo(userIDVO) {
+		return nil, repositories.ErrDataExportNotFound
+	}
+
+	return export, nil
+}
+
+func (uc *DataExportUseCaseImpl) DownloadLink(export *entities.DataExport) (*commands.DataExportDownloadLink, error) {
+	now := uc.now()
+	if !export.IsReady(now) {
+		if export.IsExpired(now) {
+			return nil, ErrDataExportExpired
+		}
+		return nil, ErrDataExportNotReady
+	}
+
+	// Never outlive the archive itself
+	expiresAt := now.Add(uc.options.LinkTTL)
+	if expiresAt.After(*export.ExpiresAt()) {
+		expiresAt = *export.ExpiresAt()
+	}
+	expiresAt = expiresAt.Truncate(time.Second)
+
+	return &commands.DataExportDownloadLink{
+		ExportID:  export.ID().String(),
+		ExpiresAt: expiresAt,
+		Signature: uc.sign(export.ID().String(), expiresAt),
+	}, nil
+}
+
+func (uc *DataExportUseCaseImpl) OpenDownload(ctx context.Context, link commands.DataExportDownloadLink) (*entities.DataExport, io.ReadCloser, error) {
+	expected := uc.sign(link.ExportID, link.ExpiresAt)
+	if !hmac.Equal([]byte(expected), []byte(link.Signature)) || !uc.now().Before(link.ExpiresAt) {
+		return nil, nil, ErrInvalidDownloadLink
+	}
+
+	id, err := value_objects.NewTokenIDFromString(link.ExportID)
+	if err != nil {
+		return nil, nil, ErrInvalidDownloadLink
+	}
+
+	export, err := uc.exportRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, nil, fmt.Errorf("uc.exportRepo.GetByID: %w", err)
+	}
+
+	if !export.IsReady(uc.now()) {
+		return nil, nil, ErrDataExportExpired
+	}
+
+	file, err := uc.fileStore.Open(ctx, dataExportFileKey(export))
+	if err != nil {
+		if errors.Is(err, filestore.ErrNotFound) {
+			return nil, nil, ErrDataExportExpired
+		}
+		return nil, nil, fmt.Errorf("uc.fileStore.Open: %w", err)
+	}
+
+	// Links work without logging in, so the actor is whoever holds the link; the owner goes in metadata
+	uc.auditTrail.record(ctx, auditEntry{
+		action:     value_objects.AuditActionDataExportDownloaded,
+		targetType: entities.AuditTargetDataExport,
+		targetID:   export.ID().String(),
+		metadata:   map[string]interface{}{"user_id": export.UserID().String()},
+	})
+
+	return export, file, nil
+}
+
+func (uc *DataExportUseCaseImpl) ProcessNext(ctx context.Context) (bool, error) {
+	now := uc.now()
+	export, err := uc.exportRepo.ClaimNext(ctx, now, now.Add(-uc.options.StaleAfter))
+	if err != nil {
+		if errors.Is(err, repositories.ErrDataExportNotFound) {
+			return false, nil
+		}
+		return false, fmt.Errorf("uc.exportRepo.ClaimNext: %w", err)
+	}
+
+	size, buildErr := uc.buildArchive(ctx, export)
+	if buildErr != nil {
+		export.Fail(buildErr.Error(), uc.now())
+	} else {
+		export.Complete(size, uc.now(), uc.options.ArchiveTTL)
+	}
+
+	if err := uc.exportRepo.Update(ctx, export); err != nil {
+		return true, fmt.Errorf("uc.exportRepo.Update: %w", err)
+	}
+	if buildErr != nil {
+		return true, fmt.Errorf("uc.buildArchive: %w", buildErr)
+	}
+
+	return true, nil
+}
+
+func (uc *DataExportUseCaseImpl) Run(ctx context.Context) {
+	ticker := time.NewTicker(uc.options.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		// Drain the queue before waiting again
+		for ctx.Err() == nil {
+			processed, err := uc.ProcessNext(ctx)
+			if err != nil {
+				log.Printf("Data export failed: %v", err)
+			}
+			if !processed {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-uc.wake:
+		case <-ticker.C:
+		}
+	}
+}
+
+// buildArchive streams the export's ZIP into the file store and returns its size
+func (uc *DataExportUseCaseImpl) buildArchive(ctx context.Context, export *entities.DataExport) (int64, error) {
+	key := dataExportFileKey(export)
+
+	file, err := uc.fileStore.Create(ctx, key)
+	if err != nil {
+		return 0, fmt.Errorf("uc.fileStore.Create: %w", err)
+	}
+
+	counter := &countingWriter{writer: file}
+	writeErr := uc.writeArchive(ctx, export.UserID(), counter)
+	closeErr := file.Close()
+	if writeErr != nil || closeErr != nil {
+		// Do not leave a partial archive behind
+		if err := uc.fileStore.Delete(ctx, key); err != nil {
+			log.Printf("Failed to delete partial data export %s: %v", key, err)
+		}
+		if writeErr != nil {
+			return 0, writeErr
+		}
+		return 0, fmt.Errorf("file.Close: %w", closeErr)
+	}
+
+	return counter.written, nil
+}
+
+// sign authenticates an export ID together with the link expiry
+func (uc *DataExportUseCaseImpl) sign(exportID string, expiresAt time.Time) string {
+	mac := hmac.New(sha256.New, []byte(uc.options.LinkSecret))
+	mac.Write([]byte(exportID + ":" + strconv.FormatInt(expiresAt.Unix(), 10)))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func dataExportFileKey(export *entities.DataExport) string {
+	return "data-export-" + export.ID().String() + ".zip"
+}
+
+// countingWriter tracks the number of bytes written through it
+type countingWriter struct {
+	writer  io.Writer
+	written int64
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	n, err := w.writer.Write(p)
+	w.written += int64(n)
+	return n, err
+}
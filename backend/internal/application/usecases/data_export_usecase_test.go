@@ -0,0 +1,476 @@
+package usecases
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/atdevten/peace/internal/application/commands"
+	"github.com/atdevten/peace/internal/application/services/filestore"
+	"github.com/atdevten/peace/internal/domain/entities"
+	domainrepositories "github.com/atdevten/peace/internal/domain/repositories"
+	"github.com/atdevten/peace/internal/domain/value_objects"
+	"github.com/atdevten/peace/testutils/helpers"
+	repositories "github.com/atdevten/peace/testutils/mocks/repositories"
+	services "github.com/atdevten/peace/testutils/mocks/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+var testDataExportOptions = DataExportOptions{
+	LinkSecret: "test-secret",
+	LinkTTL:    15 * time.Minute,
+	ArchiveTTL: 7 * 24 * time.Hour,
+}
+
+// bufferFile is an in-memory file handed out by the mocked file store
+type bufferFile struct {
+	bytes.Buffer
+}
+
+func (f *bufferFile) Close() error {
+	return nil
+}
+
+func readArchiveFile(t *testing.T, archive *zip.Reader, name string) []byte {
+	file, err := archive.Open(name)
+	require.NoError(t, err, name)
+	defer file.Close()
+	content, err := io.ReadAll(file)
+	require.NoError(t, err)
+	return content
+}
+
+func TestDataExportUseCaseImpl_RequestExport(t *testing.T) {
+	user := helpers.CreateTestUser()
+
+	failed, err := entities.NewDataExport(user.ID())
+	require.NoError(t, err)
+	failed.Fail("boom", time.Now())
+	pending, err := entities.NewDataExport(user.ID())
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		exports []*entities.DataExport
+		wantErr error
+	}{
+		{
+			name: "first export",
+		},
+		{
+			name:    "previous export failed",
+			exports: []*entities.DataExport{failed},
+		},
+		{
+			name:    "another export in progress",
+			exports: []*entities.DataExport{pending},
+			wantErr: ErrDataExportInProgress,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUserRepo := repositories.NewMockUserRepository(ctrl)
+			mockUserRepo.EXPECT().GetByID(gomock.Any(), gomock.Any()).Return(user, nil)
+
+			mockExportRepo := repositories.NewMockDataExportRepository(ctrl)
+			mockExportRepo.EXPECT().ListByUserID(gomock.Any(), gomock.Any()).Return(tt.exports, nil)
+
+			var recorded *entities.AuditEvent
+			mockAuditRepo := repositories.NewMockAuditEventRepository(ctrl)
+			if tt.wantErr == nil {
+				mockExportRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+				mockAuditRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, event *entities.AuditEvent) error {
+						recorded = event
+						return nil
+					})
+			}
+
+			useCase := NewDataExportUseCase(mockExportRepo, mockUserRepo, repositories.NewMockMentalHealthRecordRepository(ctrl),
+				repositories.NewMockSessionRepository(ctrl), services.NewMockStore(ctrl), mockAuditRepo, testDataExportOptions).(*DataExportUseCaseImpl)
+			export, err := useCase.RequestExport(context.Background(), user.ID().String())
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Empty(t, useCase.wake)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, value_objects.DataExportStatusPending, export.Status())
+			// The worker of this instance is woken up
+			assert.Len(t, useCase.wake, 1)
+
+			require.NotNil(t, recorded)
+			assert.Equal(t, value_objects.AuditActionDataExportRequested, recorded.Action())
+			assert.Equal(t, entities.AuditTargetDataExport, recorded.TargetType())
+			assert.Equal(t, export.ID().String(), recorded.TargetID())
+		})
+	}
+}
+
+func TestDataExportUseCaseImpl_ProcessNext(t *testing.T) {
+	tests := []struct {
+		name          string
+		claimErr      error
+		recordsErr    error
+		wantProcessed bool
+		wantStatus    value_objects.DataExportStatus
+		wantErr       string
+	}{
+		{
+			name:          "builds the archive",
+			wantProcessed: true,
+			wantStatus:    value_objects.DataExportStatusCompleted,
+		},
+		{
+			name:          "failure removes the partial archive",
+			recordsErr:    errors.New("connection lost"),
+			wantProcessed: true,
+			wantStatus:    value_objects.DataExportStatusFailed,
+			wantErr:       "connection lost",
+		},
+		{
+			name:     "nothing queued",
+			claimErr: domainrepositories.ErrDataExportNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			user := helpers.CreateTestUser()
+			export, err := entities.NewDataExport(user.ID())
+			require.NoError(t, err)
+			export.Start(time.Now())
+
+			first := helpers.CreateTestMentalHealthRecordWithUserID(user.ID())
+			second := helpers.CreateTestMentalHealthRecordWithUserID(user.ID())
+			session, err := entities.NewSession(user.ID(), "test-agent", "203.0.113.7", "local")
+			require.NoError(t, err)
+
+			mockExportRepo := repositories.NewMockDataExportRepository(ctrl)
+			mockUserRepo := repositories.NewMockUserRepository(ctrl)
+			mockRecordRepo := repositories.NewMockMentalHealthRecordRepository(ctrl)
+			mockSessionRepo := repositories.NewMockSessionRepository(ctrl)
+			mockStore := services.NewMockStore(ctrl)
+
+			file := &bufferFile{}
+			if tt.claimErr != nil {
+				mockExportRepo.EXPECT().ClaimNext(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, tt.claimErr)
+			} else {
+				mockExportRepo.EXPECT().ClaimNext(gomock.Any(), gomock.Any(), gomock.Any()).Return(export, nil)
+				mockStore.EXPECT().Create(gomock.Any(), dataExportFileKey(export)).Return(file, nil)
+				mockUserRepo.EXPECT().GetByID(gomock.Any(), gomock.Any()).Return(user, nil)
+				mockExportRepo.EXPECT().Update(gomock.Any(), export).Return(nil)
+			}
+			if tt.recordsErr != nil {
+				mockRecordRepo.EXPECT().GetByFilter(gomock.Any(), gomock.Any()).Return(nil, tt.recordsErr)
+				mockStore.EXPECT().Delete(gomock.Any(), dataExportFileKey(export)).Return(nil)
+			} else if tt.claimErr == nil {
+				// Records are read once for the JSON file and once for the CSV file
+				mockRecordRepo.EXPECT().GetByFilter(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, filter *domainrepositories.MentalHealthRecordFilter) ([]*entities.MentalHealthRecord, error) {
+						assert.Equal(t, user.ID().String(), filter.UserID.String())
+						assert.Equal(t, dataExportPageSize, *filter.Limit)
+						return []*entities.MentalHealthRecord{first, second}, nil
+					}).Times(2)
+				mockRecordRepo.EXPECT().GetTagsByRecordIDs(gomock.Any(), gomock.Any()).
+					Return(map[string][]string{first.ID().String(): {"motivation"}}, nil).Times(2)
+				mockRecordRepo.EXPECT().GetTagMoodAverages(gomock.Any(), gomock.Any()).
+					Return([]*domainrepositories.TagMoodAverage{{TagName: "motivation", Count: 1, HappyAvg: 5, EnergyAvg: 7}}, nil)
+				mockSessionRepo.EXPECT().ListHistory(gomock.Any(), gomock.Any()).Return([]*entities.Session{session}, nil)
+			}
+
+			useCase := NewDataExportUseCase(mockExportRepo, mockUserRepo, mockRecordRepo, mockSessionRepo, mockStore, nil, testDataExportOptions)
+			processed, err := useCase.ProcessNext(context.Background())
+
+			assert.Equal(t, tt.wantProcessed, processed)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+			if !tt.wantProcessed {
+				return
+			}
+
+			assert.Equal(t, tt.wantStatus, export.Status())
+			if tt.wantStatus != value_objects.DataExportStatusCompleted {
+				return
+			}
+
+			assert.True(t, export.IsReady(time.Now()))
+			content := file.Bytes()
+			require.NotEmpty(t, content)
+			assert.Equal(t, int64(len(content)), export.SizeBytes())
+
+			archive, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+			require.NoError(t, err)
+
+			var profile map[string]interface{}
+			require.NoError(t, json.Unmarshal(readArchiveFile(t, archive, "profile.json"), &profile))
+			assert.Equal(t, "test@example.com", profile["email"])
+			assert.NotContains(t, profile, "password_hash")
+
+			var records []exportedRecord
+			require.NoError(t, json.Unmarshal(readArchiveFile(t, archive, "mental_health_records.json"), &records))
+			require.Len(t, records, 2)
+			assert.Equal(t, first.ID().String(), records[0].ID)
+			assert.Equal(t, []string{"motivation"}, records[0].Tags)
+			assert.Empty(t, records[1].Tags)
+
+			rows, err := csv.NewReader(bytes.NewReader(readArchiveFile(t, archive, "mental_health_records.csv"))).ReadAll()
+			require.NoError(t, err)
+			require.Len(t, rows, 3)
+			assert.Equal(t, exportedRecordCSVHeader, rows[0])
+			assert.Equal(t, "motivation", rows[1][7])
+
+			var tags []exportedTag
+			require.NoError(t, json.Unmarshal(readArchiveFile(t, archive, "tags.json"), &tags))
+			assert.Equal(t, []exportedTag{{Name: "motivation", RecordCount: 1, HappyAvg: 5, EnergyAvg: 7}}, tags)
+
+			var sessions []exportedSession
+			require.NoError(t, json.Unmarshal(readArchiveFile(t, archive, "sessions.json"), &sessions))
+			require.Len(t, sessions, 1)
+			assert.Equal(t, "203.0.113.7", sessions[0].IPAddress)
+		})
+	}
+}
+
+func TestDataExportUseCaseImpl_DownloadLink(t *testing.T) {
+	user := helpers.CreateTestUser()
+	now := time.Now()
+
+	ready, err := entities.NewDataExport(user.ID())
+	require.NoError(t, err)
+	ready.Complete(7, now, time.Hour)
+	pending, err := entities.NewDataExport(user.ID())
+	require.NoError(t, err)
+
+	tests := []struct {
+		name          string
+		export        *entities.DataExport
+		at            time.Time
+		wantExpiresAt time.Time
+		wantErr       error
+	}{
+		{
+			name:          "ready export",
+			export:        ready,
+			at:            now,
+			wantExpiresAt: now.Add(15 * time.Minute),
+		},
+		{
+			name:          "link never outlives the archive",
+			export:        ready,
+			at:            now.Add(55 * time.Minute),
+			wantExpiresAt: *ready.ExpiresAt(),
+		},
+		{
+			name:    "archive not built yet",
+			export:  pending,
+			at:      now,
+			wantErr: ErrDataExportNotReady,
+		},
+		{
+			name:    "archive expired",
+			export:  ready,
+			at:      now.Add(2 * time.Hour),
+			wantErr: ErrDataExportExpired,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			useCase := NewDataExportUseCase(repositories.NewMockDataExportRepository(ctrl), repositories.NewMockUserRepository(ctrl),
+				repositories.NewMockMentalHealthRecordRepository(ctrl), repositories.NewMockSessionRepository(ctrl),
+				services.NewMockStore(ctrl), nil, testDataExportOptions).(*DataExportUseCaseImpl)
+			useCase.now = func() time.Time { return tt.at }
+
+			link, err := useCase.DownloadLink(tt.export)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, link)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.export.ID().String(), link.ExportID)
+			assert.True(t, link.ExpiresAt.After(tt.at))
+			assert.False(t, link.ExpiresAt.After(tt.wantExpiresAt))
+		})
+	}
+}
+
+func TestDataExportUseCaseImpl_OpenDownload(t *testing.T) {
+	user := helpers.CreateTestUser()
+	now := time.Now()
+
+	ready, err := entities.NewDataExport(user.ID())
+	require.NoError(t, err)
+	ready.Complete(7, now, time.Hour)
+
+	tests := []struct {
+		name      string
+		tamper    func(link *commands.DataExportDownloadLink)
+		openAfter time.Duration
+		openErr   error
+		wantErr   error
+	}{
+		{
+			name: "signed link opens the archive",
+		},
+		{
+			name:    "extended expiry",
+			tamper:  func(link *commands.DataExportDownloadLink) { link.ExpiresAt = link.ExpiresAt.Add(time.Hour) },
+			wantErr: ErrInvalidDownloadLink,
+		},
+		{
+			name:    "signature of another export",
+			tamper:  func(link *commands.DataExportDownloadLink) { link.ExportID = value_objects.NewTokenID().String() },
+			wantErr: ErrInvalidDownloadLink,
+		},
+		{
+			name:      "expired link",
+			openAfter: 15 * time.Minute,
+			wantErr:   ErrInvalidDownloadLink,
+		},
+		{
+			name:    "archive removed from the store",
+			openErr: filestore.ErrNotFound,
+			wantErr: ErrDataExportExpired,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockExportRepo := repositories.NewMockDataExportRepository(ctrl)
+			mockStore := services.NewMockStore(ctrl)
+			var recorded *entities.AuditEvent
+			mockAuditRepo := repositories.NewMockAuditEventRepository(ctrl)
+			if tt.tamper == nil && tt.openAfter == 0 {
+				mockExportRepo.EXPECT().GetByID(gomock.Any(), gomock.Any()).Return(ready, nil)
+				if tt.openErr != nil {
+					mockStore.EXPECT().Open(gomock.Any(), dataExportFileKey(ready)).Return(nil, tt.openErr)
+				} else {
+					mockStore.EXPECT().Open(gomock.Any(), dataExportFileKey(ready)).Return(io.NopCloser(bytes.NewReader([]byte("archive"))), nil)
+					mockAuditRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+						func(ctx context.Context, event *entities.AuditEvent) error {
+							recorded = event
+							return nil
+						})
+				}
+			}
+
+			useCase := NewDataExportUseCase(mockExportRepo, repositories.NewMockUserRepository(ctrl), repositories.NewMockMentalHealthRecordRepository(ctrl),
+				repositories.NewMockSessionRepository(ctrl), mockStore, mockAuditRepo, testDataExportOptions).(*DataExportUseCaseImpl)
+			useCase.now = func() time.Time { return now }
+
+			link, err := useCase.DownloadLink(ready)
+			require.NoError(t, err)
+			if tt.tamper != nil {
+				tt.tamper(link)
+			}
+
+			useCase.now = func() time.Time { return now.Add(tt.openAfter) }
+			export, file, err := useCase.OpenDownload(context.Background(), *link)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, file)
+				return
+			}
+
+			require.NoError(t, err)
+			defer file.Close()
+			assert.Equal(t, ready.ID().String(), export.ID().String())
+			content, err := io.ReadAll(file)
+			require.NoError(t, err)
+			assert.Equal(t, "archive", string(content))
+
+			// Whoever holds the link downloads it, the owner is recorded alongside
+			require.NotNil(t, recorded)
+			assert.Equal(t, value_objects.AuditActionDataExportDownloaded, recorded.Action())
+			assert.Nil(t, recorded.ActorID())
+			assert.Equal(t, user.ID().String(), recorded.Metadata()["user_id"])
+		})
+	}
+}
+
+func TestDataExportUseCaseImpl_GetExport(t *testing.T) {
+	user := helpers.CreateTestUser()
+	export, err := entities.NewDataExport(user.ID())
+	require.NoError(t, err)
+
+	tests := []struct {
+		name      string
+		userID    string
+		exportErr error
+		wantErr   error
+	}{
+		{
+			name:   "own export",
+			userID: user.ID().String(),
+		},
+		{
+			name:    "export of another user",
+			userID:  value_objects.NewUserID().String(),
+			wantErr: domainrepositories.ErrDataExportNotFound,
+		},
+		{
+			name:      "unknown export",
+			userID:    user.ID().String(),
+			exportErr: domainrepositories.ErrDataExportNotFound,
+			wantErr:   domainrepositories.ErrDataExportNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockExportRepo := repositories.NewMockDataExportRepository(ctrl)
+			if tt.exportErr != nil {
+				mockExportRepo.EXPECT().GetByID(gomock.Any(), export.ID()).Return(nil, tt.exportErr)
+			} else {
+				mockExportRepo.EXPECT().GetByID(gomock.Any(), export.ID()).Return(export, nil)
+			}
+
+			useCase := NewDataExportUseCase(mockExportRepo, repositories.NewMockUserRepository(ctrl), repositories.NewMockMentalHealthRecordRepository(ctrl),
+				repositories.NewMockSessionRepository(ctrl), services.NewMockStore(ctrl), nil, testDataExportOptions)
+			got, err := useCase.GetExport(context.Background(), tt.userID, export.ID().String())
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, got)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, export.ID().String(), got.ID().String())
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/atdevten/peace/internal/application/commands"
+	"github.com/atdevten/peace/internal/domain/repositories"
+	"github.com/atdevten/peace/internal/domain/value_objects"
+	"github.com/atdevten/peace/internal/pkg/pagination"
+)
+
+type FeedUseCase interface {
+	GetPublicFeed(ctx context.Context, command *commands.GetPublicFeedCommand) (*commands.PublicFeedPage, error)
+}
+
+type FeedUseCaseImpl struct {
+	recordRepo repositories.MentalHealthRecordRepository
+}
+
+func NewFeedUseCase(recordRepo repositories.MentalHealthRecordRepository) FeedUseCase {
+	return &FeedUseCaseImpl{
+		recordRepo: recordRepo,
+	}
+}
+
+func (uc *FeedUseCaseImpl) GetPublicFeed(ctx context.Context, command *commands.GetPublicFeedCommand) (*commands.PublicFeedPage, error) {
+	// Fetch one extra entry to know whether another page exists
+	filter := &repositories.PublicFeedFilter{
+		Limit: command.Limit + 1,
+	}
+
+	// Resume after the previous page
+	if command.Cursor != nil {
+		cursor, err := pagination.DecodeCursor(*command.Cursor)
+		if err != nil {
+			return nil, fmt.Errorf("pagination.DecodeCursor: %w", err)
+		}
+		filter.Cursor = &repositories.MentalHealthRecordCursor{
+			CreatedAt: cursor.CreatedAt,
+			ID:        cursor.ID,
+		}
+	}
+
+	entries, err := uc.recordRepo.GetPublicFeed(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("uc.recordRepo.GetPublicFeed: %w", err)
+	}
+
+	page := &commands.PublicFeedPage{}
+
+	// Trim the look-ahead entry and point the cursor at the last returned one
+	if len(entries) > command.Limit {
+		entries = entries[:command.Limit]
+		last := entries[len(entries)-1]
+		nextCursor := pagination.EncodeCursor(pagination.Cursor{
+			CreatedAt: last.CreatedAt,
+			ID:        last.RecordID.String(),
+		})
+		page.NextCursor = &nextCursor
+	}
+
+	// Load tags for the whole page in one query
+	recordIDs := make([]*value_objects.MentalHealthRecordID, len(entries))
+	for i, entry := range entries {
+		recordIDs[i] = entry.RecordID
+	}
+
+	tagsByRecord, err := uc.recordRepo.GetTagsByRecordIDs(ctx, recordIDs)
+	if err != nil {
+		return nil, fmt.Errorf("uc.recordRepo.GetTagsByRecordIDs: %w", err)
+	}
+
+	page.Entries = make([]commands.FeedEntry, 0, len(entries))
+	for _, entry := range entries {
+		tagNames := tagsByRecord[entry.RecordID.String()]
+		if tagNames == nil {
+			tagNames = []string{}
+		}
+
+		page.Entries = append(page.Entries, commands.FeedEntry{
+			ID:          entry.RecordID.String(),
+			HappyLevel:  entry.HappyLevel,
+			EnergyLevel: entry.EnergyLevel,
+			Notes:       entry.Notes,
+			Tags:        tagNames,
+			CreatedAt:   entry.CreatedAt,
+			Username:    entry.Username,
+			AvatarURL:   entry.AvatarURL,
+		})
+	}
+
+	return page, nil
+}
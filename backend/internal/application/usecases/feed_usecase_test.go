@@ -0,0 +1,132 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/atdevten/peace/internal/application/commands"
+	domainrepositories "github.com/atdevten/peace/internal/domain/repositories"
+	"github.com/atdevten/peace/internal/domain/value_objects"
+	"github.com/atdevten/peace/internal/pkg/pagination"
+	"github.com/atdevten/peace/testutils/helpers"
+	repositories "github.com/atdevten/peace/testutils/mocks/repositories"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func newFeedEntry(createdAt time.Time, username string) *domainrepositories.PublicFeedEntry {
+	return &domainrepositories.PublicFeedEntry{
+		RecordID:    value_objects.NewMentalHealthRecordID(),
+		HappyLevel:  7,
+		EnergyLevel: 6,
+		CreatedAt:   createdAt,
+		Username:    username,
+		AvatarURL:   helpers.StringPtr("https://example.com/" + username + ".png"),
+	}
+}
+
+func TestFeedUseCaseImpl_GetPublicFeed(t *testing.T) {
+	base := time.Date(2023, 12, 1, 10, 0, 0, 0, time.UTC)
+	entries := []*domainrepositories.PublicFeedEntry{
+		newFeedEntry(base.Add(2*time.Hour), "alice"),
+		newFeedEntry(base.Add(time.Hour), "bob"),
+		newFeedEntry(base, "carol"),
+	}
+
+	tests := []struct {
+		name        string
+		limit       int
+		cursor      *string
+		mockEntries []*domainrepositories.PublicFeedEntry
+		mockError   error
+		expectRepo  bool
+		wantLen     int
+		wantCursor  bool
+		wantErr     bool
+		expectedErr string
+	}{
+		{
+			name:        "full page with next cursor",
+			limit:       2,
+			mockEntries: entries,
+			expectRepo:  true,
+			wantLen:     2,
+			wantCursor:  true,
+		},
+		{
+			name:        "last page",
+			limit:       5,
+			mockEntries: entries,
+			expectRepo:  true,
+			wantLen:     3,
+		},
+		{
+			name:        "invalid cursor",
+			limit:       2,
+			cursor:      helpers.StringPtr("garbage"),
+			wantErr:     true,
+			expectedErr: "invalid cursor",
+		},
+		{
+			name:        "repository error",
+			limit:       2,
+			mockError:   errors.New("database error"),
+			expectRepo:  true,
+			wantErr:     true,
+			expectedErr: "database error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRecordRepo := repositories.NewMockMentalHealthRecordRepository(ctrl)
+			if tt.expectRepo {
+				mockRecordRepo.EXPECT().
+					GetPublicFeed(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, filter *domainrepositories.PublicFeedFilter) ([]*domainrepositories.PublicFeedEntry, error) {
+						assert.Equal(t, tt.limit+1, filter.Limit)
+						return tt.mockEntries, tt.mockError
+					})
+				if tt.mockError == nil {
+					mockRecordRepo.EXPECT().GetTagsByRecordIDs(gomock.Any(), gomock.Len(tt.wantLen)).Return(map[string][]string{
+						entries[0].RecordID.String(): {"motivation"},
+					}, nil)
+				}
+			}
+
+			command, err := commands.NewGetPublicFeedCommand(&tt.limit, tt.cursor)
+			require.NoError(t, err)
+
+			useCase := NewFeedUseCase(mockRecordRepo)
+			page, err := useCase.GetPublicFeed(context.Background(), command)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedErr)
+				assert.Nil(t, page)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Len(t, page.Entries, tt.wantLen)
+			assert.Equal(t, "alice", page.Entries[0].Username)
+			assert.Equal(t, []string{"motivation"}, page.Entries[0].Tags)
+			assert.Empty(t, page.Entries[1].Tags)
+
+			if tt.wantCursor {
+				require.NotNil(t, page.NextCursor)
+				cursor, err := pagination.DecodeCursor(*page.NextCursor)
+				require.NoError(t, err)
+				assert.Equal(t, entries[1].RecordID.String(), cursor.ID)
+			} else {
+				assert.Nil(t, page.NextCursor)
+			}
+		})
+	}
+}
@@ -0,0 +1,197 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/atdevten/peace/internal/application/services/ratelimit"
+)
+
+// LoginLockoutOptions tunes how repeated failed logins lock out an email or a client IP address
+type LoginLockoutOptions struct {
+	EmailThreshold int           // failures per email before it is locked out, 0 disables
+	IPThreshold    int           // failures per IP address before it is locked out, 0 disables
+	BaseDelay      time.Duration // first lockout, doubled by every further failure
+	MaxDelay       time.Duration
+	Window         time.Duration // failures are forgotten this long after the first one
+}
+
+// LoginLockedError is returned while the email or the IP address of a login is locked out
+type LoginLockedError struct {
+	RetryAfter time.Duration
+}
+
+func (e *LoginLockedError) Error() string {
+	return "too many failed login attempts, please try again later"
+}
+
+const (
+	// maxMFAChallengeFailures is how many wrong codes a two-factor challenge takes before it is discarded
+	maxMFAChallengeFailures = 5
+	// mfaChallengeWindow outlives the challenge token, so failures are remembered for as long as it is valid
+	mfaChallengeWindow = 10 * time.Minute
+	// maxAccountEmails is how many account emails of one kind an address is sent per accountEmailWindow
+	maxAccountEmails   = 5
+	accountEmailWindow = time.Hour
+)
+
+// errChallengeUntracked refuses two-factor logins without a store: a challenge whose attempts are not
+// counted could be replayed and its codes guessed without limit
+var errChallengeUntracked = errors.New("two-factor challenges cannot be tracked without an attempt store")
+
+// loginThrottle counts failed logins per email and per IP address and locks out either once it reaches
+// its threshold. Unknown emails are counted too, so lockouts do not reveal which accounts exist.
+type loginThrottle struct {
+	store   ratelimit.Store // nil disables lockouts and refuses two-factor challenges
+	options LoginLockoutOptions
+}
+
+type lockoutSubject struct {
+	key       string
+	threshold int
+}
+
+func (t loginThrottle) subjects(email string, ip string) []lockoutSubject {
+	var subjects []lockoutSubject
+	if t.options.EmailThreshold > 0 {
+		subjects = append(subjects, lockoutSubject{key: "email:" + email, threshold: t.options.EmailThreshold})
+	}
+	if ip != "" && t.options.IPThreshold > 0 {
+		subjects = append(subjects, lockoutSubject{key: "ip:" + ip, threshold: t.options.IPThreshold})
+	}
+	return subjects
+}
+
+// check returns a LoginLockedError while the email or the IP address is locked out
+func (t loginThrottle) check(ctx context.Context, email string, ip string) error {
+	if t.store == nil {
+		return nil
+	}
+
+	var retryAfter time.Duration
+	for _, subject := range t.subjects(email, ip) {
+		remaining, err := t.store.LockedFor(ctx, "login:lock:"+subject.key)
+		if err != nil {
+			return fmt.Errorf("t.store.LockedFor: %w", err)
+		}
+		if remaining > retryAfter {
+			retryAfter = remaining
+		}
+	}
+
+	if retryAfter > 0 {
+		return &LoginLockedError{RetryAfter: retryAfter}
+	}
+	return nil
+}
+
+// fail records a failed login, locking out the email or IP address that reached its threshold
+func (t loginThrottle) fail(ctx context.Context, email string, ip string) error {
+	if t.store == nil {
+		return nil
+	}
+
+	for _, subject := range t.subjects(email, ip) {
+		failures, _, err := t.store.Hit(ctx, "login:fail:"+subject.key, t.options.Window)
+		if err != nil {
+			return fmt.Errorf("t.store.Hit: %w", err)
+		}
+		delay := t.delay(failures - int64(subject.threshold))
+		if failures < int64(subject.threshold) || delay <= 0 {
+			continue
+		}
+
+		if err := t.store.Lock(ctx, "login:lock:"+subject.key, delay); err != nil {
+			return fmt.Errorf("t.store.Lock: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// succeed forgets the failures of email. Those of the IP address stand: one valid login says
+// nothing about the other accounts tried from it.
+func (t loginThrottle) succeed(ctx context.Context, email string) error {
+	if t.store == nil || t.options.EmailThreshold <= 0 {
+		return nil
+	}
+
+	if err := t.store.Reset(ctx, "login:fail:email:"+email); err != nil {
+		return fmt.Errorf("t.store.Reset: %w", err)
+	}
+	return nil
+}
+
+// delay is the lockout after excess failures beyond the threshold: BaseDelay doubled per failure, capped at MaxDelay
+func (t loginThrottle) delay(excess int64) time.Duration {
+	delay := t.options.BaseDelay
+	for i := int64(0); i < excess && delay < t.options.MaxDelay; i++ {
+		delay *= 2
+	}
+	if t.options.MaxDelay > 0 && delay > t.options.MaxDelay {
+		delay = t.options.MaxDelay
+	}
+	return delay
+}
+
+// checkChallenge reports whether the two-factor challenge tokenID was spent by a login or has used up its attempts
+func (t loginThrottle) checkChallenge(ctx context.Context, tokenID string) (bool, error) {
+	if t.store == nil {
+		return false, errChallengeUntracked
+	}
+
+	remaining, err := t.store.LockedFor(ctx, "login:mfa:lock:"+tokenID)
+	if err != nil {
+		return false, fmt.Errorf("t.store.LockedFor: %w", err)
+	}
+	return remaining > 0, nil
+}
+
+// failChallenge records a wrong code for the two-factor challenge tokenID and discards the challenge
+// once it reaches maxMFAChallengeFailures
+func (t loginThrottle) failChallenge(ctx context.Context, tokenID string) error {
+	if t.store == nil {
+		return errChallengeUntracked
+	}
+
+	failures, _, err := t.store.Hit(ctx, "login:mfa:fail:"+tokenID, mfaChallengeWindow)
+	if err != nil {
+		return fmt.Errorf("t.store.Hit: %w", err)
+	}
+	if failures < maxMFAChallengeFailures {
+		return nil
+	}
+
+	if err := t.store.Lock(ctx, "login:mfa:lock:"+tokenID, mfaChallengeWindow); err != nil {
+		return fmt.Errorf("t.store.Lock: %w", err)
+	}
+	return nil
+}
+
+// spendChallenge discards the two-factor challenge tokenID once it has logged the user in
+func (t loginThrottle) spendChallenge(ctx context.Context, tokenID string) error {
+	if t.store == nil {
+		return errChallengeUntracked
+	}
+
+	if err := t.store.Lock(ctx, "login:mfa:lock:"+tokenID, mfaChallengeWindow); err != nil {
+		return fmt.Errorf("t.store.Lock: %w", err)
+	}
+	return nil
+}
+
+// allowEmail counts a request for an account email of kind to address and reports whether it may be sent,
+// so the endpoints that send them cannot be used to flood a mailbox
+func (t loginThrottle) allowEmail(ctx context.Context, kind string, address string) (bool, error) {
+	if t.store == nil {
+		return true, nil
+	}
+
+	requests, _, err := t.store.Hit(ctx, "mail:"+kind+":"+address, accountEmailWindow)
+	if err != nil {
+		return false, fmt.Errorf("t.store.Hit: %w", err)
+	}
+	return requests <= maxAccountEmails, nil
+}
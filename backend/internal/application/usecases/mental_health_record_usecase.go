@@ -9,6 +9,7 @@ import (
 	"github.com/atdevten/peace/internal/domain/entities"
 	"github.com/atdevten/peace/internal/domain/repositories"
 	"github.com/atdevten/peace/internal/domain/value_objects"
+	"github.com/atdevten/peace/internal/pkg/pagination"
 	"github.com/atdevten/peace/internal/pkg/timeutil"
 )
 
@@ -17,18 +18,28 @@ type MentalHealthRecordUseCase interface {
 	Update(ctx context.Context, command commands.UpdateMentalHealthRecordCommand) (*entities.MentalHealthRecord, error)
 	Delete(ctx context.Context, command commands.DeleteMentalHealthRecordCommand) error
 	GetByID(ctx context.Context, id string, userID string) (*entities.MentalHealthRecord, error)
-	GetByCondition(ctx context.Context, userID string, startedAt *string, endedAt *string) ([]*entities.MentalHealthRecord, error)
+	GetByCondition(ctx context.Context, command *commands.GetMentalHealthRecordsCommand) (*commands.MentalHealthRecordsPage, error)
 	GetHeatmap(ctx context.Context, command *commands.GetMentalHealthHeatmapCommand) (*commands.MentalHealthHeatmapResult, error)
 	GetStreak(ctx context.Context, command *commands.GetMentalHealthStreakCommand) (*commands.MentalHealthStreakResult, error)
+	GetAnalytics(ctx context.Context, command *commands.GetMentalHealthAnalyticsCommand) (*commands.MentalHealthAnalyticsResult, error)
+	GetTagReport(ctx context.Context, command *commands.GetMentalHealthTagReportCommand) (*commands.MentalHealthTagReportResult, error)
 }
 
+// maxTagsPerRecord caps how many tags a single check-in can carry
+const maxTagsPerRecord = 10
+
+// defaultAnalyticsRange is used when the analytics request omits started_at
+const defaultAnalyticsRange = 30 * 24 * time.Hour
+
 type MentalHealthRecordUseCaseImpl struct {
 	recordRepo repositories.MentalHealthRecordRepository
+	userRepo   repositories.UserRepository
 }
 
-func NewMentalHealthRecordUseCase(recordRepo repositories.MentalHealthRecordRepository) MentalHealthRecordUseCase {
+func NewMentalHealthRecordUseCase(recordRepo repositories.MentalHealthRecordRepository, userRepo repositories.UserRepository) MentalHealthRecordUseCase {
 	return &MentalHealthRecordUseCaseImpl{
 		recordRepo: recordRepo,
+		userRepo:   userRepo,
 	}
 }
 
@@ -45,7 +56,14 @@ func (uc *MentalHealthRecordUseCaseImpl) Create(ctx context.Context, command com
 		return nil, fmt.Errorf("entities.NewMentalHealthRecord: %w", err)
 	}
 
-	// Create in repository
+	// Validate tag names before writing anything
+	tags, err := normalizeRecordTags(command.TagNames)
+	if err != nil {
+		return nil, err
+	}
+	record.SetTags(tags)
+
+	// Create in repository, with the record's labels
 	if err := uc.recordRepo.Create(ctx, record); err != nil {
 		return nil, fmt.Errorf("uc.recordRepo.Create: %w", err)
 	}
@@ -55,6 +73,7 @@ func (uc *MentalHealthRecordUseCaseImpl) Create(ctx context.Context, command com
 	if err != nil {
 		return nil, fmt.Errorf("uc.recordRepo.GetByID: %w", err)
 	}
+	newRecord.SetTags(tags)
 
 	return newRecord, nil
 }
@@ -107,11 +126,26 @@ func (uc *MentalHealthRecordUseCaseImpl) Update(ctx context.Context, command com
 		existingRecord.DeletedAt(),
 	)
 
-	// Update record
+	// Replace the labels only when the caller wants to change them; nil keeps the stored ones
+	if command.TagNames != nil {
+		tags, err := normalizeRecordTags(command.TagNames)
+		if err != nil {
+			return nil, err
+		}
+		updatedRecord.SetTags(tags)
+	}
+
+	// Update record and labels together
 	if err := uc.recordRepo.Update(ctx, updatedRecord); err != nil {
 		return nil, fmt.Errorf("uc.recordRepo.Update: %w", err)
 	}
 
+	if command.TagNames == nil {
+		if err := uc.attachTags(ctx, updatedRecord); err != nil {
+			return nil, err
+		}
+	}
+
 	return updatedRecord, nil
 }
 
@@ -159,44 +193,124 @@ func (uc *MentalHealthRecordUseCaseImpl) GetByID(ctx context.Context, id string,
 		return nil, fmt.Errorf("unauthorized: user does not own this record")
 	}
 
+	if err := uc.attachTags(ctx, record); err != nil {
+		return nil, err
+	}
+
 	return record, nil
 }
 
-func (uc *MentalHealthRecordUseCaseImpl) GetByCondition(ctx context.Context, userID string, startedAt *string, endedAt *string) ([]*entities.MentalHealthRecord, error) {
+func (uc *MentalHealthRecordUseCaseImpl) GetByCondition(ctx context.Context, command *commands.GetMentalHealthRecordsCommand) (*commands.MentalHealthRecordsPage, error) {
 	// Create search condition
-	userIDVO, err := value_objects.NewUserIDFromString(userID)
+	userIDVO, err := value_objects.NewUserIDFromString(command.UserID)
 	if err != nil {
 		return nil, fmt.Errorf("value_objects.NewUserIDFromString: %w", err)
 	}
 
+	// Fetch one extra record to know whether another page exists
+	limit := command.Limit + 1
 	filter := &repositories.MentalHealthRecordFilter{
-		UserID: userIDVO,
+		UserID:    userIDVO,
+		Limit:     &limit,
+		OrderDesc: command.OrderDesc,
 	}
 
 	// Parse date range if provided - now supports ISO 8601 format with timezone
-	if startedAt != nil {
-		startTime, err := timeutil.ParseTime(*startedAt)
+	if command.StartedAt != nil {
+		startTime, err := timeutil.ParseTime(*command.StartedAt)
 		if err != nil {
 			return nil, fmt.Errorf("invalid start date format, expected ISO 8601 (e.g., 2025-08-23T17:00:00.000Z): %w", err)
 		}
 		filter.StartedAt = &startTime
 	}
 
-	if endedAt != nil {
-		endTime, err := timeutil.ParseTime(*endedAt)
+	if command.EndedAt != nil {
+		endTime, err := timeutil.ParseTime(*command.EndedAt)
 		if err != nil {
 			return nil, fmt.Errorf("invalid end date format, expected ISO 8601 (e.g., 2025-08-23T17:00:00.000Z): %w", err)
 		}
 		filter.EndedAt = &endTime
 	}
 
+	// Validate level bounds and status through their value objects
+	if command.Filters.MinHappyLevel != nil {
+		filter.MinHappyLevel, err = value_objects.NewHappyLevel(*command.Filters.MinHappyLevel)
+		if err != nil {
+			return nil, fmt.Errorf("value_objects.NewHappyLevel: %w", err)
+		}
+	}
+	if command.Filters.MaxHappyLevel != nil {
+		filter.MaxHappyLevel, err = value_objects.NewHappyLevel(*command.Filters.MaxHappyLevel)
+		if err != nil {
+			return nil, fmt.Errorf("value_objects.NewHappyLevel: %w", err)
+		}
+	}
+	if command.Filters.MinEnergyLevel != nil {
+		filter.MinEnergyLevel, err = value_objects.NewEnergyLevel(*command.Filters.MinEnergyLevel)
+		if err != nil {
+			return nil, fmt.Errorf("value_objects.NewEnergyLevel: %w", err)
+		}
+	}
+	if command.Filters.MaxEnergyLevel != nil {
+		filter.MaxEnergyLevel, err = value_objects.NewEnergyLevel(*command.Filters.MaxEnergyLevel)
+		if err != nil {
+			return nil, fmt.Errorf("value_objects.NewEnergyLevel: %w", err)
+		}
+	}
+	if command.Filters.Status != nil {
+		filter.Status, err = value_objects.NewMentalHealthRecordStatus(*command.Filters.Status)
+		if err != nil {
+			return nil, fmt.Errorf("value_objects.NewMentalHealthRecordStatus: %w", err)
+		}
+	}
+	filter.Search = command.Filters.Search
+
+	for _, name := range command.Filters.TagNames {
+		tagName, err := value_objects.NewTagName(name)
+		if err != nil {
+			return nil, fmt.Errorf("value_objects.NewTagName: %w", err)
+		}
+		filter.TagNames = append(filter.TagNames, tagName.Value())
+	}
+
+	// Resume after the previous page
+	if command.Cursor != nil {
+		cursor, err := pagination.DecodeCursor(*command.Cursor)
+		if err != nil {
+			return nil, fmt.Errorf("pagination.DecodeCursor: %w", err)
+		}
+		filter.Cursor = &repositories.MentalHealthRecordCursor{
+			CreatedAt: cursor.CreatedAt,
+			ID:        cursor.ID,
+		}
+	}
+
 	// Get records from repository
 	records, err := uc.recordRepo.GetByFilter(ctx, filter)
 	if err != nil {
 		return nil, fmt.Errorf("uc.recordRepo.GetByFilter: %w", err)
 	}
 
-	return records, nil
+	page := &commands.MentalHealthRecordsPage{
+		Records: records,
+	}
+
+	// Trim the look-ahead record and point the cursor at the last returned one
+	if len(records) > command.Limit {
+		page.Records = records[:command.Limit]
+		last := page.Records[len(page.Records)-1]
+		nextCursor := pagination.EncodeCursor(pagination.Cursor{
+			CreatedAt: last.CreatedAt(),
+			ID:        last.ID().String(),
+		})
+		page.NextCursor = &nextCursor
+	}
+
+	if err := uc.attachTags(ctx, page.Records...); err != nil {
+		return nil, err
+	}
+
+	return page, nil
 }
 
 func (uc *MentalHealthRecordUseCaseImpl) GetHeatmap(ctx context.Context, command *commands.GetMentalHealthHeatmapCommand) (*commands.MentalHealthHeatmapResult, error) { // Create search condition
@@ -226,6 +340,12 @@ func (uc *MentalHealthRecordUseCaseImpl) GetHeatmap(ctx context.Context, command
 		filter.EndedAt = &endTime
 	}
 
+	// Resolve the timezone used to group records into days
+	timezone, err := uc.resolveTimezone(ctx, userIDVO, command.Timezone)
+	if err != nil {
+		return nil, err
+	}
+
 	// Get records from repository
 	records, err := uc.recordRepo.GetByFilter(ctx, filter)
 	if err != nil {
@@ -236,8 +356,8 @@ func (uc *MentalHealthRecordUseCaseImpl) GetHeatmap(ctx context.Context, command
 	dateData := make(map[string]commands.HeatmapDataPoint)
 
 	for _, record := range records {
-		// Format date in UTC for consistent grouping
-		date := record.CreatedAt().Format("2006-01-02")
+		// Format date in the resolved timezone so entries land on the user's local day
+		date := timeutil.FormatDate(record.CreatedAt().In(timezone.Location()))
 
 		if existingData, exists := dateData[date]; exists {
 			// Calculate running average for multiple records on same date
@@ -268,6 +388,7 @@ func (uc *MentalHealthRecordUseCaseImpl) GetHeatmap(ctx context.Context, command
 			StartedAt: command.StartedAt,
 			EndedAt:   command.EndedAt,
 		},
+		Timezone: timezone.String(),
 	}
 
 	return result, nil
@@ -280,8 +401,14 @@ func (uc *MentalHealthRecordUseCaseImpl) GetStreak(ctx context.Context, command
 		return nil, fmt.Errorf("value_objects.NewUserIDFromString: %w", err)
 	}
 
+	// Resolve the timezone used to group records into days
+	timezone, err := uc.resolveTimezone(ctx, userIDVO, command.Timezone)
+	if err != nil {
+		return nil, err
+	}
+
 	// Get distinct dates for the user
-	dates, err := uc.recordRepo.GetDistinctDatesForUser(ctx, userIDVO)
+	dates, err := uc.recordRepo.GetDistinctDatesForUser(ctx, userIDVO, timezone.Location())
 	if err != nil {
 		return nil, fmt.Errorf("uc.recordRepo.GetDistinctDatesForUser: %w", err)
 	}
@@ -290,27 +417,251 @@ func (uc *MentalHealthRecordUseCaseImpl) GetStreak(ctx context.Context, command
 		return &commands.MentalHealthStreakResult{
 			Streak:        0,
 			LastEntryDate: nil,
+			Timezone:      timezone.String(),
 		}, nil
 	}
 
 	// Calculate streak using the dates
-	streak := calculateStreakFromDates(dates)
+	streak := calculateStreakFromDates(dates, time.Now().In(timezone.Location()))
 
 	// Return result with last entry date
 	return &commands.MentalHealthStreakResult{
 		Streak:        streak,
 		LastEntryDate: &dates[0], // First element is most recent due to DESC order
+		Timezone:      timezone.String(),
 	}, nil
 }
 
+func (uc *MentalHealthRecordUseCaseImpl) GetAnalytics(ctx context.Context, command *commands.GetMentalHealthAnalyticsCommand) (*commands.MentalHealthAnalyticsResult, error) {
+	// Create user ID value object
+	userIDVO, err := value_objects.NewUserIDFromString(command.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("value_objects.NewUserIDFromString: %w", err)
+	}
+
+	// Parse date range, defaulting to the last 30 days
+	endTime := time.Now().UTC()
+	if command.EndedAt != nil {
+		endTime, err = timeutil.ParseTime(*command.EndedAt)
+		if err != nil {
+			return nil, fmt.Errorf("invalid end date format, expected ISO 8601 (e.g., 2025-08-23T17:00:00.000Z): %w", err)
+		}
+	}
+
+	startTime := endTime.Add(-defaultAnalyticsRange)
+	if command.StartedAt != nil {
+		startTime, err = timeutil.ParseTime(*command.StartedAt)
+		if err != nil {
+			return nil, fmt.Errorf("invalid start date format, expected ISO 8601 (e.g., 2025-08-23T17:00:00.000Z): %w", err)
+		}
+	}
+
+	if startTime.After(endTime) {
+		return nil, fmt.Errorf("started_at must be before ended_at")
+	}
+
+	// Resolve the timezone used to group records into days, weekdays and hours
+	timezone, err := uc.resolveTimezone(ctx, userIDVO, command.Timezone)
+	if err != nil {
+		return nil, err
+	}
+
+	analytics, err := uc.recordRepo.GetAnalytics(ctx, &repositories.MentalHealthRecordAnalyticsFilter{
+		UserID:    userIDVO,
+		StartedAt: startTime,
+		EndedAt:   endTime,
+		Location:  timezone.Location(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("uc.recordRepo.GetAnalytics: %w", err)
+	}
+
+	startedAt := timeutil.FormatTime(startTime)
+	endedAt := timeutil.FormatTime(endTime)
+
+	result := &commands.MentalHealthAnalyticsResult{
+		TotalRecords: analytics.Summary.Count,
+		Happy: commands.LevelStats{
+			Average:    analytics.Summary.HappyAvg,
+			Min:        analytics.Summary.HappyMin,
+			Max:        analytics.Summary.HappyMax,
+			Stddev:     analytics.Summary.HappyStddev,
+			TrendSlope: analytics.Summary.HappyTrendSlope,
+		},
+		Energy: commands.LevelStats{
+			Average:    analytics.Summary.EnergyAvg,
+			Min:        analytics.Summary.EnergyMin,
+			Max:        analytics.Summary.EnergyMax,
+			Stddev:     analytics.Summary.EnergyStddev,
+			TrendSlope: analytics.Summary.EnergyTrendSlope,
+		},
+		Daily:    make([]commands.AnalyticsDailyPoint, 0, len(analytics.Daily)),
+		Weekdays: toAnalyticsBuckets(analytics.Weekdays),
+		Hours:    toAnalyticsBuckets(analytics.Hours),
+		DateRange: commands.DateRange{
+			StartedAt: &startedAt,
+			EndedAt:   &endedAt,
+		},
+		Timezone: timezone.String(),
+	}
+
+	for _, day := range analytics.Daily {
+		result.Daily = append(result.Daily, commands.AnalyticsDailyPoint{
+			Date:            day.Date,
+			Count:           day.Count,
+			HappyAverage:    day.HappyAvg,
+			EnergyAverage:   day.EnergyAvg,
+			HappyRolling7:   day.HappyRolling7,
+			EnergyRolling7:  day.EnergyRolling7,
+			HappyRolling30:  day.HappyRolling30,
+			EnergyRolling30: day.EnergyRolling30,
+		})
+	}
+
+	return result, nil
+}
+
+func (uc *MentalHealthRecordUseCaseImpl) GetTagReport(ctx context.Context, command *commands.GetMentalHealthTagReportCommand) (*commands.MentalHealthTagReportResult, error) {
+	// Create user ID value object
+	userIDVO, err := value_objects.NewUserIDFromString(command.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("value_objects.NewUserIDFromString: %w", err)
+	}
+
+	filter := &repositories.MentalHealthRecordTagReportFilter{
+		UserID: userIDVO,
+	}
+
+	// Parse date range if provided
+	if command.StartedAt != nil {
+		startTime, err := timeutil.ParseTime(*command.StartedAt)
+		if err != nil {
+			return nil, fmt.Errorf("invalid start date format, expected ISO 8601 (e.g., 2025-08-23T17:00:00.000Z): %w", err)
+		}
+		filter.StartedAt = &startTime
+	}
+
+	if command.EndedAt != nil {
+		endTime, err := timeutil.ParseTime(*command.EndedAt)
+		if err != nil {
+			return nil, fmt.Errorf("invalid end date format, expected ISO 8601 (e.g., 2025-08-23T17:00:00.000Z): %w", err)
+		}
+		filter.EndedAt = &endTime
+	}
+
+	averages, err := uc.recordRepo.GetTagMoodAverages(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("uc.recordRepo.GetTagMoodAverages: %w", err)
+	}
+
+	result := &commands.MentalHealthTagReportResult{
+		Tags: make([]commands.TagMoodPoint, 0, len(averages)),
+		DateRange: commands.DateRange{
+			StartedAt: command.StartedAt,
+			EndedAt:   command.EndedAt,
+		},
+	}
+
+	for _, average := range averages {
+		result.Tags = append(result.Tags, commands.TagMoodPoint{
+			TagName:       average.TagName,
+			Count:         average.Count,
+			HappyAverage:  average.HappyAvg,
+			EnergyAverage: average.EnergyAvg,
+		})
+	}
+
+	return result, nil
+}
+
+// normalizeRecordTags validates the label names of a record and drops duplicates; the result is
+// never nil so that an empty list clears the labels
+func normalizeRecordTags(names []string) ([]string, error) {
+	seen := make(map[string]bool)
+	tags := make([]string, 0, len(names))
+
+	for _, name := range names {
+		tagName, err := value_objects.NewTagName(name)
+		if err != nil {
+			return nil, fmt.Errorf("value_objects.NewTagName: %w", err)
+		}
+		if seen[tagName.Value()] {
+			continue
+		}
+		seen[tagName.Value()] = true
+
+		if len(seen) > maxTagsPerRecord {
+			return nil, fmt.Errorf("a record can have at most %d tags", maxTagsPerRecord)
+		}
+
+		tags = append(tags, tagName.Value())
+	}
+
+	return tags, nil
+}
+
+// attachTags loads the tags of the given records in a single query
+func (uc *MentalHealthRecordUseCaseImpl) attachTags(ctx context.Context, records ...*entities.MentalHealthRecord) error {
+	if len(records) == 0 {
+		return nil
+	}
+
+	recordIDs := make([]*value_objects.MentalHealthRecordID, len(records))
+	for i, record := range records {
+		recordIDs[i] = record.ID()
+	}
+
+	tagsByRecord, err := uc.recordRepo.GetTagsByRecordIDs(ctx, recordIDs)
+	if err != nil {
+		return fmt.Errorf("uc.recordRepo.GetTagsByRecordIDs: %w", err)
+	}
+
+	for _, record := range records {
+		record.SetTags(tagsByRecord[record.ID().String()])
+	}
+
+	return nil
+}
+
+// toAnalyticsBuckets maps weekday or hour aggregates to application result points
+func toAnalyticsBuckets(buckets []repositories.MentalHealthRecordBucketAverage) []commands.AnalyticsBucketPoint {
+	points := make([]commands.AnalyticsBucketPoint, 0, len(buckets))
+	for _, bucket := range buckets {
+		points = append(points, commands.AnalyticsBucketPoint{
+			Bucket:        bucket.Bucket,
+			Count:         bucket.Count,
+			HappyAverage:  bucket.HappyAvg,
+			EnergyAverage: bucket.EnergyAvg,
+		})
+	}
+	return points
+}
+
+// resolveTimezone returns the requested override if present, otherwise the user's stored timezone
+func (uc *MentalHealthRecordUseCaseImpl) resolveTimezone(ctx context.Context, userID *value_objects.UserID, override *string) (*value_objects.Timezone, error) {
+	if override != nil {
+		timezone, err := value_objects.NewTimezone(*override)
+		if err != nil {
+			return nil, fmt.Errorf("value_objects.NewTimezone: %w", err)
+		}
+		return timezone, nil
+	}
+
+	user, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("uc.userRepo.GetByID: %w", err)
+	}
+
+	return user.Timezone(), nil
+}
+
 // Helper function to calculate streak from dates
-func calculateStreakFromDates(dates []string) int {
+// now must already be expressed in the timezone the dates were bucketed in
+func calculateStreakFromDates(dates []string, now time.Time) int {
 	if len(dates) == 0 {
 		return 0
 	}
 
-	// Get current date in UTC using timeutil
-	now := time.Now().UTC()
 	today := timeutil.FormatDate(now)
 	yesterday := timeutil.FormatDate(now.AddDate(0, 0, -1))
 
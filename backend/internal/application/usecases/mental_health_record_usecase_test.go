@@ -4,9 +4,13 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/atdevten/peace/internal/application/commands"
 	"github.com/atdevten/peace/internal/domain/entities"
+	domainrepositories "github.com/atdevten/peace/internal/domain/repositories"
+	"github.com/atdevten/peace/internal/domain/value_objects"
+	"github.com/atdevten/peace/internal/pkg/pagination"
 	"github.com/atdevten/peace/testutils/helpers"
 	repositories "github.com/atdevten/peace/testutils/mocks/repositories"
 	"github.com/stretchr/testify/assert"
@@ -91,7 +95,8 @@ func TestMentalHealthRecordUseCaseImpl_Create(t *testing.T) {
 				}
 			}
 
-			useCase := NewMentalHealthRecordUseCase(mockRepo)
+			allowUntaggedRecords(mockRepo)
+			useCase := NewMentalHealthRecordUseCase(mockRepo, repositories.NewMockUserRepository(ctrl))
 			record, err := useCase.Create(context.Background(), tt.command)
 
 			if tt.wantErr {
@@ -186,7 +191,8 @@ func TestMentalHealthRecordUseCaseImpl_Update(t *testing.T) {
 				}
 			}
 
-			useCase := NewMentalHealthRecordUseCase(mockRepo)
+			allowUntaggedRecords(mockRepo)
+			useCase := NewMentalHealthRecordUseCase(mockRepo, repositories.NewMockUserRepository(ctrl))
 			record, err := useCase.Update(context.Background(), tt.command)
 
 			if tt.wantErr {
@@ -265,7 +271,8 @@ func TestMentalHealthRecordUseCaseImpl_Delete(t *testing.T) {
 				}
 			}
 
-			useCase := NewMentalHealthRecordUseCase(mockRepo)
+			allowUntaggedRecords(mockRepo)
+			useCase := NewMentalHealthRecordUseCase(mockRepo, repositories.NewMockUserRepository(ctrl))
 			err := useCase.Delete(context.Background(), tt.command)
 
 			if tt.wantErr {
@@ -332,7 +339,8 @@ func TestMentalHealthRecordUseCaseImpl_GetByID(t *testing.T) {
 				mockRepo.EXPECT().GetByID(gomock.Any(), gomock.Any()).Return(tt.mockRecord, tt.mockError)
 			}
 
-			useCase := NewMentalHealthRecordUseCase(mockRepo)
+			allowUntaggedRecords(mockRepo)
+			useCase := NewMentalHealthRecordUseCase(mockRepo, repositories.NewMockUserRepository(ctrl))
 			record, err := useCase.GetByID(context.Background(), tt.id, tt.userID)
 
 			if tt.wantErr {
@@ -353,8 +361,12 @@ func TestMentalHealthRecordUseCaseImpl_GetByCondition(t *testing.T) {
 		userID      string
 		startedAt   *string
 		endedAt     *string
+		cursor      *string
 		mockRecords []*entities.MentalHealthRecord
 		mockError   error
+		expectRepo  bool
+		wantLen     int
+		wantCursor  bool
 		wantErr     bool
 		expectedErr string
 	}{
@@ -362,6 +374,8 @@ func TestMentalHealthRecordUseCaseImpl_GetByCondition(t *testing.T) {
 			name:        "successful get with no date range",
 			userID:      "550e8400-e29b-41d4-a716-446655440000",
 			mockRecords: []*entities.MentalHealthRecord{helpers.CreateTestMentalHealthRecord()},
+			expectRepo:  true,
+			wantLen:     1,
 			wantErr:     false,
 		},
 		{
@@ -370,8 +384,39 @@ func TestMentalHealthRecordUseCaseImpl_GetByCondition(t *testing.T) {
 			startedAt:   helpers.StringPtr("2023-01-01T00:00:00Z"),
 			endedAt:     helpers.StringPtr("2023-12-31T23:59:59Z"),
 			mockRecords: []*entities.MentalHealthRecord{helpers.CreateTestMentalHealthRecord()},
+			expectRepo:  true,
+			wantLen:     1,
 			wantErr:     false,
 		},
+		{
+			name:   "more records than the page size returns a cursor",
+			userID: "550e8400-e29b-41d4-a716-446655440000",
+			mockRecords: []*entities.MentalHealthRecord{
+				newRecordAt(t, time.Date(2023, 12, 3, 8, 0, 0, 0, time.UTC), 5, 5),
+				newRecordAt(t, time.Date(2023, 12, 2, 8, 0, 0, 0, time.UTC), 5, 5),
+				newRecordAt(t, time.Date(2023, 12, 1, 8, 0, 0, 0, time.UTC), 5, 5),
+			},
+			expectRepo: true,
+			wantLen:    2,
+			wantCursor: true,
+			wantErr:    false,
+		},
+		{
+			name:        "valid cursor",
+			userID:      "550e8400-e29b-41d4-a716-446655440000",
+			cursor:      helpers.StringPtr(pagination.EncodeCursor(pagination.Cursor{CreatedAt: time.Now(), ID: "550e8400-e29b-41d4-a716-446655440001"})),
+			mockRecords: []*entities.MentalHealthRecord{helpers.CreateTestMentalHealthRecord()},
+			expectRepo:  true,
+			wantLen:     1,
+			wantErr:     false,
+		},
+		{
+			name:        "invalid cursor",
+			userID:      "550e8400-e29b-41d4-a716-446655440000",
+			cursor:      helpers.StringPtr("not-a-cursor"),
+			wantErr:     true,
+			expectedErr: "invalid cursor",
+		},
 		{
 			name:        "invalid user ID",
 			userID:      "invalid-id",
@@ -389,6 +434,7 @@ func TestMentalHealthRecordUseCaseImpl_GetByCondition(t *testing.T) {
 			name:        "repository error",
 			userID:      "550e8400-e29b-41d4-a716-446655440000",
 			mockError:   errors.New("database error"),
+			expectRepo:  true,
 			wantErr:     true,
 			expectedErr: "database error",
 		},
@@ -402,22 +448,576 @@ func TestMentalHealthRecordUseCaseImpl_GetByCondition(t *testing.T) {
 
 			// Setup mock repository
 			mockRepo := repositories.NewMockMentalHealthRecordRepository(ctrl)
-			if tt.userID != "invalid-id" && (tt.startedAt == nil || *tt.startedAt != "invalid-date") {
-				mockRepo.EXPECT().GetByFilter(gomock.Any(), gomock.Any()).Return(tt.mockRecords, tt.mockError)
+			if tt.expectRepo {
+				mockRepo.EXPECT().
+					GetByFilter(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, filter *domainrepositories.MentalHealthRecordFilter) ([]*entities.MentalHealthRecord, error) {
+						// Page size of 2 plus one look-ahead record, newest first
+						assert.Equal(t, 3, *filter.Limit)
+						assert.True(t, filter.OrderDesc)
+						assert.Equal(t, tt.cursor != nil, filter.Cursor != nil)
+						return tt.mockRecords, tt.mockError
+					})
 			}
 
-			useCase := NewMentalHealthRecordUseCase(mockRepo)
-			records, err := useCase.GetByCondition(context.Background(), tt.userID, tt.startedAt, tt.endedAt)
+			command, err := commands.NewGetMentalHealthRecordsCommand(tt.userID, tt.startedAt, tt.endedAt, helpers.IntPtr(2), tt.cursor, "", commands.MentalHealthRecordListFilters{})
+			require.NoError(t, err)
+
+			allowUntaggedRecords(mockRepo)
+			useCase := NewMentalHealthRecordUseCase(mockRepo, repositories.NewMockUserRepository(ctrl))
+			page, err := useCase.GetByCondition(context.Background(), command)
 
 			if tt.wantErr {
 				require.Error(t, err)
 				assert.Contains(t, err.Error(), tt.expectedErr)
-				assert.Nil(t, records)
+				assert.Nil(t, page)
 			} else {
 				require.NoError(t, err)
-				assert.NotNil(t, records)
-				assert.Len(t, records, len(tt.mockRecords))
+				assert.NotNil(t, page)
+				assert.Len(t, page.Records, tt.wantLen)
+				if tt.wantCursor {
+					require.NotNil(t, page.NextCursor)
+					cursor, err := pagination.DecodeCursor(*page.NextCursor)
+					require.NoError(t, err)
+					assert.Equal(t, tt.mockRecords[1].ID().String(), cursor.ID)
+				} else {
+					assert.Nil(t, page.NextCursor)
+				}
+			}
+		})
+	}
+}
+
+func TestMentalHealthRecordUseCaseImpl_GetByCondition_Filters(t *testing.T) {
+	tests := []struct {
+		name        string
+		filters     commands.MentalHealthRecordListFilters
+		checkFilter func(t *testing.T, filter *domainrepositories.MentalHealthRecordFilter)
+		wantErr     bool
+		expectedErr string
+	}{
+		{
+			name: "levels, status and search are passed to the repository",
+			filters: commands.MentalHealthRecordListFilters{
+				MinHappyLevel:  helpers.IntPtr(2),
+				MaxHappyLevel:  helpers.IntPtr(8),
+				MaxEnergyLevel: helpers.IntPtr(3),
+				Status:         helpers.StringPtr("private"),
+				Search:         helpers.StringPtr("  sleep  "),
+			},
+			checkFilter: func(t *testing.T, filter *domainrepositories.MentalHealthRecordFilter) {
+				require.NotNil(t, filter.MinHappyLevel)
+				assert.Equal(t, 2, filter.MinHappyLevel.Value())
+				require.NotNil(t, filter.MaxHappyLevel)
+				assert.Equal(t, 8, filter.MaxHappyLevel.Value())
+				assert.Nil(t, filter.MinEnergyLevel)
+				require.NotNil(t, filter.MaxEnergyLevel)
+				assert.Equal(t, 3, filter.MaxEnergyLevel.Value())
+				require.NotNil(t, filter.Status)
+				assert.Equal(t, "private", filter.Status.String())
+				require.NotNil(t, filter.Search)
+				assert.Equal(t, "sleep", *filter.Search)
+			},
+		},
+		{
+			name:    "blank search is ignored",
+			filters: commands.MentalHealthRecordListFilters{Search: helpers.StringPtr("   ")},
+			checkFilter: func(t *testing.T, filter *domainrepositories.MentalHealthRecordFilter) {
+				assert.Nil(t, filter.Search)
+			},
+		},
+		{
+			name:        "happy level out of range",
+			filters:     commands.MentalHealthRecordListFilters{MinHappyLevel: helpers.IntPtr(0)},
+			wantErr:     true,
+			expectedErr: "happy level must be between 1 and 10",
+		},
+		{
+			name:        "energy level out of range",
+			filters:     commands.MentalHealthRecordListFilters{MaxEnergyLevel: helpers.IntPtr(11)},
+			wantErr:     true,
+			expectedErr: "energy level must be between 1 and 10",
+		},
+		{
+			name:        "invalid status",
+			filters:     commands.MentalHealthRecordListFilters{Status: helpers.StringPtr("archived")},
+			wantErr:     true,
+			expectedErr: "invalid mental health record status",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := repositories.NewMockMentalHealthRecordRepository(ctrl)
+			if !tt.wantErr {
+				mockRepo.EXPECT().
+					GetByFilter(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, filter *domainrepositories.MentalHealthRecordFilter) ([]*entities.MentalHealthRecord, error) {
+						tt.checkFilter(t, filter)
+						return nil, nil
+					})
+			}
+
+			command, err := commands.NewGetMentalHealthRecordsCommand("550e8400-e29b-41d4-a716-446655440000", nil, nil, nil, nil, "", tt.filters)
+			require.NoError(t, err)
+
+			allowUntaggedRecords(mockRepo)
+			useCase := NewMentalHealthRecordUseCase(mockRepo, repositories.NewMockUserRepository(ctrl))
+			page, err := useCase.GetByCondition(context.Background(), command)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedErr)
+				assert.Nil(t, page)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.NotNil(t, page)
+		})
+	}
+}
+
+// allowUntaggedRecords lets the record repository mock load the labels of records that carry none
+func allowUntaggedRecords(mockRepo *repositories.MockMentalHealthRecordRepository) {
+	mockRepo.EXPECT().GetTagsByRecordIDs(gomock.Any(), gomock.Any()).Return(map[string][]string{}, nil).AnyTimes()
+}
+
+func TestMentalHealthRecordUseCaseImpl_Create_WithTags(t *testing.T) {
+	tests := []struct {
+		name        string
+		tagNames    []string
+		setupMocks  func(mockRepo *repositories.MockMentalHealthRecordRepository)
+		wantTags    []string
+		wantErr     bool
+		expectedErr string
+	}{
+		{
+			name:     "labels are saved with the record",
+			tagNames: []string{"exercise", "poor sleep", "exercise"},
+			setupMocks: func(mockRepo *repositories.MockMentalHealthRecordRepository) {
+				mockRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, record *entities.MentalHealthRecord) error {
+						assert.Equal(t, []string{"exercise", "poor sleep"}, record.Tags())
+						return nil
+					})
+				mockRepo.EXPECT().GetByID(gomock.Any(), gomock.Any()).Return(helpers.CreateTestMentalHealthRecord(), nil)
+			},
+			wantTags: []string{"exercise", "poor sleep"},
+		},
+		{
+			name:        "invalid tag name",
+			tagNames:    []string{"bad!tag"},
+			setupMocks:  func(*repositories.MockMentalHealthRecordRepository) {},
+			wantErr:     true,
+			expectedErr: "tag name contains invalid characters",
+		},
+		{
+			name:        "too many tags",
+			tagNames:    []string{"t1", "t2", "t3", "t4", "t5", "t6", "t7", "t8", "t9", "t10", "t11"},
+			setupMocks:  func(*repositories.MockMentalHealthRecordRepository) {},
+			wantErr:     true,
+			expectedErr: "a record can have at most 10 tags",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := repositories.NewMockMentalHealthRecordRepository(ctrl)
+			tt.setupMocks(mockRepo)
+
+			command, err := commands.NewCreateMentalHealthRecordCommand("550e8400-e29b-41d4-a716-446655440000", 5, 7, nil, "public", tt.tagNames)
+			require.NoError(t, err)
+
+			useCase := NewMentalHealthRecordUseCase(mockRepo, repositories.NewMockUserRepository(ctrl))
+			record, err := useCase.Create(context.Background(), command)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedErr)
+				assert.Nil(t, record)
+				return
 			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantTags, record.Tags())
+		})
+	}
+}
+
+func TestMentalHealthRecordUseCaseImpl_GetTagReport(t *testing.T) {
+	tests := []struct {
+		name        string
+		command     *commands.GetMentalHealthTagReportCommand
+		mockResult  []*domainrepositories.TagMoodAverage
+		mockError   error
+		expectRepo  bool
+		wantErr     bool
+		expectedErr string
+	}{
+		{
+			name:    "successful report",
+			command: commands.NewGetMentalHealthTagReportCommand("550e8400-e29b-41d4-a716-446655440000", helpers.StringPtr("2023-01-01T00:00:00Z"), nil),
+			mockResult: []*domainrepositories.TagMoodAverage{
+				{TagName: "exercise", Count: 4, HappyAvg: 7.5, EnergyAvg: 6.25},
+				{TagName: "work", Count: 2, HappyAvg: 4, EnergyAvg: 3},
+			},
+			expectRepo: true,
+		},
+		{
+			name:        "invalid end date",
+			command:     commands.NewGetMentalHealthTagReportCommand("550e8400-e29b-41d4-a716-446655440000", nil, helpers.StringPtr("tomorrow")),
+			wantErr:     true,
+			expectedErr: "invalid end date format",
+		},
+		{
+			name:        "repository error",
+			command:     commands.NewGetMentalHealthTagReportCommand("550e8400-e29b-41d4-a716-446655440000", nil, nil),
+			mockError:   errors.New("database error"),
+			expectRepo:  true,
+			wantErr:     true,
+			expectedErr: "database error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := repositories.NewMockMentalHealthRecordRepository(ctrl)
+			if tt.expectRepo {
+				mockRepo.EXPECT().GetTagMoodAverages(gomock.Any(), gomock.Any()).Return(tt.mockResult, tt.mockError)
+			}
+
+			useCase := NewMentalHealthRecordUseCase(mockRepo, repositories.NewMockUserRepository(ctrl))
+			result, err := useCase.GetTagReport(context.Background(), tt.command)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedErr)
+				assert.Nil(t, result)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Len(t, result.Tags, len(tt.mockResult))
+			assert.Equal(t, "exercise", result.Tags[0].TagName)
+			assert.Equal(t, 7.5, result.Tags[0].HappyAverage)
+			assert.Equal(t, 4, result.Tags[0].Count)
+		})
+	}
+}
+
+// newRecordAt builds a record for the fixed test user created at the given instant
+func newRecordAt(t *testing.T, createdAt time.Time, happyLevel int, energyLevel int) *entities.MentalHealthRecord {
+	userID, err := value_objects.NewUserIDFromString("550e8400-e29b-41d4-a716-446655440000")
+	require.NoError(t, err)
+	happy, err := value_objects.NewHappyLevel(happyLevel)
+	require.NoError(t, err)
+	energy, err := value_objects.NewEnergyLevel(energyLevel)
+	require.NoError(t, err)
+	status, err := value_objects.NewMentalHealthRecordStatus("public")
+	require.NoError(t, err)
+
+	return entities.NewMentalHealthRecordFromExisting(
+		value_objects.NewMentalHealthRecordID(),
+		userID,
+		happy,
+		energy,
+		nil,
+		status,
+		createdAt,
+		createdAt,
+		nil,
+	)
+}
+
+func TestMentalHealthRecordUseCaseImpl_GetHeatmap(t *testing.T) {
+	// 2023-12-01 20:00 UTC is 2023-12-02 in Asia/Tokyo, 2023-12-01 in America/New_York
+	lateEvening := time.Date(2023, 12, 1, 20, 0, 0, 0, time.UTC)
+	morning := time.Date(2023, 12, 2, 1, 0, 0, 0, time.UTC)
+
+	tokyoUser := helpers.CreateTestUser()
+	require.NoError(t, tokyoUser.UpdateTimezone("Asia/Tokyo"))
+
+	tests := []struct {
+		name         string
+		command      *commands.GetMentalHealthHeatmapCommand
+		mockUser     *entities.User
+		mockUserErr  error
+		expectedDays map[string]int
+		expectedTZ   string
+		wantErr      bool
+		expectedErr  string
+	}{
+		{
+			name:         "buckets by user timezone",
+			command:      commands.NewGetMentalHealthHeatmapCommand("550e8400-e29b-41d4-a716-446655440000", nil, nil, nil),
+			mockUser:     tokyoUser,
+			expectedDays: map[string]int{"2023-12-02": 2},
+			expectedTZ:   "Asia/Tokyo",
+		},
+		{
+			name:         "defaults to UTC",
+			command:      commands.NewGetMentalHealthHeatmapCommand("550e8400-e29b-41d4-a716-446655440000", nil, nil, nil),
+			mockUser:     helpers.CreateTestUser(),
+			expectedDays: map[string]int{"2023-12-01": 1, "2023-12-02": 1},
+			expectedTZ:   "UTC",
+		},
+		{
+			name:         "query override wins over user timezone",
+			command:      commands.NewGetMentalHealthHeatmapCommand("550e8400-e29b-41d4-a716-446655440000", nil, nil, helpers.StringPtr("America/New_York")),
+			expectedDays: map[string]int{"2023-12-01": 2},
+			expectedTZ:   "America/New_York",
+		},
+		{
+			name:        "invalid override",
+			command:     commands.NewGetMentalHealthHeatmapCommand("550e8400-e29b-41d4-a716-446655440000", nil, nil, helpers.StringPtr("Nowhere/City")),
+			wantErr:     true,
+			expectedErr: "invalid timezone",
+		},
+		{
+			name:        "user not found",
+			command:     commands.NewGetMentalHealthHeatmapCommand("550e8400-e29b-41d4-a716-446655440000", nil, nil, nil),
+			mockUserErr: errors.New("user not found"),
+			wantErr:     true,
+			expectedErr: "user not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := repositories.NewMockMentalHealthRecordRepository(ctrl)
+			mockUserRepo := repositories.NewMockUserRepository(ctrl)
+			if tt.command.Timezone == nil {
+				mockUserRepo.EXPECT().GetByID(gomock.Any(), gomock.Any()).Return(tt.mockUser, tt.mockUserErr)
+			}
+			if !tt.wantErr {
+				mockRepo.EXPECT().GetByFilter(gomock.Any(), gomock.Any()).Return([]*entities.MentalHealthRecord{
+					newRecordAt(t, lateEvening, 4, 6),
+					newRecordAt(t, morning, 8, 8),
+				}, nil)
+			}
+
+			allowUntaggedRecords(mockRepo)
+			useCase := NewMentalHealthRecordUseCase(mockRepo, mockUserRepo)
+			result, err := useCase.GetHeatmap(context.Background(), tt.command)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedErr)
+				assert.Nil(t, result)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedTZ, result.Timezone)
+			assert.Equal(t, 2, result.TotalRecords)
+			assert.Len(t, result.Data, len(tt.expectedDays))
+			for day, count := range tt.expectedDays {
+				assert.Equal(t, count, result.Data[day].Count, day)
+			}
+		})
+	}
+}
+
+func TestMentalHealthRecordUseCaseImpl_GetStreak(t *testing.T) {
+	tests := []struct {
+		name           string
+		command        *commands.GetMentalHealthStreakCommand
+		mockDates      []string
+		expectedStreak int
+		expectedTZ     string
+	}{
+		{
+			name:           "no entries",
+			command:        commands.NewGetMentalHealthStreakCommand("550e8400-e29b-41d4-a716-446655440000", helpers.StringPtr("Asia/Tokyo")),
+			mockDates:      nil,
+			expectedStreak: 0,
+			expectedTZ:     "Asia/Tokyo",
+		},
+		{
+			name:    "consecutive days ending today in the requested timezone",
+			command: commands.NewGetMentalHealthStreakCommand("550e8400-e29b-41d4-a716-446655440000", helpers.StringPtr("Pacific/Kiritimati")),
+			mockDates: func() []string {
+				loc, _ := time.LoadLocation("Pacific/Kiritimati")
+				now := time.Now().In(loc)
+				return []string{
+					now.Format("2006-01-02"),
+					now.AddDate(0, 0, -1).Format("2006-01-02"),
+					now.AddDate(0, 0, -2).Format("2006-01-02"),
+				}
+			}(),
+			expectedStreak: 3,
+			expectedTZ:     "Pacific/Kiritimati",
+		},
+		{
+			name:           "stale entries break the streak",
+			command:        commands.NewGetMentalHealthStreakCommand("550e8400-e29b-41d4-a716-446655440000", helpers.StringPtr("UTC")),
+			mockDates:      []string{"2020-01-02", "2020-01-01"},
+			expectedStreak: 0,
+			expectedTZ:     "UTC",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := repositories.NewMockMentalHealthRecordRepository(ctrl)
+			mockRepo.EXPECT().
+				GetDistinctDatesForUser(gomock.Any(), gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, _ *value_objects.UserID, loc *time.Location) ([]string, error) {
+					assert.Equal(t, tt.expectedTZ, loc.String())
+					return tt.mockDates, nil
+				})
+
+			allowUntaggedRecords(mockRepo)
+			useCase := NewMentalHealthRecordUseCase(mockRepo, repositories.NewMockUserRepository(ctrl))
+			result, err := useCase.GetStreak(context.Background(), tt.command)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedStreak, result.Streak)
+			assert.Equal(t, tt.expectedTZ, result.Timezone)
+		})
+	}
+}
+
+func TestMentalHealthRecordUseCaseImpl_GetAnalytics(t *testing.T) {
+	analytics := &domainrepositories.MentalHealthRecordAnalytics{
+		Summary: domainrepositories.MentalHealthRecordSummary{
+			Count:           3,
+			HappyAvg:        6,
+			HappyMin:        4,
+			HappyMax:        8,
+			HappyStddev:     1.63,
+			HappyTrendSlope: 0.5,
+			EnergyAvg:       5,
+			EnergyMin:       3,
+			EnergyMax:       7,
+		},
+		Daily: []domainrepositories.MentalHealthRecordDailyAverage{
+			{Date: "2023-12-01", Count: 2, HappyAvg: 5, EnergyAvg: 4, HappyRolling7: 5, EnergyRolling7: 4, HappyRolling30: 5, EnergyRolling30: 4},
+			{Date: "2023-12-02", Count: 1, HappyAvg: 8, EnergyAvg: 7, HappyRolling7: 6, EnergyRolling7: 5, HappyRolling30: 6, EnergyRolling30: 5},
+		},
+		Weekdays: []domainrepositories.MentalHealthRecordBucketAverage{{Bucket: 5, Count: 2, HappyAvg: 5, EnergyAvg: 4}},
+		Hours:    []domainrepositories.MentalHealthRecordBucketAverage{{Bucket: 20, Count: 3, HappyAvg: 6, EnergyAvg: 5}},
+	}
+
+	tests := []struct {
+		name        string
+		command     *commands.GetMentalHealthAnalyticsCommand
+		expectRepo  bool
+		mockErr     error
+		checkFilter func(t *testing.T, filter *domainrepositories.MentalHealthRecordAnalyticsFilter)
+		wantErr     bool
+		expectedErr string
+	}{
+		{
+			name:       "explicit range with timezone override",
+			command:    commands.NewGetMentalHealthAnalyticsCommand("550e8400-e29b-41d4-a716-446655440000", helpers.StringPtr("2023-12-01T00:00:00Z"), helpers.StringPtr("2023-12-31T00:00:00Z"), helpers.StringPtr("Asia/Tokyo")),
+			expectRepo: true,
+			checkFilter: func(t *testing.T, filter *domainrepositories.MentalHealthRecordAnalyticsFilter) {
+				assert.Equal(t, time.Date(2023, 12, 1, 0, 0, 0, 0, time.UTC), filter.StartedAt.UTC())
+				assert.Equal(t, time.Date(2023, 12, 31, 0, 0, 0, 0, time.UTC), filter.EndedAt.UTC())
+				assert.Equal(t, "Asia/Tokyo", filter.Location.String())
+			},
+		},
+		{
+			name:       "defaults to the last 30 days",
+			command:    commands.NewGetMentalHealthAnalyticsCommand("550e8400-e29b-41d4-a716-446655440000", nil, nil, helpers.StringPtr("UTC")),
+			expectRepo: true,
+			checkFilter: func(t *testing.T, filter *domainrepositories.MentalHealthRecordAnalyticsFilter) {
+				assert.Equal(t, 30*24*time.Hour, filter.EndedAt.Sub(filter.StartedAt))
+				assert.WithinDuration(t, time.Now(), filter.EndedAt, time.Minute)
+			},
+		},
+		{
+			name:        "start after end",
+			command:     commands.NewGetMentalHealthAnalyticsCommand("550e8400-e29b-41d4-a716-446655440000", helpers.StringPtr("2023-12-31T00:00:00Z"), helpers.StringPtr("2023-12-01T00:00:00Z"), helpers.StringPtr("UTC")),
+			wantErr:     true,
+			expectedErr: "started_at must be before ended_at",
+		},
+		{
+			name:        "invalid start date",
+			command:     commands.NewGetMentalHealthAnalyticsCommand("550e8400-e29b-41d4-a716-446655440000", helpers.StringPtr("yesterday"), nil, helpers.StringPtr("UTC")),
+			wantErr:     true,
+			expectedErr: "invalid start date format",
+		},
+		{
+			name:        "invalid timezone",
+			command:     commands.NewGetMentalHealthAnalyticsCommand("550e8400-e29b-41d4-a716-446655440000", nil, nil, helpers.StringPtr("Nowhere/City")),
+			wantErr:     true,
+			expectedErr: "invalid timezone",
+		},
+		{
+			name:        "repository error",
+			command:     commands.NewGetMentalHealthAnalyticsCommand("550e8400-e29b-41d4-a716-446655440000", nil, nil, helpers.StringPtr("UTC")),
+			expectRepo:  true,
+			mockErr:     errors.New("database error"),
+			wantErr:     true,
+			expectedErr: "uc.recordRepo.GetAnalytics",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := repositories.NewMockMentalHealthRecordRepository(ctrl)
+			if tt.expectRepo {
+				mockRepo.EXPECT().
+					GetAnalytics(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, filter *domainrepositories.MentalHealthRecordAnalyticsFilter) (*domainrepositories.MentalHealthRecordAnalytics, error) {
+						if tt.checkFilter != nil {
+							tt.checkFilter(t, filter)
+						}
+						if tt.mockErr != nil {
+							return nil, tt.mockErr
+						}
+						return analytics, nil
+					})
+			}
+
+			allowUntaggedRecords(mockRepo)
+			useCase := NewMentalHealthRecordUseCase(mockRepo, repositories.NewMockUserRepository(ctrl))
+			result, err := useCase.GetAnalytics(context.Background(), tt.command)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedErr)
+				assert.Nil(t, result)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, 3, result.TotalRecords)
+			assert.Equal(t, 6.0, result.Happy.Average)
+			assert.Equal(t, 4, result.Happy.Min)
+			assert.Equal(t, 8, result.Happy.Max)
+			assert.Equal(t, 0.5, result.Happy.TrendSlope)
+			assert.Equal(t, 7, result.Energy.Max)
+			require.Len(t, result.Daily, 2)
+			assert.Equal(t, "2023-12-02", result.Daily[1].Date)
+			assert.Equal(t, 6.0, result.Daily[1].HappyRolling7)
+			require.Len(t, result.Weekdays, 1)
+			assert.Equal(t, 5, result.Weekdays[0].Bucket)
+			require.Len(t, result.Hours, 1)
+			assert.Equal(t, 20, result.Hours[0].Bucket)
+			assert.NotNil(t, result.DateRange.StartedAt)
+			assert.NotNil(t, result.DateRange.EndedAt)
 		})
 	}
 }
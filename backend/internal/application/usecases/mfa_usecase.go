@@ -0,0 +1,228 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/atdevten/peace/internal/application/commands"
+	"github.com/atdevten/peace/internal/domain/entities"
+	"github.com/atdevten/peace/internal/domain/repositories"
+	"github.com/atdevten/peace/internal/domain/value_objects"
+	"github.com/atdevten/peace/internal/pkg/totp"
+)
+
+var (
+	// ErrMFAAlreadyEnabled is returned when enrolling while a confirmed factor exists
+	ErrMFAAlreadyEnabled = errors.New("two-factor authentication is already enabled")
+	// ErrMFANotEnabled is returned when a flow needs a factor the user does not have
+	ErrMFANotEnabled = errors.New("two-factor authentication is not enabled")
+)
+
+type MFAUseCase interface {
+	Status(ctx context.Context, userID string) (*commands.MFAStatus, error)
+	// EnrollTOTP starts (or restarts) a pending enrollment
+	EnrollTOTP(ctx context.Context, userID string) (*commands.TOTPEnrollment, error)
+	// ConfirmTOTP activates the pending enrollment and returns recovery codes, shown only once
+	ConfirmTOTP(ctx context.Context, userID string, code string) ([]string, error)
+	DisableTOTP(ctx context.Context, userID string, code string) error
+	RegenerateRecoveryCodes(ctx context.Context, userID string, code string) ([]string, error)
+}
+
+type MFAUseCaseImpl struct {
+	userRepo     repositories.UserRepository
+	totpRepo     repositories.TOTPFactorRepository
+	secondFactor secondFactor
+	auditTrail   auditTrail
+	issuer       string
+}
+
+// NewMFAUseCase creates the use case; issuer is the account name shown in authenticator apps
+func NewMFAUseCase(
+	userRepo repositories.UserRepository,
+	totpRepo repositories.TOTPFactorRepository,
+	recoveryCodeRepo repositories.RecoveryCodeRepository,
+	auditRepo repositories.AuditEventRepository,
+	issuer string,
+) MFAUseCase {
+	return &MFAUseCaseImpl{
+		userRepo:     userRepo,
+		totpRepo:     totpRepo,
+		secondFactor: secondFactor{totpRepo: totpRepo, recoveryCodeRepo: recoveryCodeRepo},
+		auditTrail:   auditTrail{repo: auditRepo},
+		issuer:       issuer,
+	}
+}
+
+func (uc *MFAUseCaseImpl) Status(ctx context.Context, userID string) (*commands.MFAStatus, error) {
+	userIDVO, err := value_objects.NewUserIDFromString(userID)
+	if err != nil {
+		return nil, fmt.Errorf("value_objects.NewUserIDFromString: %w", err)
+	}
+
+	factor, err := uc.secondFactor.confirmedFactor(ctx, userIDVO)
+	if err != nil {
+		return nil, fmt.Errorf("uc.secondFactor.confirmedFactor: %w", err)
+	}
+	if factor == nil {
+		return &commands.MFAStatus{}, nil
+	}
+
+	remaining, err := uc.secondFactor.recoveryCodeRepo.CountUnused(ctx, userIDVO)
+	if err != nil {
+		return nil, fmt.Errorf("uc.recoveryCodeRepo.CountUnused: %w", err)
+	}
+
+	return &commands.MFAStatus{Enabled: true, RemainingRecoveryCodes: remaining}, nil
+}
+
+func (uc *MFAUseCaseImpl) EnrollTOTP(ctx context.Context, userID string) (*commands.TOTPEnrollment, error) {
+	userIDVO, err := value_objects.NewUserIDFromString(userID)
+	if err != nil {
+		return nil, fmt.Errorf("value_objects.NewUserIDFromString: %w", err)
+	}
+
+	user, err := uc.userRepo.GetByID(ctx, userIDVO)
+	if err != nil {
+		return nil, fmt.Errorf("uc.userRepo.GetByID: %w", err)
+	}
+
+	factor, err := uc.secondFactor.confirmedFactor(ctx, user.ID())
+	if err != nil {
+		return nil, fmt.Errorf("uc.secondFactor.confirmedFactor: %w", err)
+	}
+	if factor != nil {
+		return nil, ErrMFAAlreadyEnabled
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return nil, fmt.Errorf("totp.GenerateSecret: %w", err)
+	}
+
+	pending, err := entities.NewTOTPFactor(user.ID(), secret)
+	if err != nil {
+		return nil, fmt.Errorf("entities.NewTOTPFactor: %w", err)
+	}
+
+	if err := uc.totpRepo.Save(ctx, pending); err != nil {
+		return nil, fmt.Errorf("uc.totpRepo.Save: %w", err)
+	}
+
+	return &commands.TOTPEnrollment{
+		Secret: secret,
+		URI:    totp.URI(uc.issuer, user.Email().String(), secret),
+	}, nil
+}
+
+func (uc *MFAUseCaseImpl) ConfirmTOTP(ctx context.Context, userID string, code string) ([]string, error) {
+	userIDVO, err := value_objects.NewUserIDFromString(userID)
+	if err != nil {
+		return nil, fmt.Errorf("value_objects.NewUserIDFromString: %w", err)
+	}
+
+	factor, err := uc.totpRepo.GetByUserID(ctx, userIDVO)
+	if err != nil {
+		if errors.Is(err, repositories.ErrTOTPFactorNotFound) {
+			return nil, errors.New("no two-factor enrollment in progress")
+		}
+		return nil, fmt.Errorf("uc.totpRepo.GetByUserID: %w", err)
+	}
+	if factor.IsConfirmed() {
+		return nil, ErrMFAAlreadyEnabled
+	}
+
+	// The first code proves the authenticator app holds the secret
+	step, ok := totp.Validate(factor.Secret(), code, time.Now())
+	if !ok {
+		return nil, ErrInvalidMFACode
+	}
+
+	if err := factor.Confirm(step, time.Now()); err != nil {
+		return nil, fmt.Errorf("factor.Confirm: %w", err)
+	}
+
+	if err := uc.totpRepo.Confirm(ctx, factor); err != nil {
+		if errors.Is(err, repositories.ErrTOTPFactorNotFound) {
+			return nil, errors.New("no two-factor enrollment in progress")
+		}
+		return nil, fmt.Errorf("uc.totpRepo.Confirm: %w", err)
+	}
+
+	codes, err := uc.secondFactor.regenerateRecoveryCodes(ctx, userIDVO)
+	if err != nil {
+		return nil, fmt.Errorf("uc.secondFactor.regenerateRecoveryCodes: %w", err)
+	}
+
+	uc.recordFactorChange(ctx, value_objects.AuditActionMFAEnabled, userIDVO)
+
+	return codes, nil
+}
+
+func (uc *MFAUseCaseImpl) DisableTOTP(ctx context.Context, userID string, code string) error {
+	userIDVO, factor, err := uc.verifiedFactor(ctx, userID, code)
+	if err != nil {
+		return err
+	}
+
+	if err := uc.totpRepo.Delete(ctx, factor.UserID()); err != nil {
+		return fmt.Errorf("uc.totpRepo.Delete: %w", err)
+	}
+
+	if err := uc.secondFactor.recoveryCodeRepo.DeleteByUserID(ctx, userIDVO); err != nil {
+		return fmt.Errorf("uc.recoveryCodeRepo.DeleteByUserID: %w", err)
+	}
+
+	uc.recordFactorChange(ctx, value_objects.AuditActionMFADisabled, userIDVO)
+
+	return nil
+}
+
+func (uc *MFAUseCaseImpl) RegenerateRecoveryCodes(ctx context.Context, userID string, code string) ([]string, error) {
+	userIDVO, _, err := uc.verifiedFactor(ctx, userID, code)
+	if err != nil {
+		return nil, err
+	}
+
+	codes, err := uc.secondFactor.regenerateRecoveryCodes(ctx, userIDVO)
+	if err != nil {
+		return nil, fmt.Errorf("uc.secondFactor.regenerateRecoveryCodes: %w", err)
+	}
+
+	uc.recordFactorChange(ctx, value_objects.AuditActionRecoveryCodesRegenerated, userIDVO)
+
+	return codes, nil
+}
+
+// recordFactorChange audits a change to the user's second factor, made by the user themselves
+func (uc *MFAUseCaseImpl) recordFactorChange(ctx context.Context, action value_objects.AuditAction, userID *value_objects.UserID) {
+	uc.auditTrail.record(ctx, auditEntry{
+		action:     action,
+		actorID:    userID,
+		targetType: entities.AuditTargetUser,
+		targetID:   userID.String(),
+	})
+}
+
+// verifiedFactor loads the user's active factor and checks a code against it
+func (uc *MFAUseCaseImpl) verifiedFactor(ctx context.Context, userID string, code string) (*value_objects.UserID, *entities.TOTPFactor, error) {
+	userIDVO, err := value_objects.NewUserIDFromString(userID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("value_objects.NewUserIDFromString: %w", err)
+	}
+
+	factor, err := uc.secondFactor.confirmedFactor(ctx, userIDVO)
+	if err != nil {
+		return nil, nil, fmt.Errorf("uc.secondFactor.confirmedFactor: %w", err)
+	}
+	if factor == nil {
+		return nil, nil, ErrMFANotEnabled
+	}
+
+	if err := uc.secondFactor.verify(ctx, factor, code); err != nil {
+		return nil, nil, err
+	}
+
+	return userIDVO, factor, nil
+}
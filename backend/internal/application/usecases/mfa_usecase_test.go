@@ -0,0 +1,233 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/atdevten/peace/internal/domain/entities"
+	domainrepositories "github.com/atdevten/peace/internal/domain/repositories"
+	"github.com/atdevten/peace/internal/domain/value_objects"
+	"github.com/atdevten/peace/internal/pkg/totp"
+	"github.com/atdevten/peace/testutils/helpers"
+	repositories "github.com/atdevten/peace/testutils/mocks/repositories"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+const testTOTPSecret = "JBSWY3DPEHPK3PXP"
+
+func newConfirmedTOTPFactor(userID *value_objects.UserID) *entities.TOTPFactor {
+	confirmedAt := time.Now().Add(-time.Hour)
+	return entities.NewTOTPFactorFromRepository(userID, testTOTPSecret, &confirmedAt, 0, confirmedAt)
+}
+
+func currentTOTPCode(t *testing.T) string {
+	code, err := totp.CodeAt(testTOTPSecret, totp.Step(time.Now()))
+	require.NoError(t, err)
+	return code
+}
+
+func TestMFAUseCaseImpl_EnrollTOTP(t *testing.T) {
+	user := helpers.CreateTestUser()
+
+	tests := []struct {
+		name      string
+		existing  *entities.TOTPFactor
+		wantErr   error
+		expectNew bool
+	}{
+		{
+			name:      "new enrollment",
+			expectNew: true,
+		},
+		{
+			name:      "pending enrollment is replaced",
+			existing:  entities.NewTOTPFactorFromRepository(user.ID(), testTOTPSecret, nil, 0, time.Now()),
+			expectNew: true,
+		},
+		{
+			name:     "already enabled",
+			existing: newConfirmedTOTPFactor(user.ID()),
+			wantErr:  ErrMFAAlreadyEnabled,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUserRepo := repositories.NewMockUserRepository(ctrl)
+			mockUserRepo.EXPECT().GetByID(gomock.Any(), gomock.Any()).Return(user, nil)
+
+			mockTOTPRepo := repositories.NewMockTOTPFactorRepository(ctrl)
+			if tt.existing != nil {
+				mockTOTPRepo.EXPECT().GetByUserID(gomock.Any(), user.ID()).Return(tt.existing, nil)
+			} else {
+				mockTOTPRepo.EXPECT().GetByUserID(gomock.Any(), user.ID()).Return(nil, domainrepositories.ErrTOTPFactorNotFound)
+			}
+
+			var saved *entities.TOTPFactor
+			if tt.expectNew {
+				mockTOTPRepo.EXPECT().Save(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, factor *entities.TOTPFactor) error {
+						saved = factor
+						return nil
+					})
+			}
+
+			useCase := NewMFAUseCase(mockUserRepo, mockTOTPRepo, repositories.NewMockRecoveryCodeRepository(ctrl), nil, "Peace")
+			enrollment, err := useCase.EnrollTOTP(context.Background(), user.ID().String())
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.NotNil(t, saved)
+			assert.False(t, saved.IsConfirmed())
+			assert.Equal(t, saved.Secret(), enrollment.Secret)
+			assert.Contains(t, enrollment.URI, "otpauth://totp/Peace:")
+			assert.Contains(t, enrollment.URI, "secret="+enrollment.Secret)
+		})
+	}
+}
+
+func TestMFAUseCaseImpl_ConfirmTOTP(t *testing.T) {
+	userID := helpers.CreateTestUserID()
+
+	tests := []struct {
+		name    string
+		code    string
+		pending *entities.TOTPFactor
+		wantErr error
+	}{
+		{
+			name:    "valid first code",
+			code:    currentTOTPCode(t),
+			pending: entities.NewTOTPFactorFromRepository(userID, testTOTPSecret, nil, 0, time.Now()),
+		},
+		{
+			name:    "wrong code",
+			code:    "000000",
+			pending: entities.NewTOTPFactorFromRepository(userID, testTOTPSecret, nil, 0, time.Now()),
+			wantErr: ErrInvalidMFACode,
+		},
+		{
+			name:    "already enabled",
+			code:    currentTOTPCode(t),
+			pending: newConfirmedTOTPFactor(userID),
+			wantErr: ErrMFAAlreadyEnabled,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockTOTPRepo := repositories.NewMockTOTPFactorRepository(ctrl)
+			mockTOTPRepo.EXPECT().GetByUserID(gomock.Any(), userID).Return(tt.pending, nil)
+
+			mockRecoveryRepo := repositories.NewMockRecoveryCodeRepository(ctrl)
+			var recorded *entities.AuditEvent
+			mockAuditRepo := repositories.NewMockAuditEventRepository(ctrl)
+			var stored []*entities.RecoveryCode
+			if tt.wantErr == nil {
+				mockAuditRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, event *entities.AuditEvent) error {
+						recorded = event
+						return nil
+					})
+
+				mockTOTPRepo.EXPECT().Confirm(gomock.Any(), tt.pending).Return(nil)
+				mockRecoveryRepo.EXPECT().ReplaceByUserID(gomock.Any(), userID, gomock.Any()).DoAndReturn(
+					func(ctx context.Context, userID *value_objects.UserID, codes []*entities.RecoveryCode) error {
+						stored = codes
+						return nil
+					})
+			}
+
+			useCase := NewMFAUseCase(repositories.NewMockUserRepository(ctrl), mockTOTPRepo, mockRecoveryRepo, mockAuditRepo, "Peace")
+			codes, err := useCase.ConfirmTOTP(context.Background(), userID.String(), tt.code)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.True(t, tt.pending.IsConfirmed())
+			require.Len(t, codes, recoveryCodeCount)
+			require.Len(t, stored, recoveryCodeCount)
+
+			// Only hashes are stored
+			recoveryCode, err := value_objects.NewRecoveryCodeFromString(codes[0])
+			require.NoError(t, err)
+			assert.Equal(t, recoveryCode.Hash(), stored[0].CodeHash())
+
+			require.NotNil(t, recorded)
+			assert.Equal(t, value_objects.AuditActionMFAEnabled, recorded.Action())
+			assert.Equal(t, userID.String(), recorded.TargetID())
+		})
+	}
+}
+
+func TestMFAUseCaseImpl_DisableTOTP(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userID := helpers.CreateTestUserID()
+
+	mockTOTPRepo := repositories.NewMockTOTPFactorRepository(ctrl)
+	mockRecoveryRepo := repositories.NewMockRecoveryCodeRepository(ctrl)
+	mockAuditRepo := repositories.NewMockAuditEventRepository(ctrl)
+	useCase := NewMFAUseCase(repositories.NewMockUserRepository(ctrl), mockTOTPRepo, mockRecoveryRepo, mockAuditRepo, "Peace")
+
+	// A wrong code leaves the factor in place
+	mockTOTPRepo.EXPECT().GetByUserID(gomock.Any(), userID).Return(newConfirmedTOTPFactor(userID), nil)
+	err := useCase.DisableTOTP(context.Background(), userID.String(), "000000")
+	assert.ErrorIs(t, err, ErrInvalidMFACode)
+
+	mockTOTPRepo.EXPECT().GetByUserID(gomock.Any(), userID).Return(newConfirmedTOTPFactor(userID), nil)
+	mockTOTPRepo.EXPECT().UseStep(gomock.Any(), gomock.Any()).Return(nil)
+	mockTOTPRepo.EXPECT().Delete(gomock.Any(), userID).Return(nil)
+	mockRecoveryRepo.EXPECT().DeleteByUserID(gomock.Any(), userID).Return(nil)
+	mockAuditRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, event *entities.AuditEvent) error {
+			assert.Equal(t, value_objects.AuditActionMFADisabled, event.Action())
+			return nil
+		})
+	require.NoError(t, useCase.DisableTOTP(context.Background(), userID.String(), currentTOTPCode(t)))
+
+	// Nothing to disable
+	mockTOTPRepo.EXPECT().GetByUserID(gomock.Any(), userID).Return(nil, domainrepositories.ErrTOTPFactorNotFound)
+	err = useCase.DisableTOTP(context.Background(), userID.String(), currentTOTPCode(t))
+	assert.ErrorIs(t, err, ErrMFANotEnabled)
+}
+
+func TestMFAUseCaseImpl_Status(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userID := helpers.CreateTestUserID()
+
+	mockTOTPRepo := repositories.NewMockTOTPFactorRepository(ctrl)
+	mockRecoveryRepo := repositories.NewMockRecoveryCodeRepository(ctrl)
+	useCase := NewMFAUseCase(repositories.NewMockUserRepository(ctrl), mockTOTPRepo, mockRecoveryRepo, nil, "Peace")
+
+	mockTOTPRepo.EXPECT().GetByUserID(gomock.Any(), userID).Return(newConfirmedTOTPFactor(userID), nil)
+	mockRecoveryRepo.EXPECT().CountUnused(gomock.Any(), userID).Return(7, nil)
+	status, err := useCase.Status(context.Background(), userID.String())
+	require.NoError(t, err)
+	assert.True(t, status.Enabled)
+	assert.Equal(t, 7, status.RemainingRecoveryCodes)
+
+	mockTOTPRepo.EXPECT().GetByUserID(gomock.Any(), userID).Return(nil, domainrepositories.ErrTOTPFactorNotFound)
+	status, err = useCase.Status(context.Background(), userID.String())
+	require.NoError(t, err)
+	assert.False(t, status.Enabled)
+}
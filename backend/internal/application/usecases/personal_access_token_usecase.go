@@ -0,0 +1,204 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/atdevten/peace/internal/application/commands"
+	"github.com/atdevten/peace/internal/domain/entities"
+	"github.com/atdevten/peace/internal/domain/repositories"
+	"github.com/atdevten/peace/internal/domain/value_objects"
+)
+
+const (
+	// maxPersonalAccessTokens caps the tokens one user can hold
+	maxPersonalAccessTokens = 20
+	// personalAccessTokenTouchInterval limits how often authenticated requests write last_used_at
+	personalAccessTokenTouchInterval = time.Minute
+)
+
+var (
+	// ErrPersonalAccessTokenLimit is returned when a user already holds the maximum number of tokens
+	ErrPersonalAccessTokenLimit = errors.New("token limit reached, delete a token you no longer use first")
+	// ErrScopeNotAllowed is returned when a token would be granted a scope its user's role cannot use
+	ErrScopeNotAllowed = errors.New("quotes:write requires the editor or admin role")
+	// ErrInvalidPersonalAccessToken is deliberately vague so callers cannot probe token state
+	ErrInvalidPersonalAccessToken = errors.New("invalid or expired personal access token")
+)
+
+// PersonalAccessTokenUseCase manages the long-lived tokens users create for scripts and integrations
+type PersonalAccessTokenUseCase interface {
+	ListTokens(ctx context.Context, userID string) ([]*entities.PersonalAccessToken, error)
+	// CreateToken returns the new token and its secret, which is shown to the user this once
+	CreateToken(ctx context.Context, command commands.CreatePersonalAccessTokenCommand) (*entities.PersonalAccessToken, string, error)
+	DeleteToken(ctx context.Context, userID string, tokenID string) error
+	// Authenticate resolves a presented token to the token and the active user it acts as
+	Authenticate(ctx context.Context, token string) (*entities.PersonalAccessToken, *entities.User, error)
+}
+
+type PersonalAccessTokenUseCaseImpl struct {
+	tokenRepo  repositories.PersonalAccessTokenRepository
+	userRepo   repositories.UserRepository
+	auditTrail auditTrail
+}
+
+func NewPersonalAccessTokenUseCase(tokenRepo repositories.PersonalAccessTokenRepository, userRepo repositories.UserRepository, auditRepo repositories.AuditEventRepository) PersonalAccessTokenUseCase {
+	return &PersonalAccessTokenUseCaseImpl{
+		tokenRepo:  tokenRepo,
+		userRepo:   userRepo,
+		auditTrail: auditTrail{repo: auditRepo},
+	}
+}
+
+func (uc *PersonalAccessTokenUseCaseImpl) ListTokens(ctx context.Context, userID string) ([]*entities.PersonalAccessToken, error) {
+	userIDVO, err := value_objects.NewUserIDFromString(userID)
+	if err != nil {
+		return nil, fmt.Errorf("value_objects.NewUserIDFromString: %w", err)
+	}
+
+	tokens, err := uc.tokenRepo.ListByUserID(ctx, userIDVO)
+	if err != nil {
+		return nil, fmt.Errorf("uc.tokenRepo.ListByUserID: %w", err)
+	}
+
+	return tokens, nil
+}
+
+func (uc *PersonalAccessTokenUseCaseImpl) CreateToken(ctx context.Context, command commands.CreatePersonalAccessTokenCommand) (*entities.PersonalAccessToken, string, error) {
+	userIDVO, err := value_objects.NewUserIDFromString(command.UserID)
+	if err != nil {
+		return nil, "", fmt.Errorf("value_objects.NewUserIDFromString: %w", err)
+	}
+
+	user, err := uc.userRepo.GetByID(ctx, userIDVO)
+	if err != nil {
+		return nil, "", fmt.Errorf("uc.userRepo.GetByID: %w", err)
+	}
+
+	scopes := make([]value_objects.TokenScope, 0, len(command.Scopes))
+	for _, raw := range command.Scopes {
+		scope, err := value_objects.NewTokenScope(raw)
+		if err != nil {
+			return nil, "", fmt.Errorf("value_objects.NewTokenScope: %w", err)
+		}
+		if *scope == value_objects.ScopeQuotesWrite && user.Role() != value_objects.RoleEditor && user.Role() != value_objects.RoleAdmin {
+			return nil, "", ErrScopeNotAllowed
+		}
+		scopes = append(scopes, *scope)
+	}
+
+	count, err := uc.tokenRepo.CountByUserID(ctx, userIDVO)
+	if err != nil {
+		return nil, "", fmt.Errorf("uc.tokenRepo.CountByUserID: %w", err)
+	}
+	if count >= maxPersonalAccessTokens {
+		return nil, "", ErrPersonalAccessTokenLimit
+	}
+
+	secret, err := value_objects.NewSecretToken()
+	if err != nil {
+		return nil, "", fmt.Errorf("value_objects.NewSecretToken: %w", err)
+	}
+
+	token, err := entities.NewPersonalAccessToken(userIDVO, command.Name, secret, scopes, command.ExpiresIn)
+	if err != nil {
+		return nil, "", fmt.Errorf("entities.NewPersonalAccessToken: %w", err)
+	}
+
+	if err := uc.tokenRepo.Create(ctx, token); err != nil {
+		return nil, "", fmt.Errorf("uc.tokenRepo.Create: %w", err)
+	}
+
+	uc.auditTrail.record(ctx, auditEntry{
+		action:     value_objects.AuditActionTokenCreated,
+		actorID:    userIDVO,
+		targetType: entities.AuditTargetToken,
+		targetID:   token.ID().String(),
+		metadata:   map[string]interface{}{"name": token.Name(), "scopes": command.Scopes},
+	})
+
+	return token, entities.PersonalAccessTokenPrefix + secret.String(), nil
+}
+
+func (uc *PersonalAccessTokenUseCaseImpl) DeleteToken(ctx context.Context, userID string, tokenID string) error {
+	userIDVO, err := value_objects.NewUserIDFromString(userID)
+	if err != nil {
+		return fmt.Errorf("value_objects.NewUserIDFromString: %w", err)
+	}
+
+	tokenIDVO, err := value_objects.NewTokenIDFromString(tokenID)
+	if err != nil {
+		return fmt.Errorf("value_objects.NewTokenIDFromString: %w", err)
+	}
+
+	token, err := uc.tokenRepo.GetByID(ctx, tokenIDVO)
+	if err != nil {
+		return fmt.Errorf("uc.tokenRepo.GetByID: %w", err)
+	}
+
+	// Other users' tokens are reported as missing so their IDs cannot be probed
+	if !token.BelongsTo(userIDVO) {
+		return fmt.Errorf("uc.tokenRepo.GetByID: %w", repositories.ErrPersonalAccessTokenNotFound)
+	}
+
+	if err := uc.tokenRepo.Delete(ctx, token.ID()); err != nil {
+		return fmt.Errorf("uc.tokenRepo.Delete: %w", err)
+	}
+
+	uc.auditTrail.record(ctx, auditEntry{
+		action:     value_objects.AuditActionTokenDeleted,
+		actorID:    userIDVO,
+		targetType: entities.AuditTargetToken,
+		targetID:   token.ID().String(),
+		metadata:   map[string]interface{}{"name": token.Name()},
+	})
+
+	return nil
+}
+
+func (uc *PersonalAccessTokenUseCaseImpl) Authenticate(ctx context.Context, token string) (*entities.PersonalAccessToken, *entities.User, error) {
+	raw, ok := strings.CutPrefix(token, entities.PersonalAccessTokenPrefix)
+	if !ok {
+		return nil, nil, ErrInvalidPersonalAccessToken
+	}
+
+	secret, err := value_objects.NewSecretTokenFromString(raw)
+	if err != nil {
+		return nil, nil, ErrInvalidPersonalAccessToken
+	}
+
+	stored, err := uc.tokenRepo.GetByHash(ctx, secret.Hash())
+	if err != nil {
+		if errors.Is(err, repositories.ErrPersonalAccessTokenNotFound) {
+			return nil, nil, ErrInvalidPersonalAccessToken
+		}
+		return nil, nil, fmt.Errorf("uc.tokenRepo.GetByHash: %w", err)
+	}
+
+	now := time.Now()
+	if stored.IsExpired(now) {
+		return nil, nil, ErrInvalidPersonalAccessToken
+	}
+
+	// Tokens stop working with their account
+	user, err := uc.userRepo.GetByID(ctx, stored.UserID())
+	if err != nil {
+		return nil, nil, ErrInvalidPersonalAccessToken
+	}
+	if err := user.CanLogin(false); err != nil {
+		return nil, nil, ErrInvalidPersonalAccessToken
+	}
+
+	// Only write activity once per interval to keep authenticated requests cheap
+	if stored.LastUsedAt() == nil || now.Sub(*stored.LastUsedAt()) >= personalAccessTokenTouchInterval {
+		if err := uc.tokenRepo.UpdateLastUsedAt(ctx, stored.ID(), now); err != nil {
+			return nil, nil, fmt.Errorf("uc.tokenRepo.UpdateLastUsedAt: %w", err)
+		}
+		stored.Touch(now)
+	}
+
+	return stored, user, nil
+}
@@ -0,0 +1,259 @@
+package usecases
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/atdevten/peace/internal/application/commands"
+	"github.com/atdevten/peace/internal/domain/entities"
+	domainrepositories "github.com/atdevten/peace/internal/domain/repositories"
+	"github.com/atdevten/peace/internal/domain/value_objects"
+	"github.com/atdevten/peace/testutils/helpers"
+	repositories "github.com/atdevten/peace/testutils/mocks/repositories"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func newTestPersonalAccessToken(userID *value_objects.UserID, secret *value_objects.SecretToken, expiresAt time.Time, lastUsedAt *time.Time) *entities.PersonalAccessToken {
+	return entities.NewPersonalAccessTokenFromRepository(
+		value_objects.NewTokenID(),
+		userID,
+		"notebook",
+		secret.Hash(),
+		[]value_objects.TokenScope{value_objects.ScopeRecordsRead},
+		expiresAt,
+		lastUsedAt,
+		time.Now().Add(-time.Hour),
+	)
+}
+
+func TestPersonalAccessTokenUseCaseImpl_CreateToken(t *testing.T) {
+	tests := []struct {
+		name    string
+		role    value_objects.Role
+		scopes  []string
+		count   int64
+		wantErr string
+	}{
+		{
+			name:   "creates a token with the requested scopes",
+			role:   value_objects.RoleUser,
+			scopes: []string{"records:read", "records:write"},
+		},
+		{
+			name:   "editors may grant quotes:write",
+			role:   value_objects.RoleEditor,
+			scopes: []string{"quotes:write"},
+		},
+		{
+			name:    "regular users may not grant quotes:write",
+			role:    value_objects.RoleUser,
+			scopes:  []string{"records:read", "quotes:write"},
+			wantErr: ErrScopeNotAllowed.Error(),
+		},
+		{
+			name:    "unknown scope",
+			role:    value_objects.RoleUser,
+			scopes:  []string{"users:admin"},
+			wantErr: "invalid token scope",
+		},
+		{
+			name:    "token limit reached",
+			role:    value_objects.RoleUser,
+			scopes:  []string{"records:read"},
+			count:   maxPersonalAccessTokens,
+			wantErr: ErrPersonalAccessTokenLimit.Error(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			user := helpers.CreateTestUser()
+			user.ChangeRole(tt.role)
+
+			mockUserRepo := repositories.NewMockUserRepository(ctrl)
+			mockUserRepo.EXPECT().GetByID(gomock.Any(), gomock.Any()).Return(user, nil)
+
+			mockTokenRepo := repositories.NewMockPersonalAccessTokenRepository(ctrl)
+			mockTokenRepo.EXPECT().CountByUserID(gomock.Any(), gomock.Any()).Return(tt.count, nil).MaxTimes(1)
+			var stored *entities.PersonalAccessToken
+			var recorded *entities.AuditEvent
+			mockAuditRepo := repositories.NewMockAuditEventRepository(ctrl)
+			if tt.wantErr == "" {
+				mockAuditRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, event *entities.AuditEvent) error {
+						recorded = event
+						return nil
+					})
+
+				mockTokenRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, token *entities.PersonalAccessToken) error {
+						stored = token
+						return nil
+					})
+			}
+
+			command, err := commands.NewCreatePersonalAccessTokenCommand(user.ID().String(), "notebook", tt.scopes, nil)
+			require.NoError(t, err)
+
+			useCase := NewPersonalAccessTokenUseCase(mockTokenRepo, mockUserRepo, mockAuditRepo)
+			token, secret, err := useCase.CreateToken(context.Background(), command)
+
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				assert.Nil(t, token)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Same(t, stored, token)
+			assert.Len(t, token.Scopes(), len(tt.scopes))
+			assert.WithinDuration(t, time.Now().Add(90*24*time.Hour), token.ExpiresAt(), time.Minute)
+
+			// Only the hash of the secret after its prefix is stored
+			require.True(t, strings.HasPrefix(secret, entities.PersonalAccessTokenPrefix))
+			raw, err := value_objects.NewSecretTokenFromString(strings.TrimPrefix(secret, entities.PersonalAccessTokenPrefix))
+			require.NoError(t, err)
+			assert.Equal(t, raw.Hash(), token.TokenHash())
+
+			// The secret never reaches the audit log
+			require.NotNil(t, recorded)
+			assert.Equal(t, value_objects.AuditActionTokenCreated, recorded.Action())
+			assert.Equal(t, entities.AuditTargetToken, recorded.TargetType())
+			assert.Equal(t, token.ID().String(), recorded.TargetID())
+			assert.NotContains(t, recorded.Metadata(), "secret")
+		})
+	}
+}
+
+func TestPersonalAccessTokenUseCaseImpl_DeleteToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userID := helpers.CreateTestUserID()
+	secret, err := value_objects.NewSecretToken()
+	require.NoError(t, err)
+	own := newTestPersonalAccessToken(userID, secret, time.Now().Add(time.Hour), nil)
+	other := newTestPersonalAccessToken(value_objects.NewUserID(), secret, time.Now().Add(time.Hour), nil)
+
+	mockTokenRepo := repositories.NewMockPersonalAccessTokenRepository(ctrl)
+	mockTokenRepo.EXPECT().GetByID(gomock.Any(), own.ID()).Return(own, nil)
+	mockTokenRepo.EXPECT().GetByID(gomock.Any(), other.ID()).Return(other, nil)
+	mockTokenRepo.EXPECT().Delete(gomock.Any(), own.ID()).Return(nil)
+
+	var recorded *entities.AuditEvent
+	mockAuditRepo := repositories.NewMockAuditEventRepository(ctrl)
+	mockAuditRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, event *entities.AuditEvent) error {
+			recorded = event
+			return nil
+		})
+
+	useCase := NewPersonalAccessTokenUseCase(mockTokenRepo, repositories.NewMockUserRepository(ctrl), mockAuditRepo)
+	require.NoError(t, useCase.DeleteToken(context.Background(), userID.String(), own.ID().String()))
+	require.NotNil(t, recorded)
+	assert.Equal(t, value_objects.AuditActionTokenDeleted, recorded.Action())
+	assert.Equal(t, own.ID().String(), recorded.TargetID())
+
+	// Other users' tokens are reported as missing
+	err = useCase.DeleteToken(context.Background(), userID.String(), other.ID().String())
+	assert.ErrorIs(t, err, domainrepositories.ErrPersonalAccessTokenNotFound)
+}
+
+func TestPersonalAccessTokenUseCaseImpl_Authenticate(t *testing.T) {
+	secret, err := value_objects.NewSecretToken()
+	require.NoError(t, err)
+	presented := entities.PersonalAccessTokenPrefix + secret.String()
+
+	deactivated := helpers.CreateTestUser()
+	require.NoError(t, deactivated.Deactivate())
+
+	tests := []struct {
+		name        string
+		presented   string
+		token       *entities.PersonalAccessToken
+		tokenErr    error
+		user        *entities.User
+		expectTouch bool
+		wantErr     error
+	}{
+		{
+			name:        "valid token records its first use",
+			presented:   presented,
+			token:       newTestPersonalAccessToken(helpers.CreateTestUserID(), secret, time.Now().Add(time.Hour), nil),
+			user:        helpers.CreateTestUser(),
+			expectTouch: true,
+		},
+		{
+			name:      "recently used token is not written again",
+			presented: presented,
+			token:     newTestPersonalAccessToken(helpers.CreateTestUserID(), secret, time.Now().Add(time.Hour), helpers.TimePtr(time.Now())),
+			user:      helpers.CreateTestUser(),
+		},
+		{
+			name:      "expired token",
+			presented: presented,
+			token:     newTestPersonalAccessToken(helpers.CreateTestUserID(), secret, time.Now().Add(-time.Second), nil),
+			wantErr:   ErrInvalidPersonalAccessToken,
+		},
+		{
+			name:      "unknown token",
+			presented: presented,
+			tokenErr:  domainrepositories.ErrPersonalAccessTokenNotFound,
+			wantErr:   ErrInvalidPersonalAccessToken,
+		},
+		{
+			name:      "deactivated account",
+			presented: presented,
+			token:     newTestPersonalAccessToken(helpers.CreateTestUserID(), secret, time.Now().Add(time.Hour), nil),
+			user:      deactivated,
+			wantErr:   ErrInvalidPersonalAccessToken,
+		},
+		{
+			name:      "not a personal access token",
+			presented: secret.String(),
+			wantErr:   ErrInvalidPersonalAccessToken,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockTokenRepo := repositories.NewMockPersonalAccessTokenRepository(ctrl)
+			if tt.token != nil || tt.tokenErr != nil {
+				mockTokenRepo.EXPECT().GetByHash(gomock.Any(), secret.Hash()).Return(tt.token, tt.tokenErr)
+			}
+			mockUserRepo := repositories.NewMockUserRepository(ctrl)
+			if tt.user != nil {
+				mockUserRepo.EXPECT().GetByID(gomock.Any(), tt.token.UserID()).Return(tt.user, nil)
+			}
+			if tt.expectTouch {
+				mockTokenRepo.EXPECT().UpdateLastUsedAt(gomock.Any(), tt.token.ID(), gomock.Any()).Return(nil)
+			}
+
+			useCase := NewPersonalAccessTokenUseCase(mockTokenRepo, mockUserRepo, nil)
+			token, user, err := useCase.Authenticate(context.Background(), tt.presented)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, token)
+				assert.Nil(t, user)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Same(t, tt.token, token)
+			assert.Same(t, tt.user, user)
+			assert.NotNil(t, token.LastUsedAt())
+		})
+	}
+}
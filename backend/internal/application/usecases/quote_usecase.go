@@ -2,29 +2,48 @@ package usecases
 
 import (
 	"context"
+	"fmt"
+	"html"
+	"math/rand"
+	"strings"
 
+	"github.com/atdevten/peace/internal/application/commands"
 	"github.com/atdevten/peace/internal/domain/entities"
 	"github.com/atdevten/peace/internal/domain/repositories"
 	"github.com/atdevten/peace/internal/domain/value_objects"
+	"github.com/atdevten/peace/internal/pkg/pagination"
 )
 
 type QuoteUseCase interface {
 	CreateQuote(ctx context.Context, content, author string) error
 	GetQuoteByID(ctx context.Context, id string) (*entities.Quote, error)
-	GetAllQuotes(ctx context.Context) ([]*entities.Quote, error)
+	// ListQuotes pages through the quotes matching the command, with their total count
+	ListQuotes(ctx context.Context, command *commands.ListQuotesCommand) (*commands.QuotesPage, error)
+	// SearchQuotes ranks the quotes matching a full-text search, with author and tag facets
+	SearchQuotes(ctx context.Context, command *commands.SearchQuotesCommand) (*commands.QuoteSearchPage, error)
 	GetRandomQuote(ctx context.Context) (*entities.Quote, error)
-	GetQuotesByFilter(ctx context.Context, author *string, content *string) ([]*entities.Quote, error)
 	UpdateQuote(ctx context.Context, id string, content, author string) error
 	DeleteQuote(ctx context.Context, id string) error
 }
 
+// quoteSearchFacetLimit is the number of most frequent authors and tags returned with a search
+const quoteSearchFacetLimit = 10
+
+// snippetHighlighter escapes search snippets for HTML and turns the highlight markers into <mark>
+var snippetHighlighter = strings.NewReplacer(
+	repositories.QuoteHighlightStart, "<mark>",
+	repositories.QuoteHighlightStop, "</mark>",
+)
+
 type QuoteUseCaseImpl struct {
-	quoteRepo repositories.QuoteRepository
+	quoteRepo  repositories.QuoteRepository
+	auditTrail auditTrail
 }
 
-func NewQuoteUseCase(quoteRepo repositories.QuoteRepository) QuoteUseCase {
+func NewQuoteUseCase(quoteRepo repositories.QuoteRepository, auditRepo repositories.AuditEventRepository) QuoteUseCase {
 	return &QuoteUseCaseImpl{
-		quoteRepo: quoteRepo,
+		quoteRepo:  quoteRepo,
+		auditTrail: auditTrail{repo: auditRepo},
 	}
 }
 
@@ -34,7 +53,12 @@ func (u *QuoteUseCaseImpl) CreateQuote(ctx context.Context, content, author stri
 		return err
 	}
 
-	return u.quoteRepo.Create(ctx, quote)
+	if err := u.quoteRepo.Create(ctx, quote); err != nil {
+		return err
+	}
+
+	u.recordQuoteEvent(ctx, value_objects.AuditActionQuoteCreated, quote.ID())
+	return nil
 }
 
 func (u *QuoteUseCaseImpl) GetQuoteByID(ctx context.Context, id string) (*entities.Quote, error) {
@@ -46,20 +70,129 @@ func (u *QuoteUseCaseImpl) GetQuoteByID(ctx context.Context, id string) (*entiti
 	return u.quoteRepo.GetByID(ctx, quoteID)
 }
 
-func (u *QuoteUseCaseImpl) GetAllQuotes(ctx context.Context) ([]*entities.Quote, error) {
-	return u.quoteRepo.GetAll(ctx)
-}
+func (u *QuoteUseCaseImpl) ListQuotes(ctx context.Context, command *commands.ListQuotesCommand) (*commands.QuotesPage, error) {
+	sort := value_objects.QuoteSortCreatedAt
+	if command.Options.Sort != nil {
+		sortVO, err := value_objects.NewQuoteSort(*command.Options.Sort)
+		if err != nil {
+			return nil, fmt.Errorf("value_objects.NewQuoteSort: %w", err)
+		}
+		sort = *sortVO
+	}
 
-func (u *QuoteUseCaseImpl) GetRandomQuote(ctx context.Context) (*entities.Quote, error) {
-	return u.quoteRepo.GetRandom(ctx)
-}
+	// Newest first by default, alphabetical and shuffled orders ascending
+	descending := sort == value_objects.QuoteSortCreatedAt
+	if command.Options.Order != nil {
+		descending = *command.Options.Order == "desc"
+	}
 
-func (u *QuoteUseCaseImpl) GetQuotesByFilter(ctx context.Context, author *string, content *string) ([]*entities.Quote, error) {
 	filter := &repositories.QuoteFilter{
-		Author:  author,
-		Content: content,
+		Author:     command.Options.Author,
+		Content:    command.Options.Content,
+		Sort:       sort,
+		Descending: descending,
+		Offset:     command.Offset,
+	}
+
+	page := &commands.QuotesPage{}
+
+	// Shuffle with the caller's seed, or a new one returned to page through the same order
+	if sort == value_objects.QuoteSortRandom {
+		seed := rand.Int63n(1 << 31)
+		if command.Options.Seed != nil {
+			seed = *command.Options.Seed
+		}
+		filter.Seed = seed
+		page.Seed = &seed
+	}
+
+	total, err := u.quoteRepo.Count(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("u.quoteRepo.Count: %w", err)
+	}
+	page.Total = total
+
+	// Resume after the previous page
+	if command.Cursor != nil {
+		cursor, err := pagination.DecodeCursor(*command.Cursor)
+		if err != nil {
+			return nil, fmt.Errorf("pagination.DecodeCursor: %w", err)
+		}
+		quoteID, err := value_objects.NewQuoteIDFromString(cursor.ID)
+		if err != nil {
+			return nil, fmt.Errorf("value_objects.NewQuoteIDFromString: %w", err)
+		}
+		filter.Cursor = &repositories.QuoteCursor{
+			CreatedAt: cursor.CreatedAt,
+			Author:    cursor.Key,
+			ID:        quoteID.Value(),
+		}
+	}
+
+	// Fetch one extra quote to know whether another page exists
+	filter.Limit = command.Limit + 1
+	quotes, err := u.quoteRepo.GetByFilter(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("u.quoteRepo.GetByFilter: %w", err)
+	}
+
+	// Trim the look-ahead quote and point the cursor at the last returned one
+	if len(quotes) > command.Limit {
+		quotes = quotes[:command.Limit]
+		last := quotes[len(quotes)-1]
+		nextCursor := pagination.Cursor{
+			CreatedAt: last.CreatedAt(),
+			ID:        last.ID().String(),
+		}
+		if sort == value_objects.QuoteSortAuthor {
+			nextCursor.Key = last.Author().Value()
+		}
+		encoded := pagination.EncodeCursor(nextCursor)
+		page.NextCursor = &encoded
 	}
-	return u.quoteRepo.GetByFilter(ctx, filter)
+	page.Quotes = quotes
+
+	return page, nil
+}
+
+func (u *QuoteUseCaseImpl) SearchQuotes(ctx context.Context, command *commands.SearchQuotesCommand) (*commands.QuoteSearchPage, error) {
+	result, err := u.quoteRepo.Search(ctx, &repositories.QuoteSearch{
+		Text:       command.Text,
+		Author:     command.Author,
+		Tag:        command.Tag,
+		Offset:     command.Offset,
+		Limit:      command.Limit,
+		FacetLimit: quoteSearchFacetLimit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("u.quoteRepo.Search: %w", err)
+	}
+
+	page := &commands.QuoteSearchPage{
+		Hits:    make([]commands.QuoteSearchHit, 0, len(result.Hits)),
+		Total:   result.Total,
+		Authors: make([]commands.QuoteFacet, 0, len(result.Authors)),
+		Tags:    make([]commands.QuoteFacet, 0, len(result.Tags)),
+	}
+	for _, hit := range result.Hits {
+		page.Hits = append(page.Hits, commands.QuoteSearchHit{
+			Quote:   hit.Quote,
+			Rank:    hit.Rank,
+			Snippet: snippetHighlighter.Replace(html.EscapeString(hit.Snippet)),
+		})
+	}
+	for _, facet := range result.Authors {
+		page.Authors = append(page.Authors, commands.QuoteFacet{Value: facet.Value, Count: facet.Count})
+	}
+	for _, facet := range result.Tags {
+		page.Tags = append(page.Tags, commands.QuoteFacet{Value: facet.Value, Count: facet.Count})
+	}
+
+	return page, nil
+}
+
+func (u *QuoteUseCaseImpl) GetRandomQuote(ctx context.Context) (*entities.Quote, error) {
+	return u.quoteRepo.GetRandom(ctx)
 }
 
 func (u *QuoteUseCaseImpl) UpdateQuote(ctx context.Context, id string, content, author string) error {
@@ -90,7 +223,12 @@ func (u *QuoteUseCaseImpl) UpdateQuote(ctx context.Context, id string, content,
 		existingQuote.DeletedAt(),
 	)
 
-	return u.quoteRepo.Update(ctx, updatedQuote)
+	if err := u.quoteRepo.Update(ctx, updatedQuote); err != nil {
+		return err
+	}
+
+	u.recordQuoteEvent(ctx, value_objects.AuditActionQuoteUpdated, quoteID)
+	return nil
 }
 
 func (u *QuoteUseCaseImpl) DeleteQuote(ctx context.Context, id string) error {
@@ -99,5 +237,19 @@ func (u *QuoteUseCaseImpl) DeleteQuote(ctx context.Context, id string) error {
 		return err
 	}
 
-	return u.quoteRepo.Delete(ctx, quoteID)
+	if err := u.quoteRepo.Delete(ctx, quoteID); err != nil {
+		return err
+	}
+
+	u.recordQuoteEvent(ctx, value_objects.AuditActionQuoteDeleted, quoteID)
+	return nil
+}
+
+// recordQuoteEvent audits a change to a quote by the caller of the request
+func (u *QuoteUseCaseImpl) recordQuoteEvent(ctx context.Context, action value_objects.AuditAction, quoteID *value_objects.QuoteID) {
+	u.auditTrail.record(ctx, auditEntry{
+		action:     action,
+		targetType: entities.AuditTargetQuote,
+		targetID:   quoteID.String(),
+	})
 }
@@ -4,8 +4,13 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
+	"github.com/atdevten/peace/internal/application/commands"
 	"github.com/atdevten/peace/internal/domain/entities"
+	domainrepositories "github.com/atdevten/peace/internal/domain/repositories"
+	"github.com/atdevten/peace/internal/domain/value_objects"
+	"github.com/atdevten/peace/internal/pkg/pagination"
 	"github.com/atdevten/peace/testutils/helpers"
 	repositories "github.com/atdevten/peace/testutils/mocks/repositories"
 	"github.com/stretchr/testify/assert"
@@ -64,7 +69,7 @@ func TestQuoteUseCaseImpl_CreateQuote(t *testing.T) {
 				mockRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(tt.mockError)
 			}
 
-			useCase := NewQuoteUseCase(mockRepo)
+			useCase := NewQuoteUseCase(mockRepo, nil)
 			err := useCase.CreateQuote(context.Background(), tt.content, tt.author)
 
 			if tt.wantErr {
@@ -119,7 +124,7 @@ func TestQuoteUseCaseImpl_GetQuoteByID(t *testing.T) {
 				mockRepo.EXPECT().GetByID(gomock.Any(), gomock.Any()).Return(tt.mockQuote, tt.mockError)
 			}
 
-			useCase := NewQuoteUseCase(mockRepo)
+			useCase := NewQuoteUseCase(mockRepo, nil)
 			quote, err := useCase.GetQuoteByID(context.Background(), tt.id)
 
 			if tt.wantErr {
@@ -134,24 +139,24 @@ func TestQuoteUseCaseImpl_GetQuoteByID(t *testing.T) {
 	}
 }
 
-func TestQuoteUseCaseImpl_GetAllQuotes(t *testing.T) {
+func TestQuoteUseCaseImpl_GetRandomQuote(t *testing.T) {
 	tests := []struct {
 		name        string
-		mockQuotes  []*entities.Quote
+		mockQuote   *entities.Quote
 		mockError   error
 		wantErr     bool
 		expectedErr string
 	}{
 		{
-			name:       "successful get all",
-			mockQuotes: []*entities.Quote{helpers.CreateTestQuote()},
-			wantErr:    false,
+			name:      "successful get random",
+			mockQuote: helpers.CreateTestQuote(),
+			wantErr:   false,
 		},
 		{
-			name:        "repository error",
-			mockError:   errors.New("database error"),
+			name:        "no quotes available",
+			mockError:   errors.New("no quotes found"),
 			wantErr:     true,
-			expectedErr: "database error",
+			expectedErr: "no quotes found",
 		},
 	}
 
@@ -163,42 +168,109 @@ func TestQuoteUseCaseImpl_GetAllQuotes(t *testing.T) {
 
 			// Setup mock repository
 			mockRepo := repositories.NewMockQuoteRepository(ctrl)
-			mockRepo.EXPECT().GetAll(gomock.Any()).Return(tt.mockQuotes, tt.mockError)
+			mockRepo.EXPECT().GetRandom(gomock.Any()).Return(tt.mockQuote, tt.mockError)
 
-			useCase := NewQuoteUseCase(mockRepo)
-			quotes, err := useCase.GetAllQuotes(context.Background())
+			useCase := NewQuoteUseCase(mockRepo, nil)
+			quote, err := useCase.GetRandomQuote(context.Background())
 
 			if tt.wantErr {
 				require.Error(t, err)
 				assert.Contains(t, err.Error(), tt.expectedErr)
-				assert.Nil(t, quotes)
+				assert.Nil(t, quote)
 			} else {
 				require.NoError(t, err)
-				assert.NotNil(t, quotes)
-				assert.Len(t, quotes, len(tt.mockQuotes))
+				assert.NotNil(t, quote)
 			}
 		})
 	}
 }
 
-func TestQuoteUseCaseImpl_GetRandomQuote(t *testing.T) {
+func TestQuoteUseCaseImpl_ListQuotes(t *testing.T) {
+	intPtr := func(i int) *int { return &i }
+	int64Ptr := func(i int64) *int64 { return &i }
+	authorCursor := pagination.EncodeCursor(pagination.Cursor{CreatedAt: time.Now(), ID: "41", Key: "Seneca"})
+
 	tests := []struct {
 		name        string
-		mockQuote   *entities.Quote
+		options     commands.QuoteListOptions
+		limit       *int
+		offset      *int
+		cursor      *string
+		mockQuotes  []*entities.Quote
+		mockTotal   int64
 		mockError   error
+		checkFilter func(t *testing.T, filter *domainrepositories.QuoteFilter)
+		wantLen     int
+		wantNext    bool
+		wantSeed    *int64
+		skipRepo    bool
 		wantErr     bool
 		expectedErr string
 	}{
 		{
-			name:      "successful get random",
-			mockQuote: helpers.CreateTestQuote(),
-			wantErr:   false,
+			name:       "newest first by default",
+			limit:      intPtr(1),
+			mockQuotes: []*entities.Quote{helpers.CreateTestQuote(), helpers.CreateTestQuote()},
+			mockTotal:  5,
+			checkFilter: func(t *testing.T, filter *domainrepositories.QuoteFilter) {
+				assert.Equal(t, value_objects.QuoteSortCreatedAt, filter.Sort)
+				assert.True(t, filter.Descending)
+				assert.Equal(t, 2, filter.Limit)
+				assert.Nil(t, filter.Cursor)
+			},
+			wantLen:  1,
+			wantNext: true,
 		},
 		{
-			name:        "no quotes available",
-			mockError:   errors.New("no quotes found"),
+			name:       "filtered by author with offset",
+			options:    commands.QuoteListOptions{Author: helpers.StringPtr(" Anonymous "), Sort: helpers.StringPtr("author")},
+			offset:     intPtr(20),
+			mockQuotes: []*entities.Quote{helpers.CreateTestQuote()},
+			mockTotal:  21,
+			checkFilter: func(t *testing.T, filter *domainrepositories.QuoteFilter) {
+				assert.Equal(t, "Anonymous", *filter.Author)
+				assert.Equal(t, value_objects.QuoteSortAuthor, filter.Sort)
+				assert.False(t, filter.Descending)
+				assert.Equal(t, 20, filter.Offset)
+			},
+			wantLen: 1,
+		},
+		{
+			name:       "author cursor",
+			options:    commands.QuoteListOptions{Sort: helpers.StringPtr("author"), Order: helpers.StringPtr("desc")},
+			cursor:     &authorCursor,
+			mockQuotes: []*entities.Quote{},
+			checkFilter: func(t *testing.T, filter *domainrepositories.QuoteFilter) {
+				require.NotNil(t, filter.Cursor)
+				assert.Equal(t, "Seneca", filter.Cursor.Author)
+				assert.Equal(t, 41, filter.Cursor.ID)
+				assert.True(t, filter.Descending)
+			},
+			wantLen: 0,
+		},
+		{
+			name:       "random with seed",
+			options:    commands.QuoteListOptions{Sort: helpers.StringPtr("random"), Seed: int64Ptr(7)},
+			mockQuotes: []*entities.Quote{helpers.CreateTestQuote()},
+			checkFilter: func(t *testing.T, filter *domainrepositories.QuoteFilter) {
+				assert.Equal(t, value_objects.QuoteSortRandom, filter.Sort)
+				assert.Equal(t, int64(7), filter.Seed)
+			},
+			wantLen:  1,
+			wantSeed: int64Ptr(7),
+		},
+		{
+			name:        "invalid sort",
+			options:     commands.QuoteListOptions{Sort: helpers.StringPtr("content")},
+			skipRepo:    true,
 			wantErr:     true,
-			expectedErr: "no quotes found",
+			expectedErr: "invalid quote sort: content",
+		},
+		{
+			name:        "repository error",
+			mockError:   errors.New("database error"),
+			wantErr:     true,
+			expectedErr: "database error",
 		},
 	}
 
@@ -210,48 +282,83 @@ func TestQuoteUseCaseImpl_GetRandomQuote(t *testing.T) {
 
 			// Setup mock repository
 			mockRepo := repositories.NewMockQuoteRepository(ctrl)
-			mockRepo.EXPECT().GetRandom(gomock.Any()).Return(tt.mockQuote, tt.mockError)
+			if !tt.skipRepo {
+				mockRepo.EXPECT().Count(gomock.Any(), gomock.Any()).Return(tt.mockTotal, tt.mockError)
+			}
+			if !tt.wantErr {
+				mockRepo.EXPECT().GetByFilter(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, filter *domainrepositories.QuoteFilter) ([]*entities.Quote, error) {
+						tt.checkFilter(t, filter)
+						return tt.mockQuotes, nil
+					})
+			}
 
-			useCase := NewQuoteUseCase(mockRepo)
-			quote, err := useCase.GetRandomQuote(context.Background())
+			command, err := commands.NewListQuotesCommand(tt.options, tt.limit, tt.offset, tt.cursor)
+			require.NoError(t, err)
+
+			useCase := NewQuoteUseCase(mockRepo, nil)
+			page, err := useCase.ListQuotes(context.Background(), command)
 
 			if tt.wantErr {
 				require.Error(t, err)
 				assert.Contains(t, err.Error(), tt.expectedErr)
-				assert.Nil(t, quote)
-			} else {
-				require.NoError(t, err)
-				assert.NotNil(t, quote)
+				assert.Nil(t, page)
+				return
 			}
+
+			require.NoError(t, err)
+			assert.Len(t, page.Quotes, tt.wantLen)
+			assert.Equal(t, tt.mockTotal, page.Total)
+			assert.Equal(t, tt.wantNext, page.NextCursor != nil)
+			assert.Equal(t, tt.wantSeed, page.Seed)
 		})
 	}
 }
 
-func TestQuoteUseCaseImpl_GetQuotesByFilter(t *testing.T) {
+func TestQuoteUseCaseImpl_ListQuotesRandomSeed(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := repositories.NewMockQuoteRepository(ctrl)
+	mockRepo.EXPECT().Count(gomock.Any(), gomock.Any()).Return(int64(1), nil)
+	mockRepo.EXPECT().GetByFilter(gomock.Any(), gomock.Any()).Return([]*entities.Quote{helpers.CreateTestQuote()}, nil)
+
+	command, err := commands.NewListQuotesCommand(commands.QuoteListOptions{Sort: helpers.StringPtr("random")}, nil, nil, nil)
+	require.NoError(t, err)
+
+	// A seed is picked and returned so that the client can page through the same order
+	useCase := NewQuoteUseCase(mockRepo, nil)
+	page, err := useCase.ListQuotes(context.Background(), command)
+	require.NoError(t, err)
+	require.NotNil(t, page.Seed)
+	assert.GreaterOrEqual(t, *page.Seed, int64(0))
+}
+
+func TestQuoteUseCaseImpl_SearchQuotes(t *testing.T) {
 	tests := []struct {
 		name        string
-		author      *string
-		content     *string
-		mockQuotes  []*entities.Quote
+		mockResult  *domainrepositories.QuoteSearchResult
 		mockError   error
+		wantSnippet string
 		wantErr     bool
 		expectedErr string
 	}{
 		{
-			name:       "successful filter by author",
-			author:     helpers.StringPtr("Anonymous"),
-			mockQuotes: []*entities.Quote{helpers.CreateTestQuote()},
-			wantErr:    false,
-		},
-		{
-			name:       "successful filter by content",
-			content:    helpers.StringPtr("Life"),
-			mockQuotes: []*entities.Quote{helpers.CreateTestQuote()},
-			wantErr:    false,
+			name: "highlights escaped snippets",
+			mockResult: &domainrepositories.QuoteSearchResult{
+				Hits: []domainrepositories.QuoteSearchHit{{
+					Quote:   helpers.CreateTestQuote(),
+					Rank:    0.6,
+					Snippet: "<b>" + domainrepositories.QuoteHighlightStart + "Life" + domainrepositories.QuoteHighlightStop + "</b> is beautiful",
+				}},
+				Total:   1,
+				Authors: []domainrepositories.QuoteFacet{{Value: "Anonymous", Count: 1}},
+				Tags:    []domainrepositories.QuoteFacet{{Value: "motivation", Count: 1}},
+			},
+			wantSnippet: "&lt;b&gt;<mark>Life</mark>&lt;/b&gt; is beautiful",
 		},
 		{
 			name:        "repository error",
-			author:      helpers.StringPtr("Anonymous"),
 			mockError:   errors.New("database error"),
 			wantErr:     true,
 			expectedErr: "database error",
@@ -266,20 +373,35 @@ func TestQuoteUseCaseImpl_GetQuotesByFilter(t *testing.T) {
 
 			// Setup mock repository
 			mockRepo := repositories.NewMockQuoteRepository(ctrl)
-			mockRepo.EXPECT().GetByFilter(gomock.Any(), gomock.Any()).Return(tt.mockQuotes, tt.mockError)
+			mockRepo.EXPECT().Search(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(ctx context.Context, search *domainrepositories.QuoteSearch) (*domainrepositories.QuoteSearchResult, error) {
+					assert.Equal(t, "life", search.Text)
+					assert.Equal(t, "motivation", *search.Tag)
+					assert.Nil(t, search.Author)
+					assert.Greater(t, search.FacetLimit, 0)
+					return tt.mockResult, tt.mockError
+				})
 
-			useCase := NewQuoteUseCase(mockRepo)
-			quotes, err := useCase.GetQuotesByFilter(context.Background(), tt.author, tt.content)
+			command, err := commands.NewSearchQuotesCommand(" life ", helpers.StringPtr(" "), helpers.StringPtr("motivation"), nil, nil)
+			require.NoError(t, err)
+
+			useCase := NewQuoteUseCase(mockRepo, nil)
+			page, err := useCase.SearchQuotes(context.Background(), command)
 
 			if tt.wantErr {
 				require.Error(t, err)
 				assert.Contains(t, err.Error(), tt.expectedErr)
-				assert.Nil(t, quotes)
-			} else {
-				require.NoError(t, err)
-				assert.NotNil(t, quotes)
-				assert.Len(t, quotes, len(tt.mockQuotes))
+				assert.Nil(t, page)
+				return
 			}
+
+			require.NoError(t, err)
+			require.Len(t, page.Hits, 1)
+			assert.Equal(t, tt.wantSnippet, page.Hits[0].Snippet)
+			assert.Equal(t, 0.6, page.Hits[0].Rank)
+			assert.Equal(t, int64(1), page.Total)
+			assert.Equal(t, []commands.QuoteFacet{{Value: "Anonymous", Count: 1}}, page.Authors)
+			assert.Equal(t, []commands.QuoteFacet{{Value: "motivation", Count: 1}}, page.Tags)
 		})
 	}
 }
@@ -355,7 +477,7 @@ func TestQuoteUseCaseImpl_UpdateQuote(t *testing.T) {
 				}
 			}
 
-			useCase := NewQuoteUseCase(mockRepo)
+			useCase := NewQuoteUseCase(mockRepo, nil)
 			err := useCase.UpdateQuote(context.Background(), tt.id, tt.content, tt.author)
 
 			if tt.wantErr {
@@ -410,7 +532,7 @@ func TestQuoteUseCaseImpl_DeleteQuote(t *testing.T) {
 				mockRepo.EXPECT().Delete(gomock.Any(), gomock.Any()).Return(tt.mockError)
 			}
 
-			useCase := NewQuoteUseCase(mockRepo)
+			useCase := NewQuoteUseCase(mockRepo, nil)
 			err := useCase.DeleteQuote(context.Background(), tt.id)
 
 			if tt.wantErr {
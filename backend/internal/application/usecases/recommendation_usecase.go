@@ -0,0 +1,257 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+
+	"github.com/atdevten/peace/internal/application/commands"
+	"github.com/atdevten/peace/internal/domain/entities"
+	"github.com/atdevten/peace/internal/domain/repositories"
+	"github.com/atdevten/peace/internal/domain/value_objects"
+)
+
+type RecommendationUseCase interface {
+	// RecommendQuotes picks quotes tagged for the mood of the user's latest record, leaving out
+	// the quotes the user already liked or skipped
+	RecommendQuotes(ctx context.Context, command *commands.RecommendQuotesCommand) (*commands.QuoteRecommendations, error)
+	// RecordFeedback stores a like or skip of a quote, replacing the user's earlier signal
+	RecordFeedback(ctx context.Context, command *commands.QuoteFeedbackCommand) error
+}
+
+// Levels of a mental health record that mood rules read
+const (
+	MoodLevelHappy  = "happy"
+	MoodLevelEnergy = "energy"
+)
+
+// MoodTagRule recommends quotes carrying any of Tags when Level of the latest record lies
+// within Min..Max
+type MoodTagRule struct {
+	Level string // happy or energy
+	Min   int
+	Max   int
+	Tags  []string
+}
+
+func (r MoodTagRule) matches(record *entities.MentalHealthRecord) bool {
+	var level int
+	switch r.Level {
+	case MoodLevelHappy:
+		level = record.HappyLevel().Value()
+	case MoodLevelEnergy:
+		level = record.EnergyLevel().Value()
+	default:
+		return false
+	}
+	return level >= r.Min && level <= r.Max
+}
+
+// RecommendationOptions configures how moods map to quote tags
+type RecommendationOptions struct {
+	Rules []MoodTagRule // every matching rule adds its tags
+}
+
+// likedTagSources is the number of most recently liked quotes whose tags stand in for a mood
+// that no rule matches
+const likedTagSources = 5
+
+type RecommendationUseCaseImpl struct {
+	quoteRepo    repositories.QuoteRepository
+	recordRepo   repositories.MentalHealthRecordRepository
+	tagRepo      repositories.TagRepository
+	feedbackRepo repositories.QuoteFeedbackRepository
+	options      RecommendationOptions
+}
+
+func NewRecommendationUseCase(
+	quoteRepo repositories.QuoteRepository,
+	recordRepo repositories.MentalHealthRecordRepository,
+	tagRepo repositories.TagRepository,
+	feedbackRepo repositories.QuoteFeedbackRepository,
+	options RecommendationOptions,
+) RecommendationUseCase {
+	return &RecommendationUseCaseImpl{
+		quoteRepo:    quoteRepo,
+		recordRepo:   recordRepo,
+		tagRepo:      tagRepo,
+		feedbackRepo: feedbackRepo,
+		options:      options,
+	}
+}
+
+func (uc *RecommendationUseCaseImpl) RecommendQuotes(ctx context.Context, command *commands.RecommendQuotesCommand) (*commands.QuoteRecommendations, error) {
+	userID, err := value_objects.NewUserIDFromString(command.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("value_objects.NewUserIDFromString: %w", err)
+	}
+
+	record, err := uc.latestRecord(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	feedback, err := uc.feedbackRepo.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("uc.feedbackRepo.ListByUser: %w", err)
+	}
+	seen := make([]*value_objects.QuoteID, 0, len(feedback))
+	var liked []*value_objects.QuoteID
+	for _, item := range feedback {
+		seen = append(seen, item.QuoteID())
+		if item.Signal() == value_objects.QuoteFeedbackLike {
+			liked = append(liked, item.QuoteID())
+		}
+	}
+
+	// The mood picks the tags; without a matching rule, recent likes do
+	result := &commands.QuoteRecommendations{Basis: commands.RecommendationBasisRandom, Record: record}
+	if record != nil {
+		result.Tags = uc.moodTags(record)
+		if len(result.Tags) > 0 {
+			result.Basis = commands.RecommendationBasisMood
+		}
+	}
+	if len(result.Tags) == 0 && len(liked) > 0 {
+		result.Tags, err = uc.likedTags(ctx, liked)
+		if err != nil {
+			return nil, err
+		}
+		if len(result.Tags) > 0 {
+			result.Basis = commands.RecommendationBasisLikes
+		}
+	}
+
+	// The shuffle holds until a new record comes in; feedback moves the next quotes up
+	filter := &repositories.QuoteFilter{
+		ExcludeIDs: seen,
+		TagNames:   result.Tags,
+		Sort:       value_objects.QuoteSortRandom,
+		Seed:       recommendationSeed(userID, record),
+		Limit:      command.Limit,
+	}
+	quotes, err := uc.quoteRepo.GetByFilter(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("uc.quoteRepo.GetByFilter: %w", err)
+	}
+
+	// Top up with other quotes when too few carry the tags
+	if len(result.Tags) > 0 && len(quotes) < command.Limit {
+		filter.TagNames = nil
+		filter.Limit = command.Limit - len(quotes)
+		for _, quote := range quotes {
+			filter.ExcludeIDs = append(filter.ExcludeIDs, quote.ID())
+		}
+		more, err := uc.quoteRepo.GetByFilter(ctx, filter)
+		if err != nil {
+			return nil, fmt.Errorf("uc.quoteRepo.GetByFilter: %w", err)
+		}
+		quotes = append(quotes, more...)
+	}
+
+	result.Quotes = quotes
+	return result, nil
+}
+
+func (uc *RecommendationUseCaseImpl) RecordFeedback(ctx context.Context, command *commands.QuoteFeedbackCommand) error {
+	userID, err := value_objects.NewUserIDFromString(command.UserID)
+	if err != nil {
+		return fmt.Errorf("value_objects.NewUserIDFromString: %w", err)
+	}
+
+	quoteID, err := value_objects.NewQuoteIDFromString(command.QuoteID)
+	if err != nil {
+		return fmt.Errorf("value_objects.NewQuoteIDFromString: %w", err)
+	}
+
+	// Only existing quotes take feedback; "quote not found" reaches the caller as is
+	if _, err := uc.quoteRepo.GetByID(ctx, quoteID); err != nil {
+		return err
+	}
+
+	feedback, err := entities.NewQuoteFeedback(userID, quoteID, command.Signal)
+	if err != nil {
+		return fmt.Errorf("entities.NewQuoteFeedback: %w", err)
+	}
+
+	if err := uc.feedbackRepo.Save(ctx, feedback); err != nil {
+		return fmt.Errorf("uc.feedbackRepo.Save: %w", err)
+	}
+	return nil
+}
+
+// latestRecord returns the newest record of the user, nil when there is none
+func (uc *RecommendationUseCaseImpl) latestRecord(ctx context.Context, userID *value_objects.UserID) (*entities.MentalHealthRecord, error) {
+	limit := 1
+	records, err := uc.recordRepo.GetByFilter(ctx, &repositories.MentalHealthRecordFilter{
+		UserID:    userID,
+		Limit:     &limit,
+		OrderDesc: true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("uc.recordRepo.GetByFilter: %w", err)
+	}
+	if len(records) == 0 {
+		return nil, nil
+	}
+	return records[0], nil
+}
+
+// moodTags collects the tags of the rules matching the record, in rule order without duplicates
+func (uc *RecommendationUseCaseImpl) moodTags(record *entities.MentalHealthRecord) []string {
+	var tags []string
+	for _, rule := range uc.options.Rules {
+		if rule.matches(record) {
+			tags = appendUniqueTags(tags, rule.Tags...)
+		}
+	}
+	return tags
+}
+
+// likedTags collects the tags of the most recently liked quotes
+func (uc *RecommendationUseCaseImpl) likedTags(ctx context.Context, liked []*value_objects.QuoteID) ([]string, error) {
+	if len(liked) > likedTagSources {
+		liked = liked[:likedTagSources]
+	}
+
+	var tags []string
+	for _, quoteID := range liked {
+		quoteTags, err := uc.tagRepo.GetByQuoteID(ctx, quoteID)
+		if err != nil {
+			return nil, fmt.Errorf("uc.tagRepo.GetByQuoteID: %w", err)
+		}
+		for _, tag := range quoteTags {
+			tags = appendUniqueTags(tags, tag.Name().String())
+		}
+	}
+	return tags, nil
+}
+
+func appendUniqueTags(tags []string, names ...string) []string {
+	for _, name := range names {
+		duplicate := false
+		for _, tag := range tags {
+			if tag == name {
+				duplicate = true
+				break
+			}
+		}
+		if !duplicate {
+			tags = append(tags, name)
+		}
+	}
+	return tags
+}
+
+// recommendationSeed derives the shuffle of the recommendations from the user and their latest
+// record, so a new record brings new quotes
+func recommendationSeed(userID *value_objects.UserID, record *entities.MentalHealthRecord) int64 {
+	latest := "none"
+	if record != nil {
+		latest = record.ID().String()
+	}
+
+	hash := fnv.New64a()
+	hash.Write([]byte(userID.String() + "|" + latest))
+	return int64(hash.Sum64() & 0x7fffffff)
+}
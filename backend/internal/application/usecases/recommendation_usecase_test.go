@@ -0,0 +1,264 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/atdevten/peace/internal/application/commands"
+	"github.com/atdevten/peace/internal/domain/entities"
+	domainrepositories "github.com/atdevten/peace/internal/domain/repositories"
+	"github.com/atdevten/peace/internal/domain/value_objects"
+	"github.com/atdevten/peace/testutils/helpers"
+	repositories "github.com/atdevten/peace/testutils/mocks/repositories"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+var testMoodTagRules = []MoodTagRule{
+	{Level: MoodLevelEnergy, Min: 1, Max: 4, Tags: []string{"motivation", "energy"}},
+	{Level: MoodLevelHappy, Min: 1, Max: 4, Tags: []string{"hope", "motivation"}},
+}
+
+func newTestRecordWithLevels(t *testing.T, userID *value_objects.UserID, happyLevel int, energyLevel int) *entities.MentalHealthRecord {
+	record, err := entities.NewMentalHealthRecord(userID.String(), happyLevel, energyLevel, nil, "private")
+	require.NoError(t, err)
+	return record
+}
+
+func newTestQuoteFeedback(t *testing.T, userID *value_objects.UserID, quoteID int, signal string) *entities.QuoteFeedback {
+	feedback, err := entities.NewQuoteFeedback(userID, value_objects.NewQuoteIDFromInt(quoteID), signal)
+	require.NoError(t, err)
+	return feedback
+}
+
+func TestRecommendationUseCaseImpl_RecommendQuotes(t *testing.T) {
+	userID := helpers.CreateTestUserID()
+
+	lowMood := newTestRecordWithLevels(t, userID, 3, 2)
+	lowEnergy := newTestRecordWithLevels(t, userID, 8, 3)
+	calm := newTestRecordWithLevels(t, userID, 7, 7)
+	gratitude, err := entities.NewTag("gratitude", "")
+	require.NoError(t, err)
+
+	tagged := newTestQuoteWithID(7)
+	other := newTestQuoteWithID(9)
+
+	tests := []struct {
+		name       string
+		options    RecommendationOptions
+		limit      int
+		setupMocks func(quoteRepo *repositories.MockQuoteRepository, recordRepo *repositories.MockMentalHealthRecordRepository, tagRepo *repositories.MockTagRepository, feedbackRepo *repositories.MockQuoteFeedbackRepository)
+		wantQuotes []*entities.Quote
+		wantBasis  string
+		wantTags   []string
+		wantRecord *entities.MentalHealthRecord
+		wantErr    string
+	}{
+		{
+			name:    "picks quotes tagged for a low mood, leaving out reacted quotes",
+			options: RecommendationOptions{Rules: testMoodTagRules},
+			limit:   2,
+			setupMocks: func(quoteRepo *repositories.MockQuoteRepository, recordRepo *repositories.MockMentalHealthRecordRepository, tagRepo *repositories.MockTagRepository, feedbackRepo *repositories.MockQuoteFeedbackRepository) {
+				feedback := []*entities.QuoteFeedback{
+					newTestQuoteFeedback(t, userID, 4, "like"),
+					newTestQuoteFeedback(t, userID, 5, "skip"),
+				}
+				recordRepo.EXPECT().GetByFilter(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, filter *domainrepositories.MentalHealthRecordFilter) ([]*entities.MentalHealthRecord, error) {
+						assert.Equal(t, userID, filter.UserID)
+						assert.Equal(t, 1, *filter.Limit)
+						assert.True(t, filter.OrderDesc)
+						return []*entities.MentalHealthRecord{lowMood}, nil
+					})
+				feedbackRepo.EXPECT().ListByUser(gomock.Any(), userID).Return(feedback, nil)
+				quoteRepo.EXPECT().GetByFilter(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, filter *domainrepositories.QuoteFilter) ([]*entities.Quote, error) {
+						assert.Equal(t, []string{"motivation", "energy", "hope"}, filter.TagNames)
+						assert.Equal(t, []*value_objects.QuoteID{value_objects.NewQuoteIDFromInt(4), value_objects.NewQuoteIDFromInt(5)}, filter.ExcludeIDs)
+						assert.Equal(t, value_objects.QuoteSortRandom, filter.Sort)
+						assert.Equal(t, recommendationSeed(userID, lowMood), filter.Seed)
+						assert.Equal(t, 2, filter.Limit)
+						return []*entities.Quote{tagged, other}, nil
+					})
+			},
+			wantQuotes: []*entities.Quote{tagged, other},
+			wantBasis:  commands.RecommendationBasisMood,
+			wantTags:   []string{"motivation", "energy", "hope"},
+			wantRecord: lowMood,
+		},
+		{
+			name:    "tops up with other quotes when too few carry the tags",
+			options: RecommendationOptions{Rules: testMoodTagRules},
+			limit:   3,
+			setupMocks: func(quoteRepo *repositories.MockQuoteRepository, recordRepo *repositories.MockMentalHealthRecordRepository, tagRepo *repositories.MockTagRepository, feedbackRepo *repositories.MockQuoteFeedbackRepository) {
+				recordRepo.EXPECT().GetByFilter(gomock.Any(), gomock.Any()).Return([]*entities.MentalHealthRecord{lowEnergy}, nil)
+				feedbackRepo.EXPECT().ListByUser(gomock.Any(), userID).Return(nil, nil)
+				gomock.InOrder(
+					quoteRepo.EXPECT().GetByFilter(gomock.Any(), gomock.Any()).DoAndReturn(
+						func(ctx context.Context, filter *domainrepositories.QuoteFilter) ([]*entities.Quote, error) {
+							assert.Equal(t, []string{"motivation", "energy"}, filter.TagNames)
+							return []*entities.Quote{tagged}, nil
+						}),
+					quoteRepo.EXPECT().GetByFilter(gomock.Any(), gomock.Any()).DoAndReturn(
+						func(ctx context.Context, filter *domainrepositories.QuoteFilter) ([]*entities.Quote, error) {
+							assert.Empty(t, filter.TagNames)
+							assert.Equal(t, []*value_objects.QuoteID{tagged.ID()}, filter.ExcludeIDs)
+							assert.Equal(t, 2, filter.Limit)
+							return []*entities.Quote{other}, nil
+						}),
+				)
+			},
+			wantQuotes: []*entities.Quote{tagged, other},
+			wantBasis:  commands.RecommendationBasisMood,
+			wantTags:   []string{"motivation", "energy"},
+			wantRecord: lowEnergy,
+		},
+		{
+			name:    "falls back to the tags of liked quotes when no rule matches",
+			options: RecommendationOptions{Rules: testMoodTagRules},
+			limit:   1,
+			setupMocks: func(quoteRepo *repositories.MockQuoteRepository, recordRepo *repositories.MockMentalHealthRecordRepository, tagRepo *repositories.MockTagRepository, feedbackRepo *repositories.MockQuoteFeedbackRepository) {
+				feedback := []*entities.QuoteFeedback{
+					newTestQuoteFeedback(t, userID, 5, "skip"),
+					newTestQuoteFeedback(t, userID, 4, "like"),
+				}
+				recordRepo.EXPECT().GetByFilter(gomock.Any(), gomock.Any()).Return([]*entities.MentalHealthRecord{calm}, nil)
+				feedbackRepo.EXPECT().ListByUser(gomock.Any(), userID).Return(feedback, nil)
+				tagRepo.EXPECT().GetByQuoteID(gomock.Any(), value_objects.NewQuoteIDFromInt(4)).Return([]*entities.Tag{gratitude}, nil)
+				quoteRepo.EXPECT().GetByFilter(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, filter *domainrepositories.QuoteFilter) ([]*entities.Quote, error) {
+						assert.Equal(t, []string{"gratitude"}, filter.TagNames)
+						return []*entities.Quote{tagged}, nil
+					})
+			},
+			wantQuotes: []*entities.Quote{tagged},
+			wantBasis:  commands.RecommendationBasisLikes,
+			wantTags:   []string{"gratitude"},
+			wantRecord: calm,
+		},
+		{
+			name:    "picks any quotes without records or feedback",
+			options: RecommendationOptions{Rules: testMoodTagRules},
+			limit:   5,
+			setupMocks: func(quoteRepo *repositories.MockQuoteRepository, recordRepo *repositories.MockMentalHealthRecordRepository, tagRepo *repositories.MockTagRepository, feedbackRepo *repositories.MockQuoteFeedbackRepository) {
+				recordRepo.EXPECT().GetByFilter(gomock.Any(), gomock.Any()).Return(nil, nil)
+				feedbackRepo.EXPECT().ListByUser(gomock.Any(), userID).Return(nil, nil)
+				quoteRepo.EXPECT().GetByFilter(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, filter *domainrepositories.QuoteFilter) ([]*entities.Quote, error) {
+						assert.Empty(t, filter.TagNames)
+						assert.Equal(t, recommendationSeed(userID, nil), filter.Seed)
+						return []*entities.Quote{tagged}, nil
+					})
+			},
+			wantQuotes: []*entities.Quote{tagged},
+			wantBasis:  commands.RecommendationBasisRandom,
+		},
+		{
+			name:    "fails when the records cannot be read",
+			options: RecommendationOptions{Rules: testMoodTagRules},
+			limit:   5,
+			setupMocks: func(quoteRepo *repositories.MockQuoteRepository, recordRepo *repositories.MockMentalHealthRecordRepository, tagRepo *repositories.MockTagRepository, feedbackRepo *repositories.MockQuoteFeedbackRepository) {
+				recordRepo.EXPECT().GetByFilter(gomock.Any(), gomock.Any()).Return(nil, errors.New("database error"))
+			},
+			wantErr: "database error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockQuoteRepo := repositories.NewMockQuoteRepository(ctrl)
+			mockRecordRepo := repositories.NewMockMentalHealthRecordRepository(ctrl)
+			mockTagRepo := repositories.NewMockTagRepository(ctrl)
+			mockFeedbackRepo := repositories.NewMockQuoteFeedbackRepository(ctrl)
+			tt.setupMocks(mockQuoteRepo, mockRecordRepo, mockTagRepo, mockFeedbackRepo)
+
+			useCase := NewRecommendationUseCase(mockQuoteRepo, mockRecordRepo, mockTagRepo, mockFeedbackRepo, tt.options)
+			result, err := useCase.RecommendQuotes(context.Background(), &commands.RecommendQuotesCommand{UserID: userID.String(), Limit: tt.limit})
+
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				assert.Nil(t, result)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantQuotes, result.Quotes)
+			assert.Equal(t, tt.wantBasis, result.Basis)
+			assert.Equal(t, tt.wantTags, result.Tags)
+			assert.Equal(t, tt.wantRecord, result.Record)
+		})
+	}
+}
+
+func TestMoodTagRule_Matches(t *testing.T) {
+	userID := helpers.CreateTestUserID()
+	record := newTestRecordWithLevels(t, userID, 4, 9)
+
+	assert.True(t, MoodTagRule{Level: MoodLevelHappy, Min: 1, Max: 4}.matches(record))
+	assert.False(t, MoodTagRule{Level: MoodLevelHappy, Min: 5, Max: 10}.matches(record))
+	assert.True(t, MoodTagRule{Level: MoodLevelEnergy, Min: 8, Max: 10}.matches(record))
+	assert.False(t, MoodTagRule{Level: "sleep", Min: 1, Max: 10}.matches(record))
+}
+
+func TestRecommendationUseCaseImpl_RecordFeedback(t *testing.T) {
+	userID := helpers.CreateTestUserID()
+
+	tests := []struct {
+		name       string
+		signal     string
+		quoteErr   error
+		wantSignal value_objects.QuoteFeedbackSignal
+		wantErr    string
+	}{
+		{
+			name:       "stores the signal of an existing quote",
+			signal:     "skip",
+			wantSignal: value_objects.QuoteFeedbackSkip,
+		},
+		{
+			name:     "rejects missing quotes",
+			signal:   "like",
+			quoteErr: errors.New("quote not found"),
+			wantErr:  "quote not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockQuoteRepo := repositories.NewMockQuoteRepository(ctrl)
+			mockFeedbackRepo := repositories.NewMockQuoteFeedbackRepository(ctrl)
+			if tt.quoteErr != nil {
+				mockQuoteRepo.EXPECT().GetByID(gomock.Any(), value_objects.NewQuoteIDFromInt(7)).Return(nil, tt.quoteErr)
+			} else {
+				mockQuoteRepo.EXPECT().GetByID(gomock.Any(), value_objects.NewQuoteIDFromInt(7)).Return(newTestQuoteWithID(7), nil)
+				mockFeedbackRepo.EXPECT().Save(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, feedback *entities.QuoteFeedback) error {
+						assert.Equal(t, userID.String(), feedback.UserID().String())
+						assert.Equal(t, 7, feedback.QuoteID().Value())
+						assert.Equal(t, tt.wantSignal, feedback.Signal())
+						return nil
+					})
+			}
+
+			useCase := NewRecommendationUseCase(mockQuoteRepo, repositories.NewMockMentalHealthRecordRepository(ctrl),
+				repositories.NewMockTagRepository(ctrl), mockFeedbackRepo, RecommendationOptions{})
+			err := useCase.RecordFeedback(context.Background(), &commands.QuoteFeedbackCommand{UserID: userID.String(), QuoteID: "7", Signal: tt.signal})
+
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Equal(t, tt.wantErr, err.Error())
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
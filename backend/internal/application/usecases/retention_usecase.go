@@ -0,0 +1,198 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/atdevten/peace/internal/application/commands"
+	"github.com/atdevten/peace/internal/application/services/filestore"
+	"github.com/atdevten/peace/internal/domain/entities"
+	"github.com/atdevten/peace/internal/domain/repositories"
+	"github.com/atdevten/peace/internal/domain/value_objects"
+)
+
+type RetentionUseCase interface {
+	// RunOnce purges or anonymizes every account deleted longer than the grace period ago
+	RunOnce(ctx context.Context) (*commands.RetentionReport, error)
+	// Run calls RunOnce right away and then at every interval until ctx is cancelled
+	Run(ctx context.Context)
+}
+
+const (
+	defaultRetentionInterval  = time.Hour
+	defaultRetentionBatchSize = 100
+)
+
+// RetentionOptions configures what happens to deleted accounts and when
+type RetentionOptions struct {
+	GracePeriod time.Duration                 // how long a deleted account can still be restored
+	Action      value_objects.RetentionAction // purge or anonymize accounts past the grace period
+	DryRun      bool                          // only log what would be removed
+	Interval    time.Duration                 // time between two passes of Run
+	BatchSize   int                           // accounts loaded at once
+}
+
+type RetentionUseCaseImpl struct {
+	retentionRepo repositories.RetentionRepository
+	exportRepo    repositories.DataExportRepository
+	fileStore     filestore.Store
+	options       RetentionOptions
+	now           func() time.Time
+}
+
+func NewRetentionUseCase(
+	retentionRepo repositories.RetentionRepository,
+	exportRepo repositories.DataExportRepository,
+	fileStore filestore.Store,
+	options RetentionOptions,
+) RetentionUseCase {
+	// Purging removes data for good, so it is never what an unset action means
+	if options.Action == "" {
+		options.Action = value_objects.RetentionActionAnonymize
+	}
+	if options.Interval <= 0 {
+		options.Interval = defaultRetentionInterval
+	}
+	if options.BatchSize <= 0 {
+		options.BatchSize = defaultRetentionBatchSize
+	}
+
+	return &RetentionUseCaseImpl{
+		retentionRepo: retentionRepo,
+		exportRepo:    exportRepo,
+		fileStore:     fileStore,
+		options:       options,
+		now:           time.Now,
+	}
+}
+
+func (uc *RetentionUseCaseImpl) RunOnce(ctx context.Context) (*commands.RetentionReport, error) {
+	report := &commands.RetentionReport{
+		Action: uc.options.Action.String(),
+		DryRun: uc.options.DryRun,
+	}
+
+	filter := &repositories.DeletedUserFilter{
+		DeletedBefore: uc.now().Add(-uc.options.GracePeriod),
+		Limit:         uc.options.BatchSize,
+	}
+
+	for {
+		users, err := uc.retentionRepo.ListDeletedUsers(ctx, filter)
+		if err != nil {
+			return report, fmt.Errorf("uc.retentionRepo.ListDeletedUsers: %w", err)
+		}
+
+		for _, user := range users {
+			if err := ctx.Err(); err != nil {
+				return report, err
+			}
+			// One broken account must not hold back the others
+			if err := uc.processUser(ctx, user, report); err != nil {
+				report.Failed++
+				log.Printf("Retention failed for user %s: %v", user.ID().String(), err)
+			}
+		}
+
+		if len(users) < uc.options.BatchSize {
+			return report, nil
+		}
+		// Processed accounts drop out of the listing, but failed and dry-run ones do not
+		last := users[len(users)-1]
+		filter.Cursor = &repositories.DeletedUserCursor{
+			DeletedAt: *last.DeletedAt(),
+			ID:        last.ID().String(),
+		}
+	}
+}
+
+func (uc *RetentionUseCaseImpl) Run(ctx context.Context) {
+	ticker := time.NewTicker(uc.options.Interval)
+	defer ticker.Stop()
+
+	for {
+		report, err := uc.RunOnce(ctx)
+		if err != nil && ctx.Err() == nil {
+			log.Printf("Retention pass failed: %v", err)
+		}
+		if report != nil && (report.Accounts > 0 || report.Failed > 0) {
+			log.Printf("Retention pass: %s %d accounts, %d failed (dry run: %t)",
+				report.Action, report.Accounts, report.Failed, report.DryRun)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// processUser applies the configured action to one deleted account and adds it to the report
+func (uc *RetentionUseCaseImpl) processUser(ctx context.Context, user *entities.User, report *commands.RetentionReport) error {
+	counts, err := uc.retentionRepo.CountUserData(ctx, user.ID())
+	if err != nil {
+		return fmt.Errorf("uc.retentionRepo.CountUserData: %w", err)
+	}
+
+	exports, err := uc.exportRepo.ListByUserID(ctx, user.ID())
+	if err != nil {
+		return fmt.Errorf("uc.exportRepo.ListByUserID: %w", err)
+	}
+
+	entry := &repositories.RetentionLogEntry{
+		UserID:        user.ID(),
+		Action:        uc.options.Action,
+		UserDeletedAt: *user.DeletedAt(),
+		Records:       counts.Records,
+		Sessions:      counts.Sessions,
+		Exports:       int64(len(exports)),
+		ProcessedAt:   uc.now(),
+	}
+
+	if uc.options.DryRun {
+		log.Printf("Retention dry run: would %s user %s deleted at %s (%d records, %d sessions, %d exports)",
+			entry.Action, user.ID().String(), entry.UserDeletedAt.Format(time.RFC3339), entry.Records, entry.Sessions, entry.Exports)
+		addToRetentionReport(report, entry)
+		return nil
+	}
+
+	switch uc.options.Action {
+	case value_objects.RetentionActionAnonymize:
+		if err := uc.retentionRepo.AnonymizeUser(ctx, entry); err != nil {
+			return ignoreRestoredUser(fmt.Errorf("uc.retentionRepo.AnonymizeUser: %w", err))
+		}
+	default:
+		if err := uc.retentionRepo.PurgeUser(ctx, entry); err != nil {
+			return ignoreRestoredUser(fmt.Errorf("uc.retentionRepo.PurgeUser: %w", err))
+		}
+	}
+
+	// Archives go only once their rows are gone, so a failed pass never breaks a download
+	for _, export := range exports {
+		if err := uc.fileStore.Delete(ctx, dataExportFileKey(export)); err != nil {
+			log.Printf("Failed to delete data export %s of user %s: %v", export.ID().String(), user.ID().String(), err)
+		}
+	}
+
+	addToRetentionReport(report, entry)
+	return nil
+}
+
+// ignoreRestoredUser drops the error of an account restored, or processed elsewhere, since it was listed
+func ignoreRestoredUser(err error) error {
+	if errors.Is(err, repositories.ErrUserNotFound) {
+		return nil
+	}
+	return err
+}
+
+func addToRetentionReport(report *commands.RetentionReport, entry *repositories.RetentionLogEntry) {
+	report.Accounts++
+	report.Records += entry.Records
+	report.Sessions += entry.Sessions
+	report.Exports += entry.Exports
+}
@@ -0,0 +1,188 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/atdevten/peace/internal/domain/entities"
+	domainrepositories "github.com/atdevten/peace/internal/domain/repositories"
+	"github.com/atdevten/peace/internal/domain/value_objects"
+	"github.com/atdevten/peace/testutils/helpers"
+	repositories "github.com/atdevten/peace/testutils/mocks/repositories"
+	services "github.com/atdevten/peace/testutils/mocks/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func createDeletedTestUser(t *testing.T) *entities.User {
+	user := helpers.CreateTestUser()
+	require.NoError(t, user.SoftDelete())
+	return user
+}
+
+func TestRetentionUseCaseImpl_RunOnce(t *testing.T) {
+	now := time.Now()
+	counts := &domainrepositories.UserDataCounts{Records: 3, Sessions: 2}
+
+	user := createDeletedTestUser(t)
+	export, err := entities.NewDataExport(user.ID())
+	require.NoError(t, err)
+	first, second, third := createDeletedTestUser(t), createDeletedTestUser(t), createDeletedTestUser(t)
+
+	tests := []struct {
+		name         string
+		options      RetentionOptions
+		setupMocks   func(retentionRepo *repositories.MockRetentionRepository, exportRepo *repositories.MockDataExportRepository, store *services.MockStore)
+		wantAction   string
+		wantDryRun   bool
+		wantAccounts int
+		wantFailed   int
+		wantRecords  int64
+		wantSessions int64
+		wantExports  int64
+		wantErr      string
+	}{
+		{
+			name:    "purges accounts past the grace period and their archives",
+			options: RetentionOptions{GracePeriod: 30 * 24 * time.Hour, Action: value_objects.RetentionActionPurge},
+			setupMocks: func(retentionRepo *repositories.MockRetentionRepository, exportRepo *repositories.MockDataExportRepository, store *services.MockStore) {
+				retentionRepo.EXPECT().ListDeletedUsers(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, filter *domainrepositories.DeletedUserFilter) ([]*entities.User, error) {
+						assert.Equal(t, now.Add(-30*24*time.Hour), filter.DeletedBefore)
+						assert.Nil(t, filter.Cursor)
+						return []*entities.User{user}, nil
+					})
+				retentionRepo.EXPECT().CountUserData(gomock.Any(), user.ID()).Return(counts, nil)
+				exportRepo.EXPECT().ListByUserID(gomock.Any(), user.ID()).Return([]*entities.DataExport{export}, nil)
+				retentionRepo.EXPECT().PurgeUser(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, entry *domainrepositories.RetentionLogEntry) error {
+						assert.Equal(t, value_objects.RetentionActionPurge, entry.Action)
+						assert.Equal(t, *user.DeletedAt(), entry.UserDeletedAt)
+						assert.Equal(t, int64(3), entry.Records)
+						assert.Equal(t, int64(1), entry.Exports)
+						return nil
+					})
+				store.EXPECT().Delete(gomock.Any(), dataExportFileKey(export)).Return(nil)
+			},
+			wantAction:   "purge",
+			wantAccounts: 1,
+			wantRecords:  3,
+			wantSessions: 2,
+			wantExports:  1,
+		},
+		{
+			name:    "anonymizes when configured to",
+			options: RetentionOptions{Action: value_objects.RetentionActionAnonymize},
+			setupMocks: func(retentionRepo *repositories.MockRetentionRepository, exportRepo *repositories.MockDataExportRepository, store *services.MockStore) {
+				retentionRepo.EXPECT().ListDeletedUsers(gomock.Any(), gomock.Any()).Return([]*entities.User{user}, nil)
+				retentionRepo.EXPECT().CountUserData(gomock.Any(), user.ID()).Return(counts, nil)
+				exportRepo.EXPECT().ListByUserID(gomock.Any(), user.ID()).Return(nil, nil)
+				retentionRepo.EXPECT().AnonymizeUser(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			wantAction:   "anonymize",
+			wantAccounts: 1,
+			wantRecords:  3,
+			wantSessions: 2,
+		},
+		{
+			name:    "anonymizes when no action is set",
+			options: RetentionOptions{},
+			setupMocks: func(retentionRepo *repositories.MockRetentionRepository, exportRepo *repositories.MockDataExportRepository, store *services.MockStore) {
+				retentionRepo.EXPECT().ListDeletedUsers(gomock.Any(), gomock.Any()).Return([]*entities.User{user}, nil)
+				retentionRepo.EXPECT().CountUserData(gomock.Any(), user.ID()).Return(counts, nil)
+				exportRepo.EXPECT().ListByUserID(gomock.Any(), user.ID()).Return(nil, nil)
+				retentionRepo.EXPECT().AnonymizeUser(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, entry *domainrepositories.RetentionLogEntry) error {
+						assert.Equal(t, value_objects.RetentionActionAnonymize, entry.Action)
+						return nil
+					})
+			},
+			wantAction:   "anonymize",
+			wantAccounts: 1,
+			wantRecords:  3,
+			wantSessions: 2,
+		},
+		{
+			name:    "dry run changes nothing",
+			options: RetentionOptions{Action: value_objects.RetentionActionPurge, DryRun: true},
+			setupMocks: func(retentionRepo *repositories.MockRetentionRepository, exportRepo *repositories.MockDataExportRepository, store *services.MockStore) {
+				retentionRepo.EXPECT().ListDeletedUsers(gomock.Any(), gomock.Any()).Return([]*entities.User{user}, nil)
+				retentionRepo.EXPECT().CountUserData(gomock.Any(), user.ID()).Return(counts, nil)
+				exportRepo.EXPECT().ListByUserID(gomock.Any(), user.ID()).Return([]*entities.DataExport{export}, nil)
+			},
+			wantAction:   "purge",
+			wantDryRun:   true,
+			wantAccounts: 1,
+			wantRecords:  3,
+			wantSessions: 2,
+			wantExports:  1,
+		},
+		{
+			name:    "pages through accounts and carries on after a failure",
+			options: RetentionOptions{Action: value_objects.RetentionActionPurge, BatchSize: 2},
+			setupMocks: func(retentionRepo *repositories.MockRetentionRepository, exportRepo *repositories.MockDataExportRepository, store *services.MockStore) {
+				gomock.InOrder(
+					retentionRepo.EXPECT().ListDeletedUsers(gomock.Any(), gomock.Any()).Return([]*entities.User{first, second}, nil),
+					retentionRepo.EXPECT().ListDeletedUsers(gomock.Any(), gomock.Any()).DoAndReturn(
+						func(ctx context.Context, filter *domainrepositories.DeletedUserFilter) ([]*entities.User, error) {
+							require.NotNil(t, filter.Cursor)
+							assert.Equal(t, second.ID().String(), filter.Cursor.ID)
+							return []*entities.User{third}, nil
+						}),
+				)
+				retentionRepo.EXPECT().CountUserData(gomock.Any(), first.ID()).Return(nil, errors.New("database error"))
+				retentionRepo.EXPECT().CountUserData(gomock.Any(), gomock.Any()).Return(counts, nil).Times(2)
+				exportRepo.EXPECT().ListByUserID(gomock.Any(), gomock.Any()).Return(nil, nil).Times(2)
+				// The third account was restored after it was listed
+				retentionRepo.EXPECT().PurgeUser(gomock.Any(), gomock.Any()).Return(nil)
+				retentionRepo.EXPECT().PurgeUser(gomock.Any(), gomock.Any()).Return(domainrepositories.ErrUserNotFound)
+			},
+			wantAction:   "purge",
+			wantAccounts: 1,
+			wantFailed:   1,
+			wantRecords:  3,
+			wantSessions: 2,
+		},
+		{
+			name: "list error",
+			setupMocks: func(retentionRepo *repositories.MockRetentionRepository, exportRepo *repositories.MockDataExportRepository, store *services.MockStore) {
+				retentionRepo.EXPECT().ListDeletedUsers(gomock.Any(), gomock.Any()).Return(nil, errors.New("database error"))
+			},
+			wantErr: "database error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRetentionRepo := repositories.NewMockRetentionRepository(ctrl)
+			mockExportRepo := repositories.NewMockDataExportRepository(ctrl)
+			mockStore := services.NewMockStore(ctrl)
+			tt.setupMocks(mockRetentionRepo, mockExportRepo, mockStore)
+
+			useCase := NewRetentionUseCase(mockRetentionRepo, mockExportRepo, mockStore, tt.options).(*RetentionUseCaseImpl)
+			useCase.now = func() time.Time { return now }
+			report, err := useCase.RunOnce(context.Background())
+
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantAction, report.Action)
+			assert.Equal(t, tt.wantDryRun, report.DryRun)
+			assert.Equal(t, tt.wantAccounts, report.Accounts)
+			assert.Equal(t, tt.wantFailed, report.Failed)
+			assert.Equal(t, tt.wantRecords, report.Records)
+			assert.Equal(t, tt.wantSessions, report.Sessions)
+			assert.Equal(t, tt.wantExports, report.Exports)
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/atdevten/peace/internal/domain/entities"
+	"github.com/atdevten/peace/internal/domain/repositories"
+	"github.com/atdevten/peace/internal/domain/value_objects"
+	"github.com/atdevten/peace/internal/pkg/totp"
+)
+
+// recoveryCodeCount is the number of recovery codes handed out per generation
+const recoveryCodeCount = 10
+
+// ErrInvalidMFACode is returned when a TOTP or recovery code is wrong, expired or already used
+var ErrInvalidMFACode = errors.New("invalid two-factor code")
+
+// secondFactor verifies codes against a user's TOTP factor and recovery codes
+type secondFactor struct {
+	totpRepo         repositories.TOTPFactorRepository
+	recoveryCodeRepo repositories.RecoveryCodeRepository
+}
+
+// confirmedFactor returns the user's active TOTP factor, or nil when two-factor login is off
+func (f secondFactor) confirmedFactor(ctx context.Context, userID *value_objects.UserID) (*entities.TOTPFactor, error) {
+	factor, err := f.totpRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrTOTPFactorNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("f.totpRepo.GetByUserID: %w", err)
+	}
+
+	if !factor.IsConfirmed() {
+		return nil, nil
+	}
+
+	return factor, nil
+}
+
+// verify accepts a current TOTP code or an unused recovery code, consuming it
+func (f secondFactor) verify(ctx context.Context, factor *entities.TOTPFactor, code string) error {
+	if step, ok := totp.Validate(factor.Secret(), code, time.Now()); ok {
+		if err := factor.UseStep(step); err != nil {
+			return ErrInvalidMFACode
+		}
+		if err := f.totpRepo.UseStep(ctx, factor); err != nil {
+			if errors.Is(err, repositories.ErrTOTPCodeReplayed) {
+				return ErrInvalidMFACode
+			}
+			return fmt.Errorf("f.totpRepo.UseStep: %w", err)
+		}
+		return nil
+	}
+
+	recoveryCode, err := value_objects.NewRecoveryCodeFromString(code)
+	if err != nil {
+		return ErrInvalidMFACode
+	}
+
+	if err := f.recoveryCodeRepo.Use(ctx, factor.UserID(), recoveryCode.Hash()); err != nil {
+		if errors.Is(err, repositories.ErrRecoveryCodeNotFound) {
+			return ErrInvalidMFACode
+		}
+		return fmt.Errorf("f.recoveryCodeRepo.Use: %w", err)
+	}
+
+	return nil
+}
+
+// regenerateRecoveryCodes replaces the user's recovery codes and returns the new ones for display
+func (f secondFactor) regenerateRecoveryCodes(ctx context.Context, userID *value_objects.UserID) ([]string, error) {
+	codes := make([]*entities.RecoveryCode, 0, recoveryCodeCount)
+	plain := make([]string, 0, recoveryCodeCount)
+
+	for i := 0; i < recoveryCodeCount; i++ {
+		recoveryCode, err := value_objects.NewRecoveryCode()
+		if err != nil {
+			return nil, fmt.Errorf("value_objects.NewRecoveryCode: %w", err)
+		}
+
+		code, err := entities.NewRecoveryCode(userID, recoveryCode)
+		if err != nil {
+			return nil, fmt.Errorf("entities.NewRecoveryCode: %w", err)
+		}
+
+		codes = append(codes, code)
+		plain = append(plain, recoveryCode.String())
+	}
+
+	if err := f.recoveryCodeRepo.ReplaceByUserID(ctx, userID, codes); err != nil {
+		return nil, fmt.Errorf("f.recoveryCodeRepo.ReplaceByUserID: %w", err)
+	}
+
+	return plain, nil
+}
@@ -0,0 +1,29 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/atdevten/peace/internal/domain/repositories"
+	"github.com/atdevten/peace/internal/domain/value_objects"
+)
+
+// sessionRevoker signs a user out of every device. Access tokens stop working at once, since
+// every authenticated request checks that its session is still active.
+type sessionRevoker struct {
+	sessionRepo      repositories.SessionRepository
+	refreshTokenRepo repositories.RefreshTokenRepository
+}
+
+// revokeAllSessions ends every session of the user together with their refresh tokens
+func (r sessionRevoker) revokeAllSessions(ctx context.Context, userID *value_objects.UserID) error {
+	if err := r.refreshTokenRepo.RevokeByUserID(ctx, userID); err != nil {
+		return fmt.Errorf("r.refreshTokenRepo.RevokeByUserID: %w", err)
+	}
+
+	if err := r.sessionRepo.RevokeByUserID(ctx, userID); err != nil {
+		return fmt.Errorf("r.sessionRepo.RevokeByUserID: %w", err)
+	}
+
+	return nil
+}
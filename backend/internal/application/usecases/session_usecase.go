@@ -0,0 +1,130 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/atdevten/peace/internal/domain/entities"
+	"github.com/atdevten/peace/internal/domain/repositories"
+	"github.com/atdevten/peace/internal/domain/value_objects"
+)
+
+// sessionTouchInterval limits how often authenticated requests write last_used_at
+const sessionTouchInterval = time.Minute
+
+// ErrSessionRevoked is returned when an access token belongs to a session that was ended
+var ErrSessionRevoked = errors.New("session has been revoked")
+
+type SessionUseCase interface {
+	ListSessions(ctx context.Context, userID string) ([]*entities.Session, error)
+	RevokeSession(ctx context.Context, userID string, sessionID string) error
+	// Authenticate checks that the session of an access token is still active for the user
+	Authenticate(ctx context.Context, userID string, sessionID string) error
+}
+
+type SessionUseCaseImpl struct {
+	sessionRepo      repositories.SessionRepository
+	refreshTokenRepo repositories.RefreshTokenRepository
+	auditTrail       auditTrail
+}
+
+func NewSessionUseCase(sessionRepo repositories.SessionRepository, refreshTokenRepo repositories.RefreshTokenRepository, auditRepo repositories.AuditEventRepository) SessionUseCase {
+	return &SessionUseCaseImpl{
+		sessionRepo:      sessionRepo,
+		refreshTokenRepo: refreshTokenRepo,
+		auditTrail:       auditTrail{repo: auditRepo},
+	}
+}
+
+func (uc *SessionUseCaseImpl) ListSessions(ctx context.Context, userID string) ([]*entities.Session, error) {
+	userIDVO, err := value_objects.NewUserIDFromString(userID)
+	if err != nil {
+		return nil, fmt.Errorf("value_objects.NewUserIDFromString: %w", err)
+	}
+
+	sessions, err := uc.sessionRepo.ListActiveByUserID(ctx, userIDVO)
+	if err != nil {
+		return nil, fmt.Errorf("uc.sessionRepo.ListActiveByUserID: %w", err)
+	}
+
+	return sessions, nil
+}
+
+func (uc *SessionUseCaseImpl) RevokeSession(ctx context.Context, userID string, sessionID string) error {
+	userIDVO, err := value_objects.NewUserIDFromString(userID)
+	if err != nil {
+		return fmt.Errorf("value_objects.NewUserIDFromString: %w", err)
+	}
+
+	sessionIDVO, err := value_objects.NewTokenIDFromString(sessionID)
+	if err != nil {
+		return fmt.Errorf("value_objects.NewTokenIDFromString: %w", err)
+	}
+
+	session, err := uc.sessionRepo.GetByID(ctx, sessionIDVO)
+	if err != nil {
+		return fmt.Errorf("uc.sessionRepo.GetByID: %w", err)
+	}
+
+	// Other users' sessions are reported as missing so their IDs cannot be probed
+	if !session.BelongsTo(userIDVO) {
+		return fmt.Errorf("uc.sessionRepo.GetByID: %w", repositories.ErrSessionNotFound)
+	}
+
+	if session.IsRevoked() {
+		return nil
+	}
+
+	if err := uc.refreshTokenRepo.RevokeFamily(ctx, session.ID()); err != nil {
+		return fmt.Errorf("uc.refreshTokenRepo.RevokeFamily: %w", err)
+	}
+
+	if err := uc.sessionRepo.Revoke(ctx, session.ID()); err != nil {
+		return fmt.Errorf("uc.sessionRepo.Revoke: %w", err)
+	}
+
+	uc.auditTrail.record(ctx, auditEntry{
+		action:     value_objects.AuditActionSessionRevoked,
+		actorID:    userIDVO,
+		targetType: entities.AuditTargetSession,
+		targetID:   session.ID().String(),
+	})
+
+	return nil
+}
+
+func (uc *SessionUseCaseImpl) Authenticate(ctx context.Context, userID string, sessionID string) error {
+	userIDVO, err := value_objects.NewUserIDFromString(userID)
+	if err != nil {
+		return fmt.Errorf("value_objects.NewUserIDFromString: %w", err)
+	}
+
+	sessionIDVO, err := value_objects.NewTokenIDFromString(sessionID)
+	if err != nil {
+		return fmt.Errorf("value_objects.NewTokenIDFromString: %w", err)
+	}
+
+	session, err := uc.sessionRepo.GetByID(ctx, sessionIDVO)
+	if err != nil {
+		if errors.Is(err, repositories.ErrSessionNotFound) {
+			return ErrSessionRevoked
+		}
+		return fmt.Errorf("uc.sessionRepo.GetByID: %w", err)
+	}
+
+	if !session.BelongsTo(userIDVO) || session.IsRevoked() {
+		return ErrSessionRevoked
+	}
+
+	// Only write activity once per interval to keep authenticated requests cheap
+	now := time.Now()
+	if now.Sub(session.LastUsedAt()) >= sessionTouchInterval {
+		if err := uc.sessionRepo.UpdateLastUsedAt(ctx, session.ID(), now); err != nil {
+			return fmt.Errorf("uc.sessionRepo.UpdateLastUsedAt: %w", err)
+		}
+	}
+
+	return nil
+}
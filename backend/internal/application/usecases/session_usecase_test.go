@@ -0,0 +1,180 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/atdevten/peace/internal/domain/entities"
+	domainrepositories "github.com/atdevten/peace/internal/domain/repositories"
+	"github.com/atdevten/peace/internal/domain/value_objects"
+	"github.com/atdevten/peace/testutils/helpers"
+	repositories "github.com/atdevten/peace/testutils/mocks/repositories"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func newTestSession(userID *value_objects.UserID, lastUsedAt time.Time, revokedAt *time.Time) *entities.Session {
+	return entities.NewSessionFromRepository(
+		value_objects.NewTokenID(),
+		userID,
+		"test-agent",
+		"203.0.113.7",
+		"local",
+		lastUsedAt,
+		lastUsedAt,
+		revokedAt,
+	)
+}
+
+func TestSessionUseCaseImpl_ListSessions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userID := helpers.CreateTestUserID()
+	sessions := []*entities.Session{newTestSession(userID, time.Now(), nil)}
+
+	mockSessionRepo := repositories.NewMockSessionRepository(ctrl)
+	mockSessionRepo.EXPECT().ListActiveByUserID(gomock.Any(), userID).Return(sessions, nil)
+
+	useCase := NewSessionUseCase(mockSessionRepo, repositories.NewMockRefreshTokenRepository(ctrl), nil)
+	got, err := useCase.ListSessions(context.Background(), userID.String())
+	require.NoError(t, err)
+	assert.Equal(t, sessions, got)
+
+	_, err = useCase.ListSessions(context.Background(), "not-a-uuid")
+	require.Error(t, err)
+}
+
+func TestSessionUseCaseImpl_RevokeSession(t *testing.T) {
+	userID := helpers.CreateTestUserID()
+	past := helpers.TimePtr(time.Now().Add(-time.Minute))
+
+	tests := []struct {
+		name         string
+		session      *entities.Session
+		sessionErr   error
+		expectRevoke bool
+		wantErr      error
+	}{
+		{
+			name:         "revokes own session and its refresh tokens",
+			session:      newTestSession(userID, time.Now(), nil),
+			expectRevoke: true,
+		},
+		{
+			name:    "already revoked session",
+			session: newTestSession(userID, time.Now(), past),
+		},
+		{
+			name:    "session of another user",
+			session: newTestSession(value_objects.NewUserID(), time.Now(), nil),
+			wantErr: domainrepositories.ErrSessionNotFound,
+		},
+		{
+			name:       "unknown session",
+			sessionErr: domainrepositories.ErrSessionNotFound,
+			wantErr:    domainrepositories.ErrSessionNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockSessionRepo := repositories.NewMockSessionRepository(ctrl)
+			mockRefreshRepo := repositories.NewMockRefreshTokenRepository(ctrl)
+			mockSessionRepo.EXPECT().GetByID(gomock.Any(), gomock.Any()).Return(tt.session, tt.sessionErr)
+			var recorded *entities.AuditEvent
+			mockAuditRepo := repositories.NewMockAuditEventRepository(ctrl)
+			if tt.expectRevoke {
+				mockRefreshRepo.EXPECT().RevokeFamily(gomock.Any(), tt.session.ID()).Return(nil)
+				mockSessionRepo.EXPECT().Revoke(gomock.Any(), tt.session.ID()).Return(nil)
+				mockAuditRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, event *entities.AuditEvent) error {
+						recorded = event
+						return nil
+					})
+			}
+
+			useCase := NewSessionUseCase(mockSessionRepo, mockRefreshRepo, mockAuditRepo)
+			err := useCase.RevokeSession(context.Background(), userID.String(), value_objects.NewTokenID().String())
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			if tt.expectRevoke {
+				require.NotNil(t, recorded)
+				assert.Equal(t, value_objects.AuditActionSessionRevoked, recorded.Action())
+				assert.Equal(t, userID.String(), recorded.ActorID().String())
+				assert.Equal(t, entities.AuditTargetSession, recorded.TargetType())
+				assert.Equal(t, tt.session.ID().String(), recorded.TargetID())
+			}
+		})
+	}
+}
+
+func TestSessionUseCaseImpl_Authenticate(t *testing.T) {
+	userID := helpers.CreateTestUserID()
+	past := helpers.TimePtr(time.Now().Add(-time.Minute))
+
+	tests := []struct {
+		name        string
+		session     *entities.Session
+		sessionErr  error
+		expectTouch bool
+		wantErr     error
+	}{
+		{
+			name:    "recently used session",
+			session: newTestSession(userID, time.Now(), nil),
+		},
+		{
+			name:        "stale session records activity",
+			session:     newTestSession(userID, time.Now().Add(-time.Hour), nil),
+			expectTouch: true,
+		},
+		{
+			name:    "revoked session",
+			session: newTestSession(userID, time.Now(), past),
+			wantErr: ErrSessionRevoked,
+		},
+		{
+			name:    "session of another user",
+			session: newTestSession(value_objects.NewUserID(), time.Now(), nil),
+			wantErr: ErrSessionRevoked,
+		},
+		{
+			name:       "unknown session",
+			sessionErr: domainrepositories.ErrSessionNotFound,
+			wantErr:    ErrSessionRevoked,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockSessionRepo := repositories.NewMockSessionRepository(ctrl)
+			mockSessionRepo.EXPECT().GetByID(gomock.Any(), gomock.Any()).Return(tt.session, tt.sessionErr)
+			if tt.expectTouch {
+				mockSessionRepo.EXPECT().UpdateLastUsedAt(gomock.Any(), tt.session.ID(), gomock.Any()).Return(nil)
+			}
+
+			useCase := NewSessionUseCase(mockSessionRepo, repositories.NewMockRefreshTokenRepository(ctrl), nil)
+			err := useCase.Authenticate(context.Background(), userID.String(), value_objects.NewTokenID().String())
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -24,15 +24,18 @@ type TagUseCase interface {
 type tagUseCase struct {
 	tagRepository   repositories.TagRepository
 	quoteRepository repositories.QuoteRepository
+	auditTrail      auditTrail
 }
 
 func NewTagUseCase(
 	tagRepository repositories.TagRepository,
 	quoteRepository repositories.QuoteRepository,
+	auditRepository repositories.AuditEventRepository,
 ) TagUseCase {
 	return &tagUseCase{
 		tagRepository:   tagRepository,
 		quoteRepository: quoteRepository,
+		auditTrail:      auditTrail{repo: auditRepository},
 	}
 }
 
@@ -47,6 +50,13 @@ func (uc *tagUseCase) CreateTag(ctx context.Context, cmd *commands.CreateTagComm
 		return nil, err
 	}
 
+	uc.auditTrail.record(ctx, auditEntry{
+		action:     value_objects.AuditActionTagCreated,
+		targetType: entities.AuditTargetTag,
+		targetID:   tag.ID().String(),
+		metadata:   map[string]interface{}{"name": tag.Name().Value()},
+	})
+
 	return tag, nil
 }
 
@@ -86,12 +96,28 @@ func (uc *tagUseCase) UpdateTag(ctx context.Context, id int, cmd *commands.Updat
 		return nil, err
 	}
 
+	uc.auditTrail.record(ctx, auditEntry{
+		action:     value_objects.AuditActionTagUpdated,
+		targetType: entities.AuditTargetTag,
+		targetID:   tagID.String(),
+		metadata:   map[string]interface{}{"name": tag.Name().Value()},
+	})
+
 	return tag, nil
 }
 
 func (uc *tagUseCase) DeleteTag(ctx context.Context, id int) error {
 	tagID := value_objects.NewTagIDFromInt(id)
-	return uc.tagRepository.Delete(ctx, tagID)
+	if err := uc.tagRepository.Delete(ctx, tagID); err != nil {
+		return err
+	}
+
+	uc.auditTrail.record(ctx, auditEntry{
+		action:     value_objects.AuditActionTagDeleted,
+		targetType: entities.AuditTargetTag,
+		targetID:   tagID.String(),
+	})
+	return nil
 }
 
 func (uc *tagUseCase) GetTagsByQuoteID(ctx context.Context, quoteID int) ([]*entities.Tag, error) {
@@ -114,12 +140,32 @@ func (uc *tagUseCase) AddTagToQuote(ctx context.Context, quoteID int, cmd *comma
 		return err
 	}
 
-	return uc.tagRepository.AddTagToQuote(ctx, quoteIDVO, tagIDVO)
+	if err := uc.tagRepository.AddTagToQuote(ctx, quoteIDVO, tagIDVO); err != nil {
+		return err
+	}
+
+	uc.recordQuoteTagEvent(ctx, value_objects.AuditActionQuoteTagAdded, quoteIDVO, tagIDVO)
+	return nil
 }
 
 func (uc *tagUseCase) RemoveTagFromQuote(ctx context.Context, quoteID int, cmd *commands.RemoveTagFromQuoteCommand) error {
 	quoteIDVO := value_objects.NewQuoteIDFromInt(quoteID)
 	tagIDVO := value_objects.NewTagIDFromInt(cmd.TagID)
 
-	return uc.tagRepository.RemoveTagFromQuote(ctx, quoteIDVO, tagIDVO)
+	if err := uc.tagRepository.RemoveTagFromQuote(ctx, quoteIDVO, tagIDVO); err != nil {
+		return err
+	}
+
+	uc.recordQuoteTagEvent(ctx, value_objects.AuditActionQuoteTagRemoved, quoteIDVO, tagIDVO)
+	return nil
+}
+
+// recordQuoteTagEvent audits tagging or untagging a quote; the quote is the target
+func (uc *tagUseCase) recordQuoteTagEvent(ctx context.Context, action value_objects.AuditAction, quoteID *value_objects.QuoteID, tagID *value_objects.TagID) {
+	uc.auditTrail.record(ctx, auditEntry{
+		action:     action,
+		targetType: entities.AuditTargetQuote,
+		targetID:   quoteID.String(),
+		metadata:   map[string]interface{}{"tag_id": tagID.IntValue()},
+	})
 }
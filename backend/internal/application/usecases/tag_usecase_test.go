@@ -65,7 +65,7 @@ func TestTagUseCase_CreateTag(t *testing.T) {
 				mockTagRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(tt.mockError)
 			}
 
-			useCase := NewTagUseCase(mockTagRepo, mockQuoteRepo)
+			useCase := NewTagUseCase(mockTagRepo, mockQuoteRepo, nil)
 			tag, err := useCase.CreateTag(context.Background(), tt.command)
 
 			if tt.wantErr {
@@ -116,7 +116,7 @@ func TestTagUseCase_GetTagByID(t *testing.T) {
 
 			mockTagRepo.EXPECT().GetByID(gomock.Any(), gomock.Any()).Return(tt.mockTag, tt.mockError)
 
-			useCase := NewTagUseCase(mockTagRepo, mockQuoteRepo)
+			useCase := NewTagUseCase(mockTagRepo, mockQuoteRepo, nil)
 			tag, err := useCase.GetTagByID(context.Background(), tt.id)
 
 			if tt.wantErr {
@@ -167,7 +167,7 @@ func TestTagUseCase_GetTagByName(t *testing.T) {
 
 			mockTagRepo.EXPECT().GetByFilter(gomock.Any(), gomock.Any()).Return(tt.mockTag, tt.mockError)
 
-			useCase := NewTagUseCase(mockTagRepo, mockQuoteRepo)
+			useCase := NewTagUseCase(mockTagRepo, mockQuoteRepo, nil)
 			tag, err := useCase.GetTagByName(context.Background(), tt.tagName)
 
 			if tt.wantErr {
@@ -215,7 +215,7 @@ func TestTagUseCase_GetAllTags(t *testing.T) {
 
 			mockTagRepo.EXPECT().GetAll(gomock.Any()).Return(tt.mockTags, tt.mockError)
 
-			useCase := NewTagUseCase(mockTagRepo, mockQuoteRepo)
+			useCase := NewTagUseCase(mockTagRepo, mockQuoteRepo, nil)
 			tags, err := useCase.GetAllTags(context.Background())
 
 			if tt.wantErr {
@@ -290,7 +290,7 @@ func TestTagUseCase_UpdateTag(t *testing.T) {
 				mockTagRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
 			}
 
-			useCase := NewTagUseCase(mockTagRepo, mockQuoteRepo)
+			useCase := NewTagUseCase(mockTagRepo, mockQuoteRepo, nil)
 			tag, err := useCase.UpdateTag(context.Background(), tt.id, tt.command)
 
 			if tt.wantErr {
@@ -339,7 +339,7 @@ func TestTagUseCase_DeleteTag(t *testing.T) {
 
 			mockTagRepo.EXPECT().Delete(gomock.Any(), gomock.Any()).Return(tt.mockError)
 
-			useCase := NewTagUseCase(mockTagRepo, mockQuoteRepo)
+			useCase := NewTagUseCase(mockTagRepo, mockQuoteRepo, nil)
 			err := useCase.DeleteTag(context.Background(), tt.id)
 
 			if tt.wantErr {
@@ -388,7 +388,7 @@ func TestTagUseCase_GetTagsByQuoteID(t *testing.T) {
 
 			mockTagRepo.EXPECT().GetByQuoteID(gomock.Any(), gomock.Any()).Return(tt.mockTags, tt.mockError)
 
-			useCase := NewTagUseCase(mockTagRepo, mockQuoteRepo)
+			useCase := NewTagUseCase(mockTagRepo, mockQuoteRepo, nil)
 			tags, err := useCase.GetTagsByQuoteID(context.Background(), tt.quoteID)
 
 			if tt.wantErr {
@@ -447,7 +447,7 @@ func TestTagUseCase_AddTagToQuote(t *testing.T) {
 			mockTagRepo.EXPECT().GetByID(gomock.Any(), gomock.Any()).Return(helpers.CreateTestTag(), nil)
 			mockTagRepo.EXPECT().AddTagToQuote(gomock.Any(), gomock.Any(), gomock.Any()).Return(tt.mockError)
 
-			useCase := NewTagUseCase(mockTagRepo, mockQuoteRepo)
+			useCase := NewTagUseCase(mockTagRepo, mockQuoteRepo, nil)
 			err := useCase.AddTagToQuote(context.Background(), tt.quoteID, tt.command)
 
 			if tt.wantErr {
@@ -501,7 +501,7 @@ func TestTagUseCase_RemoveTagFromQuote(t *testing.T) {
 
 			mockTagRepo.EXPECT().RemoveTagFromQuote(gomock.Any(), gomock.Any(), gomock.Any()).Return(tt.mockError)
 
-			useCase := NewTagUseCase(mockTagRepo, mockQuoteRepo)
+			useCase := NewTagUseCase(mockTagRepo, mockQuoteRepo, nil)
 			err := useCase.RemoveTagFromQuote(context.Background(), tt.quoteID, tt.command)
 
 			if tt.wantErr {
@@ -3,6 +3,7 @@ package usecases
 import (
 	"context"
 	"errors"
+	"fmt"
 
 	"github.com/atdevten/peace/internal/domain/entities"
 	"github.com/atdevten/peace/internal/domain/repositories"
@@ -13,16 +14,37 @@ type UserUseCase interface {
 	GetByID(ctx context.Context, userID string) (*entities.User, error)
 	UpdateProfile(ctx context.Context, userID string, firstName *string, lastName *string) (*entities.User, error)
 	UpdatePassword(ctx context.Context, userID string, newPassword string) error
+	UpdateTimezone(ctx context.Context, userID string, timezone string) (*entities.User, error)
+	UpdateFeedOptOut(ctx context.Context, userID string, optOut bool) (*entities.User, error)
+	// ChangeRole lets an admin set another user's role and signs the user out, so the new role applies at once
+	ChangeRole(ctx context.Context, actorID string, userID string, role string) (*entities.User, error)
 	Deactivate(ctx context.Context, userID string) error
 	Delete(ctx context.Context, userID string) error
 }
 
+// ErrCannotChangeOwnRole keeps admins from demoting themselves and locking everyone out
+var ErrCannotChangeOwnRole = errors.New("cannot change your own role")
+
 type UserUseCaseImpl struct {
-	userRepo repositories.UserRepository
+	userRepo       repositories.UserRepository
+	sessionRevoker sessionRevoker
+	tokenRepo      repositories.PersonalAccessTokenRepository
+	auditTrail     auditTrail
 }
 
-func NewUserUseCase(userRepo repositories.UserRepository) UserUseCase {
-	return &UserUseCaseImpl{userRepo: userRepo}
+func NewUserUseCase(
+	userRepo repositories.UserRepository,
+	sessionRepo repositories.SessionRepository,
+	refreshTokenRepo repositories.RefreshTokenRepository,
+	tokenRepo repositories.PersonalAccessTokenRepository,
+	auditRepo repositories.AuditEventRepository,
+) UserUseCase {
+	return &UserUseCaseImpl{
+		userRepo:       userRepo,
+		sessionRevoker: sessionRevoker{sessionRepo: sessionRepo, refreshTokenRepo: refreshTokenRepo},
+		tokenRepo:      tokenRepo,
+		auditTrail:     auditTrail{repo: auditRepo},
+	}
 }
 
 func (uc *UserUseCaseImpl) GetByID(ctx context.Context, userID string) (*entities.User, error) {
@@ -44,6 +66,7 @@ func (uc *UserUseCaseImpl) UpdateProfile(ctx context.Context, userID string, fir
 	if err := uc.userRepo.Update(ctx, user); err != nil {
 		return nil, err
 	}
+	uc.recordUserEvent(ctx, value_objects.AuditActionProfileUpdated, user, nil)
 	return user, nil
 }
 
@@ -58,7 +81,73 @@ func (uc *UserUseCaseImpl) UpdatePassword(ctx context.Context, userID string, ne
 	if err := user.UpdatePassword(newPassword); err != nil {
 		return err
 	}
-	return uc.userRepo.Update(ctx, user)
+	if err := uc.userRepo.Update(ctx, user); err != nil {
+		return err
+	}
+	uc.recordUserEvent(ctx, value_objects.AuditActionPasswordChanged, user, nil)
+	return nil
+}
+
+func (uc *UserUseCaseImpl) UpdateTimezone(ctx context.Context, userID string, timezone string) (*entities.User, error) {
+	if timezone == "" {
+		return nil, errors.New("timezone is required")
+	}
+	user, err := uc.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := user.UpdateTimezone(timezone); err != nil {
+		return nil, err
+	}
+	if err := uc.userRepo.Update(ctx, user); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+func (uc *UserUseCaseImpl) UpdateFeedOptOut(ctx context.Context, userID string, optOut bool) (*entities.User, error) {
+	user, err := uc.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	user.UpdateFeedOptOut(optOut)
+	if err := uc.userRepo.Update(ctx, user); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+func (uc *UserUseCaseImpl) ChangeRole(ctx context.Context, actorID string, userID string, role string) (*entities.User, error) {
+	roleVO, err := value_objects.NewRole(role)
+	if err != nil {
+		return nil, err
+	}
+	if actorID == userID {
+		return nil, ErrCannotChangeOwnRole
+	}
+	user, err := uc.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	previousRole := user.Role()
+	user.ChangeRole(*roleVO)
+	if err := uc.userRepo.Update(ctx, user); err != nil {
+		return nil, err
+	}
+	// Tokens carry the role they were issued with, so the old ones must not outlive the change
+	if err := uc.sessionRevoker.revokeAllSessions(ctx, user.ID()); err != nil {
+		return nil, fmt.Errorf("uc.sessionRevoker.revokeAllSessions: %w", err)
+	}
+	// The acting admin is known here even when the request carries no actor
+	actor, _ := value_objects.NewUserIDFromString(actorID)
+	uc.auditTrail.record(ctx, auditEntry{
+		action:     value_objects.AuditActionRoleChanged,
+		actorID:    actor,
+		targetType: entities.AuditTargetUser,
+		targetID:   user.ID().String(),
+		metadata:   map[string]interface{}{"from": previousRole.String(), "to": roleVO.String()},
+	})
+	return user, nil
 }
 
 func (uc *UserUseCaseImpl) Deactivate(ctx context.Context, userID string) error {
@@ -69,13 +158,53 @@ func (uc *UserUseCaseImpl) Deactivate(ctx context.Context, userID string) error
 	if err := user.Deactivate(); err != nil {
 		return err
 	}
-	return uc.userRepo.Update(ctx, user)
+	if err := uc.userRepo.Update(ctx, user); err != nil {
+		return err
+	}
+	if err := uc.revokeAccess(ctx, user); err != nil {
+		return err
+	}
+	uc.recordUserEvent(ctx, value_objects.AuditActionUserDeactivated, user, nil)
+	return nil
 }
 
+// Delete only marks the account as deleted; the retention job purges or anonymizes it after the grace period
 func (uc *UserUseCaseImpl) Delete(ctx context.Context, userID string) error {
-	id, err := value_objects.NewUserIDFromString(userID)
+	user, err := uc.GetByID(ctx, userID)
 	if err != nil {
 		return err
 	}
-	return uc.userRepo.Delete(ctx, id)
+	if err := user.SoftDelete(); err != nil {
+		return err
+	}
+	if err := uc.userRepo.Update(ctx, user); err != nil {
+		return err
+	}
+	if err := uc.revokeAccess(ctx, user); err != nil {
+		return err
+	}
+	uc.recordUserEvent(ctx, value_objects.AuditActionUserDeleted, user, nil)
+	return nil
+}
+
+// revokeAccess signs the user out everywhere and removes their personal access tokens, so nothing issued
+// before the account was closed keeps working if it is ever restored
+func (uc *UserUseCaseImpl) revokeAccess(ctx context.Context, user *entities.User) error {
+	if err := uc.sessionRevoker.revokeAllSessions(ctx, user.ID()); err != nil {
+		return fmt.Errorf("uc.sessionRevoker.revokeAllSessions: %w", err)
+	}
+	if err := uc.tokenRepo.DeleteByUserID(ctx, user.ID()); err != nil {
+		return fmt.Errorf("uc.tokenRepo.DeleteByUserID: %w", err)
+	}
+	return nil
+}
+
+// recordUserEvent audits a change to the user's account, made by the authenticated user of the request
+func (uc *UserUseCaseImpl) recordUserEvent(ctx context.Context, action value_objects.AuditAction, user *entities.User, metadata map[string]interface{}) {
+	uc.auditTrail.record(ctx, auditEntry{
+		action:     action,
+		targetType: entities.AuditTargetUser,
+		targetID:   user.ID().String(),
+		metadata:   metadata,
+	})
 }
@@ -4,8 +4,11 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
+	"github.com/atdevten/peace/internal/application/services/audit"
 	"github.com/atdevten/peace/internal/domain/entities"
+	"github.com/atdevten/peace/internal/domain/value_objects"
 	"github.com/atdevten/peace/testutils/helpers"
 	repositories "github.com/atdevten/peace/testutils/mocks/repositories"
 	"github.com/stretchr/testify/assert"
@@ -55,7 +58,7 @@ func TestUserUseCaseImpl_GetByID(t *testing.T) {
 				mockRepo.EXPECT().GetByID(gomock.Any(), gomock.Any()).Return(tt.mockUser, tt.mockError)
 			}
 
-			useCase := NewUserUseCase(mockRepo)
+			useCase := NewUserUseCase(mockRepo, nil, nil, nil, nil)
 			user, err := useCase.GetByID(context.Background(), tt.userID)
 
 			if tt.wantErr {
@@ -126,7 +129,7 @@ func TestUserUseCaseImpl_UpdateProfile(t *testing.T) {
 				}
 			}
 
-			useCase := NewUserUseCase(mockRepo)
+			useCase := NewUserUseCase(mockRepo, nil, nil, nil, nil)
 			user, err := useCase.UpdateProfile(context.Background(), tt.userID, tt.firstName, tt.lastName)
 
 			if tt.wantErr {
@@ -191,7 +194,7 @@ func TestUserUseCaseImpl_UpdatePassword(t *testing.T) {
 				}
 			}
 
-			useCase := NewUserUseCase(mockRepo)
+			useCase := NewUserUseCase(mockRepo, nil, nil, nil, nil)
 			err := useCase.UpdatePassword(context.Background(), tt.userID, tt.newPassword)
 
 			if tt.wantErr {
@@ -204,12 +207,201 @@ func TestUserUseCaseImpl_UpdatePassword(t *testing.T) {
 	}
 }
 
+func TestUserUseCaseImpl_UpdateFeedOptOut(t *testing.T) {
+	tests := []struct {
+		name        string
+		userID      string
+		optOut      bool
+		mockUser    *entities.User
+		mockError   error
+		wantErr     bool
+		expectedErr string
+	}{
+		{
+			name:     "opt out of the feed",
+			userID:   "550e8400-e29b-41d4-a716-446655440000",
+			optOut:   true,
+			mockUser: helpers.CreateTestUser(),
+			wantErr:  false,
+		},
+		{
+			name:     "opt back in",
+			userID:   "550e8400-e29b-41d4-a716-446655440000",
+			optOut:   false,
+			mockUser: helpers.CreateTestUser(),
+			wantErr:  false,
+		},
+		{
+			name:        "user not found",
+			userID:      "550e8400-e29b-41d4-a716-446655440000",
+			optOut:      true,
+			mockError:   errors.New("user not found"),
+			wantErr:     true,
+			expectedErr: "user not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup mock controller
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			// Setup mock repository
+			mockRepo := repositories.NewMockUserRepository(ctrl)
+			mockRepo.EXPECT().GetByID(gomock.Any(), gomock.Any()).Return(tt.mockUser, tt.mockError)
+			if tt.mockError == nil {
+				mockRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+			}
+
+			useCase := NewUserUseCase(mockRepo, nil, nil, nil, nil)
+			user, err := useCase.UpdateFeedOptOut(context.Background(), tt.userID, tt.optOut)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedErr)
+				assert.Nil(t, user)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.optOut, user.FeedOptOut())
+			}
+		})
+	}
+}
+
+func TestUserUseCaseImpl_ChangeRole(t *testing.T) {
+	const (
+		adminID = "550e8400-e29b-41d4-a716-446655440001"
+		userID  = "550e8400-e29b-41d4-a716-446655440000"
+	)
+
+	tests := []struct {
+		name        string
+		actorID     string
+		role        string
+		mockUser    *entities.User
+		mockError   error
+		expectLoad  bool
+		revokeError error
+		wantErr     bool
+		expectedErr string
+	}{
+		{
+			name:       "promote to editor",
+			actorID:    adminID,
+			role:       "editor",
+			mockUser:   helpers.CreateTestUser(),
+			expectLoad: true,
+		},
+		{
+			name:        "unknown role",
+			actorID:     adminID,
+			role:        "superuser",
+			wantErr:     true,
+			expectedErr: "invalid role",
+		},
+		{
+			name:        "own role",
+			actorID:     userID,
+			role:        "user",
+			wantErr:     true,
+			expectedErr: ErrCannotChangeOwnRole.Error(),
+		},
+		{
+			name:        "user not found",
+			actorID:     adminID,
+			role:        "admin",
+			mockError:   errors.New("user not found"),
+			expectLoad:  true,
+			wantErr:     true,
+			expectedErr: "user not found",
+		},
+		{
+			name:        "sessions cannot be revoked",
+			actorID:     adminID,
+			role:        "user",
+			mockUser:    helpers.CreateTestUser(),
+			expectLoad:  true,
+			revokeError: errors.New("database unavailable"),
+			wantErr:     true,
+			expectedErr: "uc.sessionRevoker.revokeAllSessions",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := repositories.NewMockUserRepository(ctrl)
+			mockSessionRepo := repositories.NewMockSessionRepository(ctrl)
+			mockRefreshRepo := repositories.NewMockRefreshTokenRepository(ctrl)
+			if tt.expectLoad {
+				mockRepo.EXPECT().GetByID(gomock.Any(), gomock.Any()).Return(tt.mockUser, tt.mockError)
+				if tt.mockError == nil {
+					mockRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+					// The user is signed out everywhere so no token keeps the old role
+					mockRefreshRepo.EXPECT().RevokeByUserID(gomock.Any(), tt.mockUser.ID()).Return(tt.revokeError)
+					if tt.revokeError == nil {
+						mockSessionRepo.EXPECT().RevokeByUserID(gomock.Any(), tt.mockUser.ID()).Return(nil)
+					}
+				}
+			}
+
+			useCase := NewUserUseCase(mockRepo, mockSessionRepo, mockRefreshRepo, nil, nil)
+			user, err := useCase.ChangeRole(context.Background(), tt.actorID, userID, tt.role)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedErr)
+				assert.Nil(t, user)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.role, user.Role().String())
+			}
+		})
+	}
+}
+
+func TestUserUseCaseImpl_ChangeRoleRevokesSessions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// An admin signed in before being demoted
+	admin := helpers.CreateTestUser()
+	admin.ChangeRole(value_objects.RoleAdmin)
+	session, err := entities.NewSession(admin.ID(), "test-agent", "203.0.113.7", "local")
+	require.NoError(t, err)
+
+	mockRepo := repositories.NewMockUserRepository(ctrl)
+	mockRepo.EXPECT().GetByID(gomock.Any(), gomock.Any()).Return(admin, nil)
+	mockRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+	mockSessionRepo := repositories.NewMockSessionRepository(ctrl)
+	mockSessionRepo.EXPECT().RevokeByUserID(gomock.Any(), admin.ID()).DoAndReturn(
+		func(ctx context.Context, userID *value_objects.UserID) error {
+			return session.Revoke(time.Now())
+		})
+	mockSessionRepo.EXPECT().GetByID(gomock.Any(), gomock.Any()).Return(session, nil)
+	mockRefreshRepo := repositories.NewMockRefreshTokenRepository(ctrl)
+	mockRefreshRepo.EXPECT().RevokeByUserID(gomock.Any(), admin.ID()).Return(nil)
+
+	useCase := NewUserUseCase(mockRepo, mockSessionRepo, mockRefreshRepo, nil, nil)
+	_, err = useCase.ChangeRole(context.Background(), value_objects.NewUserID().String(), admin.ID().String(), "user")
+	require.NoError(t, err)
+
+	// The access token still claims the admin role, but its session no longer authenticates
+	sessions := NewSessionUseCase(mockSessionRepo, mockRefreshRepo, nil)
+	err = sessions.Authenticate(context.Background(), admin.ID().String(), session.ID().String())
+	assert.ErrorIs(t, err, ErrSessionRevoked)
+}
+
 func TestUserUseCaseImpl_Deactivate(t *testing.T) {
 	tests := []struct {
 		name        string
 		userID      string
 		mockUser    *entities.User
 		mockError   error
+		tokenError  error
 		wantErr     bool
 		expectedErr string
 	}{
@@ -219,6 +411,14 @@ func TestUserUseCaseImpl_Deactivate(t *testing.T) {
 			mockUser: helpers.CreateTestUser(),
 			wantErr:  false,
 		},
+		{
+			name:        "tokens cannot be removed",
+			userID:      "550e8400-e29b-41d4-a716-446655440000",
+			mockUser:    helpers.CreateTestUser(),
+			tokenError:  errors.New("database unavailable"),
+			wantErr:     true,
+			expectedErr: "uc.tokenRepo.DeleteByUserID",
+		},
 		{
 			name:        "invalid user ID",
 			userID:      "invalid-id",
@@ -240,18 +440,25 @@ func TestUserUseCaseImpl_Deactivate(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			defer ctrl.Finish()
 
-			// Setup mock repository
+			// Setup mock repositories
 			mockRepo := repositories.NewMockUserRepository(ctrl)
+			mockSessionRepo := repositories.NewMockSessionRepository(ctrl)
+			mockRefreshRepo := repositories.NewMockRefreshTokenRepository(ctrl)
+			mockTokenRepo := repositories.NewMockPersonalAccessTokenRepository(ctrl)
 			if tt.userID != "invalid-id" {
 				// Mock GetByID call
 				mockRepo.EXPECT().GetByID(gomock.Any(), gomock.Any()).Return(tt.mockUser, tt.mockError)
 				// Mock Update call if no error in GetByID
 				if tt.mockError == nil {
 					mockRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+					// Sessions, refresh tokens and personal access tokens all stop working
+					mockRefreshRepo.EXPECT().RevokeByUserID(gomock.Any(), tt.mockUser.ID()).Return(nil)
+					mockSessionRepo.EXPECT().RevokeByUserID(gomock.Any(), tt.mockUser.ID()).Return(nil)
+					mockTokenRepo.EXPECT().DeleteByUserID(gomock.Any(), tt.mockUser.ID()).Return(tt.tokenError)
 				}
 			}
 
-			useCase := NewUserUseCase(mockRepo)
+			useCase := NewUserUseCase(mockRepo, mockSessionRepo, mockRefreshRepo, mockTokenRepo, nil)
 			err := useCase.Deactivate(context.Background(), tt.userID)
 
 			if tt.wantErr {
@@ -265,17 +472,23 @@ func TestUserUseCaseImpl_Deactivate(t *testing.T) {
 }
 
 func TestUserUseCaseImpl_Delete(t *testing.T) {
+	deletedUser := helpers.CreateTestUser()
+	require.NoError(t, deletedUser.SoftDelete())
+
 	tests := []struct {
 		name        string
 		userID      string
+		mockUser    *entities.User
 		mockError   error
+		revokeError error
 		wantErr     bool
 		expectedErr string
 	}{
 		{
-			name:    "successful deletion",
-			userID:  "550e8400-e29b-41d4-a716-446655440000",
-			wantErr: false,
+			name:     "successful deletion",
+			userID:   "550e8400-e29b-41d4-a716-446655440000",
+			mockUser: helpers.CreateTestUser(),
+			wantErr:  false,
 		},
 		{
 			name:        "invalid user ID",
@@ -290,6 +503,21 @@ func TestUserUseCaseImpl_Delete(t *testing.T) {
 			wantErr:     true,
 			expectedErr: "user not found",
 		},
+		{
+			name:        "already deleted",
+			userID:      "550e8400-e29b-41d4-a716-446655440000",
+			mockUser:    deletedUser,
+			wantErr:     true,
+			expectedErr: "user is already deleted",
+		},
+		{
+			name:        "sessions cannot be revoked",
+			userID:      "550e8400-e29b-41d4-a716-446655440000",
+			mockUser:    helpers.CreateTestUser(),
+			revokeError: errors.New("database unavailable"),
+			wantErr:     true,
+			expectedErr: "uc.sessionRevoker.revokeAllSessions",
+		},
 	}
 
 	for _, tt := range tests {
@@ -298,13 +526,31 @@ func TestUserUseCaseImpl_Delete(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			defer ctrl.Finish()
 
-			// Setup mock repository
+			// Setup mock repositories
 			mockRepo := repositories.NewMockUserRepository(ctrl)
+			mockSessionRepo := repositories.NewMockSessionRepository(ctrl)
+			mockRefreshRepo := repositories.NewMockRefreshTokenRepository(ctrl)
+			mockTokenRepo := repositories.NewMockPersonalAccessTokenRepository(ctrl)
 			if tt.userID != "invalid-id" {
-				mockRepo.EXPECT().Delete(gomock.Any(), gomock.Any()).Return(tt.mockError)
+				mockRepo.EXPECT().GetByID(gomock.Any(), gomock.Any()).Return(tt.mockUser, tt.mockError)
+				// Deletion is a soft delete: the account is updated, never removed
+				if tt.mockError == nil && tt.mockUser.DeletedAt() == nil {
+					mockRepo.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(
+						func(ctx context.Context, user *entities.User) error {
+							assert.NotNil(t, user.DeletedAt())
+							assert.False(t, user.IsActive())
+							return nil
+						})
+					// Nothing issued before the deletion works after a restore
+					mockRefreshRepo.EXPECT().RevokeByUserID(gomock.Any(), tt.mockUser.ID()).Return(tt.revokeError)
+					if tt.revokeError == nil {
+						mockSessionRepo.EXPECT().RevokeByUserID(gomock.Any(), tt.mockUser.ID()).Return(nil)
+						mockTokenRepo.EXPECT().DeleteByUserID(gomock.Any(), tt.mockUser.ID()).Return(nil)
+					}
+				}
 			}
 
-			useCase := NewUserUseCase(mockRepo)
+			useCase := NewUserUseCase(mockRepo, mockSessionRepo, mockRefreshRepo, mockTokenRepo, nil)
 			err := useCase.Delete(context.Background(), tt.userID)
 
 			if tt.wantErr {
@@ -316,3 +562,97 @@ func TestUserUseCaseImpl_Delete(t *testing.T) {
 		})
 	}
 }
+
+func TestUserUseCaseImpl_AuditTrail(t *testing.T) {
+	t.Run("deletion is recorded with the actor of the request", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		user := helpers.CreateTestUser()
+		mockRepo := repositories.NewMockUserRepository(ctrl)
+		mockRepo.EXPECT().GetByID(gomock.Any(), gomock.Any()).Return(user, nil)
+		mockRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+
+		var recorded *entities.AuditEvent
+		mockAuditRepo := repositories.NewMockAuditEventRepository(ctrl)
+		mockAuditRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, event *entities.AuditEvent) error {
+				recorded = event
+				return nil
+			})
+
+		mockSessionRepo := repositories.NewMockSessionRepository(ctrl)
+		mockSessionRepo.EXPECT().RevokeByUserID(gomock.Any(), gomock.Any()).Return(nil)
+		mockRefreshRepo := repositories.NewMockRefreshTokenRepository(ctrl)
+		mockRefreshRepo.EXPECT().RevokeByUserID(gomock.Any(), gomock.Any()).Return(nil)
+		mockTokenRepo := repositories.NewMockPersonalAccessTokenRepository(ctrl)
+		mockTokenRepo.EXPECT().DeleteByUserID(gomock.Any(), gomock.Any()).Return(nil)
+
+		ctx := audit.WithActor(context.Background(), audit.Actor{UserID: user.ID(), IPAddress: "203.0.113.7", UserAgent: "test-agent"})
+		useCase := NewUserUseCase(mockRepo, mockSessionRepo, mockRefreshRepo, mockTokenRepo, mockAuditRepo)
+		require.NoError(t, useCase.Delete(ctx, user.ID().String()))
+
+		require.NotNil(t, recorded)
+		assert.Equal(t, value_objects.AuditActionUserDeleted, recorded.Action())
+		assert.Equal(t, user.ID().String(), recorded.ActorID().String())
+		assert.Equal(t, entities.AuditTargetUser, recorded.TargetType())
+		assert.Equal(t, user.ID().String(), recorded.TargetID())
+		assert.Equal(t, "203.0.113.7", recorded.IPAddress())
+		assert.Equal(t, "test-agent", recorded.UserAgent())
+	})
+
+	t.Run("role changes record the acting admin and both roles", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		user := helpers.CreateTestUser()
+		mockRepo := repositories.NewMockUserRepository(ctrl)
+		mockRepo.EXPECT().GetByID(gomock.Any(), gomock.Any()).Return(user, nil)
+		mockRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+
+		var recorded *entities.AuditEvent
+		mockAuditRepo := repositories.NewMockAuditEventRepository(ctrl)
+		mockAuditRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, event *entities.AuditEvent) error {
+				recorded = event
+				return nil
+			})
+
+		mockSessionRepo := repositories.NewMockSessionRepository(ctrl)
+		mockSessionRepo.EXPECT().RevokeByUserID(gomock.Any(), gomock.Any()).Return(nil)
+		mockRefreshRepo := repositories.NewMockRefreshTokenRepository(ctrl)
+		mockRefreshRepo.EXPECT().RevokeByUserID(gomock.Any(), gomock.Any()).Return(nil)
+
+		adminID := value_objects.NewUserID()
+		useCase := NewUserUseCase(mockRepo, mockSessionRepo, mockRefreshRepo, nil, mockAuditRepo)
+		_, err := useCase.ChangeRole(context.Background(), adminID.String(), user.ID().String(), "editor")
+		require.NoError(t, err)
+
+		require.NotNil(t, recorded)
+		assert.Equal(t, value_objects.AuditActionRoleChanged, recorded.Action())
+		assert.Equal(t, adminID.String(), recorded.ActorID().String())
+		assert.Equal(t, "user", recorded.Metadata()["from"])
+		assert.Equal(t, "editor", recorded.Metadata()["to"])
+	})
+
+	t.Run("a failure to record does not fail the operation", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockRepo := repositories.NewMockUserRepository(ctrl)
+		mockRepo.EXPECT().GetByID(gomock.Any(), gomock.Any()).Return(helpers.CreateTestUser(), nil)
+		mockRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+
+		mockAuditRepo := repositories.NewMockAuditEventRepository(ctrl)
+		mockAuditRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(errors.New("database unavailable"))
+		mockSessionRepo := repositories.NewMockSessionRepository(ctrl)
+		mockSessionRepo.EXPECT().RevokeByUserID(gomock.Any(), gomock.Any()).Return(nil)
+		mockRefreshRepo := repositories.NewMockRefreshTokenRepository(ctrl)
+		mockRefreshRepo.EXPECT().RevokeByUserID(gomock.Any(), gomock.Any()).Return(nil)
+		mockTokenRepo := repositories.NewMockPersonalAccessTokenRepository(ctrl)
+		mockTokenRepo.EXPECT().DeleteByUserID(gomock.Any(), gomock.Any()).Return(nil)
+
+		useCase := NewUserUseCase(mockRepo, mockSessionRepo, mockRefreshRepo, mockTokenRepo, mockAuditRepo)
+		assert.NoError(t, useCase.Deactivate(context.Background(), "550e8400-e29b-41d4-a716-446655440000"))
+	})
+}
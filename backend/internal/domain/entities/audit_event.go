@@ -0,0 +1,137 @@
+package entities
+
+import (
+	"errors"
+	"time"
+
+	"github.com/atdevten/peace/internal/domain/value_objects"
+)
+
+// Kinds of objects an audit event can be about
+const (
+	AuditTargetUser       = "user"
+	AuditTargetSession    = "session"
+	AuditTargetToken      = "personal_access_token"
+	AuditTargetDataExport = "data_export"
+	AuditTargetQuote      = "quote"
+	AuditTargetTag        = "tag"
+)
+
+// AuditEvent records who did what to which object, and from where. Events are never changed
+// once recorded.
+type AuditEvent struct {
+	id         *value_objects.TokenID
+	actorID    *value_objects.UserID // nil for anonymous callers
+	action     value_objects.AuditAction
+	targetType string
+	targetID   string
+	ipAddress  string
+	userAgent  string
+	metadata   map[string]interface{}
+	createdAt  time.Time
+}
+
+// NewAuditEvent records an action; actorID is nil when the caller is not authenticated
+func NewAuditEvent(
+	actorID *value_objects.UserID,
+	action value_objects.AuditAction,
+	targetType string,
+	targetID string,
+	ipAddress string,
+	userAgent string,
+	metadata map[string]interface{},
+) (*AuditEvent, error) {
+	if action == "" {
+		return nil, errors.New("action is required")
+	}
+
+	if targetID != "" && targetType == "" {
+		return nil, errors.New("target type is required with a target ID")
+	}
+
+	if len(userAgent) > maxDeviceLength {
+		userAgent = userAgent[:maxDeviceLength]
+	}
+
+	if len(ipAddress) > maxIPAddressLength {
+		ipAddress = ipAddress[:maxIPAddressLength]
+	}
+
+	if metadata == nil {
+		metadata = map[string]interface{}{}
+	}
+
+	return &AuditEvent{
+		id:         value_objects.NewTokenID(),
+		actorID:    actorID,
+		action:     action,
+		targetType: targetType,
+		targetID:   targetID,
+		ipAddress:  ipAddress,
+		userAgent:  userAgent,
+		metadata:   metadata,
+		createdAt:  time.Now(),
+	}, nil
+}
+
+// Factory method from repository data
+func NewAuditEventFromRepository(
+	id *value_objects.TokenID,
+	actorID *value_objects.UserID,
+	action value_objects.AuditAction,
+	targetType string,
+	targetID string,
+	ipAddress string,
+	userAgent string,
+	metadata map[string]interface{},
+	createdAt time.Time,
+) *AuditEvent {
+	return &AuditEvent{
+		id:         id,
+		actorID:    actorID,
+		action:     action,
+		targetType: targetType,
+		targetID:   targetID,
+		ipAddress:  ipAddress,
+		userAgent:  userAgent,
+		metadata:   metadata,
+		createdAt:  createdAt,
+	}
+}
+
+// Getters
+func (e *AuditEvent) ID() *value_objects.TokenID {
+	return e.id
+}
+
+func (e *AuditEvent) ActorID() *value_objects.UserID {
+	return e.actorID
+}
+
+func (e *AuditEvent) Action() value_objects.AuditAction {
+	return e.action
+}
+
+func (e *AuditEvent) TargetType() string {
+	return e.targetType
+}
+
+func (e *AuditEvent) TargetID() string {
+	return e.targetID
+}
+
+func (e *AuditEvent) IPAddress() string {
+	return e.ipAddress
+}
+
+func (e *AuditEvent) UserAgent() string {
+	return e.userAgent
+}
+
+func (e *AuditEvent) Metadata() map[string]interface{} {
+	return e.metadata
+}
+
+func (e *AuditEvent) CreatedAt() time.Time {
+	return e.createdAt
+}
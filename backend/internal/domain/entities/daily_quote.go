@@ -0,0 +1,63 @@
+package entities
+
+import (
+	"errors"
+	"time"
+
+	"github.com/atdevten/peace/internal/domain/value_objects"
+)
+
+// DailyQuote is the quote shown to a user, or to anonymous visitors, on one calendar day.
+// The day is the local date of the user, kept as midnight UTC.
+type DailyQuote struct {
+	userID    *value_objects.UserID // nil for the global quote of anonymous visitors
+	day       time.Time
+	quoteID   *value_objects.QuoteID
+	createdAt time.Time
+}
+
+// NewDailyQuote picks the quote of the day for the user, or the global one when userID is nil
+func NewDailyQuote(userID *value_objects.UserID, day time.Time, quoteID *value_objects.QuoteID) (*DailyQuote, error) {
+	if quoteID == nil {
+		return nil, errors.New("quote ID is required")
+	}
+
+	return &DailyQuote{
+		userID:    userID,
+		day:       time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, time.UTC),
+		quoteID:   quoteID,
+		createdAt: time.Now(),
+	}, nil
+}
+
+// Factory method from repository data
+func NewDailyQuoteFromRepository(
+	userID *value_objects.UserID,
+	day time.Time,
+	quoteID *value_objects.QuoteID,
+	createdAt time.Time,
+) *DailyQuote {
+	return &DailyQuote{
+		userID:    userID,
+		day:       day,
+		quoteID:   quoteID,
+		createdAt: createdAt,
+	}
+}
+
+// Getters
+func (d *DailyQuote) UserID() *value_objects.UserID {
+	return d.userID
+}
+
+func (d *DailyQuote) Day() time.Time {
+	return d.day
+}
+
+func (d *DailyQuote) QuoteID() *value_objects.QuoteID {
+	return d.quoteID
+}
+
+func (d *DailyQuote) CreatedAt() time.Time {
+	return d.createdAt
+}
@@ -0,0 +1,149 @@
+package entities
+
+import (
+	"errors"
+	"time"
+
+	"github.com/atdevten/peace/internal/domain/value_objects"
+)
+
+// maxDataExportErrorLength matches the error column
+const maxDataExportErrorLength = 255
+
+// DataExport is a user's request for an archive of all their data. It is built in the
+// background and can be downloaded until it expires.
+type DataExport struct {
+	id          *value_objects.TokenID
+	userID      *value_objects.UserID
+	status      value_objects.DataExportStatus
+	sizeBytes   int64
+	errorReason *string
+	createdAt   time.Time
+	startedAt   *time.Time
+	completedAt *time.Time
+	expiresAt   *time.Time
+}
+
+// NewDataExport queues an export of the user's data
+func NewDataExport(userID *value_objects.UserID) (*DataExport, error) {
+	if userID == nil {
+		return nil, errors.New("user ID is required")
+	}
+
+	return &DataExport{
+		id:        value_objects.NewTokenID(),
+		userID:    userID,
+		status:    value_objects.DataExportStatusPending,
+		createdAt: time.Now(),
+	}, nil
+}
+
+// Factory method from repository data
+func NewDataExportFromRepository(
+	id *value_objects.TokenID,
+	userID *value_objects.UserID,
+	status value_objects.DataExportStatus,
+	sizeBytes int64,
+	errorReason *string,
+	createdAt time.Time,
+	startedAt *time.Time,
+	completedAt *time.Time,
+	expiresAt *time.Time,
+) *DataExport {
+	return &DataExport{
+		id:          id,
+		userID:      userID,
+		status:      status,
+		sizeBytes:   sizeBytes,
+		errorReason: errorReason,
+		createdAt:   createdAt,
+		startedAt:   startedAt,
+		completedAt: completedAt,
+		expiresAt:   expiresAt,
+	}
+}
+
+// Getters
+func (e *DataExport) ID() *value_objects.TokenID {
+	return e.id
+}
+
+func (e *DataExport) UserID() *value_objects.UserID {
+	return e.userID
+}
+
+func (e *DataExport) Status() value_objects.DataExportStatus {
+	return e.status
+}
+
+func (e *DataExport) SizeBytes() int64 {
+	return e.sizeBytes
+}
+
+func (e *DataExport) ErrorReason() *string {
+	return e.errorReason
+}
+
+func (e *DataExport) CreatedAt() time.Time {
+	return e.createdAt
+}
+
+func (e *DataExport) StartedAt() *time.Time {
+	return e.startedAt
+}
+
+func (e *DataExport) CompletedAt() *time.Time {
+	return e.completedAt
+}
+
+func (e *DataExport) ExpiresAt() *time.Time {
+	return e.expiresAt
+}
+
+// Business methods
+
+// IsInProgress reports whether the export is still waiting for or being built by a worker
+func (e *DataExport) IsInProgress() bool {
+	return e.status == value_objects.DataExportStatusPending || e.status == value_objects.DataExportStatusProcessing
+}
+
+// IsReady reports whether the archive can be downloaded at the given time
+func (e *DataExport) IsReady(now time.Time) bool {
+	return e.status == value_objects.DataExportStatusCompleted && e.expiresAt != nil && now.Before(*e.expiresAt)
+}
+
+// IsExpired reports whether a completed archive is past its download window
+func (e *DataExport) IsExpired(now time.Time) bool {
+	return e.status == value_objects.DataExportStatusCompleted && e.expiresAt != nil && !now.Before(*e.expiresAt)
+}
+
+// BelongsTo reports whether the export was requested by the given user
+func (e *DataExport) BelongsTo(userID *value_objects.UserID) bool {
+	return userID != nil && e.userID.String() == userID.String()
+}
+
+// Start marks the export as picked up by a worker
+func (e *DataExport) Start(now time.Time) {
+	e.status = value_objects.DataExportStatusProcessing
+	e.startedAt = &now
+	e.errorReason = nil
+}
+
+// Complete records the archive size and keeps it downloadable for ttl
+func (e *DataExport) Complete(sizeBytes int64, now time.Time, ttl time.Duration) {
+	expiresAt := now.Add(ttl)
+	e.status = value_objects.DataExportStatusCompleted
+	e.sizeBytes = sizeBytes
+	e.completedAt = &now
+	e.expiresAt = &expiresAt
+}
+
+// Fail records why the archive could not be built
+func (e *DataExport) Fail(reason string, now time.Time) {
+	if len(reason) > maxDataExportErrorLength {
+		reason = reason[:maxDataExportErrorLength]
+	}
+	e.status = value_objects.DataExportStatusFailed
+	e.errorReason = &reason
+	e.completedAt = &now
+}
@@ -0,0 +1,110 @@
+package entities
+
+import (
+	"errors"
+	"time"
+
+	"github.com/atdevten/peace/internal/domain/value_objects"
+)
+
+// EmailVerificationToken is a single-use, time-limited proof that the user owns their email.
+// Only the hash of the secret is kept; the raw secret is mailed to the user.
+type EmailVerificationToken struct {
+	id        *value_objects.TokenID
+	userID    *value_objects.UserID
+	tokenHash string
+	expiresAt time.Time
+	usedAt    *time.Time
+	createdAt time.Time
+}
+
+// NewEmailVerificationToken creates a verification token for the user that expires after ttl
+func NewEmailVerificationToken(userID *value_objects.UserID, secret *value_objects.SecretToken, ttl time.Duration) (*EmailVerificationToken, error) {
+	if userID == nil {
+		return nil, errors.New("user ID is required")
+	}
+
+	if secret == nil {
+		return nil, errors.New("secret token is required")
+	}
+
+	if ttl <= 0 {
+		return nil, errors.New("token lifetime must be positive")
+	}
+
+	now := time.Now()
+	return &EmailVerificationToken{
+		id:        value_objects.NewTokenID(),
+		userID:    userID,
+		tokenHash: secret.Hash(),
+		expiresAt: now.Add(ttl),
+		createdAt: now,
+	}, nil
+}
+
+// Factory method from repository data
+func NewEmailVerificationTokenFromRepository(
+	id *value_objects.TokenID,
+	userID *value_objects.UserID,
+	tokenHash string,
+	expiresAt time.Time,
+	usedAt *time.Time,
+	createdAt time.Time,
+) *EmailVerificationToken {
+	return &EmailVerificationToken{
+		id:        id,
+		userID:    userID,
+		tokenHash: tokenHash,
+		expiresAt: expiresAt,
+		usedAt:    usedAt,
+		createdAt: createdAt,
+	}
+}
+
+// Getters
+func (t *EmailVerificationToken) ID() *value_objects.TokenID {
+	return t.id
+}
+
+func (t *EmailVerificationToken) UserID() *value_objects.UserID {
+	return t.userID
+}
+
+func (t *EmailVerificationToken) TokenHash() string {
+	return t.tokenHash
+}
+
+func (t *EmailVerificationToken) ExpiresAt() time.Time {
+	return t.expiresAt
+}
+
+func (t *EmailVerificationToken) UsedAt() *time.Time {
+	return t.usedAt
+}
+
+func (t *EmailVerificationToken) CreatedAt() time.Time {
+	return t.createdAt
+}
+
+// Business methods
+func (t *EmailVerificationToken) IsExpired(now time.Time) bool {
+	return !now.Before(t.expiresAt)
+}
+
+func (t *EmailVerificationToken) IsUsed() bool {
+	return t.usedAt != nil
+}
+
+// Use marks the token as consumed, failing if it is spent or expired
+func (t *EmailVerificationToken) Use(now time.Time) error {
+	if t.IsUsed() {
+		return errors.New("verification token has already been used")
+	}
+
+	if t.IsExpired(now) {
+		return errors.New("verification token has expired")
+	}
+
+	t.usedAt = &now
+	return nil
+}
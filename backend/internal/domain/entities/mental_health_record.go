@@ -13,6 +13,7 @@ type MentalHealthRecord struct {
 	energyLevel *value_objects.EnergyLevel
 	notes       *string
 	status      *value_objects.MentalHealthRecordStatus
+	tags        []string // labels of the owner, private to them and unrelated to quote tags
 	createdAt   time.Time
 	updatedAt   time.Time
 	deletedAt   *time.Time
@@ -114,3 +115,12 @@ func (m *MentalHealthRecord) DeletedAt() *time.Time {
 func (m *MentalHealthRecord) Status() *value_objects.MentalHealthRecordStatus {
 	return m.status
 }
+
+func (m *MentalHealthRecord) Tags() []string {
+	return m.tags
+}
+
+// SetTags attaches the label names of this record
+func (m *MentalHealthRecord) SetTags(tags []string) {
+	m.tags = tags
+}
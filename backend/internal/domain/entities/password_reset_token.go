@@ -0,0 +1,110 @@
+package entities
+
+import (
+	"errors"
+	"time"
+
+	"github.com/atdevten/peace/internal/domain/value_objects"
+)
+
+// PasswordResetToken is a single-use, time-limited grant to set a new password.
+// Only the hash of the secret is kept; the raw secret is mailed to the user.
+type PasswordResetToken struct {
+	id        *value_objects.TokenID
+	userID    *value_objects.UserID
+	tokenHash string
+	expiresAt time.Time
+	usedAt    *time.Time
+	createdAt time.Time
+}
+
+// NewPasswordResetToken creates a reset token for the user that expires after ttl
+func NewPasswordResetToken(userID *value_objects.UserID, secret *value_objects.SecretToken, ttl time.Duration) (*PasswordResetToken, error) {
+	if userID == nil {
+		return nil, errors.New("user ID is required")
+	}
+
+	if secret == nil {
+		return nil, errors.New("secret token is required")
+	}
+
+	if ttl <= 0 {
+		return nil, errors.New("token lifetime must be positive")
+	}
+
+	now := time.Now()
+	return &PasswordResetToken{
+		id:        value_objects.NewTokenID(),
+		userID:    userID,
+		tokenHash: secret.Hash(),
+		expiresAt: now.Add(ttl),
+		createdAt: now,
+	}, nil
+}
+
+// Factory method from repository data
+func NewPasswordResetTokenFromRepository(
+	id *value_objects.TokenID,
+	userID *value_objects.UserID,
+	tokenHash string,
+	expiresAt time.Time,
+	usedAt *time.Time,
+	createdAt time.Time,
+) *PasswordResetToken {
+	return &PasswordResetToken{
+		id:        id,
+		userID:    userID,
+		tokenHash: tokenHash,
+		expiresAt: expiresAt,
+		usedAt:    usedAt,
+		createdAt: createdAt,
+	}
+}
+
+// Getters
+func (t *PasswordResetToken) ID() *value_objects.TokenID {
+	return t.id
+}
+
+func (t *PasswordResetToken) UserID() *value_objects.UserID {
+	return t.userID
+}
+
+func (t *PasswordResetToken) TokenHash() string {
+	return t.tokenHash
+}
+
+func (t *PasswordResetToken) ExpiresAt() time.Time {
+	return t.expiresAt
+}
+
+func (t *PasswordResetToken) UsedAt() *time.Time {
+	return t.usedAt
+}
+
+func (t *PasswordResetToken) CreatedAt() time.Time {
+	return t.createdAt
+}
+
+// Business methods
+func (t *PasswordResetToken) IsExpired(now time.Time) bool {
+	return !now.Before(t.expiresAt)
+}
+
+func (t *PasswordResetToken) IsUsed() bool {
+	return t.usedAt != nil
+}
+
+// Use marks the token as consumed, failing if it is spent or expired
+func (t *PasswordResetToken) Use(now time.Time) error {
+	if t.IsUsed() {
+		return errors.New("reset token has already been used")
+	}
+
+	if t.IsExpired(now) {
+		return errors.New("reset token has expired")
+	}
+
+	t.usedAt = &now
+	return nil
+}
@@ -0,0 +1,160 @@
+package entities
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/atdevten/peace/internal/domain/value_objects"
+)
+
+// PersonalAccessTokenPrefix starts every personal access token, telling them apart from JWTs
+// and making leaked tokens easy to find by secret scanners
+const PersonalAccessTokenPrefix = "peace_pat_"
+
+// maxPersonalAccessTokenNameLength matches the name column
+const maxPersonalAccessTokenNameLength = 100
+
+// PersonalAccessToken is a long-lived credential a user creates for scripts and integrations.
+// It acts as its user within its scopes; only the hash of the secret is kept.
+type PersonalAccessToken struct {
+	id         *value_objects.TokenID
+	userID     *value_objects.UserID
+	name       string
+	tokenHash  string
+	scopes     []value_objects.TokenScope
+	expiresAt  time.Time
+	lastUsedAt *time.Time
+	createdAt  time.Time
+}
+
+// NewPersonalAccessToken creates a token for the user that expires after ttl. The secret handed
+// to the user is PersonalAccessTokenPrefix followed by secret.
+func NewPersonalAccessToken(userID *value_objects.UserID, name string, secret *value_objects.SecretToken, scopes []value_objects.TokenScope, ttl time.Duration) (*PersonalAccessToken, error) {
+	if userID == nil {
+		return nil, errors.New("user ID is required")
+	}
+
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, errors.New("token name is required")
+	}
+	if len(name) > maxPersonalAccessTokenNameLength {
+		return nil, errors.New("token name too long")
+	}
+
+	if secret == nil {
+		return nil, errors.New("secret token is required")
+	}
+
+	if len(scopes) == 0 {
+		return nil, errors.New("at least one scope is required")
+	}
+
+	if ttl <= 0 {
+		return nil, errors.New("token lifetime must be positive")
+	}
+
+	// Drop duplicate scopes, keeping the order they were given in
+	unique := make([]value_objects.TokenScope, 0, len(scopes))
+	for _, scope := range scopes {
+		if !containsScope(unique, scope) {
+			unique = append(unique, scope)
+		}
+	}
+
+	now := time.Now()
+	return &PersonalAccessToken{
+		id:        value_objects.NewTokenID(),
+		userID:    userID,
+		name:      name,
+		tokenHash: secret.Hash(),
+		scopes:    unique,
+		expiresAt: now.Add(ttl),
+		createdAt: now,
+	}, nil
+}
+
+// Factory method from repository data
+func NewPersonalAccessTokenFromRepository(
+	id *value_objects.TokenID,
+	userID *value_objects.UserID,
+	name string,
+	tokenHash string,
+	scopes []value_objects.TokenScope,
+	expiresAt time.Time,
+	lastUsedAt *time.Time,
+	createdAt time.Time,
+) *PersonalAccessToken {
+	return &PersonalAccessToken{
+		id:         id,
+		userID:     userID,
+		name:       name,
+		tokenHash:  tokenHash,
+		scopes:     scopes,
+		expiresAt:  expiresAt,
+		lastUsedAt: lastUsedAt,
+		createdAt:  createdAt,
+	}
+}
+
+// Getters
+func (t *PersonalAccessToken) ID() *value_objects.TokenID {
+	return t.id
+}
+
+func (t *PersonalAccessToken) UserID() *value_objects.UserID {
+	return t.userID
+}
+
+func (t *PersonalAccessToken) Name() string {
+	return t.name
+}
+
+func (t *PersonalAccessToken) TokenHash() string {
+	return t.tokenHash
+}
+
+func (t *PersonalAccessToken) Scopes() []value_objects.TokenScope {
+	return t.scopes
+}
+
+func (t *PersonalAccessToken) ExpiresAt() time.Time {
+	return t.expiresAt
+}
+
+func (t *PersonalAccessToken) LastUsedAt() *time.Time {
+	return t.lastUsedAt
+}
+
+func (t *PersonalAccessToken) CreatedAt() time.Time {
+	return t.createdAt
+}
+
+// Business methods
+func (t *PersonalAccessToken) IsExpired(now time.Time) bool {
+	return !now.Before(t.expiresAt)
+}
+
+func (t *PersonalAccessToken) HasScope(scope value_objects.TokenScope) bool {
+	return containsScope(t.scopes, scope)
+}
+
+// BelongsTo reports whether the token was created by the given user
+func (t *PersonalAccessToken) BelongsTo(userID *value_objects.UserID) bool {
+	return userID != nil && t.userID.String() == userID.String()
+}
+
+// Touch records use of the token
+func (t *PersonalAccessToken) Touch(now time.Time) {
+	t.lastUsedAt = &now
+}
+
+func containsScope(scopes []value_objects.TokenScope, scope value_objects.TokenScope) bool {
+	for _, s := range scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,78 @@
+package entities
+
+import (
+	"errors"
+	"time"
+
+	"github.com/atdevten/peace/internal/domain/value_objects"
+)
+
+// QuoteFeedback is the latest reaction of a user to a quote; a new signal replaces the old one
+type QuoteFeedback struct {
+	userID    *value_objects.UserID
+	quoteID   *value_objects.QuoteID
+	signal    value_objects.QuoteFeedbackSignal
+	createdAt time.Time
+	updatedAt time.Time
+}
+
+func NewQuoteFeedback(userID *value_objects.UserID, quoteID *value_objects.QuoteID, signal string) (*QuoteFeedback, error) {
+	if userID == nil {
+		return nil, errors.New("user ID is required")
+	}
+	if quoteID == nil {
+		return nil, errors.New("quote ID is required")
+	}
+
+	signalVO, err := value_objects.NewQuoteFeedbackSignal(signal)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	return &QuoteFeedback{
+		userID:    userID,
+		quoteID:   quoteID,
+		signal:    *signalVO,
+		createdAt: now,
+		updatedAt: now,
+	}, nil
+}
+
+// Factory method from repository data
+func NewQuoteFeedbackFromRepository(
+	userID *value_objects.UserID,
+	quoteID *value_objects.QuoteID,
+	signal value_objects.QuoteFeedbackSignal,
+	createdAt time.Time,
+	updatedAt time.Time,
+) *QuoteFeedback {
+	return &QuoteFeedback{
+		userID:    userID,
+		quoteID:   quoteID,
+		signal:    signal,
+		createdAt: createdAt,
+		updatedAt: updatedAt,
+	}
+}
+
+// Getters
+func (f *QuoteFeedback) UserID() *value_objects.UserID {
+	return f.userID
+}
+
+func (f *QuoteFeedback) QuoteID() *value_objects.QuoteID {
+	return f.quoteID
+}
+
+func (f *QuoteFeedback) Signal() value_objects.QuoteFeedbackSignal {
+	return f.signal
+}
+
+func (f *QuoteFeedback) CreatedAt() time.Time {
+	return f.createdAt
+}
+
+func (f *QuoteFeedback) UpdatedAt() time.Time {
+	return f.updatedAt
+}
@@ -0,0 +1,79 @@
+package entities
+
+import (
+	"errors"
+	"time"
+
+	"github.com/atdevten/peace/internal/domain/value_objects"
+)
+
+// RecoveryCode is a single-use backup for the second login factor.
+// Only the hash of the code is kept; the raw code is shown to the user once.
+type RecoveryCode struct {
+	id        *value_objects.TokenID
+	userID    *value_objects.UserID
+	codeHash  string
+	usedAt    *time.Time
+	createdAt time.Time
+}
+
+// NewRecoveryCode creates a stored recovery code for the user
+func NewRecoveryCode(userID *value_objects.UserID, code *value_objects.RecoveryCode) (*RecoveryCode, error) {
+	if userID == nil {
+		return nil, errors.New("user ID is required")
+	}
+
+	if code == nil {
+		return nil, errors.New("recovery code is required")
+	}
+
+	return &RecoveryCode{
+		id:        value_objects.NewTokenID(),
+		userID:    userID,
+		codeHash:  code.Hash(),
+		createdAt: time.Now(),
+	}, nil
+}
+
+// Factory method from repository data
+func NewRecoveryCodeFromRepository(
+	id *value_objects.TokenID,
+	userID *value_objects.UserID,
+	codeHash string,
+	usedAt *time.Time,
+	createdAt time.Time,
+) *RecoveryCode {
+	return &RecoveryCode{
+		id:        id,
+		userID:    userID,
+		codeHash:  codeHash,
+		usedAt:    usedAt,
+		createdAt: createdAt,
+	}
+}
+
+// Getters
+func (c *RecoveryCode) ID() *value_objects.TokenID {
+	return c.id
+}
+
+func (c *RecoveryCode) UserID() *value_objects.UserID {
+	return c.userID
+}
+
+func (c *RecoveryCode) CodeHash() string {
+	return c.codeHash
+}
+
+func (c *RecoveryCode) UsedAt() *time.Time {
+	return c.usedAt
+}
+
+func (c *RecoveryCode) CreatedAt() time.Time {
+	return c.createdAt
+}
+
+// Business methods
+func (c *RecoveryCode) IsUsed() bool {
+	return c.usedAt != nil
+}
@@ -0,0 +1,142 @@
+package entities
+
+import (
+	"errors"
+	"time"
+
+	"github.com/atdevten/peace/internal/domain/value_objects"
+)
+
+// maxDeviceLength caps the stored device description (usually a User-Agent)
+const maxDeviceLength = 255
+
+// RefreshToken is one link in a rotation chain. Every login starts a new family;
+// each refresh consumes the current token and issues its successor in the same family.
+type RefreshToken struct {
+	id        *value_objects.TokenID
+	familyID  *value_objects.TokenID
+	userID    *value_objects.UserID
+	device    string
+	expiresAt time.Time
+	usedAt    *time.Time
+	revokedAt *time.Time
+	createdAt time.Time
+}
+
+// NewRefreshToken creates a refresh token; a nil familyID starts a new family
+func NewRefreshToken(userID *value_objects.UserID, familyID *value_objects.TokenID, device string, ttl time.Duration) (*RefreshToken, error) {
+	if userID == nil {
+		return nil, errors.New("user ID is required")
+	}
+
+	if ttl <= 0 {
+		return nil, errors.New("token lifetime must be positive")
+	}
+
+	id := value_objects.NewTokenID()
+	if familyID == nil {
+		familyID = id
+	}
+
+	if len(device) > maxDeviceLength {
+		device = device[:maxDeviceLength]
+	}
+
+	now := time.Now()
+	return &RefreshToken{
+		id:        id,
+		familyID:  familyID,
+		userID:    userID,
+		device:    device,
+		expiresAt: now.Add(ttl),
+		createdAt: now,
+	}, nil
+}
+
+// Factory method from repository data
+func NewRefreshTokenFromRepository(
+	id *value_objects.TokenID,
+	familyID *value_objects.TokenID,
+	userID *value_objects.UserID,
+	device string,
+	expiresAt time.Time,
+	usedAt *time.Time,
+	revokedAt *time.Time,
+	createdAt time.Time,
+) *RefreshToken {
+	return &RefreshToken{
+		id:        id,
+		familyID:  familyID,
+		userID:    userID,
+		device:    device,
+		expiresAt: expiresAt,
+		usedAt:    usedAt,
+		revokedAt: revokedAt,
+		createdAt: createdAt,
+	}
+}
+
+// Getters
+func (t *RefreshToken) ID() *value_objects.TokenID {
+	return t.id
+}
+
+func (t *RefreshToken) FamilyID() *value_objects.TokenID {
+	return t.familyID
+}
+
+func (t *RefreshToken) UserID() *value_objects.UserID {
+	return t.userID
+}
+
+func (t *RefreshToken) Device() string {
+	return t.device
+}
+
+func (t *RefreshToken) ExpiresAt() time.Time {
+	return t.expiresAt
+}
+
+func (t *RefreshToken) UsedAt() *time.Time {
+	return t.usedAt
+}
+
+func (t *RefreshToken) RevokedAt() *time.Time {
+	return t.revokedAt
+}
+
+func (t *RefreshToken) CreatedAt() time.Time {
+	return t.createdAt
+}
+
+// Business methods
+func (t *RefreshToken) IsExpired(now time.Time) bool {
+	return !now.Before(t.expiresAt)
+}
+
+// IsUsed reports whether the token was already rotated; presenting it again means it leaked
+func (t *RefreshToken) IsUsed() bool {
+	return t.usedAt != nil
+}
+
+func (t *RefreshToken) IsRevoked() bool {
+	return t.revokedAt != nil
+}
+
+// Use marks the token as rotated, failing if it is spent, revoked or expired
+func (t *RefreshToken) Use(now time.Time) error {
+	if t.IsRevoked() {
+		return errors.New("refresh token has been revoked")
+	}
+
+	if t.IsUsed() {
+		return errors.New("refresh token has already been used")
+	}
+
+	if t.IsExpired(now) {
+		return errors.New("refresh token has expired")
+	}
+
+	t.usedAt = &now
+	return nil
+}
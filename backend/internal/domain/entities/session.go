@@ -0,0 +1,134 @@
+package entities
+
+import (
+	"errors"
+	"time"
+
+	"github.com/atdevten/peace/internal/domain/value_objects"
+)
+
+// maxIPAddressLength fits an IPv6 address with zone
+const maxIPAddressLength = 64
+
+// Session represents one login of a user on a device. Its ID is shared by the
+// refresh token family of that login and carried as the sid claim of access tokens.
+type Session struct {
+	id         *value_objects.TokenID
+	userID     *value_objects.UserID
+	userAgent  string
+	ipAddress  string
+	provider   string
+	createdAt  time.Time
+	lastUsedAt time.Time
+	revokedAt  *time.Time
+}
+
+// NewSession starts a session for a login through the given provider (local, google, ...)
+func NewSession(userID *value_objects.UserID, userAgent string, ipAddress string, provider string) (*Session, error) {
+	if userID == nil {
+		return nil, errors.New("user ID is required")
+	}
+
+	if provider == "" {
+		return nil, errors.New("provider is required")
+	}
+
+	if len(userAgent) > maxDeviceLength {
+		userAgent = userAgent[:maxDeviceLength]
+	}
+
+	if len(ipAddress) > maxIPAddressLength {
+		ipAddress = ipAddress[:maxIPAddressLength]
+	}
+
+	now := time.Now()
+	return &Session{
+		id:         value_objects.NewTokenID(),
+		userID:     userID,
+		userAgent:  userAgent,
+		ipAddress:  ipAddress,
+		provider:   provider,
+		createdAt:  now,
+		lastUsedAt: now,
+	}, nil
+}
+
+// Factory method from repository data
+func NewSessionFromRepository(
+	id *value_objects.TokenID,
+	userID *value_objects.UserID,
+	userAgent string,
+	ipAddress string,
+	provider string,
+	createdAt time.Time,
+	lastUsedAt time.Time,
+	revokedAt *time.Time,
+) *Session {
+	return &Session{
+		id:         id,
+		userID:     userID,
+		userAgent:  userAgent,
+		ipAddress:  ipAddress,
+		provider:   provider,
+		createdAt:  createdAt,
+		lastUsedAt: lastUsedAt,
+		revokedAt:  revokedAt,
+	}
+}
+
+// Getters
+func (s *Session) ID() *value_objects.TokenID {
+	return s.id
+}
+
+func (s *Session) UserID() *value_objects.UserID {
+	return s.userID
+}
+
+func (s *Session) UserAgent() string {
+	return s.userAgent
+}
+
+func (s *Session) IPAddress() string {
+	return s.ipAddress
+}
+
+func (s *Session) Provider() string {
+	return s.provider
+}
+
+func (s *Session) CreatedAt() time.Time {
+	return s.createdAt
+}
+
+func (s *Session) LastUsedAt() time.Time {
+	return s.lastUsedAt
+}
+
+func (s *Session) RevokedAt() *time.Time {
+	return s.revokedAt
+}
+
+// Business methods
+func (s *Session) IsRevoked() bool {
+	return s.revokedAt != nil
+}
+
+// BelongsTo reports whether the session was started by the given user
+func (s *Session) BelongsTo(userID *value_objects.UserID) bool {
+	return userID != nil && s.userID.String() == userID.String()
+}
+
+// Touch records activity on the session
+func (s *Session) Touch(now time.Time) {
+	s.lastUsedAt = now
+}
+
+func (s *Session) Revoke(now time.Time) error {
+	if s.IsRevoked() {
+		return errors.New("session is already revoked")
+	}
+
+	s.revokedAt = &now
+	return nil
+}
@@ -0,0 +1,100 @@
+package entities
+
+import (
+	"errors"
+	"time"
+
+	"github.com/atdevten/peace/internal/domain/value_objects"
+)
+
+// TOTPFactor is a user's authenticator app enrollment. It only protects logins
+// once confirmed with a first valid code.
+type TOTPFactor struct {
+	userID       *value_objects.UserID
+	secret       string
+	confirmedAt  *time.Time
+	lastUsedStep int64
+	createdAt    time.Time
+}
+
+// NewTOTPFactor starts an unconfirmed enrollment with the given base32 secret
+func NewTOTPFactor(userID *value_objects.UserID, secret string) (*TOTPFactor, error) {
+	if userID == nil {
+		return nil, errors.New("user ID is required")
+	}
+
+	if secret == "" {
+		return nil, errors.New("secret is required")
+	}
+
+	return &TOTPFactor{
+		userID:    userID,
+		secret:    secret,
+		createdAt: time.Now(),
+	}, nil
+}
+
+// Factory method from repository data
+func NewTOTPFactorFromRepository(
+	userID *value_objects.UserID,
+	secret string,
+	confirmedAt *time.Time,
+	lastUsedStep int64,
+	createdAt time.Time,
+) *TOTPFactor {
+	return &TOTPFactor{
+		userID:       userID,
+		secret:       secret,
+		confirmedAt:  confirmedAt,
+		lastUsedStep: lastUsedStep,
+		createdAt:    createdAt,
+	}
+}
+
+// Getters
+func (f *TOTPFactor) UserID() *value_objects.UserID {
+	return f.userID
+}
+
+func (f *TOTPFactor) Secret() string {
+	return f.secret
+}
+
+func (f *TOTPFactor) ConfirmedAt() *time.Time {
+	return f.confirmedAt
+}
+
+// LastUsedStep is the time step of the last accepted code; codes of earlier or equal steps are replays
+func (f *TOTPFactor) LastUsedStep() int64 {
+	return f.lastUsedStep
+}
+
+func (f *TOTPFactor) CreatedAt() time.Time {
+	return f.createdAt
+}
+
+// Business methods
+func (f *TOTPFactor) IsConfirmed() bool {
+	return f.confirmedAt != nil
+}
+
+// Confirm activates the factor with the step of the code that proved possession
+func (f *TOTPFactor) Confirm(step int64, now time.Time) error {
+	if f.IsConfirmed() {
+		return errors.New("two-factor authentication is already enabled")
+	}
+
+	f.confirmedAt = &now
+	f.lastUsedStep = step
+	return nil
+}
+
+// UseStep records an accepted code, refusing codes that were already used
+func (f *TOTPFactor) UseStep(step int64) error {
+	if step <= f.lastUsedStep {
+		return errors.New("code has already been used")
+	}
+
+	f.lastUsedStep = step
+	return nil
+}
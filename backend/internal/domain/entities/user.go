@@ -20,11 +20,14 @@ type User struct {
 	isActive      bool
 	emailVerified bool
 	authProvider  string
-	googleID      *string
-	googlePicture *string
+	pictureURL    *string
+	timezone      *value_objects.Timezone
+	feedOptOut    bool
+	role          value_objects.Role
 	createdAt     time.Time
 	updatedAt     time.Time
 	deletedAt     *time.Time
+	version       int // version of the stored row this entity was read from
 }
 
 // NewUser creates a new User entity with validation
@@ -77,19 +80,25 @@ func NewUser(
 		isActive:      true,
 		emailVerified: false,
 		authProvider:  "local",
-		googleID:      nil,
-		googlePicture: nil,
+		pictureURL:    nil,
+		timezone:      value_objects.NewDefaultTimezone(),
+		role:          value_objects.RoleUser,
 	}, nil
 }
 
-// NewGoogleUser creates a new User entity from Google OAuth data
-func NewGoogleUser(
+// NewExternalUser creates a new User entity signing up through an external login provider;
+// the provider account itself is recorded as a UserIdentity
+func NewExternalUser(
 	email string,
 	firstName *string,
 	lastName *string,
-	googleID string,
-	googlePicture *string,
+	provider string,
+	pictureURL *string,
 ) (*User, error) {
+	if strings.TrimSpace(provider) == "" || provider == "local" {
+		return nil, errors.New("external provider is required")
+	}
+
 	// Create and validate value objects
 	emailVO, err := value_objects.NewEmail(email)
 	if err != nil {
@@ -168,12 +177,13 @@ func NewGoogleUser(
 		username:      usernameVO,
 		firstName:     firstNameVO,
 		lastName:      lastNameVO,
-		passwordHash:  nil, // No password for Google users
+		passwordHash:  nil, // No password for external sign-ups
 		isActive:      true,
-		emailVerified: true, // Google emails are verified
-		authProvider:  "google",
-		googleID:      &googleID,
-		googlePicture: googlePicture,
+		emailVerified: true, // Only provider-verified emails sign up
+		authProvider:  provider,
+		pictureURL:    pictureURL,
+		timezone:      value_objects.NewDefaultTimezone(),
+		role:          value_objects.RoleUser,
 		deletedAt:     nil,
 		createdAt:     time.Now(),
 		updatedAt:     time.Now(),
@@ -241,12 +251,27 @@ func (u *User) AuthProvider() string {
 	return u.authProvider
 }
 
-func (u *User) GoogleID() *string {
-	return u.googleID
+func (u *User) PictureURL() *string {
+	return u.pictureURL
+}
+
+// HasPassword reports whether the user can log in with a password
+func (u *User) HasPassword() bool {
+	return u.passwordHash != nil
+}
+
+func (u *User) Timezone() *value_objects.Timezone {
+	return u.timezone
 }
 
-func (u *User) GooglePicture() *string {
-	return u.googlePicture
+// FeedOptOut reports whether the user's public records are hidden from the community feed
+func (u *User) FeedOptOut() bool {
+	return u.feedOptOut
+}
+
+// Role is the access level checked by management endpoints
+func (u *User) Role() value_objects.Role {
+	return u.role
 }
 
 func (u *User) IsActive() bool {
@@ -269,12 +294,37 @@ func (u *User) DeletedAt() *time.Time {
 	return u.deletedAt
 }
 
+// Version is the version of the stored row; an update only applies while it is still current
+func (u *User) Version() int {
+	return u.version
+}
+
+// SetVersion records the version the repository stored for this user
+func (u *User) SetVersion(version int) {
+	u.version = version
+}
+
 // Business methods
 func (u *User) VerifyPassword(password string) error {
 	if !u.isActive {
 		return errors.New("user account is deactivated")
 	}
 
+	return u.CheckPassword(password)
+}
+
+// VerifyDeletedPassword checks the password of a deleted account, which stays inactive until it is restored
+func (u *User) VerifyDeletedPassword(password string) error {
+	if u.deletedAt == nil {
+		return errors.New("user is not deleted")
+	}
+
+	return u.CheckPassword(password)
+}
+
+// CheckPassword checks the password alone, whatever the state of the account; callers that log
+// the user in check CanLogin as well
+func (u *User) CheckPassword(password string) error {
 	passwordVO, err := value_objects.NewPassword(password)
 	if err != nil {
 		return err
@@ -346,6 +396,27 @@ func (u *User) UpdateProfile(firstName *string, lastName *string) error {
 	return nil
 }
 
+func (u *User) UpdateTimezone(timezone string) error {
+	timezoneVO, err := value_objects.NewTimezone(timezone)
+	if err != nil {
+		return err
+	}
+
+	u.timezone = timezoneVO
+	u.updatedAt = time.Now()
+	return nil
+}
+
+func (u *User) UpdateFeedOptOut(optOut bool) {
+	u.feedOptOut = optOut
+	u.updatedAt = time.Now()
+}
+
+func (u *User) ChangeRole(role value_objects.Role) {
+	u.role = role
+	u.updatedAt = time.Now()
+}
+
 func (u *User) SoftDelete() error {
 	if u.deletedAt != nil {
 		return errors.New("user is already deleted")
@@ -358,6 +429,23 @@ func (u *User) SoftDelete() error {
 	return nil
 }
 
+// IsRestorable reports whether a deleted account is still within the grace period after which it is purged or anonymized
+func (u *User) IsRestorable(gracePeriod time.Duration, now time.Time) bool {
+	return u.deletedAt != nil && now.Before(u.deletedAt.Add(gracePeriod))
+}
+
+// Restore undoes SoftDelete and reactivates the account
+func (u *User) Restore() error {
+	if u.deletedAt == nil {
+		return errors.New("user is not deleted")
+	}
+
+	u.deletedAt = nil
+	u.isActive = true
+	u.updatedAt = time.Now()
+	return nil
+}
+
 // Factory method from repository data
 func NewUserFromRepository(
 	id *value_objects.UserID,
@@ -369,12 +457,23 @@ func NewUserFromRepository(
 	isActive bool,
 	emailVerified bool,
 	authProvider string,
-	googleID *string,
-	googlePicture *string,
+	pictureURL *string,
+	timezone *value_objects.Timezone,
+	feedOptOut bool,
+	role value_objects.Role,
 	createdAt time.Time,
 	updatedAt time.Time,
 	deletedAt *time.Time,
+	version int,
 ) *User {
+	if timezone == nil {
+		timezone = value_objects.NewDefaultTimezone()
+	}
+
+	if role == "" {
+		role = value_objects.RoleUser
+	}
+
 	return &User{
 		id:            id,
 		email:         email,
@@ -385,11 +484,14 @@ func NewUserFromRepository(
 		isActive:      isActive,
 		emailVerified: emailVerified,
 		authProvider:  authProvider,
-		googleID:      googleID,
-		googlePicture: googlePicture,
+		pictureURL:    pictureURL,
+		timezone:      timezone,
+		feedOptOut:    feedOptOut,
+		role:          role,
 		createdAt:     createdAt,
 		updatedAt:     updatedAt,
 		deletedAt:     deletedAt,
+		version:       version,
 	}
 }
 
@@ -412,8 +514,9 @@ func (u *User) GetFullName() string {
 	return firstName + " " + lastName
 }
 
-// CanLogin checks if user can login
-func (u *User) CanLogin() error {
+// CanLogin checks if user can login. When requireVerifiedEmail is set, local
+// accounts must have confirmed their email first; OAuth accounts are verified by the provider.
+func (u *User) CanLogin(requireVerifiedEmail bool) error {
 	if !u.isActive {
 		return errors.New("account is deactivated")
 	}
@@ -422,5 +525,9 @@ func (u *User) CanLogin() error {
 		return errors.New("account is deleted")
 	}
 
+	if requireVerifiedEmail && u.authProvider == "local" && !u.emailVerified {
+		return errors.New("email address is not verified")
+	}
+
 	return nil
 }
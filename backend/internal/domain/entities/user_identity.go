@@ -0,0 +1,81 @@
+package entities
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/atdevten/peace/internal/domain/value_objects"
+)
+
+// UserIdentity links a user to an account at an external login provider (google, keycloak, github, ...).
+// A provider account belongs to at most one user, and a user links at most one account per provider.
+type UserIdentity struct {
+	userID    *value_objects.UserID
+	provider  string
+	subject   string
+	email     string
+	createdAt time.Time
+}
+
+// NewUserIdentity links the provider account identified by subject; email is informational only
+func NewUserIdentity(userID *value_objects.UserID, provider string, subject string, email string) (*UserIdentity, error) {
+	if userID == nil {
+		return nil, errors.New("user ID is required")
+	}
+
+	if strings.TrimSpace(provider) == "" {
+		return nil, errors.New("provider is required")
+	}
+
+	if strings.TrimSpace(subject) == "" {
+		return nil, errors.New("subject is required")
+	}
+
+	return &UserIdentity{
+		userID:    userID,
+		provider:  provider,
+		subject:   subject,
+		email:     email,
+		createdAt: time.Now(),
+	}, nil
+}
+
+// Factory method from repository data
+func NewUserIdentityFromRepository(
+	userID *value_objects.UserID,
+	provider string,
+	subject string,
+	email string,
+	createdAt time.Time,
+) *UserIdentity {
+	return &UserIdentity{
+		userID:    userID,
+		provider:  provider,
+		subject:   subject,
+		email:     email,
+		createdAt: createdAt,
+	}
+}
+
+// Getters
+func (i *UserIdentity) UserID() *value_objects.UserID {
+	return i.userID
+}
+
+func (i *UserIdentity) Provider() string {
+	return i.provider
+}
+
+// Subject is the stable account ID at the provider (the OIDC sub claim)
+func (i *UserIdentity) Subject() string {
+	return i.subject
+}
+
+func (i *UserIdentity) Email() string {
+	return i.email
+}
+
+func (i *UserIdentity) CreatedAt() time.Time {
+	return i.createdAt
+}
@@ -0,0 +1,33 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/atdevten/peace/internal/domain/entities"
+	"github.com/atdevten/peace/internal/domain/value_objects"
+)
+
+// AuditEventCursor is the (created_at, id) position of the last event of a page
+type AuditEventCursor struct {
+	CreatedAt time.Time
+	ID        string
+}
+
+// AuditEventFilter pages through audit events, newest first; nil fields are ignored
+type AuditEventFilter struct {
+	ActorID    *value_objects.UserID
+	Action     *value_objects.AuditAction
+	TargetType *string
+	TargetID   *string
+	From       *time.Time        // inclusive
+	To         *time.Time        // exclusive
+	Cursor     *AuditEventCursor // only events before this position
+	Limit      int
+}
+
+// AuditEventRepository is append-only: events can be recorded and read, never changed or removed
+type AuditEventRepository interface {
+	Create(ctx context.Context, event *entities.AuditEvent) error
+	List(ctx context.Context, filter *AuditEventFilter) ([]*entities.AuditEvent, error)
+}
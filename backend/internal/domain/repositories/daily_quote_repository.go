@@ -0,0 +1,25 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/atdevten/peace/internal/domain/entities"
+	"github.com/atdevten/peace/internal/domain/value_objects"
+)
+
+var (
+	ErrDailyQuoteNotFound = errors.New("daily quote not found")
+)
+
+// DailyQuoteRepository keeps the history of quotes of the day; a nil user ID stands for the
+// global quote of anonymous visitors
+type DailyQuoteRepository interface {
+	// GetByDay returns the quote picked for the day, or ErrDailyQuoteNotFound
+	GetByDay(ctx context.Context, userID *value_objects.UserID, day time.Time) (*entities.DailyQuote, error)
+	// ListQuoteIDsSince returns the quotes picked on days from since onwards
+	ListQuoteIDsSince(ctx context.Context, userID *value_objects.UserID, since time.Time) ([]*value_objects.QuoteID, error)
+	// Save records the quote of the day, replacing an earlier pick for the same day
+	Save(ctx context.Context, dailyQuote *entities.DailyQuote) error
+}
@@ -0,0 +1,26 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/atdevten/peace/internal/domain/entities"
+	"github.com/atdevten/peace/internal/domain/value_objects"
+)
+
+var (
+	ErrDataExportNotFound = errors.New("data export not found")
+)
+
+type DataExportRepository interface {
+	Create(ctx context.Context, export *entities.DataExport) error
+	GetByID(ctx context.Context, id *value_objects.TokenID) (*entities.DataExport, error)
+	// ListByUserID returns all exports of the user, newest first
+	ListByUserID(ctx context.Context, userID *value_objects.UserID) ([]*entities.DataExport, error)
+	// ClaimNext marks the oldest pending export as processing and returns it, along with exports
+	// whose worker started before staleBefore and never finished. It returns ErrDataExportNotFound
+	// when there is nothing to do; concurrent callers never claim the same export.
+	ClaimNext(ctx context.Context, now time.Time, staleBefore time.Time) (*entities.DataExport, error)
+	Update(ctx context.Context, export *entities.DataExport) error
+}
@@ -0,0 +1,25 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"github.com/atdevten/peace/internal/domain/entities"
+	"github.com/atdevten/peace/internal/domain/value_objects"
+)
+
+var (
+	ErrEmailVerificationTokenNotFound = errors.New("email verification token not found")
+	ErrEmailVerificationTokenUsed     = errors.New("email verification token has already been used")
+)
+
+type EmailVerificationTokenRepository interface {
+	Create(ctx context.Context, token *entities.EmailVerificationToken) error
+	GetByTokenHash(ctx context.Context, tokenHash string) (*entities.EmailVerificationToken, error)
+	// GetLatestByUserID returns the most recently issued token, used to throttle resends
+	GetLatestByUserID(ctx context.Context, userID *value_objects.UserID) (*entities.EmailVerificationToken, error)
+	// MarkUsed consumes the token, returning ErrEmailVerificationTokenUsed if another request already did
+	MarkUsed(ctx context.Context, token *entities.EmailVerificationToken) error
+	// InvalidateByUserID consumes every outstanding token of the user
+	InvalidateByUserID(ctx context.Context, userID *value_objects.UserID) error
+}
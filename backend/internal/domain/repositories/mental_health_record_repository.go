@@ -8,21 +8,127 @@ import (
 	"github.com/atdevten/peace/internal/domain/value_objects"
 )
 
+// MentalHealthRecordCursor is the (created_at, id) position of the last record of a page
+type MentalHealthRecordCursor struct {
+	CreatedAt time.Time
+	ID        string
+}
+
 type MentalHealthRecordFilter struct {
 	UserID    *value_objects.UserID
 	StartedAt *time.Time
 	EndedAt   *time.Time
+	Cursor    *MentalHealthRecordCursor // only records after this position in sort order
 	Limit     *int
 	Offset    *int
-	OrderDesc bool // default true
+	OrderDesc bool // newest first when true
+
+	// Optional level bounds, inclusive
+	MinHappyLevel  *value_objects.HappyLevel
+	MaxHappyLevel  *value_objects.HappyLevel
+	MinEnergyLevel *value_objects.EnergyLevel
+	MaxEnergyLevel *value_objects.EnergyLevel
+	Status         *value_objects.MentalHealthRecordStatus
+	Search         *string  // full-text query over notes
+	TagNames       []string // records must carry every listed tag
+}
+
+// MentalHealthRecordTagReportFilter selects the records aggregated by GetTagMoodAverages
+type MentalHealthRecordTagReportFilter struct {
+	UserID    *value_objects.UserID
+	StartedAt *time.Time
+	EndedAt   *time.Time
+}
+
+// TagMoodAverage holds the mean levels of the records carrying one tag
+type TagMoodAverage struct {
+	TagName   string
+	Count     int
+	HappyAvg  float64
+	EnergyAvg float64
+}
+
+// MentalHealthRecordAnalyticsFilter selects the records aggregated by GetAnalytics
+type MentalHealthRecordAnalyticsFilter struct {
+	UserID    *value_objects.UserID
+	StartedAt time.Time
+	EndedAt   time.Time
+	Location  *time.Location // days, weekdays and hours are bucketed in this location
+}
+
+// MentalHealthRecordSummary holds whole-range aggregates of happy and energy levels
+type MentalHealthRecordSummary struct {
+	Count            int
+	HappyAvg         float64
+	HappyMin         int
+	HappyMax         int
+	HappyStddev      float64
+	HappyTrendSlope  float64 // change in happy level per day
+	EnergyAvg        float64
+	EnergyMin        int
+	EnergyMax        int
+	EnergyStddev     float64
+	EnergyTrendSlope float64 // change in energy level per day
+}
+
+// MentalHealthRecordDailyAverage holds one local day with its trailing rolling averages
+type MentalHealthRecordDailyAverage struct {
+	Date            string // YYYY-MM-DD in the filter location
+	Count           int
+	HappyAvg        float64
+	EnergyAvg       float64
+	HappyRolling7   float64
+	EnergyRolling7  float64
+	HappyRolling30  float64
+	EnergyRolling30 float64
+}
+
+// MentalHealthRecordBucketAverage holds the means for one weekday (0 = Sunday) or hour of day
+type MentalHealthRecordBucketAverage struct {
+	Bucket    int
+	Count     int
+	HappyAvg  float64
+	EnergyAvg float64
+}
+
+type MentalHealthRecordAnalytics struct {
+	Summary  MentalHealthRecordSummary
+	Daily    []MentalHealthRecordDailyAverage
+	Weekdays []MentalHealthRecordBucketAverage
+	Hours    []MentalHealthRecordBucketAverage
+}
+
+// PublicFeedFilter pages through public records of active, opted-in users, newest first
+type PublicFeedFilter struct {
+	Cursor *MentalHealthRecordCursor
+	Limit  int
+}
+
+// PublicFeedEntry is a public record joined with the author's display fields only
+type PublicFeedEntry struct {
+	RecordID    *value_objects.MentalHealthRecordID
+	HappyLevel  int
+	EnergyLevel int
+	Notes       *string
+	CreatedAt   time.Time
+	Username    string
+	AvatarURL   *string
 }
 
 type MentalHealthRecordRepository interface {
+	// Create stores the record with its labels (Tags) in one transaction, creating the owner's
+	// labels that do not exist yet
 	Create(ctx context.Context, record *entities.MentalHealthRecord) error
 	GetByID(ctx context.Context, id *value_objects.MentalHealthRecordID) (*entities.MentalHealthRecord, error)
 	GetByFilter(ctx context.Context, filter *MentalHealthRecordFilter) ([]*entities.MentalHealthRecord, error)
 	GetAll(ctx context.Context) ([]*entities.MentalHealthRecord, error)
+	// Update stores the record and, unless its Tags are nil, replaces its labels in the same transaction
 	Update(ctx context.Context, record *entities.MentalHealthRecord) error
 	Delete(ctx context.Context, id *value_objects.MentalHealthRecordID) error
-	GetDistinctDatesForUser(ctx context.Context, userID *value_objects.UserID) ([]string, error)
+	GetDistinctDatesForUser(ctx context.Context, userID *value_objects.UserID, location *time.Location) ([]string, error)
+	GetAnalytics(ctx context.Context, filter *MentalHealthRecordAnalyticsFilter) (*MentalHealthRecordAnalytics, error)
+	GetTagMoodAverages(ctx context.Context, filter *MentalHealthRecordTagReportFilter) ([]*TagMoodAverage, error)
+	GetPublicFeed(ctx context.Context, filter *PublicFeedFilter) ([]*PublicFeedEntry, error)
+	// GetTagsByRecordIDs returns the label names of each record, keyed by record ID and sorted by name
+	GetTagsByRecordIDs(ctx context.Context, recordIDs []*value_objects.MentalHealthRecordID) (map[string][]string, error)
 }
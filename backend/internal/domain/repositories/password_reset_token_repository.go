@@ -0,0 +1,23 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"github.com/atdevten/peace/internal/domain/entities"
+	"github.com/atdevten/peace/internal/domain/value_objects"
+)
+
+var (
+	ErrPasswordResetTokenNotFound = errors.New("password reset token not found")
+	ErrPasswordResetTokenUsed     = errors.New("password reset token has already been used")
+)
+
+type PasswordResetTokenRepository interface {
+	Create(ctx context.Context, token *entities.PasswordResetToken) error
+	GetByTokenHash(ctx context.Context, tokenHash string) (*entities.PasswordResetToken, error)
+	// MarkUsed consumes the token, returning ErrPasswordResetTokenUsed if another request already did
+	MarkUsed(ctx context.Context, token *entities.PasswordResetToken) error
+	// InvalidateByUserID consumes every outstanding token of the user
+	InvalidateByUserID(ctx context.Context, userID *value_objects.UserID) error
+}
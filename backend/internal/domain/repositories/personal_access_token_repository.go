@@ -0,0 +1,27 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/atdevten/peace/internal/domain/entities"
+	"github.com/atdevten/peace/internal/domain/value_objects"
+)
+
+var (
+	ErrPersonalAccessTokenNotFound = errors.New("personal access token not found")
+)
+
+type PersonalAccessTokenRepository interface {
+	Create(ctx context.Context, token *entities.PersonalAccessToken) error
+	GetByID(ctx context.Context, id *value_objects.TokenID) (*entities.PersonalAccessToken, error)
+	GetByHash(ctx context.Context, tokenHash string) (*entities.PersonalAccessToken, error)
+	// ListByUserID returns all tokens of the user, expired ones included, newest first
+	ListByUserID(ctx context.Context, userID *value_objects.UserID) ([]*entities.PersonalAccessToken, error)
+	CountByUserID(ctx context.Context, userID *value_objects.UserID) (int64, error)
+	UpdateLastUsedAt(ctx context.Context, id *value_objects.TokenID, lastUsedAt time.Time) error
+	Delete(ctx context.Context, id *value_objects.TokenID) error
+	// DeleteByUserID removes every token of the user
+	DeleteByUserID(ctx context.Context, userID *value_objects.UserID) error
+}
@@ -0,0 +1,16 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/atdevten/peace/internal/domain/entities"
+	"github.com/atdevten/peace/internal/domain/value_objects"
+)
+
+// QuoteFeedbackRepository keeps the latest like or skip of each user for each quote
+type QuoteFeedbackRepository interface {
+	// Save records the feedback, replacing an earlier signal of the user for the same quote
+	Save(ctx context.Context, feedback *entities.QuoteFeedback) error
+	// ListByUser returns the feedback of the user, most recent first
+	ListByUser(ctx context.Context, userID *value_objects.UserID) ([]*entities.QuoteFeedback, error)
+}
@@ -2,15 +2,35 @@ package repositories
 
 import (
 	"context"
+	"time"
 
 	"github.com/atdevten/peace/internal/domain/entities"
 	"github.com/atdevten/peace/internal/domain/value_objects"
 )
 
+// QuoteShuffleModulus bounds the keys of the random quote order
+const QuoteShuffleModulus = 1 << 32
+
+// QuoteCursor is the position of the last quote of a page in the listing order
+type QuoteCursor struct {
+	CreatedAt time.Time // created_at order
+	Author    string    // author order
+	ID        int       // tie-breaker; the random order derives its key from it
+}
+
 type QuoteFilter struct {
-	ID      *value_objects.QuoteID
-	Author  *string
-	Content *string
+	ID         *value_objects.QuoteID
+	ExcludeIDs []*value_objects.QuoteID
+	Author     *string
+	Content    *string
+	TagNames   []string // quotes carrying any listed tag
+
+	Sort       value_objects.QuoteSort // empty orders by created_at
+	Descending bool
+	Seed       int64        // shuffles the random order
+	Cursor     *QuoteCursor // only quotes after this position in the listing order
+	Offset     int
+	Limit      int // 0 returns every match
 }
 
 func NewQuoteFilter(id *value_objects.QuoteID, author *string, content *string) *QuoteFilter {
@@ -21,11 +41,65 @@ func NewQuoteFilter(id *value_objects.QuoteID, author *string, content *string)
 	}
 }
 
+// QuoteShuffleMultiplier derives from the seed the odd multiplier of the random order. Quote
+// IDs times an odd number modulo 2^32 never collide, so each seed yields a distinct, complete
+// permutation that pages like any other order.
+func QuoteShuffleMultiplier(seed int64) int64 {
+	const golden = 2654435761 // Knuth's multiplicative hash constant
+	return int64(((uint64(seed)&0x7fffffff)*2 + 1) * golden % QuoteShuffleModulus)
+}
+
+// QuoteShuffleKey is the position of a quote in the random order of the seed
+func QuoteShuffleKey(seed int64, id int) int64 {
+	return int64(id) * QuoteShuffleMultiplier(seed) % QuoteShuffleModulus
+}
+
+// Markers around the matched terms of search snippets
+const (
+	QuoteHighlightStart = "\x02"
+	QuoteHighlightStop  = "\x03"
+)
+
+// QuoteSearch is a ranked full-text search over quote content and authors, with fuzzy
+// matching of author names
+type QuoteSearch struct {
+	Text       string
+	Author     *string // exact author, to drill into a facet
+	Tag        *string // tag name, to drill into a facet
+	Offset     int
+	Limit      int
+	FacetLimit int // most frequent values returned per facet
+}
+
+// QuoteSearchHit is a matching quote with its relevance and the matched passage of its content
+type QuoteSearchHit struct {
+	Quote   *entities.Quote
+	Rank    float64
+	Snippet string // matched terms wrapped in QuoteHighlightStart and QuoteHighlightStop
+}
+
+// QuoteFacet counts the matching quotes sharing a value
+type QuoteFacet struct {
+	Value string
+	Count int64
+}
+
+type QuoteSearchResult struct {
+	Hits    []QuoteSearchHit
+	Total   int64
+	Authors []QuoteFacet
+	Tags    []QuoteFacet
+}
+
 type QuoteRepository interface {
 	Create(ctx context.Context, quote *entities.Quote) error
 	GetByID(ctx context.Context, id *value_objects.QuoteID) (*entities.Quote, error)
+	// GetByFilter returns a page of matching quotes in the order of the filter
 	GetByFilter(ctx context.Context, filter *QuoteFilter) ([]*entities.Quote, error)
-	GetAll(ctx context.Context) ([]*entities.Quote, error)
+	// Count returns the number of matching quotes, ignoring the order and page of the filter
+	Count(ctx context.Context, filter *QuoteFilter) (int64, error)
+	// Search returns a page of quotes matching the search, best first, with facets over all matches
+	Search(ctx context.Context, search *QuoteSearch) (*QuoteSearchResult, error)
 	GetRandom(ctx context.Context) (*entities.Quote, error)
 	Update(ctx context.Context, quote *entities.Quote) error
 	Delete(ctx context.Context, id *value_objects.QuoteID) error
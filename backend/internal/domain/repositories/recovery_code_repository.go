@@ -0,0 +1,22 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"github.com/atdevten/peace/internal/domain/entities"
+	"github.com/atdevten/peace/internal/domain/value_objects"
+)
+
+var (
+	ErrRecoveryCodeNotFound = errors.New("recovery code not found")
+)
+
+type RecoveryCodeRepository interface {
+	// ReplaceByUserID deletes the user's codes and stores the new set
+	ReplaceByUserID(ctx context.Context, userID *value_objects.UserID, codes []*entities.RecoveryCode) error
+	// Use marks an unused code as used; ErrRecoveryCodeNotFound if no unused code matches
+	Use(ctx context.Context, userID *value_objects.UserID, codeHash string) error
+	CountUnused(ctx context.Context, userID *value_objects.UserID) (int, error)
+	DeleteByUserID(ctx context.Context, userID *value_objects.UserID) error
+}
@@ -0,0 +1,25 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"github.com/atdevten/peace/internal/domain/entities"
+	"github.com/atdevten/peace/internal/domain/value_objects"
+)
+
+var (
+	ErrRefreshTokenNotFound = errors.New("refresh token not found")
+	ErrRefreshTokenUsed     = errors.New("refresh token has already been used")
+)
+
+type RefreshTokenRepository interface {
+	Create(ctx context.Context, token *entities.RefreshToken) error
+	GetByID(ctx context.Context, id *value_objects.TokenID) (*entities.RefreshToken, error)
+	// MarkUsed rotates the token, returning ErrRefreshTokenUsed if it was already rotated or revoked
+	MarkUsed(ctx context.Context, token *entities.RefreshToken) error
+	// RevokeFamily revokes every token descending from the same login
+	RevokeFamily(ctx context.Context, familyID *value_objects.TokenID) error
+	// RevokeByUserID revokes every token of the user
+	RevokeByUserID(ctx context.Context, userID *value_objects.UserID) error
+}
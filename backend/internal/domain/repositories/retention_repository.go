@@ -0,0 +1,66 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/atdevten/peace/internal/domain/entities"
+	"github.com/atdevten/peace/internal/domain/value_objects"
+)
+
+// DeletedUserCursor is the (deleted_at, id) position of the last account of a page
+type DeletedUserCursor struct {
+	DeletedAt time.Time
+	ID        string
+}
+
+// DeletedUserFilter pages through deleted accounts the retention job has not processed yet, oldest deletion first
+type DeletedUserFilter struct {
+	DeletedBefore time.Time          // only accounts deleted before this time
+	Cursor        *DeletedUserCursor // only accounts after this position
+	Limit         int
+}
+
+// UserDataCounts sizes what the retention job removes from an account
+type UserDataCounts struct {
+	Records  int64
+	Sessions int64
+}
+
+// RetentionLogEntry records what the retention job did to an account; it holds no personal data
+type RetentionLogEntry struct {
+	UserID        *value_objects.UserID
+	Action        value_objects.RetentionAction
+	UserDeletedAt time.Time
+	Records       int64
+	Sessions      int64
+	Exports       int64
+	ProcessedAt   time.Time
+}
+
+// AccountRestoration records a deleted account restored by its owner during the grace period
+type AccountRestoration struct {
+	UserID        *value_objects.UserID
+	UserDeletedAt time.Time
+	RestoredAt    time.Time
+	IPAddress     string
+	UserAgent     string
+}
+
+// RetentionRepository removes the data of deleted accounts. The audit log is exempt: audit_events
+// is append-only, so events about a purged or anonymized account keep the actor ID, IP address,
+// user agent and any email they were recorded with.
+type RetentionRepository interface {
+	ListDeletedUsers(ctx context.Context, filter *DeletedUserFilter) ([]*entities.User, error)
+	CountUserData(ctx context.Context, userID *value_objects.UserID) (*UserDataCounts, error)
+	// PurgeUser deletes a deleted account with everything it owns and logs entry, atomically.
+	// It returns ErrUserNotFound if the account no longer exists or is not deleted anymore.
+	PurgeUser(ctx context.Context, entry *RetentionLogEntry) error
+	// AnonymizeUser replaces a deleted account's personal data with placeholders, deletes its
+	// credentials, sessions and exports, strips the notes from its records and logs entry, atomically.
+	// It returns ErrUserNotFound if the account no longer exists, is not deleted or was already anonymized.
+	AnonymizeUser(ctx context.Context, entry *RetentionLogEntry) error
+	// RestoreUser clears the deletion of an account, reactivates it and records restoration, atomically.
+	// It returns ErrUserNotFound if the account is not deleted or was already purged or anonymized.
+	RestoreUser(ctx context.Context, restoration *AccountRestoration) error
+}
@@ -0,0 +1,38 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/atdevten/peace/internal/domain/entities"
+	"github.com/atdevten/peace/internal/domain/value_objects"
+)
+
+var (
+	ErrSessionNotFound = errors.New("session not found")
+)
+
+// SessionCursor is the (created_at, id) position of the last session of a page
+type SessionCursor struct {
+	CreatedAt time.Time
+	ID        string
+}
+
+// SessionHistoryFilter pages through every session of a user, revoked ones included, oldest first
+type SessionHistoryFilter struct {
+	UserID *value_objects.UserID
+	Cursor *SessionCursor // only sessions after this position
+	Limit  int
+}
+
+type SessionRepository interface {
+	Create(ctx context.Context, session *entities.Session) error
+	GetByID(ctx context.Context, id *value_objects.TokenID) (*entities.Session, error)
+	// ListActiveByUserID returns the user's sessions that are not revoked, most recently used first
+	ListActiveByUserID(ctx context.Context, userID *value_objects.UserID) ([]*entities.Session, error)
+	ListHistory(ctx context.Context, filter *SessionHistoryFilter) ([]*entities.Session, error)
+	UpdateLastUsedAt(ctx context.Context, id *value_objects.TokenID, lastUsedAt time.Time) error
+	Revoke(ctx context.Context, id *value_objects.TokenID) error
+	RevokeByUserID(ctx context.Context, userID *value_objects.UserID) error
+}
@@ -0,0 +1,25 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"github.com/atdevten/peace/internal/domain/entities"
+	"github.com/atdevten/peace/internal/domain/value_objects"
+)
+
+var (
+	ErrTOTPFactorNotFound = errors.New("totp factor not found")
+	ErrTOTPCodeReplayed   = errors.New("totp code has already been used")
+)
+
+type TOTPFactorRepository interface {
+	// Save stores the factor, replacing any previous enrollment of the user
+	Save(ctx context.Context, factor *entities.TOTPFactor) error
+	GetByUserID(ctx context.Context, userID *value_objects.UserID) (*entities.TOTPFactor, error)
+	// Confirm activates a pending factor; ErrTOTPFactorNotFound if there is none
+	Confirm(ctx context.Context, factor *entities.TOTPFactor) error
+	// UseStep advances the last used step; ErrTOTPCodeReplayed if the step is not newer
+	UseStep(ctx context.Context, factor *entities.TOTPFactor) error
+	Delete(ctx context.Context, userID *value_objects.UserID) error
+}
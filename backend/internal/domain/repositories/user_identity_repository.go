@@ -0,0 +1,23 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"github.com/atdevten/peace/internal/domain/entities"
+	"github.com/atdevten/peace/internal/domain/value_objects"
+)
+
+var (
+	ErrUserIdentityNotFound = errors.New("user identity not found")
+	// ErrUserIdentityExists is returned when the provider account, or the user's slot for that provider, is taken
+	ErrUserIdentityExists = errors.New("user identity already exists")
+)
+
+type UserIdentityRepository interface {
+	Create(ctx context.Context, identity *entities.UserIdentity) error
+	GetByProviderSubject(ctx context.Context, provider string, subject string) (*entities.UserIdentity, error)
+	// ListByUserID returns the user's linked identities ordered by provider
+	ListByUserID(ctx context.Context, userID *value_objects.UserID) ([]*entities.UserIdentity, error)
+	Delete(ctx context.Context, userID *value_objects.UserID, provider string) error
+}
@@ -2,11 +2,18 @@ package repositories
 
 import (
 	"context"
+	"errors"
 
 	"github.com/atdevten/peace/internal/domain/entities"
 	"github.com/atdevten/peace/internal/domain/value_objects"
 )
 
+// ErrUserNotFound is returned when no user matches a lookup
+var ErrUserNotFound = errors.New("user not found")
+
+// ErrUserVersionConflict is returned by Update when the user changed since it was read
+var ErrUserVersionConflict = errors.New("user was modified concurrently, please retry")
+
 type UserFilter struct {
 	ID       *value_objects.UserID
 	Email    *value_objects.Email
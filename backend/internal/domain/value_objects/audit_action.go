@@ -0,0 +1,67 @@
+package value_objects
+
+import (
+	"fmt"
+	"strings"
+)
+
+// AuditAction names a security-relevant event recorded in the audit log, as <subject>.<event>
+type AuditAction string
+
+const (
+	AuditActionUserRegistered           AuditAction = "user.registered"
+	AuditActionLogin                    AuditAction = "auth.login"
+	AuditActionLoginFailed              AuditAction = "auth.login_failed"
+	AuditActionLogout                   AuditAction = "auth.logout"
+	AuditActionLogoutAll                AuditAction = "auth.logout_all"
+	AuditActionPasswordResetRequested   AuditAction = "auth.password_reset_requested"
+	AuditActionPasswordReset            AuditAction = "auth.password_reset"
+	AuditActionEmailVerified            AuditAction = "auth.email_verified"
+	AuditActionIdentityLinked           AuditAction = "auth.identity_linked"
+	AuditActionIdentityUnlinked         AuditAction = "auth.identity_unlinked"
+	AuditActionMFAEnabled               AuditAction = "auth.mfa_enabled"
+	AuditActionMFADisabled              AuditAction = "auth.mfa_disabled"
+	AuditActionRecoveryCodesRegenerated AuditAction = "auth.recovery_codes_regenerated"
+	AuditActionSessionRevoked           AuditAction = "auth.session_revoked"
+	AuditActionTokenCreated             AuditAction = "auth.token_created"
+	AuditActionTokenDeleted             AuditAction = "auth.token_deleted"
+	AuditActionProfileUpdated           AuditAction = "user.profile_updated"
+	AuditActionPasswordChanged          AuditAction = "user.password_changed"
+	AuditActionRoleChanged              AuditAction = "user.role_changed"
+	AuditActionUserDeactivated          AuditAction = "user.deactivated"
+	AuditActionUserDeleted              AuditAction = "user.deleted"
+	AuditActionUserRestored             AuditAction = "user.restored"
+	AuditActionDataExportRequested      AuditAction = "user.data_export_requested"
+	AuditActionDataExportDownloaded     AuditAction = "user.data_export_downloaded"
+	AuditActionQuoteCreated             AuditAction = "quote.created"
+	AuditActionQuoteUpdated             AuditAction = "quote.updated"
+	AuditActionQuoteDeleted             AuditAction = "quote.deleted"
+	AuditActionQuoteTagAdded            AuditAction = "quote.tag_added"
+	AuditActionQuoteTagRemoved          AuditAction = "quote.tag_removed"
+	AuditActionTagCreated               AuditAction = "tag.created"
+	AuditActionTagUpdated               AuditAction = "tag.updated"
+	AuditActionTagDeleted               AuditAction = "tag.deleted"
+)
+
+func (a AuditAction) String() string {
+	return string(a)
+}
+
+func NewAuditAction(action string) (*AuditAction, error) {
+	action = strings.TrimSpace(action)
+
+	switch AuditAction(action) {
+	case AuditActionUserRegistered, AuditActionLogin, AuditActionLoginFailed, AuditActionLogout, AuditActionLogoutAll,
+		AuditActionPasswordResetRequested, AuditActionPasswordReset, AuditActionEmailVerified,
+		AuditActionIdentityLinked, AuditActionIdentityUnlinked, AuditActionMFAEnabled, AuditActionMFADisabled, AuditActionRecoveryCodesRegenerated,
+		AuditActionSessionRevoked, AuditActionTokenCreated, AuditActionTokenDeleted,
+		AuditActionProfileUpdated, AuditActionPasswordChanged, AuditActionRoleChanged, AuditActionUserDeactivated,
+		AuditActionUserDeleted, AuditActionUserRestored, AuditActionDataExportRequested, AuditActionDataExportDownloaded,
+		AuditActionQuoteCreated, AuditActionQuoteUpdated, AuditActionQuoteDeleted, AuditActionQuoteTagAdded, AuditActionQuoteTagRemoved,
+		AuditActionTagCreated, AuditActionTagUpdated, AuditActionTagDeleted:
+		actionVO := AuditAction(action)
+		return &actionVO, nil
+	default:
+		return nil, fmt.Errorf("invalid audit action: %s", action)
+	}
+}
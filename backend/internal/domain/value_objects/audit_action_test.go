@@ -0,0 +1,47 @@
+package value_objects
+
+import (
+	"testing"
+)
+
+func TestNewAuditAction(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       string
+		wantValue   AuditAction
+		wantErr     bool
+		expectedErr string
+	}{
+		{name: "login", input: "auth.login", wantValue: AuditActionLogin},
+		{name: "mfa disabled", input: "auth.mfa_disabled", wantValue: AuditActionMFADisabled},
+		{name: "quote deleted with spaces", input: " quote.deleted ", wantValue: AuditActionQuoteDeleted},
+		{name: "empty action", input: "", wantErr: true, expectedErr: "invalid audit action: "},
+		{name: "unknown action", input: "auth.hack", wantErr: true, expectedErr: "invalid audit action: auth.hack"},
+		{name: "case sensitive", input: "AUTH.LOGIN", wantErr: true, expectedErr: "invalid audit action: AUTH.LOGIN"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewAuditAction(tt.input)
+
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("NewAuditAction() expected error but got none")
+					return
+				}
+				if err.Error() != tt.expectedErr {
+					t.Errorf("NewAuditAction() error = %v, want %v", err.Error(), tt.expectedErr)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Errorf("NewAuditAction() unexpected error = %v", err)
+				return
+			}
+			if *got != tt.wantValue {
+				t.Errorf("NewAuditAction() = %v, want %v", *got, tt.wantValue)
+			}
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package value_objects
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DataExportStatus tracks a data export job from request to downloadable archive
+type DataExportStatus string
+
+const (
+	DataExportStatusPending    DataExportStatus = "pending"
+	DataExportStatusProcessing DataExportStatus = "processing"
+	DataExportStatusCompleted  DataExportStatus = "completed"
+	DataExportStatusFailed     DataExportStatus = "failed"
+)
+
+func (s DataExportStatus) String() string {
+	return string(s)
+}
+
+func NewDataExportStatus(status string) (*DataExportStatus, error) {
+	status = strings.TrimSpace(status)
+
+	switch DataExportStatus(status) {
+	case DataExportStatusPending, DataExportStatusProcessing, DataExportStatusCompleted, DataExportStatusFailed:
+		statusVO := DataExportStatus(status)
+		return &statusVO, nil
+	default:
+		return nil, fmt.Errorf("invalid data export status: %s", status)
+	}
+}
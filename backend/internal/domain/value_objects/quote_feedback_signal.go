@@ -0,0 +1,30 @@
+package value_objects
+
+import (
+	"fmt"
+	"strings"
+)
+
+// QuoteFeedbackSignal is how a user reacted to a recommended quote
+type QuoteFeedbackSignal string
+
+const (
+	QuoteFeedbackLike QuoteFeedbackSignal = "like"
+	QuoteFeedbackSkip QuoteFeedbackSignal = "skip"
+)
+
+func (s QuoteFeedbackSignal) String() string {
+	return string(s)
+}
+
+func NewQuoteFeedbackSignal(signal string) (*QuoteFeedbackSignal, error) {
+	signal = strings.TrimSpace(signal)
+
+	switch QuoteFeedbackSignal(signal) {
+	case QuoteFeedbackLike, QuoteFeedbackSkip:
+		signalVO := QuoteFeedbackSignal(signal)
+		return &signalVO, nil
+	default:
+		return nil, fmt.Errorf("invalid quote feedback signal: %s", signal)
+	}
+}
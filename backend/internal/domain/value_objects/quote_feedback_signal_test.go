@@ -0,0 +1,46 @@
+package value_objects
+
+import (
+	"testing"
+)
+
+func TestNewQuoteFeedbackSignal(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       string
+		wantValue   QuoteFeedbackSignal
+		wantErr     bool
+		expectedErr string
+	}{
+		{name: "like", input: "like", wantValue: QuoteFeedbackLike},
+		{name: "skip with spaces", input: " skip ", wantValue: QuoteFeedbackSkip},
+		{name: "empty signal", input: "", wantErr: true, expectedErr: "invalid quote feedback signal: "},
+		{name: "unknown signal", input: "dislike", wantErr: true, expectedErr: "invalid quote feedback signal: dislike"},
+		{name: "case sensitive", input: "LIKE", wantErr: true, expectedErr: "invalid quote feedback signal: LIKE"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewQuoteFeedbackSignal(tt.input)
+
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("NewQuoteFeedbackSignal() expected error but got none")
+					return
+				}
+				if err.Error() != tt.expectedErr {
+					t.Errorf("NewQuoteFeedbackSignal() error = %v, want %v", err.Error(), tt.expectedErr)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Errorf("NewQuoteFeedbackSignal() unexpected error = %v", err)
+				return
+			}
+			if *got != tt.wantValue {
+				t.Errorf("NewQuoteFeedbackSignal() = %v, want %v", *got, tt.wantValue)
+			}
+		})
+	}
+}
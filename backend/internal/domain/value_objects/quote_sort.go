@@ -0,0 +1,31 @@
+package value_objects
+
+import (
+	"fmt"
+	"strings"
+)
+
+// QuoteSort is the order of a quote listing
+type QuoteSort string
+
+const (
+	QuoteSortCreatedAt QuoteSort = "created_at"
+	QuoteSortAuthor    QuoteSort = "author"
+	QuoteSortRandom    QuoteSort = "random" // shuffled, stable for a given seed
+)
+
+func (s QuoteSort) String() string {
+	return string(s)
+}
+
+func NewQuoteSort(sort string) (*QuoteSort, error) {
+	sort = strings.TrimSpace(sort)
+
+	switch QuoteSort(sort) {
+	case QuoteSortCreatedAt, QuoteSortAuthor, QuoteSortRandom:
+		sortVO := QuoteSort(sort)
+		return &sortVO, nil
+	default:
+		return nil, fmt.Errorf("invalid quote sort: %s", sort)
+	}
+}
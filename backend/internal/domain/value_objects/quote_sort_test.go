@@ -0,0 +1,47 @@
+package value_objects
+
+import (
+	"testing"
+)
+
+func TestNewQuoteSort(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       string
+		wantValue   QuoteSort
+		wantErr     bool
+		expectedErr string
+	}{
+		{name: "created at", input: "created_at", wantValue: QuoteSortCreatedAt},
+		{name: "author", input: "author", wantValue: QuoteSortAuthor},
+		{name: "random with spaces", input: " random ", wantValue: QuoteSortRandom},
+		{name: "empty sort", input: "", wantErr: true, expectedErr: "invalid quote sort: "},
+		{name: "unknown sort", input: "content", wantErr: true, expectedErr: "invalid quote sort: content"},
+		{name: "case sensitive", input: "AUTHOR", wantErr: true, expectedErr: "invalid quote sort: AUTHOR"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewQuoteSort(tt.input)
+
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("NewQuoteSort() expected error but got none")
+					return
+				}
+				if err.Error() != tt.expectedErr {
+					t.Errorf("NewQuoteSort() error = %v, want %v", err.Error(), tt.expectedErr)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Errorf("NewQuoteSort() unexpected error = %v", err)
+				return
+			}
+			if *got != tt.wantValue {
+				t.Errorf("NewQuoteSort() = %v, want %v", *got, tt.wantValue)
+			}
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package value_objects
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/hex"
+	"errors"
+	"strings"
+)
+
+const (
+	// recoveryCodeBytes gives 40 bits of entropy per code
+	recoveryCodeBytes = 5
+	// recoveryCodeLength is the number of base32 characters encoding those bytes
+	recoveryCodeLength = 8
+)
+
+var recoveryCodeEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// RecoveryCode is a one-time backup code for two-factor login, shown as xxxx-xxxx.
+// Only its hash is ever persisted.
+type RecoveryCode struct {
+	value string // normalized: lowercase, no separators
+}
+
+func NewRecoveryCode() (*RecoveryCode, error) {
+	buf := make([]byte, recoveryCodeBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return nil, err
+	}
+	return &RecoveryCode{value: strings.ToLower(recoveryCodeEncoding.EncodeToString(buf))}, nil
+}
+
+func NewRecoveryCodeFromString(code string) (*RecoveryCode, error) {
+	code = strings.ToLower(strings.TrimSpace(code))
+	code = strings.ReplaceAll(code, "-", "")
+	code = strings.ReplaceAll(code, " ", "")
+
+	if code == "" {
+		return nil, errors.New("recovery code cannot be empty")
+	}
+
+	if len(code) != recoveryCodeLength {
+		return nil, errors.New("invalid recovery code")
+	}
+
+	return &RecoveryCode{value: code}, nil
+}
+
+// String returns the code grouped for display
+func (c *RecoveryCode) String() string {
+	half := len(c.value) / 2
+	return c.value[:half] + "-" + c.value[half:]
+}
+
+// Hash returns the hex encoded SHA-256 digest used for storage and lookup
+func (c *RecoveryCode) Hash() string {
+	sum := sha256.Sum256([]byte(c.value))
+	return hex.EncodeToString(sum[:])
+}
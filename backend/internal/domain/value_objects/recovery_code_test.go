@@ -0,0 +1,102 @@
+package value_objects
+
+import (
+	"testing"
+)
+
+func TestNewRecoveryCode(t *testing.T) {
+	first, err := NewRecoveryCode()
+	if err != nil {
+		t.Fatalf("NewRecoveryCode() unexpected error = %v", err)
+	}
+	second, err := NewRecoveryCode()
+	if err != nil {
+		t.Fatalf("NewRecoveryCode() unexpected error = %v", err)
+	}
+
+	if len(first.String()) != 9 || first.String()[4] != '-' {
+		t.Errorf("NewRecoveryCode() = %v, want xxxx-xxxx", first.String())
+	}
+	if first.String() == second.String() {
+		t.Errorf("NewRecoveryCode() returned the same code twice")
+	}
+}
+
+func TestNewRecoveryCodeFromString(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       string
+		wantValue   string
+		wantErr     bool
+		expectedErr string
+	}{
+		{
+			name:        "displayed form",
+			input:       "abcd-efgh",
+			wantValue:   "abcd-efgh",
+			wantErr:     false,
+			expectedErr: "",
+		},
+		{
+			name:        "uppercase without separator",
+			input:       "  ABCDEFGH ",
+			wantValue:   "abcd-efgh",
+			wantErr:     false,
+			expectedErr: "",
+		},
+		{
+			name:        "empty code",
+			input:       "  ",
+			wantValue:   "",
+			wantErr:     true,
+			expectedErr: "recovery code cannot be empty",
+		},
+		{
+			name:        "wrong length",
+			input:       "abcd-efg",
+			wantValue:   "",
+			wantErr:     true,
+			expectedErr: "invalid recovery code",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewRecoveryCodeFromString(tt.input)
+
+			// Check error
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("NewRecoveryCodeFromString() expected error but got none")
+					return
+				}
+				if err.Error() != tt.expectedErr {
+					t.Errorf("NewRecoveryCodeFromString() error = %v, expected %v", err.Error(), tt.expectedErr)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Errorf("NewRecoveryCodeFromString() unexpected error = %v", err)
+				return
+			}
+
+			// Check value
+			if got.String() != tt.wantValue {
+				t.Errorf("NewRecoveryCodeFromString() = %v, want %v", got.String(), tt.wantValue)
+			}
+		})
+	}
+}
+
+func TestRecoveryCode_Hash(t *testing.T) {
+	displayed, _ := NewRecoveryCodeFromString("abcd-efgh")
+	typed, _ := NewRecoveryCodeFromString("ABCDEFGH")
+
+	if displayed.Hash() != typed.Hash() {
+		t.Errorf("Hash() differs for the same code in different forms")
+	}
+	if displayed.Hash() == displayed.String() {
+		t.Errorf("Hash() returned the raw code")
+	}
+}
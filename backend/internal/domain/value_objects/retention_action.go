@@ -0,0 +1,32 @@
+package value_objects
+
+import (
+	"fmt"
+	"strings"
+)
+
+// RetentionAction is what happens to a deleted account once its grace period is over
+type RetentionAction string
+
+const (
+	// RetentionActionPurge deletes the account and everything it owns
+	RetentionActionPurge RetentionAction = "purge"
+	// RetentionActionAnonymize replaces the account's personal data with placeholders and keeps its records without notes
+	RetentionActionAnonymize RetentionAction = "anonymize"
+)
+
+func (a RetentionAction) String() string {
+	return string(a)
+}
+
+func NewRetentionAction(action string) (*RetentionAction, error) {
+	action = strings.ToLower(strings.TrimSpace(action))
+
+	switch RetentionAction(action) {
+	case RetentionActionPurge, RetentionActionAnonymize:
+		actionVO := RetentionAction(action)
+		return &actionVO, nil
+	default:
+		return nil, fmt.Errorf("invalid retention action: %s", action)
+	}
+}
@@ -0,0 +1,31 @@
+package value_objects
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Role grants access to management endpoints; every account starts as RoleUser
+type Role string
+
+const (
+	RoleUser   Role = "user"
+	RoleEditor Role = "editor"
+	RoleAdmin  Role = "admin"
+)
+
+func (r Role) String() string {
+	return string(r)
+}
+
+func NewRole(role string) (*Role, error) {
+	role = strings.TrimSpace(role)
+
+	switch Role(role) {
+	case RoleUser, RoleEditor, RoleAdmin:
+		roleVO := Role(role)
+		return &roleVO, nil
+	default:
+		return nil, fmt.Errorf("invalid role: %s", role)
+	}
+}
@@ -0,0 +1,47 @@
+package value_objects
+
+import (
+	"testing"
+)
+
+func TestNewRole(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       string
+		wantValue   Role
+		wantErr     bool
+		expectedErr string
+	}{
+		{name: "user", input: "user", wantValue: RoleUser},
+		{name: "editor", input: "editor", wantValue: RoleEditor},
+		{name: "admin with spaces", input: " admin ", wantValue: RoleAdmin},
+		{name: "empty role", input: "", wantErr: true, expectedErr: "invalid role: "},
+		{name: "unknown role", input: "root", wantErr: true, expectedErr: "invalid role: root"},
+		{name: "case sensitive", input: "Admin", wantErr: true, expectedErr: "invalid role: Admin"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewRole(tt.input)
+
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("NewRole() expected error but got none")
+					return
+				}
+				if err.Error() != tt.expectedErr {
+					t.Errorf("NewRole() error = %v, want %v", err.Error(), tt.expectedErr)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Errorf("NewRole() unexpected error = %v", err)
+				return
+			}
+			if *got != tt.wantValue {
+				t.Errorf("NewRole() = %v, want %v", *got, tt.wantValue)
+			}
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package value_objects
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"strings"
+)
+
+// secretTokenBytes is the amount of entropy behind every generated token
+const secretTokenBytes = 32
+
+// SecretToken is a random, URL-safe token handed to a user exactly once.
+// Only its hash is ever persisted.
+type SecretToken struct {
+	value string
+}
+
+func NewSecretToken() (*SecretToken, error) {
+	buf := make([]byte, secretTokenBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return nil, err
+	}
+	return &SecretToken{value: base64.RawURLEncoding.EncodeToString(buf)}, nil
+}
+
+func NewSecretTokenFromString(token string) (*SecretToken, error) {
+	token = strings.TrimSpace(token)
+
+	if token == "" {
+		return nil, errors.New("token cannot be empty")
+	}
+
+	if len(token) > 256 {
+		return nil, errors.New("token too long")
+	}
+
+	return &SecretToken{value: token}, nil
+}
+
+func (t *SecretToken) String() string {
+	return t.value
+}
+
+// Hash returns the hex encoded SHA-256 digest used for storage and lookup
+func (t *SecretToken) Hash() string {
+	sum := sha256.Sum256([]byte(t.value))
+	return hex.EncodeToString(sum[:])
+}
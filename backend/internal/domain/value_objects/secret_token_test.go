@@ -0,0 +1,107 @@
+package value_objects
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNewSecretToken(t *testing.T) {
+	first, err := NewSecretToken()
+	if err != nil {
+		t.Fatalf("NewSecretToken() unexpected error = %v", err)
+	}
+	second, err := NewSecretToken()
+	if err != nil {
+		t.Fatalf("NewSecretToken() unexpected error = %v", err)
+	}
+
+	if first.String() == "" {
+		t.Errorf("NewSecretToken() returned empty token")
+	}
+	if first.String() == second.String() {
+		t.Errorf("NewSecretToken() returned the same token twice")
+	}
+	if first.Hash() == first.String() {
+		t.Errorf("Hash() returned the raw token")
+	}
+}
+
+func TestNewSecretTokenFromString(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       string
+		wantValue   string
+		wantErr     bool
+		expectedErr string
+	}{
+		{
+			name:        "valid token",
+			input:       "abc123",
+			wantValue:   "abc123",
+			wantErr:     false,
+			expectedErr: "",
+		},
+		{
+			name:        "token with spaces",
+			input:       "  abc123  ",
+			wantValue:   "abc123",
+			wantErr:     false,
+			expectedErr: "",
+		},
+		{
+			name:        "empty token",
+			input:       "",
+			wantValue:   "",
+			wantErr:     true,
+			expectedErr: "token cannot be empty",
+		},
+		{
+			name:        "token too long",
+			input:       strings.Repeat("a", 257),
+			wantValue:   "",
+			wantErr:     true,
+			expectedErr: "token too long",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewSecretTokenFromString(tt.input)
+
+			// Check error
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("NewSecretTokenFromString() expected error but got none")
+					return
+				}
+				if err.Error() != tt.expectedErr {
+					t.Errorf("NewSecretTokenFromString() error = %v, expected %v", err.Error(), tt.expectedErr)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Errorf("NewSecretTokenFromString() unexpected error = %v", err)
+				return
+			}
+
+			// Check value
+			if got.String() != tt.wantValue {
+				t.Errorf("NewSecretTokenFromString() = %v, want %v", got.String(), tt.wantValue)
+			}
+		})
+	}
+}
+
+func TestSecretToken_Hash(t *testing.T) {
+	token, err := NewSecretTokenFromString("abc")
+	if err != nil {
+		t.Fatalf("NewSecretTokenFromString() unexpected error = %v", err)
+	}
+
+	// SHA-256("abc")
+	want := "ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad"
+	if got := token.Hash(); got != want {
+		t.Errorf("Hash() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,50 @@
+package value_objects
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// DefaultTimezone is used for users who have not chosen a timezone yet
+const DefaultTimezone = "UTC"
+
+type Timezone struct {
+	value    string
+	location *time.Location
+}
+
+func NewTimezone(name string) (*Timezone, error) {
+	name = strings.TrimSpace(name)
+
+	if name == "" {
+		return nil, errors.New("timezone cannot be empty")
+	}
+
+	// Reject "Local" so the result never depends on the server's own zone
+	if name == "Local" {
+		return nil, fmt.Errorf("invalid timezone: %s", name)
+	}
+
+	location, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("invalid timezone: %s", name)
+	}
+
+	return &Timezone{value: name, location: location}, nil
+}
+
+// NewDefaultTimezone returns the UTC timezone
+func NewDefaultTimezone() *Timezone {
+	return &Timezone{value: DefaultTimezone, location: time.UTC}
+}
+
+func (t *Timezone) String() string {
+	return t.value
+}
+
+// Location returns the time.Location used for day bucketing
+func (t *Timezone) Location() *time.Location {
+	return t.location
+}
@@ -0,0 +1,114 @@
+package value_objects
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewTimezone(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       string
+		wantValue   string
+		wantErr     bool
+		expectedErr string
+	}{
+		{
+			name:        "valid UTC",
+			input:       "UTC",
+			wantValue:   "UTC",
+			wantErr:     false,
+			expectedErr: "",
+		},
+		{
+			name:        "valid IANA zone",
+			input:       "Asia/Ho_Chi_Minh",
+			wantValue:   "Asia/Ho_Chi_Minh",
+			wantErr:     false,
+			expectedErr: "",
+		},
+		{
+			name:        "valid zone with spaces",
+			input:       "  America/New_York  ",
+			wantValue:   "America/New_York",
+			wantErr:     false,
+			expectedErr: "",
+		},
+		{
+			name:        "empty timezone",
+			input:       "",
+			wantValue:   "",
+			wantErr:     true,
+			expectedErr: "timezone cannot be empty",
+		},
+		{
+			name:        "unknown zone",
+			input:       "Mars/Olympus_Mons",
+			wantValue:   "",
+			wantErr:     true,
+			expectedErr: "invalid timezone: Mars/Olympus_Mons",
+		},
+		{
+			name:        "server local zone",
+			input:       "Local",
+			wantValue:   "",
+			wantErr:     true,
+			expectedErr: "invalid timezone: Local",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewTimezone(tt.input)
+
+			// Check error
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("NewTimezone() expected error but got none")
+					return
+				}
+				if err.Error() != tt.expectedErr {
+					t.Errorf("NewTimezone() error = %v, expected %v", err.Error(), tt.expectedErr)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Errorf("NewTimezone() unexpected error = %v", err)
+				return
+			}
+
+			// Check value
+			if got.String() != tt.wantValue {
+				t.Errorf("NewTimezone() = %v, want %v", got.String(), tt.wantValue)
+			}
+			if got.Location() == nil {
+				t.Errorf("NewTimezone() location is nil")
+			}
+		})
+	}
+}
+
+func TestTimezone_Location(t *testing.T) {
+	tz, err := NewTimezone("Asia/Tokyo")
+	if err != nil {
+		t.Fatalf("NewTimezone() unexpected error = %v", err)
+	}
+
+	// 2023-12-01 20:00 UTC is already 2023-12-02 in Tokyo
+	instant := time.Date(2023, 12, 1, 20, 0, 0, 0, time.UTC)
+	if got := instant.In(tz.Location()).Format("2006-01-02"); got != "2023-12-02" {
+		t.Errorf("Location() bucketed day = %v, want 2023-12-02", got)
+	}
+}
+
+func TestNewDefaultTimezone(t *testing.T) {
+	tz := NewDefaultTimezone()
+
+	if tz.String() != DefaultTimezone {
+		t.Errorf("NewDefaultTimezone() = %v, want %v", tz.String(), DefaultTimezone)
+	}
+	if tz.Location() != time.UTC {
+		t.Errorf("NewDefaultTimezone() location = %v, want UTC", tz.Location())
+	}
+}
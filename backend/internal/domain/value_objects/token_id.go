@@ -0,0 +1,27 @@
+package value_objects
+
+import "github.com/google/uuid"
+
+// TokenID identifies a stored auth token row (reset, verification, ...)
+type TokenID struct {
+	value string
+}
+
+func NewTokenID() *TokenID {
+	return &TokenID{value: uuid.New().String()}
+}
+
+func NewTokenIDFromString(id string) (*TokenID, error) {
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, err
+	}
+	return &TokenID{value: id}, nil
+}
+
+func (t *TokenID) String() string {
+	return t.value
+}
+
+func (t *TokenID) IsZero() bool {
+	return t.value == ""
+}
@@ -0,0 +1,31 @@
+package value_objects
+
+import (
+	"fmt"
+	"strings"
+)
+
+// TokenScope limits what a personal access token may do; tokens never reach account management
+type TokenScope string
+
+const (
+	ScopeRecordsRead  TokenScope = "records:read"
+	ScopeRecordsWrite TokenScope = "records:write"
+	ScopeQuotesWrite  TokenScope = "quotes:write"
+)
+
+func (s TokenScope) String() string {
+	return string(s)
+}
+
+func NewTokenScope(scope string) (*TokenScope, error) {
+	scope = strings.TrimSpace(scope)
+
+	switch TokenScope(scope) {
+	case ScopeRecordsRead, ScopeRecordsWrite, ScopeQuotesWrite:
+		scopeVO := TokenScope(scope)
+		return &scopeVO, nil
+	default:
+		return nil, fmt.Errorf("invalid token scope: %s", scope)
+	}
+}
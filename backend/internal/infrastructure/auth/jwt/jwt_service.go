@@ -10,40 +10,78 @@ import (
 	"github.com/golang-jwt/jwt/v5"
 )
 
+const (
+	// mfaExpiry bounds the time between the password step and the second factor of a login
+	mfaExpiry = 5 * time.Minute
+	// linkExpiry bounds the time between an external login and the password confirmation linking it
+	linkExpiry = 5 * time.Minute
+	// restoreExpiry bounds the time between the login of a deleted account and its restore
+	restoreExpiry = 15 * time.Minute
+)
+
+// Options configures the token service
+type Options struct {
+	Keys          *KeySet
+	Issuer        string // iss of issued tokens, required on validation
+	Audience      string // aud of issued tokens, required on validation
+	AccessExpiry  time.Duration
+	RefreshExpiry time.Duration
+}
+
 type jwtService struct {
-	secretKey     []byte
+	keys          *KeySet
+	issuer        string
+	audience      string
 	accessExpiry  time.Duration
 	refreshExpiry time.Duration
+	parser        *jwt.Parser
 }
 
-func NewService(secretKey string, accessExpiry time.Duration, refreshExpiry time.Duration) appjwt.Service {
+func NewService(options Options) appjwt.Service {
 	return &jwtService{
-		secretKey:     []byte(secretKey),
-		accessExpiry:  accessExpiry,
-		refreshExpiry: refreshExpiry,
+		keys:          options.Keys,
+		issuer:        options.Issuer,
+		audience:      options.Audience,
+		accessExpiry:  options.AccessExpiry,
+		refreshExpiry: options.RefreshExpiry,
+		parser: jwt.NewParser(
+			jwt.WithValidMethods(options.Keys.algorithms()),
+			jwt.WithIssuer(options.Issuer),
+			jwt.WithAudience(options.Audience),
+			jwt.WithExpirationRequired(),
+		),
 	}
 }
 
 type jwtClaims struct {
-	UserID string `json:"user_id"`
-	Email  string `json:"email"`
-	Type   string `json:"type"`
+	UserID     string `json:"user_id"`
+	Email      string `json:"email"`
+	Type       string `json:"type"`
+	SessionID  string `json:"sid,omitempty"`
+	Role       string `json:"role,omitempty"`
+	Provider   string `json:"provider,omitempty"`
+	ExternalID string `json:"ext_id,omitempty"`
 	jwt.RegisteredClaims
 }
 
-func (s *jwtService) generateToken(userID value_objects.UserID, email value_objects.Email, tokenType string, expiry time.Duration) (string, error) {
-	claims := &jwtClaims{
-		UserID: userID.String(),
-		Email:  email.String(),
-		Type:   tokenType,
-		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(expiry)),
-			IssuedAt:  jwt.NewNumericDate(time.Now()),
-		},
+// generateToken fills in the registered claims and signs the token
+func (s *jwtService) generateToken(claims *jwtClaims, expiry time.Duration) (string, error) {
+	claims.Issuer = s.issuer
+	claims.Audience = jwt.ClaimStrings{s.audience}
+	claims.ExpiresAt = jwt.NewNumericDate(time.Now().Add(expiry))
+	claims.IssuedAt = jwt.NewNumericDate(time.Now())
+
+	signing := s.keys.signing
+	if signing == nil {
+		return "", errors.New("no JWT signing key configured")
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	tokenString, err := token.SignedString(s.secretKey)
+	token := jwt.NewWithClaims(signing.method, claims)
+	if signing.id != "" {
+		token.Header["kid"] = signing.id
+	}
+
+	tokenString, err := token.SignedString(signing.sign)
 	if err != nil {
 		return "", err
 	}
@@ -51,18 +89,57 @@ func (s *jwtService) generateToken(userID value_objects.UserID, email value_obje
 	return tokenString, nil
 }
 
-func (s *jwtService) GenerateAccessToken(userID value_objects.UserID, email value_objects.Email) (string, error) {
-	return s.generateToken(userID, email, "access", s.accessExpiry)
+func (s *jwtService) GenerateAccessToken(userID value_objects.UserID, email value_objects.Email, role value_objects.Role, sessionID value_objects.TokenID) (string, error) {
+	return s.generateToken(&jwtClaims{
+		UserID:    userID.String(),
+		Email:     email.String(),
+		Type:      "access",
+		SessionID: sessionID.String(),
+		Role:      role.String(),
+	}, s.accessExpiry)
 }
 
-func (s *jwtService) GenerateRefreshToken(userID value_objects.UserID, email value_objects.Email) (string, error) {
-	return s.generateToken(userID, email, "refresh", s.refreshExpiry)
+func (s *jwtService) GenerateRefreshToken(userID value_objects.UserID, email value_objects.Email, tokenID value_objects.TokenID) (string, error) {
+	return s.generateToken(&jwtClaims{
+		UserID:           userID.String(),
+		Email:            email.String(),
+		Type:             "refresh",
+		RegisteredClaims: jwt.RegisteredClaims{ID: tokenID.String()},
+	}, s.refreshExpiry)
+}
+
+func (s *jwtService) GenerateMFAToken(userID value_objects.UserID, email value_objects.Email, provider string) (string, error) {
+	// The ID lets failed attempts be counted per challenge
+	return s.generateToken(&jwtClaims{
+		UserID:           userID.String(),
+		Email:            email.String(),
+		Type:             "mfa",
+		Provider:         provider,
+		RegisteredClaims: jwt.RegisteredClaims{ID: value_objects.NewTokenID().String()},
+	}, mfaExpiry)
+}
+
+func (s *jwtService) GenerateLinkToken(userID value_objects.UserID, email value_objects.Email, provider string, externalID string) (string, error) {
+	return s.generateToken(&jwtClaims{
+		UserID:     userID.String(),
+		Email:      email.String(),
+		Type:       "link",
+		Provider:   provider,
+		ExternalID: externalID,
+	}, linkExpiry)
+}
+
+func (s *jwtService) GenerateRestoreToken(userID value_objects.UserID, email value_objects.Email, provider string) (string, error) {
+	return s.generateToken(&jwtClaims{
+		UserID:   userID.String(),
+		Email:    email.String(),
+		Type:     "restore",
+		Provider: provider,
+	}, restoreExpiry)
 }
 
 func (s *jwtService) validateAndCheckType(tokenString string, expectedType string) (*appjwt.Claims, error) {
-	token, err := jwt.ParseWithClaims(tokenString, &jwtClaims{}, func(token *jwt.Token) (interface{}, error) {
-		return s.secretKey, nil
-	})
+	token, err := s.parser.ParseWithClaims(tokenString, &jwtClaims{}, s.keys.lookup)
 
 	if err != nil {
 		return nil, err
@@ -72,7 +149,7 @@ func (s *jwtService) validateAndCheckType(tokenString string, expectedType strin
 		if claims.Type != expectedType {
 			return nil, errors.New("invalid token type")
 		}
-		return &appjwt.Claims{UserID: claims.UserID, Email: claims.Email, Type: claims.Type}, nil
+		return &appjwt.Claims{UserID: claims.UserID, Email: claims.Email, Type: claims.Type, TokenID: claims.ID, SessionID: claims.SessionID, Role: claims.Role, Provider: claims.Provider, ExternalID: claims.ExternalID}, nil
 	}
 
 	return nil, errors.New("invalid token")
@@ -85,3 +162,15 @@ func (s *jwtService) ValidateAccessToken(tokenString string) (*appjwt.Claims, er
 func (s *jwtService) ValidateRefreshToken(tokenString string) (*appjwt.Claims, error) {
 	return s.validateAndCheckType(tokenString, "refresh")
 }
+
+func (s *jwtService) ValidateMFAToken(tokenString string) (*appjwt.Claims, error) {
+	return s.validateAndCheckType(tokenString, "mfa")
+}
+
+func (s *jwtService) ValidateLinkToken(tokenString string) (*appjwt.Claims, error) {
+	return s.validateAndCheckType(tokenString, "link")
+}
+
+func (s *jwtService) ValidateRestoreToken(tokenString string) (*appjwt.Claims, error) {
+	return s.validateAndCheckType(tokenString, "restore")
+}
@@ -0,0 +1,208 @@
+package jwt
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/atdevten/peace/internal/domain/value_objects"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeKeyPair stores a PEM private key as <dir>/<kid>.key and its public key as <publicDir>/<kid>.pem
+func writeKeyPair(t *testing.T, dir string, publicDir string, kid string, private interface{}, public interface{}) string {
+	privateDER, err := x509.MarshalPKCS8PrivateKey(private)
+	require.NoError(t, err)
+	publicDER, err := x509.MarshalPKIXPublicKey(public)
+	require.NoError(t, err)
+
+	privatePath := filepath.Join(dir, kid+".key")
+	require.NoError(t, os.WriteFile(privatePath, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privateDER}), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(publicDir, kid+".pem"), pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicDER}), 0o644))
+
+	return privatePath
+}
+
+func newRSAKeyPair(t *testing.T, dir string, publicDir string, kid string) string {
+	private, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	return writeKeyPair(t, dir, publicDir, kid, private, &private.PublicKey)
+}
+
+func newEd25519KeyPair(t *testing.T, dir string, publicDir string, kid string) string {
+	public, private, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	return writeKeyPair(t, dir, publicDir, kid, private, public)
+}
+
+func newTestService(t *testing.T, keys *KeySet) *jwtService {
+	return NewService(Options{
+		Keys:          keys,
+		Issuer:        "peace",
+		Audience:      "peace",
+		AccessExpiry:  time.Hour,
+		RefreshExpiry: 24 * time.Hour,
+	}).(*jwtService)
+}
+
+func issueAccessToken(t *testing.T, service *jwtService) string {
+	email, err := value_objects.NewEmail("test@example.com")
+	require.NoError(t, err)
+
+	token, err := service.GenerateAccessToken(*value_objects.NewUserID(), *email, value_objects.RoleEditor, *value_objects.NewTokenID())
+	require.NoError(t, err)
+	return token
+}
+
+func TestJWTService_HMAC(t *testing.T) {
+	keys, err := LoadKeySet("test-secret", "", "", "")
+	require.NoError(t, err)
+	service := newTestService(t, keys)
+
+	token := issueAccessToken(t, service)
+	claims, err := service.ValidateAccessToken(token)
+	require.NoError(t, err)
+	assert.Equal(t, "test@example.com", claims.Email)
+	assert.Equal(t, "access", claims.Type)
+	assert.Equal(t, "editor", claims.Role)
+
+	// Token types are not interchangeable
+	_, err = service.ValidateRefreshToken(token)
+	assert.Error(t, err)
+
+	// Restore tokens of deleted accounts are never accepted as access tokens
+	email, err := value_objects.NewEmail("test@example.com")
+	require.NoError(t, err)
+	restoreToken, err := service.GenerateRestoreToken(*value_objects.NewUserID(), *email, "google")
+	require.NoError(t, err)
+	_, err = service.ValidateAccessToken(restoreToken)
+	assert.Error(t, err)
+	restoreClaims, err := service.ValidateRestoreToken(restoreToken)
+	require.NoError(t, err)
+	assert.Equal(t, "google", restoreClaims.Provider)
+
+	// Shared secrets are never published
+	assert.Empty(t, keys.PublicKeys())
+}
+
+func TestJWTService_AsymmetricKeys(t *testing.T) {
+	tests := []struct {
+		name    string
+		newKey  func(t *testing.T, dir string, publicDir string, kid string) string
+		alg     string
+		keyType string
+	}{
+		{name: "RS256", newKey: newRSAKeyPair, alg: "RS256", keyType: "RSA"},
+		{name: "EdDSA", newKey: newEd25519KeyPair, alg: "EdDSA", keyType: "OKP"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			publicDir := t.TempDir()
+			privatePath := tt.newKey(t, dir, publicDir, "key-1")
+
+			signer := newTestService(t, mustLoadKeySet(t, "", "key-1", privatePath, ""))
+			token := issueAccessToken(t, signer)
+
+			parsed, _, err := jwt.NewParser().ParseUnverified(token, &jwtClaims{})
+			require.NoError(t, err)
+			assert.Equal(t, tt.alg, parsed.Method.Alg())
+			assert.Equal(t, "key-1", parsed.Header["kid"])
+
+			// A verification-only service accepts the token but cannot issue its own
+			verifier := newTestService(t, mustLoadKeySet(t, "", "", "", publicDir))
+			_, err = verifier.ValidateAccessToken(token)
+			require.NoError(t, err)
+			_, err = verifier.GenerateMFAToken(*value_objects.NewUserID(), value_objects.Email{}, "local")
+			assert.Error(t, err)
+
+			jwks := signer.keys.PublicKeys()
+			require.Len(t, jwks, 1)
+			assert.Equal(t, "key-1", jwks[0].KeyID)
+			assert.Equal(t, tt.alg, jwks[0].Algorithm)
+			assert.Equal(t, tt.keyType, jwks[0].KeyType)
+		})
+	}
+}
+
+func TestJWTService_KeyRotation(t *testing.T) {
+	dir := t.TempDir()
+	publicDir := t.TempDir()
+	oldKey := newRSAKeyPair(t, dir, publicDir, "old")
+	newKey := newEd25519KeyPair(t, dir, publicDir, "new")
+
+	oldToken := issueAccessToken(t, newTestService(t, mustLoadKeySet(t, "", "old", oldKey, "")))
+
+	// After rotation tokens of the previous key stay valid while its public key is kept
+	rotated := newTestService(t, mustLoadKeySet(t, "", "new", newKey, publicDir))
+	_, err := rotated.ValidateAccessToken(oldToken)
+	require.NoError(t, err)
+	_, err = rotated.ValidateAccessToken(issueAccessToken(t, rotated))
+	require.NoError(t, err)
+	assert.Len(t, rotated.keys.PublicKeys(), 2)
+
+	// Once the old public key is removed its tokens are rejected
+	require.NoError(t, os.Remove(filepath.Join(publicDir, "old.pem")))
+	retired := newTestService(t, mustLoadKeySet(t, "", "new", newKey, publicDir))
+	_, err = retired.ValidateAccessToken(oldToken)
+	assert.Error(t, err)
+}
+
+func TestJWTService_RejectsForgedTokens(t *testing.T) {
+	dir := t.TempDir()
+	publicDir := t.TempDir()
+	privatePath := newRSAKeyPair(t, dir, publicDir, "key-1")
+	service := newTestService(t, mustLoadKeySet(t, "", "key-1", privatePath, ""))
+
+	publicPEM, err := os.ReadFile(filepath.Join(publicDir, "key-1.pem"))
+	require.NoError(t, err)
+
+	claims := &jwtClaims{
+		UserID: value_objects.NewUserID().String(),
+		Email:  "test@example.com",
+		Type:   "access",
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    "peace",
+			Audience:  jwt.ClaimStrings{"peace"},
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+		},
+	}
+
+	// HS256 signed with the public key, the classic algorithm confusion attack
+	confused := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	confused.Header["kid"] = "key-1"
+	confusedToken, err := confused.SignedString(publicPEM)
+	require.NoError(t, err)
+	_, err = service.ValidateAccessToken(confusedToken)
+	assert.Error(t, err)
+
+	// Unsigned token
+	unsigned := jwt.NewWithClaims(jwt.SigningMethodNone, claims)
+	unsigned.Header["kid"] = "key-1"
+	unsignedToken, err := unsigned.SignedString(jwt.UnsafeAllowNoneSignatureType)
+	require.NoError(t, err)
+	_, err = service.ValidateAccessToken(unsignedToken)
+	assert.Error(t, err)
+
+	// Token for another audience
+	other := newTestService(t, mustLoadKeySet(t, "", "key-1", privatePath, ""))
+	other.audience = "another-service"
+	_, err = service.ValidateAccessToken(issueAccessToken(t, other))
+	assert.Error(t, err)
+}
+
+func mustLoadKeySet(t *testing.T, secret string, signingKeyID string, signingKeyFile string, verificationKeysDir string) *KeySet {
+	keys, err := LoadKeySet(secret, signingKeyID, signingKeyFile, verificationKeysDir)
+	require.NoError(t, err)
+	return keys
+}
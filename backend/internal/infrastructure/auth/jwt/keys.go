@@ -0,0 +1,195 @@
+package jwt
+
+import (
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	appjwt "github.com/atdevten/peace/internal/application/services/jwt"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// key is one signing or verification key with the algorithm it is used with
+type key struct {
+	id     string
+	method jwt.SigningMethod
+	sign   interface{} // private key or shared secret; nil for verification-only keys
+	verify interface{} // public key or shared secret
+}
+
+// KeySet holds the key tokens are signed with and every key tokens may be verified with.
+// Several verification keys can be active at once so keys can be rotated without
+// invalidating tokens issued under the previous one.
+type KeySet struct {
+	signing      *key
+	verification map[string]*key
+}
+
+// NewHMACKeySet returns a key set signing and verifying with a single HS256 shared secret
+func NewHMACKeySet(secret string) (*KeySet, error) {
+	if secret == "" {
+		return nil, errors.New("jwt secret is required")
+	}
+
+	k := &key{method: jwt.SigningMethodHS256, sign: []byte(secret), verify: []byte(secret)}
+	return &KeySet{signing: k, verification: map[string]*key{"": k}}, nil
+}
+
+// LoadKeySet builds the key set from configuration.
+//
+// Without signingKeyFile and verificationKeysDir tokens use the HS256 secret. Otherwise
+// signingKeyFile is a PEM RSA (RS256) or Ed25519 (EdDSA) private key identified by
+// signingKeyID, and verificationKeysDir holds PEM public keys named <kid>.pem. A
+// process that only verifies tokens, such as the websocket server, sets just the directory.
+func LoadKeySet(secret string, signingKeyID string, signingKeyFile string, verificationKeysDir string) (*KeySet, error) {
+	if signingKeyFile == "" && verificationKeysDir == "" {
+		return NewHMACKeySet(secret)
+	}
+
+	set := &KeySet{verification: map[string]*key{}}
+
+	if signingKeyFile != "" {
+		if signingKeyID == "" {
+			return nil, errors.New("a key ID is required for the signing key")
+		}
+
+		pem, err := os.ReadFile(signingKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("os.ReadFile: %w", err)
+		}
+
+		k, err := parsePrivateKey(signingKeyID, pem)
+		if err != nil {
+			return nil, fmt.Errorf("parsePrivateKey: %w", err)
+		}
+
+		set.signing = k
+		set.verification[k.id] = k
+	}
+
+	if verificationKeysDir != "" {
+		files, err := filepath.Glob(filepath.Join(verificationKeysDir, "*.pem"))
+		if err != nil {
+			return nil, fmt.Errorf("filepath.Glob: %w", err)
+		}
+
+		for _, file := range files {
+			kid := strings.TrimSuffix(filepath.Base(file), ".pem")
+			if _, exists := set.verification[kid]; exists {
+				continue
+			}
+
+			pem, err := os.ReadFile(file)
+			if err != nil {
+				return nil, fmt.Errorf("os.ReadFile: %w", err)
+			}
+
+			k, err := parsePublicKey(kid, pem)
+			if err != nil {
+				return nil, fmt.Errorf("parsePublicKey %s: %w", file, err)
+			}
+			set.verification[kid] = k
+		}
+	}
+
+	if len(set.verification) == 0 {
+		return nil, errors.New("no JWT verification keys found")
+	}
+
+	return set, nil
+}
+
+func parsePrivateKey(kid string, pem []byte) (*key, error) {
+	if rsaKey, err := jwt.ParseRSAPrivateKeyFromPEM(pem); err == nil {
+		return &key{id: kid, method: jwt.SigningMethodRS256, sign: rsaKey, verify: &rsaKey.PublicKey}, nil
+	}
+
+	if edKey, err := jwt.ParseEdPrivateKeyFromPEM(pem); err == nil {
+		privateKey, ok := edKey.(ed25519.PrivateKey)
+		if !ok {
+			return nil, errors.New("unsupported EdDSA key")
+		}
+		return &key{id: kid, method: jwt.SigningMethodEdDSA, sign: privateKey, verify: privateKey.Public()}, nil
+	}
+
+	return nil, errors.New("signing key must be a PEM encoded RSA or Ed25519 private key")
+}
+
+func parsePublicKey(kid string, pem []byte) (*key, error) {
+	if rsaKey, err := jwt.ParseRSAPublicKeyFromPEM(pem); err == nil {
+		return &key{id: kid, method: jwt.SigningMethodRS256, verify: rsaKey}, nil
+	}
+
+	if edKey, err := jwt.ParseEdPublicKeyFromPEM(pem); err == nil {
+		return &key{id: kid, method: jwt.SigningMethodEdDSA, verify: edKey}, nil
+	}
+
+	return nil, errors.New("verification key must be a PEM encoded RSA or Ed25519 public key")
+}
+
+// lookup returns the verification key named by the token's kid header, checking its algorithm
+func (s *KeySet) lookup(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+
+	k, ok := s.verification[kid]
+	if !ok {
+		return nil, fmt.Errorf("unknown key ID %q", kid)
+	}
+
+	// Never let the token choose the algorithm, e.g. HS256 signed with a public key
+	if token.Method.Alg() != k.method.Alg() {
+		return nil, fmt.Errorf("unexpected signing method %s", token.Method.Alg())
+	}
+
+	return k.verify, nil
+}
+
+// algorithms lists the algorithms of the verification keys
+func (s *KeySet) algorithms() []string {
+	seen := map[string]bool{}
+	var algs []string
+	for _, k := range s.verification {
+		if !seen[k.method.Alg()] {
+			seen[k.method.Alg()] = true
+			algs = append(algs, k.method.Alg())
+		}
+	}
+	sort.Strings(algs)
+	return algs
+}
+
+// PublicKeys returns the asymmetric verification keys as JWKs, sorted by key ID
+func (s *KeySet) PublicKeys() []appjwt.JSONWebKey {
+	keys := make([]appjwt.JSONWebKey, 0, len(s.verification))
+
+	for _, k := range s.verification {
+		jwk := appjwt.JSONWebKey{KeyID: k.id, Use: "sig", Algorithm: k.method.Alg()}
+
+		switch public := k.verify.(type) {
+		case *rsa.PublicKey:
+			jwk.KeyType = "RSA"
+			jwk.N = base64.RawURLEncoding.EncodeToString(public.N.Bytes())
+			jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(public.E)).Bytes())
+		case ed25519.PublicKey:
+			jwk.KeyType = "OKP"
+			jwk.Curve = "Ed25519"
+			jwk.X = base64.RawURLEncoding.EncodeToString(public)
+		default:
+			// Shared secrets are not public
+			continue
+		}
+
+		keys = append(keys, jwk)
+	}
+
+	sort.Slice(keys, func(i, j int) bool { return keys[i].KeyID < keys[j].KeyID })
+	return keys
+}
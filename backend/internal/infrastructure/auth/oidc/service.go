@@ -0,0 +1,389 @@
+package oidc
+
+import (
+	"bytes"
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/atdevten/peace/internal/application/services/oauth"
+)
+
+// defaultHTTPTimeout bounds each call to a provider
+const defaultHTTPTimeout = 10 * time.Second
+
+// ProviderConfig configures one OAuth 2.0 / OpenID Connect provider
+type ProviderConfig struct {
+	Name         string
+	Issuer       string // endpoints not given explicitly are read from <Issuer>/.well-known/openid-configuration
+	ClientID     string
+	ClientSecret string
+	RedirectURI  string
+	Scopes       []string
+	AuthURL      string
+	TokenURL     string
+	UserInfoURL  string
+	EmailsURL    string // lists the account's emails for providers whose userinfo omits private ones (GitHub)
+	TrustEmail   bool   // treat reported emails as verified when the provider has no email_verified claim
+}
+
+// Options configures the OIDC service
+type Options struct {
+	Providers   []ProviderConfig
+	StateSecret string
+	StateTTL    time.Duration
+	HTTPClient  *http.Client // defaults to a client with a 10s timeout
+}
+
+type oidcService struct {
+	providers map[string]*provider
+	names     []string
+	state     stateSigner
+	client    *http.Client
+	now       func() time.Time
+}
+
+type provider struct {
+	config ProviderConfig
+
+	mu        sync.Mutex
+	endpoints *endpoints // resolved lazily, discovery is retried until it succeeds
+}
+
+type endpoints struct {
+	authURL     string
+	tokenURL    string
+	userInfoURL string
+}
+
+func NewService(options Options) oauth.Service {
+	client := options.HTTPClient
+	if client == nil {
+		client = &http.Client{Timeout: defaultHTTPTimeout}
+	}
+
+	s := &oidcService{
+		providers: make(map[string]*provider, len(options.Providers)),
+		state:     stateSigner{secret: []byte(options.StateSecret), ttl: options.StateTTL},
+		client:    client,
+		now:       time.Now,
+	}
+	for _, config := range options.Providers {
+		s.providers[config.Name] = &provider{config: config}
+		s.names = append(s.names, config.Name)
+	}
+
+	return s
+}
+
+func (s *oidcService) Providers() []string {
+	return append([]string(nil), s.names...)
+}
+
+func (s *oidcService) AuthorizationURL(ctx context.Context, name string) (*oauth.Authorization, error) {
+	p, ok := s.providers[name]
+	if !ok {
+		return nil, oauth.ErrUnknownProvider
+	}
+
+	endpoints, err := s.endpoints(ctx, p)
+	if err != nil {
+		return nil, err
+	}
+
+	state, err := s.state.issue(name, s.now())
+	if err != nil {
+		return nil, fmt.Errorf("s.state.issue: %w", err)
+	}
+	verifier, err := newCodeVerifier()
+	if err != nil {
+		return nil, fmt.Errorf("newCodeVerifier: %w", err)
+	}
+
+	params := url.Values{}
+	params.Set("response_type", "code")
+	params.Set("client_id", p.config.ClientID)
+	params.Set("redirect_uri", p.config.RedirectURI)
+	params.Set("scope", strings.Join(p.config.Scopes, " "))
+	params.Set("state", state)
+	params.Set("code_challenge", codeChallenge(verifier))
+	params.Set("code_challenge_method", "S256")
+
+	separator := "?"
+	if strings.Contains(endpoints.authURL, "?") {
+		separator = "&"
+	}
+
+	return &oauth.Authorization{
+		URL:          endpoints.authURL + separator + params.Encode(),
+		PendingLogin: oauth.PendingLogin{State: state, Verifier: verifier},
+	}, nil
+}
+
+func (s *oidcService) Exchange(ctx context.Context, name string, code string, state string, pending oauth.PendingLogin) (*oauth.UserInfo, error) {
+	p, ok := s.providers[name]
+	if !ok {
+		return nil, oauth.ErrUnknownProvider
+	}
+	// Only the callback of the login this browser started is accepted
+	if pending.Verifier == "" || subtle.ConstantTimeCompare([]byte(state), []byte(pending.State)) != 1 {
+		return nil, oauth.ErrInvalidState
+	}
+	if !s.state.verify(name, state, s.now()) {
+		return nil, oauth.ErrInvalidState
+	}
+
+	endpoints, err := s.endpoints(ctx, p)
+	if err != nil {
+		return nil, err
+	}
+
+	accessToken, err := s.redeemCode(ctx, p, endpoints.tokenURL, code, pending.Verifier)
+	if err != nil {
+		return nil, fmt.Errorf("failed to exchange code for token: %w", err)
+	}
+
+	info, err := s.userInfo(ctx, p, endpoints.userInfoURL, accessToken)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user info: %w", err)
+	}
+
+	return info, nil
+}
+
+// endpoints returns the provider's configured endpoints, completed from its discovery document
+func (s *oidcService) endpoints(ctx context.Context, p *provider) (*endpoints, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.endpoints != nil {
+		return p.endpoints, nil
+	}
+
+	resolved := &endpoints{
+		authURL:     p.config.AuthURL,
+		tokenURL:    p.config.TokenURL,
+		userInfoURL: p.config.UserInfoURL,
+	}
+
+	if resolved.authURL == "" || resolved.tokenURL == "" || resolved.userInfoURL == "" {
+		if p.config.Issuer == "" {
+			return nil, fmt.Errorf("provider %s has neither an issuer nor explicit endpoints", p.config.Name)
+		}
+
+		var document struct {
+			Issuer                string `json:"issuer"`
+			AuthorizationEndpoint string `json:"authorization_endpoint"`
+			TokenEndpoint         string `json:"token_endpoint"`
+			UserInfoEndpoint      string `json:"userinfo_endpoint"`
+		}
+		discoveryURL := strings.TrimSuffix(p.config.Issuer, "/") + "/.well-known/openid-configuration"
+		if err := s.getJSON(ctx, discoveryURL, "", &document); err != nil {
+			return nil, fmt.Errorf("failed to load discovery document of %s: %w", p.config.Name, err)
+		}
+		if strings.TrimSuffix(document.Issuer, "/") != strings.TrimSuffix(p.config.Issuer, "/") {
+			return nil, fmt.Errorf("discovery document of %s is for issuer %q", p.config.Name, document.Issuer)
+		}
+
+		if resolved.authURL == "" {
+			resolved.authURL = document.AuthorizationEndpoint
+		}
+		if resolved.tokenURL == "" {
+			resolved.tokenURL = document.TokenEndpoint
+		}
+		if resolved.userInfoURL == "" {
+			resolved.userInfoURL = document.UserInfoEndpoint
+		}
+		if resolved.authURL == "" || resolved.tokenURL == "" || resolved.userInfoURL == "" {
+			return nil, fmt.Errorf("discovery document of %s is missing endpoints", p.config.Name)
+		}
+	}
+
+	p.endpoints = resolved
+	return resolved, nil
+}
+
+// redeemCode exchanges an authorization code and its PKCE verifier for an access token
+func (s *oidcService) redeemCode(ctx context.Context, p *provider, tokenURL string, code string, verifier string) (string, error) {
+	data := url.Values{}
+	data.Set("grant_type", "authorization_code")
+	data.Set("code", code)
+	data.Set("redirect_uri", p.config.RedirectURI)
+	data.Set("client_id", p.config.ClientID)
+	if p.config.ClientSecret != "" {
+		data.Set("client_secret", p.config.ClientSecret)
+	}
+	data.Set("code_verifier", verifier)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, tokenURL, strings.NewReader(data.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	body, status, err := s.do(req)
+	if err != nil {
+		return "", err
+	}
+
+	// Some providers (GitHub) report errors with a 200 status
+	var tokenResp struct {
+		AccessToken      string `json:"access_token"`
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}
+	if err := json.Unmarshal(body, &tokenResp); err != nil {
+		return "", fmt.Errorf("failed to parse token response (status %d): %w", status, err)
+	}
+	if tokenResp.Error != "" {
+		return "", fmt.Errorf("token exchange failed: %s - %s", tokenResp.Error, tokenResp.ErrorDescription)
+	}
+	if status != http.StatusOK || tokenResp.AccessToken == "" {
+		return "", fmt.Errorf("token exchange failed with status %d", status)
+	}
+
+	return tokenResp.AccessToken, nil
+}
+
+// userInfo reads and normalizes the account behind accessToken, accepting both standard OIDC
+// claims and the field names of plain OAuth providers (id, verified_email, name, avatar_url)
+func (s *oidcService) userInfo(ctx context.Context, p *provider, userInfoURL string, accessToken string) (*oauth.UserInfo, error) {
+	var claims map[string]interface{}
+	if err := s.getJSON(ctx, userInfoURL, accessToken, &claims); err != nil {
+		return nil, err
+	}
+
+	info := &oauth.UserInfo{
+		Provider:  p.config.Name,
+		Subject:   firstString(claims, "sub", "id"),
+		Email:     firstString(claims, "email"),
+		FirstName: firstString(claims, "given_name"),
+		LastName:  firstString(claims, "family_name"),
+		Picture:   firstString(claims, "picture", "avatar_url"),
+	}
+	if info.Subject == "" {
+		return nil, errors.New("user info has no subject")
+	}
+
+	verified, reported := firstBool(claims, "email_verified", "verified_email")
+	info.EmailVerified = verified || (!reported && p.config.TrustEmail && info.Email != "")
+
+	if info.FirstName == "" && info.LastName == "" {
+		if name := strings.TrimSpace(firstString(claims, "name")); name != "" {
+			first, last, _ := strings.Cut(name, " ")
+			info.FirstName, info.LastName = first, strings.TrimSpace(last)
+		}
+	}
+
+	if !info.EmailVerified && p.config.EmailsURL != "" {
+		email, err := s.primaryEmail(ctx, p.config.EmailsURL, accessToken)
+		if err != nil {
+			return nil, err
+		}
+		if email != "" {
+			info.Email, info.EmailVerified = email, true
+		}
+	}
+
+	return info, nil
+}
+
+// primaryEmail returns the primary verified address of a GitHub style email list
+func (s *oidcService) primaryEmail(ctx context.Context, emailsURL string, accessToken string) (string, error) {
+	var emails []struct {
+		Email    string `json:"email"`
+		Primary  bool   `json:"primary"`
+		Verified bool   `json:"verified"`
+	}
+	if err := s.getJSON(ctx, emailsURL, accessToken, &emails); err != nil {
+		return "", err
+	}
+
+	for _, email := range emails {
+		if email.Primary && email.Verified {
+			return email.Email, nil
+		}
+	}
+
+	return "", nil
+}
+
+// getJSON fetches endpoint, authenticated with accessToken when given, and decodes the response into target
+func (s *oidcService) getJSON(ctx context.Context, endpoint string, accessToken string, target interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if accessToken != "" {
+		req.Header.Set("Authorization", "Bearer "+accessToken)
+	}
+
+	body, status, err := s.do(req)
+	if err != nil {
+		return err
+	}
+	if status != http.StatusOK {
+		return fmt.Errorf("GET %s returned status %d", req.URL.Path, status)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber() // numeric account IDs must not turn into floats
+	if err := decoder.Decode(target); err != nil {
+		return fmt.Errorf("failed to parse response of %s: %w", req.URL.Path, err)
+	}
+
+	return nil
+}
+
+func (s *oidcService) do(req *http.Request) ([]byte, int, error) {
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return body, resp.StatusCode, nil
+}
+
+// firstString returns the first of keys present in claims as a string
+func firstString(claims map[string]interface{}, keys ...string) string {
+	for _, key := range keys {
+		switch value := claims[key].(type) {
+		case string:
+			if value != "" {
+				return value
+			}
+		case json.Number:
+			return value.String()
+		}
+	}
+	return ""
+}
+
+// firstBool returns the first of keys present in claims, some providers send booleans as strings
+func firstBool(claims map[string]interface{}, keys ...string) (value bool, found bool) {
+	for _, key := range keys {
+		switch value := claims[key].(type) {
+		case bool:
+			return value, true
+		case string:
+			return value == "true", true
+		}
+	}
+	return false, false
+}
@@ -0,0 +1,263 @@
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/atdevten/peace/internal/application/services/oauth"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeProvider is a minimal authorization server: it hands out code "valid-code" for the
+// PKCE challenge registered through authorize and serves fixed userinfo for its token
+type fakeProvider struct {
+	server        *httptest.Server
+	challenge     string
+	userInfo      map[string]interface{}
+	emails        []map[string]interface{}
+	discoveryHits int
+}
+
+func newFakeProvider(t *testing.T) *fakeProvider {
+	fake := &fakeProvider{
+		userInfo: map[string]interface{}{
+			"sub":            "subject-1",
+			"email":          "user@example.com",
+			"email_verified": true,
+			"given_name":     "Jane",
+			"family_name":    "Doe",
+			"picture":        "https://example.com/jane.png",
+		},
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		fake.discoveryHits++
+		writeJSON(w, map[string]string{
+			"issuer":                 fake.server.URL,
+			"authorization_endpoint": fake.server.URL + "/authorize",
+			"token_endpoint":         fake.server.URL + "/token",
+			"userinfo_endpoint":      fake.server.URL + "/userinfo",
+		})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		if r.PostForm.Get("code") != "valid-code" || codeChallenge(r.PostForm.Get("code_verifier")) != fake.challenge {
+			w.WriteHeader(http.StatusBadRequest)
+			writeJSON(w, map[string]string{"error": "invalid_grant"})
+			return
+		}
+		writeJSON(w, map[string]string{"access_token": "access-1", "token_type": "Bearer"})
+	})
+	mux.HandleFunc("/userinfo", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer access-1" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		writeJSON(w, fake.userInfo)
+	})
+	mux.HandleFunc("/emails", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, fake.emails)
+	})
+
+	fake.server = httptest.NewServer(mux)
+	t.Cleanup(fake.server.Close)
+	return fake
+}
+
+// authorize plays the browser: it follows the authorization URL's PKCE challenge into the fake provider
+func (f *fakeProvider) authorize(t *testing.T, authorization *oauth.Authorization) {
+	parsed, err := url.Parse(authorization.URL)
+	require.NoError(t, err)
+	assert.Equal(t, "S256", parsed.Query().Get("code_challenge_method"))
+	assert.Equal(t, authorization.State, parsed.Query().Get("state"))
+	f.challenge = parsed.Query().Get("code_challenge")
+}
+
+func writeJSON(w http.ResponseWriter, value interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(value)
+}
+
+func newTestService(providers ...ProviderConfig) *oidcService {
+	return NewService(Options{
+		Providers:   providers,
+		StateSecret: "state-secret",
+		StateTTL:    10 * time.Minute,
+	}).(*oidcService)
+}
+
+func TestOIDCService_DiscoveryLogin(t *testing.T) {
+	fake := newFakeProvider(t)
+	service := newTestService(ProviderConfig{
+		Name:        "keycloak",
+		Issuer:      fake.server.URL,
+		ClientID:    "peace",
+		RedirectURI: "http://localhost:3000/auth/keycloak/callback",
+		Scopes:      []string{"openid", "email", "profile"},
+	})
+	ctx := context.Background()
+
+	authorization, err := service.AuthorizationURL(ctx, "keycloak")
+	require.NoError(t, err)
+	assert.Contains(t, authorization.URL, fake.server.URL+"/authorize?")
+	assert.Contains(t, authorization.URL, "scope=openid+email+profile")
+	fake.authorize(t, authorization)
+
+	info, err := service.Exchange(ctx, "keycloak", "valid-code", authorization.State, authorization.PendingLogin)
+	require.NoError(t, err)
+	assert.Equal(t, &oauth.UserInfo{
+		Provider:      "keycloak",
+		Subject:       "subject-1",
+		Email:         "user@example.com",
+		EmailVerified: true,
+		FirstName:     "Jane",
+		LastName:      "Doe",
+		Picture:       "https://example.com/jane.png",
+	}, info)
+
+	// The discovery document is fetched once
+	assert.Equal(t, 1, fake.discoveryHits)
+	assert.Equal(t, []string{"keycloak"}, service.Providers())
+}
+
+func TestOIDCService_RejectsInvalidState(t *testing.T) {
+	fake := newFakeProvider(t)
+	service := newTestService(
+		ProviderConfig{Name: "keycloak", Issuer: fake.server.URL, ClientID: "peace"},
+		ProviderConfig{Name: "other", Issuer: fake.server.URL, ClientID: "peace"},
+	)
+	ctx := context.Background()
+
+	authorization, err := service.AuthorizationURL(ctx, "keycloak")
+	require.NoError(t, err)
+	fake.authorize(t, authorization)
+
+	_, err = service.Exchange(ctx, "keycloak", "valid-code", authorization.State+"x", authorization.PendingLogin)
+	assert.ErrorIs(t, err, oauth.ErrInvalidState)
+
+	// A state is only valid for the provider it was issued for
+	_, err = service.Exchange(ctx, "other", "valid-code", authorization.State, authorization.PendingLogin)
+	assert.ErrorIs(t, err, oauth.ErrInvalidState)
+
+	service.now = func() time.Time { return time.Now().Add(11 * time.Minute) }
+	_, err = service.Exchange(ctx, "keycloak", "valid-code", authorization.State, authorization.PendingLogin)
+	assert.ErrorIs(t, err, oauth.ErrInvalidState)
+
+	_, err = service.Exchange(ctx, "unknown", "valid-code", authorization.State, authorization.PendingLogin)
+	assert.ErrorIs(t, err, oauth.ErrUnknownProvider)
+	_, err = service.AuthorizationURL(ctx, "unknown")
+	assert.ErrorIs(t, err, oauth.ErrUnknownProvider)
+}
+
+func TestOIDCService_RejectsWrongCodeVerifier(t *testing.T) {
+	fake := newFakeProvider(t)
+	service := newTestService(ProviderConfig{Name: "keycloak", Issuer: fake.server.URL, ClientID: "peace"})
+	ctx := context.Background()
+
+	first, err := service.AuthorizationURL(ctx, "keycloak")
+	require.NoError(t, err)
+	second, err := service.AuthorizationURL(ctx, "keycloak")
+	require.NoError(t, err)
+	fake.authorize(t, first)
+
+	// A code issued for one login cannot be redeemed with the verifier of another
+	_, err = service.Exchange(ctx, "keycloak", "valid-code", second.State, second.PendingLogin)
+	assert.Error(t, err)
+}
+
+func TestOIDCService_RequiresPendingLogin(t *testing.T) {
+	fake := newFakeProvider(t)
+	service := newTestService(ProviderConfig{Name: "keycloak", Issuer: fake.server.URL, ClientID: "peace"})
+	ctx := context.Background()
+
+	first, err := service.AuthorizationURL(ctx, "keycloak")
+	require.NoError(t, err)
+	second, err := service.AuthorizationURL(ctx, "keycloak")
+	require.NoError(t, err)
+	fake.authorize(t, first)
+
+	// Every login has its own random verifier
+	assert.NotEmpty(t, first.Verifier)
+	assert.NotEqual(t, first.Verifier, second.Verifier)
+
+	tests := []struct {
+		name    string
+		pending oauth.PendingLogin
+	}{
+		{name: "no login cookie", pending: oauth.PendingLogin{}},
+		{name: "cookie of another login", pending: second.PendingLogin},
+		{name: "cookie without a verifier", pending: oauth.PendingLogin{State: first.State}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := service.Exchange(ctx, "keycloak", "valid-code", first.State, tt.pending)
+			assert.ErrorIs(t, err, oauth.ErrInvalidState)
+		})
+	}
+}
+
+func TestOIDCService_PlainOAuthProvider(t *testing.T) {
+	fake := newFakeProvider(t)
+	fake.userInfo = map[string]interface{}{
+		"id":         json.Number("583231"),
+		"name":       "Jane Q Doe",
+		"avatar_url": "https://example.com/avatar.png",
+		"email":      nil,
+	}
+	fake.emails = []map[string]interface{}{
+		{"email": "old@example.com", "primary": false, "verified": true},
+		{"email": "jane@example.com", "primary": true, "verified": true},
+	}
+
+	// GitHub style: explicit endpoints, no discovery, emails listed separately
+	service := newTestService(ProviderConfig{
+		Name:        "github",
+		ClientID:    "peace",
+		AuthURL:     fake.server.URL + "/authorize",
+		TokenURL:    fake.server.URL + "/token",
+		UserInfoURL: fake.server.URL + "/userinfo",
+		EmailsURL:   fake.server.URL + "/emails",
+	})
+	ctx := context.Background()
+
+	authorization, err := service.AuthorizationURL(ctx, "github")
+	require.NoError(t, err)
+	fake.authorize(t, authorization)
+
+	info, err := service.Exchange(ctx, "github", "valid-code", authorization.State, authorization.PendingLogin)
+	require.NoError(t, err)
+	assert.Equal(t, "583231", info.Subject)
+	assert.Equal(t, "jane@example.com", info.Email)
+	assert.True(t, info.EmailVerified)
+	assert.Equal(t, "Jane", info.FirstName)
+	assert.Equal(t, "Q Doe", info.LastName)
+	assert.Equal(t, "https://example.com/avatar.png", info.Picture)
+	assert.Equal(t, 0, fake.discoveryHits)
+}
+
+func TestOIDCService_UnverifiedEmail(t *testing.T) {
+	fake := newFakeProvider(t)
+	delete(fake.userInfo, "email_verified")
+
+	for _, trust := range []bool{false, true} {
+		service := newTestService(ProviderConfig{Name: "corp", Issuer: fake.server.URL, ClientID: "peace", TrustEmail: trust})
+		ctx := context.Background()
+
+		authorization, err := service.AuthorizationURL(ctx, "corp")
+		require.NoError(t, err)
+		fake.authorize(t, authorization)
+
+		info, err := service.Exchange(ctx, "corp", "valid-code", authorization.State, authorization.PendingLogin)
+		require.NoError(t, err)
+		assert.Equal(t, trust, info.EmailVerified)
+	}
+}
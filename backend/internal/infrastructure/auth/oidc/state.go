@@ -0,0 +1,73 @@
+package oidc
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// stateSigner issues state parameters scoped to a provider and expiring after ttl. The state is kept
+// with the PKCE verifier of its login in an HttpOnly cookie and must come back in the callback, which is
+// what binds the login to the browser.
+type stateSigner struct {
+	secret []byte
+	ttl    time.Duration
+}
+
+// issue returns nonce.expires.signature, scoped to provider
+func (s stateSigner) issue(provider string, now time.Time) (string, error) {
+	nonce := make([]byte, 16)
+	if _, err := rand.Read(nonce); err != nil {
+		return "", fmt.Errorf("rand.Read: %w", err)
+	}
+
+	payload := base64.RawURLEncoding.EncodeToString(nonce) + "." + strconv.FormatInt(now.Add(s.ttl).Unix(), 10)
+	return payload + "." + s.sign(provider+"|"+payload), nil
+}
+
+// verify checks the signature, the provider the state was issued for and its expiry
+func (s stateSigner) verify(provider string, state string, now time.Time) bool {
+	parts := strings.Split(state, ".")
+	if len(parts) != 3 {
+		return false
+	}
+
+	payload := parts[0] + "." + parts[1]
+	if !hmac.Equal([]byte(parts[2]), []byte(s.sign(provider+"|"+payload))) {
+		return false
+	}
+
+	expires, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return false
+	}
+
+	return now.Unix() <= expires
+}
+
+func (s stateSigner) sign(value string) string {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte(value))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// newCodeVerifier returns a random PKCE verifier for one login
+func newCodeVerifier() (string, error) {
+	verifier := make([]byte, 32)
+	if _, err := rand.Read(verifier); err != nil {
+		return "", fmt.Errorf("rand.Read: %w", err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(verifier), nil
+}
+
+// codeChallenge is the S256 PKCE challenge of verifier
+func codeChallenge(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
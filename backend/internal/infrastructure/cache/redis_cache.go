@@ -0,0 +1,43 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	appcache "github.com/atdevten/peace/internal/application/services/cache"
+	redisclient "github.com/atdevten/peace/internal/infrastructure/database/redis"
+
+	redisv8 "github.com/go-redis/redis/v8"
+)
+
+// RedisCache keeps cached values in Redis, so every API instance serves the same ones
+type RedisCache struct {
+	client redisclient.Client
+	prefix string
+}
+
+func NewRedisCache(client redisclient.Client) *RedisCache {
+	return &RedisCache{client: client, prefix: "cache:"}
+}
+
+var _ appcache.Cache = (*RedisCache)(nil)
+
+func (c *RedisCache) Get(ctx context.Context, key string) (string, bool, error) {
+	value, err := c.client.Get(ctx, c.prefix+key)
+	if errors.Is(err, redisv8.Nil) {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, fmt.Errorf("c.client.Get: %w", err)
+	}
+	return value, true, nil
+}
+
+func (c *RedisCache) Set(ctx context.Context, key string, value string, ttl time.Duration) error {
+	if err := c.client.Set(ctx, c.prefix+key, value, ttl); err != nil {
+		return fmt.Errorf("c.client.Set: %w", err)
+	}
+	return nil
+}
@@ -12,11 +12,16 @@ import (
 
 // Config represents the overall configuration
 type Config struct {
-	Server   ServerConfig
-	Database DatabaseConfig
-	App      AppConfig
-	Auth     AuthConfig
-	Log      LogConfig
+	Server    ServerConfig
+	Database  DatabaseConfig
+	App       AppConfig
+	Auth      AuthConfig
+	RateLimit RateLimitConfig
+	Export    ExportConfig
+	Retention RetentionConfig
+	Quotes    QuotesConfig
+	Mail      MailConfig
+	Log       LogConfig
 }
 
 // ServerConfig represents server configuration
@@ -27,6 +32,9 @@ type ServerConfig struct {
 	ReadTimeout   time.Duration
 	WriteTimeout  time.Duration
 	IdleTimeout   time.Duration
+	// TrustedProxies may set X-Forwarded-For; the client IP used for rate limits and sessions is
+	// the connection's address otherwise
+	TrustedProxies []string
 }
 
 // DatabaseConfig represents database configuration
@@ -71,22 +79,148 @@ type CORSConfig struct {
 
 // AuthConfig represents authentication configuration
 type AuthConfig struct {
-	JWT    JWTConfig
-	Google GoogleConfig
+	JWT               JWTConfig
+	OAuth             OAuthConfig
+	PasswordReset     PasswordResetConfig
+	EmailVerification EmailVerificationConfig
+	MFA               MFAConfig
+	LoginLockout      LoginLockoutConfig
 }
 
 // JWTConfig represents JWT configuration
 type JWTConfig struct {
-	Secret            string
-	Expiration        time.Duration
-	RefreshExpiration time.Duration
+	Secret              string // HS256 shared secret, used when no key files are configured
+	Expiration          time.Duration
+	RefreshExpiration   time.Duration
+	Issuer              string
+	Audience            string
+	SigningKeyID        string // kid header of issued tokens
+	SigningKeyFile      string // PEM RSA or Ed25519 private key
+	VerificationKeysDir string // directory of <kid>.pem public keys accepted on validation
 }
 
-// GoogleConfig represents Google OAuth configuration
-type GoogleConfig struct {
+// OAuthConfig represents external login provider configuration
+type OAuthConfig struct {
+	StateSecret string        // signs the state parameter, required with providers
+	StateTTL    time.Duration // time a user has to complete the provider login
+	Providers   []OAuthProviderConfig
+}
+
+// OAuthProviderConfig represents one OAuth 2.0 / OpenID Connect provider
+type OAuthProviderConfig struct {
+	Name         string // identifier used in URLs and stored with linked accounts, at most 20 characters
+	Issuer       string // OIDC issuer, endpoints are read from its discovery document
 	ClientID     string
 	ClientSecret string
 	RedirectURI  string
+	Scopes       []string
+	AuthURL      string // explicit endpoints for providers without discovery (GitHub)
+	TokenURL     string
+	UserInfoURL  string
+	EmailsURL    string // endpoint listing the account's emails when userinfo omits them (GitHub)
+	TrustEmail   bool   // treat emails as verified when the provider does not report it
+}
+
+// PasswordResetConfig represents password reset flow configuration
+type PasswordResetConfig struct {
+	TokenTTL time.Duration
+	URL      string // frontend page the emailed token is appended to
+}
+
+// EmailVerificationConfig represents email verification configuration
+type EmailVerificationConfig struct {
+	Required       bool // refuse login for unverified local accounts
+	TokenTTL       time.Duration
+	URL            string // frontend page the emailed token is appended to
+	ResendInterval time.Duration
+}
+
+// MFAConfig represents two-factor authentication configuration
+type MFAConfig struct {
+	Issuer string // account issuer shown in authenticator apps
+}
+
+// LoginLockoutConfig represents lockout of emails and IP addresses after failed logins
+type LoginLockoutConfig struct {
+	EmailThreshold int           // failures per email before it is locked out, 0 disables
+	IPThreshold    int           // failures per IP address before it is locked out, 0 disables
+	BaseDelay      time.Duration // first lockout, doubled by every further failure
+	MaxDelay       time.Duration
+	Window         time.Duration // failures are forgotten this long after the first one
+}
+
+// RateLimitConfig represents request throttling configuration
+type RateLimitConfig struct {
+	Driver string                   // "redis" (memory while Redis is unreachable), "memory", or "off" to also disable login lockouts
+	Groups map[string]RateLimitRule // keyed by route group: auth, user, feed, records, quotes, tags, admin
+}
+
+// RateLimitRule allows Requests per Window to each caller, Requests 0 disables it
+type RateLimitRule struct {
+	Requests int
+	Window   time.Duration
+}
+
+// rateLimitGroups are the route groups with their default rules, overridden by RATE_LIMIT_<GROUP>
+var rateLimitGroups = map[string]string{
+	"auth":    "20/1m",
+	"user":    "120/1m",
+	"feed":    "120/1m",
+	"records": "120/1m",
+	"quotes":  "300/1m",
+	"tags":    "300/1m",
+	"admin":   "60/1m",
+}
+
+// ExportConfig represents user data export configuration
+type ExportConfig struct {
+	Dir        string        // where archives are written, shared by all instances
+	LinkSecret string        // signs download links
+	LinkTTL    time.Duration // lifetime of a download link
+	ArchiveTTL time.Duration // how long a finished archive stays downloadable
+}
+
+// RetentionConfig represents the handling of deleted accounts
+type RetentionConfig struct {
+	GracePeriod time.Duration // how long a deleted account can still be restored
+	Mode        string        // "anonymize" unless "purge" is asked for explicitly
+	DryRun      bool          // only log what would be removed, on until turned off explicitly
+	Interval    time.Duration // time between two passes of the in-server job, 0 (the default) leaves it to cmd/retention
+	BatchSize   int
+}
+
+// QuotesConfig represents the quote of the day and mood-aware recommendations
+type QuotesConfig struct {
+	DailyRepeatWindow int           // days before a user sees the same quote of the day again, 0 allows repeats
+	DailyCache        string        // "redis" or "off"
+	MoodTags          []MoodTagRule // tags recommended for moods, empty recommends any quote
+}
+
+// MoodTagRule recommends quotes with any of Tags when the Level ("happy" or "energy") of the
+// latest mental health record lies within Min..Max
+type MoodTagRule struct {
+	Level string
+	Min   int
+	Max   int
+	Tags  []string
+}
+
+// defaultMoodTags lifts low energy with motivation and low happiness with hope
+const defaultMoodTags = "energy:1-4=motivation;happy:1-4=hope"
+
+// MailConfig represents outgoing mail configuration
+type MailConfig struct {
+	Driver string // "smtp", "log" (development only, writes live links to the log) or "memory"
+	From   string
+	SMTP   SMTPConfig
+}
+
+// SMTPConfig represents SMTP relay configuration
+type SMTPConfig struct {
+	Host     string
+	Port     string
+	Username string
+	Password string
 }
 
 // LogConfig represents logging configuration
@@ -98,6 +232,9 @@ type LogConfig struct {
 	CallerSkip int
 }
 
+// devSecretKey is the JWT secret of local setups, never good enough to sign anything that matters
+const devSecretKey = "dev-secret-key"
+
 // Load loads configuration from environment variables and optional .env file
 func Load() (*Config, error) {
 	return LoadWithEnvFile("")
@@ -151,6 +288,7 @@ func loadFromEnvironment() (*Config, error) {
 	if err != nil {
 		return nil, fmt.Errorf("invalid SERVER_IDLE_TIMEOUT: %w", err)
 	}
+	config.Server.TrustedProxies = splitList(getEnvOrDefault("TRUSTED_PROXIES", ""))
 
 	// Load database config - Postgres
 	config.Database.Postgres.Host = getEnvOrDefault("POSTGRES_HOST", "localhost")
@@ -192,7 +330,7 @@ func loadFromEnvironment() (*Config, error) {
 	}
 
 	// Load JWT config
-	config.Auth.JWT.Secret = getEnvOrDefault("JWT_SECRET", "dev-secret-key")
+	config.Auth.JWT.Secret = getEnvOrDefault("JWT_SECRET", devSecretKey)
 	config.Auth.JWT.Expiration, err = time.ParseDuration(getEnvOrDefault("JWT_EXPIRATION", "24h"))
 	if err != nil {
 		return nil, fmt.Errorf("invalid JWT_EXPIRATION: %w", err)
@@ -201,11 +339,129 @@ func loadFromEnvironment() (*Config, error) {
 	if err != nil {
 		return nil, fmt.Errorf("invalid JWT_REFRESH_EXPIRATION: %w", err)
 	}
+	config.Auth.JWT.Issuer = getEnvOrDefault("JWT_ISSUER", "peace")
+	config.Auth.JWT.Audience = getEnvOrDefault("JWT_AUDIENCE", "peace")
+	config.Auth.JWT.SigningKeyID = getEnvOrDefault("JWT_SIGNING_KEY_ID", "")
+	config.Auth.JWT.SigningKeyFile = getEnvOrDefault("JWT_SIGNING_KEY_FILE", "")
+	config.Auth.JWT.VerificationKeysDir = getEnvOrDefault("JWT_VERIFICATION_KEYS_DIR", "")
+
+	// Load OAuth provider config
+	config.Auth.OAuth.StateSecret = getEnvOrDefault("OAUTH_STATE_SECRET", "")
+	config.Auth.OAuth.StateTTL, err = time.ParseDuration(getEnvOrDefault("OAUTH_STATE_TTL", "10m"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid OAUTH_STATE_TTL: %w", err)
+	}
+	config.Auth.OAuth.Providers, err = loadOAuthProviders()
+	if err != nil {
+		return nil, err
+	}
+	// A known secret would let anyone forge login states, so providers are refused without a real one
+	if len(config.Auth.OAuth.Providers) > 0 && (config.Auth.OAuth.StateSecret == "" || config.Auth.OAuth.StateSecret == devSecretKey) {
+		return nil, fmt.Errorf("invalid OAUTH_STATE_SECRET: a secret of its own is required when OAUTH_PROVIDERS or GOOGLE_CLIENT_ID is set")
+	}
+
+	// Load password reset config
+	config.Auth.PasswordReset.TokenTTL, err = time.ParseDuration(getEnvOrDefault("PASSWORD_RESET_TOKEN_TTL", "1h"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid PASSWORD_RESET_TOKEN_TTL: %w", err)
+	}
+	config.Auth.PasswordReset.URL = getEnvOrDefault("PASSWORD_RESET_URL", "http://localhost:3000/reset-password")
+
+	// Load email verification config
+	config.Auth.EmailVerification.Required = getEnvAsBoolOrDefault("EMAIL_VERIFICATION_REQUIRED", false)
+	config.Auth.EmailVerification.TokenTTL, err = time.ParseDuration(getEnvOrDefault("EMAIL_VERIFICATION_TOKEN_TTL", "24h"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid EMAIL_VERIFICATION_TOKEN_TTL: %w", err)
+	}
+	config.Auth.EmailVerification.URL = getEnvOrDefault("EMAIL_VERIFICATION_URL", "http://localhost:3000/verify-email")
+	config.Auth.EmailVerification.ResendInterval, err = time.ParseDuration(getEnvOrDefault("EMAIL_VERIFICATION_RESEND_INTERVAL", "1m"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid EMAIL_VERIFICATION_RESEND_INTERVAL: %w", err)
+	}
 
-	// Load Google OAuth config
-	config.Auth.Google.ClientID = getEnvOrDefault("GOOGLE_CLIENT_ID", "")
-	config.Auth.Google.ClientSecret = getEnvOrDefault("GOOGLE_CLIENT_SECRET", "")
-	config.Auth.Google.RedirectURI = getEnvOrDefault("GOOGLE_REDIRECT_URI", "http://localhost:3000/auth/google/callback")
+	// Load two-factor authentication config
+	config.Auth.MFA.Issuer = getEnvOrDefault("MFA_ISSUER", "Peace")
+
+	// Load login lockout config
+	config.Auth.LoginLockout.EmailThreshold = getEnvAsIntOrDefault("LOGIN_LOCKOUT_EMAIL_THRESHOLD", 5)
+	config.Auth.LoginLockout.IPThreshold = getEnvAsIntOrDefault("LOGIN_LOCKOUT_IP_THRESHOLD", 20)
+	config.Auth.LoginLockout.BaseDelay, err = time.ParseDuration(getEnvOrDefault("LOGIN_LOCKOUT_BASE_DELAY", "30s"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid LOGIN_LOCKOUT_BASE_DELAY: %w", err)
+	}
+	config.Auth.LoginLockout.MaxDelay, err = time.ParseDuration(getEnvOrDefault("LOGIN_LOCKOUT_MAX_DELAY", "1h"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid LOGIN_LOCKOUT_MAX_DELAY: %w", err)
+	}
+	if config.Auth.LoginLockout.MaxDelay < config.Auth.LoginLockout.BaseDelay {
+		return nil, fmt.Errorf("invalid LOGIN_LOCKOUT_MAX_DELAY: shorter than LOGIN_LOCKOUT_BASE_DELAY")
+	}
+	config.Auth.LoginLockout.Window, err = time.ParseDuration(getEnvOrDefault("LOGIN_LOCKOUT_WINDOW", "1h"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid LOGIN_LOCKOUT_WINDOW: %w", err)
+	}
+
+	// Load rate limit config
+	config.RateLimit.Driver = getEnvOrDefault("RATE_LIMIT_DRIVER", "redis")
+	config.RateLimit.Groups, err = loadRateLimitGroups()
+	if err != nil {
+		return nil, err
+	}
+
+	// Load data export config
+	config.Export.Dir = getEnvOrDefault("EXPORT_DIR", "data/exports")
+	config.Export.LinkSecret = getEnvOrDefault("EXPORT_LINK_SECRET", "")
+	// Download links need no other credentials, so a known secret would let anyone fetch an archive
+	if config.Export.LinkSecret == "" || config.Export.LinkSecret == devSecretKey {
+		return nil, fmt.Errorf("invalid EXPORT_LINK_SECRET: a secret of its own is required to sign download links")
+	}
+	config.Export.LinkTTL, err = time.ParseDuration(getEnvOrDefault("EXPORT_LINK_TTL", "15m"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid EXPORT_LINK_TTL: %w", err)
+	}
+	config.Export.ArchiveTTL, err = time.ParseDuration(getEnvOrDefault("EXPORT_ARCHIVE_TTL", "168h"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid EXPORT_ARCHIVE_TTL: %w", err)
+	}
+
+	// Load account retention config
+	config.Retention.GracePeriod, err = time.ParseDuration(getEnvOrDefault("RETENTION_GRACE_PERIOD", "720h"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid RETENTION_GRACE_PERIOD: %w", err)
+	}
+	// Nothing is removed until retention is turned on explicitly, and purging must be asked for by name
+	config.Retention.Mode = strings.ToLower(getEnvOrDefault("RETENTION_MODE", "anonymize"))
+	if config.Retention.Mode != "purge" && config.Retention.Mode != "anonymize" {
+		return nil, fmt.Errorf("invalid RETENTION_MODE: expected purge or anonymize, got %q", config.Retention.Mode)
+	}
+	config.Retention.DryRun = getEnvAsBoolOrDefault("RETENTION_DRY_RUN", true)
+	config.Retention.Interval, err = time.ParseDuration(getEnvOrDefault("RETENTION_INTERVAL", "0"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid RETENTION_INTERVAL: %w", err)
+	}
+	config.Retention.BatchSize = getEnvAsIntOrDefault("RETENTION_BATCH_SIZE", 100)
+
+	// Load quote of the day config
+	config.Quotes.DailyRepeatWindow = getEnvAsIntOrDefault("DAILY_QUOTE_REPEAT_WINDOW", 30)
+	if config.Quotes.DailyRepeatWindow < 0 {
+		return nil, fmt.Errorf("invalid DAILY_QUOTE_REPEAT_WINDOW: cannot be negative")
+	}
+	config.Quotes.DailyCache = strings.ToLower(getEnvOrDefault("DAILY_QUOTE_CACHE", "redis"))
+	if config.Quotes.DailyCache != "redis" && config.Quotes.DailyCache != "off" {
+		return nil, fmt.Errorf("invalid DAILY_QUOTE_CACHE: expected redis or off, got %q", config.Quotes.DailyCache)
+	}
+	config.Quotes.MoodTags, err = loadMoodTagRules()
+	if err != nil {
+		return nil, err
+	}
+
+	// Load mail config; without a reachable SMTP server mails fail rather than land in the logs
+	config.Mail.Driver = getEnvOrDefault("MAIL_DRIVER", "smtp")
+	config.Mail.From = getEnvOrDefault("MAIL_FROM", "Peace <no-reply@peace.local>")
+	config.Mail.SMTP.Host = getEnvOrDefault("SMTP_HOST", "localhost")
+	config.Mail.SMTP.Port = getEnvOrDefault("SMTP_PORT", "587")
+	config.Mail.SMTP.Username = getEnvOrDefault("SMTP_USERNAME", "")
+	config.Mail.SMTP.Password = getEnvOrDefault("SMTP_PASSWORD", "")
 
 	// Load log config
 	config.Log.Level = getEnvOrDefault("LOG_LEVEL", "info")
@@ -217,6 +473,158 @@ func loadFromEnvironment() (*Config, error) {
 	return config, nil
 }
 
+// loadRateLimitGroups reads the rule of each route group from RATE_LIMIT_<GROUP>, written as
+// requests/window ("20/1m"); "0" or "off" disables the group's limit
+func loadRateLimitGroups() (map[string]RateLimitRule, error) {
+	groups := make(map[string]RateLimitRule, len(rateLimitGroups))
+	for group, defaultRule := range rateLimitGroups {
+		key := "RATE_LIMIT_" + strings.ToUpper(group)
+		value := strings.TrimSpace(getEnvOrDefault(key, defaultRule))
+		if value == "0" || strings.EqualFold(value, "off") {
+			groups[group] = RateLimitRule{}
+			continue
+		}
+
+		requests, window, found := strings.Cut(value, "/")
+		if !found {
+			return nil, fmt.Errorf("invalid %s: expected requests/window, got %q", key, value)
+		}
+		rule := RateLimitRule{}
+		var err error
+		if rule.Requests, err = strconv.Atoi(strings.TrimSpace(requests)); err != nil || rule.Requests < 0 {
+			return nil, fmt.Errorf("invalid %s: bad request count %q", key, requests)
+		}
+		if rule.Window, err = time.ParseDuration(strings.TrimSpace(window)); err != nil || rule.Window <= 0 {
+			return nil, fmt.Errorf("invalid %s: bad window %q", key, window)
+		}
+		groups[group] = rule
+	}
+
+	return groups, nil
+}
+
+// loadMoodTagRules reads QUOTE_MOOD_TAGS, rules separated by ";" and written as
+// level:min-max=tag,tag ("energy:1-4=motivation"); "off" disables mood-based picks
+func loadMoodTagRules() ([]MoodTagRule, error) {
+	value := strings.TrimSpace(getEnvOrDefault("QUOTE_MOOD_TAGS", defaultMoodTags))
+	if strings.EqualFold(value, "off") {
+		return nil, nil
+	}
+
+	var rules []MoodTagRule
+	for _, item := range strings.Split(value, ";") {
+		if item = strings.TrimSpace(item); item == "" {
+			continue
+		}
+
+		condition, tags, found := strings.Cut(item, "=")
+		if !found {
+			return nil, fmt.Errorf("invalid QUOTE_MOOD_TAGS: expected level:min-max=tags, got %q", item)
+		}
+		level, levels, found := strings.Cut(condition, ":")
+		if !found {
+			return nil, fmt.Errorf("invalid QUOTE_MOOD_TAGS: expected level:min-max=tags, got %q", item)
+		}
+
+		rule := MoodTagRule{Level: strings.ToLower(strings.TrimSpace(level)), Tags: splitList(tags)}
+		if rule.Level != "happy" && rule.Level != "energy" {
+			return nil, fmt.Errorf("invalid QUOTE_MOOD_TAGS: expected happy or energy level, got %q", level)
+		}
+		minLevel, maxLevel, found := strings.Cut(levels, "-")
+		if !found {
+			return nil, fmt.Errorf("invalid QUOTE_MOOD_TAGS: bad level range %q", levels)
+		}
+		var err error
+		if rule.Min, err = strconv.Atoi(strings.TrimSpace(minLevel)); err != nil {
+			return nil, fmt.Errorf("invalid QUOTE_MOOD_TAGS: bad level range %q", levels)
+		}
+		if rule.Max, err = strconv.Atoi(strings.TrimSpace(maxLevel)); err != nil {
+			return nil, fmt.Errorf("invalid QUOTE_MOOD_TAGS: bad level range %q", levels)
+		}
+		if rule.Min < 1 || rule.Max > 10 || rule.Min > rule.Max {
+			return nil, fmt.Errorf("invalid QUOTE_MOOD_TAGS: level range %q must lie within 1-10", levels)
+		}
+		if len(rule.Tags) == 0 {
+			return nil, fmt.Errorf("invalid QUOTE_MOOD_TAGS: no tags for %q", condition)
+		}
+		rules = append(rules, rule)
+	}
+
+	return rules, nil
+}
+
+// loadOAuthProviders reads the providers listed in OAUTH_PROVIDERS from OAUTH_<NAME>_* variables.
+// GOOGLE_CLIENT_ID keeps configuring Google for deployments that predate generic providers.
+func loadOAuthProviders() ([]OAuthProviderConfig, error) {
+	var providers []OAuthProviderConfig
+
+	for _, name := range splitList(getEnvOrDefault("OAUTH_PROVIDERS", "")) {
+		name = strings.ToLower(name)
+		if len(name) > 20 {
+			return nil, fmt.Errorf("invalid OAUTH_PROVIDERS: provider name %q is longer than 20 characters", name)
+		}
+		if name == "local" {
+			return nil, fmt.Errorf("invalid OAUTH_PROVIDERS: provider name %q is reserved", name)
+		}
+
+		prefix := "OAUTH_" + strings.ToUpper(strings.ReplaceAll(name, "-", "_")) + "_"
+		provider := OAuthProviderConfig{
+			Name:         name,
+			Issuer:       getEnvOrDefault(prefix+"ISSUER", ""),
+			ClientID:     getEnvOrDefault(prefix+"CLIENT_ID", ""),
+			ClientSecret: getEnvOrDefault(prefix+"CLIENT_SECRET", ""),
+			RedirectURI:  getEnvOrDefault(prefix+"REDIRECT_URI", "http://localhost:3000/auth/"+name+"/callback"),
+			Scopes:       splitList(getEnvOrDefault(prefix+"SCOPES", "openid,email,profile")),
+			AuthURL:      getEnvOrDefault(prefix+"AUTH_URL", ""),
+			TokenURL:     getEnvOrDefault(prefix+"TOKEN_URL", ""),
+			UserInfoURL:  getEnvOrDefault(prefix+"USERINFO_URL", ""),
+			EmailsURL:    getEnvOrDefault(prefix+"EMAILS_URL", ""),
+			TrustEmail:   getEnvAsBoolOrDefault(prefix+"TRUST_EMAIL", false),
+		}
+		if provider.ClientID == "" {
+			return nil, fmt.Errorf("invalid OAUTH_PROVIDERS: %sCLIENT_ID is required", prefix)
+		}
+		if provider.Issuer == "" && (provider.AuthURL == "" || provider.TokenURL == "" || provider.UserInfoURL == "") {
+			return nil, fmt.Errorf("invalid OAUTH_PROVIDERS: %sISSUER or explicit endpoints are required", prefix)
+		}
+
+		providers = append(providers, provider)
+	}
+
+	if clientID := getEnvOrDefault("GOOGLE_CLIENT_ID", ""); clientID != "" && !hasOAuthProvider(providers, "google") {
+		providers = append(providers, OAuthProviderConfig{
+			Name:         "google",
+			Issuer:       "https://accounts.google.com",
+			ClientID:     clientID,
+			ClientSecret: getEnvOrDefault("GOOGLE_CLIENT_SECRET", ""),
+			RedirectURI:  getEnvOrDefault("GOOGLE_REDIRECT_URI", "http://localhost:3000/auth/google/callback"),
+			Scopes:       []string{"openid", "email", "profile"},
+		})
+	}
+
+	return providers, nil
+}
+
+func hasOAuthProvider(providers []OAuthProviderConfig, name string) bool {
+	for _, provider := range providers {
+		if provider.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// splitList splits a comma separated value, dropping empty entries
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
 // Helper functions for environment variable parsing
 func getEnvOrDefault(key, defaultValue string) string {
 	if value := os.Getenv(key); value != "" {
@@ -9,6 +9,12 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
+// TestMain gives every test the one secret that has no default, tests of that secret override it
+func TestMain(m *testing.M) {
+	os.Setenv("EXPORT_LINK_SECRET", "a-long-random-export-link-secret")
+	os.Exit(m.Run())
+}
+
 func TestLoadWithPath(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -309,3 +315,230 @@ func TestConfig_Timeouts(t *testing.T) {
 	assert.Equal(t, 30*time.Second, config.Server.WriteTimeout)
 	assert.Equal(t, 60*time.Second, config.Server.IdleTimeout)
 }
+
+func TestLoadOAuthProviders(t *testing.T) {
+	t.Run("generic providers and legacy Google variables", func(t *testing.T) {
+		t.Setenv("OAUTH_PROVIDERS", "Keycloak, github")
+		t.Setenv("OAUTH_KEYCLOAK_ISSUER", "https://sso.example.com/realms/peace")
+		t.Setenv("OAUTH_KEYCLOAK_CLIENT_ID", "peace-web")
+		t.Setenv("OAUTH_GITHUB_CLIENT_ID", "gh-client")
+		t.Setenv("OAUTH_GITHUB_AUTH_URL", "https://github.com/login/oauth/authorize")
+		t.Setenv("OAUTH_GITHUB_TOKEN_URL", "https://github.com/login/oauth/access_token")
+		t.Setenv("OAUTH_GITHUB_USERINFO_URL", "https://api.github.com/user")
+		t.Setenv("OAUTH_GITHUB_SCOPES", "read:user,user:email")
+		t.Setenv("GOOGLE_CLIENT_ID", "google-client")
+
+		providers, err := loadOAuthProviders()
+		require.NoError(t, err)
+		require.Len(t, providers, 3)
+
+		assert.Equal(t, "keycloak", providers[0].Name)
+		assert.Equal(t, "https://sso.example.com/realms/peace", providers[0].Issuer)
+		assert.Equal(t, []string{"openid", "email", "profile"}, providers[0].Scopes)
+		assert.Equal(t, "http://localhost:3000/auth/keycloak/callback", providers[0].RedirectURI)
+
+		assert.Equal(t, "github", providers[1].Name)
+		assert.Equal(t, []string{"read:user", "user:email"}, providers[1].Scopes)
+
+		assert.Equal(t, "google", providers[2].Name)
+		assert.Equal(t, "https://accounts.google.com", providers[2].Issuer)
+	})
+
+	t.Run("missing endpoints", func(t *testing.T) {
+		t.Setenv("OAUTH_PROVIDERS", "corp")
+		t.Setenv("OAUTH_CORP_CLIENT_ID", "client")
+
+		_, err := loadOAuthProviders()
+		assert.Error(t, err)
+	})
+
+	t.Run("name longer than the stored column", func(t *testing.T) {
+		t.Setenv("OAUTH_PROVIDERS", "a-very-long-provider-name")
+
+		_, err := loadOAuthProviders()
+		assert.Error(t, err)
+	})
+}
+
+func TestLoadOAuthStateSecret(t *testing.T) {
+	tests := []struct {
+		name        string
+		secret      string
+		providers   bool
+		wantErr     bool
+		expectedErr string
+	}{
+		{
+			name:      "no providers need no secret",
+			providers: false,
+		},
+		{
+			name:      "providers with a secret",
+			secret:    "a-long-random-state-secret",
+			providers: true,
+		},
+		{
+			name:        "providers without a secret",
+			providers:   true,
+			wantErr:     true,
+			expectedErr: "invalid OAUTH_STATE_SECRET",
+		},
+		{
+			name:        "providers with the development secret",
+			secret:      devSecretKey,
+			providers:   true,
+			wantErr:     true,
+			expectedErr: "invalid OAUTH_STATE_SECRET",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("OAUTH_STATE_SECRET", tt.secret)
+			t.Setenv("OAUTH_PROVIDERS", "")
+			t.Setenv("GOOGLE_CLIENT_ID", "")
+			if tt.providers {
+				t.Setenv("GOOGLE_CLIENT_ID", "google-client")
+			}
+
+			config, err := loadFromEnvironment()
+
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedErr)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.secret, config.Auth.OAuth.StateSecret)
+			}
+		})
+	}
+}
+
+func TestLoadExportLinkSecret(t *testing.T) {
+	tests := []struct {
+		name        string
+		secret      string
+		wantErr     bool
+		expectedErr string
+	}{
+		{
+			name:   "a secret of its own",
+			secret: "a-long-random-export-link-secret",
+		},
+		{
+			name:        "no secret",
+			wantErr:     true,
+			expectedErr: "invalid EXPORT_LINK_SECRET",
+		},
+		{
+			name:        "the development secret",
+			secret:      devSecretKey,
+			wantErr:     true,
+			expectedErr: "invalid EXPORT_LINK_SECRET",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("EXPORT_LINK_SECRET", tt.secret)
+
+			config, err := loadFromEnvironment()
+
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedErr)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.secret, config.Export.LinkSecret)
+			}
+		})
+	}
+}
+
+func TestLoadRetention(t *testing.T) {
+	t.Run("nothing is removed by default", func(t *testing.T) {
+		t.Setenv("RETENTION_MODE", "")
+		t.Setenv("RETENTION_DRY_RUN", "")
+		t.Setenv("RETENTION_INTERVAL", "")
+
+		config, err := loadFromEnvironment()
+		require.NoError(t, err)
+		assert.Equal(t, "anonymize", config.Retention.Mode)
+		assert.True(t, config.Retention.DryRun)
+		assert.Zero(t, config.Retention.Interval)
+	})
+
+	t.Run("purge is configured explicitly", func(t *testing.T) {
+		t.Setenv("RETENTION_MODE", "purge")
+		t.Setenv("RETENTION_DRY_RUN", "false")
+		t.Setenv("RETENTION_INTERVAL", "1h")
+
+		config, err := loadFromEnvironment()
+		require.NoError(t, err)
+		assert.Equal(t, "purge", config.Retention.Mode)
+		assert.False(t, config.Retention.DryRun)
+		assert.Equal(t, time.Hour, config.Retention.Interval)
+	})
+}
+
+func TestLoadRateLimitGroups(t *testing.T) {
+	t.Run("defaults and overrides", func(t *testing.T) {
+		t.Setenv("RATE_LIMIT_AUTH", "5/30s")
+		t.Setenv("RATE_LIMIT_TAGS", "off")
+
+		groups, err := loadRateLimitGroups()
+		require.NoError(t, err)
+		assert.Len(t, groups, len(rateLimitGroups))
+		assert.Equal(t, RateLimitRule{Requests: 5, Window: 30 * time.Second}, groups["auth"])
+		assert.Equal(t, RateLimitRule{Requests: 120, Window: time.Minute}, groups["records"])
+		assert.Equal(t, RateLimitRule{}, groups["tags"])
+	})
+
+	for _, value := range []string{"20", "x/1m", "20/soon", "-1/1m", "20/0s"} {
+		t.Run("invalid "+value, func(t *testing.T) {
+			t.Setenv("RATE_LIMIT_USER", value)
+
+			_, err := loadRateLimitGroups()
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestLoadMoodTagRules(t *testing.T) {
+	t.Run("defaults", func(t *testing.T) {
+		rules, err := loadMoodTagRules()
+		require.NoError(t, err)
+		assert.Equal(t, []MoodTagRule{
+			{Level: "energy", Min: 1, Max: 4, Tags: []string{"motivation"}},
+			{Level: "happy", Min: 1, Max: 4, Tags: []string{"hope"}},
+		}, rules)
+	})
+
+	t.Run("overrides", func(t *testing.T) {
+		t.Setenv("QUOTE_MOOD_TAGS", " Energy:1-3 = motivation, energy ; happy:8-10=gratitude;")
+
+		rules, err := loadMoodTagRules()
+		require.NoError(t, err)
+		assert.Equal(t, []MoodTagRule{
+			{Level: "energy", Min: 1, Max: 3, Tags: []string{"motivation", "energy"}},
+			{Level: "happy", Min: 8, Max: 10, Tags: []string{"gratitude"}},
+		}, rules)
+	})
+
+	t.Run("off", func(t *testing.T) {
+		t.Setenv("QUOTE_MOOD_TAGS", "off")
+
+		rules, err := loadMoodTagRules()
+		require.NoError(t, err)
+		assert.Empty(t, rules)
+	})
+
+	for _, value := range []string{"energy:1-4", "energy=hope", "sleep:1-4=rest", "happy:0-4=hope", "happy:5-3=hope", "happy:1-x=hope", "happy:1-4="} {
+		t.Run("invalid "+value, func(t *testing.T) {
+			t.Setenv("QUOTE_MOOD_TAGS", value)
+
+			_, err := loadMoodTagRules()
+			assert.Error(t, err)
+		})
+	}
+}
@@ -17,7 +17,22 @@ type DatabaseManager struct {
 }
 
 func NewDatabaseManager(cfg *config.Config) (*DatabaseManager, error) {
+	dm, err := ConnectDatabaseManager(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	// Run migrations using Goose with versioning
+	if err := runGooseMigrations(dm.Postgres); err != nil {
+		return nil, fmt.Errorf("failed to run migrations: %w", err)
+	}
 
+	return dm, nil
+}
+
+// ConnectDatabaseManager connects without running migrations, for processes that rely on the schema
+// migrated by the HTTP server
+func ConnectDatabaseManager(cfg *config.Config) (*DatabaseManager, error) {
 	// Initialize PostgreSQL
 	postgresDB, err := gorm.Open(gormPostgres.Open(cfg.GetPostgresDSN()), &gorm.Config{})
 	if err != nil {
@@ -33,11 +48,6 @@ func NewDatabaseManager(cfg *config.Config) (*DatabaseManager, error) {
 	sqlDB.SetMaxIdleConns(cfg.Database.Postgres.MaxIdleConns)
 	sqlDB.SetConnMaxLifetime(cfg.Database.Postgres.ConnMaxLifetime)
 
-	// Run migrations using Goose with versioning
-	if err := runGooseMigrations(postgresDB); err != nil {
-		return nil, fmt.Errorf("failed to run migrations: %w", err)
-	}
-
 	return &DatabaseManager{
 		Postgres: postgresDB,
 	}, nil
@@ -0,0 +1,18 @@
+package models
+
+import (
+	"time"
+)
+
+type AccountRestoration struct {
+	ID            string    `gorm:"primaryKey" json:"id"`
+	UserID        string    `gorm:"not null;index" json:"user_id"`
+	UserDeletedAt time.Time `gorm:"not null" json:"user_deleted_at"`
+	RestoredAt    time.Time `json:"restored_at"`
+	IPAddress     string    `gorm:"column:ip_address;type:varchar(64)" json:"ip_address"`
+	UserAgent     string    `gorm:"type:varchar(255)" json:"user_agent"`
+}
+
+func (r *AccountRestoration) TableName() string {
+	return "account_restorations"
+}
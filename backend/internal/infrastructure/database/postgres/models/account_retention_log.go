@@ -0,0 +1,20 @@
+package models
+
+import (
+	"time"
+)
+
+type AccountRetentionLog struct {
+	ID            string    `gorm:"primaryKey" json:"id"`
+	UserID        string    `gorm:"not null" json:"user_id"`
+	Action        string    `gorm:"type:varchar(20);not null" json:"action"`
+	UserDeletedAt time.Time `gorm:"not null" json:"user_deleted_at"`
+	RecordsCount  int64     `gorm:"not null;default:0" json:"records_count"`
+	SessionsCount int64     `gorm:"not null;default:0" json:"sessions_count"`
+	ExportsCount  int64     `gorm:"not null;default:0" json:"exports_count"`
+	ProcessedAt   time.Time `gorm:"index" json:"processed_at"`
+}
+
+func (l *AccountRetentionLog) TableName() string {
+	return "account_retention_log"
+}
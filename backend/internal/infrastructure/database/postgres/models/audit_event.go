@@ -0,0 +1,21 @@
+package models
+
+import (
+	"time"
+)
+
+type AuditEvent struct {
+	ID         string    `gorm:"primaryKey" json:"id"`
+	ActorID    *string   `gorm:"index" json:"actor_id,omitempty"`
+	Action     string    `gorm:"type:varchar(64);not null;index" json:"action"`
+	TargetType string    `gorm:"type:varchar(32)" json:"target_type"`
+	TargetID   string    `gorm:"type:varchar(64)" json:"target_id"`
+	IPAddress  string    `gorm:"column:ip_address;type:varchar(64)" json:"ip_address"`
+	UserAgent  string    `gorm:"type:varchar(255)" json:"user_agent"`
+	Metadata   string    `gorm:"type:jsonb;not null;default:'{}'" json:"metadata"`
+	CreatedAt  time.Time `gorm:"index" json:"created_at"`
+}
+
+func (e *AuditEvent) TableName() string {
+	return "audit_events"
+}
@@ -0,0 +1,17 @@
+package models
+
+import (
+	"time"
+)
+
+type DailyQuote struct {
+	ID        int       `gorm:"primaryKey" json:"id"`
+	UserID    *string   `gorm:"uniqueIndex:idx_daily_quotes_user_day,where:user_id IS NOT NULL" json:"user_id,omitempty"`
+	Day       time.Time `gorm:"type:date;not null;uniqueIndex:idx_daily_quotes_user_day,where:user_id IS NOT NULL;uniqueIndex:idx_daily_quotes_global_day,where:user_id IS NULL" json:"day"`
+	QuoteID   int       `gorm:"not null" json:"quote_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func (d *DailyQuote) TableName() string {
+	return "daily_quotes"
+}
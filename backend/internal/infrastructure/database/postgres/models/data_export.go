@@ -0,0 +1,21 @@
+package models
+
+import (
+	"time"
+)
+
+type DataExport struct {
+	ID          string     `gorm:"primaryKey" json:"id"`
+	UserID      string     `gorm:"not null;index" json:"user_id"`
+	Status      string     `gorm:"type:varchar(20);not null;index" json:"status"`
+	SizeBytes   int64      `gorm:"not null;default:0" json:"size_bytes"`
+	Error       *string    `gorm:"type:varchar(255)" json:"error,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	StartedAt   *time.Time `json:"started_at,omitempty"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+	ExpiresAt   *time.Time `json:"expires_at,omitempty"`
+}
+
+func (d *DataExport) TableName() string {
+	return "data_exports"
+}
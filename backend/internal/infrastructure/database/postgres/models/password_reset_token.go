@@ -0,0 +1,18 @@
+package models
+
+import (
+	"time"
+)
+
+type PasswordResetToken struct {
+	ID        string     `gorm:"primaryKey" json:"id"`
+	UserID    string     `gorm:"not null;index" json:"user_id"`
+	TokenHash string     `gorm:"not null;uniqueIndex" json:"-"`
+	ExpiresAt time.Time  `gorm:"not null" json:"expires_at"`
+	UsedAt    *time.Time `json:"used_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+func (p *PasswordResetToken) TableName() string {
+	return "password_reset_tokens"
+}
@@ -0,0 +1,20 @@
+package models
+
+import (
+	"time"
+)
+
+type PersonalAccessToken struct {
+	ID         string     `gorm:"primaryKey" json:"id"`
+	UserID     string     `gorm:"not null;index" json:"user_id"`
+	Name       string     `gorm:"type:varchar(100);not null" json:"name"`
+	TokenHash  string     `gorm:"not null;uniqueIndex" json:"-"`
+	Scopes     string     `gorm:"type:varchar(255);not null" json:"scopes"` // space separated
+	ExpiresAt  time.Time  `gorm:"not null" json:"expires_at"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+func (p *PersonalAccessToken) TableName() string {
+	return "personal_access_tokens"
+}
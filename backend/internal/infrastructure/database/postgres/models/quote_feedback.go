@@ -0,0 +1,17 @@
+package models
+
+import (
+	"time"
+)
+
+type QuoteFeedback struct {
+	UserID    string    `gorm:"type:uuid;primaryKey" json:"user_id"`
+	QuoteID   int       `gorm:"primaryKey" json:"quote_id"`
+	Signal    string    `gorm:"type:varchar(16);not null" json:"signal"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func (f *QuoteFeedback) TableName() string {
+	return "quote_feedback"
+}
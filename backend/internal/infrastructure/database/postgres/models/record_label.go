@@ -0,0 +1,17 @@
+package models
+
+import (
+	"time"
+)
+
+// RecordLabel is a label a user puts on their own records, unrelated to quote tags
+type RecordLabel struct {
+	ID        int       `gorm:"primaryKey;autoIncrement" json:"id"`
+	UserID    string    `gorm:"type:uuid;not null;uniqueIndex:idx_record_labels_user_name" json:"user_id"`
+	Name      string    `gorm:"type:varchar(100);not null;uniqueIndex:idx_record_labels_user_name" json:"name"`
+	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
+}
+
+func (l *RecordLabel) TableName() string {
+	return "record_labels"
+}
@@ -0,0 +1,17 @@
+package models
+
+import (
+	"time"
+)
+
+// RecordTag links a record to one of its owner's labels
+type RecordTag struct {
+	ID        int       `gorm:"primaryKey;autoIncrement" json:"id"`
+	RecordID  string    `gorm:"not null;index" json:"record_id"`
+	LabelID   int       `gorm:"not null;index" json:"label_id"`
+	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
+}
+
+func (rt *RecordTag) TableName() string {
+	return "record_tags"
+}
@@ -0,0 +1,20 @@
+package models
+
+import (
+	"time"
+)
+
+type RefreshToken struct {
+	ID        string     `gorm:"primaryKey" json:"id"`
+	FamilyID  string     `gorm:"not null;index" json:"family_id"`
+	UserID    string     `gorm:"not null;index" json:"user_id"`
+	Device    string     `gorm:"type:varchar(255)" json:"device"`
+	ExpiresAt time.Time  `gorm:"not null" json:"expires_at"`
+	UsedAt    *time.Time `json:"used_at,omitempty"`
+	RevokedAt *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+func (r *RefreshToken) TableName() string {
+	return "refresh_tokens"
+}
@@ -0,0 +1,20 @@
+package models
+
+import (
+	"time"
+)
+
+type Session struct {
+	ID         string     `gorm:"primaryKey" json:"id"`
+	UserID     string     `gorm:"not null;index" json:"user_id"`
+	UserAgent  string     `gorm:"type:varchar(255)" json:"user_agent"`
+	IPAddress  string     `gorm:"column:ip_address;type:varchar(64)" json:"ip_address"`
+	Provider   string     `gorm:"type:varchar(20);not null" json:"provider"`
+	CreatedAt  time.Time  `json:"created_at"`
+	LastUsedAt time.Time  `gorm:"not null" json:"last_used_at"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+}
+
+func (s *Session) TableName() string {
+	return "sessions"
+}
@@ -0,0 +1,17 @@
+package models
+
+import (
+	"time"
+)
+
+type TOTPFactor struct {
+	UserID       string     `gorm:"primaryKey" json:"user_id"`
+	Secret       string     `gorm:"type:varchar(64);not null" json:"-"`
+	ConfirmedAt  *time.Time `json:"confirmed_at,omitempty"`
+	LastUsedStep int64      `gorm:"not null;default:0" json:"last_used_step"`
+	CreatedAt    time.Time  `json:"created_at"`
+}
+
+func (t *TOTPFactor) TableName() string {
+	return "totp_factors"
+}
@@ -10,13 +10,17 @@ type User struct {
 	Username      string     `db:"username"`
 	FirstName     *string    `db:"first_name"`
 	LastName      *string    `db:"last_name"`
-	PasswordHash  string     `db:"password_hash"`
+	PasswordHash  *string    `db:"password_hash"`
 	IsActive      bool       `db:"is_active"`
 	EmailVerified bool       `db:"email_verified"`
 	AuthProvider  string     `db:"auth_provider"`
-	GoogleID      *string    `db:"google_id"`
-	GooglePicture *string    `db:"google_picture"`
+	PictureURL    *string    `db:"picture_url"`
+	Timezone      string     `db:"timezone"`
+	FeedOptOut    bool       `db:"feed_opt_out"`
+	Role          string     `db:"role"`
 	CreatedAt     time.Time  `db:"created_at"`
 	UpdatedAt     time.Time  `db:"updated_at"`
 	DeletedAt     *time.Time `db:"deleted_at"`
+	AnonymizedAt  *time.Time `db:"anonymized_at"`
+	Version       int        `db:"version"`
 }
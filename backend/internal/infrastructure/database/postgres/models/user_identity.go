@@ -0,0 +1,17 @@
+package models
+
+import (
+	"time"
+)
+
+type UserIdentity struct {
+	Provider  string    `gorm:"primaryKey;type:varchar(20);uniqueIndex:idx_user_identities_user_provider,priority:2" json:"provider"`
+	Subject   string    `gorm:"primaryKey;type:varchar(255)" json:"subject"`
+	UserID    string    `gorm:"not null;uniqueIndex:idx_user_identities_user_provider,priority:1" json:"user_id"`
+	Email     string    `gorm:"type:varchar(255)" json:"email"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func (i *UserIdentity) TableName() string {
+	return "user_identities"
+}
@@ -0,0 +1,136 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/atdevten/peace/internal/domain/entities"
+	"github.com/atdevten/peace/internal/domain/repositories"
+	"github.com/atdevten/peace/internal/domain/value_objects"
+	"github.com/atdevten/peace/internal/infrastructure/database/postgres/models"
+
+	"gorm.io/gorm"
+)
+
+type PostgreSQLAuditEventRepository struct {
+	db *gorm.DB
+}
+
+func NewPostgreSQLAuditEventRepository(db *gorm.DB) repositories.AuditEventRepository {
+	return &PostgreSQLAuditEventRepository{
+		db: db,
+	}
+}
+
+func (r *PostgreSQLAuditEventRepository) Create(ctx context.Context, event *entities.AuditEvent) error {
+	metadata, err := json.Marshal(event.Metadata())
+	if err != nil {
+		return fmt.Errorf("json.Marshal: %w", err)
+	}
+
+	model := models.AuditEvent{
+		ID:         event.ID().String(),
+		Action:     event.Action().String(),
+		TargetType: event.TargetType(),
+		TargetID:   event.TargetID(),
+		IPAddress:  event.IPAddress(),
+		UserAgent:  event.UserAgent(),
+		Metadata:   string(metadata),
+		CreatedAt:  event.CreatedAt(),
+	}
+	if event.ActorID() != nil {
+		actorID := event.ActorID().String()
+		model.ActorID = &actorID
+	}
+
+	if err := r.db.WithContext(ctx).Create(&model).Error; err != nil {
+		return fmt.Errorf("r.db.Create: %w", err)
+	}
+	return nil
+}
+
+func (r *PostgreSQLAuditEventRepository) List(ctx context.Context, filter *repositories.AuditEventFilter) ([]*entities.AuditEvent, error) {
+	var eventModels []models.AuditEvent
+
+	query := r.db.WithContext(ctx).Model(&models.AuditEvent{})
+
+	if filter.ActorID != nil {
+		query = query.Where("actor_id = ?", filter.ActorID.String())
+	}
+	if filter.Action != nil {
+		query = query.Where("action = ?", filter.Action.String())
+	}
+	if filter.TargetType != nil {
+		query = query.Where("target_type = ?", *filter.TargetType)
+	}
+	if filter.TargetID != nil {
+		query = query.Where("target_id = ?", *filter.TargetID)
+	}
+	if filter.From != nil {
+		query = query.Where("created_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("created_at < ?", *filter.To)
+	}
+
+	// Keyset pagination: continue strictly before the cursor
+	if filter.Cursor != nil {
+		query = query.Where("(created_at < ? OR (created_at = ? AND id < ?))", filter.Cursor.CreatedAt, filter.Cursor.CreatedAt, filter.Cursor.ID)
+	}
+
+	err := query.
+		Order("created_at DESC").
+		Order("id DESC").
+		Limit(filter.Limit).
+		Find(&eventModels).Error
+	if err != nil {
+		return nil, fmt.Errorf("r.db.Find: %w", err)
+	}
+
+	events := make([]*entities.AuditEvent, 0, len(eventModels))
+	for _, model := range eventModels {
+		event, err := r.modelToEntity(model)
+		if err != nil {
+			return nil, fmt.Errorf("modelToEntity: %w", err)
+		}
+		events = append(events, event)
+	}
+
+	return events, nil
+}
+
+// Helper method to convert model to entity
+func (r *PostgreSQLAuditEventRepository) modelToEntity(model models.AuditEvent) (*entities.AuditEvent, error) {
+	id, err := value_objects.NewTokenIDFromString(model.ID)
+	if err != nil {
+		return nil, fmt.Errorf("value_objects.NewTokenIDFromString: %w", err)
+	}
+
+	var actorID *value_objects.UserID
+	if model.ActorID != nil {
+		actorID, err = value_objects.NewUserIDFromString(*model.ActorID)
+		if err != nil {
+			return nil, fmt.Errorf("value_objects.NewUserIDFromString: %w", err)
+		}
+	}
+
+	metadata := map[string]interface{}{}
+	if model.Metadata != "" {
+		if err := json.Unmarshal([]byte(model.Metadata), &metadata); err != nil {
+			return nil, fmt.Errorf("json.Unmarshal: %w", err)
+		}
+	}
+
+	return entities.NewAuditEventFromRepository(
+		id,
+		actorID,
+		value_objects.AuditAction(model.Action),
+		model.TargetType,
+		model.TargetID,
+		model.IPAddress,
+		model.UserAgent,
+		metadata,
+		model.CreatedAt,
+	), nil
+}
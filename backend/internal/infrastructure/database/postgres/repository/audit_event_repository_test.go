@@ -0,0 +1,158 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/atdevten/peace/internal/domain/entities"
+	"github.com/atdevten/peace/internal/domain/repositories"
+	"github.com/atdevten/peace/internal/domain/value_objects"
+	"github.com/atdevten/peace/internal/infrastructure/database/postgres/models"
+	"github.com/atdevten/peace/testutils/helpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// setupAuditEventTestDB creates an in-memory SQLite database for audit event testing
+func setupAuditEventTestDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	require.NoError(t, err)
+
+	err = db.AutoMigrate(&models.AuditEvent{})
+	require.NoError(t, err)
+
+	return db
+}
+
+func createTestAuditEvent(t *testing.T, actorID *value_objects.UserID, action value_objects.AuditAction, targetType string, targetID string) *entities.AuditEvent {
+	event, err := entities.NewAuditEvent(actorID, action, targetType, targetID, "203.0.113.7", "Mozilla/5.0", map[string]interface{}{"provider": "local"})
+	require.NoError(t, err)
+	return event
+}
+
+func TestPostgreSQLAuditEventRepository_CreateAndList(t *testing.T) {
+	db := setupAuditEventTestDB(t)
+	repo := NewPostgreSQLAuditEventRepository(db)
+	ctx := context.Background()
+
+	actorID := helpers.CreateTestUserID()
+	login := createTestAuditEvent(t, actorID, value_objects.AuditActionLogin, entities.AuditTargetUser, actorID.String())
+	require.NoError(t, repo.Create(ctx, login))
+	time.Sleep(10 * time.Millisecond)
+	deleted := createTestAuditEvent(t, nil, value_objects.AuditActionQuoteDeleted, entities.AuditTargetQuote, "42")
+	require.NoError(t, repo.Create(ctx, deleted))
+
+	events, err := repo.List(ctx, &repositories.AuditEventFilter{Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+
+	// Newest first, with anonymous actors and metadata preserved
+	assert.Equal(t, deleted.ID().String(), events[0].ID().String())
+	assert.Nil(t, events[0].ActorID())
+	assert.Equal(t, value_objects.AuditActionQuoteDeleted, events[0].Action())
+	assert.Equal(t, "42", events[0].TargetID())
+	assert.Equal(t, login.ID().String(), events[1].ID().String())
+	assert.Equal(t, actorID.String(), events[1].ActorID().String())
+	assert.Equal(t, "203.0.113.7", events[1].IPAddress())
+	assert.Equal(t, "Mozilla/5.0", events[1].UserAgent())
+	assert.Equal(t, "local", events[1].Metadata()["provider"])
+}
+
+func TestPostgreSQLAuditEventRepository_ListFilters(t *testing.T) {
+	db := setupAuditEventTestDB(t)
+	repo := NewPostgreSQLAuditEventRepository(db)
+	ctx := context.Background()
+
+	actorID := helpers.CreateTestUserID()
+	otherID := helpers.CreateTestUserID()
+	events := []*entities.AuditEvent{
+		createTestAuditEvent(t, actorID, value_objects.AuditActionLogin, entities.AuditTargetUser, actorID.String()),
+		createTestAuditEvent(t, actorID, value_objects.AuditActionQuoteDeleted, entities.AuditTargetQuote, "7"),
+		createTestAuditEvent(t, otherID, value_objects.AuditActionLogin, entities.AuditTargetUser, otherID.String()),
+	}
+	for _, event := range events {
+		require.NoError(t, repo.Create(ctx, event))
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	action := value_objects.AuditActionLogin
+	targetType, targetID := entities.AuditTargetQuote, "7"
+	from := events[1].CreatedAt()
+	to := events[2].CreatedAt()
+
+	tests := []struct {
+		name    string
+		filter  repositories.AuditEventFilter
+		wantIDs []string
+	}{
+		{
+			name:    "by actor",
+			filter:  repositories.AuditEventFilter{ActorID: actorID},
+			wantIDs: []string{events[1].ID().String(), events[0].ID().String()},
+		},
+		{
+			name:    "by action",
+			filter:  repositories.AuditEventFilter{Action: &action},
+			wantIDs: []string{events[2].ID().String(), events[0].ID().String()},
+		},
+		{
+			name:    "by target",
+			filter:  repositories.AuditEventFilter{TargetType: &targetType, TargetID: &targetID},
+			wantIDs: []string{events[1].ID().String()},
+		},
+		{
+			name:    "by time range",
+			filter:  repositories.AuditEventFilter{From: &from, To: &to},
+			wantIDs: []string{events[1].ID().String()},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.filter.Limit = 10
+			found, err := repo.List(ctx, &tt.filter)
+			require.NoError(t, err)
+
+			ids := make([]string, 0, len(found))
+			for _, event := range found {
+				ids = append(ids, event.ID().String())
+			}
+			assert.Equal(t, tt.wantIDs, ids)
+		})
+	}
+}
+
+func TestPostgreSQLAuditEventRepository_ListPages(t *testing.T) {
+	db := setupAuditEventTestDB(t)
+	repo := NewPostgreSQLAuditEventRepository(db)
+	ctx := context.Background()
+
+	var created []*entities.AuditEvent
+	for i := 0; i < 3; i++ {
+		event := createTestAuditEvent(t, nil, value_objects.AuditActionLoginFailed, "", "")
+		require.NoError(t, repo.Create(ctx, event))
+		created = append(created, event)
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	firstPage, err := repo.List(ctx, &repositories.AuditEventFilter{Limit: 2})
+	require.NoError(t, err)
+	require.Len(t, firstPage, 2)
+	assert.Equal(t, created[2].ID().String(), firstPage[0].ID().String())
+	assert.Equal(t, created[1].ID().String(), firstPage[1].ID().String())
+
+	last := firstPage[len(firstPage)-1]
+	secondPage, err := repo.List(ctx, &repositories.AuditEventFilter{
+		Cursor: &repositories.AuditEventCursor{CreatedAt: last.CreatedAt(), ID: last.ID().String()},
+		Limit:  2,
+	})
+	require.NoError(t, err)
+	require.Len(t, secondPage, 1)
+	assert.Equal(t, created[0].ID().String(), secondPage[0].ID().String())
+}
@@ -0,0 +1,115 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/atdevten/peace/internal/domain/entities"
+	"github.com/atdevten/peace/internal/domain/repositories"
+	"github.com/atdevten/peace/internal/domain/value_objects"
+	"github.com/atdevten/peace/internal/infrastructure/database/postgres/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type PostgreSQLDailyQuoteRepository struct {
+	db *gorm.DB
+}
+
+func NewPostgreSQLDailyQuoteRepository(db *gorm.DB) repositories.DailyQuoteRepository {
+	return &PostgreSQLDailyQuoteRepository{
+		db: db,
+	}
+}
+
+func (r *PostgreSQLDailyQuoteRepository) GetByDay(ctx context.Context, userID *value_objects.UserID, day time.Time) (*entities.DailyQuote, error) {
+	var model models.DailyQuote
+
+	err := r.ownerQuery(ctx, userID).
+		Where("day = ?", day).
+		First(&model).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, repositories.ErrDailyQuoteNotFound
+		}
+		return nil, fmt.Errorf("r.db.First: %w", err)
+	}
+
+	return r.modelToEntity(model)
+}
+
+func (r *PostgreSQLDailyQuoteRepository) ListQuoteIDsSince(ctx context.Context, userID *value_objects.UserID, since time.Time) ([]*value_objects.QuoteID, error) {
+	var quoteIDs []int
+
+	err := r.ownerQuery(ctx, userID).
+		Where("day >= ?", since).
+		Order("day DESC").
+		Pluck("quote_id", &quoteIDs).Error
+	if err != nil {
+		return nil, fmt.Errorf("r.db.Pluck: %w", err)
+	}
+
+	ids := make([]*value_objects.QuoteID, 0, len(quoteIDs))
+	for _, id := range quoteIDs {
+		ids = append(ids, value_objects.NewQuoteIDFromInt(id))
+	}
+
+	return ids, nil
+}
+
+func (r *PostgreSQLDailyQuoteRepository) Save(ctx context.Context, dailyQuote *entities.DailyQuote) error {
+	model := models.DailyQuote{
+		Day:       dailyQuote.Day(),
+		QuoteID:   dailyQuote.QuoteID().Value(),
+		CreatedAt: dailyQuote.CreatedAt(),
+	}
+
+	// Each owner has its own partial unique index, which the conflict target must name
+	conflict := clause.OnConflict{
+		Columns:     []clause.Column{{Name: "day"}},
+		TargetWhere: clause.Where{Exprs: []clause.Expression{clause.Expr{SQL: "user_id IS NULL"}}},
+		DoUpdates:   clause.AssignmentColumns([]string{"quote_id", "created_at"}),
+	}
+	if dailyQuote.UserID() != nil {
+		userID := dailyQuote.UserID().String()
+		model.UserID = &userID
+		conflict.Columns = []clause.Column{{Name: "user_id"}, {Name: "day"}}
+		conflict.TargetWhere = clause.Where{Exprs: []clause.Expression{clause.Expr{SQL: "user_id IS NOT NULL"}}}
+	}
+
+	if err := r.db.WithContext(ctx).Clauses(conflict).Create(&model).Error; err != nil {
+		return fmt.Errorf("r.db.Create: %w", err)
+	}
+	return nil
+}
+
+// ownerQuery selects the picks of the user, or the global picks when userID is nil
+func (r *PostgreSQLDailyQuoteRepository) ownerQuery(ctx context.Context, userID *value_objects.UserID) *gorm.DB {
+	query := r.db.WithContext(ctx).Model(&models.DailyQuote{})
+	if userID == nil {
+		return query.Where("user_id IS NULL")
+	}
+	return query.Where("user_id = ?", userID.String())
+}
+
+// Helper method to convert model to entity
+func (r *PostgreSQLDailyQuoteRepository) modelToEntity(model models.DailyQuote) (*entities.DailyQuote, error) {
+	var userID *value_objects.UserID
+	if model.UserID != nil {
+		var err error
+		userID, err = value_objects.NewUserIDFromString(*model.UserID)
+		if err != nil {
+			return nil, fmt.Errorf("value_objects.NewUserIDFromString: %w", err)
+		}
+	}
+
+	return entities.NewDailyQuoteFromRepository(
+		userID,
+		model.Day,
+		value_objects.NewQuoteIDFromInt(model.QuoteID),
+		model.CreatedAt,
+	), nil
+}
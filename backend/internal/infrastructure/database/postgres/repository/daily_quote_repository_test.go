@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/atdevten/peace/internal/domain/entities"
+	"github.com/atdevten/peace/internal/domain/repositories"
+	"github.com/atdevten/peace/internal/domain/value_objects"
+	"github.com/atdevten/peace/internal/infrastructure/database/postgres/models"
+	"github.com/atdevten/peace/testutils/helpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// setupDailyQuoteTestDB creates an in-memory SQLite database for daily quote testing
+func setupDailyQuoteTestDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	require.NoError(t, err)
+
+	err = db.AutoMigrate(&models.DailyQuote{})
+	require.NoError(t, err)
+
+	return db
+}
+
+func saveTestDailyQuote(t *testing.T, repo repositories.DailyQuoteRepository, userID *value_objects.UserID, day time.Time, quoteID int) {
+	dailyQuote, err := entities.NewDailyQuote(userID, day, value_objects.NewQuoteIDFromInt(quoteID))
+	require.NoError(t, err)
+	require.NoError(t, repo.Save(context.Background(), dailyQuote))
+}
+
+func TestPostgreSQLDailyQuoteRepository_SaveAndGetByDay(t *testing.T) {
+	db := setupDailyQuoteTestDB(t)
+	repo := NewPostgreSQLDailyQuoteRepository(db)
+	ctx := context.Background()
+
+	userID := helpers.CreateTestUserID()
+	day := time.Date(2025, 3, 14, 0, 0, 0, 0, time.UTC)
+	saveTestDailyQuote(t, repo, userID, day, 7)
+	saveTestDailyQuote(t, repo, nil, day, 9)
+
+	// The user and the global quote of the same day are kept apart
+	found, err := repo.GetByDay(ctx, userID, day)
+	require.NoError(t, err)
+	assert.Equal(t, 7, found.QuoteID().Value())
+	assert.Equal(t, userID.String(), found.UserID().String())
+
+	global, err := repo.GetByDay(ctx, nil, day)
+	require.NoError(t, err)
+	assert.Equal(t, 9, global.QuoteID().Value())
+	assert.Nil(t, global.UserID())
+
+	// Saving again replaces the pick of the day
+	saveTestDailyQuote(t, repo, userID, day, 8)
+	saveTestDailyQuote(t, repo, nil, day, 10)
+
+	found, err = repo.GetByDay(ctx, userID, day)
+	require.NoError(t, err)
+	assert.Equal(t, 8, found.QuoteID().Value())
+	global, err = repo.GetByDay(ctx, nil, day)
+	require.NoError(t, err)
+	assert.Equal(t, 10, global.QuoteID().Value())
+
+	_, err = repo.GetByDay(ctx, userID, day.AddDate(0, 0, 1))
+	assert.ErrorIs(t, err, repositories.ErrDailyQuoteNotFound)
+}
+
+func TestPostgreSQLDailyQuoteRepository_ListQuoteIDsSince(t *testing.T) {
+	db := setupDailyQuoteTestDB(t)
+	repo := NewPostgreSQLDailyQuoteRepository(db)
+	ctx := context.Background()
+
+	userID := helpers.CreateTestUserID()
+	otherID := helpers.CreateTestUserID()
+	day := time.Date(2025, 3, 14, 0, 0, 0, 0, time.UTC)
+	saveTestDailyQuote(t, repo, userID, day.AddDate(0, 0, -3), 1)
+	saveTestDailyQuote(t, repo, userID, day.AddDate(0, 0, -1), 2)
+	saveTestDailyQuote(t, repo, userID, day, 3)
+	saveTestDailyQuote(t, repo, otherID, day, 4)
+	saveTestDailyQuote(t, repo, nil, day, 5)
+
+	ids, err := repo.ListQuoteIDsSince(ctx, userID, day.AddDate(0, 0, -2))
+	require.NoError(t, err)
+
+	values := make([]int, 0, len(ids))
+	for _, id := range ids {
+		values = append(values, id.Value())
+	}
+	assert.Equal(t, []int{3, 2}, values)
+
+	global, err := repo.ListQuoteIDsSince(ctx, nil, day)
+	require.NoError(t, err)
+	require.Len(t, global, 1)
+	assert.Equal(t, 5, global[0].Value())
+}
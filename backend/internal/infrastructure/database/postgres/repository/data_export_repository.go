@@ -0,0 +1,191 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/atdevten/peace/internal/domain/entities"
+	"github.com/atdevten/peace/internal/domain/repositories"
+	"github.com/atdevten/peace/internal/domain/value_objects"
+	"github.com/atdevten/peace/internal/infrastructure/database/postgres/models"
+
+	"gorm.io/gorm"
+)
+
+// maxClaimAttempts bounds how often ClaimNext retries after losing a race to another worker
+const maxClaimAttempts = 5
+
+type PostgreSQLDataExportRepository struct {
+	db *gorm.DB
+}
+
+func NewPostgreSQLDataExportRepository(db *gorm.DB) repositories.DataExportRepository {
+	return &PostgreSQLDataExportRepository{
+		db: db,
+	}
+}
+
+func (r *PostgreSQLDataExportRepository) Create(ctx context.Context, export *entities.DataExport) error {
+	model := r.entityToModel(export)
+
+	if err := r.db.WithContext(ctx).Create(&model).Error; err != nil {
+		return fmt.Errorf("r.db.Create: %w", err)
+	}
+	return nil
+}
+
+func (r *PostgreSQLDataExportRepository) GetByID(ctx context.Context, id *value_objects.TokenID) (*entities.DataExport, error) {
+	var model models.DataExport
+
+	result := r.db.WithContext(ctx).Where("id = ?", id.String()).First(&model)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, repositories.ErrDataExportNotFound
+		}
+		return nil, fmt.Errorf("r.db.First: %w", result.Error)
+	}
+
+	return r.modelToEntity(model)
+}
+
+func (r *PostgreSQLDataExportRepository) ListByUserID(ctx context.Context, userID *value_objects.UserID) ([]*entities.DataExport, error) {
+	var exportModels []models.DataExport
+
+	err := r.db.WithContext(ctx).
+		Where("user_id = ?", userID.String()).
+		Order("created_at DESC").
+		Find(&exportModels).Error
+	if err != nil {
+		return nil, fmt.Errorf("r.db.Find: %w", err)
+	}
+
+	exports := make([]*entities.DataExport, 0, len(exportModels))
+	for _, model := range exportModels {
+		export, err := r.modelToEntity(model)
+		if err != nil {
+			return nil, fmt.Errorf("modelToEntity: %w", err)
+		}
+		exports = append(exports, export)
+	}
+
+	return exports, nil
+}
+
+func (r *PostgreSQLDataExportRepository) ClaimNext(ctx context.Context, now time.Time, staleBefore time.Time) (*entities.DataExport, error) {
+	for attempt := 0; attempt < maxClaimAttempts; attempt++ {
+		var model models.DataExport
+
+		result := r.db.WithContext(ctx).
+			Where("status = ? OR (status = ? AND started_at < ?)",
+				value_objects.DataExportStatusPending.String(),
+				value_objects.DataExportStatusProcessing.String(),
+				staleBefore,
+			).
+			Order("created_at ASC").
+			First(&model)
+		if result.Error != nil {
+			if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+				return nil, repositories.ErrDataExportNotFound
+			}
+			return nil, fmt.Errorf("r.db.First: %w", result.Error)
+		}
+
+		// Only take the export if no other worker claimed it since it was read
+		claim := r.db.WithContext(ctx).
+			Model(&models.DataExport{}).
+			Where("id = ? AND status = ?", model.ID, model.Status)
+		if model.StartedAt == nil {
+			claim = claim.Where("started_at IS NULL")
+		} else {
+			claim = claim.Where("started_at = ?", *model.StartedAt)
+		}
+
+		claim = claim.Updates(map[string]interface{}{
+			"status":     value_objects.DataExportStatusProcessing.String(),
+			"started_at": now,
+			"error":      nil,
+		})
+		if claim.Error != nil {
+			return nil, fmt.Errorf("r.db.Updates: %w", claim.Error)
+		}
+		if claim.RowsAffected == 0 {
+			continue
+		}
+
+		model.Status = value_objects.DataExportStatusProcessing.String()
+		model.StartedAt = &now
+		model.Error = nil
+		return r.modelToEntity(model)
+	}
+
+	return nil, repositories.ErrDataExportNotFound
+}
+
+func (r *PostgreSQLDataExportRepository) Update(ctx context.Context, export *entities.DataExport) error {
+	model := r.entityToModel(export)
+
+	result := r.db.WithContext(ctx).
+		Model(&models.DataExport{}).
+		Where("id = ?", model.ID).
+		Updates(map[string]interface{}{
+			"status":       model.Status,
+			"size_bytes":   model.SizeBytes,
+			"error":        model.Error,
+			"started_at":   model.StartedAt,
+			"completed_at": model.CompletedAt,
+			"expires_at":   model.ExpiresAt,
+		})
+	if result.Error != nil {
+		return fmt.Errorf("r.db.Updates: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return repositories.ErrDataExportNotFound
+	}
+	return nil
+}
+
+func (r *PostgreSQLDataExportRepository) entityToModel(export *entities.DataExport) models.DataExport {
+	return models.DataExport{
+		ID:          export.ID().String(),
+		UserID:      export.UserID().String(),
+		Status:      export.Status().String(),
+		SizeBytes:   export.SizeBytes(),
+		Error:       export.ErrorReason(),
+		CreatedAt:   export.CreatedAt(),
+		StartedAt:   export.StartedAt(),
+		CompletedAt: export.CompletedAt(),
+		ExpiresAt:   export.ExpiresAt(),
+	}
+}
+
+// Helper method to convert model to entity
+func (r *PostgreSQLDataExportRepository) modelToEntity(model models.DataExport) (*entities.DataExport, error) {
+	id, err := value_objects.NewTokenIDFromString(model.ID)
+	if err != nil {
+		return nil, fmt.Errorf("value_objects.NewTokenIDFromString: %w", err)
+	}
+
+	userID, err := value_objects.NewUserIDFromString(model.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("value_objects.NewUserIDFromString: %w", err)
+	}
+
+	status, err := value_objects.NewDataExportStatus(model.Status)
+	if err != nil {
+		return nil, fmt.Errorf("value_objects.NewDataExportStatus: %w", err)
+	}
+
+	return entities.NewDataExportFromRepository(
+		id,
+		userID,
+		*status,
+		model.SizeBytes,
+		model.Error,
+		model.CreatedAt,
+		model.StartedAt,
+		model.CompletedAt,
+		model.ExpiresAt,
+	), nil
+}
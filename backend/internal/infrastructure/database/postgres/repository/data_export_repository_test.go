@@ -0,0 +1,129 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/atdevten/peace/internal/domain/entities"
+	"github.com/atdevten/peace/internal/domain/repositories"
+	"github.com/atdevten/peace/internal/domain/value_objects"
+	"github.com/atdevten/peace/internal/infrastructure/database/postgres/models"
+	"github.com/atdevten/peace/testutils/helpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// setupDataExportTestDB creates an in-memory SQLite database for data export testing
+func setupDataExportTestDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	require.NoError(t, err)
+
+	err = db.AutoMigrate(&models.DataExport{})
+	require.NoError(t, err)
+
+	return db
+}
+
+func createTestDataExport(t *testing.T, userID *value_objects.UserID) *entities.DataExport {
+	export, err := entities.NewDataExport(userID)
+	require.NoError(t, err)
+	return export
+}
+
+func TestPostgreSQLDataExportRepository_CreateGetAndList(t *testing.T) {
+	db := setupDataExportTestDB(t)
+	repo := NewPostgreSQLDataExportRepository(db)
+	ctx := context.Background()
+
+	userID := helpers.CreateTestUserID()
+	older := createTestDataExport(t, userID)
+	require.NoError(t, repo.Create(ctx, older))
+	time.Sleep(10 * time.Millisecond)
+	newer := createTestDataExport(t, userID)
+	require.NoError(t, repo.Create(ctx, newer))
+	require.NoError(t, repo.Create(ctx, createTestDataExport(t, value_objects.NewUserID())))
+
+	found, err := repo.GetByID(ctx, older.ID())
+	require.NoError(t, err)
+	assert.Equal(t, userID.String(), found.UserID().String())
+	assert.Equal(t, value_objects.DataExportStatusPending, found.Status())
+	assert.Nil(t, found.StartedAt())
+
+	exports, err := repo.ListByUserID(ctx, userID)
+	require.NoError(t, err)
+	require.Len(t, exports, 2)
+	assert.Equal(t, newer.ID().String(), exports[0].ID().String())
+	assert.Equal(t, older.ID().String(), exports[1].ID().String())
+
+	_, err = repo.GetByID(ctx, value_objects.NewTokenID())
+	assert.ErrorIs(t, err, repositories.ErrDataExportNotFound)
+}
+
+func TestPostgreSQLDataExportRepository_ClaimNext(t *testing.T) {
+	db := setupDataExportTestDB(t)
+	repo := NewPostgreSQLDataExportRepository(db)
+	ctx := context.Background()
+
+	first := createTestDataExport(t, helpers.CreateTestUserID())
+	require.NoError(t, repo.Create(ctx, first))
+	time.Sleep(10 * time.Millisecond)
+	second := createTestDataExport(t, helpers.CreateTestUserID())
+	require.NoError(t, repo.Create(ctx, second))
+
+	now := time.Now()
+	staleBefore := now.Add(-time.Hour)
+
+	// Oldest pending export first
+	claimed, err := repo.ClaimNext(ctx, now, staleBefore)
+	require.NoError(t, err)
+	assert.Equal(t, first.ID().String(), claimed.ID().String())
+	assert.Equal(t, value_objects.DataExportStatusProcessing, claimed.Status())
+	require.NotNil(t, claimed.StartedAt())
+
+	claimed, err = repo.ClaimNext(ctx, now, staleBefore)
+	require.NoError(t, err)
+	assert.Equal(t, second.ID().String(), claimed.ID().String())
+
+	// Both are being processed by a live worker
+	_, err = repo.ClaimNext(ctx, now, staleBefore)
+	assert.ErrorIs(t, err, repositories.ErrDataExportNotFound)
+
+	// A worker that started before staleBefore is presumed dead
+	claimed, err = repo.ClaimNext(ctx, now.Add(2*time.Hour), now.Add(time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, first.ID().String(), claimed.ID().String())
+}
+
+func TestPostgreSQLDataExportRepository_Update(t *testing.T) {
+	db := setupDataExportTestDB(t)
+	repo := NewPostgreSQLDataExportRepository(db)
+	ctx := context.Background()
+
+	export := createTestDataExport(t, helpers.CreateTestUserID())
+	require.NoError(t, repo.Create(ctx, export))
+
+	now := time.Now()
+	export.Start(now)
+	export.Complete(2048, now, 24*time.Hour)
+	require.NoError(t, repo.Update(ctx, export))
+
+	found, err := repo.GetByID(ctx, export.ID())
+	require.NoError(t, err)
+	assert.Equal(t, value_objects.DataExportStatusCompleted, found.Status())
+	assert.Equal(t, int64(2048), found.SizeBytes())
+	require.NotNil(t, found.ExpiresAt())
+	assert.WithinDuration(t, now.Add(24*time.Hour), *found.ExpiresAt(), time.Second)
+	assert.True(t, found.IsReady(now))
+
+	// Completed exports are never claimed
+	_, err = repo.ClaimNext(ctx, now, now)
+	assert.ErrorIs(t, err, repositories.ErrDataExportNotFound)
+
+	assert.ErrorIs(t, repo.Update(ctx, createTestDataExport(t, helpers.CreateTestUserID())), repositories.ErrDataExportNotFound)
+}
@@ -0,0 +1,122 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/atdevten/peace/internal/domain/entities"
+	"github.com/atdevten/peace/internal/domain/repositories"
+	"github.com/atdevten/peace/internal/domain/value_objects"
+	"github.com/atdevten/peace/internal/infrastructure/database/postgres/models"
+
+	"gorm.io/gorm"
+)
+
+type PostgreSQLEmailVerificationTokenRepository struct {
+	db *gorm.DB
+}
+
+func NewPostgreSQLEmailVerificationTokenRepository(db *gorm.DB) repositories.EmailVerificationTokenRepository {
+	return &PostgreSQLEmailVerificationTokenRepository{
+		db: db,
+	}
+}
+
+func (r *PostgreSQLEmailVerificationTokenRepository) Create(ctx context.Context, token *entities.EmailVerificationToken) error {
+	model := models.EmailVerificationToken{
+		ID:        token.ID().String(),
+		UserID:    token.UserID().String(),
+		TokenHash: token.TokenHash(),
+		ExpiresAt: token.ExpiresAt(),
+		UsedAt:    token.UsedAt(),
+		CreatedAt: token.CreatedAt(),
+	}
+
+	if err := r.db.WithContext(ctx).Create(&model).Error; err != nil {
+		return fmt.Errorf("r.db.Create: %w", err)
+	}
+	return nil
+}
+
+func (r *PostgreSQLEmailVerificationTokenRepository) GetByTokenHash(ctx context.Context, tokenHash string) (*entities.EmailVerificationToken, error) {
+	var model models.EmailVerificationToken
+
+	result := r.db.WithContext(ctx).Where("token_hash = ?", tokenHash).First(&model)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, repositories.ErrEmailVerificationTokenNotFound
+		}
+		return nil, fmt.Errorf("r.db.First: %w", result.Error)
+	}
+
+	return r.modelToEntity(model)
+}
+
+func (r *PostgreSQLEmailVerificationTokenRepository) GetLatestByUserID(ctx context.Context, userID *value_objects.UserID) (*entities.EmailVerificationToken, error) {
+	var model models.EmailVerificationToken
+
+	result := r.db.WithContext(ctx).Where("user_id = ?", userID.String()).Order("created_at DESC").First(&model)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, repositories.ErrEmailVerificationTokenNotFound
+		}
+		return nil, fmt.Errorf("r.db.First: %w", result.Error)
+	}
+
+	return r.modelToEntity(model)
+}
+
+func (r *PostgreSQLEmailVerificationTokenRepository) MarkUsed(ctx context.Context, token *entities.EmailVerificationToken) error {
+	usedAt := time.Now()
+	if token.UsedAt() != nil {
+		usedAt = *token.UsedAt()
+	}
+
+	// Conditional update so two concurrent verifications cannot both consume the token
+	result := r.db.WithContext(ctx).
+		Model(&models.EmailVerificationToken{}).
+		Where("id = ? AND used_at IS NULL", token.ID().String()).
+		Update("used_at", usedAt)
+	if result.Error != nil {
+		return fmt.Errorf("r.db.Update: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return repositories.ErrEmailVerificationTokenUsed
+	}
+	return nil
+}
+
+func (r *PostgreSQLEmailVerificationTokenRepository) InvalidateByUserID(ctx context.Context, userID *value_objects.UserID) error {
+	err := r.db.WithContext(ctx).
+		Model(&models.EmailVerificationToken{}).
+		Where("user_id = ? AND used_at IS NULL", userID.String()).
+		Update("used_at", time.Now()).Error
+	if err != nil {
+		return fmt.Errorf("r.db.Update: %w", err)
+	}
+	return nil
+}
+
+// Helper method to convert model to entity
+func (r *PostgreSQLEmailVerificationTokenRepository) modelToEntity(model models.EmailVerificationToken) (*entities.EmailVerificationToken, error) {
+	id, err := value_objects.NewTokenIDFromString(model.ID)
+	if err != nil {
+		return nil, fmt.Errorf("value_objects.NewTokenIDFromString: %w", err)
+	}
+
+	userID, err := value_objects.NewUserIDFromString(model.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("value_objects.NewUserIDFromString: %w", err)
+	}
+
+	return entities.NewEmailVerificationTokenFromRepository(
+		id,
+		userID,
+		model.TokenHash,
+		model.ExpiresAt,
+		model.UsedAt,
+		model.CreatedAt,
+	), nil
+}
@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/atdevten/peace/internal/domain/entities"
+	"github.com/atdevten/peace/internal/domain/repositories"
+	"github.com/atdevten/peace/internal/domain/value_objects"
+	"github.com/atdevten/peace/internal/infrastructure/database/postgres/models"
+	"github.com/atdevten/peace/testutils/helpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// setupEmailVerificationTokenTestDB creates an in-memory SQLite database for verification token testing
+func setupEmailVerificationTokenTestDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	require.NoError(t, err)
+
+	err = db.AutoMigrate(&models.EmailVerificationToken{})
+	require.NoError(t, err)
+
+	return db
+}
+
+func TestPostgreSQLEmailVerificationTokenRepository_GetLatestByUserID(t *testing.T) {
+	db := setupEmailVerificationTokenTestDB(t)
+	repo := NewPostgreSQLEmailVerificationTokenRepository(db)
+	ctx := context.Background()
+
+	userID := helpers.CreateTestUserID()
+
+	_, err := repo.GetLatestByUserID(ctx, userID)
+	assert.ErrorIs(t, err, repositories.ErrEmailVerificationTokenNotFound)
+
+	var latest *entities.EmailVerificationToken
+	for i := 0; i < 3; i++ {
+		secret, err := value_objects.NewSecretToken()
+		require.NoError(t, err)
+
+		token := entities.NewEmailVerificationTokenFromRepository(
+			value_objects.NewTokenID(),
+			userID,
+			secret.Hash(),
+			time.Now().Add(24*time.Hour),
+			nil,
+			time.Now().Add(time.Duration(i-3)*time.Minute),
+		)
+		require.NoError(t, repo.Create(ctx, token))
+		latest = token
+	}
+
+	found, err := repo.GetLatestByUserID(ctx, userID)
+	require.NoError(t, err)
+	assert.Equal(t, latest.ID().String(), found.ID().String())
+}
+
+func TestPostgreSQLEmailVerificationTokenRepository_MarkUsedAndInvalidate(t *testing.T) {
+	db := setupEmailVerificationTokenTestDB(t)
+	repo := NewPostgreSQLEmailVerificationTokenRepository(db)
+	ctx := context.Background()
+
+	userID := helpers.CreateTestUserID()
+	secret, err := value_objects.NewSecretToken()
+	require.NoError(t, err)
+	token, err := entities.NewEmailVerificationToken(userID, secret, time.Hour)
+	require.NoError(t, err)
+	require.NoError(t, repo.Create(ctx, token))
+
+	require.NoError(t, repo.MarkUsed(ctx, token))
+	assert.ErrorIs(t, repo.MarkUsed(ctx, token), repositories.ErrEmailVerificationTokenUsed)
+
+	other, err := value_objects.NewSecretToken()
+	require.NoError(t, err)
+	pending, err := entities.NewEmailVerificationToken(userID, other, time.Hour)
+	require.NoError(t, err)
+	require.NoError(t, repo.Create(ctx, pending))
+
+	require.NoError(t, repo.InvalidateByUserID(ctx, userID))
+	found, err := repo.GetByTokenHash(ctx, other.Hash())
+	require.NoError(t, err)
+	assert.True(t, found.IsUsed())
+}
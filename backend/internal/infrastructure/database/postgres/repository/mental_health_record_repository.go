@@ -3,12 +3,14 @@ package repository
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/atdevten/peace/internal/domain/entities"
 	"github.com/atdevten/peace/internal/domain/repositories"
 	"github.com/atdevten/peace/internal/domain/value_objects"
 	"github.com/atdevten/peace/internal/infrastructure/database/postgres/models"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type PostgreSQLMentalHealthRecordRepository struct {
@@ -31,11 +33,15 @@ func (r *PostgreSQLMentalHealthRecordRepository) Create(ctx context.Context, rec
 		Status:      record.Status().String(),
 	}
 
-	if err := r.db.WithContext(ctx).Create(&model).Error; err != nil {
-		return fmt.Errorf("r.db.Create: %w", err)
-	}
-
-	return nil
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&model).Error; err != nil {
+			return fmt.Errorf("tx.Create: %w", err)
+		}
+		if len(record.Tags()) == 0 {
+			return nil
+		}
+		return replaceRecordLabels(tx, model.UserID, model.ID, record.Tags())
+	})
 }
 
 func (r *PostgreSQLMentalHealthRecordRepository) GetByID(ctx context.Context, id *value_objects.MentalHealthRecordID) (*entities.MentalHealthRecord, error) {
@@ -118,11 +124,53 @@ func (r *PostgreSQLMentalHealthRecordRepository) GetByFilter(ctx context.Context
 		query = query.Where("created_at <= ?", *filter.EndedAt)
 	}
 
-	// Order by created_at; default DESC, allow override
+	// Level, status and notes filters
+	if filter.MinHappyLevel != nil {
+		query = query.Where("happy_level >= ?", filter.MinHappyLevel.Value())
+	}
+	if filter.MaxHappyLevel != nil {
+		query = query.Where("happy_level <= ?", filter.MaxHappyLevel.Value())
+	}
+	if filter.MinEnergyLevel != nil {
+		query = query.Where("energy_level >= ?", filter.MinEnergyLevel.Value())
+	}
+	if filter.MaxEnergyLevel != nil {
+		query = query.Where("energy_level <= ?", filter.MaxEnergyLevel.Value())
+	}
+	if filter.Status != nil {
+		query = query.Where("status = ?", filter.Status.String())
+	}
+	if filter.Search != nil {
+		// Matches the expression of idx_mental_health_records_notes_tsv so the GIN index is used
+		query = query.Where("to_tsvector('english', COALESCE(notes, '')) @@ websearch_to_tsquery('english', ?)", *filter.Search)
+	}
+
+	if len(filter.TagNames) > 0 {
+		// Keep records that carry every requested label
+		query = query.Where(`id IN (
+			SELECT record_tags.record_id
+			FROM record_tags
+			JOIN record_labels ON record_labels.id = record_tags.label_id
+			WHERE record_labels.name IN ?
+			GROUP BY record_tags.record_id
+			HAVING COUNT(DISTINCT record_labels.id) = ?
+		)`, filter.TagNames, len(filter.TagNames))
+	}
+
+	// Keyset pagination: continue strictly after the cursor in sort order
+	if filter.Cursor != nil {
+		if filter.OrderDesc {
+			query = query.Where("(created_at < ? OR (created_at = ? AND id < ?))", filter.Cursor.CreatedAt, filter.Cursor.CreatedAt, filter.Cursor.ID)
+		} else {
+			query = query.Where("(created_at > ? OR (created_at = ? AND id > ?))", filter.Cursor.CreatedAt, filter.Cursor.CreatedAt, filter.Cursor.ID)
+		}
+	}
+
+	// Order by created_at with id as a tie-breaker so keyset pages are stable
 	if filter.OrderDesc {
-		query = query.Order("created_at ASC")
+		query = query.Order("created_at DESC").Order("id DESC")
 	} else {
-		query = query.Order("created_at DESC")
+		query = query.Order("created_at ASC").Order("id ASC")
 	}
 
 	// Pagination
@@ -249,14 +297,88 @@ func (r *PostgreSQLMentalHealthRecordRepository) Update(ctx context.Context, rec
 		Status:      record.Status().String(),
 	}
 
-	if err := r.db.WithContext(ctx).Model(&models.MentalHealthRecord{}).Where("id = ?", model.ID).Updates(&model).Error; err != nil {
-		return fmt.Errorf("r.db.Updates: %w", err)
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.MentalHealthRecord{}).Where("id = ?", model.ID).Updates(&model).Error; err != nil {
+			return fmt.Errorf("tx.Updates: %w", err)
+		}
+		if record.Tags() == nil {
+			return nil
+		}
+		return replaceRecordLabels(tx, model.UserID, model.ID, record.Tags())
+	})
+}
+
+// replaceRecordLabels links the record to the owner's labels of the given names, creating the
+// missing ones. Concurrent creates of the same name settle on a single label.
+func replaceRecordLabels(tx *gorm.DB, userID string, recordID string, names []string) error {
+	if err := tx.Where("record_id = ?", recordID).Delete(&models.RecordTag{}).Error; err != nil {
+		return fmt.Errorf("tx.Delete: %w", err)
+	}
+	if len(names) == 0 {
+		return nil
+	}
+
+	labels := make([]models.RecordLabel, len(names))
+	for i, name := range names {
+		labels[i] = models.RecordLabel{UserID: userID, Name: name}
+	}
+	err := tx.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}, {Name: "name"}},
+		DoNothing: true,
+	}).Create(&labels).Error
+	if err != nil {
+		return fmt.Errorf("tx.Create: %w", err)
+	}
+
+	var labelIDs []int
+	if err := tx.Model(&models.RecordLabel{}).Where("user_id = ? AND name IN ?", userID, names).Pluck("id", &labelIDs).Error; err != nil {
+		return fmt.Errorf("tx.Pluck: %w", err)
 	}
 
+	recordTags := make([]models.RecordTag, len(labelIDs))
+	for i, labelID := range labelIDs {
+		recordTags[i] = models.RecordTag{RecordID: recordID, LabelID: labelID}
+	}
+	if err := tx.Create(&recordTags).Error; err != nil {
+		return fmt.Errorf("tx.Create: %w", err)
+	}
 	return nil
 }
 
-func (r *PostgreSQLMentalHealthRecordRepository) GetDistinctDatesForUser(ctx context.Context, userID *value_objects.UserID) ([]string, error) {
+func (r *PostgreSQLMentalHealthRecordRepository) GetTagsByRecordIDs(ctx context.Context, recordIDs []*value_objects.MentalHealthRecordID) (map[string][]string, error) {
+	tagsByRecord := make(map[string][]string)
+	if len(recordIDs) == 0 {
+		return tagsByRecord, nil
+	}
+
+	ids := make([]string, len(recordIDs))
+	for i, recordID := range recordIDs {
+		ids[i] = recordID.String()
+	}
+
+	var rows []struct {
+		RecordID string
+		Name     string
+	}
+	err := r.db.WithContext(ctx).
+		Table("record_tags").
+		Select("record_tags.record_id, record_labels.name").
+		Joins("JOIN record_labels ON record_labels.id = record_tags.label_id").
+		Where("record_tags.record_id IN ?", ids).
+		Order("record_labels.name ASC").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("r.db.Scan: %w", err)
+	}
+
+	for _, row := range rows {
+		tagsByRecord[row.RecordID] = append(tagsByRecord[row.RecordID], row.Name)
+	}
+
+	return tagsByRecord, nil
+}
+
+func (r *PostgreSQLMentalHealthRecordRepository) GetDistinctDatesForUser(ctx context.Context, userID *value_objects.UserID, location *time.Location) ([]string, error) {
 	var records []models.MentalHealthRecord
 
 	// Use GORM to get records ordered by created_at DESC (leverages index)
@@ -271,12 +393,17 @@ func (r *PostgreSQLMentalHealthRecordRepository) GetDistinctDatesForUser(ctx con
 		return nil, fmt.Errorf("r.db.Find: %w", err)
 	}
 
+	if location == nil {
+		location = time.UTC
+	}
+
 	// Extract unique dates in application layer (more efficient than DB function)
+	// Dates are bucketed in the caller's location so a day matches the user's calendar
 	seenDates := make(map[string]bool)
 	var dates []string
 
 	for _, record := range records {
-		dateStr := record.CreatedAt.Format("2006-01-02")
+		dateStr := record.CreatedAt.In(location).Format("2006-01-02")
 		if !seenDates[dateStr] {
 			seenDates[dateStr] = true
 			dates = append(dates, dateStr)
@@ -285,3 +412,272 @@ func (r *PostgreSQLMentalHealthRecordRepository) GetDistinctDatesForUser(ctx con
 
 	return dates, nil
 }
+
+// analyticsScope restricts analytics queries to the user's live records in the requested range
+const analyticsScope = "user_id = ? AND deleted_at IS NULL AND created_at >= ? AND created_at <= ?"
+
+type analyticsSummaryRow struct {
+	RecordCount      int
+	HappyAvg         float64
+	HappyMin         int
+	HappyMax         int
+	HappyStddev      float64
+	HappyTrendSlope  float64
+	EnergyAvg        float64
+	EnergyMin        int
+	EnergyMax        int
+	EnergyStddev     float64
+	EnergyTrendSlope float64
+}
+
+type analyticsDailyRow struct {
+	Day             string
+	RecordCount     int
+	HappyAvg        float64
+	EnergyAvg       float64
+	HappyRolling7   float64
+	EnergyRolling7  float64
+	HappyRolling30  float64
+	EnergyRolling30 float64
+}
+
+type analyticsBucketRow struct {
+	Bucket      int
+	RecordCount int
+	HappyAvg    float64
+	EnergyAvg   float64
+}
+
+func (r *PostgreSQLMentalHealthRecordRepository) GetAnalytics(ctx context.Context, filter *repositories.MentalHealthRecordAnalyticsFilter) (*repositories.MentalHealthRecordAnalytics, error) {
+	location := filter.Location
+	if location == nil {
+		location = time.UTC
+	}
+
+	tz := location.String()
+	scopeArgs := []interface{}{filter.UserID.String(), filter.StartedAt, filter.EndedAt}
+	db := r.db.WithContext(ctx)
+
+	// Whole-range aggregates; the trend slope is a least-squares fit in levels per day
+	var summary analyticsSummaryRow
+	summaryQuery := `
+		SELECT
+			COUNT(*) AS record_count,
+			COALESCE(AVG(happy_level), 0)::float8 AS happy_avg,
+			COALESCE(MIN(happy_level), 0) AS happy_min,
+			COALESCE(MAX(happy_level), 0) AS happy_max,
+			COALESCE(STDDEV_POP(happy_level), 0)::float8 AS happy_stddev,
+			COALESCE(REGR_SLOPE(happy_level, EXTRACT(EPOCH FROM created_at) / 86400), 0)::float8 AS happy_trend_slope,
+			COALESCE(AVG(energy_level), 0)::float8 AS energy_avg,
+			COALESCE(MIN(energy_level), 0) AS energy_min,
+			COALESCE(MAX(energy_level), 0) AS energy_max,
+			COALESCE(STDDEV_POP(energy_level), 0)::float8 AS energy_stddev,
+			COALESCE(REGR_SLOPE(energy_level, EXTRACT(EPOCH FROM created_at) / 86400), 0)::float8 AS energy_trend_slope
+		FROM mental_health_records
+		WHERE ` + analyticsScope
+	if err := db.Raw(summaryQuery, scopeArgs...).Scan(&summary).Error; err != nil {
+		return nil, fmt.Errorf("r.db.Raw summary: %w", err)
+	}
+
+	// Daily means with trailing 7/30 calendar-day rolling averages weighted by record count
+	var daily []analyticsDailyRow
+	dailyQuery := `
+		WITH daily AS (
+			SELECT
+				(created_at AT TIME ZONE ?)::date AS day,
+				COUNT(*) AS record_count,
+				SUM(happy_level) AS happy_sum,
+				SUM(energy_level) AS energy_sum
+			FROM mental_health_records
+			WHERE ` + analyticsScope + `
+			GROUP BY 1
+		)
+		SELECT
+			TO_CHAR(day, 'YYYY-MM-DD') AS day,
+			record_count,
+			(happy_sum::float8 / record_count) AS happy_avg,
+			(energy_sum::float8 / record_count) AS energy_avg,
+			(SUM(happy_sum) OVER w7)::float8 / SUM(record_count) OVER w7 AS happy_rolling7,
+			(SUM(energy_sum) OVER w7)::float8 / SUM(record_count) OVER w7 AS energy_rolling7,
+			(SUM(happy_sum) OVER w30)::float8 / SUM(record_count) OVER w30 AS happy_rolling30,
+			(SUM(energy_sum) OVER w30)::float8 / SUM(record_count) OVER w30 AS energy_rolling30
+		FROM daily
+		WINDOW
+			w7 AS (ORDER BY day RANGE BETWEEN INTERVAL '6 days' PRECEDING AND CURRENT ROW),
+			w30 AS (ORDER BY day RANGE BETWEEN INTERVAL '29 days' PRECEDING AND CURRENT ROW)
+		ORDER BY day`
+	if err := db.Raw(dailyQuery, append([]interface{}{tz}, scopeArgs...)...).Scan(&daily).Error; err != nil {
+		return nil, fmt.Errorf("r.db.Raw daily: %w", err)
+	}
+
+	// Per-weekday (0 = Sunday) and per-hour means in the local timezone
+	bucketQuery := func(field string) string {
+		return `
+		SELECT
+			EXTRACT(` + field + ` FROM created_at AT TIME ZONE ?)::int AS bucket,
+			COUNT(*) AS record_count,
+			AVG(happy_level)::float8 AS happy_avg,
+			AVG(energy_level)::float8 AS energy_avg
+		FROM mental_health_records
+		WHERE ` + analyticsScope + `
+		GROUP BY 1
+		ORDER BY 1`
+	}
+
+	var weekdays []analyticsBucketRow
+	if err := db.Raw(bucketQuery("DOW"), append([]interface{}{tz}, scopeArgs...)...).Scan(&weekdays).Error; err != nil {
+		return nil, fmt.Errorf("r.db.Raw weekdays: %w", err)
+	}
+
+	var hours []analyticsBucketRow
+	if err := db.Raw(bucketQuery("HOUR"), append([]interface{}{tz}, scopeArgs...)...).Scan(&hours).Error; err != nil {
+		return nil, fmt.Errorf("r.db.Raw hours: %w", err)
+	}
+
+	// Convert rows to domain aggregates
+	analytics := &repositories.MentalHealthRecordAnalytics{
+		Summary: repositories.MentalHealthRecordSummary{
+			Count:            summary.RecordCount,
+			HappyAvg:         summary.HappyAvg,
+			HappyMin:         summary.HappyMin,
+			HappyMax:         summary.HappyMax,
+			HappyStddev:      summary.HappyStddev,
+			HappyTrendSlope:  summary.HappyTrendSlope,
+			EnergyAvg:        summary.EnergyAvg,
+			EnergyMin:        summary.EnergyMin,
+			EnergyMax:        summary.EnergyMax,
+			EnergyStddev:     summary.EnergyStddev,
+			EnergyTrendSlope: summary.EnergyTrendSlope,
+		},
+		Daily:    make([]repositories.MentalHealthRecordDailyAverage, 0, len(daily)),
+		Weekdays: make([]repositories.MentalHealthRecordBucketAverage, 0, len(weekdays)),
+		Hours:    make([]repositories.MentalHealthRecordBucketAverage, 0, len(hours)),
+	}
+
+	for _, row := range daily {
+		analytics.Daily = append(analytics.Daily, repositories.MentalHealthRecordDailyAverage{
+			Date:            row.Day,
+			Count:           row.RecordCount,
+			HappyAvg:        row.HappyAvg,
+			EnergyAvg:       row.EnergyAvg,
+			HappyRolling7:   row.HappyRolling7,
+			EnergyRolling7:  row.EnergyRolling7,
+			HappyRolling30:  row.HappyRolling30,
+			EnergyRolling30: row.EnergyRolling30,
+		})
+	}
+
+	for _, row := range weekdays {
+		analytics.Weekdays = append(analytics.Weekdays, repositories.MentalHealthRecordBucketAverage{
+			Bucket:    row.Bucket,
+			Count:     row.RecordCount,
+			HappyAvg:  row.HappyAvg,
+			EnergyAvg: row.EnergyAvg,
+		})
+	}
+
+	for _, row := range hours {
+		analytics.Hours = append(analytics.Hours, repositories.MentalHealthRecordBucketAverage{
+			Bucket:    row.Bucket,
+			Count:     row.RecordCount,
+			HappyAvg:  row.HappyAvg,
+			EnergyAvg: row.EnergyAvg,
+		})
+	}
+
+	return analytics, nil
+}
+
+func (r *PostgreSQLMentalHealthRecordRepository) GetTagMoodAverages(ctx context.Context, filter *repositories.MentalHealthRecordTagReportFilter) ([]*repositories.TagMoodAverage, error) {
+	var rows []struct {
+		TagName     string
+		RecordCount int
+		HappyAvg    float64
+		EnergyAvg   float64
+	}
+
+	query := r.db.WithContext(ctx).
+		Table("record_tags").
+		Select("record_labels.name AS tag_name, COUNT(*) AS record_count, AVG(mental_health_records.happy_level) AS happy_avg, AVG(mental_health_records.energy_level) AS energy_avg").
+		Joins("JOIN record_labels ON record_labels.id = record_tags.label_id").
+		Joins("JOIN mental_health_records ON mental_health_records.id = record_tags.record_id").
+		Where("mental_health_records.user_id = ?", filter.UserID.String()).
+		Where("mental_health_records.deleted_at IS NULL")
+
+	if filter.StartedAt != nil {
+		query = query.Where("mental_health_records.created_at >= ?", *filter.StartedAt)
+	}
+	if filter.EndedAt != nil {
+		query = query.Where("mental_health_records.created_at <= ?", *filter.EndedAt)
+	}
+
+	// Highest average happiness first so the most uplifting tags lead the report
+	if err := query.Group("record_labels.name").Order("happy_avg DESC, record_labels.name ASC").Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("r.db.Scan: %w", err)
+	}
+
+	averages := make([]*repositories.TagMoodAverage, 0, len(rows))
+	for _, row := range rows {
+		averages = append(averages, &repositories.TagMoodAverage{
+			TagName:   row.TagName,
+			Count:     row.RecordCount,
+			HappyAvg:  row.HappyAvg,
+			EnergyAvg: row.EnergyAvg,
+		})
+	}
+
+	return averages, nil
+}
+
+func (r *PostgreSQLMentalHealthRecordRepository) GetPublicFeed(ctx context.Context, filter *repositories.PublicFeedFilter) ([]*repositories.PublicFeedEntry, error) {
+	var rows []struct {
+		ID          string
+		HappyLevel  int
+		EnergyLevel int
+		Notes       *string
+		CreatedAt   time.Time
+		Username    string
+		PictureURL  *string
+	}
+
+	// Only author display fields are selected; user_id and email never leave the query
+	query := r.db.WithContext(ctx).
+		Table("mental_health_records").
+		Select("mental_health_records.id, mental_health_records.happy_level, mental_health_records.energy_level, mental_health_records.notes, mental_health_records.created_at, users.username, users.picture_url").
+		Joins("JOIN users ON users.id = mental_health_records.user_id").
+		Where("mental_health_records.status = ? AND mental_health_records.deleted_at IS NULL", value_objects.RecordStatusPublic.String()).
+		Where("users.is_active = ? AND users.deleted_at IS NULL AND users.feed_opt_out = ?", true, false)
+
+	if filter.Cursor != nil {
+		query = query.Where("(mental_health_records.created_at < ? OR (mental_health_records.created_at = ? AND mental_health_records.id < ?))", filter.Cursor.CreatedAt, filter.Cursor.CreatedAt, filter.Cursor.ID)
+	}
+
+	err := query.
+		Order("mental_health_records.created_at DESC").
+		Order("mental_health_records.id DESC").
+		Limit(filter.Limit).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("r.db.Scan: %w", err)
+	}
+
+	entries := make([]*repositories.PublicFeedEntry, 0, len(rows))
+	for _, row := range rows {
+		recordID, err := value_objects.NewMentalHealthRecordIDFromString(row.ID)
+		if err != nil {
+			return nil, fmt.Errorf("value_objects.NewMentalHealthRecordIDFromString: %w", err)
+		}
+
+		entries = append(entries, &repositories.PublicFeedEntry{
+			RecordID:    recordID,
+			HappyLevel:  row.HappyLevel,
+			EnergyLevel: row.EnergyLevel,
+			Notes:       row.Notes,
+			CreatedAt:   row.CreatedAt,
+			Username:    row.Username,
+			AvatarURL:   row.PictureURL,
+		})
+	}
+
+	return entries, nil
+}
@@ -261,6 +261,152 @@ func TestPostgreSQLMentalHealthRecordRepository_GetByFilter(t *testing.T) {
 	}
 }
 
+func TestPostgreSQLMentalHealthRecordRepository_GetByFilter_Cursor(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewPostgreSQLMentalHealthRecordRepository(db)
+
+	// Insert four records an hour apart, oldest first
+	userID := helpers.CreateTestUserID()
+	base := time.Date(2023, 12, 1, 8, 0, 0, 0, time.UTC)
+	var ids []string
+	for i := 0; i < 4; i++ {
+		id := value_objects.NewMentalHealthRecordID().String()
+		ids = append(ids, id)
+		require.NoError(t, db.Create(&models.MentalHealthRecord{
+			ID:          id,
+			UserID:      userID.String(),
+			HappyLevel:  5,
+			EnergyLevel: 5,
+			Status:      "public",
+			CreatedAt:   base.Add(time.Duration(i) * time.Hour),
+			UpdatedAt:   base.Add(time.Duration(i) * time.Hour),
+		}).Error)
+	}
+
+	tests := []struct {
+		name      string
+		orderDesc bool
+		cursorIdx int
+		wantIDs   []string
+	}{
+		{
+			name:      "descending after the newest record",
+			orderDesc: true,
+			cursorIdx: 3,
+			wantIDs:   []string{ids[2], ids[1]},
+		},
+		{
+			name:      "ascending after the oldest record",
+			orderDesc: false,
+			cursorIdx: 0,
+			wantIDs:   []string{ids[1], ids[2]},
+		},
+		{
+			name:      "descending past the last page",
+			orderDesc: true,
+			cursorIdx: 0,
+			wantIDs:   nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			limit := 2
+			records, err := repo.GetByFilter(context.Background(), &repositories.MentalHealthRecordFilter{
+				UserID: userID,
+				Cursor: &repositories.MentalHealthRecordCursor{
+					CreatedAt: base.Add(time.Duration(tt.cursorIdx) * time.Hour),
+					ID:        ids[tt.cursorIdx],
+				},
+				Limit:     &limit,
+				OrderDesc: tt.orderDesc,
+			})
+			require.NoError(t, err)
+
+			var gotIDs []string
+			for _, record := range records {
+				gotIDs = append(gotIDs, record.ID().String())
+			}
+			assert.Equal(t, tt.wantIDs, gotIDs)
+		})
+	}
+}
+
+func TestPostgreSQLMentalHealthRecordRepository_GetByFilter_Levels(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewPostgreSQLMentalHealthRecordRepository(db)
+
+	userID := helpers.CreateTestUserID()
+	seed := []struct {
+		happy  int
+		energy int
+		status string
+	}{
+		{happy: 2, energy: 2, status: "private"},
+		{happy: 5, energy: 3, status: "public"},
+		{happy: 9, energy: 8, status: "public"},
+	}
+	for _, s := range seed {
+		require.NoError(t, db.Create(&models.MentalHealthRecord{
+			ID:          value_objects.NewMentalHealthRecordID().String(),
+			UserID:      userID.String(),
+			HappyLevel:  s.happy,
+			EnergyLevel: s.energy,
+			Status:      s.status,
+			CreatedAt:   time.Now(),
+			UpdatedAt:   time.Now(),
+		}).Error)
+	}
+
+	happy := func(v int) *value_objects.HappyLevel {
+		level, err := value_objects.NewHappyLevel(v)
+		require.NoError(t, err)
+		return level
+	}
+	energy := func(v int) *value_objects.EnergyLevel {
+		level, err := value_objects.NewEnergyLevel(v)
+		require.NoError(t, err)
+		return level
+	}
+	public, err := value_objects.NewMentalHealthRecordStatus("public")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name      string
+		filter    *repositories.MentalHealthRecordFilter
+		wantCount int
+	}{
+		{
+			name:      "max energy",
+			filter:    &repositories.MentalHealthRecordFilter{UserID: userID, MaxEnergyLevel: energy(3)},
+			wantCount: 2,
+		},
+		{
+			name:      "happy range",
+			filter:    &repositories.MentalHealthRecordFilter{UserID: userID, MinHappyLevel: happy(3), MaxHappyLevel: happy(9)},
+			wantCount: 2,
+		},
+		{
+			name:      "min energy and status",
+			filter:    &repositories.MentalHealthRecordFilter{UserID: userID, MinEnergyLevel: energy(3), Status: public},
+			wantCount: 2,
+		},
+		{
+			name:      "status with max happy",
+			filter:    &repositories.MentalHealthRecordFilter{UserID: userID, Status: public, MaxHappyLevel: happy(4)},
+			wantCount: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			records, err := repo.GetByFilter(context.Background(), tt.filter)
+			require.NoError(t, err)
+			assert.Len(t, records, tt.wantCount)
+		})
+	}
+}
+
 func TestPostgreSQLMentalHealthRecordRepository_GetDistinctDatesForUser(t *testing.T) {
 	db := setupTestDB(t)
 	repo := NewPostgreSQLMentalHealthRecordRepository(db)
@@ -278,12 +424,21 @@ func TestPostgreSQLMentalHealthRecordRepository_GetDistinctDatesForUser(t *testi
 	tests := []struct {
 		name     string
 		userID   *value_objects.UserID
+		location *time.Location
 		wantErr  bool
 		minCount int
 	}{
 		{
 			name:     "successful get distinct dates",
 			userID:   userID,
+			location: time.UTC,
+			wantErr:  false,
+			minCount: 1,
+		},
+		{
+			name:     "nil location defaults to UTC",
+			userID:   userID,
+			location: nil,
 			wantErr:  false,
 			minCount: 1,
 		},
@@ -291,7 +446,7 @@ func TestPostgreSQLMentalHealthRecordRepository_GetDistinctDatesForUser(t *testi
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			dates, err := repo.GetDistinctDatesForUser(context.Background(), tt.userID)
+			dates, err := repo.GetDistinctDatesForUser(context.Background(), tt.userID, tt.location)
 
 			if tt.wantErr {
 				require.Error(t, err)
@@ -302,3 +457,242 @@ func TestPostgreSQLMentalHealthRecordRepository_GetDistinctDatesForUser(t *testi
 		})
 	}
 }
+
+func TestPostgreSQLMentalHealthRecordRepository_GetDistinctDatesForUser_Timezone(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewPostgreSQLMentalHealthRecordRepository(db)
+
+	userID := helpers.CreateTestUserID()
+
+	// 2023-12-01 20:00 UTC falls on 2023-12-02 in Asia/Tokyo (UTC+9)
+	createdAt := time.Date(2023, 12, 1, 20, 0, 0, 0, time.UTC)
+	err := db.Create(&models.MentalHealthRecord{
+		ID:          value_objects.NewMentalHealthRecordID().String(),
+		UserID:      userID.String(),
+		HappyLevel:  5,
+		EnergyLevel: 7,
+		Status:      "public",
+		CreatedAt:   createdAt,
+		UpdatedAt:   createdAt,
+	}).Error
+	require.NoError(t, err)
+
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		location *time.Location
+		expected []string
+	}{
+		{
+			name:     "bucketed in UTC",
+			location: time.UTC,
+			expected: []string{"2023-12-01"},
+		},
+		{
+			name:     "bucketed in Asia/Tokyo",
+			location: tokyo,
+			expected: []string{"2023-12-02"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dates, err := repo.GetDistinctDatesForUser(context.Background(), userID, tt.location)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, dates)
+		})
+	}
+}
+
+func TestPostgreSQLMentalHealthRecordRepository_Tags(t *testing.T) {
+	db := setupTestDB(t)
+	require.NoError(t, db.AutoMigrate(&models.RecordLabel{}, &models.RecordTag{}))
+
+	repo := NewPostgreSQLMentalHealthRecordRepository(db)
+	ctx := context.Background()
+
+	// Three records: exercise on the happy ones, work on the low one
+	userID := helpers.CreateTestUserID()
+	seed := []struct {
+		happy int
+		tags  []string
+	}{
+		{happy: 8, tags: []string{"exercise"}},
+		{happy: 6, tags: []string{"exercise", "work"}},
+		{happy: 2, tags: []string{"work"}},
+	}
+	var records []*entities.MentalHealthRecord
+	var recordIDs []*value_objects.MentalHealthRecordID
+	for _, s := range seed {
+		record, err := entities.NewMentalHealthRecord(userID.String(), s.happy, 5, nil, "public")
+		require.NoError(t, err)
+		record.SetTags(s.tags)
+		require.NoError(t, repo.Create(ctx, record))
+		records = append(records, record)
+		recordIDs = append(recordIDs, record.ID())
+	}
+
+	t.Run("labels are created once per user", func(t *testing.T) {
+		var count int64
+		require.NoError(t, db.Model(&models.RecordLabel{}).Where("user_id = ?", userID.String()).Count(&count).Error)
+		assert.Equal(t, int64(2), count)
+	})
+
+	t.Run("replace and load record tags", func(t *testing.T) {
+		records[2].SetTags([]string{"work"})
+		require.NoError(t, repo.Update(ctx, records[2]))
+
+		tagsByRecord, err := repo.GetTagsByRecordIDs(ctx, recordIDs)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"exercise", "work"}, tagsByRecord[recordIDs[1].String()])
+		assert.Equal(t, []string{"work"}, tagsByRecord[recordIDs[2].String()])
+	})
+
+	t.Run("update without tags keeps them", func(t *testing.T) {
+		record := entities.NewMentalHealthRecordFromExisting(
+			records[1].ID(), records[1].UserID(), records[1].HappyLevel(), records[1].EnergyLevel(),
+			records[1].Notes(), records[1].Status(), records[1].CreatedAt(), records[1].UpdatedAt(), nil,
+		)
+		require.NoError(t, repo.Update(ctx, record))
+
+		tagsByRecord, err := repo.GetTagsByRecordIDs(ctx, recordIDs[1:2])
+		require.NoError(t, err)
+		assert.Equal(t, []string{"exercise", "work"}, tagsByRecord[recordIDs[1].String()])
+	})
+
+	t.Run("labels are private to their owner", func(t *testing.T) {
+		other, err := entities.NewMentalHealthRecord(helpers.CreateTestUserID().String(), 1, 1, nil, "public")
+		require.NoError(t, err)
+		other.SetTags([]string{"exercise"})
+		require.NoError(t, repo.Create(ctx, other))
+
+		records, err := repo.GetByFilter(ctx, &repositories.MentalHealthRecordFilter{UserID: userID, TagNames: []string{"exercise"}})
+		require.NoError(t, err)
+		assert.Len(t, records, 2)
+	})
+
+	t.Run("filter by tags requires every tag", func(t *testing.T) {
+		records, err := repo.GetByFilter(ctx, &repositories.MentalHealthRecordFilter{UserID: userID, TagNames: []string{"exercise", "work"}})
+		require.NoError(t, err)
+		require.Len(t, records, 1)
+		assert.Equal(t, recordIDs[1].String(), records[0].ID().String())
+	})
+
+	t.Run("tag mood averages", func(t *testing.T) {
+		averages, err := repo.GetTagMoodAverages(ctx, &repositories.MentalHealthRecordTagReportFilter{UserID: userID})
+		require.NoError(t, err)
+		require.Len(t, averages, 2)
+		assert.Equal(t, "exercise", averages[0].TagName)
+		assert.Equal(t, 2, averages[0].Count)
+		assert.InDelta(t, 7.0, averages[0].HappyAvg, 0.001)
+		assert.Equal(t, "work", averages[1].TagName)
+		assert.InDelta(t, 4.0, averages[1].HappyAvg, 0.001)
+	})
+
+	t.Run("clearing tags", func(t *testing.T) {
+		records[0].SetTags([]string{})
+		require.NoError(t, repo.Update(ctx, records[0]))
+
+		tagsByRecord, err := repo.GetTagsByRecordIDs(ctx, recordIDs[:1])
+		require.NoError(t, err)
+		assert.Empty(t, tagsByRecord[recordIDs[0].String()])
+	})
+}
+
+func TestPostgreSQLMentalHealthRecordRepository_GetPublicFeed(t *testing.T) {
+	db := setupTestDB(t)
+	require.NoError(t, db.AutoMigrate(&models.User{}))
+	repo := NewPostgreSQLMentalHealthRecordRepository(db)
+
+	users := []struct {
+		username   string
+		isActive   bool
+		deleted    bool
+		feedOptOut bool
+	}{
+		{username: "visible", isActive: true},
+		{username: "deactivated", isActive: false},
+		{username: "deleted", isActive: true, deleted: true},
+		{username: "optedout", isActive: true, feedOptOut: true},
+	}
+
+	base := time.Date(2023, 12, 1, 10, 0, 0, 0, time.UTC)
+	var visibleIDs []string
+	var visibleUserID string
+	for i, u := range users {
+		userID := value_objects.NewUserID()
+		if u.username == "visible" {
+			visibleUserID = userID.String()
+		}
+		model := &models.User{
+			ID:           userID.String(),
+			Email:        u.username + "@example.com",
+			Username:     u.username,
+			IsActive:     u.isActive,
+			AuthProvider: "local",
+			Timezone:     "UTC",
+			FeedOptOut:   u.feedOptOut,
+			CreatedAt:    base,
+			UpdatedAt:    base,
+		}
+		if u.deleted {
+			model.DeletedAt = &base
+		}
+		require.NoError(t, db.Create(model).Error)
+
+		// One public and one private record per user
+		for j, status := range []string{"public", "private"} {
+			recordID := value_objects.NewMentalHealthRecordID().String()
+			createdAt := base.Add(time.Duration(i*2+j) * time.Minute)
+			require.NoError(t, db.Create(&models.MentalHealthRecord{
+				ID:          recordID,
+				UserID:      userID.String(),
+				HappyLevel:  6,
+				EnergyLevel: 6,
+				Status:      status,
+				CreatedAt:   createdAt,
+				UpdatedAt:   createdAt,
+			}).Error)
+			if u.username == "visible" && status == "public" {
+				visibleIDs = append(visibleIDs, recordID)
+			}
+		}
+	}
+
+	// A second public record for the visible user, newest of all
+	newestID := value_objects.NewMentalHealthRecordID().String()
+	newest := base.Add(time.Hour)
+	require.NoError(t, db.Create(&models.MentalHealthRecord{
+		ID:          newestID,
+		UserID:      visibleUserID,
+		HappyLevel:  9,
+		EnergyLevel: 8,
+		Status:      "public",
+		CreatedAt:   newest,
+		UpdatedAt:   newest,
+	}).Error)
+
+	t.Run("only public records of active, opted-in users", func(t *testing.T) {
+		entries, err := repo.GetPublicFeed(context.Background(), &repositories.PublicFeedFilter{Limit: 10})
+		require.NoError(t, err)
+		require.Len(t, entries, 2)
+		assert.Equal(t, newestID, entries[0].RecordID.String())
+		assert.Equal(t, visibleIDs[0], entries[1].RecordID.String())
+		for _, entry := range entries {
+			assert.Equal(t, "visible", entry.Username)
+		}
+	})
+
+	t.Run("cursor continues after the newest entry", func(t *testing.T) {
+		entries, err := repo.GetPublicFeed(context.Background(), &repositories.PublicFeedFilter{
+			Cursor: &repositories.MentalHealthRecordCursor{CreatedAt: newest, ID: newestID},
+			Limit:  10,
+		})
+		require.NoError(t, err)
+		require.Len(t, entries, 1)
+		assert.Equal(t, visibleIDs[0], entries[0].RecordID.String())
+	})
+}
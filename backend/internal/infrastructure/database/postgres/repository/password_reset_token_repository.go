@@ -0,0 +1,108 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/atdevten/peace/internal/domain/entities"
+	"github.com/atdevten/peace/internal/domain/repositories"
+	"github.com/atdevten/peace/internal/domain/value_objects"
+	"github.com/atdevten/peace/internal/infrastructure/database/postgres/models"
+
+	"gorm.io/gorm"
+)
+
+type PostgreSQLPasswordResetTokenRepository struct {
+	db *gorm.DB
+}
+
+func NewPostgreSQLPasswordResetTokenRepository(db *gorm.DB) repositories.PasswordResetTokenRepository {
+	return &PostgreSQLPasswordResetTokenRepository{
+		db: db,
+	}
+}
+
+func (r *PostgreSQLPasswordResetTokenRepository) Create(ctx context.Context, token *entities.PasswordResetToken) error {
+	model := models.PasswordResetToken{
+		ID:        token.ID().String(),
+		UserID:    token.UserID().String(),
+		TokenHash: token.TokenHash(),
+		ExpiresAt: token.ExpiresAt(),
+		UsedAt:    token.UsedAt(),
+		CreatedAt: token.CreatedAt(),
+	}
+
+	if err := r.db.WithContext(ctx).Create(&model).Error; err != nil {
+		return fmt.Errorf("r.db.Create: %w", err)
+	}
+	return nil
+}
+
+func (r *PostgreSQLPasswordResetTokenRepository) GetByTokenHash(ctx context.Context, tokenHash string) (*entities.PasswordResetToken, error) {
+	var model models.PasswordResetToken
+
+	result := r.db.WithContext(ctx).Where("token_hash = ?", tokenHash).First(&model)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, repositories.ErrPasswordResetTokenNotFound
+		}
+		return nil, fmt.Errorf("r.db.First: %w", result.Error)
+	}
+
+	return r.modelToEntity(model)
+}
+
+func (r *PostgreSQLPasswordResetTokenRepository) MarkUsed(ctx context.Context, token *entities.PasswordResetToken) error {
+	usedAt := time.Now()
+	if token.UsedAt() != nil {
+		usedAt = *token.UsedAt()
+	}
+
+	// Conditional update so two concurrent resets cannot both consume the token
+	result := r.db.WithContext(ctx).
+		Model(&models.PasswordResetToken{}).
+		Where("id = ? AND used_at IS NULL", token.ID().String()).
+		Update("used_at", usedAt)
+	if result.Error != nil {
+		return fmt.Errorf("r.db.Update: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return repositories.ErrPasswordResetTokenUsed
+	}
+	return nil
+}
+
+func (r *PostgreSQLPasswordResetTokenRepository) InvalidateByUserID(ctx context.Context, userID *value_objects.UserID) error {
+	err := r.db.WithContext(ctx).
+		Model(&models.PasswordResetToken{}).
+		Where("user_id = ? AND used_at IS NULL", userID.String()).
+		Update("used_at", time.Now()).Error
+	if err != nil {
+		return fmt.Errorf("r.db.Update: %w", err)
+	}
+	return nil
+}
+
+// Helper method to convert model to entity
+func (r *PostgreSQLPasswordResetTokenRepository) modelToEntity(model models.PasswordResetToken) (*entities.PasswordResetToken, error) {
+	id, err := value_objects.NewTokenIDFromString(model.ID)
+	if err != nil {
+		return nil, fmt.Errorf("value_objects.NewTokenIDFromString: %w", err)
+	}
+
+	userID, err := value_objects.NewUserIDFromString(model.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("value_objects.NewUserIDFromString: %w", err)
+	}
+
+	return entities.NewPasswordResetTokenFromRepository(
+		id,
+		userID,
+		model.TokenHash,
+		model.ExpiresAt,
+		model.UsedAt,
+		model.CreatedAt,
+	), nil
+}
@@ -0,0 +1,107 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/atdevten/peace/internal/domain/entities"
+	"github.com/atdevten/peace/internal/domain/repositories"
+	"github.com/atdevten/peace/internal/domain/value_objects"
+	"github.com/atdevten/peace/internal/infrastructure/database/postgres/models"
+	"github.com/atdevten/peace/testutils/helpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// setupPasswordResetTokenTestDB creates an in-memory SQLite database for reset token testing
+func setupPasswordResetTokenTestDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	require.NoError(t, err)
+
+	err = db.AutoMigrate(&models.PasswordResetToken{})
+	require.NoError(t, err)
+
+	return db
+}
+
+func createTestResetToken(t *testing.T, userID *value_objects.UserID) (*entities.PasswordResetToken, *value_objects.SecretToken) {
+	secret, err := value_objects.NewSecretToken()
+	require.NoError(t, err)
+
+	token, err := entities.NewPasswordResetToken(userID, secret, time.Hour)
+	require.NoError(t, err)
+
+	return token, secret
+}
+
+func TestPostgreSQLPasswordResetTokenRepository_CreateAndGetByTokenHash(t *testing.T) {
+	db := setupPasswordResetTokenTestDB(t)
+	repo := NewPostgreSQLPasswordResetTokenRepository(db)
+	ctx := context.Background()
+
+	userID := helpers.CreateTestUserID()
+	token, secret := createTestResetToken(t, userID)
+	require.NoError(t, repo.Create(ctx, token))
+
+	found, err := repo.GetByTokenHash(ctx, secret.Hash())
+	require.NoError(t, err)
+	assert.Equal(t, token.ID().String(), found.ID().String())
+	assert.Equal(t, userID.String(), found.UserID().String())
+	assert.False(t, found.IsUsed())
+
+	// The raw secret is not a valid lookup key
+	_, err = repo.GetByTokenHash(ctx, secret.String())
+	assert.ErrorIs(t, err, repositories.ErrPasswordResetTokenNotFound)
+}
+
+func TestPostgreSQLPasswordResetTokenRepository_MarkUsed(t *testing.T) {
+	db := setupPasswordResetTokenTestDB(t)
+	repo := NewPostgreSQLPasswordResetTokenRepository(db)
+	ctx := context.Background()
+
+	token, secret := createTestResetToken(t, helpers.CreateTestUserID())
+	require.NoError(t, repo.Create(ctx, token))
+
+	require.NoError(t, token.Use(time.Now()))
+	require.NoError(t, repo.MarkUsed(ctx, token))
+
+	found, err := repo.GetByTokenHash(ctx, secret.Hash())
+	require.NoError(t, err)
+	assert.True(t, found.IsUsed())
+
+	// A second consumption must fail
+	err = repo.MarkUsed(ctx, token)
+	assert.ErrorIs(t, err, repositories.ErrPasswordResetTokenUsed)
+}
+
+func TestPostgreSQLPasswordResetTokenRepository_InvalidateByUserID(t *testing.T) {
+	db := setupPasswordResetTokenTestDB(t)
+	repo := NewPostgreSQLPasswordResetTokenRepository(db)
+	ctx := context.Background()
+
+	userID := helpers.CreateTestUserID()
+	first, firstSecret := createTestResetToken(t, userID)
+	second, secondSecret := createTestResetToken(t, userID)
+	other, otherSecret := createTestResetToken(t, helpers.CreateTestUserID())
+	require.NoError(t, repo.Create(ctx, first))
+	require.NoError(t, repo.Create(ctx, second))
+	require.NoError(t, repo.Create(ctx, other))
+
+	require.NoError(t, repo.InvalidateByUserID(ctx, userID))
+
+	for _, secret := range []*value_objects.SecretToken{firstSecret, secondSecret} {
+		found, err := repo.GetByTokenHash(ctx, secret.Hash())
+		require.NoError(t, err)
+		assert.True(t, found.IsUsed())
+	}
+
+	found, err := repo.GetByTokenHash(ctx, otherSecret.Hash())
+	require.NoError(t, err)
+	assert.False(t, found.IsUsed())
+}
@@ -0,0 +1,176 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/atdevten/peace/internal/domain/entities"
+	"github.com/atdevten/peace/internal/domain/repositories"
+	"github.com/atdevten/peace/internal/domain/value_objects"
+	"github.com/atdevten/peace/internal/infrastructure/database/postgres/models"
+
+	"gorm.io/gorm"
+)
+
+type PostgreSQLPersonalAccessTokenRepository struct {
+	db *gorm.DB
+}
+
+func NewPostgreSQLPersonalAccessTokenRepository(db *gorm.DB) repositories.PersonalAccessTokenRepository {
+	return &PostgreSQLPersonalAccessTokenRepository{
+		db: db,
+	}
+}
+
+func (r *PostgreSQLPersonalAccessTokenRepository) Create(ctx context.Context, token *entities.PersonalAccessToken) error {
+	scopes := make([]string, 0, len(token.Scopes()))
+	for _, scope := range token.Scopes() {
+		scopes = append(scopes, scope.String())
+	}
+
+	model := models.PersonalAccessToken{
+		ID:         token.ID().String(),
+		UserID:     token.UserID().String(),
+		Name:       token.Name(),
+		TokenHash:  token.TokenHash(),
+		Scopes:     strings.Join(scopes, " "),
+		ExpiresAt:  token.ExpiresAt(),
+		LastUsedAt: token.LastUsedAt(),
+		CreatedAt:  token.CreatedAt(),
+	}
+
+	if err := r.db.WithContext(ctx).Create(&model).Error; err != nil {
+		return fmt.Errorf("r.db.Create: %w", err)
+	}
+	return nil
+}
+
+func (r *PostgreSQLPersonalAccessTokenRepository) GetByID(ctx context.Context, id *value_objects.TokenID) (*entities.PersonalAccessToken, error) {
+	return r.getWhere(ctx, "id = ?", id.String())
+}
+
+func (r *PostgreSQLPersonalAccessTokenRepository) GetByHash(ctx context.Context, tokenHash string) (*entities.PersonalAccessToken, error) {
+	return r.getWhere(ctx, "token_hash = ?", tokenHash)
+}
+
+func (r *PostgreSQLPersonalAccessTokenRepository) ListByUserID(ctx context.Context, userID *value_objects.UserID) ([]*entities.PersonalAccessToken, error) {
+	var tokenModels []models.PersonalAccessToken
+
+	err := r.db.WithContext(ctx).
+		Where("user_id = ?", userID.String()).
+		Order("created_at DESC").
+		Find(&tokenModels).Error
+	if err != nil {
+		return nil, fmt.Errorf("r.db.Find: %w", err)
+	}
+
+	tokens := make([]*entities.PersonalAccessToken, 0, len(tokenModels))
+	for _, model := range tokenModels {
+		token, err := r.modelToEntity(model)
+		if err != nil {
+			return nil, fmt.Errorf("modelToEntity: %w", err)
+		}
+		tokens = append(tokens, token)
+	}
+
+	return tokens, nil
+}
+
+func (r *PostgreSQLPersonalAccessTokenRepository) CountByUserID(ctx context.Context, userID *value_objects.UserID) (int64, error) {
+	var count int64
+
+	err := r.db.WithContext(ctx).
+		Model(&models.PersonalAccessToken{}).
+		Where("user_id = ?", userID.String()).
+		Count(&count).Error
+	if err != nil {
+		return 0, fmt.Errorf("r.db.Count: %w", err)
+	}
+
+	return count, nil
+}
+
+func (r *PostgreSQLPersonalAccessTokenRepository) UpdateLastUsedAt(ctx context.Context, id *value_objects.TokenID, lastUsedAt time.Time) error {
+	err := r.db.WithContext(ctx).
+		Model(&models.PersonalAccessToken{}).
+		Where("id = ?", id.String()).
+		Update("last_used_at", lastUsedAt).Error
+	if err != nil {
+		return fmt.Errorf("r.db.Update: %w", err)
+	}
+	return nil
+}
+
+func (r *PostgreSQLPersonalAccessTokenRepository) Delete(ctx context.Context, id *value_objects.TokenID) error {
+	result := r.db.WithContext(ctx).
+		Where("id = ?", id.String()).
+		Delete(&models.PersonalAccessToken{})
+	if result.Error != nil {
+		return fmt.Errorf("r.db.Delete: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return repositories.ErrPersonalAccessTokenNotFound
+	}
+	return nil
+}
+
+func (r *PostgreSQLPersonalAccessTokenRepository) DeleteByUserID(ctx context.Context, userID *value_objects.UserID) error {
+	err := r.db.WithContext(ctx).
+		Where("user_id = ?", userID.String()).
+		Delete(&models.PersonalAccessToken{}).Error
+	if err != nil {
+		return fmt.Errorf("r.db.Delete: %w", err)
+	}
+	return nil
+}
+
+func (r *PostgreSQLPersonalAccessTokenRepository) getWhere(ctx context.Context, query string, arg string) (*entities.PersonalAccessToken, error) {
+	var model models.PersonalAccessToken
+
+	result := r.db.WithContext(ctx).Where(query, arg).First(&model)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, repositories.ErrPersonalAccessTokenNotFound
+		}
+		return nil, fmt.Errorf("r.db.First: %w", result.Error)
+	}
+
+	return r.modelToEntity(model)
+}
+
+// Helper method to convert model to entity
+func (r *PostgreSQLPersonalAccessTokenRepository) modelToEntity(model models.PersonalAccessToken) (*entities.PersonalAccessToken, error) {
+	id, err := value_objects.NewTokenIDFromString(model.ID)
+	if err != nil {
+		return nil, fmt.Errorf("value_objects.NewTokenIDFromString: %w", err)
+	}
+
+	userID, err := value_objects.NewUserIDFromString(model.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("value_objects.NewUserIDFromString: %w", err)
+	}
+
+	fields := strings.Fields(model.Scopes)
+	scopes := make([]value_objects.TokenScope, 0, len(fields))
+	for _, field := range fields {
+		scope, err := value_objects.NewTokenScope(field)
+		if err != nil {
+			return nil, fmt.Errorf("value_objects.NewTokenScope: %w", err)
+		}
+		scopes = append(scopes, *scope)
+	}
+
+	return entities.NewPersonalAccessTokenFromRepository(
+		id,
+		userID,
+		model.Name,
+		model.TokenHash,
+		scopes,
+		model.ExpiresAt,
+		model.LastUsedAt,
+		model.CreatedAt,
+	), nil
+}
@@ -0,0 +1,143 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/atdevten/peace/internal/domain/entities"
+	"github.com/atdevten/peace/internal/domain/repositories"
+	"github.com/atdevten/peace/internal/domain/value_objects"
+	"github.com/atdevten/peace/internal/infrastructure/database/postgres/models"
+	"github.com/atdevten/peace/testutils/helpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// setupPersonalAccessTokenTestDB creates an in-memory SQLite database for personal access token testing
+func setupPersonalAccessTokenTestDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	require.NoError(t, err)
+
+	err = db.AutoMigrate(&models.PersonalAccessToken{})
+	require.NoError(t, err)
+
+	return db
+}
+
+func createTestPersonalAccessToken(t *testing.T, userID *value_objects.UserID, name string) (*entities.PersonalAccessToken, *value_objects.SecretToken) {
+	secret, err := value_objects.NewSecretToken()
+	require.NoError(t, err)
+
+	scopes := []value_objects.TokenScope{value_objects.ScopeRecordsRead, value_objects.ScopeRecordsWrite}
+	token, err := entities.NewPersonalAccessToken(userID, name, secret, scopes, 30*24*time.Hour)
+	require.NoError(t, err)
+	return token, secret
+}
+
+func TestPostgreSQLPersonalAccessTokenRepository_CreateAndGet(t *testing.T) {
+	db := setupPersonalAccessTokenTestDB(t)
+	repo := NewPostgreSQLPersonalAccessTokenRepository(db)
+	ctx := context.Background()
+
+	token, secret := createTestPersonalAccessToken(t, helpers.CreateTestUserID(), "notebook")
+	require.NoError(t, repo.Create(ctx, token))
+
+	found, err := repo.GetByHash(ctx, secret.Hash())
+	require.NoError(t, err)
+	assert.Equal(t, token.ID().String(), found.ID().String())
+	assert.Equal(t, token.UserID().String(), found.UserID().String())
+	assert.Equal(t, "notebook", found.Name())
+	assert.Equal(t, []value_objects.TokenScope{value_objects.ScopeRecordsRead, value_objects.ScopeRecordsWrite}, found.Scopes())
+	assert.WithinDuration(t, token.ExpiresAt(), found.ExpiresAt(), time.Second)
+	assert.Nil(t, found.LastUsedAt())
+
+	found, err = repo.GetByID(ctx, token.ID())
+	require.NoError(t, err)
+	assert.Equal(t, secret.Hash(), found.TokenHash())
+
+	_, err = repo.GetByHash(ctx, "unknown")
+	assert.ErrorIs(t, err, repositories.ErrPersonalAccessTokenNotFound)
+	_, err = repo.GetByID(ctx, value_objects.NewTokenID())
+	assert.ErrorIs(t, err, repositories.ErrPersonalAccessTokenNotFound)
+}
+
+func TestPostgreSQLPersonalAccessTokenRepository_ListAndCountByUserID(t *testing.T) {
+	db := setupPersonalAccessTokenTestDB(t)
+	repo := NewPostgreSQLPersonalAccessTokenRepository(db)
+	ctx := context.Background()
+
+	userID := helpers.CreateTestUserID()
+	older, _ := createTestPersonalAccessToken(t, userID, "older")
+	require.NoError(t, repo.Create(ctx, older))
+	time.Sleep(10 * time.Millisecond)
+	newer, _ := createTestPersonalAccessToken(t, userID, "newer")
+	require.NoError(t, repo.Create(ctx, newer))
+	otherUser, _ := createTestPersonalAccessToken(t, value_objects.NewUserID(), "other")
+	require.NoError(t, repo.Create(ctx, otherUser))
+
+	tokens, err := repo.ListByUserID(ctx, userID)
+	require.NoError(t, err)
+	require.Len(t, tokens, 2)
+	assert.Equal(t, "newer", tokens[0].Name())
+	assert.Equal(t, "older", tokens[1].Name())
+
+	count, err := repo.CountByUserID(ctx, userID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+}
+
+func TestPostgreSQLPersonalAccessTokenRepository_UpdateLastUsedAtAndDelete(t *testing.T) {
+	db := setupPersonalAccessTokenTestDB(t)
+	repo := NewPostgreSQLPersonalAccessTokenRepository(db)
+	ctx := context.Background()
+
+	token, _ := createTestPersonalAccessToken(t, helpers.CreateTestUserID(), "dashboard")
+	require.NoError(t, repo.Create(ctx, token))
+
+	usedAt := time.Now()
+	require.NoError(t, repo.UpdateLastUsedAt(ctx, token.ID(), usedAt))
+	found, err := repo.GetByID(ctx, token.ID())
+	require.NoError(t, err)
+	require.NotNil(t, found.LastUsedAt())
+	assert.WithinDuration(t, usedAt, *found.LastUsedAt(), time.Second)
+
+	require.NoError(t, repo.Delete(ctx, token.ID()))
+	_, err = repo.GetByID(ctx, token.ID())
+	assert.ErrorIs(t, err, repositories.ErrPersonalAccessTokenNotFound)
+	assert.ErrorIs(t, repo.Delete(ctx, token.ID()), repositories.ErrPersonalAccessTokenNotFound)
+}
+
+func TestPostgreSQLPersonalAccessTokenRepository_DeleteByUserID(t *testing.T) {
+	db := setupPersonalAccessTokenTestDB(t)
+	repo := NewPostgreSQLPersonalAccessTokenRepository(db)
+	ctx := context.Background()
+
+	userID := helpers.CreateTestUserID()
+	otherUserID := value_objects.NewUserID()
+	for _, name := range []string{"dashboard", "backup"} {
+		token, _ := createTestPersonalAccessToken(t, userID, name)
+		require.NoError(t, repo.Create(ctx, token))
+	}
+	other, _ := createTestPersonalAccessToken(t, otherUserID, "dashboard")
+	require.NoError(t, repo.Create(ctx, other))
+
+	require.NoError(t, repo.DeleteByUserID(ctx, userID))
+
+	count, err := repo.CountByUserID(ctx, userID)
+	require.NoError(t, err)
+	assert.Zero(t, count)
+
+	// Tokens of other users are left alone
+	count, err = repo.CountByUserID(ctx, otherUserID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+
+	// Users without tokens are fine too
+	assert.NoError(t, repo.DeleteByUserID(ctx, userID))
+}
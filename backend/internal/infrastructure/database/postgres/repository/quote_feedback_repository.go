@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/atdevten/peace/internal/domain/entities"
+	"github.com/atdevten/peace/internal/domain/repositories"
+	"github.com/atdevten/peace/internal/domain/value_objects"
+	"github.com/atdevten/peace/internal/infrastructure/database/postgres/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type PostgreSQLQuoteFeedbackRepository struct {
+	db *gorm.DB
+}
+
+func NewPostgreSQLQuoteFeedbackRepository(db *gorm.DB) repositories.QuoteFeedbackRepository {
+	return &PostgreSQLQuoteFeedbackRepository{
+		db: db,
+	}
+}
+
+func (r *PostgreSQLQuoteFeedbackRepository) Save(ctx context.Context, feedback *entities.QuoteFeedback) error {
+	model := models.QuoteFeedback{
+		UserID:    feedback.UserID().String(),
+		QuoteID:   feedback.QuoteID().Value(),
+		Signal:    feedback.Signal().String(),
+		CreatedAt: feedback.CreatedAt(),
+		UpdatedAt: feedback.UpdatedAt(),
+	}
+
+	// Keep the first reaction time and replace the signal
+	conflict := clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}, {Name: "quote_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"signal", "updated_at"}),
+	}
+
+	if err := r.db.WithContext(ctx).Clauses(conflict).Create(&model).Error; err != nil {
+		return fmt.Errorf("r.db.Create: %w", err)
+	}
+	return nil
+}
+
+func (r *PostgreSQLQuoteFeedbackRepository) ListByUser(ctx context.Context, userID *value_objects.UserID) ([]*entities.QuoteFeedback, error) {
+	var feedbackModels []models.QuoteFeedback
+
+	err := r.db.WithContext(ctx).
+		Where("user_id = ?", userID.String()).
+		Order("updated_at DESC").
+		Order("quote_id DESC").
+		Find(&feedbackModels).Error
+	if err != nil {
+		return nil, fmt.Errorf("r.db.Find: %w", err)
+	}
+
+	feedback := make([]*entities.QuoteFeedback, 0, len(feedbackModels))
+	for _, model := range feedbackModels {
+		entity, err := r.modelToEntity(model)
+		if err != nil {
+			return nil, fmt.Errorf("modelToEntity: %w", err)
+		}
+		feedback = append(feedback, entity)
+	}
+
+	return feedback, nil
+}
+
+// Helper method to convert model to entity
+func (r *PostgreSQLQuoteFeedbackRepository) modelToEntity(model models.QuoteFeedback) (*entities.QuoteFeedback, error) {
+	userID, err := value_objects.NewUserIDFromString(model.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("value_objects.NewUserIDFromString: %w", err)
+	}
+
+	return entities.NewQuoteFeedbackFromRepository(
+		userID,
+		value_objects.NewQuoteIDFromInt(model.QuoteID),
+		value_objects.QuoteFeedbackSignal(model.Signal),
+		model.CreatedAt,
+		model.UpdatedAt,
+	), nil
+}
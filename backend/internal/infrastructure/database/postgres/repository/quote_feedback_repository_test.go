@@ -0,0 +1,76 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/atdevten/peace/internal/domain/entities"
+	"github.com/atdevten/peace/internal/domain/repositories"
+	"github.com/atdevten/peace/internal/domain/value_objects"
+	"github.com/atdevten/peace/internal/infrastructure/database/postgres/models"
+	"github.com/atdevten/peace/testutils/helpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// setupQuoteFeedbackTestDB creates an in-memory SQLite database for quote feedback testing
+func setupQuoteFeedbackTestDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	require.NoError(t, err)
+
+	err = db.AutoMigrate(&models.QuoteFeedback{})
+	require.NoError(t, err)
+
+	return db
+}
+
+func saveTestQuoteFeedback(t *testing.T, repo repositories.QuoteFeedbackRepository, userID *value_objects.UserID, quoteID int, signal string) {
+	feedback, err := entities.NewQuoteFeedback(userID, value_objects.NewQuoteIDFromInt(quoteID), signal)
+	require.NoError(t, err)
+	require.NoError(t, repo.Save(context.Background(), feedback))
+}
+
+func TestPostgreSQLQuoteFeedbackRepository_SaveAndListByUser(t *testing.T) {
+	db := setupQuoteFeedbackTestDB(t)
+	repo := NewPostgreSQLQuoteFeedbackRepository(db)
+	ctx := context.Background()
+
+	userID := helpers.CreateTestUserID()
+	otherID := helpers.CreateTestUserID()
+	saveTestQuoteFeedback(t, repo, userID, 7, "skip")
+	time.Sleep(10 * time.Millisecond)
+	saveTestQuoteFeedback(t, repo, userID, 8, "like")
+	saveTestQuoteFeedback(t, repo, otherID, 7, "like")
+
+	feedback, err := repo.ListByUser(ctx, userID)
+	require.NoError(t, err)
+	require.Len(t, feedback, 2)
+	assert.Equal(t, 8, feedback[0].QuoteID().Value())
+	assert.Equal(t, value_objects.QuoteFeedbackLike, feedback[0].Signal())
+	assert.Equal(t, 7, feedback[1].QuoteID().Value())
+	assert.Equal(t, value_objects.QuoteFeedbackSkip, feedback[1].Signal())
+	assert.Equal(t, userID.String(), feedback[1].UserID().String())
+
+	// A new signal for the same quote replaces the old one and moves it first
+	time.Sleep(10 * time.Millisecond)
+	saveTestQuoteFeedback(t, repo, userID, 7, "like")
+
+	feedback, err = repo.ListByUser(ctx, userID)
+	require.NoError(t, err)
+	require.Len(t, feedback, 2)
+	assert.Equal(t, 7, feedback[0].QuoteID().Value())
+	assert.Equal(t, value_objects.QuoteFeedbackLike, feedback[0].Signal())
+	assert.True(t, feedback[0].CreatedAt().Before(feedback[0].UpdatedAt()))
+
+	// Other users keep their own signals
+	feedback, err = repo.ListByUser(ctx, otherID)
+	require.NoError(t, err)
+	require.Len(t, feedback, 1)
+	assert.Equal(t, value_objects.QuoteFeedbackLike, feedback[0].Signal())
+}
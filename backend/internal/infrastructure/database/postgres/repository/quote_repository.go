@@ -32,6 +32,16 @@ func (r *QuoteRepository) Create(ctx context.Context, quote *entities.Quote) err
 		return fmt.Errorf("failed to create quote: %w", err)
 	}
 
+	// Update the entity with the generated ID
+	*quote = *entities.NewQuoteFromExisting(
+		value_objects.NewQuoteIDFromInt(model.ID),
+		quote.Content(),
+		quote.Author(),
+		model.CreatedAt,
+		model.UpdatedAt,
+		nil,
+	)
+
 	return nil
 }
 
@@ -50,23 +60,50 @@ func (r *QuoteRepository) GetByID(ctx context.Context, id *value_objects.QuoteID
 
 func (r *QuoteRepository) GetByFilter(ctx context.Context, filter *repositories.QuoteFilter) ([]*entities.Quote, error) {
 	var models []models.Quote
-	query := r.db.WithContext(ctx).Where("deleted_at IS NULL")
+	query := r.filterQuery(ctx, filter)
+
+	// Order by the sort key with the id as tie-breaker, so that pages never overlap
+	sortKey := "created_at"
+	switch filter.Sort {
+	case value_objects.QuoteSortAuthor:
+		sortKey = "author"
+	case value_objects.QuoteSortRandom:
+		sortKey = fmt.Sprintf("(CAST(id AS BIGINT) * %d %% %d)", repositories.QuoteShuffleMultiplier(filter.Seed), int64(repositories.QuoteShuffleModulus))
+	}
 
-	if filter.ID != nil {
-		query = query.Where("id = ?", filter.ID.Value())
+	direction, comparison := "ASC", ">"
+	if filter.Descending {
+		direction, comparison = "DESC", "<"
 	}
-	if filter.Author != nil {
-		query = query.Where("author ILIKE ?", "%"+*filter.Author+"%")
+
+	// Keyset pagination: continue strictly after the cursor in the listing order
+	if filter.Cursor != nil {
+		var cursorKey interface{}
+		switch filter.Sort {
+		case value_objects.QuoteSortAuthor:
+			cursorKey = filter.Cursor.Author
+		case value_objects.QuoteSortRandom:
+			cursorKey = repositories.QuoteShuffleKey(filter.Seed, filter.Cursor.ID)
+		default:
+			cursorKey = filter.Cursor.CreatedAt
+		}
+		condition := fmt.Sprintf("(%[1]s %[2]s ? OR (%[1]s = ? AND id %[2]s ?))", sortKey, comparison)
+		query = query.Where(condition, cursorKey, cursorKey, filter.Cursor.ID)
 	}
-	if filter.Content != nil {
-		query = query.Where("content ILIKE ?", "%"+*filter.Content+"%")
+
+	query = query.Order(sortKey + " " + direction).Order("id " + direction)
+	if filter.Offset > 0 {
+		query = query.Offset(filter.Offset)
+	}
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit)
 	}
 
-	if err := query.Order("created_at DESC").Find(&models).Error; err != nil {
+	if err := query.Find(&models).Error; err != nil {
 		return nil, fmt.Errorf("failed to get quotes: %w", err)
 	}
 
-	var quotes []*entities.Quote
+	quotes := make([]*entities.Quote, 0, len(models))
 	for _, model := range models {
 		quote, err := r.mapToEntity(&model)
 		if err != nil {
@@ -78,23 +115,175 @@ func (r *QuoteRepository) GetByFilter(ctx context.Context, filter *repositories.
 	return quotes, nil
 }
 
-func (r *QuoteRepository) GetAll(ctx context.Context) ([]*entities.Quote, error) {
-	var models []models.Quote
+func (r *QuoteRepository) Count(ctx context.Context, filter *repositories.QuoteFilter) (int64, error) {
+	var total int64
 
-	if err := r.db.WithContext(ctx).Where("deleted_at IS NULL").Order("created_at DESC").Find(&models).Error; err != nil {
-		return nil, fmt.Errorf("failed to get quotes: %w", err)
+	if err := r.filterQuery(ctx, filter).Count(&total).Error; err != nil {
+		return 0, fmt.Errorf("failed to count quotes: %w", err)
 	}
 
-	var quotes []*entities.Quote
-	for _, model := range models {
-		quote, err := r.mapToEntity(&model)
+	return total, nil
+}
+
+// filterQuery selects the non-deleted quotes matching the filter
+func (r *QuoteRepository) filterQuery(ctx context.Context, filter *repositories.QuoteFilter) *gorm.DB {
+	query := r.db.WithContext(ctx).Model(&models.Quote{}).Where("deleted_at IS NULL")
+
+	if filter.ID != nil {
+		query = query.Where("id = ?", filter.ID.Value())
+	}
+	if len(filter.ExcludeIDs) > 0 {
+		excluded := make([]int, 0, len(filter.ExcludeIDs))
+		for _, id := range filter.ExcludeIDs {
+			excluded = append(excluded, id.Value())
+		}
+		query = query.Where("id NOT IN ?", excluded)
+	}
+	if filter.Author != nil {
+		query = query.Where("author ILIKE ?", "%"+*filter.Author+"%")
+	}
+	if filter.Content != nil {
+		query = query.Where("content ILIKE ?", "%"+*filter.Content+"%")
+	}
+	if len(filter.TagNames) > 0 {
+		// Keep quotes that carry any requested tag
+		query = query.Where(`id IN (
+			SELECT quote_tags.quote_id
+			FROM quote_tags
+			JOIN tags ON tags.id = quote_tags.tag_id
+			WHERE tags.name IN ? AND tags.deleted_at IS NULL
+		)`, filter.TagNames)
+	}
+
+	return query
+}
+
+// quoteSearchScope matches non-deleted quotes by full text or by an author name similar to the
+// text; both placeholders take the search text
+const quoteSearchScope = `q.deleted_at IS NULL
+	AND (q.search_vector @@ websearch_to_tsquery('english', ?) OR q.author % ?)`
+
+// quoteHeadlineOptions marks the matched terms and keeps up to two short passages of long quotes
+var quoteHeadlineOptions = fmt.Sprintf(`StartSel="%s", StopSel="%s", MaxWords=30, MinWords=12, MaxFragments=2, FragmentDelimiter=" ... "`,
+	repositories.QuoteHighlightStart, repositories.QuoteHighlightStop)
+
+type quoteSearchRow struct {
+	ID        int
+	Content   string
+	Author    string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	Rank      float64
+	Snippet   string
+}
+
+type quoteFacetRow struct {
+	Value string
+	Count int64
+}
+
+func (r *QuoteRepository) Search(ctx context.Context, search *repositories.QuoteSearch) (*repositories.QuoteSearchResult, error) {
+	db := r.db.WithContext(ctx)
+
+	scope := quoteSearchScope
+	scopeArgs := []interface{}{search.Text, search.Text}
+	if search.Author != nil {
+		scope += " AND q.author = ?"
+		scopeArgs = append(scopeArgs, *search.Author)
+	}
+	if search.Tag != nil {
+		scope += ` AND EXISTS (
+			SELECT 1 FROM quote_tags fqt JOIN tags ft ON ft.id = fqt.tag_id
+			WHERE fqt.quote_id = q.id AND ft.name = ? AND ft.deleted_at IS NULL)`
+		scopeArgs = append(scopeArgs, *search.Tag)
+	}
+
+	// Rank by text relevance plus author similarity, then highlight only the quotes of the page
+	var rows []quoteSearchRow
+	hitsQuery := `
+		SELECT q.id, q.content, q.author, q.created_at, q.updated_at, hits.rank,
+			ts_headline('english', q.content, websearch_to_tsquery('english', ?), ?) AS snippet
+		FROM (
+			SELECT q.id,
+				(ts_rank_cd(q.search_vector, websearch_to_tsquery('english', ?)) + COALESCE(similarity(q.author, ?), 0))::float8 AS rank
+			FROM quotes q
+			WHERE ` + scope + `
+			ORDER BY rank DESC, q.id DESC
+			LIMIT ? OFFSET ?
+		) hits
+		JOIN quotes q ON q.id = hits.id
+		ORDER BY hits.rank DESC, q.id DESC`
+	hitsArgs := append([]interface{}{search.Text, quoteHeadlineOptions, search.Text, search.Text}, scopeArgs...)
+	hitsArgs = append(hitsArgs, search.Limit, search.Offset)
+	if err := db.Raw(hitsQuery, hitsArgs...).Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("r.db.Raw hits: %w", err)
+	}
+
+	var total int64
+	totalQuery := `SELECT COUNT(*) FROM quotes q WHERE ` + scope
+	if err := db.Raw(totalQuery, scopeArgs...).Scan(&total).Error; err != nil {
+		return nil, fmt.Errorf("r.db.Raw total: %w", err)
+	}
+
+	// Most frequent authors and tags among all matches
+	var authors []quoteFacetRow
+	authorsQuery := `
+		SELECT q.author AS value, COUNT(*) AS count
+		FROM quotes q
+		WHERE q.author IS NOT NULL AND ` + scope + `
+		GROUP BY q.author
+		ORDER BY count DESC, q.author
+		LIMIT ?`
+	if err := db.Raw(authorsQuery, append(scopeArgs, search.FacetLimit)...).Scan(&authors).Error; err != nil {
+		return nil, fmt.Errorf("r.db.Raw authors: %w", err)
+	}
+
+	var tags []quoteFacetRow
+	tagsQuery := `
+		SELECT t.name AS value, COUNT(*) AS count
+		FROM quotes q
+		JOIN quote_tags qt ON qt.quote_id = q.id
+		JOIN tags t ON t.id = qt.tag_id AND t.deleted_at IS NULL
+		WHERE ` + scope + `
+		GROUP BY t.name
+		ORDER BY count DESC, t.name
+		LIMIT ?`
+	if err := db.Raw(tagsQuery, append(scopeArgs, search.FacetLimit)...).Scan(&tags).Error; err != nil {
+		return nil, fmt.Errorf("r.db.Raw tags: %w", err)
+	}
+
+	// Convert rows to domain results
+	result := &repositories.QuoteSearchResult{
+		Hits:    make([]repositories.QuoteSearchHit, 0, len(rows)),
+		Total:   total,
+		Authors: make([]repositories.QuoteFacet, 0, len(authors)),
+		Tags:    make([]repositories.QuoteFacet, 0, len(tags)),
+	}
+	for _, row := range rows {
+		quote, err := r.mapToEntity(&models.Quote{
+			ID:        row.ID,
+			Content:   row.Content,
+			Author:    row.Author,
+			CreatedAt: row.CreatedAt,
+			UpdatedAt: row.UpdatedAt,
+		})
 		if err != nil {
 			return nil, err
 		}
-		quotes = append(quotes, quote)
+		result.Hits = append(result.Hits, repositories.QuoteSearchHit{
+			Quote:   quote,
+			Rank:    row.Rank,
+			Snippet: row.Snippet,
+		})
+	}
+	for _, row := range authors {
+		result.Authors = append(result.Authors, repositories.QuoteFacet{Value: row.Value, Count: row.Count})
+	}
+	for _, row := range tags {
+		result.Tags = append(result.Tags, repositories.QuoteFacet{Value: row.Value, Count: row.Count})
 	}
 
-	return quotes, nil
+	return result, nil
 }
 
 func (r *QuoteRepository) GetRandom(ctx context.Context) (*entities.Quote, error) {
@@ -0,0 +1,188 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/atdevten/peace/internal/domain/entities"
+	"github.com/atdevten/peace/internal/domain/repositories"
+	"github.com/atdevten/peace/internal/domain/value_objects"
+	"github.com/atdevten/peace/internal/infrastructure/database/postgres/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// setupQuoteTestDB creates an in-memory SQLite database for quote testing
+func setupQuoteTestDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	require.NoError(t, err)
+
+	err = db.AutoMigrate(&models.Quote{}, &models.Tag{}, &models.QuoteTag{})
+	require.NoError(t, err)
+
+	return db
+}
+
+// createTestQuotes stores one quote per author, in order
+func createTestQuotes(t *testing.T, repo repositories.QuoteRepository, authors ...string) []*entities.Quote {
+	quotes := make([]*entities.Quote, 0, len(authors))
+	for _, author := range authors {
+		quote, err := entities.NewQuote("Quote by "+author, author)
+		require.NoError(t, err)
+		require.NoError(t, repo.Create(context.Background(), quote))
+		quotes = append(quotes, quote)
+	}
+	return quotes
+}
+
+func quoteIDs(quotes []*entities.Quote) []int {
+	ids := make([]int, 0, len(quotes))
+	for _, quote := range quotes {
+		ids = append(ids, quote.ID().Value())
+	}
+	return ids
+}
+
+func TestQuoteRepository_GetByFilterOrders(t *testing.T) {
+	db := setupQuoteTestDB(t)
+	repo := NewPostgreSQLQuoteRepository(db)
+	ctx := context.Background()
+
+	quotes := createTestQuotes(t, repo, "Seneca", "Aurelius", "Epictetus")
+
+	tests := []struct {
+		name    string
+		filter  repositories.QuoteFilter
+		wantIDs []int
+	}{
+		{
+			name:    "newest first",
+			filter:  repositories.QuoteFilter{Sort: value_objects.QuoteSortCreatedAt, Descending: true},
+			wantIDs: quoteIDs([]*entities.Quote{quotes[2], quotes[1], quotes[0]}),
+		},
+		{
+			name:    "by author",
+			filter:  repositories.QuoteFilter{Sort: value_objects.QuoteSortAuthor},
+			wantIDs: quoteIDs([]*entities.Quote{quotes[1], quotes[2], quotes[0]}),
+		},
+		{
+			name:    "excluding quotes",
+			filter:  repositories.QuoteFilter{ExcludeIDs: []*value_objects.QuoteID{quotes[0].ID(), quotes[2].ID()}},
+			wantIDs: quoteIDs([]*entities.Quote{quotes[1]}),
+		},
+		{
+			name:    "by author with offset",
+			filter:  repositories.QuoteFilter{Sort: value_objects.QuoteSortAuthor, Offset: 1, Limit: 1},
+			wantIDs: quoteIDs([]*entities.Quote{quotes[2]}),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			found, err := repo.GetByFilter(ctx, &tt.filter)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantIDs, quoteIDs(found))
+		})
+	}
+}
+
+func TestQuoteRepository_GetByFilterPages(t *testing.T) {
+	db := setupQuoteTestDB(t)
+	repo := NewPostgreSQLQuoteRepository(db)
+	ctx := context.Background()
+
+	createTestQuotes(t, repo, "Seneca", "Aurelius", "Epictetus", "Aurelius", "Zeno")
+
+	for _, sort := range []value_objects.QuoteSort{value_objects.QuoteSortCreatedAt, value_objects.QuoteSortAuthor, value_objects.QuoteSortRandom} {
+		t.Run(sort.String(), func(t *testing.T) {
+			all, err := repo.GetByFilter(ctx, &repositories.QuoteFilter{Sort: sort, Seed: 42})
+			require.NoError(t, err)
+			require.Len(t, all, 5)
+
+			// Keyset pages of two walk through the same order without gaps or repeats
+			var paged []*entities.Quote
+			filter := repositories.QuoteFilter{Sort: sort, Seed: 42, Limit: 2}
+			for {
+				page, err := repo.GetByFilter(ctx, &filter)
+				require.NoError(t, err)
+				paged = append(paged, page...)
+				if len(page) < filter.Limit {
+					break
+				}
+				last := page[len(page)-1]
+				filter.Cursor = &repositories.QuoteCursor{
+					CreatedAt: last.CreatedAt(),
+					Author:    last.Author().Value(),
+					ID:        last.ID().Value(),
+				}
+			}
+			assert.Equal(t, quoteIDs(all), quoteIDs(paged))
+		})
+	}
+}
+
+func TestQuoteRepository_RandomOrderSeed(t *testing.T) {
+	db := setupQuoteTestDB(t)
+	repo := NewPostgreSQLQuoteRepository(db)
+	ctx := context.Background()
+
+	createTestQuotes(t, repo, "A", "B", "C", "D", "E", "F", "G", "H")
+
+	first, err := repo.GetByFilter(ctx, &repositories.QuoteFilter{Sort: value_objects.QuoteSortRandom, Seed: 1})
+	require.NoError(t, err)
+	again, err := repo.GetByFilter(ctx, &repositories.QuoteFilter{Sort: value_objects.QuoteSortRandom, Seed: 1})
+	require.NoError(t, err)
+	other, err := repo.GetByFilter(ctx, &repositories.QuoteFilter{Sort: value_objects.QuoteSortRandom, Seed: 2})
+	require.NoError(t, err)
+
+	// The same seed repeats its order, another seed shuffles the same quotes differently
+	assert.Equal(t, quoteIDs(first), quoteIDs(again))
+	assert.ElementsMatch(t, quoteIDs(first), quoteIDs(other))
+	assert.NotEqual(t, quoteIDs(first), quoteIDs(other))
+}
+
+func TestQuoteRepository_GetByFilterTags(t *testing.T) {
+	db := setupQuoteTestDB(t)
+	repo := NewPostgreSQLQuoteRepository(db)
+	ctx := context.Background()
+
+	quotes := createTestQuotes(t, repo, "Seneca", "Aurelius", "Epictetus")
+
+	tags := []models.Tag{{Name: "hope"}, {Name: "motivation"}, {Name: "retired"}}
+	require.NoError(t, db.Create(&tags).Error)
+	require.NoError(t, db.Create(&[]models.QuoteTag{
+		{QuoteID: quotes[0].ID().Value(), TagID: tags[0].ID},
+		{QuoteID: quotes[1].ID().Value(), TagID: tags[1].ID},
+		{QuoteID: quotes[1].ID().Value(), TagID: tags[0].ID},
+		{QuoteID: quotes[2].ID().Value(), TagID: tags[2].ID},
+	}).Error)
+	require.NoError(t, db.Delete(&tags[2]).Error)
+
+	// Quotes carrying any of the tags match once; deleted tags match nothing
+	found, err := repo.GetByFilter(ctx, &repositories.QuoteFilter{TagNames: []string{"hope", "motivation", "retired"}})
+	require.NoError(t, err)
+	assert.Equal(t, quoteIDs(quotes[:2]), quoteIDs(found))
+
+	found, err = repo.GetByFilter(ctx, &repositories.QuoteFilter{TagNames: []string{"motivation"}})
+	require.NoError(t, err)
+	assert.Equal(t, quoteIDs(quotes[1:2]), quoteIDs(found))
+}
+
+func TestQuoteRepository_Count(t *testing.T) {
+	db := setupQuoteTestDB(t)
+	repo := NewPostgreSQLQuoteRepository(db)
+	ctx := context.Background()
+
+	quotes := createTestQuotes(t, repo, "Seneca", "Aurelius", "Epictetus")
+	require.NoError(t, repo.Delete(ctx, quotes[0].ID()))
+
+	// Deleted quotes and the page of the filter are not counted
+	total, err := repo.Count(ctx, &repositories.QuoteFilter{Limit: 1, Offset: 1})
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), total)
+}
@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/atdevten/peace/internal/domain/entities"
+	"github.com/atdevten/peace/internal/domain/repositories"
+	"github.com/atdevten/peace/internal/domain/value_objects"
+	"github.com/atdevten/peace/internal/infrastructure/database/postgres/models"
+
+	"gorm.io/gorm"
+)
+
+type PostgreSQLRecoveryCodeRepository struct {
+	db *gorm.DB
+}
+
+func NewPostgreSQLRecoveryCodeRepository(db *gorm.DB) repositories.RecoveryCodeRepository {
+	return &PostgreSQLRecoveryCodeRepository{
+		db: db,
+	}
+}
+
+func (r *PostgreSQLRecoveryCodeRepository) ReplaceByUserID(ctx context.Context, userID *value_objects.UserID, codes []*entities.RecoveryCode) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", userID.String()).Delete(&models.RecoveryCode{}).Error; err != nil {
+			return fmt.Errorf("tx.Delete: %w", err)
+		}
+
+		if len(codes) == 0 {
+			return nil
+		}
+
+		codeModels := make([]*models.RecoveryCode, len(codes))
+		for i, code := range codes {
+			codeModels[i] = &models.RecoveryCode{
+				ID:        code.ID().String(),
+				UserID:    code.UserID().String(),
+				CodeHash:  code.CodeHash(),
+				UsedAt:    code.UsedAt(),
+				CreatedAt: code.CreatedAt(),
+			}
+		}
+
+		if err := tx.Create(&codeModels).Error; err != nil {
+			return fmt.Errorf("tx.Create: %w", err)
+		}
+		return nil
+	})
+}
+
+func (r *PostgreSQLRecoveryCodeRepository) Use(ctx context.Context, userID *value_objects.UserID, codeHash string) error {
+	// Conditional update so a code can complete only one login
+	result := r.db.WithContext(ctx).
+		Model(&models.RecoveryCode{}).
+		Where("user_id = ? AND code_hash = ? AND used_at IS NULL", userID.String(), codeHash).
+		Update("used_at", time.Now())
+	if result.Error != nil {
+		return fmt.Errorf("r.db.Update: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return repositories.ErrRecoveryCodeNotFound
+	}
+	return nil
+}
+
+func (r *PostgreSQLRecoveryCodeRepository) CountUnused(ctx context.Context, userID *value_objects.UserID) (int, error) {
+	var count int64
+
+	err := r.db.WithContext(ctx).
+		Model(&models.RecoveryCode{}).
+		Where("user_id = ? AND used_at IS NULL", userID.String()).
+		Count(&count).Error
+	if err != nil {
+		return 0, fmt.Errorf("r.db.Count: %w", err)
+	}
+
+	return int(count), nil
+}
+
+func (r *PostgreSQLRecoveryCodeRepository) DeleteByUserID(ctx context.Context, userID *value_objects.UserID) error {
+	if err := r.db.WithContext(ctx).Where("user_id = ?", userID.String()).Delete(&models.RecoveryCode{}).Error; err != nil {
+		return fmt.Errorf("r.db.Delete: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/atdevten/peace/internal/domain/entities"
+	"github.com/atdevten/peace/internal/domain/repositories"
+	"github.com/atdevten/peace/internal/domain/value_objects"
+	"github.com/atdevten/peace/internal/infrastructure/database/postgres/models"
+	"github.com/atdevten/peace/testutils/helpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// setupRecoveryCodeTestDB creates an in-memory SQLite database for recovery code testing
+func setupRecoveryCodeTestDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	require.NoError(t, err)
+
+	err = db.AutoMigrate(&models.RecoveryCode{})
+	require.NoError(t, err)
+
+	return db
+}
+
+func createTestRecoveryCodes(t *testing.T, userID *value_objects.UserID, n int) ([]*entities.RecoveryCode, []*value_objects.RecoveryCode) {
+	codes := make([]*entities.RecoveryCode, 0, n)
+	plain := make([]*value_objects.RecoveryCode, 0, n)
+	for i := 0; i < n; i++ {
+		recoveryCode, err := value_objects.NewRecoveryCode()
+		require.NoError(t, err)
+		code, err := entities.NewRecoveryCode(userID, recoveryCode)
+		require.NoError(t, err)
+		codes = append(codes, code)
+		plain = append(plain, recoveryCode)
+	}
+	return codes, plain
+}
+
+func TestPostgreSQLRecoveryCodeRepository_ReplaceAndUse(t *testing.T) {
+	db := setupRecoveryCodeTestDB(t)
+	repo := NewPostgreSQLRecoveryCodeRepository(db)
+	ctx := context.Background()
+
+	userID := helpers.CreateTestUserID()
+	oldCodes, oldPlain := createTestRecoveryCodes(t, userID, 3)
+	require.NoError(t, repo.ReplaceByUserID(ctx, userID, oldCodes))
+
+	newCodes, newPlain := createTestRecoveryCodes(t, userID, 2)
+	require.NoError(t, repo.ReplaceByUserID(ctx, userID, newCodes))
+
+	count, err := repo.CountUnused(ctx, userID)
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+
+	// Replaced codes no longer work, new ones work exactly once
+	assert.ErrorIs(t, repo.Use(ctx, userID, oldPlain[0].Hash()), repositories.ErrRecoveryCodeNotFound)
+	require.NoError(t, repo.Use(ctx, userID, newPlain[0].Hash()))
+	assert.ErrorIs(t, repo.Use(ctx, userID, newPlain[0].Hash()), repositories.ErrRecoveryCodeNotFound)
+
+	// Codes are bound to their user
+	assert.ErrorIs(t, repo.Use(ctx, value_objects.NewUserID(), newPlain[1].Hash()), repositories.ErrRecoveryCodeNotFound)
+
+	count, err = repo.CountUnused(ctx, userID)
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+
+	require.NoError(t, repo.DeleteByUserID(ctx, userID))
+	count, err = repo.CountUnused(ctx, userID)
+	require.NoError(t, err)
+	assert.Equal(t, 0, count)
+}
@@ -0,0 +1,128 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/atdevten/peace/internal/domain/entities"
+	"github.com/atdevten/peace/internal/domain/repositories"
+	"github.com/atdevten/peace/internal/domain/value_objects"
+	"github.com/atdevten/peace/internal/infrastructure/database/postgres/models"
+
+	"gorm.io/gorm"
+)
+
+type PostgreSQLRefreshTokenRepository struct {
+	db *gorm.DB
+}
+
+func NewPostgreSQLRefreshTokenRepository(db *gorm.DB) repositories.RefreshTokenRepository {
+	return &PostgreSQLRefreshTokenRepository{
+		db: db,
+	}
+}
+
+func (r *PostgreSQLRefreshTokenRepository) Create(ctx context.Context, token *entities.RefreshToken) error {
+	model := models.RefreshToken{
+		ID:        token.ID().String(),
+		FamilyID:  token.FamilyID().String(),
+		UserID:    token.UserID().String(),
+		Device:    token.Device(),
+		ExpiresAt: token.ExpiresAt(),
+		UsedAt:    token.UsedAt(),
+		RevokedAt: token.RevokedAt(),
+		CreatedAt: token.CreatedAt(),
+	}
+
+	if err := r.db.WithContext(ctx).Create(&model).Error; err != nil {
+		return fmt.Errorf("r.db.Create: %w", err)
+	}
+	return nil
+}
+
+func (r *PostgreSQLRefreshTokenRepository) GetByID(ctx context.Context, id *value_objects.TokenID) (*entities.RefreshToken, error) {
+	var model models.RefreshToken
+
+	result := r.db.WithContext(ctx).Where("id = ?", id.String()).First(&model)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, repositories.ErrRefreshTokenNotFound
+		}
+		return nil, fmt.Errorf("r.db.First: %w", result.Error)
+	}
+
+	return r.modelToEntity(model)
+}
+
+func (r *PostgreSQLRefreshTokenRepository) MarkUsed(ctx context.Context, token *entities.RefreshToken) error {
+	usedAt := time.Now()
+	if token.UsedAt() != nil {
+		usedAt = *token.UsedAt()
+	}
+
+	// Conditional update so a token can only be rotated once, even under concurrent refreshes
+	result := r.db.WithContext(ctx).
+		Model(&models.RefreshToken{}).
+		Where("id = ? AND used_at IS NULL AND revoked_at IS NULL", token.ID().String()).
+		Update("used_at", usedAt)
+	if result.Error != nil {
+		return fmt.Errorf("r.db.Update: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return repositories.ErrRefreshTokenUsed
+	}
+	return nil
+}
+
+func (r *PostgreSQLRefreshTokenRepository) RevokeFamily(ctx context.Context, familyID *value_objects.TokenID) error {
+	err := r.db.WithContext(ctx).
+		Model(&models.RefreshToken{}).
+		Where("family_id = ? AND revoked_at IS NULL", familyID.String()).
+		Update("revoked_at", time.Now()).Error
+	if err != nil {
+		return fmt.Errorf("r.db.Update: %w", err)
+	}
+	return nil
+}
+
+func (r *PostgreSQLRefreshTokenRepository) RevokeByUserID(ctx context.Context, userID *value_objects.UserID) error {
+	err := r.db.WithContext(ctx).
+		Model(&models.RefreshToken{}).
+		Where("user_id = ? AND revoked_at IS NULL", userID.String()).
+		Update("revoked_at", time.Now()).Error
+	if err != nil {
+		return fmt.Errorf("r.db.Update: %w", err)
+	}
+	return nil
+}
+
+// Helper method to convert model to entity
+func (r *PostgreSQLRefreshTokenRepository) modelToEntity(model models.RefreshToken) (*entities.RefreshToken, error) {
+	id, err := value_objects.NewTokenIDFromString(model.ID)
+	if err != nil {
+		return nil, fmt.Errorf("value_objects.NewTokenIDFromString: %w", err)
+	}
+
+	familyID, err := value_objects.NewTokenIDFromString(model.FamilyID)
+	if err != nil {
+		return nil, fmt.Errorf("value_objects.NewTokenIDFromString: %w", err)
+	}
+
+	userID, err := value_objects.NewUserIDFromString(model.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("value_objects.NewUserIDFromString: %w", err)
+	}
+
+	return entities.NewRefreshTokenFromRepository(
+		id,
+		familyID,
+		userID,
+		model.Device,
+		model.ExpiresAt,
+		model.UsedAt,
+		model.RevokedAt,
+		model.CreatedAt,
+	), nil
+}
@@ -0,0 +1,113 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/atdevten/peace/internal/domain/entities"
+	"github.com/atdevten/peace/internal/domain/repositories"
+	"github.com/atdevten/peace/internal/domain/value_objects"
+	"github.com/atdevten/peace/internal/infrastructure/database/postgres/models"
+	"github.com/atdevten/peace/testutils/helpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// setupRefreshTokenTestDB creates an in-memory SQLite database for refresh token testing
+func setupRefreshTokenTestDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	require.NoError(t, err)
+
+	err = db.AutoMigrate(&models.RefreshToken{})
+	require.NoError(t, err)
+
+	return db
+}
+
+func createTestRefreshToken(t *testing.T, userID *value_objects.UserID, familyID *value_objects.TokenID) *entities.RefreshToken {
+	token, err := entities.NewRefreshToken(userID, familyID, "test-agent", time.Hour)
+	require.NoError(t, err)
+	return token
+}
+
+func TestPostgreSQLRefreshTokenRepository_CreateAndMarkUsed(t *testing.T) {
+	db := setupRefreshTokenTestDB(t)
+	repo := NewPostgreSQLRefreshTokenRepository(db)
+	ctx := context.Background()
+
+	token := createTestRefreshToken(t, helpers.CreateTestUserID(), nil)
+	require.NoError(t, repo.Create(ctx, token))
+
+	found, err := repo.GetByID(ctx, token.ID())
+	require.NoError(t, err)
+	assert.Equal(t, token.FamilyID().String(), found.FamilyID().String())
+	assert.Equal(t, "test-agent", found.Device())
+	assert.False(t, found.IsUsed())
+
+	require.NoError(t, repo.MarkUsed(ctx, found))
+	assert.ErrorIs(t, repo.MarkUsed(ctx, found), repositories.ErrRefreshTokenUsed)
+
+	found, err = repo.GetByID(ctx, token.ID())
+	require.NoError(t, err)
+	assert.True(t, found.IsUsed())
+
+	_, err = repo.GetByID(ctx, value_objects.NewTokenID())
+	assert.ErrorIs(t, err, repositories.ErrRefreshTokenNotFound)
+}
+
+func TestPostgreSQLRefreshTokenRepository_RevokeFamily(t *testing.T) {
+	db := setupRefreshTokenTestDB(t)
+	repo := NewPostgreSQLRefreshTokenRepository(db)
+	ctx := context.Background()
+
+	userID := helpers.CreateTestUserID()
+	first := createTestRefreshToken(t, userID, nil)
+	rotated := createTestRefreshToken(t, userID, first.FamilyID())
+	otherLogin := createTestRefreshToken(t, userID, nil)
+	for _, token := range []*entities.RefreshToken{first, rotated, otherLogin} {
+		require.NoError(t, repo.Create(ctx, token))
+	}
+
+	require.NoError(t, repo.RevokeFamily(ctx, first.FamilyID()))
+
+	for _, token := range []*entities.RefreshToken{first, rotated} {
+		found, err := repo.GetByID(ctx, token.ID())
+		require.NoError(t, err)
+		assert.True(t, found.IsRevoked())
+	}
+
+	found, err := repo.GetByID(ctx, otherLogin.ID())
+	require.NoError(t, err)
+	assert.False(t, found.IsRevoked())
+
+	// A revoked token can no longer be rotated
+	assert.ErrorIs(t, repo.MarkUsed(ctx, rotated), repositories.ErrRefreshTokenUsed)
+}
+
+func TestPostgreSQLRefreshTokenRepository_RevokeByUserID(t *testing.T) {
+	db := setupRefreshTokenTestDB(t)
+	repo := NewPostgreSQLRefreshTokenRepository(db)
+	ctx := context.Background()
+
+	userID := helpers.CreateTestUserID()
+	mine := createTestRefreshToken(t, userID, nil)
+	theirs := createTestRefreshToken(t, helpers.CreateTestUserID(), nil)
+	require.NoError(t, repo.Create(ctx, mine))
+	require.NoError(t, repo.Create(ctx, theirs))
+
+	require.NoError(t, repo.RevokeByUserID(ctx, userID))
+
+	found, err := repo.GetByID(ctx, mine.ID())
+	require.NoError(t, err)
+	assert.True(t, found.IsRevoked())
+
+	found, err = repo.GetByID(ctx, theirs.ID())
+	require.NoError(t, err)
+	assert.False(t, found.IsRevoked())
+}
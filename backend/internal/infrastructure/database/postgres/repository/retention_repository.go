@@ -0,0 +1,236 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/atdevten/peace/internal/domain/entities"
+	"github.com/atdevten/peace/internal/domain/repositories"
+	"github.com/atdevten/peace/internal/domain/value_objects"
+	"github.com/atdevten/peace/internal/infrastructure/database/postgres/models"
+
+	"gorm.io/gorm"
+)
+
+// anonymizedEmailDomain is reserved (RFC 2606), so placeholder addresses can never receive mail
+const anonymizedEmailDomain = "anonymized.invalid"
+
+type PostgreSQLRetentionRepository struct {
+	db       *gorm.DB
+	userRepo *PostgreSQLUserRepository
+}
+
+func NewPostgreSQLRetentionRepository(db *gorm.DB) repositories.RetentionRepository {
+	return &PostgreSQLRetentionRepository{
+		db:       db,
+		userRepo: &PostgreSQLUserRepository{db: db},
+	}
+}
+
+func (r *PostgreSQLRetentionRepository) ListDeletedUsers(ctx context.Context, filter *repositories.DeletedUserFilter) ([]*entities.User, error) {
+	query := r.db.WithContext(ctx).
+		Where("deleted_at IS NOT NULL AND deleted_at < ?", filter.DeletedBefore).
+		Where("anonymized_at IS NULL")
+
+	if filter.Cursor != nil {
+		query = query.Where("deleted_at > ? OR (deleted_at = ? AND id > ?)",
+			filter.Cursor.DeletedAt, filter.Cursor.DeletedAt, filter.Cursor.ID)
+	}
+
+	var userModels []models.User
+	if err := query.Order("deleted_at ASC, id ASC").Limit(filter.Limit).Find(&userModels).Error; err != nil {
+		return nil, fmt.Errorf("r.db.Find: %w", err)
+	}
+
+	users := make([]*entities.User, 0, len(userModels))
+	for _, model := range userModels {
+		user, err := r.userRepo.modelToEntity(model)
+		if err != nil {
+			return nil, fmt.Errorf("modelToEntity: %w", err)
+		}
+		users = append(users, user)
+	}
+
+	return users, nil
+}
+
+func (r *PostgreSQLRetentionRepository) CountUserData(ctx context.Context, userID *value_objects.UserID) (*repositories.UserDataCounts, error) {
+	var counts repositories.UserDataCounts
+
+	err := r.db.WithContext(ctx).Model(&models.MentalHealthRecord{}).Where("user_id = ?", userID.String()).Count(&counts.Records).Error
+	if err != nil {
+		return nil, fmt.Errorf("r.db.Count: %w", err)
+	}
+
+	err = r.db.WithContext(ctx).Model(&models.Session{}).Where("user_id = ?", userID.String()).Count(&counts.Sessions).Error
+	if err != nil {
+		return nil, fmt.Errorf("r.db.Count: %w", err)
+	}
+
+	return &counts, nil
+}
+
+func (r *PostgreSQLRetentionRepository) PurgeUser(ctx context.Context, entry *repositories.RetentionLogEntry) error {
+	userID := entry.UserID.String()
+
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Children first, so nothing depends on the foreign keys cascading
+		recordIDs := tx.Model(&models.MentalHealthRecord{}).Select("id").Where("user_id = ?", userID)
+		if err := tx.Where("record_id IN (?)", recordIDs).Delete(&models.RecordTag{}).Error; err != nil {
+			return fmt.Errorf("tx.Delete: %w", err)
+		}
+		if err := tx.Where("user_id = ?", userID).Delete(&models.RecordLabel{}).Error; err != nil {
+			return fmt.Errorf("tx.Delete: %w", err)
+		}
+		if err := tx.Where("user_id = ?", userID).Delete(&models.MentalHealthRecord{}).Error; err != nil {
+			return fmt.Errorf("tx.Delete: %w", err)
+		}
+		if err := deleteUserCredentials(tx, userID); err != nil {
+			return err
+		}
+
+		// Restored accounts are left alone; returning an error rolls back the deletes above
+		result := tx.Where("id = ? AND deleted_at IS NOT NULL", userID).Delete(&models.User{})
+		if result.Error != nil {
+			return fmt.Errorf("tx.Delete: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return repositories.ErrUserNotFound
+		}
+
+		return createRetentionLogEntry(tx, entry)
+	})
+}
+
+func (r *PostgreSQLRetentionRepository) AnonymizeUser(ctx context.Context, entry *repositories.RetentionLogEntry) error {
+	userID := entry.UserID.String()
+	placeholder := "deleted_" + strings.ReplaceAll(userID, "-", "")
+
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.User{}).
+			Where("id = ? AND deleted_at IS NOT NULL AND anonymized_at IS NULL", userID).
+			Updates(map[string]interface{}{
+				"email":          placeholder + "@" + anonymizedEmailDomain,
+				"username":       placeholder,
+				"first_name":     nil,
+				"last_name":      nil,
+				"password_hash":  nil,
+				"picture_url":    nil,
+				"is_active":      false,
+				"email_verified": false,
+				"feed_opt_out":   true,
+				"updated_at":     entry.ProcessedAt,
+				"anonymized_at":  entry.ProcessedAt,
+				"version":        gorm.Expr("version + 1"),
+			})
+		if result.Error != nil {
+			return fmt.Errorf("tx.Updates: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return repositories.ErrUserNotFound
+		}
+
+		if err := deleteUserCredentials(tx, userID); err != nil {
+			return err
+		}
+
+		// Mood levels and tags stay for aggregate statistics; free-text notes may identify the user
+		err := tx.Model(&models.MentalHealthRecord{}).
+			Where("user_id = ?", userID).
+			Updates(map[string]interface{}{
+				"notes":      nil,
+				"status":     value_objects.RecordStatusPrivate.String(),
+				"updated_at": entry.ProcessedAt,
+			}).Error
+		if err != nil {
+			return fmt.Errorf("tx.Updates: %w", err)
+		}
+
+		return createRetentionLogEntry(tx, entry)
+	})
+}
+
+func (r *PostgreSQLRetentionRepository) RestoreUser(ctx context.Context, restoration *repositories.AccountRestoration) error {
+	userID := restoration.UserID.String()
+
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.User{}).
+			Where("id = ? AND deleted_at IS NOT NULL AND anonymized_at IS NULL", userID).
+			Updates(map[string]interface{}{
+				"deleted_at": nil,
+				"is_active":  true,
+				"updated_at": restoration.RestoredAt,
+				"version":    gorm.Expr("version + 1"),
+			})
+		if result.Error != nil {
+			return fmt.Errorf("tx.Updates: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return repositories.ErrUserNotFound
+		}
+
+		model := models.AccountRestoration{
+			ID:            value_objects.NewTokenID().String(),
+			UserID:        userID,
+			UserDeletedAt: restoration.UserDeletedAt,
+			RestoredAt:    restoration.RestoredAt,
+			IPAddress:     truncateColumn(restoration.IPAddress, 64),
+			UserAgent:     truncateColumn(restoration.UserAgent, 255),
+		}
+		if err := tx.Create(&model).Error; err != nil {
+			return fmt.Errorf("tx.Create: %w", err)
+		}
+		return nil
+	})
+}
+
+// deleteUserCredentials deletes everything that lets someone sign in as the user or describes their
+// activity: tokens, second factors, linked identities, sessions, restores and export archives
+func deleteUserCredentials(tx *gorm.DB, userID string) error {
+	tables := []interface{}{
+		&models.Session{},
+		&models.RefreshToken{},
+		&models.PasswordResetToken{},
+		&models.EmailVerificationToken{},
+		&models.TOTPFactor{},
+		&models.RecoveryCode{},
+		&models.UserIdentity{},
+		&models.PersonalAccessToken{},
+		&models.DataExport{},
+		&models.AccountRestoration{},
+	}
+
+	for _, table := range tables {
+		if err := tx.Where("user_id = ?", userID).Delete(table).Error; err != nil {
+			return fmt.Errorf("tx.Delete: %w", err)
+		}
+	}
+	return nil
+}
+
+func createRetentionLogEntry(tx *gorm.DB, entry *repositories.RetentionLogEntry) error {
+	model := models.AccountRetentionLog{
+		ID:            value_objects.NewTokenID().String(),
+		UserID:        entry.UserID.String(),
+		Action:        entry.Action.String(),
+		UserDeletedAt: entry.UserDeletedAt,
+		RecordsCount:  entry.Records,
+		SessionsCount: entry.Sessions,
+		ExportsCount:  entry.Exports,
+		ProcessedAt:   entry.ProcessedAt,
+	}
+
+	if err := tx.Create(&model).Error; err != nil {
+		return fmt.Errorf("tx.Create: %w", err)
+	}
+	return nil
+}
+
+// truncateColumn cuts client-supplied values down to the size of their column
+func truncateColumn(value string, size int) string {
+	if len(value) > size {
+		return value[:size]
+	}
+	return value
+}
@@ -0,0 +1,262 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/atdevten/peace/internal/domain/entities"
+	"github.com/atdevten/peace/internal/domain/repositories"
+	"github.com/atdevten/peace/internal/domain/value_objects"
+	"github.com/atdevten/peace/internal/infrastructure/database/postgres/models"
+	"github.com/atdevten/peace/testutils/helpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// setupRetentionTestDB creates an in-memory SQLite database with every table the retention job touches
+func setupRetentionTestDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	require.NoError(t, err)
+
+	err = db.AutoMigrate(
+		&models.User{},
+		&models.MentalHealthRecord{},
+		&models.RecordLabel{},
+		&models.RecordTag{},
+		&models.Session{},
+		&models.RefreshToken{},
+		&models.PasswordResetToken{},
+		&models.EmailVerificationToken{},
+		&models.TOTPFactor{},
+		&models.RecoveryCode{},
+		&models.UserIdentity{},
+		&models.PersonalAccessToken{},
+		&models.DataExport{},
+		&models.AccountRetentionLog{},
+		&models.AccountRestoration{},
+	)
+	require.NoError(t, err)
+
+	return db
+}
+
+// createRetentionTestUser stores a user with one tagged record, one session and one export,
+// soft-deleting it when deleted is true
+func createRetentionTestUser(t *testing.T, db *gorm.DB, name string, deleted bool) *entities.User {
+	ctx := context.Background()
+
+	user, err := entities.NewUser(name+"@example.com", name, helpers.StringPtr("John"), helpers.StringPtr("Doe"), "Password123")
+	require.NoError(t, err)
+	if deleted {
+		require.NoError(t, user.SoftDelete())
+	}
+	require.NoError(t, NewPostgreSQLUserRepository(db).Create(ctx, user))
+
+	record := helpers.CreateTestMentalHealthRecordWithUserID(user.ID())
+	record.SetTags([]string{"exercise"})
+	require.NoError(t, NewPostgreSQLMentalHealthRecordRepository(db).Create(ctx, record))
+
+	session, err := entities.NewSession(user.ID(), "test-agent", "127.0.0.1", "password")
+	require.NoError(t, err)
+	require.NoError(t, NewPostgreSQLSessionRepository(db).Create(ctx, session))
+
+	export, err := entities.NewDataExport(user.ID())
+	require.NoError(t, err)
+	require.NoError(t, NewPostgreSQLDataExportRepository(db).Create(ctx, export))
+
+	return user
+}
+
+func countRows(t *testing.T, db *gorm.DB, model interface{}, query string, args ...interface{}) int64 {
+	var count int64
+	require.NoError(t, db.Model(model).Where(query, args...).Count(&count).Error)
+	return count
+}
+
+func newRetentionLogEntry(user *entities.User, action value_objects.RetentionAction) *repositories.RetentionLogEntry {
+	return &repositories.RetentionLogEntry{
+		UserID:        user.ID(),
+		Action:        action,
+		UserDeletedAt: *user.DeletedAt(),
+		Records:       1,
+		Sessions:      1,
+		Exports:       1,
+		ProcessedAt:   time.Now(),
+	}
+}
+
+func TestPostgreSQLRetentionRepository_ListDeletedUsers(t *testing.T) {
+	db := setupRetentionTestDB(t)
+	repo := NewPostgreSQLRetentionRepository(db)
+	ctx := context.Background()
+
+	var deleted []*entities.User
+	for i := 0; i < 3; i++ {
+		deleted = append(deleted, createRetentionTestUser(t, db, fmt.Sprintf("deleted%d", i), true))
+		time.Sleep(10 * time.Millisecond)
+	}
+	cutoff := time.Now()
+	time.Sleep(10 * time.Millisecond)
+	createRetentionTestUser(t, db, "recent", true)
+	createRetentionTestUser(t, db, "active", false)
+
+	// Already anonymized accounts are not listed again
+	require.NoError(t, repo.AnonymizeUser(ctx, newRetentionLogEntry(deleted[0], value_objects.RetentionActionAnonymize)))
+
+	filter := &repositories.DeletedUserFilter{DeletedBefore: cutoff, Limit: 1}
+	page, err := repo.ListDeletedUsers(ctx, filter)
+	require.NoError(t, err)
+	require.Len(t, page, 1)
+	assert.Equal(t, deleted[1].ID().String(), page[0].ID().String())
+
+	filter.Cursor = &repositories.DeletedUserCursor{DeletedAt: *page[0].DeletedAt(), ID: page[0].ID().String()}
+	page, err = repo.ListDeletedUsers(ctx, filter)
+	require.NoError(t, err)
+	require.Len(t, page, 1)
+	assert.Equal(t, deleted[2].ID().String(), page[0].ID().String())
+
+	filter.Cursor = &repositories.DeletedUserCursor{DeletedAt: *page[0].DeletedAt(), ID: page[0].ID().String()}
+	page, err = repo.ListDeletedUsers(ctx, filter)
+	require.NoError(t, err)
+	assert.Empty(t, page)
+}
+
+func TestPostgreSQLRetentionRepository_CountUserData(t *testing.T) {
+	db := setupRetentionTestDB(t)
+	repo := NewPostgreSQLRetentionRepository(db)
+
+	user := createRetentionTestUser(t, db, "counted", true)
+	createRetentionTestUser(t, db, "other", true)
+
+	counts, err := repo.CountUserData(context.Background(), user.ID())
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), counts.Records)
+	assert.Equal(t, int64(1), counts.Sessions)
+}
+
+func TestPostgreSQLRetentionRepository_PurgeUser(t *testing.T) {
+	db := setupRetentionTestDB(t)
+	repo := NewPostgreSQLRetentionRepository(db)
+	ctx := context.Background()
+
+	user := createRetentionTestUser(t, db, "purged", true)
+	other := createRetentionTestUser(t, db, "other", true)
+
+	require.NoError(t, repo.PurgeUser(ctx, newRetentionLogEntry(user, value_objects.RetentionActionPurge)))
+
+	assert.Equal(t, int64(0), countRows(t, db, &models.User{}, "id = ?", user.ID().String()))
+	assert.Equal(t, int64(0), countRows(t, db, &models.MentalHealthRecord{}, "user_id = ?", user.ID().String()))
+	assert.Equal(t, int64(1), countRows(t, db, &models.RecordTag{}, "1 = 1"))
+	assert.Equal(t, int64(0), countRows(t, db, &models.RecordLabel{}, "user_id = ?", user.ID().String()))
+	assert.Equal(t, int64(0), countRows(t, db, &models.Session{}, "user_id = ?", user.ID().String()))
+	assert.Equal(t, int64(0), countRows(t, db, &models.DataExport{}, "user_id = ?", user.ID().String()))
+
+	// Other accounts are untouched
+	assert.Equal(t, int64(1), countRows(t, db, &models.MentalHealthRecord{}, "user_id = ?", other.ID().String()))
+	assert.Equal(t, int64(1), countRows(t, db, &models.Session{}, "user_id = ?", other.ID().String()))
+	assert.Equal(t, int64(1), countRows(t, db, &models.RecordLabel{}, "user_id = ?", other.ID().String()))
+
+	var logEntry models.AccountRetentionLog
+	require.NoError(t, db.Where("user_id = ?", user.ID().String()).First(&logEntry).Error)
+	assert.Equal(t, "purge", logEntry.Action)
+	assert.Equal(t, int64(1), logEntry.RecordsCount)
+
+	err := repo.PurgeUser(ctx, newRetentionLogEntry(user, value_objects.RetentionActionPurge))
+	assert.ErrorIs(t, err, repositories.ErrUserNotFound)
+}
+
+func TestPostgreSQLRetentionRepository_PurgeUser_NotDeleted(t *testing.T) {
+	db := setupRetentionTestDB(t)
+	repo := NewPostgreSQLRetentionRepository(db)
+
+	user := createRetentionTestUser(t, db, "restored", true)
+	entry := newRetentionLogEntry(user, value_objects.RetentionActionPurge)
+	require.NoError(t, db.Model(&models.User{}).Where("id = ?", user.ID().String()).Update("deleted_at", nil).Error)
+
+	err := repo.PurgeUser(context.Background(), entry)
+	assert.ErrorIs(t, err, repositories.ErrUserNotFound)
+
+	// Everything is rolled back
+	assert.Equal(t, int64(1), countRows(t, db, &models.MentalHealthRecord{}, "user_id = ?", user.ID().String()))
+	assert.Equal(t, int64(1), countRows(t, db, &models.Session{}, "user_id = ?", user.ID().String()))
+	assert.Equal(t, int64(0), countRows(t, db, &models.AccountRetentionLog{}, "user_id = ?", user.ID().String()))
+}
+
+func TestPostgreSQLRetentionRepository_AnonymizeUser(t *testing.T) {
+	db := setupRetentionTestDB(t)
+	repo := NewPostgreSQLRetentionRepository(db)
+	ctx := context.Background()
+
+	user := createRetentionTestUser(t, db, "anonymized", true)
+
+	require.NoError(t, repo.AnonymizeUser(ctx, newRetentionLogEntry(user, value_objects.RetentionActionAnonymize)))
+
+	var model models.User
+	require.NoError(t, db.Where("id = ?", user.ID().String()).First(&model).Error)
+	assert.NotContains(t, model.Email, "anonymized@example.com")
+	assert.Contains(t, model.Email, "@anonymized.invalid")
+	assert.NotEqual(t, "anonymized", model.Username)
+	assert.Nil(t, model.FirstName)
+	assert.Nil(t, model.LastName)
+	assert.Nil(t, model.PasswordHash)
+	assert.False(t, model.IsActive)
+	assert.NotNil(t, model.AnonymizedAt)
+
+	// The placeholders are still valid value objects
+	found, err := NewPostgreSQLUserRepository(db).GetByID(ctx, user.ID())
+	require.NoError(t, err)
+	assert.NotNil(t, found.DeletedAt())
+
+	var record models.MentalHealthRecord
+	require.NoError(t, db.Where("user_id = ?", user.ID().String()).First(&record).Error)
+	assert.Nil(t, record.Notes)
+	assert.Equal(t, value_objects.RecordStatusPrivate.String(), record.Status)
+
+	assert.Equal(t, int64(0), countRows(t, db, &models.Session{}, "user_id = ?", user.ID().String()))
+	assert.Equal(t, int64(0), countRows(t, db, &models.DataExport{}, "user_id = ?", user.ID().String()))
+	assert.Equal(t, int64(1), countRows(t, db, &models.AccountRetentionLog{}, "user_id = ? AND action = ?", user.ID().String(), "anonymize"))
+
+	err = repo.AnonymizeUser(ctx, newRetentionLogEntry(user, value_objects.RetentionActionAnonymize))
+	assert.ErrorIs(t, err, repositories.ErrUserNotFound)
+}
+
+func TestPostgreSQLRetentionRepository_RestoreUser(t *testing.T) {
+	db := setupRetentionTestDB(t)
+	repo := NewPostgreSQLRetentionRepository(db)
+	ctx := context.Background()
+
+	user := createRetentionTestUser(t, db, "restored", true)
+	restoration := &repositories.AccountRestoration{
+		UserID:        user.ID(),
+		UserDeletedAt: *user.DeletedAt(),
+		RestoredAt:    time.Now(),
+		IPAddress:     "203.0.113.7",
+		UserAgent:     "test-agent",
+	}
+
+	require.NoError(t, repo.RestoreUser(ctx, restoration))
+
+	found, err := NewPostgreSQLUserRepository(db).GetByID(ctx, user.ID())
+	require.NoError(t, err)
+	assert.Nil(t, found.DeletedAt())
+	assert.True(t, found.IsActive())
+	assert.Equal(t, int64(1), countRows(t, db, &models.AccountRestoration{}, "user_id = ? AND ip_address = ?", user.ID().String(), "203.0.113.7"))
+
+	// Only deleted accounts can be restored
+	err = repo.RestoreUser(ctx, restoration)
+	assert.ErrorIs(t, err, repositories.ErrUserNotFound)
+
+	// Anonymized accounts are gone for good
+	anonymized := createRetentionTestUser(t, db, "anonymized", true)
+	require.NoError(t, repo.AnonymizeUser(ctx, newRetentionLogEntry(anonymized, value_objects.RetentionActionAnonymize)))
+	restoration.UserID = anonymized.ID()
+	err = repo.RestoreUser(ctx, restoration)
+	assert.ErrorIs(t, err, repositories.ErrUserNotFound)
+}
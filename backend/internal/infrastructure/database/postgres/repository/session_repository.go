@@ -0,0 +1,168 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/atdevten/peace/internal/domain/entities"
+	"github.com/atdevten/peace/internal/domain/repositories"
+	"github.com/atdevten/peace/internal/domain/value_objects"
+	"github.com/atdevten/peace/internal/infrastructure/database/postgres/models"
+
+	"gorm.io/gorm"
+)
+
+type PostgreSQLSessionRepository struct {
+	db *gorm.DB
+}
+
+func NewPostgreSQLSessionRepository(db *gorm.DB) repositories.SessionRepository {
+	return &PostgreSQLSessionRepository{
+		db: db,
+	}
+}
+
+func (r *PostgreSQLSessionRepository) Create(ctx context.Context, session *entities.Session) error {
+	model := models.Session{
+		ID:         session.ID().String(),
+		UserID:     session.UserID().String(),
+		UserAgent:  session.UserAgent(),
+		IPAddress:  session.IPAddress(),
+		Provider:   session.Provider(),
+		CreatedAt:  session.CreatedAt(),
+		LastUsedAt: session.LastUsedAt(),
+		RevokedAt:  session.RevokedAt(),
+	}
+
+	if err := r.db.WithContext(ctx).Create(&model).Error; err != nil {
+		return fmt.Errorf("r.db.Create: %w", err)
+	}
+	return nil
+}
+
+func (r *PostgreSQLSessionRepository) GetByID(ctx context.Context, id *value_objects.TokenID) (*entities.Session, error) {
+	var model models.Session
+
+	result := r.db.WithContext(ctx).Where("id = ?", id.String()).First(&model)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, repositories.ErrSessionNotFound
+		}
+		return nil, fmt.Errorf("r.db.First: %w", result.Error)
+	}
+
+	return r.modelToEntity(model)
+}
+
+func (r *PostgreSQLSessionRepository) ListActiveByUserID(ctx context.Context, userID *value_objects.UserID) ([]*entities.Session, error) {
+	var sessionModels []models.Session
+
+	err := r.db.WithContext(ctx).
+		Where("user_id = ? AND revoked_at IS NULL", userID.String()).
+		Order("last_used_at DESC").
+		Find(&sessionModels).Error
+	if err != nil {
+		return nil, fmt.Errorf("r.db.Find: %w", err)
+	}
+
+	sessions := make([]*entities.Session, 0, len(sessionModels))
+	for _, model := range sessionModels {
+		session, err := r.modelToEntity(model)
+		if err != nil {
+			return nil, fmt.Errorf("modelToEntity: %w", err)
+		}
+		sessions = append(sessions, session)
+	}
+
+	return sessions, nil
+}
+
+func (r *PostgreSQLSessionRepository) ListHistory(ctx context.Context, filter *repositories.SessionHistoryFilter) ([]*entities.Session, error) {
+	var sessionModels []models.Session
+
+	query := r.db.WithContext(ctx).Where("user_id = ?", filter.UserID.String())
+
+	// Keyset pagination: continue strictly after the cursor
+	if filter.Cursor != nil {
+		query = query.Where("(created_at > ? OR (created_at = ? AND id > ?))", filter.Cursor.CreatedAt, filter.Cursor.CreatedAt, filter.Cursor.ID)
+	}
+
+	err := query.
+		Order("created_at ASC").
+		Order("id ASC").
+		Limit(filter.Limit).
+		Find(&sessionModels).Error
+	if err != nil {
+		return nil, fmt.Errorf("r.db.Find: %w", err)
+	}
+
+	sessions := make([]*entities.Session, 0, len(sessionModels))
+	for _, model := range sessionModels {
+		session, err := r.modelToEntity(model)
+		if err != nil {
+			return nil, fmt.Errorf("modelToEntity: %w", err)
+		}
+		sessions = append(sessions, session)
+	}
+
+	return sessions, nil
+}
+
+func (r *PostgreSQLSessionRepository) UpdateLastUsedAt(ctx context.Context, id *value_objects.TokenID, lastUsedAt time.Time) error {
+	err := r.db.WithContext(ctx).
+		Model(&models.Session{}).
+		Where("id = ?", id.String()).
+		Update("last_used_at", lastUsedAt).Error
+	if err != nil {
+		return fmt.Errorf("r.db.Update: %w", err)
+	}
+	return nil
+}
+
+func (r *PostgreSQLSessionRepository) Revoke(ctx context.Context, id *value_objects.TokenID) error {
+	err := r.db.WithContext(ctx).
+		Model(&models.Session{}).
+		Where("id = ? AND revoked_at IS NULL", id.String()).
+		Update("revoked_at", time.Now()).Error
+	if err != nil {
+		return fmt.Errorf("r.db.Update: %w", err)
+	}
+	return nil
+}
+
+func (r *PostgreSQLSessionRepository) RevokeByUserID(ctx context.Context, userID *value_objects.UserID) error {
+	err := r.db.WithContext(ctx).
+		Model(&models.Session{}).
+		Where("user_id = ? AND revoked_at IS NULL", userID.String()).
+		Update("revoked_at", time.Now()).Error
+	if err != nil {
+		return fmt.Errorf("r.db.Update: %w", err)
+	}
+	return nil
+}
+
+// Helper method to convert model to entity
+func (r *PostgreSQLSessionRepository) modelToEntity(model models.Session) (*entities.Session, error) {
+	id, err := value_objects.NewTokenIDFromString(model.ID)
+	if err != nil {
+		return nil, fmt.Errorf("value_objects.NewTokenIDFromString: %w", err)
+	}
+
+	userID, err := value_objects.NewUserIDFromString(model.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("value_objects.NewUserIDFromString: %w", err)
+	}
+
+	return entities.NewSessionFromRepository(
+		id,
+		userID,
+		model.UserAgent,
+		model.IPAddress,
+		model.Provider,
+		model.CreatedAt,
+		model.LastUsedAt,
+		model.RevokedAt,
+	), nil
+}
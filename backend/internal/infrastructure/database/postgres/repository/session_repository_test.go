@@ -0,0 +1,146 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/atdevten/peace/internal/domain/entities"
+	"github.com/atdevten/peace/internal/domain/repositories"
+	"github.com/atdevten/peace/internal/domain/value_objects"
+	"github.com/atdevten/peace/internal/infrastructure/database/postgres/models"
+	"github.com/atdevten/peace/testutils/helpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// setupSessionTestDB creates an in-memory SQLite database for session testing
+func setupSessionTestDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	require.NoError(t, err)
+
+	err = db.AutoMigrate(&models.Session{})
+	require.NoError(t, err)
+
+	return db
+}
+
+func createTestSession(t *testing.T, userID *value_objects.UserID) *entities.Session {
+	session, err := entities.NewSession(userID, "test-agent", "203.0.113.7", "local")
+	require.NoError(t, err)
+	return session
+}
+
+func TestPostgreSQLSessionRepository_CreateAndGetByID(t *testing.T) {
+	db := setupSessionTestDB(t)
+	repo := NewPostgreSQLSessionRepository(db)
+	ctx := context.Background()
+
+	session := createTestSession(t, helpers.CreateTestUserID())
+	require.NoError(t, repo.Create(ctx, session))
+
+	found, err := repo.GetByID(ctx, session.ID())
+	require.NoError(t, err)
+	assert.Equal(t, session.UserID().String(), found.UserID().String())
+	assert.Equal(t, "test-agent", found.UserAgent())
+	assert.Equal(t, "203.0.113.7", found.IPAddress())
+	assert.Equal(t, "local", found.Provider())
+	assert.False(t, found.IsRevoked())
+
+	_, err = repo.GetByID(ctx, value_objects.NewTokenID())
+	assert.ErrorIs(t, err, repositories.ErrSessionNotFound)
+}
+
+func TestPostgreSQLSessionRepository_ListActiveByUserID(t *testing.T) {
+	db := setupSessionTestDB(t)
+	repo := NewPostgreSQLSessionRepository(db)
+	ctx := context.Background()
+
+	userID := helpers.CreateTestUserID()
+	older := createTestSession(t, userID)
+	newer := createTestSession(t, userID)
+	revoked := createTestSession(t, userID)
+	otherUser := createTestSession(t, value_objects.NewUserID())
+	for _, session := range []*entities.Session{older, newer, revoked, otherUser} {
+		require.NoError(t, repo.Create(ctx, session))
+	}
+
+	require.NoError(t, repo.UpdateLastUsedAt(ctx, newer.ID(), time.Now().Add(time.Minute)))
+	require.NoError(t, repo.Revoke(ctx, revoked.ID()))
+
+	sessions, err := repo.ListActiveByUserID(ctx, userID)
+	require.NoError(t, err)
+	require.Len(t, sessions, 2)
+	assert.Equal(t, newer.ID().String(), sessions[0].ID().String())
+	assert.Equal(t, older.ID().String(), sessions[1].ID().String())
+
+	found, err := repo.GetByID(ctx, revoked.ID())
+	require.NoError(t, err)
+	assert.True(t, found.IsRevoked())
+}
+
+func TestPostgreSQLSessionRepository_ListHistory(t *testing.T) {
+	db := setupSessionTestDB(t)
+	repo := NewPostgreSQLSessionRepository(db)
+	ctx := context.Background()
+
+	userID := helpers.CreateTestUserID()
+	first := createTestSession(t, userID)
+	second := createTestSession(t, userID)
+	revoked := createTestSession(t, userID)
+	otherUser := createTestSession(t, value_objects.NewUserID())
+	for _, session := range []*entities.Session{first, second, revoked, otherUser} {
+		require.NoError(t, repo.Create(ctx, session))
+	}
+	require.NoError(t, repo.Revoke(ctx, revoked.ID()))
+
+	// Page through two at a time, revoked sessions included
+	seen := map[string]bool{}
+	filter := &repositories.SessionHistoryFilter{UserID: userID, Limit: 2}
+	for {
+		sessions, err := repo.ListHistory(ctx, filter)
+		require.NoError(t, err)
+		if len(sessions) == 0 {
+			break
+		}
+		for _, session := range sessions {
+			assert.False(t, seen[session.ID().String()], "session listed twice")
+			seen[session.ID().String()] = true
+		}
+		last := sessions[len(sessions)-1]
+		filter.Cursor = &repositories.SessionCursor{CreatedAt: last.CreatedAt(), ID: last.ID().String()}
+	}
+
+	assert.Len(t, seen, 3)
+	assert.True(t, seen[revoked.ID().String()])
+	assert.False(t, seen[otherUser.ID().String()])
+}
+
+func TestPostgreSQLSessionRepository_RevokeByUserID(t *testing.T) {
+	db := setupSessionTestDB(t)
+	repo := NewPostgreSQLSessionRepository(db)
+	ctx := context.Background()
+
+	userID := helpers.CreateTestUserID()
+	first := createTestSession(t, userID)
+	second := createTestSession(t, userID)
+	otherUser := createTestSession(t, value_objects.NewUserID())
+	for _, session := range []*entities.Session{first, second, otherUser} {
+		require.NoError(t, repo.Create(ctx, session))
+	}
+
+	require.NoError(t, repo.RevokeByUserID(ctx, userID))
+
+	sessions, err := repo.ListActiveByUserID(ctx, userID)
+	require.NoError(t, err)
+	assert.Empty(t, sessions)
+
+	found, err := repo.GetByID(ctx, otherUser.ID())
+	require.NoError(t, err)
+	assert.False(t, found.IsRevoked())
+}
@@ -0,0 +1,110 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/atdevten/peace/internal/domain/entities"
+	"github.com/atdevten/peace/internal/domain/repositories"
+	"github.com/atdevten/peace/internal/domain/value_objects"
+	"github.com/atdevten/peace/internal/infrastructure/database/postgres/models"
+
+	"gorm.io/gorm"
+)
+
+type PostgreSQLTOTPFactorRepository struct {
+	db *gorm.DB
+}
+
+func NewPostgreSQLTOTPFactorRepository(db *gorm.DB) repositories.TOTPFactorRepository {
+	return &PostgreSQLTOTPFactorRepository{
+		db: db,
+	}
+}
+
+func (r *PostgreSQLTOTPFactorRepository) Save(ctx context.Context, factor *entities.TOTPFactor) error {
+	model := models.TOTPFactor{
+		UserID:       factor.UserID().String(),
+		Secret:       factor.Secret(),
+		ConfirmedAt:  factor.ConfirmedAt(),
+		LastUsedStep: factor.LastUsedStep(),
+		CreatedAt:    factor.CreatedAt(),
+	}
+
+	// user_id is the primary key, so this replaces a previous enrollment
+	if err := r.db.WithContext(ctx).Save(&model).Error; err != nil {
+		return fmt.Errorf("r.db.Save: %w", err)
+	}
+	return nil
+}
+
+func (r *PostgreSQLTOTPFactorRepository) GetByUserID(ctx context.Context, userID *value_objects.UserID) (*entities.TOTPFactor, error) {
+	var model models.TOTPFactor
+
+	result := r.db.WithContext(ctx).Where("user_id = ?", userID.String()).First(&model)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, repositories.ErrTOTPFactorNotFound
+		}
+		return nil, fmt.Errorf("r.db.First: %w", result.Error)
+	}
+
+	return r.modelToEntity(model)
+}
+
+func (r *PostgreSQLTOTPFactorRepository) Confirm(ctx context.Context, factor *entities.TOTPFactor) error {
+	// Conditional update so an enrollment replaced in the meantime is not activated
+	result := r.db.WithContext(ctx).
+		Model(&models.TOTPFactor{}).
+		Where("user_id = ? AND secret = ? AND confirmed_at IS NULL", factor.UserID().String(), factor.Secret()).
+		Updates(map[string]interface{}{
+			"confirmed_at":   factor.ConfirmedAt(),
+			"last_used_step": factor.LastUsedStep(),
+		})
+	if result.Error != nil {
+		return fmt.Errorf("r.db.Updates: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return repositories.ErrTOTPFactorNotFound
+	}
+	return nil
+}
+
+func (r *PostgreSQLTOTPFactorRepository) UseStep(ctx context.Context, factor *entities.TOTPFactor) error {
+	// Conditional update so the same code cannot complete two logins
+	result := r.db.WithContext(ctx).
+		Model(&models.TOTPFactor{}).
+		Where("user_id = ? AND last_used_step < ?", factor.UserID().String(), factor.LastUsedStep()).
+		Update("last_used_step", factor.LastUsedStep())
+	if result.Error != nil {
+		return fmt.Errorf("r.db.Update: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return repositories.ErrTOTPCodeReplayed
+	}
+	return nil
+}
+
+func (r *PostgreSQLTOTPFactorRepository) Delete(ctx context.Context, userID *value_objects.UserID) error {
+	if err := r.db.WithContext(ctx).Where("user_id = ?", userID.String()).Delete(&models.TOTPFactor{}).Error; err != nil {
+		return fmt.Errorf("r.db.Delete: %w", err)
+	}
+	return nil
+}
+
+// Helper method to convert model to entity
+func (r *PostgreSQLTOTPFactorRepository) modelToEntity(model models.TOTPFactor) (*entities.TOTPFactor, error) {
+	userID, err := value_objects.NewUserIDFromString(model.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("value_objects.NewUserIDFromString: %w", err)
+	}
+
+	return entities.NewTOTPFactorFromRepository(
+		userID,
+		model.Secret,
+		model.ConfirmedAt,
+		model.LastUsedStep,
+		model.CreatedAt,
+	), nil
+}
@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/atdevten/peace/internal/domain/entities"
+	"github.com/atdevten/peace/internal/domain/repositories"
+	"github.com/atdevten/peace/internal/infrastructure/database/postgres/models"
+	"github.com/atdevten/peace/testutils/helpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// setupTOTPFactorTestDB creates an in-memory SQLite database for TOTP factor testing
+func setupTOTPFactorTestDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	require.NoError(t, err)
+
+	err = db.AutoMigrate(&models.TOTPFactor{})
+	require.NoError(t, err)
+
+	return db
+}
+
+func TestPostgreSQLTOTPFactorRepository_SaveAndConfirm(t *testing.T) {
+	db := setupTOTPFactorTestDB(t)
+	repo := NewPostgreSQLTOTPFactorRepository(db)
+	ctx := context.Background()
+
+	userID := helpers.CreateTestUserID()
+	_, err := repo.GetByUserID(ctx, userID)
+	assert.ErrorIs(t, err, repositories.ErrTOTPFactorNotFound)
+
+	// A second enrollment replaces the first
+	first, err := entities.NewTOTPFactor(userID, "JBSWY3DPEHPK3PXP")
+	require.NoError(t, err)
+	require.NoError(t, repo.Save(ctx, first))
+	second, err := entities.NewTOTPFactor(userID, "KRSXG5CTMVRXEZLU")
+	require.NoError(t, err)
+	require.NoError(t, repo.Save(ctx, second))
+
+	// Confirming the stale enrollment is refused
+	require.NoError(t, first.Confirm(100, time.Now()))
+	assert.ErrorIs(t, repo.Confirm(ctx, first), repositories.ErrTOTPFactorNotFound)
+
+	require.NoError(t, second.Confirm(100, time.Now()))
+	require.NoError(t, repo.Confirm(ctx, second))
+
+	found, err := repo.GetByUserID(ctx, userID)
+	require.NoError(t, err)
+	assert.Equal(t, "KRSXG5CTMVRXEZLU", found.Secret())
+	assert.True(t, found.IsConfirmed())
+	assert.Equal(t, int64(100), found.LastUsedStep())
+
+	require.NoError(t, repo.Delete(ctx, userID))
+	_, err = repo.GetByUserID(ctx, userID)
+	assert.ErrorIs(t, err, repositories.ErrTOTPFactorNotFound)
+}
+
+func TestPostgreSQLTOTPFactorRepository_UseStep(t *testing.T) {
+	db := setupTOTPFactorTestDB(t)
+	repo := NewPostgreSQLTOTPFactorRepository(db)
+	ctx := context.Background()
+
+	userID := helpers.CreateTestUserID()
+	confirmedAt := time.Now()
+	require.NoError(t, repo.Save(ctx, entities.NewTOTPFactorFromRepository(userID, "JBSWY3DPEHPK3PXP", &confirmedAt, 100, confirmedAt)))
+
+	// Two logins holding the same code: only the first may advance the step
+	winner, err := repo.GetByUserID(ctx, userID)
+	require.NoError(t, err)
+	loser, err := repo.GetByUserID(ctx, userID)
+	require.NoError(t, err)
+
+	require.NoError(t, winner.UseStep(101))
+	require.NoError(t, repo.UseStep(ctx, winner))
+
+	require.NoError(t, loser.UseStep(101))
+	assert.ErrorIs(t, repo.UseStep(ctx, loser), repositories.ErrTOTPCodeReplayed)
+}
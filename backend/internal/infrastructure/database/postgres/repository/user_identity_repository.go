@@ -0,0 +1,111 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/atdevten/peace/internal/domain/entities"
+	"github.com/atdevten/peace/internal/domain/repositories"
+	"github.com/atdevten/peace/internal/domain/value_objects"
+	"github.com/atdevten/peace/internal/infrastructure/database/postgres/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type PostgreSQLUserIdentityRepository struct {
+	db *gorm.DB
+}
+
+func NewPostgreSQLUserIdentityRepository(db *gorm.DB) repositories.UserIdentityRepository {
+	return &PostgreSQLUserIdentityRepository{
+		db: db,
+	}
+}
+
+func (r *PostgreSQLUserIdentityRepository) Create(ctx context.Context, identity *entities.UserIdentity) error {
+	model := models.UserIdentity{
+		Provider:  identity.Provider(),
+		Subject:   identity.Subject(),
+		UserID:    identity.UserID().String(),
+		Email:     identity.Email(),
+		CreatedAt: identity.CreatedAt(),
+	}
+
+	// Both unique keys (provider account, user per provider) are enforced by the database
+	result := r.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(&model)
+	if result.Error != nil {
+		return fmt.Errorf("r.db.Create: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return repositories.ErrUserIdentityExists
+	}
+	return nil
+}
+
+func (r *PostgreSQLUserIdentityRepository) GetByProviderSubject(ctx context.Context, provider string, subject string) (*entities.UserIdentity, error) {
+	var model models.UserIdentity
+
+	result := r.db.WithContext(ctx).Where("provider = ? AND subject = ?", provider, subject).First(&model)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, repositories.ErrUserIdentityNotFound
+		}
+		return nil, fmt.Errorf("r.db.First: %w", result.Error)
+	}
+
+	return r.modelToEntity(model)
+}
+
+func (r *PostgreSQLUserIdentityRepository) ListByUserID(ctx context.Context, userID *value_objects.UserID) ([]*entities.UserIdentity, error) {
+	var identityModels []models.UserIdentity
+
+	err := r.db.WithContext(ctx).
+		Where("user_id = ?", userID.String()).
+		Order("provider ASC").
+		Find(&identityModels).Error
+	if err != nil {
+		return nil, fmt.Errorf("r.db.Find: %w", err)
+	}
+
+	identities := make([]*entities.UserIdentity, 0, len(identityModels))
+	for _, model := range identityModels {
+		identity, err := r.modelToEntity(model)
+		if err != nil {
+			return nil, fmt.Errorf("modelToEntity: %w", err)
+		}
+		identities = append(identities, identity)
+	}
+
+	return identities, nil
+}
+
+func (r *PostgreSQLUserIdentityRepository) Delete(ctx context.Context, userID *value_objects.UserID, provider string) error {
+	result := r.db.WithContext(ctx).
+		Where("user_id = ? AND provider = ?", userID.String(), provider).
+		Delete(&models.UserIdentity{})
+	if result.Error != nil {
+		return fmt.Errorf("r.db.Delete: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return repositories.ErrUserIdentityNotFound
+	}
+	return nil
+}
+
+// Helper method to convert model to entity
+func (r *PostgreSQLUserIdentityRepository) modelToEntity(model models.UserIdentity) (*entities.UserIdentity, error) {
+	userID, err := value_objects.NewUserIDFromString(model.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("value_objects.NewUserIDFromString: %w", err)
+	}
+
+	return entities.NewUserIdentityFromRepository(
+		userID,
+		model.Provider,
+		model.Subject,
+		model.Email,
+		model.CreatedAt,
+	), nil
+}
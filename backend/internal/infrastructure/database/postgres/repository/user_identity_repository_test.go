@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/atdevten/peace/internal/domain/entities"
+	"github.com/atdevten/peace/internal/domain/repositories"
+	"github.com/atdevten/peace/internal/infrastructure/database/postgres/models"
+	"github.com/atdevten/peace/testutils/helpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// setupUserIdentityTestDB creates an in-memory SQLite database for user identity testing
+func setupUserIdentityTestDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	require.NoError(t, err)
+
+	err = db.AutoMigrate(&models.UserIdentity{})
+	require.NoError(t, err)
+
+	return db
+}
+
+func TestPostgreSQLUserIdentityRepository_CreateAndGet(t *testing.T) {
+	db := setupUserIdentityTestDB(t)
+	repo := NewPostgreSQLUserIdentityRepository(db)
+	ctx := context.Background()
+
+	userID := helpers.CreateTestUserID()
+	identity, err := entities.NewUserIdentity(userID, "keycloak", "subject-1", "user@example.com")
+	require.NoError(t, err)
+	require.NoError(t, repo.Create(ctx, identity))
+
+	found, err := repo.GetByProviderSubject(ctx, "keycloak", "subject-1")
+	require.NoError(t, err)
+	assert.Equal(t, userID.String(), found.UserID().String())
+	assert.Equal(t, "user@example.com", found.Email())
+
+	// Subjects are only unique per provider
+	_, err = repo.GetByProviderSubject(ctx, "github", "subject-1")
+	assert.ErrorIs(t, err, repositories.ErrUserIdentityNotFound)
+
+	// A provider account belongs to one user
+	other, err := entities.NewUserIdentity(helpers.CreateTestUserID(), "keycloak", "subject-1", "other@example.com")
+	require.NoError(t, err)
+	assert.ErrorIs(t, repo.Create(ctx, other), repositories.ErrUserIdentityExists)
+
+	// A user links one account per provider
+	second, err := entities.NewUserIdentity(userID, "keycloak", "subject-2", "user@example.com")
+	require.NoError(t, err)
+	assert.ErrorIs(t, repo.Create(ctx, second), repositories.ErrUserIdentityExists)
+}
+
+func TestPostgreSQLUserIdentityRepository_ListAndDelete(t *testing.T) {
+	db := setupUserIdentityTestDB(t)
+	repo := NewPostgreSQLUserIdentityRepository(db)
+	ctx := context.Background()
+
+	userID := helpers.CreateTestUserID()
+	for _, provider := range []string{"keycloak", "github"} {
+		identity, err := entities.NewUserIdentity(userID, provider, provider+"-subject", "user@example.com")
+		require.NoError(t, err)
+		require.NoError(t, repo.Create(ctx, identity))
+	}
+
+	identities, err := repo.ListByUserID(ctx, userID)
+	require.NoError(t, err)
+	require.Len(t, identities, 2)
+	assert.Equal(t, "github", identities[0].Provider())
+	assert.Equal(t, "keycloak", identities[1].Provider())
+
+	require.NoError(t, repo.Delete(ctx, userID, "github"))
+	assert.ErrorIs(t, repo.Delete(ctx, userID, "github"), repositories.ErrUserIdentityNotFound)
+
+	identities, err = repo.ListByUserID(ctx, userID)
+	require.NoError(t, err)
+	require.Len(t, identities, 1)
+	assert.Equal(t, "keycloak", identities[0].Provider())
+}
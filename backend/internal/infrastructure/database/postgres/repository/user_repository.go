@@ -37,9 +37,11 @@ func (r *PostgreSQLUserRepository) Create(ctx context.Context, user *entities.Us
 		lastName = &lastNameStr
 	}
 
-	var passwordHash string
+	// Accounts without a password (external provider sign-ups) store NULL
+	var passwordHash *string
 	if user.PasswordHash() != nil {
-		passwordHash = user.PasswordHash().String()
+		passwordHashStr := user.PasswordHash().String()
+		passwordHash = &passwordHashStr
 	}
 
 	model := models.User{
@@ -52,8 +54,10 @@ func (r *PostgreSQLUserRepository) Create(ctx context.Context, user *entities.Us
 		IsActive:      user.IsActive(),
 		EmailVerified: user.EmailVerified(),
 		AuthProvider:  user.AuthProvider(),
-		GoogleID:      user.GoogleID(),
-		GooglePicture: user.GooglePicture(),
+		PictureURL:    user.PictureURL(),
+		Timezone:      user.Timezone().String(),
+		FeedOptOut:    user.FeedOptOut(),
+		Role:          user.Role().String(),
 		CreatedAt:     user.CreatedAt(),
 		UpdatedAt:     user.UpdatedAt(),
 		DeletedAt:     user.DeletedAt(),
@@ -71,7 +75,7 @@ func (r *PostgreSQLUserRepository) GetByID(ctx context.Context, id *value_object
 	result := r.db.WithContext(ctx).Where("id = ?", id.String()).First(&model)
 	if result.Error != nil {
 		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
-			return nil, errors.New("user not found")
+			return nil, repositories.ErrUserNotFound
 		}
 		return nil, fmt.Errorf("r.db.First: %w", result.Error)
 	}
@@ -100,7 +104,7 @@ func (r *PostgreSQLUserRepository) GetByFilter(ctx context.Context, filter *repo
 	result := r.db.WithContext(ctx).Where(query, queryValue).First(&model)
 	if result.Error != nil {
 		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
-			return nil, errors.New("user not found")
+			return nil, repositories.ErrUserNotFound
 		}
 		return nil, fmt.Errorf("r.db.First: %w", result.Error)
 	}
@@ -141,9 +145,11 @@ func (r *PostgreSQLUserRepository) Update(ctx context.Context, user *entities.Us
 		lastName = &lastNameStr
 	}
 
-	var passwordHash string
+	// Accounts without a password (external provider sign-ups) store NULL
+	var passwordHash *string
 	if user.PasswordHash() != nil {
-		passwordHash = user.PasswordHash().String()
+		passwordHashStr := user.PasswordHash().String()
+		passwordHash = &passwordHashStr
 	}
 
 	model := models.User{
@@ -156,16 +162,31 @@ func (r *PostgreSQLUserRepository) Update(ctx context.Context, user *entities.Us
 		IsActive:      user.IsActive(),
 		EmailVerified: user.EmailVerified(),
 		AuthProvider:  user.AuthProvider(),
-		GoogleID:      user.GoogleID(),
-		GooglePicture: user.GooglePicture(),
+		PictureURL:    user.PictureURL(),
+		Timezone:      user.Timezone().String(),
+		FeedOptOut:    user.FeedOptOut(),
+		Role:          user.Role().String(),
 		CreatedAt:     user.CreatedAt(),
 		UpdatedAt:     user.UpdatedAt(),
 		DeletedAt:     user.DeletedAt(),
+		Version:       user.Version() + 1,
 	}
 
-	if err := r.db.WithContext(ctx).Model(&models.User{}).Where("id = ?", model.ID).Updates(&model).Error; err != nil {
-		return fmt.Errorf("r.db.Updates: %w", err)
+	// Select every column so false/nil values (is_active, feed_opt_out, ...) are written too;
+	// anonymized_at is only ever set by the retention job. The version check keeps a stale entity
+	// from overwriting a concurrent update.
+	result := r.db.WithContext(ctx).Model(&models.User{}).
+		Where("id = ? AND version = ?", model.ID, user.Version()).
+		Select("*").Omit("id", "created_at", "anonymized_at").
+		Updates(&model)
+	if result.Error != nil {
+		return fmt.Errorf("r.db.Updates: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return repositories.ErrUserVersionConflict
 	}
+
+	user.SetVersion(model.Version)
 	return nil
 }
 
@@ -212,8 +233,8 @@ func (r *PostgreSQLUserRepository) modelToEntity(model models.User) (*entities.U
 	}
 
 	var hashedPassword *value_objects.HashedPassword
-	if model.PasswordHash != "" {
-		hashedPassword = value_objects.NewHashedPassword(model.PasswordHash)
+	if model.PasswordHash != nil && *model.PasswordHash != "" {
+		hashedPassword = value_objects.NewHashedPassword(*model.PasswordHash)
 	}
 
 	// Convert database strings to value objects
@@ -227,6 +248,25 @@ func (r *PostgreSQLUserRepository) modelToEntity(model models.User) (*entities.U
 		return nil, fmt.Errorf("value_objects.NewOptionalLastName: %w", err)
 	}
 
+	// Rows created before the timezone column existed fall back to UTC
+	timezone := value_objects.NewDefaultTimezone()
+	if model.Timezone != "" {
+		timezone, err = value_objects.NewTimezone(model.Timezone)
+		if err != nil {
+			return nil, fmt.Errorf("value_objects.NewTimezone: %w", err)
+		}
+	}
+
+	// Rows created before the role column existed are regular users
+	role := value_objects.RoleUser
+	if model.Role != "" {
+		parsed, err := value_objects.NewRole(model.Role)
+		if err != nil {
+			return nil, fmt.Errorf("value_objects.NewRole: %w", err)
+		}
+		role = *parsed
+	}
+
 	return entities.NewUserFromRepository(
 		userID,
 		email,
@@ -237,10 +277,13 @@ func (r *PostgreSQLUserRepository) modelToEntity(model models.User) (*entities.U
 		model.IsActive,
 		model.EmailVerified,
 		model.AuthProvider,
-		model.GoogleID,
-		model.GooglePicture,
+		model.PictureURL,
+		timezone,
+		model.FeedOptOut,
+		role,
 		model.CreatedAt,
 		model.UpdatedAt,
 		model.DeletedAt,
+		model.Version,
 	), nil
 }
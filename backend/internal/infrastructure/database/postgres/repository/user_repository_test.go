@@ -208,6 +208,84 @@ func TestPostgreSQLUserRepository_Update(t *testing.T) {
 	}
 }
 
+func TestPostgreSQLUserRepository_Update_StaleVersion(t *testing.T) {
+	db := setupUserTestDB(t)
+	repo := NewPostgreSQLUserRepository(db)
+	ctx := context.Background()
+
+	testUser := helpers.CreateTestUser()
+	require.NoError(t, repo.Create(ctx, testUser))
+
+	// Two requests read the same row; the role change lands first
+	roleChange, err := repo.GetByID(ctx, testUser.ID())
+	require.NoError(t, err)
+	profileUpdate, err := repo.GetByID(ctx, testUser.ID())
+	require.NoError(t, err)
+
+	roleChange.ChangeRole(value_objects.RoleEditor)
+	require.NoError(t, repo.Update(ctx, roleChange))
+	assert.Equal(t, 1, roleChange.Version())
+
+	require.NoError(t, profileUpdate.UpdateTimezone("Europe/Paris"))
+	err = repo.Update(ctx, profileUpdate)
+	assert.ErrorIs(t, err, repositories.ErrUserVersionConflict)
+
+	stored, err := repo.GetByID(ctx, testUser.ID())
+	require.NoError(t, err)
+	assert.Equal(t, value_objects.RoleEditor, stored.Role())
+	assert.Equal(t, "UTC", stored.Timezone().String())
+
+	// The same entity can be saved again after a successful update
+	roleChange.UpdateFeedOptOut(true)
+	require.NoError(t, repo.Update(ctx, roleChange))
+	assert.Equal(t, 2, roleChange.Version())
+}
+
+func TestPostgreSQLUserRepository_Role(t *testing.T) {
+	db := setupUserTestDB(t)
+	repo := NewPostgreSQLUserRepository(db)
+
+	testUser := helpers.CreateTestUser()
+	require.NoError(t, repo.Create(context.Background(), testUser))
+
+	stored, err := repo.GetByID(context.Background(), testUser.ID())
+	require.NoError(t, err)
+	assert.Equal(t, value_objects.RoleUser, stored.Role())
+
+	stored.ChangeRole(value_objects.RoleEditor)
+	require.NoError(t, repo.Update(context.Background(), stored))
+
+	updated, err := repo.GetByID(context.Background(), testUser.ID())
+	require.NoError(t, err)
+	assert.Equal(t, value_objects.RoleEditor, updated.Role())
+
+	// Rows written before the column existed read back as regular users
+	require.NoError(t, db.Model(&models.User{}).Where("id = ?", testUser.ID().String()).Update("role", "").Error)
+	legacy, err := repo.GetByID(context.Background(), testUser.ID())
+	require.NoError(t, err)
+	assert.Equal(t, value_objects.RoleUser, legacy.Role())
+}
+
+func TestPostgreSQLUserRepository_ExternalUser(t *testing.T) {
+	db := setupUserTestDB(t)
+	repo := NewPostgreSQLUserRepository(db)
+
+	googleUser := helpers.CreateTestGoogleUser()
+	require.NoError(t, repo.Create(context.Background(), googleUser))
+
+	found, err := repo.GetByID(context.Background(), googleUser.ID())
+	require.NoError(t, err)
+	assert.Equal(t, "google", found.AuthProvider())
+	require.NotNil(t, found.PictureURL())
+	assert.Equal(t, "https://example.com/avatar.jpg", *found.PictureURL())
+	// External sign-ups have no password rather than an unusable one
+	assert.False(t, found.HasPassword())
+
+	var model models.User
+	require.NoError(t, db.Where("id = ?", googleUser.ID().String()).First(&model).Error)
+	assert.Nil(t, model.PasswordHash)
+}
+
 func TestPostgreSQLUserRepository_Delete(t *testing.T) {
 	db := setupUserTestDB(t)
 	repo := NewPostgreSQLUserRepository(db)
@@ -15,6 +15,10 @@ type Client interface {
 	MGet(ctx context.Context, keys ...string) ([]string, error)
 	Del(ctx context.Context, keys ...string) error
 	Exists(ctx context.Context, keys ...string) (int64, error)
+	PTTL(ctx context.Context, key string) (time.Duration, error)
+
+	// Scripting, for read-modify-write operations that must be atomic
+	Eval(ctx context.Context, script string, keys []string, args ...interface{}) (interface{}, error)
 
 	// Set operations
 	SAdd(ctx context.Context, key string, members ...interface{}) error
@@ -80,6 +84,14 @@ func (r *RealClient) Exists(ctx context.Context, keys ...string) (int64, error)
 	return r.cli.Exists(ctx, keys...).Result()
 }
 
+func (r *RealClient) PTTL(ctx context.Context, key string) (time.Duration, error) {
+	return r.cli.PTTL(ctx, key).Result()
+}
+
+func (r *RealClient) Eval(ctx context.Context, script string, keys []string, args ...interface{}) (interface{}, error) {
+	return r.cli.Eval(ctx, script, keys, args...).Result()
+}
+
 func (r *RealClient) SAdd(ctx context.Context, key string, members ...interface{}) error {
 	return r.cli.SAdd(ctx, key, members...).Err()
 }
@@ -118,8 +130,12 @@ func (m *MockClient) Get(ctx context.Context, key string) (string, error) { retu
 func (m *MockClient) MGet(ctx context.Context, keys ...string) ([]string, error) {
 	return []string{}, nil
 }
-func (m *MockClient) Del(ctx context.Context, keys ...string) error                      { return nil }
-func (m *MockClient) Exists(ctx context.Context, keys ...string) (int64, error)          { return 0, nil }
+func (m *MockClient) Del(ctx context.Context, keys ...string) error               { return nil }
+func (m *MockClient) Exists(ctx context.Context, keys ...string) (int64, error)   { return 0, nil }
+func (m *MockClient) PTTL(ctx context.Context, key string) (time.Duration, error) { return 0, nil }
+func (m *MockClient) Eval(ctx context.Context, script string, keys []string, args ...interface{}) (interface{}, error) {
+	return nil, nil
+}
 func (m *MockClient) SAdd(ctx context.Context, key string, members ...interface{}) error { return nil }
 func (m *MockClient) SRem(ctx context.Context, key string, members ...interface{}) error { return nil }
 func (m *MockClient) SMembers(ctx context.Context, key string) ([]string, error) {
@@ -0,0 +1,75 @@
+package filestore
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"testing"
+
+	appfilestore "github.com/atdevten/peace/internal/application/services/filestore"
+)
+
+func TestLocalStore(t *testing.T) {
+	dir := t.TempDir()
+	store, err := NewLocalStore(dir)
+	if err != nil {
+		t.Fatalf("NewLocalStore() unexpected error = %v", err)
+	}
+	ctx := context.Background()
+
+	writer, err := store.Create(ctx, "export.zip")
+	if err != nil {
+		t.Fatalf("Create() unexpected error = %v", err)
+	}
+	if _, err := writer.Write([]byte("archive")); err != nil {
+		t.Fatalf("Write() unexpected error = %v", err)
+	}
+
+	// Nothing is visible before the writer is closed
+	if _, err := store.Open(ctx, "export.zip"); !errors.Is(err, appfilestore.ErrNotFound) {
+		t.Errorf("Open() before Close error = %v, want ErrNotFound", err)
+	}
+
+	if err := writer.Close(); err != nil {
+		t.Fatalf("Close() unexpected error = %v", err)
+	}
+
+	reader, err := store.Open(ctx, "export.zip")
+	if err != nil {
+		t.Fatalf("Open() unexpected error = %v", err)
+	}
+	content, err := io.ReadAll(reader)
+	reader.Close()
+	if err != nil || string(content) != "archive" {
+		t.Errorf("Open() content = %q, %v, want %q", content, err, "archive")
+	}
+
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 1 {
+		t.Errorf("store directory has %d entries, want 1", len(entries))
+	}
+
+	if err := store.Delete(ctx, "export.zip"); err != nil {
+		t.Fatalf("Delete() unexpected error = %v", err)
+	}
+	if _, err := store.Open(ctx, "export.zip"); !errors.Is(err, appfilestore.ErrNotFound) {
+		t.Errorf("Open() after Delete error = %v, want ErrNotFound", err)
+	}
+	if err := store.Delete(ctx, "export.zip"); err != nil {
+		t.Errorf("Delete() of missing file error = %v, want nil", err)
+	}
+}
+
+func TestLocalStore_RejectsKeysOutsideDirectory(t *testing.T) {
+	store, err := NewLocalStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewLocalStore() unexpected error = %v", err)
+	}
+
+	for _, key := range []string{"", "../secret", "nested/file", `nested\file`, ".hidden"} {
+		if _, err := store.Create(context.Background(), key); err == nil {
+			t.Errorf("Create(%q) expected error", key)
+		}
+	}
+}
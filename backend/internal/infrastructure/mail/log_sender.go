@@ -0,0 +1,20 @@
+package mail
+
+import (
+	"context"
+	"log"
+
+	appmail "github.com/atdevten/peace/internal/application/services/mail"
+)
+
+type logSender struct{}
+
+// NewLogSender creates a sender that only writes emails to the process log, for local development
+func NewLogSender() appmail.Sender {
+	return &logSender{}
+}
+
+func (s *logSender) Send(ctx context.Context, message appmail.Message) error {
+	log.Printf("mail to=%s subject=%q\n%s", message.To, message.Subject, message.Body)
+	return nil
+}
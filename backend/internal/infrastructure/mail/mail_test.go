@@ -0,0 +1,63 @@
+package mail
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	appmail "github.com/atdevten/peace/internal/application/services/mail"
+)
+
+func TestMemorySender(t *testing.T) {
+	sender := NewMemorySender()
+
+	message := appmail.Message{To: "test@example.com", Subject: "Hello", Body: "Body"}
+	if err := sender.Send(context.Background(), message); err != nil {
+		t.Fatalf("Send() unexpected error = %v", err)
+	}
+
+	messages := sender.Messages()
+	if len(messages) != 1 {
+		t.Fatalf("Messages() len = %d, want 1", len(messages))
+	}
+	if messages[0] != message {
+		t.Errorf("Messages()[0] = %+v, want %+v", messages[0], message)
+	}
+
+	sender.Reset()
+	if got := len(sender.Messages()); got != 0 {
+		t.Errorf("Messages() after Reset len = %d, want 0", got)
+	}
+}
+
+func TestBuildMessage(t *testing.T) {
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	raw := string(buildMessage("no-reply@peace.local", appmail.Message{
+		To:      "test@example.com",
+		Subject: "Reset your password",
+		Body:    "line one\nline two",
+	}, now))
+
+	for _, want := range []string{
+		"From: no-reply@peace.local\r\n",
+		"To: test@example.com\r\n",
+		"Subject: Reset your password\r\n",
+		"Date: Tue, 02 Jan 2024 03:04:05 +0000\r\n",
+		"Content-Type: text/plain; charset=UTF-8\r\n",
+		"\r\n\r\nline one\r\nline two",
+	} {
+		if !strings.Contains(raw, want) {
+			t.Errorf("buildMessage() missing %q in %q", want, raw)
+		}
+	}
+}
+
+func TestSMTPSender_RequiresRecipient(t *testing.T) {
+	sender := NewSMTPSender("localhost", "25", "", "", "no-reply@peace.local")
+
+	err := sender.Send(context.Background(), appmail.Message{Subject: "x"})
+	if err == nil || err.Error() != "mail recipient is required" {
+		t.Errorf("Send() error = %v, want mail recipient is required", err)
+	}
+}
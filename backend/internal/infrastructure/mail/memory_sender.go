@@ -0,0 +1,44 @@
+package mail
+
+import (
+	"context"
+	"sync"
+
+	appmail "github.com/atdevten/peace/internal/application/services/mail"
+)
+
+// MemorySender keeps every sent email in memory so tests can inspect them
+type MemorySender struct {
+	mu       sync.Mutex
+	messages []appmail.Message
+}
+
+func NewMemorySender() *MemorySender {
+	return &MemorySender{}
+}
+
+func (s *MemorySender) Send(ctx context.Context, message appmail.Message) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.messages = append(s.messages, message)
+	return nil
+}
+
+// Messages returns a copy of the emails sent so far
+func (s *MemorySender) Messages() []appmail.Message {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	messages := make([]appmail.Message, len(s.messages))
+	copy(messages, s.messages)
+	return messages
+}
+
+// Reset forgets all sent emails
+func (s *MemorySender) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.messages = nil
+}
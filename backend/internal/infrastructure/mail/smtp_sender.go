@@ -0,0 +1,80 @@
+package mail
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	netmail "net/mail"
+	"net/smtp"
+	"strings"
+	"time"
+
+	appmail "github.com/atdevten/peace/internal/application/services/mail"
+)
+
+type smtpSender struct {
+	host     string
+	port     string
+	username string
+	password string
+	from     string
+}
+
+// NewSMTPSender creates a sender that delivers through an SMTP relay.
+// PLAIN auth is used when a username is configured.
+func NewSMTPSender(host, port, username, password, from string) appmail.Sender {
+	return &smtpSender{
+		host:     host,
+		port:     port,
+		username: username,
+		password: password,
+		from:     from,
+	}
+}
+
+func (s *smtpSender) Send(ctx context.Context, message appmail.Message) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	if message.To == "" {
+		return errors.New("mail recipient is required")
+	}
+
+	// The envelope sender must be a bare address, while the From header may carry a display name
+	envelopeFrom, err := netmail.ParseAddress(s.from)
+	if err != nil {
+		return fmt.Errorf("netmail.ParseAddress: %w", err)
+	}
+
+	var auth smtp.Auth
+	if s.username != "" {
+		auth = smtp.PlainAuth("", s.username, s.password, s.host)
+	}
+
+	addr := net.JoinHostPort(s.host, s.port)
+	if err := smtp.SendMail(addr, auth, envelopeFrom.Address, []string{message.To}, buildMessage(s.from, message, time.Now())); err != nil {
+		return fmt.Errorf("smtp.SendMail: %w", err)
+	}
+	return nil
+}
+
+// buildMessage renders an RFC 5322 plain-text message
+func buildMessage(from string, message appmail.Message, now time.Time) []byte {
+	var b strings.Builder
+	b.WriteString("From: " + headerValue(from) + "\r\n")
+	b.WriteString("To: " + headerValue(message.To) + "\r\n")
+	b.WriteString("Subject: " + headerValue(message.Subject) + "\r\n")
+	b.WriteString("Date: " + now.Format(time.RFC1123Z) + "\r\n")
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(strings.ReplaceAll(message.Body, "\n", "\r\n"))
+	return []byte(b.String())
+}
+
+// headerValue strips line breaks so values cannot inject extra headers
+func headerValue(value string) string {
+	return strings.NewReplacer("\r", "", "\n", "").Replace(value)
+}
@@ -0,0 +1,72 @@
+package ratelimit
+
+import (
+	"context"
+	"log"
+	"sync/atomic"
+	"time"
+
+	appratelimit "github.com/atdevten/peace/internal/application/services/ratelimit"
+)
+
+// FallbackStore serves from primary and switches to fallback for calls primary fails, so an
+// outage of Redis degrades limits to per instance instead of failing requests
+type FallbackStore struct {
+	primary  appratelimit.Store
+	fallback appratelimit.Store
+	degraded atomic.Bool // logs the switch once per outage instead of once per request
+}
+
+func NewFallbackStore(primary appratelimit.Store, fallback appratelimit.Store) *FallbackStore {
+	return &FallbackStore{primary: primary, fallback: fallback}
+}
+
+var _ appratelimit.Store = (*FallbackStore)(nil)
+
+func (s *FallbackStore) Hit(ctx context.Context, key string, window time.Duration) (int64, time.Duration, error) {
+	count, remaining, err := s.primary.Hit(ctx, key, window)
+	if s.failed(err) {
+		return s.fallback.Hit(ctx, key, window)
+	}
+	return count, remaining, nil
+}
+
+func (s *FallbackStore) Lock(ctx context.Context, key string, ttl time.Duration) error {
+	if err := s.primary.Lock(ctx, key, ttl); s.failed(err) {
+		return s.fallback.Lock(ctx, key, ttl)
+	}
+	return nil
+}
+
+func (s *FallbackStore) LockedFor(ctx context.Context, key string) (time.Duration, error) {
+	remaining, err := s.primary.LockedFor(ctx, key)
+	if s.failed(err) {
+		return s.fallback.LockedFor(ctx, key)
+	}
+	return remaining, nil
+}
+
+func (s *FallbackStore) Reset(ctx context.Context, keys ...string) error {
+	// Counters may live in either store after an outage
+	err := s.primary.Reset(ctx, keys...)
+	if fallbackErr := s.fallback.Reset(ctx, keys...); fallbackErr != nil {
+		return fallbackErr
+	}
+	s.failed(err)
+	return nil
+}
+
+// failed reports whether err sends the call to the fallback, logging when the primary goes down or recovers
+func (s *FallbackStore) failed(err error) bool {
+	if err != nil {
+		if !s.degraded.Swap(true) {
+			log.Printf("rate limit store unavailable, falling back to memory: %v", err)
+		}
+		return true
+	}
+
+	if s.degraded.Swap(false) {
+		log.Printf("rate limit store recovered")
+	}
+	return false
+}
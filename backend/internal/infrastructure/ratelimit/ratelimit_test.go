@@ -0,0 +1,167 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// clock is a settable time source for MemoryStore
+type clock struct{ now time.Time }
+
+func (c *clock) Now() time.Time { return c.now }
+
+func newTestMemoryStore() (*MemoryStore, *clock) {
+	c := &clock{now: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)}
+	store := NewMemoryStore()
+	store.now = c.Now
+	return store, c
+}
+
+func TestMemoryStore_Hit(t *testing.T) {
+	store, c := newTestMemoryStore()
+	ctx := context.Background()
+
+	for want := int64(1); want <= 3; want++ {
+		count, remaining, err := store.Hit(ctx, "key", time.Minute)
+		require.NoError(t, err)
+		assert.Equal(t, want, count)
+		assert.Equal(t, time.Minute, remaining)
+	}
+
+	// The window is fixed from the first hit
+	c.now = c.now.Add(40 * time.Second)
+	count, remaining, err := store.Hit(ctx, "key", time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), count)
+	assert.Equal(t, 20*time.Second, remaining)
+
+	// Other keys count separately
+	count, _, err = store.Hit(ctx, "other", time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+
+	// A new window opens once the previous one closed
+	c.now = c.now.Add(20 * time.Second)
+	count, remaining, err = store.Hit(ctx, "key", time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+	assert.Equal(t, time.Minute, remaining)
+
+	require.NoError(t, store.Reset(ctx, "key"))
+	count, _, err = store.Hit(ctx, "key", time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+}
+
+func TestMemoryStore_Lock(t *testing.T) {
+	store, c := newTestMemoryStore()
+	ctx := context.Background()
+
+	remaining, err := store.LockedFor(ctx, "lock")
+	require.NoError(t, err)
+	assert.Zero(t, remaining)
+
+	require.NoError(t, store.Lock(ctx, "lock", time.Minute))
+	c.now = c.now.Add(15 * time.Second)
+	remaining, err = store.LockedFor(ctx, "lock")
+	require.NoError(t, err)
+	assert.Equal(t, 45*time.Second, remaining)
+
+	c.now = c.now.Add(45 * time.Second)
+	remaining, err = store.LockedFor(ctx, "lock")
+	require.NoError(t, err)
+	assert.Zero(t, remaining)
+
+	require.NoError(t, store.Lock(ctx, "lock", time.Minute))
+	require.NoError(t, store.Reset(ctx, "lock"))
+	remaining, err = store.LockedFor(ctx, "lock")
+	require.NoError(t, err)
+	assert.Zero(t, remaining)
+}
+
+func TestMemoryStore_SweepsExpiredEntries(t *testing.T) {
+	store, c := newTestMemoryStore()
+	ctx := context.Background()
+
+	_, _, err := store.Hit(ctx, "short", time.Second)
+	require.NoError(t, err)
+	require.NoError(t, store.Lock(ctx, "long", time.Hour))
+
+	c.now = c.now.Add(2 * sweepInterval)
+	_, _, err = store.Hit(ctx, "new", time.Second)
+	require.NoError(t, err)
+
+	assert.NotContains(t, store.entries, "short")
+	assert.Contains(t, store.entries, "long")
+}
+
+// failingStore fails every call while down
+type failingStore struct {
+	*MemoryStore
+	down bool
+}
+
+var errStoreDown = errors.New("connection refused")
+
+func (s *failingStore) Hit(ctx context.Context, key string, window time.Duration) (int64, time.Duration, error) {
+	if s.down {
+		return 0, 0, errStoreDown
+	}
+	return s.MemoryStore.Hit(ctx, key, window)
+}
+
+func (s *failingStore) LockedFor(ctx context.Context, key string) (time.Duration, error) {
+	if s.down {
+		return 0, errStoreDown
+	}
+	return s.MemoryStore.LockedFor(ctx, key)
+}
+
+func (s *failingStore) Lock(ctx context.Context, key string, ttl time.Duration) error {
+	if s.down {
+		return errStoreDown
+	}
+	return s.MemoryStore.Lock(ctx, key, ttl)
+}
+
+func TestFallbackStore(t *testing.T) {
+	primary := &failingStore{MemoryStore: NewMemoryStore()}
+	fallback := NewMemoryStore()
+	store := NewFallbackStore(primary, fallback)
+	ctx := context.Background()
+
+	count, _, err := store.Hit(ctx, "key", time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+
+	// While the primary is down calls are served from memory instead of failing
+	primary.down = true
+	count, _, err = store.Hit(ctx, "key", time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+
+	require.NoError(t, store.Lock(ctx, "lock", time.Minute))
+	remaining, err := store.LockedFor(ctx, "lock")
+	require.NoError(t, err)
+	assert.Equal(t, time.Minute, remaining.Round(time.Second))
+
+	// Once it recovers the primary's counters are used again
+	primary.down = false
+	count, _, err = store.Hit(ctx, "key", time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+
+	// Reset clears keys in both stores
+	require.NoError(t, store.Reset(ctx, "key", "lock"))
+	remaining, err = fallback.LockedFor(ctx, "lock")
+	require.NoError(t, err)
+	assert.Zero(t, remaining)
+	count, _, err = store.Hit(ctx, "key", time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+}
@@ -0,0 +1,122 @@
+package handlers
+
+import (
+	"errors"
+	"time"
+
+	"github.com/atdevten/peace/internal/application/services/oauth"
+	"github.com/atdevten/peace/internal/application/usecases"
+	"github.com/atdevten/peace/internal/domain/entities"
+	"github.com/atdevten/peace/internal/interfaces/http/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AccountLinkHandler struct {
+	accountLinkUseCase usecases.AccountLinkUseCase
+}
+
+type LinkIdentityRequest struct {
+	Code  string `json:"code" binding:"required"`
+	State string `json:"state" binding:"required"`
+}
+
+type IdentityResponse struct {
+	Provider string    `json:"provider"`
+	Email    string    `json:"email"`
+	LinkedAt time.Time `json:"linked_at"`
+}
+
+func NewAccountLinkHandler(accountLinkUseCase usecases.AccountLinkUseCase) *AccountLinkHandler {
+	return &AccountLinkHandler{
+		accountLinkUseCase: accountLinkUseCase,
+	}
+}
+
+// ListIdentities returns the external accounts linked to the authenticated user
+func (h *AccountLinkHandler) ListIdentities(c *gin.Context) {
+	userID, ok := middleware.GetUserIDFromGinContext(c)
+	if !ok {
+		Error(c, CodeUnauthorized, "User not authenticated")
+		return
+	}
+
+	ctx := c.Request.Context()
+	identities, err := h.accountLinkUseCase.ListIdentities(ctx, userID.String())
+	if err != nil {
+		Error(c, CodeServerError, "Failed to retrieve linked accounts")
+		return
+	}
+
+	response := make([]IdentityResponse, 0, len(identities))
+	for _, identity := range identities {
+		response = append(response, toIdentityResponse(identity))
+	}
+
+	Success(c, "Linked accounts retrieved successfully", response)
+}
+
+// Link connects an account of the provider in the path to the authenticated user
+func (h *AccountLinkHandler) Link(c *gin.Context) {
+	userID, ok := middleware.GetUserIDFromGinContext(c)
+	if !ok {
+		Error(c, CodeUnauthorized, "User not authenticated")
+		return
+	}
+
+	var req LinkIdentityRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		Error(c, CodeBadRequest, "code and state are required")
+		return
+	}
+
+	ctx := c.Request.Context()
+	identity, err := h.accountLinkUseCase.Link(ctx, userID.String(), c.Param("provider"), req.Code, req.State, takePendingLogin(c))
+	if err != nil {
+		if errors.Is(err, oauth.ErrUnknownProvider) {
+			Error(c, CodeNotFound, err.Error())
+			return
+		}
+		if errors.Is(err, usecases.ErrProviderAlreadyLinked) || errors.Is(err, usecases.ErrExternalAccountInUse) {
+			Error(c, CodeConflict, err.Error())
+			return
+		}
+		Error(c, CodeBadRequest, err.Error())
+		return
+	}
+
+	Success(c, "Account linked successfully", toIdentityResponse(identity))
+}
+
+// Unlink disconnects the account of the provider in the path from the authenticated user
+func (h *AccountLinkHandler) Unlink(c *gin.Context) {
+	userID, ok := middleware.GetUserIDFromGinContext(c)
+	if !ok {
+		Error(c, CodeUnauthorized, "User not authenticated")
+		return
+	}
+
+	ctx := c.Request.Context()
+	if err := h.accountLinkUseCase.Unlink(ctx, userID.String(), c.Param("provider")); err != nil {
+		if errors.Is(err, usecases.ErrProviderNotLinked) {
+			Error(c, CodeNotFound, err.Error())
+			return
+		}
+		if errors.Is(err, usecases.ErrLastLoginMethod) {
+			Error(c, CodeConflict, err.Error())
+			return
+		}
+		Error(c, CodeServerError, err.Error())
+		return
+	}
+
+	Success(c, "Account unlinked successfully", nil)
+}
+
+func toIdentityResponse(identity *entities.UserIdentity) IdentityResponse {
+	return IdentityResponse{
+		Provider: identity.Provider(),
+		Email:    identity.Email(),
+		LinkedAt: identity.CreatedAt(),
+	}
+}
@@ -0,0 +1,61 @@
+package handlers
+
+import (
+	"errors"
+
+	"github.com/atdevten/peace/internal/application/usecases"
+	"github.com/atdevten/peace/internal/interfaces/http/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AdminHandler serves user administration endpoints; routes must be guarded by RequireRole(admin)
+type AdminHandler struct {
+	userUseCase usecases.UserUseCase
+}
+
+func NewAdminHandler(userUseCase usecases.UserUseCase) *AdminHandler {
+	return &AdminHandler{userUseCase: userUseCase}
+}
+
+type ChangeRoleRequest struct {
+	Role string `json:"role" binding:"required"`
+}
+
+// ChangeUserRole sets the role of another user
+func (h *AdminHandler) ChangeUserRole(c *gin.Context) {
+	targetID := c.Param("id")
+	if targetID == "" {
+		Error(c, CodeBadRequest, "User ID is required")
+		return
+	}
+
+	actorID, ok := middleware.GetUserIDFromGinContext(c)
+	if !ok {
+		Error(c, CodeUnauthorized, "User not authenticated")
+		return
+	}
+
+	var req ChangeRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		Error(c, CodeBadRequest, "role is required")
+		return
+	}
+
+	ctx := c.Request.Context()
+	user, err := h.userUseCase.ChangeRole(ctx, actorID.String(), targetID, req.Role)
+	if err != nil {
+		if errors.Is(err, usecases.ErrCannotChangeOwnRole) {
+			Error(c, CodeForbidden, err.Error())
+			return
+		}
+		userUpdateError(c, err)
+		return
+	}
+
+	data := gin.H{
+		"id":   user.ID().String(),
+		"role": user.Role().String(),
+	}
+	Success(c, "Role updated successfully", data)
+}
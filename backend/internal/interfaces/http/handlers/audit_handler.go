@@ -0,0 +1,111 @@
+package handlers
+
+import (
+	"strconv"
+
+	"github.com/atdevten/peace/internal/application/commands"
+	"github.com/atdevten/peace/internal/application/usecases"
+	"github.com/atdevten/peace/internal/pkg/timeutil"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AuditHandler serves the audit log; routes must be guarded by RequireRole(admin)
+type AuditHandler struct {
+	auditUseCase usecases.AuditUseCase
+}
+
+type AuditEventResponse struct {
+	ID         string                 `json:"id"`
+	ActorID    *string                `json:"actor_id"` // null for anonymous callers
+	Action     string                 `json:"action"`
+	TargetType string                 `json:"target_type"`
+	TargetID   string                 `json:"target_id"`
+	IPAddress  string                 `json:"ip_address"`
+	UserAgent  string                 `json:"user_agent"`
+	Metadata   map[string]interface{} `json:"metadata"`
+	CreatedAt  string                 `json:"created_at"`
+}
+
+func NewAuditHandler(auditUseCase usecases.AuditUseCase) *AuditHandler {
+	return &AuditHandler{
+		auditUseCase: auditUseCase,
+	}
+}
+
+// ListEvents returns audit events, newest first, filtered by actor_id, action, target_type,
+// target_id and a from/to time range
+func (h *AuditHandler) ListEvents(c *gin.Context) {
+	// Get query parameters
+	var cursorPtr *string
+	var limitPtr *int
+
+	if cursor := c.Query("cursor"); cursor != "" {
+		cursorPtr = &cursor
+	}
+	if limitStr := c.Query("limit"); limitStr != "" {
+		limit, err := strconv.Atoi(limitStr)
+		if err != nil {
+			Error(c, CodeBadRequest, "limit must be a number")
+			return
+		}
+		limitPtr = &limit
+	}
+
+	query := func(key string) *string {
+		if value, ok := c.GetQuery(key); ok {
+			return &value
+		}
+		return nil
+	}
+	filters := commands.AuditEventFilters{
+		ActorID:    query("actor_id"),
+		Action:     query("action"),
+		TargetType: query("target_type"),
+		TargetID:   query("target_id"),
+		From:       query("from"),
+		To:         query("to"),
+	}
+
+	// Create command
+	command, err := commands.NewListAuditEventsCommand(filters, limitPtr, cursorPtr)
+	if err != nil {
+		Error(c, CodeBadRequest, err.Error())
+		return
+	}
+
+	// Execute use case
+	ctx := c.Request.Context()
+	page, err := h.auditUseCase.ListEvents(ctx, command)
+	if err != nil {
+		Error(c, CodeBadRequest, err.Error())
+		return
+	}
+
+	// Build response
+	responses := make([]AuditEventResponse, 0, len(page.Events))
+	for _, event := range page.Events {
+		response := AuditEventResponse{
+			ID:         event.ID().String(),
+			Action:     event.Action().String(),
+			TargetType: event.TargetType(),
+			TargetID:   event.TargetID(),
+			IPAddress:  event.IPAddress(),
+			UserAgent:  event.UserAgent(),
+			Metadata:   event.Metadata(),
+			CreatedAt:  timeutil.FormatTime(event.CreatedAt()),
+		}
+		if event.ActorID() != nil {
+			actorID := event.ActorID().String()
+			response.ActorID = &actorID
+		}
+		responses = append(responses, response)
+	}
+
+	meta := PaginationMeta{
+		Limit:      command.Limit,
+		NextCursor: page.NextCursor,
+	}
+
+	SuccessWithMeta(c, "Audit events retrieved successfully", responses, meta)
+}
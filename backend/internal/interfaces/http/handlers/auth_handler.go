@@ -1,8 +1,16 @@
 package handlers
 
 import (
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+
 	"github.com/atdevten/peace/internal/application/commands"
+	"github.com/atdevten/peace/internal/application/services/oauth"
 	"github.com/atdevten/peace/internal/application/usecases"
+	"github.com/atdevten/peace/internal/interfaces/http/middleware"
+	"github.com/atdevten/peace/internal/pkg/timeutil"
 
 	"github.com/gin-gonic/gin"
 )
@@ -37,6 +45,28 @@ type LoginResponse struct {
 	RefreshToken string       `json:"refresh_token"`
 }
 
+// MFAChallengeResponse is returned instead of tokens when the account requires a second factor
+type MFAChallengeResponse struct {
+	MFARequired bool   `json:"mfa_required"`
+	MFAToken    string `json:"mfa_token"`
+}
+
+// LinkChallengeResponse is returned instead of tokens when an external login matches an
+// account it is not linked to; the account password must confirm the link
+type LinkChallengeResponse struct {
+	LinkRequired bool   `json:"link_required"`
+	LinkToken    string `json:"link_token"`
+	Provider     string `json:"provider"`
+}
+
+// RestoreChallengeResponse is returned instead of tokens when a deleted account logs in during
+// its grace period; the restore token is only accepted by POST /api/user/restore
+type RestoreChallengeResponse struct {
+	RestoreRequired bool   `json:"restore_required"`
+	RestoreToken    string `json:"restore_token"`
+	RestoreBefore   string `json:"restore_before"`
+}
+
 func NewAuthHandler(authUseCase usecases.AuthUseCase) *AuthHandler {
 	return &AuthHandler{
 		authUseCase: authUseCase,
@@ -94,7 +124,7 @@ func (h *AuthHandler) Login(c *gin.Context) {
 	}
 
 	// Create command
-	command, err := commands.NewLoginCommand(req.Email, req.Password)
+	command, err := commands.NewLoginCommand(req.Email, req.Password, clientInfo(c))
 	if err != nil {
 		Error(c, CodeBadRequest, err.Error())
 		return
@@ -102,27 +132,50 @@ func (h *AuthHandler) Login(c *gin.Context) {
 
 	// Execute use case
 	ctx := c.Request.Context()
-	user, access, refresh, err := h.authUseCase.Login(ctx, command)
+	result, err := h.authUseCase.Login(ctx, command)
 	if err != nil {
+		var locked *usecases.LoginLockedError
+		if errors.As(err, &locked) {
+			TooManyRequests(c, locked.Error(), locked.RetryAfter)
+			return
+		}
 		Error(c, CodeUnauthorized, err.Error())
 		return
 	}
 
-	// Build response
-	userResponse := UserResponse{
-		ID:       user.ID().String(),
-		Email:    user.Email().String(),
-		Username: user.Username().String(),
-		FullName: user.GetFullName(),
+	respondLogin(c, "Login successful", result)
+}
+
+// VerifyMFARequest completes a login answered with an MFA challenge
+type VerifyMFARequest struct {
+	MFAToken string `json:"mfa_token"`
+	Code     string `json:"code"`
+}
+
+// VerifyMFA exchanges an MFA challenge and a TOTP or recovery code for a token pair
+func (h *AuthHandler) VerifyMFA(c *gin.Context) {
+	var req VerifyMFARequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		Error(c, CodeBadRequest, "Invalid request body")
+		return
 	}
 
-	loginResponse := LoginResponse{
-		User:         userResponse,
-		AccessToken:  access,
-		RefreshToken: refresh,
+	// Create command
+	command, err := commands.NewVerifyMFACommand(req.MFAToken, req.Code, clientInfo(c))
+	if err != nil {
+		Error(c, CodeBadRequest, err.Error())
+		return
+	}
+
+	// Execute use case
+	ctx := c.Request.Context()
+	result, err := h.authUseCase.VerifyMFA(ctx, command)
+	if err != nil {
+		Error(c, CodeUnauthorized, err.Error())
+		return
 	}
 
-	Success(c, "Login successful", loginResponse)
+	respondLogin(c, "Login successful", result)
 }
 
 type RefreshRequest struct {
@@ -154,28 +207,331 @@ func (h *AuthHandler) Refresh(c *gin.Context) {
 	})
 }
 
-// GoogleLoginRequest represents the request for Google OAuth login
-type GoogleLoginRequest struct {
-	Code string `json:"code" binding:"required"`
+// LogoutRequest represents the request to end the session a refresh token belongs to
+type LogoutRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+// Logout revokes the refresh token family of the current login
+func (h *AuthHandler) Logout(c *gin.Context) {
+	var req LogoutRequest
+	if err := c.ShouldBindJSON(&req); err != nil || req.RefreshToken == "" {
+		Error(c, CodeBadRequest, "Invalid request body")
+		return
+	}
+
+	ctx := c.Request.Context()
+	if err := h.authUseCase.Logout(ctx, req.RefreshToken); err != nil {
+		Error(c, CodeUnauthorized, err.Error())
+		return
+	}
+
+	Success(c, "Logged out successfully", nil)
+}
+
+// LogoutAll revokes every refresh token of the authenticated user
+func (h *AuthHandler) LogoutAll(c *gin.Context) {
+	userID, ok := middleware.GetUserIDFromGinContext(c)
+	if !ok {
+		Error(c, CodeUnauthorized, "User not authenticated")
+		return
+	}
+
+	ctx := c.Request.Context()
+	if err := h.authUseCase.LogoutAll(ctx, userID.String()); err != nil {
+		Error(c, CodeServerError, err.Error())
+		return
+	}
+
+	Success(c, "Logged out from all devices successfully", nil)
+}
+
+// ForgotPasswordRequest represents the request to start a password reset
+type ForgotPasswordRequest struct {
+	Email string `json:"email"`
+}
+
+// ForgotPassword emails a reset link; the response is identical whether or not the account exists
+func (h *AuthHandler) ForgotPassword(c *gin.Context) {
+	var req ForgotPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		Error(c, CodeBadRequest, "Invalid request body")
+		return
+	}
+
+	command, err := commands.NewForgotPasswordCommand(req.Email)
+	if err != nil {
+		Error(c, CodeBadRequest, err.Error())
+		return
+	}
+
+	ctx := c.Request.Context()
+	if err := h.authUseCase.ForgotPassword(ctx, command); err != nil {
+		Error(c, CodeBadRequest, err.Error())
+		return
+	}
+
+	Success(c, "If the email is registered, a password reset link has been sent", nil)
+}
+
+// ResetPasswordRequest represents the request to set a new password with a reset token
+type ResetPasswordRequest struct {
+	Token       string `json:"token"`
+	NewPassword string `json:"new_password"`
+}
+
+// ResetPassword consumes a reset token and sets the new password
+func (h *AuthHandler) ResetPassword(c *gin.Context) {
+	var req ResetPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		Error(c, CodeBadRequest, "Invalid request body")
+		return
+	}
+
+	command, err := commands.NewResetPasswordCommand(req.Token, req.NewPassword)
+	if err != nil {
+		Error(c, CodeBadRequest, err.Error())
+		return
+	}
+
+	ctx := c.Request.Context()
+	if err := h.authUseCase.ResetPassword(ctx, command); err != nil {
+		Error(c, CodeBadRequest, err.Error())
+		return
+	}
+
+	Success(c, "Password has been reset successfully", nil)
+}
+
+// VerifyEmailRequest represents the request to confirm an email address
+type VerifyEmailRequest struct {
+	Token string `json:"token"`
+}
+
+// VerifyEmail consumes a verification token and marks the account's email as verified
+func (h *AuthHandler) VerifyEmail(c *gin.Context) {
+	var req VerifyEmailRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		Error(c, CodeBadRequest, "Invalid request body")
+		return
+	}
+
+	command, err := commands.NewVerifyEmailCommand(req.Token)
+	if err != nil {
+		Error(c, CodeBadRequest, err.Error())
+		return
+	}
+
+	ctx := c.Request.Context()
+	if err := h.authUseCase.VerifyEmail(ctx, command); err != nil {
+		Error(c, CodeBadRequest, err.Error())
+		return
+	}
+
+	Success(c, "Email verified successfully", nil)
+}
+
+// ResendVerificationEmailRequest represents the request to send a new verification email
+type ResendVerificationEmailRequest struct {
+	Email string `json:"email"`
+}
+
+// ResendVerificationEmail sends a new verification link, at most once per configured interval; the response
+// is identical whether the email is unknown, throttled or sent
+func (h *AuthHandler) ResendVerificationEmail(c *gin.Context) {
+	var req ResendVerificationEmailRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		Error(c, CodeBadRequest, "Invalid request body")
+		return
+	}
+
+	command, err := commands.NewResendVerificationEmailCommand(req.Email)
+	if err != nil {
+		Error(c, CodeBadRequest, err.Error())
+		return
+	}
+
+	ctx := c.Request.Context()
+	if err := h.authUseCase.ResendVerificationEmail(ctx, command); err != nil {
+		Error(c, CodeBadRequest, err.Error())
+		return
+	}
+
+	Success(c, "If the account needs verification, a new email has been sent", nil)
+}
+
+// OAuthProvidersResponse lists the external login providers that are configured
+type OAuthProvidersResponse struct {
+	Providers []string `json:"providers"`
+}
+
+// OAuthURLResponse is the provider page to redirect to; the client may compare State with the callback's,
+// the server checks it against the login cookie either way
+type OAuthURLResponse struct {
+	AuthURL string `json:"auth_url"`
+	State   string `json:"state"`
+}
+
+// OAuthLoginRequest carries the parameters a provider redirected back with
+type OAuthLoginRequest struct {
+	Code  string `json:"code"`
+	State string `json:"state"`
+}
+
+// ListOAuthProviders returns the configured external login providers
+func (h *AuthHandler) ListOAuthProviders(c *gin.Context) {
+	Success(c, "Login providers retrieved successfully", OAuthProvidersResponse{
+		Providers: h.authUseCase.OAuthProviders(),
+	})
+}
+
+// GetOAuthURL returns the authorization URL of a login provider
+func (h *AuthHandler) GetOAuthURL(c *gin.Context) {
+	ctx := c.Request.Context()
+	authorization, err := h.authUseCase.OAuthAuthorizationURL(ctx, c.Param("provider"))
+	if err != nil {
+		if errors.Is(err, oauth.ErrUnknownProvider) {
+			Error(c, CodeNotFound, err.Error())
+			return
+		}
+		Error(c, CodeServerError, "Failed to start login")
+		return
+	}
+
+	setPendingLogin(c, authorization.PendingLogin)
+	Success(c, "Authorization URL generated", OAuthURLResponse{
+		AuthURL: authorization.URL,
+		State:   authorization.State,
+	})
+}
+
+// OAuthLogin handles the callback of an external login provider
+func (h *AuthHandler) OAuthLogin(c *gin.Context) {
+	var req OAuthLoginRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		Error(c, CodeBadRequest, "Invalid request body")
+		return
+	}
+
+	// Create command
+	pending := takePendingLogin(c)
+	command, err := commands.NewOAuthLoginCommand(c.Param("provider"), req.Code, req.State, pending.State, pending.Verifier, clientInfo(c))
+	if err != nil {
+		Error(c, CodeBadRequest, err.Error())
+		return
+	}
+
+	// Execute use case
+	ctx := c.Request.Context()
+	result, err := h.authUseCase.LoginWithProvider(ctx, command)
+	if err != nil {
+		if errors.Is(err, oauth.ErrUnknownProvider) {
+			Error(c, CodeNotFound, err.Error())
+			return
+		}
+		if errors.Is(err, usecases.ErrExternalAccountMismatch) || errors.Is(err, usecases.ErrExternalAccountInUse) {
+			Error(c, CodeConflict, err.Error())
+			return
+		}
+		Error(c, CodeUnauthorized, err.Error())
+		return
+	}
+
+	respondLogin(c, "Login successful", result)
+}
+
+// ConfirmLinkRequest answers a link challenge with the password of the existing account
+type ConfirmLinkRequest struct {
+	LinkToken string `json:"link_token"`
+	Password  string `json:"password"`
 }
 
-// GoogleLogin handles Google OAuth login
-func (h *AuthHandler) GoogleLogin(c *gin.Context) {
-	var req GoogleLoginRequest
+// ConfirmLink links the provider account to the existing account and logs in
+func (h *AuthHandler) ConfirmLink(c *gin.Context) {
+	var req ConfirmLinkRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		Error(c, CodeBadRequest, "Invalid request body")
 		return
 	}
 
+	// Create command
+	command, err := commands.NewConfirmLinkCommand(req.LinkToken, req.Password, clientInfo(c))
+	if err != nil {
+		Error(c, CodeBadRequest, err.Error())
+		return
+	}
+
+	// Execute use case
+	ctx := c.Request.Context()
+	result, err := h.authUseCase.ConfirmLink(ctx, command)
+	if err != nil {
+		if errors.Is(err, usecases.ErrExternalAccountInUse) {
+			Error(c, CodeConflict, err.Error())
+			return
+		}
+		Error(c, CodeUnauthorized, err.Error())
+		return
+	}
+
+	respondLogin(c, "Account linked", result)
+}
+
+// RestoreAccount restores the deleted account a restore token, sent as the bearer token, was issued for and logs it in
+func (h *AuthHandler) RestoreAccount(c *gin.Context) {
+	restoreToken, _ := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+
+	// Create command
+	command, err := commands.NewRestoreAccountCommand(restoreToken, clientInfo(c))
+	if err != nil {
+		Error(c, CodeUnauthorized, err.Error())
+		return
+	}
+
 	// Execute use case
 	ctx := c.Request.Context()
-	user, access, refresh, err := h.authUseCase.LoginWithGoogle(ctx, req.Code)
+	result, err := h.authUseCase.RestoreAccount(ctx, command)
 	if err != nil {
+		if errors.Is(err, usecases.ErrAccountNotRestorable) {
+			Error(c, CodeForbidden, err.Error())
+			return
+		}
 		Error(c, CodeUnauthorized, err.Error())
 		return
 	}
 
+	respondLogin(c, "Account restored", result)
+}
+
+// respondLogin writes the token pair, or the MFA, link or restore challenge, of a login result
+func respondLogin(c *gin.Context, message string, result *commands.LoginResult) {
+	if result.LinkRequired() {
+		Success(c, "Confirm your password to link this account", LinkChallengeResponse{
+			LinkRequired: true,
+			LinkToken:    result.LinkToken,
+			Provider:     result.Provider,
+		})
+		return
+	}
+
+	if result.RestoreRequired() {
+		Success(c, "This account is deleted, restore it to log in", RestoreChallengeResponse{
+			RestoreRequired: true,
+			RestoreToken:    result.RestoreToken,
+			RestoreBefore:   timeutil.FormatTime(*result.RestoreBefore),
+		})
+		return
+	}
+
+	if result.MFARequired() {
+		Success(c, "Two-factor authentication required", MFAChallengeResponse{
+			MFARequired: true,
+			MFAToken:    result.MFAToken,
+		})
+		return
+	}
+
 	// Build response
+	user := result.User
 	userResponse := UserResponse{
 		ID:       user.ID().String(),
 		Email:    user.Email().String(),
@@ -185,19 +541,49 @@ func (h *AuthHandler) GoogleLogin(c *gin.Context) {
 
 	loginResponse := LoginResponse{
 		User:         userResponse,
-		AccessToken:  access,
-		RefreshToken: refresh,
+		AccessToken:  result.AccessToken,
+		RefreshToken: result.RefreshToken,
 	}
 
-	Success(c, "Google login successful", loginResponse)
+	Success(c, message, loginResponse)
 }
 
-// GetGoogleAuthURL returns the Google OAuth authorization URL
-func (h *AuthHandler) GetGoogleAuthURL(c *gin.Context) {
-	// This would typically get the URL from a service
-	// For now, we'll return a placeholder
-	Success(c, "Google OAuth URL", gin.H{
-		"auth_url": "https://accounts.google.com/o/oauth2/v2/auth?client_id=...",
-		"note":     "This endpoint should be updated to use Google service",
-	})
+// clientInfo describes the caller for the session a login starts
+func clientInfo(c *gin.Context) commands.ClientInfo {
+	return commands.ClientInfo{
+		UserAgent: c.Request.UserAgent(),
+		IPAddress: c.ClientIP(),
+	}
+}
+
+// oauthLoginCookie keeps the state and PKCE verifier of a started provider login in the browser that started it
+const oauthLoginCookie = "oauth_login"
+
+// setPendingLogin hands a started login to the browser in an HttpOnly cookie, so scripts never see the
+// verifier and only this browser can complete the login
+func setPendingLogin(c *gin.Context, pending oauth.PendingLogin) {
+	value := url.Values{"state": {pending.State}, "verifier": {pending.Verifier}}.Encode()
+	c.SetSameSite(http.SameSiteLaxMode)
+	c.SetCookie(oauthLoginCookie, value, 0, "/api", "", isHTTPS(c), true)
+}
+
+// takePendingLogin reads and clears the login cookie; without one the login is empty and refused on exchange
+func takePendingLogin(c *gin.Context) oauth.PendingLogin {
+	value, err := c.Cookie(oauthLoginCookie)
+	c.SetSameSite(http.SameSiteLaxMode)
+	c.SetCookie(oauthLoginCookie, "", -1, "/api", "", isHTTPS(c), true)
+	if err != nil {
+		return oauth.PendingLogin{}
+	}
+
+	values, err := url.ParseQuery(value)
+	if err != nil {
+		return oauth.PendingLogin{}
+	}
+	return oauth.PendingLogin{State: values.Get("state"), Verifier: values.Get("verifier")}
+}
+
+// isHTTPS reports whether the client reached the API over TLS, directly or through a proxy
+func isHTTPS(c *gin.Context) bool {
+	return c.Request.TLS != nil || c.GetHeader("X-Forwarded-Proto") == "https"
 }
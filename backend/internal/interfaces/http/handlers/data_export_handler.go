@@ -0,0 +1,171 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/atdevten/peace/internal/application/commands"
+	"github.com/atdevten/peace/internal/application/usecases"
+	"github.com/atdevten/peace/internal/domain/entities"
+	"github.com/atdevten/peace/internal/domain/repositories"
+	"github.com/atdevten/peace/internal/interfaces/http/middleware"
+	"github.com/atdevten/peace/internal/pkg/timeutil"
+
+	"github.com/gin-gonic/gin"
+)
+
+type DataExportHandler struct {
+	exportUseCase usecases.DataExportUseCase
+}
+
+type DataExportResponse struct {
+	ID                   string  `json:"id"`
+	Status               string  `json:"status"`
+	SizeBytes            int64   `json:"size_bytes"`
+	CreatedAt            string  `json:"created_at"`
+	CompletedAt          *string `json:"completed_at"`
+	ExpiresAt            *string `json:"expires_at"`
+	DownloadURL          *string `json:"download_url"` // signed, usable without credentials until download_url_expires_at
+	DownloadURLExpiresAt *string `json:"download_url_expires_at"`
+}
+
+func NewDataExportHandler(exportUseCase usecases.DataExportUseCase) *DataExportHandler {
+	return &DataExportHandler{
+		exportUseCase: exportUseCase,
+	}
+}
+
+// RequestExport queues a ZIP archive of all the authenticated user's data
+func (h *DataExportHandler) RequestExport(c *gin.Context) {
+	userID, ok := middleware.GetUserIDFromGinContext(c)
+	if !ok {
+		Error(c, CodeUnauthorized, "User not authenticated")
+		return
+	}
+
+	ctx := c.Request.Context()
+	export, err := h.exportUseCase.RequestExport(ctx, userID.String())
+	if err != nil {
+		if errors.Is(err, usecases.ErrDataExportInProgress) {
+			Error(c, CodeConflict, err.Error())
+			return
+		}
+		Error(c, CodeServerError, "Failed to request data export")
+		return
+	}
+
+	Accepted(c, "Data export requested, check its status for the download link", h.toDataExportResponse(export))
+}
+
+// ListExports returns the data exports of the authenticated user
+func (h *DataExportHandler) ListExports(c *gin.Context) {
+	userID, ok := middleware.GetUserIDFromGinContext(c)
+	if !ok {
+		Error(c, CodeUnauthorized, "User not authenticated")
+		return
+	}
+
+	ctx := c.Request.Context()
+	exports, err := h.exportUseCase.ListExports(ctx, userID.String())
+	if err != nil {
+		Error(c, CodeServerError, "Failed to retrieve data exports")
+		return
+	}
+
+	response := make([]DataExportResponse, 0, len(exports))
+	for _, export := range exports {
+		response = append(response, h.toDataExportResponse(export))
+	}
+
+	Success(c, "Data exports retrieved successfully", response)
+}
+
+// GetExport returns one data export with a fresh download link once it is ready
+func (h *DataExportHandler) GetExport(c *gin.Context) {
+	exportID := c.Param("id")
+	if exportID == "" {
+		Error(c, CodeBadRequest, "Export ID is required")
+		return
+	}
+
+	userID, ok := middleware.GetUserIDFromGinContext(c)
+	if !ok {
+		Error(c, CodeUnauthorized, "User not authenticated")
+		return
+	}
+
+	ctx := c.Request.Context()
+	export, err := h.exportUseCase.GetExport(ctx, userID.String(), exportID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrDataExportNotFound) {
+			Error(c, CodeNotFound, "Data export not found")
+			return
+		}
+		Error(c, CodeServerError, "Failed to retrieve data export")
+		return
+	}
+
+	Success(c, "Data export retrieved successfully", h.toDataExportResponse(export))
+}
+
+// Download streams an export archive to whoever holds a valid signed link
+func (h *DataExportHandler) Download(c *gin.Context) {
+	expires, err := strconv.ParseInt(c.Query("expires"), 10, 64)
+	if err != nil || c.Query("signature") == "" {
+		Error(c, CodeForbidden, usecases.ErrInvalidDownloadLink.Error())
+		return
+	}
+
+	ctx := c.Request.Context()
+	export, file, err := h.exportUseCase.OpenDownload(ctx, commands.DataExportDownloadLink{
+		ExportID:  c.Param("id"),
+		ExpiresAt: time.Unix(expires, 0),
+		Signature: c.Query("signature"),
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, usecases.ErrInvalidDownloadLink):
+			Error(c, CodeForbidden, err.Error())
+		case errors.Is(err, usecases.ErrDataExportExpired), errors.Is(err, repositories.ErrDataExportNotFound):
+			Error(c, CodeNotFound, "Data export not found or expired")
+		default:
+			Error(c, CodeServerError, "Failed to open data export")
+		}
+		return
+	}
+	defer file.Close()
+
+	filename := fmt.Sprintf("peace-export-%s.zip", export.CreatedAt().Format(timeutil.DateFormat))
+	c.DataFromReader(http.StatusOK, export.SizeBytes(), "application/zip", file, map[string]string{
+		"Content-Disposition": fmt.Sprintf(`attachment; filename="%s"`, filename),
+		"Cache-Control":       "no-store",
+	})
+}
+
+func (h *DataExportHandler) toDataExportResponse(export *entities.DataExport) DataExportResponse {
+	response := DataExportResponse{
+		ID:          export.ID().String(),
+		Status:      export.Status().String(),
+		SizeBytes:   export.SizeBytes(),
+		CreatedAt:   timeutil.FormatTime(export.CreatedAt()),
+		CompletedAt: timeutil.FormatTimePointer(export.CompletedAt()),
+		ExpiresAt:   timeutil.FormatTimePointer(export.ExpiresAt()),
+	}
+
+	// Exports that are not ready yet, or anymore, have no link
+	link, err := h.exportUseCase.DownloadLink(export)
+	if err == nil {
+		query := url.Values{}
+		query.Set("expires", strconv.FormatInt(link.ExpiresAt.Unix(), 10))
+		query.Set("signature", link.Signature)
+		downloadURL := "/api/exports/" + link.ExportID + "/download?" + query.Encode()
+		response.DownloadURL = &downloadURL
+		response.DownloadURLExpiresAt = timeutil.FormatTimePointer(&link.ExpiresAt)
+	}
+
+	return response
+}
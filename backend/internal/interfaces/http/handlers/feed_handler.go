@@ -0,0 +1,93 @@
+package handlers
+
+import (
+	"strconv"
+
+	"github.com/atdevten/peace/internal/application/commands"
+	"github.com/atdevten/peace/internal/application/usecases"
+	"github.com/atdevten/peace/internal/pkg/timeutil"
+
+	"github.com/gin-gonic/gin"
+)
+
+type FeedHandler struct {
+	feedUseCase usecases.FeedUseCase
+}
+
+type FeedAuthorResponse struct {
+	Username  string  `json:"username"`
+	AvatarURL *string `json:"avatar_url,omitempty"`
+}
+
+type FeedEntryResponse struct {
+	ID          string             `json:"id"`
+	HappyLevel  int                `json:"happy_level"`
+	EnergyLevel int                `json:"energy_level"`
+	Notes       *string            `json:"notes,omitempty"`
+	Tags        []string           `json:"tags"`
+	CreatedAt   string             `json:"created_at"`
+	Author      FeedAuthorResponse `json:"author"`
+}
+
+func NewFeedHandler(feedUseCase usecases.FeedUseCase) *FeedHandler {
+	return &FeedHandler{
+		feedUseCase: feedUseCase,
+	}
+}
+
+func (h *FeedHandler) GetPublicFeed(c *gin.Context) {
+	// Get query parameters
+	var cursorPtr *string
+	var limitPtr *int
+
+	if cursor := c.Query("cursor"); cursor != "" {
+		cursorPtr = &cursor
+	}
+	if limitStr := c.Query("limit"); limitStr != "" {
+		limit, err := strconv.Atoi(limitStr)
+		if err != nil {
+			Error(c, CodeBadRequest, "limit must be a number")
+			return
+		}
+		limitPtr = &limit
+	}
+
+	// Create command
+	command, err := commands.NewGetPublicFeedCommand(limitPtr, cursorPtr)
+	if err != nil {
+		Error(c, CodeBadRequest, err.Error())
+		return
+	}
+
+	// Execute use case
+	ctx := c.Request.Context()
+	page, err := h.feedUseCase.GetPublicFeed(ctx, command)
+	if err != nil {
+		Error(c, CodeBadRequest, err.Error())
+		return
+	}
+
+	// Build response
+	responses := make([]FeedEntryResponse, 0, len(page.Entries))
+	for _, entry := range page.Entries {
+		responses = append(responses, FeedEntryResponse{
+			ID:          entry.ID,
+			HappyLevel:  entry.HappyLevel,
+			EnergyLevel: entry.EnergyLevel,
+			Notes:       entry.Notes,
+			Tags:        entry.Tags,
+			CreatedAt:   timeutil.FormatTime(entry.CreatedAt),
+			Author: FeedAuthorResponse{
+				Username:  entry.Username,
+				AvatarURL: entry.AvatarURL,
+			},
+		})
+	}
+
+	meta := PaginationMeta{
+		Limit:      command.Limit,
+		NextCursor: page.NextCursor,
+	}
+
+	SuccessWithMeta(c, "Public feed retrieved successfully", responses, meta)
+}
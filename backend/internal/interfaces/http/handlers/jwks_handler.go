@@ -0,0 +1,30 @@
+package handlers
+
+import (
+	"net/http"
+
+	appjwt "github.com/atdevten/peace/internal/application/services/jwt"
+
+	"github.com/gin-gonic/gin"
+)
+
+type JWKSHandler struct {
+	keys appjwt.KeyPublisher
+}
+
+// JWKSResponse is a JSON Web Key Set (RFC 7517)
+type JWKSResponse struct {
+	Keys []appjwt.JSONWebKey `json:"keys"`
+}
+
+func NewJWKSHandler(keys appjwt.KeyPublisher) *JWKSHandler {
+	return &JWKSHandler{
+		keys: keys,
+	}
+}
+
+// GetJWKS publishes the token verification keys in the standard format, outside the API envelope
+func (h *JWKSHandler) GetJWKS(c *gin.Context) {
+	c.Header("Cache-Control", "public, max-age=300")
+	c.JSON(http.StatusOK, JWKSResponse{Keys: h.keys.PublicKeys()})
+}
@@ -2,6 +2,8 @@ package handlers
 
 import (
 	"net/http"
+	"strconv"
+	"strings"
 
 	"github.com/atdevten/peace/internal/application/commands"
 	"github.com/atdevten/peace/internal/application/usecases"
@@ -16,28 +18,31 @@ type MentalHealthRecordHandler struct {
 }
 
 type CreateMentalHealthRecordRequest struct {
-	HappyLevel  int     `json:"happy_level"`
-	EnergyLevel int     `json:"energy_level"`
-	Notes       *string `json:"notes,omitempty"`
-	Status      string  `json:"status"`
+	HappyLevel  int      `json:"happy_level"`
+	EnergyLevel int      `json:"energy_level"`
+	Notes       *string  `json:"notes,omitempty"`
+	Status      string   `json:"status"`
+	Tags        []string `json:"tags,omitempty"`
 }
 
 type UpdateMentalHealthRecordRequest struct {
-	HappyLevel  *int    `json:"happy_level"`
-	EnergyLevel *int    `json:"energy_level"`
-	Notes       *string `json:"notes,omitempty"`
-	Status      string  `json:"status"`
+	HappyLevel  *int     `json:"happy_level"`
+	EnergyLevel *int     `json:"energy_level"`
+	Notes       *string  `json:"notes,omitempty"`
+	Status      string   `json:"status"`
+	Tags        []string `json:"tags"` // omitted keeps the current tags, [] clears them
 }
 
 type MentalHealthRecordResponse struct {
-	ID          string  `json:"id"`
-	UserID      string  `json:"user_id"`
-	HappyLevel  int     `json:"happy_level"`
-	EnergyLevel int     `json:"energy_level"`
-	Notes       *string `json:"notes,omitempty"`
-	Status      string  `json:"status"`
-	CreatedAt   string  `json:"created_at"`
-	UpdatedAt   string  `json:"updated_at"`
+	ID          string   `json:"id"`
+	UserID      string   `json:"user_id"`
+	HappyLevel  int      `json:"happy_level"`
+	EnergyLevel int      `json:"energy_level"`
+	Notes       *string  `json:"notes,omitempty"`
+	Status      string   `json:"status"`
+	Tags        []string `json:"tags"`
+	CreatedAt   string   `json:"created_at"`
+	UpdatedAt   string   `json:"updated_at"`
 }
 
 type HeatmapDataPoint struct {
@@ -55,6 +60,7 @@ type MentalHealthHeatmapResponse struct {
 	Data         map[string]HeatmapDataPoint `json:"data"`
 	TotalRecords int                         `json:"total_records"`
 	DateRange    DateRange                   `json:"date_range"`
+	Timezone     string                      `json:"timezone"`
 }
 
 func NewMentalHealthRecordHandler(recordUseCase usecases.MentalHealthRecordUseCase) *MentalHealthRecordHandler {
@@ -84,6 +90,7 @@ func (h *MentalHealthRecordHandler) Create(c *gin.Context) {
 		req.EnergyLevel,
 		req.Notes,
 		req.Status,
+		req.Tags,
 	)
 	if err != nil {
 		Error(c, CodeBadRequest, err.Error())
@@ -106,6 +113,7 @@ func (h *MentalHealthRecordHandler) Create(c *gin.Context) {
 		EnergyLevel: record.EnergyLevel().Value(),
 		Notes:       record.Notes(),
 		Status:      record.Status().String(),
+		Tags:        recordTagNames(record.Tags()),
 		CreatedAt:   timeutil.FormatTime(record.CreatedAt()),
 		UpdatedAt:   timeutil.FormatTime(record.UpdatedAt()),
 	}
@@ -152,6 +160,7 @@ func (h *MentalHealthRecordHandler) Update(c *gin.Context) {
 		*req.EnergyLevel,
 		req.Notes,
 		req.Status,
+		req.Tags,
 	)
 	if err != nil {
 		Error(c, CodeBadRequest, err.Error())
@@ -182,6 +191,7 @@ func (h *MentalHealthRecordHandler) Update(c *gin.Context) {
 		EnergyLevel: record.EnergyLevel().Value(),
 		Notes:       record.Notes(),
 		Status:      record.Status().String(),
+		Tags:        recordTagNames(record.Tags()),
 		CreatedAt:   timeutil.FormatTime(record.CreatedAt()),
 		UpdatedAt:   timeutil.FormatTime(record.UpdatedAt()),
 	}
@@ -269,6 +279,7 @@ func (h *MentalHealthRecordHandler) GetByID(c *gin.Context) {
 		EnergyLevel: record.EnergyLevel().Value(),
 		Notes:       record.Notes(),
 		Status:      record.Status().String(),
+		Tags:        recordTagNames(record.Tags()),
 		CreatedAt:   timeutil.FormatTime(record.CreatedAt()),
 		UpdatedAt:   timeutil.FormatTime(record.UpdatedAt()),
 	}
@@ -287,9 +298,13 @@ func (h *MentalHealthRecordHandler) GetByCondition(c *gin.Context) {
 	// Get query parameters
 	startedAt := c.Query("started_at")
 	endedAt := c.Query("ended_at")
+	cursor := c.Query("cursor")
+	sort := c.Query("sort")
 
 	var startedAtPtr *string
 	var endedAtPtr *string
+	var cursorPtr *string
+	var limitPtr *int
 
 	if startedAt != "" {
 		startedAtPtr = &startedAt
@@ -297,24 +312,67 @@ func (h *MentalHealthRecordHandler) GetByCondition(c *gin.Context) {
 	if endedAt != "" {
 		endedAtPtr = &endedAt
 	}
+	if cursor != "" {
+		cursorPtr = &cursor
+	}
+
+	// Parse numeric query parameters
+	var filters commands.MentalHealthRecordListFilters
+	numericParams := []struct {
+		name   string
+		target **int
+	}{
+		{"limit", &limitPtr},
+		{"min_happy", &filters.MinHappyLevel},
+		{"max_happy", &filters.MaxHappyLevel},
+		{"min_energy", &filters.MinEnergyLevel},
+		{"max_energy", &filters.MaxEnergyLevel},
+	}
+	for _, param := range numericParams {
+		raw := c.Query(param.name)
+		if raw == "" {
+			continue
+		}
+		value, err := strconv.Atoi(raw)
+		if err != nil {
+			Error(c, CodeBadRequest, param.name+" must be a number")
+			return
+		}
+		*param.target = &value
+	}
+
+	if status := c.Query("status"); status != "" {
+		filters.Status = &status
+	}
+	if search := c.Query("q"); search != "" {
+		filters.Search = &search
+	}
+	if tags := c.Query("tags"); tags != "" {
+		for _, tag := range strings.Split(tags, ",") {
+			if tag = strings.TrimSpace(tag); tag != "" {
+				filters.TagNames = append(filters.TagNames, tag)
+			}
+		}
+	}
+
+	// Create command
+	command, err := commands.NewGetMentalHealthRecordsCommand(userID.String(), startedAtPtr, endedAtPtr, limitPtr, cursorPtr, sort, filters)
+	if err != nil {
+		Error(c, CodeBadRequest, err.Error())
+		return
+	}
 
-	// Execute use case with pagination and order via context keys
-	// For now, pass through via query params handled in usecase next iteration; keep signature
+	// Execute use case
 	ctx := c.Request.Context()
-	records, err := h.recordUseCase.GetByCondition(ctx, userID.String(), startedAtPtr, endedAtPtr)
+	page, err := h.recordUseCase.GetByCondition(ctx, command)
 	if err != nil {
-		if err.Error() == "invalid start date format, expected YYYY-MM-DD" ||
-			err.Error() == "invalid end date format, expected YYYY-MM-DD" {
-			Error(c, CodeBadRequest, err.Error())
-			return
-		}
 		Error(c, CodeBadRequest, err.Error())
 		return
 	}
 
 	// Build response
-	var responses []MentalHealthRecordResponse
-	for _, record := range records {
+	responses := make([]MentalHealthRecordResponse, 0, len(page.Records))
+	for _, record := range page.Records {
 		response := MentalHealthRecordResponse{
 			ID:          record.ID().String(),
 			UserID:      record.UserID().String(),
@@ -322,13 +380,19 @@ func (h *MentalHealthRecordHandler) GetByCondition(c *gin.Context) {
 			EnergyLevel: record.EnergyLevel().Value(),
 			Notes:       record.Notes(),
 			Status:      record.Status().String(),
+			Tags:        recordTagNames(record.Tags()),
 			CreatedAt:   timeutil.FormatTime(record.CreatedAt()),
 			UpdatedAt:   timeutil.FormatTime(record.UpdatedAt()),
 		}
 		responses = append(responses, response)
 	}
 
-	Success(c, "Mental health records retrieved successfully", responses)
+	meta := PaginationMeta{
+		Limit:      command.Limit,
+		NextCursor: page.NextCursor,
+	}
+
+	SuccessWithMeta(c, "Mental health records retrieved successfully", responses, meta)
 }
 
 func (h *MentalHealthRecordHandler) GetHeatmap(c *gin.Context) {
@@ -342,9 +406,11 @@ func (h *MentalHealthRecordHandler) GetHeatmap(c *gin.Context) {
 	// Get query parameters
 	startedAt := c.Query("started_at")
 	endedAt := c.Query("ended_at")
+	timezone := c.Query("tz")
 
 	var startedAtPtr *string
 	var endedAtPtr *string
+	var timezonePtr *string
 
 	if startedAt != "" {
 		startedAtPtr = &startedAt
@@ -352,9 +418,12 @@ func (h *MentalHealthRecordHandler) GetHeatmap(c *gin.Context) {
 	if endedAt != "" {
 		endedAtPtr = &endedAt
 	}
+	if timezone != "" {
+		timezonePtr = &timezone
+	}
 
 	// Create command
-	command := commands.NewGetMentalHealthHeatmapCommand(userID.String(), startedAtPtr, endedAtPtr)
+	command := commands.NewGetMentalHealthHeatmapCommand(userID.String(), startedAtPtr, endedAtPtr, timezonePtr)
 
 	// Execute use case
 	ctx := c.Request.Context()
@@ -386,6 +455,7 @@ func (h *MentalHealthRecordHandler) GetHeatmap(c *gin.Context) {
 			StartedAt: heatmapResult.DateRange.StartedAt,
 			EndedAt:   heatmapResult.DateRange.EndedAt,
 		},
+		Timezone: heatmapResult.Timezone,
 	}
 
 	Success(c, "Mental health heatmap retrieved successfully", response)
@@ -394,6 +464,7 @@ func (h *MentalHealthRecordHandler) GetHeatmap(c *gin.Context) {
 type MentalHealthStreakResponse struct {
 	Streak        int     `json:"streak"`
 	LastEntryDate *string `json:"last_entry_date,omitempty"`
+	Timezone      string  `json:"timezone"`
 }
 
 func (h *MentalHealthRecordHandler) GetStreak(c *gin.Context) {
@@ -404,8 +475,14 @@ func (h *MentalHealthRecordHandler) GetStreak(c *gin.Context) {
 		return
 	}
 
+	// Optional timezone override
+	var timezonePtr *string
+	if timezone := c.Query("tz"); timezone != "" {
+		timezonePtr = &timezone
+	}
+
 	// Create command
-	command := commands.NewGetMentalHealthStreakCommand(userID.String())
+	command := commands.NewGetMentalHealthStreakCommand(userID.String(), timezonePtr)
 
 	// Execute use case
 	ctx := c.Request.Context()
@@ -419,7 +496,225 @@ func (h *MentalHealthRecordHandler) GetStreak(c *gin.Context) {
 	response := MentalHealthStreakResponse{
 		Streak:        streakResult.Streak,
 		LastEntryDate: streakResult.LastEntryDate,
+		Timezone:      streakResult.Timezone,
 	}
 
 	Success(c, "Mental health streak retrieved successfully", response)
 }
+
+type LevelStatsResponse struct {
+	Average    float64 `json:"average"`
+	Min        int     `json:"min"`
+	Max        int     `json:"max"`
+	Stddev     float64 `json:"stddev"`
+	TrendSlope float64 `json:"trend_slope"`
+}
+
+type AnalyticsDailyPointResponse struct {
+	Date            string  `json:"date"`
+	Count           int     `json:"count"`
+	HappyAverage    float64 `json:"happy_average"`
+	EnergyAverage   float64 `json:"energy_average"`
+	HappyRolling7   float64 `json:"happy_rolling_7d"`
+	EnergyRolling7  float64 `json:"energy_rolling_7d"`
+	HappyRolling30  float64 `json:"happy_rolling_30d"`
+	EnergyRolling30 float64 `json:"energy_rolling_30d"`
+}
+
+type AnalyticsWeekdayResponse struct {
+	Weekday       int     `json:"weekday"` // 0 = Sunday
+	Count         int     `json:"count"`
+	HappyAverage  float64 `json:"happy_average"`
+	EnergyAverage float64 `json:"energy_average"`
+}
+
+type AnalyticsHourResponse struct {
+	Hour          int     `json:"hour"`
+	Count         int     `json:"count"`
+	HappyAverage  float64 `json:"happy_average"`
+	EnergyAverage float64 `json:"energy_average"`
+}
+
+type MentalHealthAnalyticsResponse struct {
+	TotalRecords int                           `json:"total_records"`
+	Happy        LevelStatsResponse            `json:"happy"`
+	Energy       LevelStatsResponse            `json:"energy"`
+	Daily        []AnalyticsDailyPointResponse `json:"daily"`
+	Weekdays     []AnalyticsWeekdayResponse    `json:"weekdays"`
+	Hours        []AnalyticsHourResponse       `json:"hours"`
+	DateRange    DateRange                     `json:"date_range"`
+	Timezone     string                        `json:"timezone"`
+}
+
+func (h *MentalHealthRecordHandler) GetAnalytics(c *gin.Context) {
+	// Get user ID from context
+	userID, exists := middleware.GetUserIDFromGinContext(c)
+	if !exists {
+		Error(c, CodeUnauthorized, "User not authenticated")
+		return
+	}
+
+	// Get query parameters
+	startedAt := c.Query("started_at")
+	endedAt := c.Query("ended_at")
+	timezone := c.Query("tz")
+
+	var startedAtPtr *string
+	var endedAtPtr *string
+	var timezonePtr *string
+
+	if startedAt != "" {
+		startedAtPtr = &startedAt
+	}
+	if endedAt != "" {
+		endedAtPtr = &endedAt
+	}
+	if timezone != "" {
+		timezonePtr = &timezone
+	}
+
+	// Create command
+	command := commands.NewGetMentalHealthAnalyticsCommand(userID.String(), startedAtPtr, endedAtPtr, timezonePtr)
+
+	// Execute use case
+	ctx := c.Request.Context()
+	analyticsResult, err := h.recordUseCase.GetAnalytics(ctx, command)
+	if err != nil {
+		Error(c, CodeBadRequest, err.Error())
+		return
+	}
+
+	// Build response
+	response := MentalHealthAnalyticsResponse{
+		TotalRecords: analyticsResult.TotalRecords,
+		Happy: LevelStatsResponse{
+			Average:    analyticsResult.Happy.Average,
+			Min:        analyticsResult.Happy.Min,
+			Max:        analyticsResult.Happy.Max,
+			Stddev:     analyticsResult.Happy.Stddev,
+			TrendSlope: analyticsResult.Happy.TrendSlope,
+		},
+		Energy: LevelStatsResponse{
+			Average:    analyticsResult.Energy.Average,
+			Min:        analyticsResult.Energy.Min,
+			Max:        analyticsResult.Energy.Max,
+			Stddev:     analyticsResult.Energy.Stddev,
+			TrendSlope: analyticsResult.Energy.TrendSlope,
+		},
+		Daily:    make([]AnalyticsDailyPointResponse, 0, len(analyticsResult.Daily)),
+		Weekdays: make([]AnalyticsWeekdayResponse, 0, len(analyticsResult.Weekdays)),
+		Hours:    make([]AnalyticsHourResponse, 0, len(analyticsResult.Hours)),
+		DateRange: DateRange{
+			StartedAt: analyticsResult.DateRange.StartedAt,
+			EndedAt:   analyticsResult.DateRange.EndedAt,
+		},
+		Timezone: analyticsResult.Timezone,
+	}
+
+	for _, day := range analyticsResult.Daily {
+		response.Daily = append(response.Daily, AnalyticsDailyPointResponse{
+			Date:            day.Date,
+			Count:           day.Count,
+			HappyAverage:    day.HappyAverage,
+			EnergyAverage:   day.EnergyAverage,
+			HappyRolling7:   day.HappyRolling7,
+			EnergyRolling7:  day.EnergyRolling7,
+			HappyRolling30:  day.HappyRolling30,
+			EnergyRolling30: day.EnergyRolling30,
+		})
+	}
+
+	for _, weekday := range analyticsResult.Weekdays {
+		response.Weekdays = append(response.Weekdays, AnalyticsWeekdayResponse{
+			Weekday:       weekday.Bucket,
+			Count:         weekday.Count,
+			HappyAverage:  weekday.HappyAverage,
+			EnergyAverage: weekday.EnergyAverage,
+		})
+	}
+
+	for _, hour := range analyticsResult.Hours {
+		response.Hours = append(response.Hours, AnalyticsHourResponse{
+			Hour:          hour.Bucket,
+			Count:         hour.Count,
+			HappyAverage:  hour.HappyAverage,
+			EnergyAverage: hour.EnergyAverage,
+		})
+	}
+
+	Success(c, "Mental health analytics retrieved successfully", response)
+}
+
+type TagMoodResponse struct {
+	Tag           string  `json:"tag"`
+	Count         int     `json:"count"`
+	HappyAverage  float64 `json:"happy_average"`
+	EnergyAverage float64 `json:"energy_average"`
+}
+
+type MentalHealthTagReportResponse struct {
+	Tags      []TagMoodResponse `json:"tags"`
+	DateRange DateRange         `json:"date_range"`
+}
+
+func (h *MentalHealthRecordHandler) GetTagReport(c *gin.Context) {
+	// Get user ID from context
+	userID, exists := middleware.GetUserIDFromGinContext(c)
+	if !exists {
+		Error(c, CodeUnauthorized, "User not authenticated")
+		return
+	}
+
+	// Get query parameters
+	startedAt := c.Query("started_at")
+	endedAt := c.Query("ended_at")
+
+	var startedAtPtr *string
+	var endedAtPtr *string
+
+	if startedAt != "" {
+		startedAtPtr = &startedAt
+	}
+	if endedAt != "" {
+		endedAtPtr = &endedAt
+	}
+
+	// Create command
+	command := commands.NewGetMentalHealthTagReportCommand(userID.String(), startedAtPtr, endedAtPtr)
+
+	// Execute use case
+	ctx := c.Request.Context()
+	reportResult, err := h.recordUseCase.GetTagReport(ctx, command)
+	if err != nil {
+		Error(c, CodeBadRequest, err.Error())
+		return
+	}
+
+	// Build response
+	response := MentalHealthTagReportResponse{
+		Tags: make([]TagMoodResponse, 0, len(reportResult.Tags)),
+		DateRange: DateRange{
+			StartedAt: reportResult.DateRange.StartedAt,
+			EndedAt:   reportResult.DateRange.EndedAt,
+		},
+	}
+
+	for _, tag := range reportResult.Tags {
+		response.Tags = append(response.Tags, TagMoodResponse{
+			Tag:           tag.TagName,
+			Count:         tag.Count,
+			HappyAverage:  tag.HappyAverage,
+			EnergyAverage: tag.EnergyAverage,
+		})
+	}
+
+	Success(c, "Mental health tag report retrieved successfully", response)
+}
+
+// recordTagNames flattens record tags to their names for responses
+func recordTagNames(tags []string) []string {
+	if tags == nil {
+		return []string{}
+	}
+	return tags
+}
@@ -0,0 +1,162 @@
+package handlers
+
+import (
+	"errors"
+
+	"github.com/atdevten/peace/internal/application/usecases"
+	"github.com/atdevten/peace/internal/interfaces/http/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+type MFAHandler struct {
+	mfaUseCase usecases.MFAUseCase
+}
+
+// MFACodeRequest carries a TOTP code, or a recovery code where accepted
+type MFACodeRequest struct {
+	Code string `json:"code"`
+}
+
+type TOTPEnrollmentResponse struct {
+	Secret     string `json:"secret"`
+	OTPAuthURI string `json:"otpauth_uri"`
+}
+
+type MFAStatusResponse struct {
+	Enabled                bool `json:"enabled"`
+	RemainingRecoveryCodes int  `json:"remaining_recovery_codes"`
+}
+
+type RecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+func NewMFAHandler(mfaUseCase usecases.MFAUseCase) *MFAHandler {
+	return &MFAHandler{
+		mfaUseCase: mfaUseCase,
+	}
+}
+
+// Status reports whether two-factor login is enabled for the authenticated user
+func (h *MFAHandler) Status(c *gin.Context) {
+	userID, ok := middleware.GetUserIDFromGinContext(c)
+	if !ok {
+		Error(c, CodeUnauthorized, "User not authenticated")
+		return
+	}
+
+	ctx := c.Request.Context()
+	status, err := h.mfaUseCase.Status(ctx, userID.String())
+	if err != nil {
+		Error(c, CodeServerError, err.Error())
+		return
+	}
+
+	Success(c, "Two-factor status retrieved successfully", MFAStatusResponse{
+		Enabled:                status.Enabled,
+		RemainingRecoveryCodes: status.RemainingRecoveryCodes,
+	})
+}
+
+// EnrollTOTP starts an authenticator app enrollment for the authenticated user
+func (h *MFAHandler) EnrollTOTP(c *gin.Context) {
+	userID, ok := middleware.GetUserIDFromGinContext(c)
+	if !ok {
+		Error(c, CodeUnauthorized, "User not authenticated")
+		return
+	}
+
+	ctx := c.Request.Context()
+	enrollment, err := h.mfaUseCase.EnrollTOTP(ctx, userID.String())
+	if err != nil {
+		if errors.Is(err, usecases.ErrMFAAlreadyEnabled) {
+			Error(c, CodeConflict, err.Error())
+			return
+		}
+		Error(c, CodeBadRequest, err.Error())
+		return
+	}
+
+	Success(c, "Two-factor enrollment started", TOTPEnrollmentResponse{
+		Secret:     enrollment.Secret,
+		OTPAuthURI: enrollment.URI,
+	})
+}
+
+// ConfirmTOTP activates the pending enrollment with a first code and returns recovery codes
+func (h *MFAHandler) ConfirmTOTP(c *gin.Context) {
+	userID, req, ok := h.bindCode(c)
+	if !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+	codes, err := h.mfaUseCase.ConfirmTOTP(ctx, userID, req.Code)
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	Success(c, "Two-factor authentication enabled", RecoveryCodesResponse{RecoveryCodes: codes})
+}
+
+// DisableTOTP turns two-factor login off after checking a TOTP or recovery code
+func (h *MFAHandler) DisableTOTP(c *gin.Context) {
+	userID, req, ok := h.bindCode(c)
+	if !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+	if err := h.mfaUseCase.DisableTOTP(ctx, userID, req.Code); err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	Success(c, "Two-factor authentication disabled", nil)
+}
+
+// RegenerateRecoveryCodes replaces the recovery codes after checking a TOTP or recovery code
+func (h *MFAHandler) RegenerateRecoveryCodes(c *gin.Context) {
+	userID, req, ok := h.bindCode(c)
+	if !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+	codes, err := h.mfaUseCase.RegenerateRecoveryCodes(ctx, userID, req.Code)
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	Success(c, "Recovery codes regenerated", RecoveryCodesResponse{RecoveryCodes: codes})
+}
+
+func (h *MFAHandler) bindCode(c *gin.Context) (string, MFACodeRequest, bool) {
+	userID, ok := middleware.GetUserIDFromGinContext(c)
+	if !ok {
+		Error(c, CodeUnauthorized, "User not authenticated")
+		return "", MFACodeRequest{}, false
+	}
+
+	var req MFACodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil || req.Code == "" {
+		Error(c, CodeBadRequest, "code is required")
+		return "", MFACodeRequest{}, false
+	}
+
+	return userID.String(), req, true
+}
+
+func (h *MFAHandler) writeError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, usecases.ErrInvalidMFACode):
+		Error(c, CodeUnauthorized, err.Error())
+	case errors.Is(err, usecases.ErrMFAAlreadyEnabled):
+		Error(c, CodeConflict, err.Error())
+	default:
+		Error(c, CodeBadRequest, err.Error())
+	}
+}
@@ -0,0 +1,154 @@
+package handlers
+
+import (
+	"errors"
+	"time"
+
+	"github.com/atdevten/peace/internal/application/commands"
+	"github.com/atdevten/peace/internal/application/usecases"
+	"github.com/atdevten/peace/internal/domain/entities"
+	"github.com/atdevten/peace/internal/domain/repositories"
+	"github.com/atdevten/peace/internal/interfaces/http/middleware"
+	"github.com/atdevten/peace/internal/pkg/timeutil"
+
+	"github.com/gin-gonic/gin"
+)
+
+type PersonalAccessTokenHandler struct {
+	tokenUseCase usecases.PersonalAccessTokenUseCase
+}
+
+type CreatePersonalAccessTokenRequest struct {
+	Name          string   `json:"name"`
+	Scopes        []string `json:"scopes"`
+	ExpiresInDays *int     `json:"expires_in_days"` // defaults to 90, at most 365
+}
+
+type PersonalAccessTokenResponse struct {
+	ID         string   `json:"id"`
+	Name       string   `json:"name"`
+	Scopes     []string `json:"scopes"`
+	ExpiresAt  string   `json:"expires_at"`
+	LastUsedAt *string  `json:"last_used_at"`
+	CreatedAt  string   `json:"created_at"`
+	Expired    bool     `json:"expired"`
+}
+
+// CreatedPersonalAccessTokenResponse carries the secret, which is never shown again
+type CreatedPersonalAccessTokenResponse struct {
+	PersonalAccessTokenResponse
+	Token string `json:"token"`
+}
+
+func NewPersonalAccessTokenHandler(tokenUseCase usecases.PersonalAccessTokenUseCase) *PersonalAccessTokenHandler {
+	return &PersonalAccessTokenHandler{
+		tokenUseCase: tokenUseCase,
+	}
+}
+
+// ListTokens returns the personal access tokens of the authenticated user
+func (h *PersonalAccessTokenHandler) ListTokens(c *gin.Context) {
+	userID, ok := middleware.GetUserIDFromGinContext(c)
+	if !ok {
+		Error(c, CodeUnauthorized, "User not authenticated")
+		return
+	}
+
+	ctx := c.Request.Context()
+	tokens, err := h.tokenUseCase.ListTokens(ctx, userID.String())
+	if err != nil {
+		Error(c, CodeServerError, "Failed to retrieve tokens")
+		return
+	}
+
+	response := make([]PersonalAccessTokenResponse, 0, len(tokens))
+	for _, token := range tokens {
+		response = append(response, toPersonalAccessTokenResponse(token))
+	}
+
+	Success(c, "Tokens retrieved successfully", response)
+}
+
+// CreateToken issues a personal access token; its secret is only part of this response
+func (h *PersonalAccessTokenHandler) CreateToken(c *gin.Context) {
+	var req CreatePersonalAccessTokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		Error(c, CodeBadRequest, "Invalid request body")
+		return
+	}
+
+	userID, ok := middleware.GetUserIDFromGinContext(c)
+	if !ok {
+		Error(c, CodeUnauthorized, "User not authenticated")
+		return
+	}
+
+	command, err := commands.NewCreatePersonalAccessTokenCommand(userID.String(), req.Name, req.Scopes, req.ExpiresInDays)
+	if err != nil {
+		Error(c, CodeBadRequest, err.Error())
+		return
+	}
+
+	ctx := c.Request.Context()
+	token, secret, err := h.tokenUseCase.CreateToken(ctx, command)
+	if err != nil {
+		switch {
+		case errors.Is(err, usecases.ErrScopeNotAllowed):
+			Error(c, CodeForbidden, err.Error())
+		case errors.Is(err, usecases.ErrPersonalAccessTokenLimit):
+			Error(c, CodeConflict, err.Error())
+		default:
+			Error(c, CodeBadRequest, err.Error())
+		}
+		return
+	}
+
+	Success(c, "Token created successfully, copy it now as it will not be shown again", CreatedPersonalAccessTokenResponse{
+		PersonalAccessTokenResponse: toPersonalAccessTokenResponse(token),
+		Token:                       secret,
+	})
+}
+
+// DeleteToken revokes one personal access token of the authenticated user
+func (h *PersonalAccessTokenHandler) DeleteToken(c *gin.Context) {
+	tokenID := c.Param("id")
+	if tokenID == "" {
+		Error(c, CodeBadRequest, "Token ID is required")
+		return
+	}
+
+	userID, ok := middleware.GetUserIDFromGinContext(c)
+	if !ok {
+		Error(c, CodeUnauthorized, "User not authenticated")
+		return
+	}
+
+	ctx := c.Request.Context()
+	if err := h.tokenUseCase.DeleteToken(ctx, userID.String(), tokenID); err != nil {
+		if errors.Is(err, repositories.ErrPersonalAccessTokenNotFound) {
+			Error(c, CodeNotFound, "Token not found")
+			return
+		}
+		Error(c, CodeBadRequest, err.Error())
+		return
+	}
+
+	Success(c, "Token deleted successfully", nil)
+}
+
+func toPersonalAccessTokenResponse(token *entities.PersonalAccessToken) PersonalAccessTokenResponse {
+	scopes := make([]string, 0, len(token.Scopes()))
+	for _, scope := range token.Scopes() {
+		scopes = append(scopes, scope.String())
+	}
+
+	return PersonalAccessTokenResponse{
+		ID:         token.ID().String(),
+		Name:       token.Name(),
+		Scopes:     scopes,
+		ExpiresAt:  timeutil.FormatTime(token.ExpiresAt()),
+		LastUsedAt: timeutil.FormatTimePointer(token.LastUsedAt()),
+		CreatedAt:  timeutil.FormatTime(token.CreatedAt()),
+		Expired:    token.IsExpired(time.Now()),
+	}
+}
@@ -2,15 +2,20 @@ package handlers
 
 import (
 	"net/http"
+	"strconv"
 
+	"github.com/atdevten/peace/internal/application/commands"
 	"github.com/atdevten/peace/internal/application/usecases"
 	"github.com/atdevten/peace/internal/domain/entities"
+	"github.com/atdevten/peace/internal/interfaces/http/middleware"
 	"github.com/atdevten/peace/internal/pkg/timeutil"
 	"github.com/gin-gonic/gin"
 )
 
 type QuoteHandler struct {
-	quoteUseCase usecases.QuoteUseCase
+	quoteUseCase          usecases.QuoteUseCase
+	dailyQuoteUseCase     usecases.DailyQuoteUseCase
+	recommendationUseCase usecases.RecommendationUseCase
 }
 
 // Request/Response structs
@@ -24,6 +29,10 @@ type UpdateQuoteRequest struct {
 	Author  string `json:"author"`
 }
 
+type QuoteFeedbackRequest struct {
+	Signal string `json:"signal"` // like or skip
+}
+
 type QuoteResponse struct {
 	ID        string `json:"id"`
 	Content   string `json:"content"`
@@ -32,9 +41,64 @@ type QuoteResponse struct {
 	UpdatedAt string `json:"updated_at"`
 }
 
-func NewQuoteHandler(quoteUseCase usecases.QuoteUseCase) *QuoteHandler {
+// QuoteListMeta extends the pagination of quote listings with the total count and the order
+type QuoteListMeta struct {
+	PaginationMeta
+	Offset int    `json:"offset"`
+	Total  int64  `json:"total"`
+	Sort   string `json:"sort"`
+	Seed   *int64 `json:"seed,omitempty"` // pass back with sort=random to keep the same order
+}
+
+type QuoteSearchHitResponse struct {
+	QuoteResponse
+	Rank    float64 `json:"rank"`
+	Snippet string  `json:"snippet"` // HTML-escaped, matched terms wrapped in <mark>
+}
+
+type QuoteFacetResponse struct {
+	Value string `json:"value"`
+	Count int64  `json:"count"`
+}
+
+// QuoteSearchMeta pages a search and summarizes all its matches by author and tag
+type QuoteSearchMeta struct {
+	Limit  int   `json:"limit"`
+	Offset int   `json:"offset"`
+	Total  int64 `json:"total"`
+	Facets struct {
+		Authors []QuoteFacetResponse `json:"authors"`
+		Tags    []QuoteFacetResponse `json:"tags"`
+	} `json:"facets"`
+}
+
+// DailyQuoteResponse is a quote of the day with the local day it belongs to
+type DailyQuoteResponse struct {
+	QuoteResponse
+	Day      string `json:"day"`
+	Timezone string `json:"timezone"`
+}
+
+// RecommendedQuotesResponse lists the quotes picked for the user and what they were picked by
+type RecommendedQuotesResponse struct {
+	Quotes []QuoteResponse             `json:"quotes"`
+	Basis  string                      `json:"basis"` // mood, likes or random
+	Tags   []string                    `json:"tags"`
+	Mood   *RecommendationMoodResponse `json:"mood,omitempty"` // latest record, absent without records
+}
+
+type RecommendationMoodResponse struct {
+	RecordID    string `json:"record_id"`
+	HappyLevel  int    `json:"happy_level"`
+	EnergyLevel int    `json:"energy_level"`
+	RecordedAt  string `json:"recorded_at"`
+}
+
+func NewQuoteHandler(quoteUseCase usecases.QuoteUseCase, dailyQuoteUseCase usecases.DailyQuoteUseCase, recommendationUseCase usecases.RecommendationUseCase) *QuoteHandler {
 	return &QuoteHandler{
-		quoteUseCase: quoteUseCase,
+		quoteUseCase:          quoteUseCase,
+		dailyQuoteUseCase:     dailyQuoteUseCase,
+		recommendationUseCase: recommendationUseCase,
 	}
 }
 
@@ -68,19 +132,165 @@ func (h *QuoteHandler) CreateQuote(c *gin.Context) {
 	})
 }
 
-func (h *QuoteHandler) GetAllQuotes(c *gin.Context) {
-	quotes, err := h.quoteUseCase.GetAllQuotes(c.Request.Context())
+// ListQuotes returns a page of quotes filtered by author and content, ordered by sort
+// (created_at, author or random with seed) and order, paged by limit with either offset or cursor
+func (h *QuoteHandler) ListQuotes(c *gin.Context) {
+	// Get query parameters
+	var cursorPtr *string
+	var limitPtr, offsetPtr *int
+	var seedPtr *int64
+
+	if cursor := c.Query("cursor"); cursor != "" {
+		cursorPtr = &cursor
+	}
+	if limitStr := c.Query("limit"); limitStr != "" {
+		limit, err := strconv.Atoi(limitStr)
+		if err != nil {
+			Error(c, CodeBadRequest, "limit must be a number")
+			return
+		}
+		limitPtr = &limit
+	}
+	if offsetStr := c.Query("offset"); offsetStr != "" {
+		offset, err := strconv.Atoi(offsetStr)
+		if err != nil {
+			Error(c, CodeBadRequest, "offset must be a number")
+			return
+		}
+		offsetPtr = &offset
+	}
+	if seedStr := c.Query("seed"); seedStr != "" {
+		seed, err := strconv.ParseInt(seedStr, 10, 64)
+		if err != nil {
+			Error(c, CodeBadRequest, "seed must be a number")
+			return
+		}
+		seedPtr = &seed
+	}
+
+	query := func(key string) *string {
+		if value, ok := c.GetQuery(key); ok {
+			return &value
+		}
+		return nil
+	}
+	options := commands.QuoteListOptions{
+		Author:  query("author"),
+		Content: query("content"),
+		Sort:    query("sort"),
+		Order:   query("order"),
+		Seed:    seedPtr,
+	}
+
+	// Create command
+	command, err := commands.NewListQuotesCommand(options, limitPtr, offsetPtr, cursorPtr)
+	if err != nil {
+		Error(c, CodeBadRequest, err.Error())
+		return
+	}
+
+	// Execute use case
+	ctx := c.Request.Context()
+	page, err := h.quoteUseCase.ListQuotes(ctx, command)
 	if err != nil {
-		Error(c, CodeServerError, "Failed to get quotes: "+err.Error())
+		Error(c, CodeBadRequest, err.Error())
 		return
 	}
 
-	var quotesData []QuoteResponse
-	for _, quote := range quotes {
+	// Build response
+	quotesData := make([]QuoteResponse, 0, len(page.Quotes))
+	for _, quote := range page.Quotes {
 		quotesData = append(quotesData, h.buildQuoteResponse(quote))
 	}
 
-	Success(c, "Quotes retrieved successfully", quotesData)
+	sort := "created_at"
+	if command.Options.Sort != nil {
+		sort = *command.Options.Sort
+	}
+	meta := QuoteListMeta{
+		PaginationMeta: PaginationMeta{
+			Limit:      command.Limit,
+			NextCursor: page.NextCursor,
+		},
+		Offset: command.Offset,
+		Total:  page.Total,
+		Sort:   sort,
+		Seed:   page.Seed,
+	}
+
+	SuccessWithMeta(c, "Quotes retrieved successfully", quotesData, meta)
+}
+
+// SearchQuotes ranks quotes matching the full-text query q, narrowed by the author and tag
+// facets and paged by limit and offset
+func (h *QuoteHandler) SearchQuotes(c *gin.Context) {
+	// Get query parameters
+	var limitPtr, offsetPtr *int
+
+	if limitStr := c.Query("limit"); limitStr != "" {
+		limit, err := strconv.Atoi(limitStr)
+		if err != nil {
+			Error(c, CodeBadRequest, "limit must be a number")
+			return
+		}
+		limitPtr = &limit
+	}
+	if offsetStr := c.Query("offset"); offsetStr != "" {
+		offset, err := strconv.Atoi(offsetStr)
+		if err != nil {
+			Error(c, CodeBadRequest, "offset must be a number")
+			return
+		}
+		offsetPtr = &offset
+	}
+
+	query := func(key string) *string {
+		if value, ok := c.GetQuery(key); ok {
+			return &value
+		}
+		return nil
+	}
+
+	// Create command
+	command, err := commands.NewSearchQuotesCommand(c.Query("q"), query("author"), query("tag"), limitPtr, offsetPtr)
+	if err != nil {
+		Error(c, CodeBadRequest, err.Error())
+		return
+	}
+
+	// Execute use case
+	ctx := c.Request.Context()
+	page, err := h.quoteUseCase.SearchQuotes(ctx, command)
+	if err != nil {
+		Error(c, CodeServerError, "Failed to search quotes: "+err.Error())
+		return
+	}
+
+	// Build response
+	hits := make([]QuoteSearchHitResponse, 0, len(page.Hits))
+	for _, hit := range page.Hits {
+		hits = append(hits, QuoteSearchHitResponse{
+			QuoteResponse: h.buildQuoteResponse(hit.Quote),
+			Rank:          hit.Rank,
+			Snippet:       hit.Snippet,
+		})
+	}
+
+	meta := QuoteSearchMeta{
+		Limit:  command.Limit,
+		Offset: command.Offset,
+		Total:  page.Total,
+	}
+	meta.Facets.Authors = make([]QuoteFacetResponse, 0, len(page.Authors))
+	for _, facet := range page.Authors {
+		meta.Facets.Authors = append(meta.Facets.Authors, QuoteFacetResponse{Value: facet.Value, Count: facet.Count})
+	}
+	meta.Facets.Tags = make([]QuoteFacetResponse, 0, len(page.Tags))
+	for _, facet := range page.Tags {
+		meta.Facets.Tags = append(meta.Facets.Tags, QuoteFacetResponse{Value: facet.Value, Count: facet.Count})
+	}
+
+	SuccessWithMeta(c, "Quotes found successfully", hits, meta)
 }
 
 func (h *QuoteHandler) GetRandomQuote(c *gin.Context) {
@@ -98,6 +308,119 @@ func (h *QuoteHandler) GetRandomQuote(c *gin.Context) {
 	Success(c, "Random quote retrieved successfully", response)
 }
 
+// GetDailyQuote returns the quote of the day of the authenticated user, or the global quote of
+// the day for anonymous callers
+func (h *QuoteHandler) GetDailyQuote(c *gin.Context) {
+	var userIDPtr *string
+	if userID, ok := middleware.GetUserIDFromGinContext(c); ok {
+		id := userID.String()
+		userIDPtr = &id
+	}
+
+	dailyQuote, err := h.dailyQuoteUseCase.GetDailyQuote(c.Request.Context(), userIDPtr)
+	if err != nil {
+		if err.Error() == "no quotes found" {
+			Error(c, CodeNotFound, "No quotes available")
+			return
+		}
+		Error(c, CodeServerError, "Failed to get daily quote: "+err.Error())
+		return
+	}
+
+	response := DailyQuoteResponse{
+		QuoteResponse: h.buildQuoteResponse(dailyQuote.Quote),
+		Day:           dailyQuote.Day.Format(timeutil.DateFormat),
+		Timezone:      dailyQuote.Timezone,
+	}
+	Success(c, "Daily quote retrieved successfully", response)
+}
+
+// GetRecommendedQuotes returns quotes tagged for the mood of the user's latest record, leaving
+// out the quotes they already liked or skipped
+func (h *QuoteHandler) GetRecommendedQuotes(c *gin.Context) {
+	userID, ok := middleware.GetUserIDFromGinContext(c)
+	if !ok {
+		Error(c, CodeUnauthorized, "User not authenticated")
+		return
+	}
+
+	var limitPtr *int
+	if limitStr := c.Query("limit"); limitStr != "" {
+		limit, err := strconv.Atoi(limitStr)
+		if err != nil {
+			Error(c, CodeBadRequest, "limit must be a number")
+			return
+		}
+		limitPtr = &limit
+	}
+
+	command, err := commands.NewRecommendQuotesCommand(userID.String(), limitPtr)
+	if err != nil {
+		Error(c, CodeBadRequest, err.Error())
+		return
+	}
+
+	recommendations, err := h.recommendationUseCase.RecommendQuotes(c.Request.Context(), command)
+	if err != nil {
+		Error(c, CodeServerError, "Failed to recommend quotes: "+err.Error())
+		return
+	}
+
+	response := RecommendedQuotesResponse{
+		Quotes: make([]QuoteResponse, 0, len(recommendations.Quotes)),
+		Basis:  recommendations.Basis,
+		Tags:   recommendations.Tags,
+	}
+	if response.Tags == nil {
+		response.Tags = []string{}
+	}
+	for _, quote := range recommendations.Quotes {
+		response.Quotes = append(response.Quotes, h.buildQuoteResponse(quote))
+	}
+	if record := recommendations.Record; record != nil {
+		response.Mood = &RecommendationMoodResponse{
+			RecordID:    record.ID().String(),
+			HappyLevel:  record.HappyLevel().Value(),
+			EnergyLevel: record.EnergyLevel().Value(),
+			RecordedAt:  timeutil.FormatTime(record.CreatedAt()),
+		}
+	}
+	Success(c, "Recommended quotes retrieved successfully", response)
+}
+
+// RecordFeedback stores whether the user liked or skipped a quote; skipped and liked quotes are
+// no longer recommended, and likes steer recommendations when the mood does not
+func (h *QuoteHandler) RecordFeedback(c *gin.Context) {
+	userID, ok := middleware.GetUserIDFromGinContext(c)
+	if !ok {
+		Error(c, CodeUnauthorized, "User not authenticated")
+		return
+	}
+
+	var req QuoteFeedbackRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		Error(c, CodeBadRequest, "Invalid request body")
+		return
+	}
+
+	command, err := commands.NewQuoteFeedbackCommand(userID.String(), c.Param("id"), req.Signal)
+	if err != nil {
+		Error(c, CodeBadRequest, err.Error())
+		return
+	}
+
+	if err := h.recommendationUseCase.RecordFeedback(c.Request.Context(), command); err != nil {
+		if err.Error() == "quote not found" {
+			Error(c, CodeNotFound, "Quote not found")
+			return
+		}
+		Error(c, CodeServerError, "Failed to record feedback: "+err.Error())
+		return
+	}
+
+	Success(c, "Feedback recorded successfully", nil)
+}
+
 func (h *QuoteHandler) GetByID(c *gin.Context) {
 	id := c.Param("id")
 	if id == "" {
@@ -0,0 +1,96 @@
+package handlers
+
+import (
+	"errors"
+
+	"github.com/atdevten/peace/internal/application/usecases"
+	"github.com/atdevten/peace/internal/domain/repositories"
+	"github.com/atdevten/peace/internal/interfaces/http/middleware"
+	"github.com/atdevten/peace/internal/pkg/timeutil"
+
+	"github.com/gin-gonic/gin"
+)
+
+type SessionHandler struct {
+	sessionUseCase usecases.SessionUseCase
+}
+
+type SessionResponse struct {
+	ID         string `json:"id"`
+	UserAgent  string `json:"user_agent"`
+	IPAddress  string `json:"ip_address"`
+	Provider   string `json:"provider"`
+	CreatedAt  string `json:"created_at"`
+	LastUsedAt string `json:"last_used_at"`
+	Current    bool   `json:"current"`
+}
+
+func NewSessionHandler(sessionUseCase usecases.SessionUseCase) *SessionHandler {
+	return &SessionHandler{
+		sessionUseCase: sessionUseCase,
+	}
+}
+
+// ListSessions returns the active sessions of the authenticated user
+func (h *SessionHandler) ListSessions(c *gin.Context) {
+	userID, ok := middleware.GetUserIDFromGinContext(c)
+	if !ok {
+		Error(c, CodeUnauthorized, "User not authenticated")
+		return
+	}
+
+	ctx := c.Request.Context()
+	sessions, err := h.sessionUseCase.ListSessions(ctx, userID.String())
+	if err != nil {
+		Error(c, CodeServerError, err.Error())
+		return
+	}
+
+	// Flag the session the request was made with
+	var currentID string
+	if sessionID, ok := middleware.GetSessionIDFromGinContext(c); ok {
+		currentID = sessionID.String()
+	}
+
+	responses := make([]SessionResponse, 0, len(sessions))
+	for _, session := range sessions {
+		responses = append(responses, SessionResponse{
+			ID:         session.ID().String(),
+			UserAgent:  session.UserAgent(),
+			IPAddress:  session.IPAddress(),
+			Provider:   session.Provider(),
+			CreatedAt:  timeutil.FormatTime(session.CreatedAt()),
+			LastUsedAt: timeutil.FormatTime(session.LastUsedAt()),
+			Current:    session.ID().String() == currentID,
+		})
+	}
+
+	Success(c, "Sessions retrieved successfully", responses)
+}
+
+// RevokeSession ends one session of the authenticated user
+func (h *SessionHandler) RevokeSession(c *gin.Context) {
+	sessionID := c.Param("id")
+	if sessionID == "" {
+		Error(c, CodeBadRequest, "Session ID is required")
+		return
+	}
+
+	userID, ok := middleware.GetUserIDFromGinContext(c)
+	if !ok {
+		Error(c, CodeUnauthorized, "User not authenticated")
+		return
+	}
+
+	ctx := c.Request.Context()
+	if err := h.sessionUseCase.RevokeSession(ctx, userID.String(), sessionID); err != nil {
+		if errors.Is(err, repositories.ErrSessionNotFound) {
+			Error(c, CodeNotFound, "Session not found")
+			return
+		}
+		Error(c, CodeBadRequest, err.Error())
+		return
+	}
+
+	Success(c, "Session revoked successfully", nil)
+}
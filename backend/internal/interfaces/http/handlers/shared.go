@@ -1,7 +1,10 @@
 package handlers
 
 import (
+	"math"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
@@ -11,16 +14,25 @@ type APIResponse struct {
 	Code    string      `json:"code"`
 	Message string      `json:"message"`
 	Data    interface{} `json:"data,omitempty"`
+	Meta    interface{} `json:"meta,omitempty"`
+}
+
+// PaginationMeta describes how to fetch the next page of a list response
+type PaginationMeta struct {
+	Limit      int     `json:"limit"`
+	NextCursor *string `json:"next_cursor"`
 }
 
 // Response codes
 const (
-	CodeSuccess      = "SUCCESS"
-	CodeBadRequest   = "BAD_REQUEST"
-	CodeNotFound     = "NOT_FOUND"
-	CodeUnauthorized = "UNAUTHORIZED"
-	CodeForbidden    = "FORBIDDEN"
-	CodeServerError  = "SERVER_ERROR"
+	CodeSuccess         = "SUCCESS"
+	CodeBadRequest      = "BAD_REQUEST"
+	CodeNotFound        = "NOT_FOUND"
+	CodeUnauthorized    = "UNAUTHORIZED"
+	CodeForbidden       = "FORBIDDEN"
+	CodeConflict        = "CONFLICT"
+	CodeTooManyRequests = "TOO_MANY_REQUESTS"
+	CodeServerError     = "SERVER_ERROR"
 )
 
 // Success response with data
@@ -32,6 +44,25 @@ func Success(c *gin.Context, message string, data interface{}) {
 	})
 }
 
+// Accepted response for work that continues in the background
+func Accepted(c *gin.Context, message string, data interface{}) {
+	c.JSON(http.StatusAccepted, APIResponse{
+		Code:    CodeSuccess,
+		Message: message,
+		Data:    data,
+	})
+}
+
+// SuccessWithMeta response with data and list metadata such as pagination
+func SuccessWithMeta(c *gin.Context, message string, data interface{}, meta interface{}) {
+	c.JSON(http.StatusOK, APIResponse{
+		Code:    CodeSuccess,
+		Message: message,
+		Data:    data,
+		Meta:    meta,
+	})
+}
+
 // Error response with custom code and message
 func Error(c *gin.Context, code string, message string) {
 	status := http.StatusInternalServerError
@@ -44,6 +75,10 @@ func Error(c *gin.Context, code string, message string) {
 		status = http.StatusForbidden
 	case CodeNotFound:
 		status = http.StatusNotFound
+	case CodeConflict:
+		status = http.StatusConflict
+	case CodeTooManyRequests:
+		status = http.StatusTooManyRequests
 	case CodeServerError:
 		status = http.StatusInternalServerError
 	default:
@@ -56,3 +91,13 @@ func Error(c *gin.Context, code string, message string) {
 		Data:    nil,
 	})
 }
+
+// TooManyRequests responds 429 and tells the client in Retry-After how many seconds to wait
+func TooManyRequests(c *gin.Context, message string, retryAfter time.Duration) {
+	seconds := int(math.Ceil(retryAfter.Seconds()))
+	if seconds < 1 {
+		seconds = 1
+	}
+	c.Header("Retry-After", strconv.Itoa(seconds))
+	Error(c, CodeTooManyRequests, message)
+}
@@ -1,7 +1,10 @@
 package handlers
 
 import (
+	"errors"
+
 	"github.com/atdevten/peace/internal/application/usecases"
+	"github.com/atdevten/peace/internal/domain/repositories"
 	"github.com/atdevten/peace/internal/interfaces/http/middleware"
 	"github.com/gin-gonic/gin"
 )
@@ -27,10 +30,13 @@ func (h *UserHandler) Me(c *gin.Context) {
 		return
 	}
 	data := gin.H{
-		"id":        user.ID().String(),
-		"email":     user.Email().String(),
-		"username":  user.Username().String(),
-		"full_name": user.GetFullName(),
+		"id":           user.ID().String(),
+		"email":        user.Email().String(),
+		"username":     user.Username().String(),
+		"full_name":    user.GetFullName(),
+		"timezone":     user.Timezone().String(),
+		"feed_opt_out": user.FeedOptOut(),
+		"role":         user.Role().String(),
 	}
 	Success(c, "Me retrieved successfully", data)
 }
@@ -54,14 +60,17 @@ func (h *UserHandler) UpdateProfile(c *gin.Context) {
 	ctx := c.Request.Context()
 	user, err := h.userUseCase.UpdateProfile(ctx, userID.String(), req.FirstName, req.LastName)
 	if err != nil {
-		Error(c, CodeBadRequest, err.Error())
+		userUpdateError(c, err)
 		return
 	}
 	data := gin.H{
-		"id":        user.ID().String(),
-		"email":     user.Email().String(),
-		"username":  user.Username().String(),
-		"full_name": user.GetFullName(),
+		"id":           user.ID().String(),
+		"email":        user.Email().String(),
+		"username":     user.Username().String(),
+		"full_name":    user.GetFullName(),
+		"timezone":     user.Timezone().String(),
+		"feed_opt_out": user.FeedOptOut(),
+		"role":         user.Role().String(),
 	}
 	Success(c, "Profile updated successfully", data)
 }
@@ -83,12 +92,68 @@ func (h *UserHandler) UpdatePassword(c *gin.Context) {
 	}
 	ctx := c.Request.Context()
 	if err := h.userUseCase.UpdatePassword(ctx, userID.String(), req.NewPassword); err != nil {
-		Error(c, CodeBadRequest, err.Error())
+		userUpdateError(c, err)
 		return
 	}
 	Success(c, "Password updated successfully", nil)
 }
 
+type UpdateTimezoneRequest struct {
+	Timezone string `json:"timezone"`
+}
+
+func (h *UserHandler) UpdateTimezone(c *gin.Context) {
+	userID, ok := middleware.GetUserIDFromGinContext(c)
+	if !ok {
+		Error(c, CodeUnauthorized, "User not authenticated")
+		return
+	}
+	var req UpdateTimezoneRequest
+	if err := c.ShouldBindJSON(&req); err != nil || req.Timezone == "" {
+		Error(c, CodeBadRequest, "timezone is required")
+		return
+	}
+	ctx := c.Request.Context()
+	user, err := h.userUseCase.UpdateTimezone(ctx, userID.String(), req.Timezone)
+	if err != nil {
+		userUpdateError(c, err)
+		return
+	}
+	data := gin.H{
+		"id":       user.ID().String(),
+		"timezone": user.Timezone().String(),
+	}
+	Success(c, "Timezone updated successfully", data)
+}
+
+type UpdateFeedPreferenceRequest struct {
+	OptOut *bool `json:"opt_out"`
+}
+
+func (h *UserHandler) UpdateFeedPreference(c *gin.Context) {
+	userID, ok := middleware.GetUserIDFromGinContext(c)
+	if !ok {
+		Error(c, CodeUnauthorized, "User not authenticated")
+		return
+	}
+	var req UpdateFeedPreferenceRequest
+	if err := c.ShouldBindJSON(&req); err != nil || req.OptOut == nil {
+		Error(c, CodeBadRequest, "opt_out is required")
+		return
+	}
+	ctx := c.Request.Context()
+	user, err := h.userUseCase.UpdateFeedOptOut(ctx, userID.String(), *req.OptOut)
+	if err != nil {
+		userUpdateError(c, err)
+		return
+	}
+	data := gin.H{
+		"id":           user.ID().String(),
+		"feed_opt_out": user.FeedOptOut(),
+	}
+	Success(c, "Feed preference updated successfully", data)
+}
+
 func (h *UserHandler) Deactivate(c *gin.Context) {
 	userID, ok := middleware.GetUserIDFromGinContext(c)
 	if !ok {
@@ -97,7 +162,7 @@ func (h *UserHandler) Deactivate(c *gin.Context) {
 	}
 	ctx := c.Request.Context()
 	if err := h.userUseCase.Deactivate(ctx, userID.String()); err != nil {
-		Error(c, CodeBadRequest, err.Error())
+		userUpdateError(c, err)
 		return
 	}
 	Success(c, "Account deactivated successfully", nil)
@@ -111,8 +176,17 @@ func (h *UserHandler) DeleteAccount(c *gin.Context) {
 	}
 	ctx := c.Request.Context()
 	if err := h.userUseCase.Delete(ctx, userID.String()); err != nil {
-		Error(c, CodeBadRequest, err.Error())
+		userUpdateError(c, err)
 		return
 	}
 	Success(c, "Account deleted successfully", nil)
 }
+
+// userUpdateError reports a failed account change; losing a concurrent update is a conflict the client can retry
+func userUpdateError(c *gin.Context, err error) {
+	if errors.Is(err, repositories.ErrUserVersionConflict) {
+		Error(c, CodeConflict, err.Error())
+		return
+	}
+	Error(c, CodeBadRequest, err.Error())
+}
@@ -0,0 +1,27 @@
+package middleware
+
+import (
+	"github.com/atdevten/peace/internal/application/services/audit"
+	"github.com/atdevten/peace/internal/domain/value_objects"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AuditActor stores the client of every request in its context for the audit log. The auth
+// middlewares add the user once they have authenticated the request.
+func AuditActor() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		setAuditActor(c, nil)
+		c.Next()
+	}
+}
+
+// setAuditActor records the request's client, and the user it acts as, in the request context
+func setAuditActor(c *gin.Context, userID *value_objects.UserID) {
+	ctx := audit.WithActor(c.Request.Context(), audit.Actor{
+		UserID:    userID,
+		IPAddress: c.ClientIP(),
+		UserAgent: c.Request.UserAgent(),
+	})
+	c.Request = c.Request.WithContext(ctx)
+}
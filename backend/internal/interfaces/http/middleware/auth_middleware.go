@@ -1,29 +1,63 @@
 package middleware
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"strings"
 
 	appjwt "github.com/atdevten/peace/internal/application/services/jwt"
+	"github.com/atdevten/peace/internal/domain/entities"
 	"github.com/atdevten/peace/internal/domain/value_objects"
 
 	"github.com/gin-gonic/gin"
 )
 
+// SessionValidator checks that the session an access token was issued for is still active
+type SessionValidator interface {
+	Authenticate(ctx context.Context, userID string, sessionID string) error
+}
+
+// TokenAuthenticator resolves personal access tokens to the token and the user they act as
+type TokenAuthenticator interface {
+	Authenticate(ctx context.Context, token string) (*entities.PersonalAccessToken, *entities.User, error)
+}
+
 // AuthMiddleware provides Gin-compatible middleware functions
 type AuthMiddleware struct {
 	jwtService appjwt.Service
+	sessions   SessionValidator
+	tokens     TokenAuthenticator
 }
 
-func NewAuthMiddleware(jwtService appjwt.Service) *AuthMiddleware {
+// NewAuthMiddleware creates the middleware; a nil sessions validator skips session checks and
+// nil tokens rejects personal access tokens everywhere
+func NewAuthMiddleware(jwtService appjwt.Service, sessions SessionValidator, tokens TokenAuthenticator) *AuthMiddleware {
 	return &AuthMiddleware{
 		jwtService: jwtService,
+		sessions:   sessions,
+		tokens:     tokens,
 	}
 }
 
 // RequireAuth is a Gin middleware that requires JWT authentication
 func (m *AuthMiddleware) RequireAuth() gin.HandlerFunc {
+	return m.requireAuth(nil)
+}
+
+// RequireAuthOrToken is RequireAuth that also accepts personal access tokens carrying readScope
+// on GET and HEAD requests and writeScope on the others. An empty scope keeps tokens out.
+func (m *AuthMiddleware) RequireAuthOrToken(readScope, writeScope value_objects.TokenScope) gin.HandlerFunc {
+	return m.requireAuth(func(method string) value_objects.TokenScope {
+		if method == http.MethodGet || method == http.MethodHead {
+			return readScope
+		}
+		return writeScope
+	})
+}
+
+// requireAuth authenticates bearer JWTs, and personal access tokens when scopeFor names the scope a request needs
+func (m *AuthMiddleware) requireAuth(scopeFor func(method string) value_objects.TokenScope) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// Get authorization header
 		authHeader := c.GetHeader("Authorization")
@@ -49,6 +83,11 @@ func (m *AuthMiddleware) RequireAuth() gin.HandlerFunc {
 
 		tokenString := parts[1]
 
+		if strings.HasPrefix(tokenString, entities.PersonalAccessTokenPrefix) {
+			m.authenticateToken(c, tokenString, scopeFor)
+			return
+		}
+
 		// Validate access token
 		claims, err := m.jwtService.ValidateAccessToken(tokenString)
 		if err != nil {
@@ -82,9 +121,36 @@ func (m *AuthMiddleware) RequireAuth() gin.HandlerFunc {
 			return
 		}
 
+		// Parse role
+		role, err := roleFromClaim(claims.Role)
+		if err != nil {
+			c.JSON(http.StatusUnauthorized, gin.H{
+				"success": false,
+				"message": "Invalid role in token",
+			})
+			c.Abort()
+			return
+		}
+
+		// Reject tokens whose session has been revoked
+		sessionID, err := m.validateSession(c, userID, claims.SessionID)
+		if err != nil {
+			c.JSON(http.StatusUnauthorized, gin.H{
+				"success": false,
+				"message": "Session is no longer valid",
+			})
+			c.Abort()
+			return
+		}
+
 		// Store user info in Gin context (store pointer to match getters)
 		c.Set("user_id", userID)
 		c.Set("user_email", email)
+		c.Set("user_role", role)
+		if sessionID != nil {
+			c.Set("session_id", sessionID)
+		}
+		setAuditActor(c, userID)
 
 		// Continue to next handler
 		c.Next()
@@ -136,15 +202,142 @@ func (m *AuthMiddleware) OptionalAuth() gin.HandlerFunc {
 			return
 		}
 
+		// Parse role
+		role, err := roleFromClaim(claims.Role)
+		if err != nil {
+			// Invalid role, continue without user info
+			c.Next()
+			return
+		}
+
+		// Revoked session, continue without user info
+		sessionID, err := m.validateSession(c, userID, claims.SessionID)
+		if err != nil {
+			c.Next()
+			return
+		}
+
 		// Store user info in Gin context
 		c.Set("user_id", userID)
 		c.Set("user_email", email)
+		c.Set("user_role", role)
+		if sessionID != nil {
+			c.Set("session_id", sessionID)
+		}
+		setAuditActor(c, userID)
 
 		// Continue to next handler
 		c.Next()
 	}
 }
 
+// authenticateToken lets a personal access token through when it carries the scope the request needs
+func (m *AuthMiddleware) authenticateToken(c *gin.Context, tokenString string, scopeFor func(method string) value_objects.TokenScope) {
+	var scope value_objects.TokenScope
+	if scopeFor != nil {
+		scope = scopeFor(c.Request.Method)
+	}
+	if scope == "" || m.tokens == nil {
+		c.JSON(http.StatusForbidden, gin.H{
+			"success": false,
+			"message": "Personal access tokens cannot be used for this endpoint",
+		})
+		c.Abort()
+		return
+	}
+
+	token, user, err := m.tokens.Authenticate(c.Request.Context(), tokenString)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"success": false,
+			"message": "Invalid token",
+		})
+		c.Abort()
+		return
+	}
+
+	if !token.HasScope(scope) {
+		c.JSON(http.StatusForbidden, gin.H{
+			"success": false,
+			"message": "Token is missing the " + scope.String() + " scope",
+		})
+		c.Abort()
+		return
+	}
+
+	// Tokens act as their user with the user's current role
+	c.Set("user_id", user.ID())
+	c.Set("user_email", user.Email())
+	c.Set("user_role", user.Role())
+	setAuditActor(c, user.ID())
+
+	c.Next()
+}
+
+// RequireRole is a Gin middleware that only lets users with one of the given roles through.
+// It must run after RequireAuth.
+func (m *AuthMiddleware) RequireRole(roles ...value_objects.Role) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		role, ok := GetUserRoleFromGinContext(c)
+		if !ok {
+			c.JSON(http.StatusUnauthorized, gin.H{
+				"success": false,
+				"message": "User not authenticated",
+			})
+			c.Abort()
+			return
+		}
+
+		for _, allowed := range roles {
+			if role == allowed {
+				c.Next()
+				return
+			}
+		}
+
+		c.JSON(http.StatusForbidden, gin.H{
+			"success": false,
+			"message": "Insufficient permissions",
+		})
+		c.Abort()
+	}
+}
+
+// roleFromClaim parses the role claim; tokens issued before roles existed belong to regular users
+func roleFromClaim(claim string) (value_objects.Role, error) {
+	if claim == "" {
+		return value_objects.RoleUser, nil
+	}
+
+	role, err := value_objects.NewRole(claim)
+	if err != nil {
+		return "", err
+	}
+
+	return *role, nil
+}
+
+// validateSession parses the sid claim and, when a validator is configured, checks the session is active
+func (m *AuthMiddleware) validateSession(c *gin.Context, userID *value_objects.UserID, sid string) (*value_objects.TokenID, error) {
+	if m.sessions == nil {
+		if sid == "" {
+			return nil, nil
+		}
+		return value_objects.NewTokenIDFromString(sid)
+	}
+
+	sessionID, err := value_objects.NewTokenIDFromString(sid)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := m.sessions.Authenticate(c.Request.Context(), userID.String(), sessionID.String()); err != nil {
+		return nil, err
+	}
+
+	return sessionID, nil
+}
+
 // Helper functions to extract user info from Gin context
 func GetUserIDFromGinContext(c *gin.Context) (*value_objects.UserID, bool) {
 	userID, exists := c.Get("user_id")
@@ -172,3 +365,25 @@ func GetUserEmailFromGinContext(c *gin.Context) (*value_objects.Email, bool) {
 
 	return emailValue, ok
 }
+
+func GetUserRoleFromGinContext(c *gin.Context) (value_objects.Role, bool) {
+	role, exists := c.Get("user_role")
+	if !exists {
+		return "", false
+	}
+
+	roleValue, ok := role.(value_objects.Role)
+
+	return roleValue, ok
+}
+
+func GetSessionIDFromGinContext(c *gin.Context) (*value_objects.TokenID, bool) {
+	sessionID, exists := c.Get("session_id")
+	if !exists {
+		return nil, false
+	}
+
+	sessionIDValue, ok := sessionID.(*value_objects.TokenID)
+
+	return sessionIDValue, ok
+}
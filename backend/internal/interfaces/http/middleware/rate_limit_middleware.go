@@ -0,0 +1,66 @@
+package middleware
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/atdevten/peace/internal/application/services/ratelimit"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RateLimitRejecter writes the response of a request over its limit, retryAfter being the time until the window closes
+type RateLimitRejecter func(c *gin.Context, retryAfter time.Duration)
+
+// RateLimitMiddleware throttles route groups with counters kept in a ratelimit.Store
+type RateLimitMiddleware struct {
+	store  ratelimit.Store
+	reject RateLimitRejecter
+}
+
+// NewRateLimitMiddleware creates the middleware; reject writes the 429 response and a nil store disables all limits
+func NewRateLimitMiddleware(store ratelimit.Store, reject RateLimitRejecter) *RateLimitMiddleware {
+	return &RateLimitMiddleware{
+		store:  store,
+		reject: reject,
+	}
+}
+
+// Limit allows each caller requests per window on the routes of group. Callers are told apart by user
+// once RequireAuth has run and by client IP before, so place it after RequireAuth on protected groups.
+// A non-positive requests disables the limit.
+func (m *RateLimitMiddleware) Limit(group string, requests int, window time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if m.store == nil || requests <= 0 || window <= 0 {
+			c.Next()
+			return
+		}
+
+		caller := "ip:" + c.ClientIP()
+		if userID, ok := GetUserIDFromGinContext(c); ok {
+			caller = "user:" + userID.String()
+		}
+
+		count, remaining, err := m.store.Hit(c.Request.Context(), "http:"+group+":"+caller, window)
+		if err != nil {
+			// Fail open, an unavailable store must not take the API down
+			c.Next()
+			return
+		}
+
+		left := int64(requests) - count
+		if left < 0 {
+			left = 0
+		}
+		c.Header("X-RateLimit-Limit", strconv.Itoa(requests))
+		c.Header("X-RateLimit-Remaining", strconv.FormatInt(left, 10))
+
+		if count > int64(requests) {
+			m.reject(c, remaining)
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -3,15 +3,27 @@ package server
 import (
 	"context"
 	"fmt"
+	"log"
 	"net/http"
+	"sync"
+	"time"
 
-	"github.com/atdevten/peace/internal/application/services/google"
+	appcache "github.com/atdevten/peace/internal/application/services/cache"
 	appjwt "github.com/atdevten/peace/internal/application/services/jwt"
+	appmail "github.com/atdevten/peace/internal/application/services/mail"
+	appratelimit "github.com/atdevten/peace/internal/application/services/ratelimit"
 	appUsecases "github.com/atdevten/peace/internal/application/usecases"
+	"github.com/atdevten/peace/internal/domain/value_objects"
 	infraJWT "github.com/atdevten/peace/internal/infrastructure/auth/jwt"
+	infraOIDC "github.com/atdevten/peace/internal/infrastructure/auth/oidc"
+	infraCache "github.com/atdevten/peace/internal/infrastructure/cache"
 	infraConfig "github.com/atdevten/peace/internal/infrastructure/config"
 	infraDB "github.com/atdevten/peace/internal/infrastructure/database"
 	pgRepo "github.com/atdevten/peace/internal/infrastructure/database/postgres/repository"
+	redisclient "github.com/atdevten/peace/internal/infrastructure/database/redis"
+	infraFileStore "github.com/atdevten/peace/internal/infrastructure/filestore"
+	infraMail "github.com/atdevten/peace/internal/infrastructure/mail"
+	infraRateLimit "github.com/atdevten/peace/internal/infrastructure/ratelimit"
 	httpHandlers "github.com/atdevten/peace/internal/interfaces/http/handlers"
 	httpMiddleware "github.com/atdevten/peace/internal/interfaces/http/middleware"
 
@@ -21,10 +33,17 @@ import (
 
 // HTTPServer wires infrastructure, application and interface layers, and runs Gin HTTP server
 type HTTPServer struct {
-	cfg        *infraConfig.Config
-	dbManager  *infraDB.DatabaseManager
-	engine     *gin.Engine
-	httpServer *http.Server
+	cfg         *infraConfig.Config
+	dbManager   *infraDB.DatabaseManager
+	redisClient redisclient.Client // nil unless rate limits or the quote of the day cache use Redis
+	engine      *gin.Engine
+	httpServer  *http.Server
+
+	// Background jobs run alongside the HTTP server until shutdown
+	workers     []func(ctx context.Context)
+	workerCtx   context.Context
+	stopWorkers context.CancelFunc
+	workersDone sync.WaitGroup
 }
 
 // NewHTTPServer initializes dependencies, registers routes, and returns a ready server instance
@@ -40,41 +59,177 @@ func NewHTTPServer(cfg *infraConfig.Config) (*HTTPServer, error) {
 	recordRepo := pgRepo.NewPostgreSQLMentalHealthRecordRepository(dbManager.Postgres)
 	quoteRepo := pgRepo.NewPostgreSQLQuoteRepository(dbManager.Postgres)
 	tagRepo := pgRepo.NewTagRepository(dbManager.Postgres)
+	refreshTokenRepo := pgRepo.NewPostgreSQLRefreshTokenRepository(dbManager.Postgres)
+	sessionRepo := pgRepo.NewPostgreSQLSessionRepository(dbManager.Postgres)
+	totpRepo := pgRepo.NewPostgreSQLTOTPFactorRepository(dbManager.Postgres)
+	recoveryCodeRepo := pgRepo.NewPostgreSQLRecoveryCodeRepository(dbManager.Postgres)
+	resetTokenRepo := pgRepo.NewPostgreSQLPasswordResetTokenRepository(dbManager.Postgres)
+	verificationTokenRepo := pgRepo.NewPostgreSQLEmailVerificationTokenRepository(dbManager.Postgres)
+	identityRepo := pgRepo.NewPostgreSQLUserIdentityRepository(dbManager.Postgres)
+	personalAccessTokenRepo := pgRepo.NewPostgreSQLPersonalAccessTokenRepository(dbManager.Postgres)
+	dataExportRepo := pgRepo.NewPostgreSQLDataExportRepository(dbManager.Postgres)
+	retentionRepo := pgRepo.NewPostgreSQLRetentionRepository(dbManager.Postgres)
+	auditRepo := pgRepo.NewPostgreSQLAuditEventRepository(dbManager.Postgres)
+	dailyQuoteRepo := pgRepo.NewPostgreSQLDailyQuoteRepository(dbManager.Postgres)
+	quoteFeedbackRepo := pgRepo.NewPostgreSQLQuoteFeedbackRepository(dbManager.Postgres)
 
 	// Services (infrastructure implementation for application port)
-	var jwtService appjwt.Service = infraJWT.NewService(
+	jwtKeys, err := infraJWT.LoadKeySet(
 		cfg.Auth.JWT.Secret,
-		cfg.Auth.JWT.Expiration,
-		cfg.Auth.JWT.RefreshExpiration,
+		cfg.Auth.JWT.SigningKeyID,
+		cfg.Auth.JWT.SigningKeyFile,
+		cfg.Auth.JWT.VerificationKeysDir,
 	)
+	if err != nil {
+		return nil, fmt.Errorf("infraJWT.LoadKeySet: %w", err)
+	}
+	var jwtService appjwt.Service = infraJWT.NewService(infraJWT.Options{
+		Keys:          jwtKeys,
+		Issuer:        cfg.Auth.JWT.Issuer,
+		Audience:      cfg.Auth.JWT.Audience,
+		AccessExpiry:  cfg.Auth.JWT.Expiration,
+		RefreshExpiry: cfg.Auth.JWT.RefreshExpiration,
+	})
 
-	// Google OAuth service
-	googleService := google.NewService(
-		cfg.Auth.Google.ClientID,
-		cfg.Auth.Google.ClientSecret,
-		cfg.Auth.Google.RedirectURI,
-	)
+	// External login providers (OAuth 2.0 / OpenID Connect)
+	oauthProviders := make([]infraOIDC.ProviderConfig, 0, len(cfg.Auth.OAuth.Providers))
+	for _, provider := range cfg.Auth.OAuth.Providers {
+		oauthProviders = append(oauthProviders, infraOIDC.ProviderConfig{
+			Name:         provider.Name,
+			Issuer:       provider.Issuer,
+			ClientID:     provider.ClientID,
+			ClientSecret: provider.ClientSecret,
+			RedirectURI:  provider.RedirectURI,
+			Scopes:       provider.Scopes,
+			AuthURL:      provider.AuthURL,
+			TokenURL:     provider.TokenURL,
+			UserInfoURL:  provider.UserInfoURL,
+			EmailsURL:    provider.EmailsURL,
+			TrustEmail:   provider.TrustEmail,
+		})
+	}
+	oauthService := infraOIDC.NewService(infraOIDC.Options{
+		Providers:   oauthProviders,
+		StateSecret: cfg.Auth.OAuth.StateSecret,
+		StateTTL:    cfg.Auth.OAuth.StateTTL,
+	})
+
+	// Mail sender
+	mailSender, err := newMailSender(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("newMailSender: %w", err)
+	}
+
+	// Rate limit counters and login lockouts
+	rateLimitStore, redisCli, err := newRateLimitStore(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("newRateLimitStore: %w", err)
+	}
+	// Two-factor challenges are refused when their attempts cannot be counted, so login attempts are
+	// kept in memory when RATE_LIMIT_DRIVER=off turns the shared store off
+	loginAttempts := rateLimitStore
+	if loginAttempts == nil {
+		loginAttempts = infraRateLimit.NewMemoryStore()
+	}
+
+	// Quotes of the day, cached until the day ends
+	dailyQuoteCache, redisCli := newDailyQuoteCache(cfg, redisCli)
+
+	// Storage for data export archives
+	exportStore, err := infraFileStore.NewLocalStore(cfg.Export.Dir)
+	if err != nil {
+		return nil, fmt.Errorf("infraFileStore.NewLocalStore: %w", err)
+	}
 
 	// Use cases
-	authUC := appUsecases.NewAuthUseCase(userRepo, jwtService, googleService)
-	userUC := appUsecases.NewUserUseCase(userRepo)
-	recordUC := appUsecases.NewMentalHealthRecordUseCase(recordRepo)
-	quoteUC := appUsecases.NewQuoteUseCase(quoteRepo)
-	tagUC := appUsecases.NewTagUseCase(tagRepo, quoteRepo)
+	authUC := appUsecases.NewAuthUseCase(userRepo, sessionRepo, refreshTokenRepo, resetTokenRepo, verificationTokenRepo, totpRepo, recoveryCodeRepo, identityRepo, retentionRepo, auditRepo, jwtService, oauthService, loginAttempts, mailSender, appUsecases.AuthOptions{
+		RefreshTokenTTL:                 cfg.Auth.JWT.RefreshExpiration,
+		PasswordResetTTL:                cfg.Auth.PasswordReset.TokenTTL,
+		PasswordResetURL:                cfg.Auth.PasswordReset.URL,
+		RequireVerifiedEmail:            cfg.Auth.EmailVerification.Required,
+		EmailVerificationTTL:            cfg.Auth.EmailVerification.TokenTTL,
+		EmailVerificationURL:            cfg.Auth.EmailVerification.URL,
+		EmailVerificationResendInterval: cfg.Auth.EmailVerification.ResendInterval,
+		LoginLockout: appUsecases.LoginLockoutOptions{
+			EmailThreshold: cfg.Auth.LoginLockout.EmailThreshold,
+			IPThreshold:    cfg.Auth.LoginLockout.IPThreshold,
+			BaseDelay:      cfg.Auth.LoginLockout.BaseDelay,
+			MaxDelay:       cfg.Auth.LoginLockout.MaxDelay,
+			Window:         cfg.Auth.LoginLockout.Window,
+		},
+		DeletionGracePeriod: cfg.Retention.GracePeriod,
+	})
+	userUC := appUsecases.NewUserUseCase(userRepo, sessionRepo, refreshTokenRepo, personalAccessTokenRepo, auditRepo)
+	recordUC := appUsecases.NewMentalHealthRecordUseCase(recordRepo, userRepo)
+	quoteUC := appUsecases.NewQuoteUseCase(quoteRepo, auditRepo)
+	dailyQuoteUC := appUsecases.NewDailyQuoteUseCase(quoteRepo, dailyQuoteRepo, userRepo, dailyQuoteCache, appUsecases.DailyQuoteOptions{
+		RepeatWindow: cfg.Quotes.DailyRepeatWindow,
+	})
+	moodTagRules := make([]appUsecases.MoodTagRule, 0, len(cfg.Quotes.MoodTags))
+	for _, rule := range cfg.Quotes.MoodTags {
+		moodTagRules = append(moodTagRules, appUsecases.MoodTagRule(rule))
+	}
+	recommendationUC := appUsecases.NewRecommendationUseCase(quoteRepo, recordRepo, tagRepo, quoteFeedbackRepo, appUsecases.RecommendationOptions{
+		Rules: moodTagRules,
+	})
+	tagUC := appUsecases.NewTagUseCase(tagRepo, quoteRepo, auditRepo)
+	feedUC := appUsecases.NewFeedUseCase(recordRepo)
+	sessionUC := appUsecases.NewSessionUseCase(sessionRepo, refreshTokenRepo, auditRepo)
+	mfaUC := appUsecases.NewMFAUseCase(userRepo, totpRepo, recoveryCodeRepo, auditRepo, cfg.Auth.MFA.Issuer)
+	accountLinkUC := appUsecases.NewAccountLinkUseCase(userRepo, identityRepo, oauthService, auditRepo)
+	personalAccessTokenUC := appUsecases.NewPersonalAccessTokenUseCase(personalAccessTokenRepo, userRepo, auditRepo)
+	dataExportUC := appUsecases.NewDataExportUseCase(dataExportRepo, userRepo, recordRepo, sessionRepo, exportStore, auditRepo, appUsecases.DataExportOptions{
+		LinkSecret: cfg.Export.LinkSecret,
+		LinkTTL:    cfg.Export.LinkTTL,
+		ArchiveTTL: cfg.Export.ArchiveTTL,
+	})
+	auditUC := appUsecases.NewAuditUseCase(auditRepo)
+	retentionAction, err := value_objects.NewRetentionAction(cfg.Retention.Mode)
+	if err != nil {
+		return nil, fmt.Errorf("value_objects.NewRetentionAction: %w", err)
+	}
+	retentionUC := appUsecases.NewRetentionUseCase(retentionRepo, dataExportRepo, exportStore, appUsecases.RetentionOptions{
+		GracePeriod: cfg.Retention.GracePeriod,
+		Action:      *retentionAction,
+		DryRun:      cfg.Retention.DryRun,
+		Interval:    cfg.Retention.Interval,
+		BatchSize:   cfg.Retention.BatchSize,
+	})
 
 	// Handlers
 	authHandler := httpHandlers.NewAuthHandler(authUC)
 	userHandler := httpHandlers.NewUserHandler(userUC)
+	adminHandler := httpHandlers.NewAdminHandler(userUC)
+	auditHandler := httpHandlers.NewAuditHandler(auditUC)
 	recordHandler := httpHandlers.NewMentalHealthRecordHandler(recordUC)
-	quoteHandler := httpHandlers.NewQuoteHandler(quoteUC)
+	quoteHandler := httpHandlers.NewQuoteHandler(quoteUC, dailyQuoteUC, recommendationUC)
 	tagHandler := httpHandlers.NewTagHandler(tagUC)
+	feedHandler := httpHandlers.NewFeedHandler(feedUC)
+	sessionHandler := httpHandlers.NewSessionHandler(sessionUC)
+	mfaHandler := httpHandlers.NewMFAHandler(mfaUC)
+	accountLinkHandler := httpHandlers.NewAccountLinkHandler(accountLinkUC)
+	personalAccessTokenHandler := httpHandlers.NewPersonalAccessTokenHandler(personalAccessTokenUC)
+	dataExportHandler := httpHandlers.NewDataExportHandler(dataExportUC)
+	jwksHandler := httpHandlers.NewJWKSHandler(jwtKeys)
 
 	// Middleware
-	authMW := httpMiddleware.NewAuthMiddleware(jwtService)
+	authMW := httpMiddleware.NewAuthMiddleware(jwtService, sessionUC, personalAccessTokenUC)
+	rateLimitMW := httpMiddleware.NewRateLimitMiddleware(rateLimitStore, func(c *gin.Context, retryAfter time.Duration) {
+		httpHandlers.TooManyRequests(c, "Too many requests, please try again later", retryAfter)
+	})
+	limit := func(group string) gin.HandlerFunc {
+		rule := cfg.RateLimit.Groups[group]
+		return rateLimitMW.Limit(group, rule.Requests, rule.Window)
+	}
 
 	// Gin engine
 	engine := gin.Default()
 
+	// Client IPs come from X-Forwarded-For of trusted proxies only, so callers cannot spoof their way past limits
+	if err := engine.SetTrustedProxies(cfg.Server.TrustedProxies); err != nil {
+		return nil, fmt.Errorf("engine.SetTrustedProxies: %w", err)
+	}
+
 	// CORS middleware
 	corsConfig := cors.Config{
 		AllowOrigins:     cfg.App.CORS.AllowedOrigins,
@@ -92,6 +247,9 @@ func NewHTTPServer(cfg *infraConfig.Config) (*HTTPServer, error) {
 	}
 	engine.Use(cors.New(corsConfig))
 
+	// Who made each request, for the audit log
+	engine.Use(httpMiddleware.AuditActor())
+
 	// Public health endpoints
 	engine.GET("/healthz", func(c *gin.Context) {
 		c.JSON(http.StatusOK, gin.H{"status": "ok"})
@@ -100,73 +258,156 @@ func NewHTTPServer(cfg *infraConfig.Config) (*HTTPServer, error) {
 		c.JSON(http.StatusOK, gin.H{"status": "ok"})
 	})
 
+	// Public keys for services verifying our tokens
+	engine.GET("/.well-known/jwks.json", jwksHandler.GetJWKS)
+
 	// API routes under /api
 	api := engine.Group("/api")
 
 	// Auth routes (public)
 	authGroup := api.Group("/auth")
+	authGroup.Use(limit("auth"))
 	{
 		authGroup.POST("/register", authHandler.Register)
 		authGroup.POST("/login", authHandler.Login)
+		authGroup.POST("/login/mfa", authHandler.VerifyMFA)
 		authGroup.POST("/refresh", authHandler.Refresh)
-		authGroup.GET("/google/url", authHandler.GetGoogleAuthURL)
-		authGroup.POST("/google/login", authHandler.GoogleLogin)
+		authGroup.POST("/logout", authHandler.Logout)
+		authGroup.POST("/logout-all", authMW.RequireAuth(), authHandler.LogoutAll)
+		authGroup.POST("/password/forgot", authHandler.ForgotPassword)
+		authGroup.POST("/password/reset", authHandler.ResetPassword)
+		authGroup.POST("/verify-email", authHandler.VerifyEmail)
+		authGroup.POST("/verify-email/resend", authHandler.ResendVerificationEmail)
+		authGroup.GET("/oauth/providers", authHandler.ListOAuthProviders)
+		authGroup.GET("/oauth/:provider/url", authHandler.GetOAuthURL)
+		authGroup.POST("/oauth/:provider/login", authHandler.OAuthLogin)
+		authGroup.POST("/oauth/link", authHandler.ConfirmLink)
 	}
 
 	// User routes (protected)
+	// Deleted accounts authenticate with the restore token of their login, which is accepted nowhere else
+	api.POST("/user/restore", limit("auth"), authHandler.RestoreAccount)
+
 	userGroup := api.Group("/user")
-	userGroup.Use(authMW.RequireAuth())
+	userGroup.Use(authMW.RequireAuth(), limit("user"))
 	{
 		userGroup.GET("/me", userHandler.Me)
 		userGroup.PUT("/profile", userHandler.UpdateProfile)
 		userGroup.PUT("/password", userHandler.UpdatePassword)
+		userGroup.PUT("/timezone", userHandler.UpdateTimezone)
+		userGroup.PUT("/feed-preference", userHandler.UpdateFeedPreference)
 		userGroup.POST("/deactivate", userHandler.Deactivate)
 		userGroup.DELETE("/account", userHandler.DeleteAccount)
+		userGroup.GET("/sessions", sessionHandler.ListSessions)
+		userGroup.DELETE("/sessions/:id", sessionHandler.RevokeSession)
+		userGroup.GET("/mfa", mfaHandler.Status)
+		userGroup.POST("/mfa/totp/enroll", mfaHandler.EnrollTOTP)
+		userGroup.POST("/mfa/totp/confirm", mfaHandler.ConfirmTOTP)
+		userGroup.POST("/mfa/totp/disable", mfaHandler.DisableTOTP)
+		userGroup.POST("/mfa/recovery-codes", mfaHandler.RegenerateRecoveryCodes)
+		userGroup.GET("/identities", accountLinkHandler.ListIdentities)
+		userGroup.POST("/identities/:provider", accountLinkHandler.Link)
+		userGroup.DELETE("/identities/:provider", accountLinkHandler.Unlink)
+		userGroup.GET("/tokens", personalAccessTokenHandler.ListTokens)
+		userGroup.POST("/tokens", personalAccessTokenHandler.CreateToken)
+		userGroup.DELETE("/tokens/:id", personalAccessTokenHandler.DeleteToken)
+		userGroup.POST("/export", dataExportHandler.RequestExport)
+		userGroup.GET("/export", dataExportHandler.ListExports)
+		userGroup.GET("/export/:id", dataExportHandler.GetExport)
 	}
 
-	// Mental health records (protected)
+	// Data export downloads (public, authorised by the signed link)
+	api.GET("/exports/:id/download", limit("user"), dataExportHandler.Download)
+
+	// Community feed of public records (protected)
+	feedGroup := api.Group("/feed")
+	feedGroup.Use(authMW.RequireAuth(), limit("feed"))
+	{
+		feedGroup.GET("", feedHandler.GetPublicFeed)
+	}
+
+	// Mental health records (protected, open to personal access tokens with records scopes)
 	recordGroup := api.Group("/records")
-	recordGroup.Use(authMW.RequireAuth())
+	recordGroup.Use(authMW.RequireAuthOrToken(value_objects.ScopeRecordsRead, value_objects.ScopeRecordsWrite), limit("records"))
 	{
 		recordGroup.POST("", recordHandler.Create)
 		recordGroup.GET("", recordHandler.GetByCondition)
 		recordGroup.GET("/heatmap", recordHandler.GetHeatmap)
 		recordGroup.GET("/streak", recordHandler.GetStreak)
+		recordGroup.GET("/analytics", recordHandler.GetAnalytics)
+		recordGroup.GET("/tags/report", recordHandler.GetTagReport)
 		recordGroup.GET("/:id", recordHandler.GetByID)
 		recordGroup.PUT("/:id", recordHandler.Update)
 		recordGroup.DELETE("/:id", recordHandler.Delete)
 	}
 
-	// Quotes (public)
+	// Quote and tag management is limited to editors and admins
+	manageContent := []gin.HandlerFunc{
+		authMW.RequireAuth(),
+		authMW.RequireRole(value_objects.RoleEditor, value_objects.RoleAdmin),
+	}
+
+	// Quotes (public reads)
 	quotesGroup := api.Group("/quotes")
+	quotesGroup.Use(limit("quotes"))
 	{
-		quotesGroup.GET("", quoteHandler.GetAllQuotes)
+		quotesGroup.GET("", quoteHandler.ListQuotes)
 		quotesGroup.GET("/random", quoteHandler.GetRandomQuote)
+		quotesGroup.GET("/daily", authMW.OptionalAuth(), quoteHandler.GetDailyQuote)
+		quotesGroup.GET("/search", quoteHandler.SearchQuotes)
+		quotesGroup.GET("/recommended", authMW.RequireAuth(), quoteHandler.GetRecommendedQuotes)
 		quotesGroup.GET("/:id", quoteHandler.GetByID)
-		quotesGroup.POST("", quoteHandler.CreateQuote)
-		quotesGroup.PUT("/:id", quoteHandler.UpdateQuote)
-		quotesGroup.DELETE("/:id", quoteHandler.DeleteQuote)
+		quotesGroup.POST("/:id/feedback", authMW.RequireAuth(), quoteHandler.RecordFeedback)
 
 		// Quote tags
 		quotesGroup.GET("/:id/tags", tagHandler.GetTagsByQuoteID)
-		quotesGroup.POST("/:id/tags", tagHandler.AddTagToQuote)
-		quotesGroup.DELETE("/:id/tags", tagHandler.RemoveTagFromQuote)
+
+		// Quote management is also open to personal access tokens with quotes:write
+		quotesAdmin := quotesGroup.Group("",
+			authMW.RequireAuthOrToken("", value_objects.ScopeQuotesWrite),
+			authMW.RequireRole(value_objects.RoleEditor, value_objects.RoleAdmin),
+		)
+		quotesAdmin.POST("", quoteHandler.CreateQuote)
+		quotesAdmin.PUT("/:id", quoteHandler.UpdateQuote)
+		quotesAdmin.DELETE("/:id", quoteHandler.DeleteQuote)
+		quotesAdmin.POST("/:id/tags", tagHandler.AddTagToQuote)
+		quotesAdmin.DELETE("/:id/tags", tagHandler.RemoveTagFromQuote)
 	}
 
-	// Tags (public)
+	// Tags (public reads)
 	tagsGroup := api.Group("/tags")
+	tagsGroup.Use(limit("tags"))
 	{
 		tagsGroup.GET("", tagHandler.GetAllTags)
-		tagsGroup.POST("", tagHandler.CreateTag)
-		tagsGroup.PUT("/:id", tagHandler.UpdateTag)
-		tagsGroup.DELETE("/:id", tagHandler.DeleteTag)
+
+		tagsAdmin := tagsGroup.Group("", manageContent...)
+		tagsAdmin.POST("", tagHandler.CreateTag)
+		tagsAdmin.PUT("/:id", tagHandler.UpdateTag)
+		tagsAdmin.DELETE("/:id", tagHandler.DeleteTag)
+	}
+
+	// Administration (admins only)
+	adminGroup := api.Group("/admin")
+	adminGroup.Use(authMW.RequireAuth(), authMW.RequireRole(value_objects.RoleAdmin), limit("admin"))
+	{
+		adminGroup.PUT("/users/:id/role", adminHandler.ChangeUserRole)
+		adminGroup.GET("/audit-events", auditHandler.ListEvents)
 	}
 
 	s := &HTTPServer{
-		cfg:       cfg,
-		dbManager: dbManager,
-		engine:    engine,
+		cfg:         cfg,
+		dbManager:   dbManager,
+		redisClient: redisCli,
+		engine:      engine,
+		workers:     []func(ctx context.Context){dataExportUC.Run},
 	}
+	// Retention is opt-in: with RETENTION_INTERVAL=0 deleted accounts are left to cmd/retention, e.g. run from cron
+	if cfg.Retention.Interval > 0 {
+		log.Printf("Retention job enabled: %s accounts deleted more than %s ago every %s (dry run: %t)",
+			retentionAction, cfg.Retention.GracePeriod, cfg.Retention.Interval, cfg.Retention.DryRun)
+		s.workers = append(s.workers, retentionUC.Run)
+	}
+	s.workerCtx, s.stopWorkers = context.WithCancel(context.Background())
 
 	// Prepare http.Server with timeouts
 	s.httpServer = &http.Server{
@@ -181,11 +422,78 @@ func NewHTTPServer(cfg *infraConfig.Config) (*HTTPServer, error) {
 	return s, nil
 }
 
+// newMailSender picks the mail transport configured by MAIL_DRIVER
+func newMailSender(cfg *infraConfig.Config) (appmail.Sender, error) {
+	switch cfg.Mail.Driver {
+	case "smtp":
+		return infraMail.NewSMTPSender(
+			cfg.Mail.SMTP.Host,
+			cfg.Mail.SMTP.Port,
+			cfg.Mail.SMTP.Username,
+			cfg.Mail.SMTP.Password,
+			cfg.Mail.From,
+		), nil
+	case "log":
+		log.Printf("MAIL_DRIVER=log writes password reset and verification links to the log; do not use it in production")
+		return infraMail.NewLogSender(), nil
+	case "memory":
+		return infraMail.NewMemorySender(), nil
+	default:
+		return nil, fmt.Errorf("unknown mail driver: %s", cfg.Mail.Driver)
+	}
+}
+
+// newRateLimitStore picks where rate limit counters live, as configured by RATE_LIMIT_DRIVER. Redis shares
+// them between instances; while it is unreachable, at startup or later, counters are kept in memory.
+func newRateLimitStore(cfg *infraConfig.Config) (appratelimit.Store, redisclient.Client, error) {
+	switch cfg.RateLimit.Driver {
+	case "redis":
+		memory := infraRateLimit.NewMemoryStore()
+		client := redisclient.NewRealClient(cfg.GetRedisAddr(), cfg.Database.Redis.Password, cfg.Database.Redis.DB)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		defer cancel()
+		if err := client.Ping(ctx); err != nil {
+			log.Printf("Redis at %s is unreachable, rate limits are kept in memory until it responds: %v", cfg.GetRedisAddr(), err)
+		}
+
+		return infraRateLimit.NewFallbackStore(infraRateLimit.NewRedisStore(client), memory), client, nil
+	case "memory":
+		return infraRateLimit.NewMemoryStore(), nil, nil
+	case "off":
+		return nil, nil, nil
+	default:
+		return nil, nil, fmt.Errorf("unknown rate limit driver: %s", cfg.RateLimit.Driver)
+	}
+}
+
+// newDailyQuoteCache returns the cache of quotes of the day, nil when disabled. It shares the Redis
+// client of the rate limits when they have one, and returns the client it uses.
+func newDailyQuoteCache(cfg *infraConfig.Config, client redisclient.Client) (appcache.Cache, redisclient.Client) {
+	if cfg.Quotes.DailyCache != "redis" {
+		return nil, client
+	}
+
+	if client == nil {
+		client = redisclient.NewRealClient(cfg.GetRedisAddr(), cfg.Database.Redis.Password, cfg.Database.Redis.DB)
+	}
+	return infraCache.NewRedisCache(client), client
+}
+
 // Run starts the HTTP server and blocks until it stops
 func (s *HTTPServer) Run() error {
 	if s.httpServer == nil {
 		return fmt.Errorf("http server is not initialized")
 	}
+
+	for _, worker := range s.workers {
+		s.workersDone.Add(1)
+		go func(worker func(ctx context.Context)) {
+			defer s.workersDone.Done()
+			worker(s.workerCtx)
+		}(worker)
+	}
+
 	return s.httpServer.ListenAndServe()
 }
 
@@ -197,10 +505,31 @@ func (s *HTTPServer) Shutdown(ctx context.Context) error {
 			firstErr = fmt.Errorf("http shutdown: %w", err)
 		}
 	}
+	// Let background jobs finish their current step before closing connections
+	if s.stopWorkers != nil {
+		s.stopWorkers()
+		done := make(chan struct{})
+		go func() {
+			s.workersDone.Wait()
+			close(done)
+		}()
+		select {
+		case <-done:
+		case <-ctx.Done():
+			if firstErr == nil {
+				firstErr = fmt.Errorf("background workers: %w", ctx.Err())
+			}
+		}
+	}
 	// Close DB connections
 	if s.dbManager != nil {
 		s.dbManager.Close()
 	}
+	if s.redisClient != nil {
+		if err := s.redisClient.Close(); err != nil && firstErr == nil {
+			firstErr = fmt.Errorf("redis close: %w", err)
+		}
+	}
 	return firstErr
 }
 
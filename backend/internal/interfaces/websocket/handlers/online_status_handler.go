@@ -28,13 +28,15 @@ var wsUpgrader = websocket.Upgrader{
 type OnlineStatusHandler struct {
 	userOnlineStatusUC usecases.UserOnlineStatusUseCase
 	jwtService         appjwt.Service
+	sessions           httpmiddleware.SessionValidator
 }
 
-// NewOnlineStatusHandler creates a new OnlineStatusHandler
-func NewOnlineStatusHandler(userOnlineStatusUC usecases.UserOnlineStatusUseCase, jwtService appjwt.Service) *OnlineStatusHandler {
+// NewOnlineStatusHandler creates a new OnlineStatusHandler; sessions rejects tokens of logged-out or revoked sessions
+func NewOnlineStatusHandler(userOnlineStatusUC usecases.UserOnlineStatusUseCase, jwtService appjwt.Service, sessions httpmiddleware.SessionValidator) *OnlineStatusHandler {
 	return &OnlineStatusHandler{
 		userOnlineStatusUC: userOnlineStatusUC,
 		jwtService:         jwtService,
+		sessions:           sessions,
 	}
 }
 
@@ -75,6 +77,10 @@ func (h *OnlineStatusHandler) HandleWebSocket(c *gin.Context) {
 			handlers.Error(c, "UNAUTHORIZED", "Invalid token in subprotocol")
 			return
 		}
+		if err := h.sessions.Authenticate(c.Request.Context(), claims.UserID, claims.SessionID); err != nil {
+			handlers.Error(c, "UNAUTHORIZED", "Session is no longer valid")
+			return
+		}
 		userID = claims.UserID
 		userEmail = claims.Email
 	}
@@ -9,6 +9,8 @@ import (
 	"github.com/atdevten/peace/internal/domain/repositories"
 	jwtinfra "github.com/atdevten/peace/internal/infrastructure/auth/jwt"
 	"github.com/atdevten/peace/internal/infrastructure/config"
+	infraDB "github.com/atdevten/peace/internal/infrastructure/database"
+	pgRepo "github.com/atdevten/peace/internal/infrastructure/database/postgres/repository"
 	redisclient "github.com/atdevten/peace/internal/infrastructure/database/redis"
 	"github.com/atdevten/peace/internal/infrastructure/database/redis/repository"
 	httpmiddleware "github.com/atdevten/peace/internal/interfaces/http/middleware"
@@ -19,6 +21,7 @@ import (
 // WebSocketServer wires infrastructure, application and interface layers, and runs WebSocket server
 type WebSocketServer struct {
 	cfg                  *config.Config
+	dbManager            *infraDB.DatabaseManager
 	userOnlineStatusRepo repositories.UserOnlineStatusRepository
 	userOnlineStatusUC   usecases.UserOnlineStatusUseCase
 	engine               *gin.Engine
@@ -37,15 +40,41 @@ func NewWebSocketServer(cfg *config.Config) (*WebSocketServer, error) {
 	}
 	userOnlineStatusRepo := repository.NewRedisUserOnlineStatusRepository(redisCli)
 
+	// PostgreSQL holds the sessions that access tokens belong to; the HTTP server migrates it
+	dbManager, err := infraDB.ConnectDatabaseManager(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("infraDB.ConnectDatabaseManager: %w", err)
+	}
+	sessionRepo := pgRepo.NewPostgreSQLSessionRepository(dbManager.Postgres)
+	refreshTokenRepo := pgRepo.NewPostgreSQLRefreshTokenRepository(dbManager.Postgres)
+
 	// JWT service and middleware
-	jwtSvc := jwtinfra.NewService(cfg.Auth.JWT.Secret, cfg.Auth.JWT.Expiration, cfg.Auth.JWT.RefreshExpiration)
-	authMW := httpmiddleware.NewAuthMiddleware(jwtSvc)
+	// Usually verification-only: configure JWT_VERIFICATION_KEYS_DIR without a signing key
+	jwtKeys, err := jwtinfra.LoadKeySet(
+		cfg.Auth.JWT.Secret,
+		cfg.Auth.JWT.SigningKeyID,
+		cfg.Auth.JWT.SigningKeyFile,
+		cfg.Auth.JWT.VerificationKeysDir,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("jwtinfra.LoadKeySet: %w", err)
+	}
+	jwtSvc := jwtinfra.NewService(jwtinfra.Options{
+		Keys:          jwtKeys,
+		Issuer:        cfg.Auth.JWT.Issuer,
+		Audience:      cfg.Auth.JWT.Audience,
+		AccessExpiry:  cfg.Auth.JWT.Expiration,
+		RefreshExpiry: cfg.Auth.JWT.RefreshExpiration,
+	})
 
 	// Use cases
 	userOnlineStatusUC := usecases.NewUserOnlineStatusUseCase(userOnlineStatusRepo)
+	// Logged-out and revoked sessions cannot connect, as on the HTTP API
+	sessionUC := usecases.NewSessionUseCase(sessionRepo, refreshTokenRepo, nil)
+	authMW := httpmiddleware.NewAuthMiddleware(jwtSvc, sessionUC, nil)
 
 	// Handlers
-	onlineStatusHandler := websocketHandlers.NewOnlineStatusHandler(userOnlineStatusUC, jwtSvc)
+	onlineStatusHandler := websocketHandlers.NewOnlineStatusHandler(userOnlineStatusUC, jwtSvc, sessionUC)
 
 	// Gin engine
 	engine := gin.Default()
@@ -88,6 +117,7 @@ func NewWebSocketServer(cfg *config.Config) (*WebSocketServer, error) {
 
 	s := &WebSocketServer{
 		cfg:                  cfg,
+		dbManager:            dbManager,
 		userOnlineStatusRepo: userOnlineStatusRepo,
 		userOnlineStatusUC:   userOnlineStatusUC,
 		engine:               engine,
@@ -132,6 +162,10 @@ func (s *WebSocketServer) Shutdown(ctx context.Context) error {
 		}
 	}
 
+	if s.dbManager != nil {
+		s.dbManager.Close()
+	}
+
 	return firstErr
 }
 
@@ -0,0 +1,65 @@
+package pagination
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+const (
+	// DefaultLimit is the page size used when the client does not ask for one
+	DefaultLimit = 20
+	// MaxLimit is the largest page size a client may request
+	MaxLimit = 100
+)
+
+// Cursor identifies the last row of a page for keyset pagination on (created_at, id)
+type Cursor struct {
+	CreatedAt time.Time `json:"t"`
+	ID        string    `json:"id"`
+	Key       string    `json:"k,omitempty"` // sort value of the row for orders other than created_at
+}
+
+// EncodeCursor returns an opaque, URL-safe token for the cursor
+func EncodeCursor(cursor Cursor) string {
+	payload, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(payload)
+}
+
+// DecodeCursor parses a token produced by EncodeCursor
+func DecodeCursor(token string) (*Cursor, error) {
+	if token == "" {
+		return nil, errors.New("cursor cannot be empty")
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, errors.New("invalid cursor")
+	}
+
+	var cursor Cursor
+	if err := json.Unmarshal(payload, &cursor); err != nil {
+		return nil, errors.New("invalid cursor")
+	}
+
+	if cursor.CreatedAt.IsZero() || cursor.ID == "" {
+		return nil, errors.New("invalid cursor")
+	}
+
+	return &cursor, nil
+}
+
+// NormalizeLimit applies the default page size and rejects sizes outside 1..MaxLimit
+func NormalizeLimit(limit *int) (int, error) {
+	if limit == nil {
+		return DefaultLimit, nil
+	}
+
+	if *limit < 1 || *limit > MaxLimit {
+		return 0, fmt.Errorf("limit must be between 1 and %d", MaxLimit)
+	}
+
+	return *limit, nil
+}
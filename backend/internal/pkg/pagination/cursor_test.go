@@ -0,0 +1,108 @@
+package pagination
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEncodeDecodeCursor(t *testing.T) {
+	original := Cursor{
+		CreatedAt: time.Date(2023, 12, 1, 10, 0, 0, 123456789, time.UTC),
+		ID:        "550e8400-e29b-41d4-a716-446655440000",
+	}
+
+	token := EncodeCursor(original)
+	if token == "" {
+		t.Fatalf("EncodeCursor() returned empty token")
+	}
+
+	decoded, err := DecodeCursor(token)
+	if err != nil {
+		t.Fatalf("DecodeCursor() unexpected error = %v", err)
+	}
+
+	if !decoded.CreatedAt.Equal(original.CreatedAt) {
+		t.Errorf("DecodeCursor() CreatedAt = %v, want %v", decoded.CreatedAt, original.CreatedAt)
+	}
+	if decoded.ID != original.ID {
+		t.Errorf("DecodeCursor() ID = %v, want %v", decoded.ID, original.ID)
+	}
+}
+
+func TestDecodeCursor(t *testing.T) {
+	tests := []struct {
+		name        string
+		token       string
+		expectedErr string
+	}{
+		{
+			name:        "empty token",
+			token:       "",
+			expectedErr: "cursor cannot be empty",
+		},
+		{
+			name:        "not base64",
+			token:       "%%%",
+			expectedErr: "invalid cursor",
+		},
+		{
+			name:        "not json",
+			token:       "bm90LWpzb24",
+			expectedErr: "invalid cursor",
+		},
+		{
+			name:        "missing id",
+			token:       EncodeCursor(Cursor{CreatedAt: time.Now()}),
+			expectedErr: "invalid cursor",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := DecodeCursor(tt.token)
+			if err == nil {
+				t.Errorf("DecodeCursor() expected error but got none")
+				return
+			}
+			if err.Error() != tt.expectedErr {
+				t.Errorf("DecodeCursor() error = %v, expected %v", err.Error(), tt.expectedErr)
+			}
+		})
+	}
+}
+
+func TestNormalizeLimit(t *testing.T) {
+	intPtr := func(i int) *int { return &i }
+
+	tests := []struct {
+		name    string
+		limit   *int
+		want    int
+		wantErr bool
+	}{
+		{name: "default", limit: nil, want: DefaultLimit},
+		{name: "within range", limit: intPtr(50), want: 50},
+		{name: "max", limit: intPtr(MaxLimit), want: MaxLimit},
+		{name: "zero", limit: intPtr(0), wantErr: true},
+		{name: "too large", limit: intPtr(MaxLimit + 1), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NormalizeLimit(tt.limit)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("NormalizeLimit() expected error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("NormalizeLimit() unexpected error = %v", err)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("NormalizeLimit() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
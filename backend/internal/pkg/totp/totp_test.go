@@ -0,0 +1,86 @@
+package totp
+
+import (
+	"encoding/base32"
+	"strings"
+	"testing"
+	"time"
+)
+
+// rfcSecret is the SHA1 test key of RFC 6238 Appendix B
+var rfcSecret = base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString([]byte("12345678901234567890"))
+
+func TestCodeAt_RFC6238Vectors(t *testing.T) {
+	tests := []struct {
+		unix int64
+		want string // last six digits of the RFC's eight digit values
+	}{
+		{unix: 59, want: "287082"},
+		{unix: 1111111109, want: "081804"},
+		{unix: 1111111111, want: "050471"},
+		{unix: 1234567890, want: "005924"},
+		{unix: 2000000000, want: "279037"},
+	}
+
+	for _, tt := range tests {
+		got, err := CodeAt(rfcSecret, Step(time.Unix(tt.unix, 0)))
+		if err != nil {
+			t.Fatalf("CodeAt() unexpected error = %v", err)
+		}
+		if got != tt.want {
+			t.Errorf("CodeAt(%d) = %v, want %v", tt.unix, got, tt.want)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	now := time.Unix(1234567890, 0)
+	current := Step(now)
+
+	previous, _ := CodeAt(rfcSecret, current-1)
+	tooOld, _ := CodeAt(rfcSecret, current-2)
+
+	if step, ok := Validate(rfcSecret, "005924", now); !ok || step != current {
+		t.Errorf("Validate() current code = (%v, %v), want (%v, true)", step, ok, current)
+	}
+	if step, ok := Validate(rfcSecret, previous, now); !ok || step != current-1 {
+		t.Errorf("Validate() previous code = (%v, %v), want (%v, true)", step, ok, current-1)
+	}
+	if _, ok := Validate(rfcSecret, tooOld, now); ok {
+		t.Errorf("Validate() accepted a code outside the skew window")
+	}
+	if _, ok := Validate(rfcSecret, "12345", now); ok {
+		t.Errorf("Validate() accepted a short code")
+	}
+	if _, ok := Validate("not base32!", "005924", now); ok {
+		t.Errorf("Validate() accepted an invalid secret")
+	}
+}
+
+func TestGenerateSecret(t *testing.T) {
+	first, err := GenerateSecret()
+	if err != nil {
+		t.Fatalf("GenerateSecret() unexpected error = %v", err)
+	}
+	second, _ := GenerateSecret()
+
+	if len(first) != 32 {
+		t.Errorf("GenerateSecret() length = %v, want 32", len(first))
+	}
+	if first == second {
+		t.Errorf("GenerateSecret() returned the same secret twice")
+	}
+}
+
+func TestURI(t *testing.T) {
+	uri := URI("Peace", "user@example.com", "JBSWY3DPEHPK3PXP")
+
+	if !strings.HasPrefix(uri, "otpauth://totp/Peace:user@example.com?") {
+		t.Errorf("URI() = %v, unexpected label", uri)
+	}
+	for _, part := range []string{"secret=JBSWY3DPEHPK3PXP", "issuer=Peace", "digits=6", "period=30"} {
+		if !strings.Contains(uri, part) {
+			t.Errorf("URI() = %v, missing %v", uri, part)
+		}
+	}
+}
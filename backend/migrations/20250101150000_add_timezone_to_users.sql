@@ -0,0 +1,10 @@
+-- +goose Up
+-- Add timezone column to users table
+ALTER TABLE users ADD COLUMN IF NOT EXISTS timezone VARCHAR(64) NOT NULL DEFAULT 'UTC';
+
+-- Add comments
+COMMENT ON COLUMN users.timezone IS 'IANA timezone used to group records into days (e.g. Asia/Ho_Chi_Minh)';
+
+-- +goose Down
+-- Drop timezone column
+ALTER TABLE users DROP COLUMN IF EXISTS timezone;
@@ -0,0 +1,10 @@
+-- +goose Up
+-- Composite index backing per-user range scans used by analytics, heatmap and streak queries
+CREATE INDEX IF NOT EXISTS idx_mental_health_records_user_id_created_at
+    ON mental_health_records(user_id, created_at)
+    WHERE deleted_at IS NULL;
+
+COMMENT ON INDEX idx_mental_health_records_user_id_created_at IS 'Per-user time range lookups over live records';
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_mental_health_records_user_id_created_at;
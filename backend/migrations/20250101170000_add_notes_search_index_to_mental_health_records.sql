@@ -0,0 +1,18 @@
+-- +goose Up
+-- Full-text search index over record notes
+CREATE INDEX IF NOT EXISTS idx_mental_health_records_notes_tsv
+    ON mental_health_records USING GIN (to_tsvector('english', COALESCE(notes, '')));
+
+-- Level range filters are always scoped to a user
+CREATE INDEX IF NOT EXISTS idx_mental_health_records_user_id_energy_level
+    ON mental_health_records(user_id, energy_level);
+
+CREATE INDEX IF NOT EXISTS idx_mental_health_records_user_id_happy_level
+    ON mental_health_records(user_id, happy_level);
+
+COMMENT ON INDEX idx_mental_health_records_notes_tsv IS 'English tsvector over notes for full-text search';
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_mental_health_records_user_id_happy_level;
+DROP INDEX IF EXISTS idx_mental_health_records_user_id_energy_level;
+DROP INDEX IF EXISTS idx_mental_health_records_notes_tsv;
@@ -0,0 +1,42 @@
+-- +goose Up
+-- Create record_labels table, the private labels each user puts on their check-ins
+CREATE TABLE IF NOT EXISTS record_labels (
+    id SERIAL PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE(user_id, name)
+);
+
+-- Create record_tags junction table linking mental health records and their labels
+CREATE TABLE IF NOT EXISTS record_tags (
+    id SERIAL PRIMARY KEY,
+    record_id UUID NOT NULL REFERENCES mental_health_records(id) ON DELETE CASCADE,
+    label_id INTEGER NOT NULL REFERENCES record_labels(id) ON DELETE CASCADE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create indexes
+CREATE UNIQUE INDEX IF NOT EXISTS idx_record_tags_record_label ON record_tags(record_id, label_id);
+CREATE INDEX IF NOT EXISTS idx_record_tags_label_id ON record_tags(label_id);
+
+-- Add comments
+COMMENT ON TABLE record_labels IS 'Labels users put on their own check-ins, private to each user and separate from quote tags';
+COMMENT ON COLUMN record_labels.id IS 'Unique auto-increment identifier for the label';
+COMMENT ON COLUMN record_labels.user_id IS 'Owner of the label';
+COMMENT ON COLUMN record_labels.name IS 'Label name, unique per user';
+COMMENT ON COLUMN record_labels.created_at IS 'When the label was first used';
+COMMENT ON TABLE record_tags IS 'Junction table linking mental health records and their labels (many-to-many)';
+COMMENT ON COLUMN record_tags.id IS 'Unique auto-increment identifier for the record-label relationship';
+COMMENT ON COLUMN record_tags.record_id IS 'Reference to mental_health_records table';
+COMMENT ON COLUMN record_tags.label_id IS 'Reference to record_labels table';
+COMMENT ON COLUMN record_tags.created_at IS 'When the record-label relationship was created';
+
+-- +goose Down
+-- Drop indexes
+DROP INDEX IF EXISTS idx_record_tags_label_id;
+DROP INDEX IF EXISTS idx_record_tags_record_label;
+
+-- Drop tables
+DROP TABLE IF EXISTS record_tags;
+DROP TABLE IF EXISTS record_labels;
@@ -0,0 +1,15 @@
+-- +goose Up
+-- Allow users to hide their public records from the community feed
+ALTER TABLE users ADD COLUMN IF NOT EXISTS feed_opt_out BOOLEAN NOT NULL DEFAULT false;
+
+COMMENT ON COLUMN users.feed_opt_out IS 'Whether the user''s public records are hidden from the community feed';
+
+-- Feed reads the newest public records across all users
+CREATE INDEX IF NOT EXISTS idx_mental_health_records_public_created_at
+    ON mental_health_records(created_at DESC, id DESC)
+    WHERE status = 'public' AND deleted_at IS NULL;
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_mental_health_records_public_created_at;
+
+ALTER TABLE users DROP COLUMN IF EXISTS feed_opt_out;
@@ -0,0 +1,31 @@
+-- +goose Up
+-- Create password_reset_tokens table holding hashed, single-use reset tokens
+CREATE TABLE IF NOT EXISTS password_reset_tokens (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    token_hash VARCHAR(64) NOT NULL UNIQUE,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    used_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create indexes
+CREATE INDEX IF NOT EXISTS idx_password_reset_tokens_user_id ON password_reset_tokens(user_id);
+CREATE INDEX IF NOT EXISTS idx_password_reset_tokens_expires_at ON password_reset_tokens(expires_at);
+
+-- Add comments
+COMMENT ON TABLE password_reset_tokens IS 'Single-use, time-limited tokens for the password reset flow';
+COMMENT ON COLUMN password_reset_tokens.id IS 'Unique identifier for the reset token';
+COMMENT ON COLUMN password_reset_tokens.user_id IS 'Reference to users table';
+COMMENT ON COLUMN password_reset_tokens.token_hash IS 'SHA-256 hex digest of the token sent by email; the raw token is never stored';
+COMMENT ON COLUMN password_reset_tokens.expires_at IS 'When the token stops being accepted';
+COMMENT ON COLUMN password_reset_tokens.used_at IS 'When the token was consumed or invalidated, NULL while still usable';
+COMMENT ON COLUMN password_reset_tokens.created_at IS 'When the token was issued';
+
+-- +goose Down
+-- Drop indexes
+DROP INDEX IF EXISTS idx_password_reset_tokens_expires_at;
+DROP INDEX IF EXISTS idx_password_reset_tokens_user_id;
+
+-- Drop table
+DROP TABLE IF EXISTS password_reset_tokens;
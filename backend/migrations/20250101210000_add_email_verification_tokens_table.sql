@@ -0,0 +1,31 @@
+-- +goose Up
+-- Create email_verification_tokens table holding hashed, single-use verification tokens
+CREATE TABLE IF NOT EXISTS email_verification_tokens (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    token_hash VARCHAR(64) NOT NULL UNIQUE,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    used_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create indexes
+CREATE INDEX IF NOT EXISTS idx_email_verification_tokens_user_id_created_at ON email_verification_tokens(user_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_email_verification_tokens_expires_at ON email_verification_tokens(expires_at);
+
+-- Add comments
+COMMENT ON TABLE email_verification_tokens IS 'Single-use, time-limited tokens proving ownership of a user email address';
+COMMENT ON COLUMN email_verification_tokens.id IS 'Unique identifier for the verification token';
+COMMENT ON COLUMN email_verification_tokens.user_id IS 'Reference to users table';
+COMMENT ON COLUMN email_verification_tokens.token_hash IS 'SHA-256 hex digest of the token sent by email; the raw token is never stored';
+COMMENT ON COLUMN email_verification_tokens.expires_at IS 'When the token stops being accepted';
+COMMENT ON COLUMN email_verification_tokens.used_at IS 'When the token was consumed or invalidated, NULL while still usable';
+COMMENT ON COLUMN email_verification_tokens.created_at IS 'When the token was issued';
+
+-- +goose Down
+-- Drop indexes
+DROP INDEX IF EXISTS idx_email_verification_tokens_expires_at;
+DROP INDEX IF EXISTS idx_email_verification_tokens_user_id_created_at;
+
+-- Drop table
+DROP TABLE IF EXISTS email_verification_tokens;
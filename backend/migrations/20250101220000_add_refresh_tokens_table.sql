@@ -0,0 +1,37 @@
+-- +goose Up
+-- Create refresh_tokens table tracking rotated refresh tokens grouped by login family
+CREATE TABLE IF NOT EXISTS refresh_tokens (
+    id UUID PRIMARY KEY,
+    family_id UUID NOT NULL,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    device VARCHAR(255) NOT NULL DEFAULT '',
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    used_at TIMESTAMP WITH TIME ZONE,
+    revoked_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create indexes
+CREATE INDEX IF NOT EXISTS idx_refresh_tokens_family_id ON refresh_tokens(family_id);
+CREATE INDEX IF NOT EXISTS idx_refresh_tokens_user_id ON refresh_tokens(user_id);
+CREATE INDEX IF NOT EXISTS idx_refresh_tokens_expires_at ON refresh_tokens(expires_at);
+
+-- Add comments
+COMMENT ON TABLE refresh_tokens IS 'Server-side record of issued refresh tokens, used for rotation, reuse detection and logout';
+COMMENT ON COLUMN refresh_tokens.id IS 'Token identifier, carried as the jti claim of the refresh JWT';
+COMMENT ON COLUMN refresh_tokens.family_id IS 'Identifier shared by all tokens rotated from the same login';
+COMMENT ON COLUMN refresh_tokens.user_id IS 'Reference to users table';
+COMMENT ON COLUMN refresh_tokens.device IS 'Client description captured at login (User-Agent)';
+COMMENT ON COLUMN refresh_tokens.expires_at IS 'When the token stops being accepted';
+COMMENT ON COLUMN refresh_tokens.used_at IS 'When the token was rotated; presenting it again revokes the family';
+COMMENT ON COLUMN refresh_tokens.revoked_at IS 'When the token was revoked by logout or reuse detection';
+COMMENT ON COLUMN refresh_tokens.created_at IS 'When the token was issued';
+
+-- +goose Down
+-- Drop indexes
+DROP INDEX IF EXISTS idx_refresh_tokens_expires_at;
+DROP INDEX IF EXISTS idx_refresh_tokens_user_id;
+DROP INDEX IF EXISTS idx_refresh_tokens_family_id;
+
+-- Drop table
+DROP TABLE IF EXISTS refresh_tokens;
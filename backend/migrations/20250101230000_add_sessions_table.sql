@@ -0,0 +1,33 @@
+-- +goose Up
+-- Create sessions table with one row per login
+CREATE TABLE IF NOT EXISTS sessions (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    user_agent VARCHAR(255) NOT NULL DEFAULT '',
+    ip_address VARCHAR(64) NOT NULL DEFAULT '',
+    provider VARCHAR(20) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    last_used_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    revoked_at TIMESTAMP WITH TIME ZONE
+);
+
+-- Create indexes
+CREATE INDEX IF NOT EXISTS idx_sessions_user_id_active ON sessions(user_id, last_used_at DESC) WHERE revoked_at IS NULL;
+
+-- Add comments
+COMMENT ON TABLE sessions IS 'Logins of a user per device; revoking a session invalidates its access and refresh tokens';
+COMMENT ON COLUMN sessions.id IS 'Session identifier, shared with the refresh token family and carried as the sid claim';
+COMMENT ON COLUMN sessions.user_id IS 'Reference to users table';
+COMMENT ON COLUMN sessions.user_agent IS 'User-Agent of the client that logged in';
+COMMENT ON COLUMN sessions.ip_address IS 'Client IP address at login';
+COMMENT ON COLUMN sessions.provider IS 'How the user logged in (local, google)';
+COMMENT ON COLUMN sessions.created_at IS 'When the login happened';
+COMMENT ON COLUMN sessions.last_used_at IS 'Last time a token of this session was used';
+COMMENT ON COLUMN sessions.revoked_at IS 'When the session was ended by logout or revocation, NULL while active';
+
+-- +goose Down
+-- Drop indexes
+DROP INDEX IF EXISTS idx_sessions_user_id_active;
+
+-- Drop table
+DROP TABLE IF EXISTS sessions;
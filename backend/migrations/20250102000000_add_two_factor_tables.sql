@@ -0,0 +1,44 @@
+-- +goose Up
+-- Create totp_factors table holding one authenticator app enrollment per user
+CREATE TABLE IF NOT EXISTS totp_factors (
+    user_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    secret VARCHAR(64) NOT NULL,
+    confirmed_at TIMESTAMP WITH TIME ZONE,
+    last_used_step BIGINT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create recovery_codes table holding hashed single-use backup codes
+CREATE TABLE IF NOT EXISTS recovery_codes (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    code_hash VARCHAR(64) NOT NULL,
+    used_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create indexes
+CREATE INDEX IF NOT EXISTS idx_recovery_codes_user_id_code_hash ON recovery_codes(user_id, code_hash);
+
+-- Add comments
+COMMENT ON TABLE totp_factors IS 'TOTP (RFC 6238) second factor enrollments';
+COMMENT ON COLUMN totp_factors.user_id IS 'Reference to users table';
+COMMENT ON COLUMN totp_factors.secret IS 'Base32 shared secret; needed in clear to verify codes';
+COMMENT ON COLUMN totp_factors.confirmed_at IS 'When the enrollment was confirmed with a first code; NULL while pending';
+COMMENT ON COLUMN totp_factors.last_used_step IS 'Time step of the last accepted code, used to reject replays';
+COMMENT ON COLUMN totp_factors.created_at IS 'When the enrollment was started';
+
+COMMENT ON TABLE recovery_codes IS 'Single-use backup codes for two-factor login';
+COMMENT ON COLUMN recovery_codes.id IS 'Recovery code identifier';
+COMMENT ON COLUMN recovery_codes.user_id IS 'Reference to users table';
+COMMENT ON COLUMN recovery_codes.code_hash IS 'SHA-256 hex digest of the normalized code; the raw code is never stored';
+COMMENT ON COLUMN recovery_codes.used_at IS 'When the code was used to log in';
+COMMENT ON COLUMN recovery_codes.created_at IS 'When the code was generated';
+
+-- +goose Down
+-- Drop indexes
+DROP INDEX IF EXISTS idx_recovery_codes_user_id_code_hash;
+
+-- Drop tables
+DROP TABLE IF EXISTS recovery_codes;
+DROP TABLE IF EXISTS totp_factors;
@@ -0,0 +1,12 @@
+-- +goose Up
+-- Access level for management endpoints (quote and tag editing, user administration)
+ALTER TABLE users ADD COLUMN IF NOT EXISTS role VARCHAR(20) NOT NULL DEFAULT 'user';
+
+ALTER TABLE users ADD CONSTRAINT chk_users_role CHECK (role IN ('user', 'editor', 'admin'));
+
+COMMENT ON COLUMN users.role IS 'Access level: user, editor (manages quotes and tags) or admin (also manages user roles)';
+
+-- +goose Down
+ALTER TABLE users DROP CONSTRAINT IF EXISTS chk_users_role;
+
+ALTER TABLE users DROP COLUMN IF EXISTS role;
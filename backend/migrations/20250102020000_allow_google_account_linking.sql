@@ -0,0 +1,21 @@
+-- +goose Up
+-- Accounts created through Google have no password; store NULL instead of an unusable empty hash
+ALTER TABLE users ALTER COLUMN password_hash DROP NOT NULL;
+
+UPDATE users SET password_hash = NULL WHERE password_hash = '';
+
+COMMENT ON COLUMN users.password_hash IS 'Hashed user password, NULL for accounts that only log in through Google';
+
+-- A Google account can be linked to at most one user
+DROP INDEX IF EXISTS idx_users_google_id;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_google_id ON users(google_id) WHERE google_id IS NOT NULL;
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_users_google_id;
+CREATE INDEX IF NOT EXISTS idx_users_google_id ON users(google_id) WHERE google_id IS NOT NULL;
+
+UPDATE users SET password_hash = '' WHERE password_hash IS NULL;
+
+ALTER TABLE users ALTER COLUMN password_hash SET NOT NULL;
+
+COMMENT ON COLUMN users.password_hash IS 'Hashed user password';
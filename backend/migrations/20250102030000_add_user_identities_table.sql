@@ -0,0 +1,54 @@
+-- +goose Up
+-- Create user_identities table with one row per linked external login (Google, Keycloak, GitHub, ...)
+CREATE TABLE IF NOT EXISTS user_identities (
+    provider VARCHAR(20) NOT NULL,
+    subject VARCHAR(255) NOT NULL,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    email VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (provider, subject)
+);
+
+-- A user links at most one account per provider
+CREATE UNIQUE INDEX IF NOT EXISTS idx_user_identities_user_provider ON user_identities(user_id, provider);
+
+-- Add comments
+COMMENT ON TABLE user_identities IS 'External login provider accounts linked to users';
+COMMENT ON COLUMN user_identities.provider IS 'Configured provider name (google, keycloak, github, ...)';
+COMMENT ON COLUMN user_identities.subject IS 'Stable account ID at the provider (OIDC sub claim)';
+COMMENT ON COLUMN user_identities.user_id IS 'Reference to users table';
+COMMENT ON COLUMN user_identities.email IS 'Email reported by the provider when the account was linked';
+COMMENT ON COLUMN user_identities.created_at IS 'When the account was linked';
+
+-- Move linked Google accounts over from the users table
+INSERT INTO user_identities (provider, subject, user_id, email, created_at)
+SELECT 'google', google_id, id, email, created_at FROM users WHERE google_id IS NOT NULL
+ON CONFLICT DO NOTHING;
+
+DROP INDEX IF EXISTS idx_users_google_id;
+ALTER TABLE users DROP COLUMN IF EXISTS google_id;
+
+-- The avatar is no longer Google specific
+ALTER TABLE users RENAME COLUMN google_picture TO picture_url;
+COMMENT ON COLUMN users.picture_url IS 'Profile picture URL reported by the sign-up provider';
+COMMENT ON COLUMN users.auth_provider IS 'Provider the account signed up with: local or a configured external provider';
+COMMENT ON COLUMN users.password_hash IS 'Hashed user password, NULL for accounts that only log in through external providers';
+
+-- +goose Down
+ALTER TABLE users RENAME COLUMN picture_url TO google_picture;
+COMMENT ON COLUMN users.google_picture IS 'Google profile picture URL';
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS google_id VARCHAR(255);
+COMMENT ON COLUMN users.google_id IS 'Google OAuth user ID';
+
+UPDATE users SET google_id = user_identities.subject
+FROM user_identities
+WHERE user_identities.user_id = users.id AND user_identities.provider = 'google';
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_google_id ON users(google_id) WHERE google_id IS NOT NULL;
+
+-- Drop indexes
+DROP INDEX IF EXISTS idx_user_identities_user_provider;
+
+-- Drop table
+DROP TABLE IF EXISTS user_identities;
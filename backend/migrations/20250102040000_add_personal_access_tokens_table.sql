@@ -0,0 +1,33 @@
+-- +goose Up
+-- Create personal_access_tokens table holding hashed, scoped tokens for scripts and integrations
+CREATE TABLE IF NOT EXISTS personal_access_tokens (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    token_hash VARCHAR(64) NOT NULL UNIQUE,
+    scopes VARCHAR(255) NOT NULL,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    last_used_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create indexes
+CREATE INDEX IF NOT EXISTS idx_personal_access_tokens_user_id ON personal_access_tokens(user_id, created_at DESC);
+
+-- Add comments
+COMMENT ON TABLE personal_access_tokens IS 'Long-lived tokens users create for scripts and integrations, accepted as bearer credentials within their scopes';
+COMMENT ON COLUMN personal_access_tokens.id IS 'Unique identifier for the token';
+COMMENT ON COLUMN personal_access_tokens.user_id IS 'Reference to users table, the token acts as this user';
+COMMENT ON COLUMN personal_access_tokens.name IS 'Label given by the user to recognise the token';
+COMMENT ON COLUMN personal_access_tokens.token_hash IS 'SHA-256 hex digest of the secret after its peace_pat_ prefix; the raw token is never stored';
+COMMENT ON COLUMN personal_access_tokens.scopes IS 'Space separated scopes (records:read, records:write, quotes:write)';
+COMMENT ON COLUMN personal_access_tokens.expires_at IS 'When the token stops being accepted';
+COMMENT ON COLUMN personal_access_tokens.last_used_at IS 'Last time the token authenticated a request, NULL if never used';
+COMMENT ON COLUMN personal_access_tokens.created_at IS 'When the token was created';
+
+-- +goose Down
+-- Drop indexes
+DROP INDEX IF EXISTS idx_personal_access_tokens_user_id;
+
+-- Drop table
+DROP TABLE IF EXISTS personal_access_tokens;
@@ -0,0 +1,37 @@
+-- +goose Up
+-- Create data_exports table tracking archives of a user's data built in the background
+CREATE TABLE IF NOT EXISTS data_exports (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'processing', 'completed', 'failed')),
+    size_bytes BIGINT NOT NULL DEFAULT 0,
+    error VARCHAR(255),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    started_at TIMESTAMP WITH TIME ZONE,
+    completed_at TIMESTAMP WITH TIME ZONE,
+    expires_at TIMESTAMP WITH TIME ZONE
+);
+
+-- Create indexes
+CREATE INDEX IF NOT EXISTS idx_data_exports_user_id ON data_exports(user_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_data_exports_queue ON data_exports(status, created_at) WHERE status IN ('pending', 'processing');
+
+-- Add comments
+COMMENT ON TABLE data_exports IS 'Requests for a ZIP archive of all of a user''s data, built by a background worker';
+COMMENT ON COLUMN data_exports.id IS 'Unique identifier for the export, also names its archive file';
+COMMENT ON COLUMN data_exports.user_id IS 'Reference to users table, whose data is exported';
+COMMENT ON COLUMN data_exports.status IS 'pending until a worker claims it, then processing, completed or failed';
+COMMENT ON COLUMN data_exports.size_bytes IS 'Size of the finished archive';
+COMMENT ON COLUMN data_exports.error IS 'Why the archive could not be built, NULL unless failed';
+COMMENT ON COLUMN data_exports.created_at IS 'When the export was requested';
+COMMENT ON COLUMN data_exports.started_at IS 'When a worker claimed the export; processing exports started long ago are claimed again';
+COMMENT ON COLUMN data_exports.completed_at IS 'When the export completed or failed';
+COMMENT ON COLUMN data_exports.expires_at IS 'When the archive stops being downloadable';
+
+-- +goose Down
+-- Drop indexes
+DROP INDEX IF EXISTS idx_data_exports_queue;
+DROP INDEX IF EXISTS idx_data_exports_user_id;
+
+-- Drop table
+DROP TABLE IF EXISTS data_exports;
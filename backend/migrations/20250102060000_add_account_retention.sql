@@ -0,0 +1,41 @@
+-- +goose Up
+-- Mark accounts whose personal data was scrubbed by the retention job
+ALTER TABLE users ADD COLUMN IF NOT EXISTS anonymized_at TIMESTAMP WITH TIME ZONE;
+
+COMMENT ON COLUMN users.deleted_at IS 'When the user deleted the account; its data is purged or anonymized after the grace period';
+COMMENT ON COLUMN users.anonymized_at IS 'When the retention job replaced the account''s personal data with placeholders';
+
+-- Create account_retention_log table recording what the retention job removed
+CREATE TABLE IF NOT EXISTS account_retention_log (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL,
+    action VARCHAR(20) NOT NULL CHECK (action IN ('purge', 'anonymize')),
+    user_deleted_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    records_count INTEGER NOT NULL DEFAULT 0,
+    sessions_count INTEGER NOT NULL DEFAULT 0,
+    exports_count INTEGER NOT NULL DEFAULT 0,
+    processed_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create indexes
+CREATE INDEX IF NOT EXISTS idx_account_retention_log_processed_at ON account_retention_log(processed_at DESC);
+
+-- Add comments
+COMMENT ON TABLE account_retention_log IS 'Append-only record of deleted accounts purged or anonymized after the grace period; holds no personal data';
+COMMENT ON COLUMN account_retention_log.id IS 'Unique identifier for the log entry';
+COMMENT ON COLUMN account_retention_log.user_id IS 'ID of the processed account, not a foreign key as purged users no longer exist';
+COMMENT ON COLUMN account_retention_log.action IS 'purge (account and data deleted) or anonymize (personal data replaced, records kept without notes)';
+COMMENT ON COLUMN account_retention_log.user_deleted_at IS 'When the user deleted the account';
+COMMENT ON COLUMN account_retention_log.records_count IS 'Mental health records deleted or anonymized';
+COMMENT ON COLUMN account_retention_log.sessions_count IS 'Sessions deleted';
+COMMENT ON COLUMN account_retention_log.exports_count IS 'Data export archives deleted';
+COMMENT ON COLUMN account_retention_log.processed_at IS 'When the retention job processed the account';
+
+-- +goose Down
+-- Drop indexes
+DROP INDEX IF EXISTS idx_account_retention_log_processed_at;
+
+-- Drop table
+DROP TABLE IF EXISTS account_retention_log;
+
+ALTER TABLE users DROP COLUMN IF EXISTS anonymized_at;
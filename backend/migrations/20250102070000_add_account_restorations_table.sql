@@ -0,0 +1,29 @@
+-- +goose Up
+-- Create account_restorations table recording deleted accounts restored during their grace period
+CREATE TABLE IF NOT EXISTS account_restorations (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    user_deleted_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    restored_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    ip_address VARCHAR(64),
+    user_agent VARCHAR(255)
+);
+
+-- Create indexes
+CREATE INDEX IF NOT EXISTS idx_account_restorations_user_id ON account_restorations(user_id);
+
+-- Add comments
+COMMENT ON TABLE account_restorations IS 'Deleted accounts restored by their owner before the retention job processed them';
+COMMENT ON COLUMN account_restorations.id IS 'Unique identifier for the restoration';
+COMMENT ON COLUMN account_restorations.user_id IS 'ID of the restored account';
+COMMENT ON COLUMN account_restorations.user_deleted_at IS 'When the account had been deleted';
+COMMENT ON COLUMN account_restorations.restored_at IS 'When the account was restored';
+COMMENT ON COLUMN account_restorations.ip_address IS 'Client IP address of the restore request';
+COMMENT ON COLUMN account_restorations.user_agent IS 'User agent of the restore request';
+
+-- +goose Down
+-- Drop indexes
+DROP INDEX IF EXISTS idx_account_restorations_user_id;
+
+-- Drop table
+DROP TABLE IF EXISTS account_restorations;
@@ -0,0 +1,58 @@
+-- +goose Up
+-- Create audit_events table, an append-only log of security-relevant actions
+CREATE TABLE IF NOT EXISTS audit_events (
+    id UUID PRIMARY KEY,
+    actor_id UUID,
+    action VARCHAR(64) NOT NULL,
+    target_type VARCHAR(32),
+    target_id VARCHAR(64),
+    ip_address VARCHAR(64),
+    user_agent VARCHAR(255),
+    metadata JSONB NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create indexes
+CREATE INDEX IF NOT EXISTS idx_audit_events_created_at ON audit_events(created_at DESC, id DESC);
+CREATE INDEX IF NOT EXISTS idx_audit_events_actor_id ON audit_events(actor_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_audit_events_action ON audit_events(action, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_audit_events_target ON audit_events(target_type, target_id, created_at DESC);
+
+-- Reject changes to recorded events
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION audit_events_append_only() RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'audit_events is append-only';
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+CREATE TRIGGER audit_events_append_only
+    BEFORE UPDATE OR DELETE ON audit_events
+    FOR EACH ROW EXECUTE FUNCTION audit_events_append_only();
+
+-- Add comments
+COMMENT ON TABLE audit_events IS 'Append-only log of who did what: logins, password changes, account and content changes';
+COMMENT ON COLUMN audit_events.id IS 'Unique identifier for the event';
+COMMENT ON COLUMN audit_events.actor_id IS 'User who acted, NULL for anonymous callers; not a foreign key so events outlive purged accounts';
+COMMENT ON COLUMN audit_events.action IS 'What happened, as <subject>.<event> (auth.login, quote.deleted, ...)';
+COMMENT ON COLUMN audit_events.target_type IS 'Kind of object acted on (user, session, quote, tag)';
+COMMENT ON COLUMN audit_events.target_id IS 'ID of the object acted on';
+COMMENT ON COLUMN audit_events.ip_address IS 'Client IP address of the request';
+COMMENT ON COLUMN audit_events.user_agent IS 'User agent of the request';
+COMMENT ON COLUMN audit_events.metadata IS 'Action-specific details, such as the reason of a failed login';
+COMMENT ON COLUMN audit_events.created_at IS 'When the action happened';
+
+-- +goose Down
+-- Drop trigger
+DROP TRIGGER IF EXISTS audit_events_append_only ON audit_events;
+DROP FUNCTION IF EXISTS audit_events_append_only();
+
+-- Drop indexes
+DROP INDEX IF EXISTS idx_audit_events_target;
+DROP INDEX IF EXISTS idx_audit_events_action;
+DROP INDEX IF EXISTS idx_audit_events_actor_id;
+DROP INDEX IF EXISTS idx_audit_events_created_at;
+
+-- Drop table
+DROP TABLE IF EXISTS audit_events;
@@ -0,0 +1,9 @@
+-- +goose Up
+-- Cover the keyset pagination orders of the quote listing
+CREATE INDEX IF NOT EXISTS idx_quotes_listing_created_at ON quotes(created_at, id) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_quotes_listing_author ON quotes(author, id) WHERE deleted_at IS NULL;
+
+-- +goose Down
+-- Drop indexes
+DROP INDEX IF EXISTS idx_quotes_listing_author;
+DROP INDEX IF EXISTS idx_quotes_listing_created_at;
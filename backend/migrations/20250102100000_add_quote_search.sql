@@ -0,0 +1,27 @@
+-- +goose Up
+-- Trigram matching for fuzzy author search
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+-- Full-text document of each quote: content ranks above author
+ALTER TABLE quotes ADD COLUMN IF NOT EXISTS search_vector TSVECTOR
+    GENERATED ALWAYS AS (
+        setweight(to_tsvector('english', COALESCE(content, '')), 'A') ||
+        setweight(to_tsvector('simple', COALESCE(author, '')), 'B')
+    ) STORED;
+
+-- Create indexes
+CREATE INDEX IF NOT EXISTS idx_quotes_search_vector ON quotes USING GIN (search_vector);
+CREATE INDEX IF NOT EXISTS idx_quotes_author_trgm ON quotes USING GIN (author gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS idx_quotes_content_trgm ON quotes USING GIN (content gin_trgm_ops);
+
+-- Add comments
+COMMENT ON COLUMN quotes.search_vector IS 'Full-text document of the quote (content weighted A, author B), maintained by Postgres';
+
+-- +goose Down
+-- Drop indexes
+DROP INDEX IF EXISTS idx_quotes_content_trgm;
+DROP INDEX IF EXISTS idx_quotes_author_trgm;
+DROP INDEX IF EXISTS idx_quotes_search_vector;
+
+-- Drop column; pg_trgm stays installed as other schemas may use it
+ALTER TABLE quotes DROP COLUMN IF EXISTS search_vector;
@@ -0,0 +1,29 @@
+-- +goose Up
+-- Create daily_quotes table, the history of quotes of the day
+CREATE TABLE IF NOT EXISTS daily_quotes (
+    id SERIAL PRIMARY KEY,
+    user_id UUID REFERENCES users(id) ON DELETE CASCADE,
+    day DATE NOT NULL,
+    quote_id INTEGER NOT NULL REFERENCES quotes(id) ON DELETE CASCADE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create indexes; one quote per user and day, and one global quote per day
+CREATE UNIQUE INDEX IF NOT EXISTS idx_daily_quotes_user_day ON daily_quotes(user_id, day) WHERE user_id IS NOT NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_daily_quotes_global_day ON daily_quotes(day) WHERE user_id IS NULL;
+
+-- Add comments
+COMMENT ON TABLE daily_quotes IS 'Quote of the day picked for each user and local day, kept to avoid repeats';
+COMMENT ON COLUMN daily_quotes.id IS 'Unique auto-increment identifier for the pick';
+COMMENT ON COLUMN daily_quotes.user_id IS 'User the quote was picked for, NULL for the global quote of anonymous visitors';
+COMMENT ON COLUMN daily_quotes.day IS 'Local date of the user (UTC for the global quote)';
+COMMENT ON COLUMN daily_quotes.quote_id IS 'Reference to quotes table';
+COMMENT ON COLUMN daily_quotes.created_at IS 'When the quote was picked';
+
+-- +goose Down
+-- Drop indexes
+DROP INDEX IF EXISTS idx_daily_quotes_global_day;
+DROP INDEX IF EXISTS idx_daily_quotes_user_day;
+
+-- Drop table
+DROP TABLE IF EXISTS daily_quotes;
@@ -0,0 +1,28 @@
+-- +goose Up
+-- Create quote_feedback table, the likes and skips of recommended quotes
+CREATE TABLE IF NOT EXISTS quote_feedback (
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    quote_id INTEGER NOT NULL REFERENCES quotes(id) ON DELETE CASCADE,
+    signal VARCHAR(16) NOT NULL CHECK (signal IN ('like', 'skip')),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, quote_id)
+);
+
+-- Create indexes
+CREATE INDEX IF NOT EXISTS idx_quote_feedback_user_updated_at ON quote_feedback(user_id, updated_at DESC);
+
+-- Add comments
+COMMENT ON TABLE quote_feedback IS 'Latest reaction of each user to each recommended quote';
+COMMENT ON COLUMN quote_feedback.user_id IS 'Reference to users table';
+COMMENT ON COLUMN quote_feedback.quote_id IS 'Reference to quotes table';
+COMMENT ON COLUMN quote_feedback.signal IS 'like or skip; a new signal replaces the previous one';
+COMMENT ON COLUMN quote_feedback.created_at IS 'When the user first reacted to the quote';
+COMMENT ON COLUMN quote_feedback.updated_at IS 'When the signal was last set';
+
+-- +goose Down
+-- Drop indexes
+DROP INDEX IF EXISTS idx_quote_feedback_user_updated_at;
+
+-- Drop table
+DROP TABLE IF EXISTS quote_feedback;
@@ -0,0 +1,8 @@
+-- +goose Up
+-- Version counter for optimistic locking, so concurrent updates of a user cannot overwrite each other
+ALTER TABLE users ADD COLUMN IF NOT EXISTS version INTEGER NOT NULL DEFAULT 0;
+
+COMMENT ON COLUMN users.version IS 'Incremented on every update; an update only applies when the version it read is still current';
+
+-- +goose Down
+ALTER TABLE users DROP COLUMN IF EXISTS version;
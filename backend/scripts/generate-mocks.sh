@@ -33,9 +33,57 @@ echo "✅ Generated repositories/quote_repository_mock.go"
 mockgen -source=internal/domain/repositories/tag_repository.go -destination=testutils/mocks/repositories/tag_repository_mock.go
 echo "✅ Generated repositories/tag_repository_mock.go"
 
+mockgen -source=internal/domain/repositories/refresh_token_repository.go -destination=testutils/mocks/repositories/refresh_token_repository_mock.go
+echo "✅ Generated repositories/refresh_token_repository_mock.go"
+
+mockgen -source=internal/domain/repositories/password_reset_token_repository.go -destination=testutils/mocks/repositories/password_reset_token_repository_mock.go
+echo "✅ Generated repositories/password_reset_token_repository_mock.go"
+
+mockgen -source=internal/domain/repositories/email_verification_token_repository.go -destination=testutils/mocks/repositories/email_verification_token_repository_mock.go
+echo "✅ Generated repositories/email_verification_token_repository_mock.go"
+
+mockgen -source=internal/domain/repositories/session_repository.go -destination=testutils/mocks/repositories/session_repository_mock.go
+echo "✅ Generated repositories/session_repository_mock.go"
+
+mockgen -source=internal/domain/repositories/totp_factor_repository.go -destination=testutils/mocks/repositories/totp_factor_repository_mock.go
+echo "✅ Generated repositories/totp_factor_repository_mock.go"
+
+mockgen -source=internal/domain/repositories/recovery_code_repository.go -destination=testutils/mocks/repositories/recovery_code_repository_mock.go
+echo "✅ Generated repositories/recovery_code_repository_mock.go"
+
+mockgen -source=internal/domain/repositories/user_identity_repository.go -destination=testutils/mocks/repositories/user_identity_repository_mock.go
+echo "✅ Generated repositories/user_identity_repository_mock.go"
+
+mockgen -source=internal/domain/repositories/personal_access_token_repository.go -destination=testutils/mocks/repositories/personal_access_token_repository_mock.go
+echo "✅ Generated repositories/personal_access_token_repository_mock.go"
+
+mockgen -source=internal/domain/repositories/data_export_repository.go -destination=testutils/mocks/repositories/data_export_repository_mock.go
+echo "✅ Generated repositories/data_export_repository_mock.go"
+
+mockgen -source=internal/domain/repositories/retention_repository.go -destination=testutils/mocks/repositories/retention_repository_mock.go
+echo "✅ Generated repositories/retention_repository_mock.go"
+
+mockgen -source=internal/domain/repositories/audit_event_repository.go -destination=testutils/mocks/repositories/audit_event_repository_mock.go
+echo "✅ Generated repositories/audit_event_repository_mock.go"
+
+mockgen -source=internal/domain/repositories/daily_quote_repository.go -destination=testutils/mocks/repositories/daily_quote_repository_mock.go
+echo "✅ Generated repositories/daily_quote_repository_mock.go"
+
+mockgen -source=internal/domain/repositories/quote_feedback_repository.go -destination=testutils/mocks/repositories/quote_feedback_repository_mock.go
+echo "✅ Generated repositories/quote_feedback_repository_mock.go"
+
 mockgen -source=internal/domain/repositories/user_online_status_repository.go -destination=testutils/mocks/repositories/user_online_status_repository_mock.go
 echo "✅ Generated repositories/user_online_status_repository_mock.go"
 
+echo "📁 Generating service mocks..."
+
+# Generate service mocks
+mockgen -source=internal/application/services/cache/cache.go -destination=testutils/mocks/services/cache_mock.go -package=mock_services
+echo "✅ Generated services/cache_mock.go"
+
+mockgen -source=internal/application/services/filestore/filestore.go -destination=testutils/mocks/services/filestore_mock.go -package=mock_services
+echo "✅ Generated services/filestore_mock.go"
+
 echo "📁 Generating use case mocks..."
 
 # Generate use case mocks
@@ -54,5 +102,35 @@ echo "✅ Generated usecases/quote_usecase_mock.go"
 mockgen -source=internal/application/usecases/tag_usecase.go -destination=testutils/mocks/usecases/tag_usecase_mock.go
 echo "✅ Generated usecases/tag_usecase_mock.go"
 
+mockgen -source=internal/application/usecases/feed_usecase.go -destination=testutils/mocks/usecases/feed_usecase_mock.go
+echo "✅ Generated usecases/feed_usecase_mock.go"
+
+mockgen -source=internal/application/usecases/session_usecase.go -destination=testutils/mocks/usecases/session_usecase_mock.go
+echo "✅ Generated usecases/session_usecase_mock.go"
+
+mockgen -source=internal/application/usecases/mfa_usecase.go -destination=testutils/mocks/usecases/mfa_usecase_mock.go
+echo "✅ Generated usecases/mfa_usecase_mock.go"
+
+mockgen -source=internal/application/usecases/account_link_usecase.go -destination=testutils/mocks/usecases/account_link_usecase_mock.go
+echo "✅ Generated usecases/account_link_usecase_mock.go"
+
+mockgen -source=internal/application/usecases/personal_access_token_usecase.go -destination=testutils/mocks/usecases/personal_access_token_usecase_mock.go
+echo "✅ Generated usecases/personal_access_token_usecase_mock.go"
+
+mockgen -source=internal/application/usecases/data_export_usecase.go -destination=testutils/mocks/usecases/data_export_usecase_mock.go
+echo "✅ Generated usecases/data_export_usecase_mock.go"
+
+mockgen -source=internal/application/usecases/retention_usecase.go -destination=testutils/mocks/usecases/retention_usecase_mock.go
+echo "✅ Generated usecases/retention_usecase_mock.go"
+
+mockgen -source=internal/application/usecases/audit_usecase.go -destination=testutils/mocks/usecases/audit_usecase_mock.go
+echo "✅ Generated usecases/audit_usecase_mock.go"
+
+mockgen -source=internal/application/usecases/daily_quote_usecase.go -destination=testutils/mocks/usecases/daily_quote_usecase_mock.go
+echo "✅ Generated usecases/daily_quote_usecase_mock.go"
+
+mockgen -source=internal/application/usecases/recommendation_usecase.go -destination=testutils/mocks/usecases/recommendation_usecase_mock.go
+echo "✅ Generated usecases/recommendation_usecase_mock.go"
+
 mockgen -source=internal/application/usecases/user_online_status_usecase.go -destination=testutils/mocks/usecases/user_online_status_usecase_mock.go
 echo "✅ Generated usecases/user_online_status_usecase_mock.go"
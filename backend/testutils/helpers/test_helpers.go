@@ -36,11 +36,11 @@ func CreateTestUser() *entities.User {
 
 // CreateTestGoogleUser creates a test Google user
 func CreateTestGoogleUser() *entities.User {
-	user, _ := entities.NewGoogleUser(
+	user, _ := entities.NewExternalUser(
 		"google@example.com",
 		StringPtr("Google"),
 		StringPtr("User"),
-		"google123",
+		"google",
 		StringPtr("https://example.com/avatar.jpg"),
 	)
 	return user
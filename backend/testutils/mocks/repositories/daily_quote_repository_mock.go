@@ -0,0 +1,88 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/domain/repositories/daily_quote_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/domain/repositories/daily_quote_repository.go -destination=testutils/mocks/repositories/daily_quote_repository_mock.go
+//
+
+// Package mock_repositories is a generated GoMock package.
+package mock_repositories
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	entities "github.com/atdevten/peace/internal/domain/entities"
+	value_objects "github.com/atdevten/peace/internal/domain/value_objects"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockDailyQuoteRepository is a mock of DailyQuoteRepository interface.
+type MockDailyQuoteRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockDailyQuoteRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockDailyQuoteRepositoryMockRecorder is the mock recorder for MockDailyQuoteRepository.
+type MockDailyQuoteRepositoryMockRecorder struct {
+	mock *MockDailyQuoteRepository
+}
+
+// NewMockDailyQuoteRepository creates a new mock instance.
+func NewMockDailyQuoteRepository(ctrl *gomock.Controller) *MockDailyQuoteRepository {
+	mock := &MockDailyQuoteRepository{ctrl: ctrl}
+	mock.recorder = &MockDailyQuoteRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDailyQuoteRepository) EXPECT() *MockDailyQuoteRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetByDay mocks base method.
+func (m *MockDailyQuoteRepository) GetByDay(ctx context.Context, userID *value_objects.UserID, day time.Time) (*entities.DailyQuote, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByDay", ctx, userID, day)
+	ret0, _ := ret[0].(*entities.DailyQuote)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByDay indicates an expected call of GetByDay.
+func (mr *MockDailyQuoteRepositoryMockRecorder) GetByDay(ctx, userID, day any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByDay", reflect.TypeOf((*MockDailyQuoteRepository)(nil).GetByDay), ctx, userID, day)
+}
+
+// ListQuoteIDsSince mocks base method.
+func (m *MockDailyQuoteRepository) ListQuoteIDsSince(ctx context.Context, userID *value_objects.UserID, since time.Time) ([]*value_objects.QuoteID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListQuoteIDsSince", ctx, userID, since)
+	ret0, _ := ret[0].([]*value_objects.QuoteID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListQuoteIDsSince indicates an expected call of ListQuoteIDsSince.
+func (mr *MockDailyQuoteRepositoryMockRecorder) ListQuoteIDsSince(ctx, userID, since any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListQuoteIDsSince", reflect.TypeOf((*MockDailyQuoteRepository)(nil).ListQuoteIDsSince), ctx, userID, since)
+}
+
+// Save mocks base method.
+func (m *MockDailyQuoteRepository) Save(ctx context.Context, dailyQuote *entities.DailyQuote) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, dailyQuote)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockDailyQuoteRepositoryMockRecorder) Save(ctx, dailyQuote any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockDailyQuoteRepository)(nil).Save), ctx, dailyQuote)
+}
@@ -0,0 +1,115 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/domain/repositories/email_verification_token_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/domain/repositories/email_verification_token_repository.go -destination=testutils/mocks/repositories/email_verification_token_repository_mock.go
+//
+
+// Package mock_repositories is a generated GoMock package.
+package mock_repositories
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entities "github.com/atdevten/peace/internal/domain/entities"
+	value_objects "github.com/atdevten/peace/internal/domain/value_objects"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockEmailVerificationTokenRepository is a mock of EmailVerificationTokenRepository interface.
+type MockEmailVerificationTokenRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockEmailVerificationTokenRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockEmailVerificationTokenRepositoryMockRecorder is the mock recorder for MockEmailVerificationTokenRepository.
+type MockEmailVerificationTokenRepositoryMockRecorder struct {
+	mock *MockEmailVerificationTokenRepository
+}
+
+// NewMockEmailVerificationTokenRepository creates a new mock instance.
+func NewMockEmailVerificationTokenRepository(ctrl *gomock.Controller) *MockEmailVerificationTokenRepository {
+	mock := &MockEmailVerificationTokenRepository{ctrl: ctrl}
+	mock.recorder = &MockEmailVerificationTokenRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEmailVerificationTokenRepository) EXPECT() *MockEmailVerificationTokenRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockEmailVerificationTokenRepository) Create(ctx context.Context, token *entities.EmailVerificationToken) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, token)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockEmailVerificationTokenRepositoryMockRecorder) Create(ctx, token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockEmailVerificationTokenRepository)(nil).Create), ctx, token)
+}
+
+// GetByTokenHash mocks base method.
+func (m *MockEmailVerificationTokenRepository) GetByTokenHash(ctx context.Context, tokenHash string) (*entities.EmailVerificationToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByTokenHash", ctx, tokenHash)
+	ret0, _ := ret[0].(*entities.EmailVerificationToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByTokenHash indicates an expected call of GetByTokenHash.
+func (mr *MockEmailVerificationTokenRepositoryMockRecorder) GetByTokenHash(ctx, tokenHash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByTokenHash", reflect.TypeOf((*MockEmailVerificationTokenRepository)(nil).GetByTokenHash), ctx, tokenHash)
+}
+
+// GetLatestByUserID mocks base method.
+func (m *MockEmailVerificationTokenRepository) GetLatestByUserID(ctx context.Context, userID *value_objects.UserID) (*entities.EmailVerificationToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLatestByUserID", ctx, userID)
+	ret0, _ := ret[0].(*entities.EmailVerificationToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLatestByUserID indicates an expected call of GetLatestByUserID.
+func (mr *MockEmailVerificationTokenRepositoryMockRecorder) GetLatestByUserID(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLatestByUserID", reflect.TypeOf((*MockEmailVerificationTokenRepository)(nil).GetLatestByUserID), ctx, userID)
+}
+
+// InvalidateByUserID mocks base method.
+func (m *MockEmailVerificationTokenRepository) InvalidateByUserID(ctx context.Context, userID *value_objects.UserID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InvalidateByUserID", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InvalidateByUserID indicates an expected call of InvalidateByUserID.
+func (mr *MockEmailVerificationTokenRepositoryMockRecorder) InvalidateByUserID(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InvalidateByUserID", reflect.TypeOf((*MockEmailVerificationTokenRepository)(nil).InvalidateByUserID), ctx, userID)
+}
+
+// MarkUsed mocks base method.
+func (m *MockEmailVerificationTokenRepository) MarkUsed(ctx context.Context, token *entities.EmailVerificationToken) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkUsed", ctx, token)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkUsed indicates an expected call of MarkUsed.
+func (mr *MockEmailVerificationTokenRepositoryMockRecorder) MarkUsed(ctx, token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkUsed", reflect.TypeOf((*MockEmailVerificationTokenRepository)(nil).MarkUsed), ctx, token)
+}
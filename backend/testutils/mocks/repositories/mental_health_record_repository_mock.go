@@ -12,6 +12,7 @@ package mock_repositories
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	entities "github.com/atdevten/peace/internal/domain/entities"
 	repositories "github.com/atdevten/peace/internal/domain/repositories"
@@ -86,6 +87,21 @@ func (mr *MockMentalHealthRecordRepositoryMockRecorder) GetAll(ctx any) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockMentalHealthRecordRepository)(nil).GetAll), ctx)
 }
 
+// GetAnalytics mocks base method.
+func (m *MockMentalHealthRecordRepository) GetAnalytics(ctx context.Context, filter *repositories.MentalHealthRecordAnalyticsFilter) (*repositories.MentalHealthRecordAnalytics, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAnalytics", ctx, filter)
+	ret0, _ := ret[0].(*repositories.MentalHealthRecordAnalytics)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAnalytics indicates an expected call of GetAnalytics.
+func (mr *MockMentalHealthRecordRepositoryMockRecorder) GetAnalytics(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAnalytics", reflect.TypeOf((*MockMentalHealthRecordRepository)(nil).GetAnalytics), ctx, filter)
+}
+
 // GetByFilter mocks base method.
 func (m *MockMentalHealthRecordRepository) GetByFilter(ctx context.Context, filter *repositories.MentalHealthRecordFilter) ([]*entities.MentalHealthRecord, error) {
 	m.ctrl.T.Helper()
@@ -117,18 +133,63 @@ func (mr *MockMentalHealthRecordRepositoryMockRecorder) GetByID(ctx, id any) *go
 }
 
 // GetDistinctDatesForUser mocks base method.
-func (m *MockMentalHealthRecordRepository) GetDistinctDatesForUser(ctx context.Context, userID *value_objects.UserID) ([]string, error) {
+func (m *MockMentalHealthRecordRepository) GetDistinctDatesForUser(ctx context.Context, userID *value_objects.UserID, location *time.Location) ([]string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetDistinctDatesForUser", ctx, userID)
+	ret := m.ctrl.Call(m, "GetDistinctDatesForUser", ctx, userID, location)
 	ret0, _ := ret[0].([]string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetDistinctDatesForUser indicates an expected call of GetDistinctDatesForUser.
-func (mr *MockMentalHealthRecordRepositoryMockRecorder) GetDistinctDatesForUser(ctx, userID any) *gomock.Call {
+func (mr *MockMentalHealthRecordRepositoryMockRecorder) GetDistinctDatesForUser(ctx, userID, location any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDistinctDatesForUser", reflect.TypeOf((*MockMentalHealthRecordRepository)(nil).GetDistinctDatesForUser), ctx, userID, location)
+}
+
+// GetPublicFeed mocks base method.
+func (m *MockMentalHealthRecordRepository) GetPublicFeed(ctx context.Context, filter *repositories.PublicFeedFilter) ([]*repositories.PublicFeedEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPublicFeed", ctx, filter)
+	ret0, _ := ret[0].([]*repositories.PublicFeedEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPublicFeed indicates an expected call of GetPublicFeed.
+func (mr *MockMentalHealthRecordRepositoryMockRecorder) GetPublicFeed(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPublicFeed", reflect.TypeOf((*MockMentalHealthRecordRepository)(nil).GetPublicFeed), ctx, filter)
+}
+
+// GetTagMoodAverages mocks base method.
+func (m *MockMentalHealthRecordRepository) GetTagMoodAverages(ctx context.Context, filter *repositories.MentalHealthRecordTagReportFilter) ([]*repositories.TagMoodAverage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTagMoodAverages", ctx, filter)
+	ret0, _ := ret[0].([]*repositories.TagMoodAverage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTagMoodAverages indicates an expected call of GetTagMoodAverages.
+func (mr *MockMentalHealthRecordRepositoryMockRecorder) GetTagMoodAverages(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTagMoodAverages", reflect.TypeOf((*MockMentalHealthRecordRepository)(nil).GetTagMoodAverages), ctx, filter)
+}
+
+// GetTagsByRecordIDs mocks base method.
+func (m *MockMentalHealthRecordRepository) GetTagsByRecordIDs(ctx context.Context, recordIDs []*value_objects.MentalHealthRecordID) (map[string][]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTagsByRecordIDs", ctx, recordIDs)
+	ret0, _ := ret[0].(map[string][]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTagsByRecordIDs indicates an expected call of GetTagsByRecordIDs.
+func (mr *MockMentalHealthRecordRepositoryMockRecorder) GetTagsByRecordIDs(ctx, recordIDs any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDistinctDatesForUser", reflect.TypeOf((*MockMentalHealthRecordRepository)(nil).GetDistinctDatesForUser), ctx, userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTagsByRecordIDs", reflect.TypeOf((*MockMentalHealthRecordRepository)(nil).GetTagsByRecordIDs), ctx, recordIDs)
 }
 
 // Update mocks base method.
@@ -0,0 +1,72 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/domain/repositories/quote_feedback_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/domain/repositories/quote_feedback_repository.go -destination=testutils/mocks/repositories/quote_feedback_repository_mock.go
+//
+
+// Package mock_repositories is a generated GoMock package.
+package mock_repositories
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entities "github.com/atdevten/peace/internal/domain/entities"
+	value_objects "github.com/atdevten/peace/internal/domain/value_objects"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockQuoteFeedbackRepository is a mock of QuoteFeedbackRepository interface.
+type MockQuoteFeedbackRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockQuoteFeedbackRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockQuoteFeedbackRepositoryMockRecorder is the mock recorder for MockQuoteFeedbackRepository.
+type MockQuoteFeedbackRepositoryMockRecorder struct {
+	mock *MockQuoteFeedbackRepository
+}
+
+// NewMockQuoteFeedbackRepository creates a new mock instance.
+func NewMockQuoteFeedbackRepository(ctrl *gomock.Controller) *MockQuoteFeedbackRepository {
+	mock := &MockQuoteFeedbackRepository{ctrl: ctrl}
+	mock.recorder = &MockQuoteFeedbackRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockQuoteFeedbackRepository) EXPECT() *MockQuoteFeedbackRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ListByUser mocks base method.
+func (m *MockQuoteFeedbackRepository) ListByUser(ctx context.Context, userID *value_objects.UserID) ([]*entities.QuoteFeedback, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByUser", ctx, userID)
+	ret0, _ := ret[0].([]*entities.QuoteFeedback)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByUser indicates an expected call of ListByUser.
+func (mr *MockQuoteFeedbackRepositoryMockRecorder) ListByUser(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByUser", reflect.TypeOf((*MockQuoteFeedbackRepository)(nil).ListByUser), ctx, userID)
+}
+
+// Save mocks base method.
+func (m *MockQuoteFeedbackRepository) Save(ctx context.Context, feedback *entities.QuoteFeedback) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, feedback)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockQuoteFeedbackRepositoryMockRecorder) Save(ctx, feedback any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockQuoteFeedbackRepository)(nil).Save), ctx, feedback)
+}
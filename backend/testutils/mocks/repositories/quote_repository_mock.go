@@ -43,6 +43,21 @@ func (m *MockQuoteRepository) EXPECT() *MockQuoteRepositoryMockRecorder {
 	return m.recorder
 }
 
+// Count mocks base method.
+func (m *MockQuoteRepository) Count(ctx context.Context, filter *repositories.QuoteFilter) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Count", ctx, filter)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Count indicates an expected call of Count.
+func (mr *MockQuoteRepositoryMockRecorder) Count(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Count", reflect.TypeOf((*MockQuoteRepository)(nil).Count), ctx, filter)
+}
+
 // Create mocks base method.
 func (m *MockQuoteRepository) Create(ctx context.Context, quote *entities.Quote) error {
 	m.ctrl.T.Helper()
@@ -71,21 +86,6 @@ func (mr *MockQuoteRepositoryMockRecorder) Delete(ctx, id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockQuoteRepository)(nil).Delete), ctx, id)
 }
 
-// GetAll mocks base method.
-func (m *MockQuoteRepository) GetAll(ctx context.Context) ([]*entities.Quote, error) {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAll", ctx)
-	ret0, _ := ret[0].([]*entities.Quote)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
-}
-
-// GetAll indicates an expected call of GetAll.
-func (mr *MockQuoteRepositoryMockRecorder) GetAll(ctx any) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockQuoteRepository)(nil).GetAll), ctx)
-}
-
 // GetByFilter mocks base method.
 func (m *MockQuoteRepository) GetByFilter(ctx context.Context, filter *repositories.QuoteFilter) ([]*entities.Quote, error) {
 	m.ctrl.T.Helper()
@@ -131,6 +131,21 @@ func (mr *MockQuoteRepositoryMockRecorder) GetRandom(ctx any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRandom", reflect.TypeOf((*MockQuoteRepository)(nil).GetRandom), ctx)
 }
 
+// Search mocks base method.
+func (m *MockQuoteRepository) Search(ctx context.Context, search *repositories.QuoteSearch) (*repositories.QuoteSearchResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", ctx, search)
+	ret0, _ := ret[0].(*repositories.QuoteSearchResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Search indicates an expected call of Search.
+func (mr *MockQuoteRepositoryMockRecorder) Search(ctx, search any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockQuoteRepository)(nil).Search), ctx, search)
+}
+
 // Update mocks base method.
 func (m *MockQuoteRepository) Update(ctx context.Context, quote *entities.Quote) error {
 	m.ctrl.T.Helper()
@@ -0,0 +1,116 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/domain/repositories/retention_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/domain/repositories/retention_repository.go -destination=testutils/mocks/repositories/retention_repository_mock.go
+//
+
+// Package mock_repositories is a generated GoMock package.
+package mock_repositories
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entities "github.com/atdevten/peace/internal/domain/entities"
+	repositories "github.com/atdevten/peace/internal/domain/repositories"
+	value_objects "github.com/atdevten/peace/internal/domain/value_objects"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRetentionRepository is a mock of RetentionRepository interface.
+type MockRetentionRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRetentionRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockRetentionRepositoryMockRecorder is the mock recorder for MockRetentionRepository.
+type MockRetentionRepositoryMockRecorder struct {
+	mock *MockRetentionRepository
+}
+
+// NewMockRetentionRepository creates a new mock instance.
+func NewMockRetentionRepository(ctrl *gomock.Controller) *MockRetentionRepository {
+	mock := &MockRetentionRepository{ctrl: ctrl}
+	mock.recorder = &MockRetentionRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRetentionRepository) EXPECT() *MockRetentionRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AnonymizeUser mocks base method.
+func (m *MockRetentionRepository) AnonymizeUser(ctx context.Context, entry *repositories.RetentionLogEntry) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AnonymizeUser", ctx, entry)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AnonymizeUser indicates an expected call of AnonymizeUser.
+func (mr *MockRetentionRepositoryMockRecorder) AnonymizeUser(ctx, entry any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AnonymizeUser", reflect.TypeOf((*MockRetentionRepository)(nil).AnonymizeUser), ctx, entry)
+}
+
+// CountUserData mocks base method.
+func (m *MockRetentionRepository) CountUserData(ctx context.Context, userID *value_objects.UserID) (*repositories.UserDataCounts, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountUserData", ctx, userID)
+	ret0, _ := ret[0].(*repositories.UserDataCounts)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountUserData indicates an expected call of CountUserData.
+func (mr *MockRetentionRepositoryMockRecorder) CountUserData(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountUserData", reflect.TypeOf((*MockRetentionRepository)(nil).CountUserData), ctx, userID)
+}
+
+// ListDeletedUsers mocks base method.
+func (m *MockRetentionRepository) ListDeletedUsers(ctx context.Context, filter *repositories.DeletedUserFilter) ([]*entities.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDeletedUsers", ctx, filter)
+	ret0, _ := ret[0].([]*entities.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDeletedUsers indicates an expected call of ListDeletedUsers.
+func (mr *MockRetentionRepositoryMockRecorder) ListDeletedUsers(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDeletedUsers", reflect.TypeOf((*MockRetentionRepository)(nil).ListDeletedUsers), ctx, filter)
+}
+
+// PurgeUser mocks base method.
+func (m *MockRetentionRepository) PurgeUser(ctx context.Context, entry *repositories.RetentionLogEntry) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeUser", ctx, entry)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PurgeUser indicates an expected call of PurgeUser.
+func (mr *MockRetentionRepositoryMockRecorder) PurgeUser(ctx, entry any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeUser", reflect.TypeOf((*MockRetentionRepository)(nil).PurgeUser), ctx, entry)
+}
+
+// RestoreUser mocks base method.
+func (m *MockRetentionRepository) RestoreUser(ctx context.Context, restoration *repositories.AccountRestoration) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreUser", ctx, restoration)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreUser indicates an expected call of RestoreUser.
+func (mr *MockRetentionRepositoryMockRecorder) RestoreUser(ctx, restoration any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreUser", reflect.TypeOf((*MockRetentionRepository)(nil).RestoreUser), ctx, restoration)
+}
@@ -0,0 +1,114 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/domain/repositories/totp_factor_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/domain/repositories/totp_factor_repository.go -destination=testutils/mocks/repositories/totp_factor_repository_mock.go
+//
+
+// Package mock_repositories is a generated GoMock package.
+package mock_repositories
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entities "github.com/atdevten/peace/internal/domain/entities"
+	value_objects "github.com/atdevten/peace/internal/domain/value_objects"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockTOTPFactorRepository is a mock of TOTPFactorRepository interface.
+type MockTOTPFactorRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockTOTPFactorRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockTOTPFactorRepositoryMockRecorder is the mock recorder for MockTOTPFactorRepository.
+type MockTOTPFactorRepositoryMockRecorder struct {
+	mock *MockTOTPFactorRepository
+}
+
+// NewMockTOTPFactorRepository creates a new mock instance.
+func NewMockTOTPFactorRepository(ctrl *gomock.Controller) *MockTOTPFactorRepository {
+	mock := &MockTOTPFactorRepository{ctrl: ctrl}
+	mock.recorder = &MockTOTPFactorRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTOTPFactorRepository) EXPECT() *MockTOTPFactorRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Confirm mocks base method.
+func (m *MockTOTPFactorRepository) Confirm(ctx context.Context, factor *entities.TOTPFactor) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Confirm", ctx, factor)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Confirm indicates an expected call of Confirm.
+func (mr *MockTOTPFactorRepositoryMockRecorder) Confirm(ctx, factor any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Confirm", reflect.TypeOf((*MockTOTPFactorRepository)(nil).Confirm), ctx, factor)
+}
+
+// Delete mocks base method.
+func (m *MockTOTPFactorRepository) Delete(ctx context.Context, userID *value_objects.UserID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockTOTPFactorRepositoryMockRecorder) Delete(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockTOTPFactorRepository)(nil).Delete), ctx, userID)
+}
+
+// GetByUserID mocks base method.
+func (m *MockTOTPFactorRepository) GetByUserID(ctx context.Context, userID *value_objects.UserID) (*entities.TOTPFactor, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByUserID", ctx, userID)
+	ret0, _ := ret[0].(*entities.TOTPFactor)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByUserID indicates an expected call of GetByUserID.
+func (mr *MockTOTPFactorRepositoryMockRecorder) GetByUserID(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByUserID", reflect.TypeOf((*MockTOTPFactorRepository)(nil).GetByUserID), ctx, userID)
+}
+
+// Save mocks base method.
+func (m *MockTOTPFactorRepository) Save(ctx context.Context, factor *entities.TOTPFactor) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, factor)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockTOTPFactorRepositoryMockRecorder) Save(ctx, factor any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockTOTPFactorRepository)(nil).Save), ctx, factor)
+}
+
+// UseStep mocks base method.
+func (m *MockTOTPFactorRepository) UseStep(ctx context.Context, factor *entities.TOTPFactor) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseStep", ctx, factor)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UseStep indicates an expected call of UseStep.
+func (mr *MockTOTPFactorRepositoryMockRecorder) UseStep(ctx, factor any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseStep", reflect.TypeOf((*MockTOTPFactorRepository)(nil).UseStep), ctx, factor)
+}
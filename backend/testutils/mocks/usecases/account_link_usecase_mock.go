@@ -0,0 +1,86 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/application/usecases/account_link_usecase.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/application/usecases/account_link_usecase.go -destination=testutils/mocks/usecases/account_link_usecase_mock.go
+//
+
+// Package mock_usecases is a generated GoMock package.
+package mock_usecases
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entities "github.com/atdevten/peace/internal/domain/entities"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockAccountLinkUseCase is a mock of AccountLinkUseCase interface.
+type MockAccountLinkUseCase struct {
+	ctrl     *gomock.Controller
+	recorder *MockAccountLinkUseCaseMockRecorder
+	isgomock struct{}
+}
+
+// MockAccountLinkUseCaseMockRecorder is the mock recorder for MockAccountLinkUseCase.
+type MockAccountLinkUseCaseMockRecorder struct {
+	mock *MockAccountLinkUseCase
+}
+
+// NewMockAccountLinkUseCase creates a new mock instance.
+func NewMockAccountLinkUseCase(ctrl *gomock.Controller) *MockAccountLinkUseCase {
+	mock := &MockAccountLinkUseCase{ctrl: ctrl}
+	mock.recorder = &MockAccountLinkUseCaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAccountLinkUseCase) EXPECT() *MockAccountLinkUseCaseMockRecorder {
+	return m.recorder
+}
+
+// Link mocks base method.
+func (m *MockAccountLinkUseCase) Link(ctx context.Context, userID, provider, code, state string) (*entities.UserIdentity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Link", ctx, userID, provider, code, state)
+	ret0, _ := ret[0].(*entities.UserIdentity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Link indicates an expected call of Link.
+func (mr *MockAccountLinkUseCaseMockRecorder) Link(ctx, userID, provider, code, state any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Link", reflect.TypeOf((*MockAccountLinkUseCase)(nil).Link), ctx, userID, provider, code, state)
+}
+
+// ListIdentities mocks base method.
+func (m *MockAccountLinkUseCase) ListIdentities(ctx context.Context, userID string) ([]*entities.UserIdentity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListIdentities", ctx, userID)
+	ret0, _ := ret[0].([]*entities.UserIdentity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListIdentities indicates an expected call of ListIdentities.
+func (mr *MockAccountLinkUseCaseMockRecorder) ListIdentities(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListIdentities", reflect.TypeOf((*MockAccountLinkUseCase)(nil).ListIdentities), ctx, userID)
+}
+
+// Unlink mocks base method.
+func (m *MockAccountLinkUseCase) Unlink(ctx context.Context, userID, provider string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Unlink", ctx, userID, provider)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Unlink indicates an expected call of Unlink.
+func (mr *MockAccountLinkUseCaseMockRecorder) Unlink(ctx, userID, provider any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unlink", reflect.TypeOf((*MockAccountLinkUseCase)(nil).Unlink), ctx, userID, provider)
+}
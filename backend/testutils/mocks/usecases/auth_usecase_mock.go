@@ -14,6 +14,7 @@ import (
 	reflect "reflect"
 
 	commands "github.com/atdevten/peace/internal/application/commands"
+	oauth "github.com/atdevten/peace/internal/application/services/oauth"
 	entities "github.com/atdevten/peace/internal/domain/entities"
 	gomock "go.uber.org/mock/gomock"
 )
@@ -42,15 +43,42 @@ func (m *MockAuthUseCase) EXPECT() *MockAuthUseCaseMockRecorder {
 	return m.recorder
 }
 
+// ConfirmLink mocks base method.
+func (m *MockAuthUseCase) ConfirmLink(ctx context.Context, command commands.ConfirmLinkCommand) (*commands.LoginResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfirmLink", ctx, command)
+	ret0, _ := ret[0].(*commands.LoginResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConfirmLink indicates an expected call of ConfirmLink.
+func (mr *MockAuthUseCaseMockRecorder) ConfirmLink(ctx, command any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmLink", reflect.TypeOf((*MockAuthUseCase)(nil).ConfirmLink), ctx, command)
+}
+
+// ForgotPassword mocks base method.
+func (m *MockAuthUseCase) ForgotPassword(ctx context.Context, command commands.ForgotPasswordCommand) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ForgotPassword", ctx, command)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ForgotPassword indicates an expected call of ForgotPassword.
+func (mr *MockAuthUseCaseMockRecorder) ForgotPassword(ctx, command any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForgotPassword", reflect.TypeOf((*MockAuthUseCase)(nil).ForgotPassword), ctx, command)
+}
+
 // Login mocks base method.
-func (m *MockAuthUseCase) Login(ctx context.Context, command commands.LoginCommand) (*entities.User, string, string, error) {
+func (m *MockAuthUseCase) Login(ctx context.Context, command commands.LoginCommand) (*commands.LoginResult, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Login", ctx, command)
-	ret0, _ := ret[0].(*entities.User)
-	ret1, _ := ret[1].(string)
-	ret2, _ := ret[2].(string)
-	ret3, _ := ret[3].(error)
-	return ret0, ret1, ret2, ret3
+	ret0, _ := ret[0].(*commands.LoginResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // Login indicates an expected call of Login.
@@ -59,21 +87,76 @@ func (mr *MockAuthUseCaseMockRecorder) Login(ctx, command any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Login", reflect.TypeOf((*MockAuthUseCase)(nil).Login), ctx, command)
 }
 
-// LoginWithGoogle mocks base method.
-func (m *MockAuthUseCase) LoginWithGoogle(ctx context.Context, code string) (*entities.User, string, string, error) {
+// LoginWithProvider mocks base method.
+func (m *MockAuthUseCase) LoginWithProvider(ctx context.Context, command commands.OAuthLoginCommand) (*commands.LoginResult, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "LoginWithGoogle", ctx, code)
-	ret0, _ := ret[0].(*entities.User)
-	ret1, _ := ret[1].(string)
-	ret2, _ := ret[2].(string)
-	ret3, _ := ret[3].(error)
-	return ret0, ret1, ret2, ret3
+	ret := m.ctrl.Call(m, "LoginWithProvider", ctx, command)
+	ret0, _ := ret[0].(*commands.LoginResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoginWithProvider indicates an expected call of LoginWithProvider.
+func (mr *MockAuthUseCaseMockRecorder) LoginWithProvider(ctx, command any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoginWithProvider", reflect.TypeOf((*MockAuthUseCase)(nil).LoginWithProvider), ctx, command)
+}
+
+// Logout mocks base method.
+func (m *MockAuthUseCase) Logout(ctx context.Context, refreshToken string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Logout", ctx, refreshToken)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Logout indicates an expected call of Logout.
+func (mr *MockAuthUseCaseMockRecorder) Logout(ctx, refreshToken any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Logout", reflect.TypeOf((*MockAuthUseCase)(nil).Logout), ctx, refreshToken)
+}
+
+// LogoutAll mocks base method.
+func (m *MockAuthUseCase) LogoutAll(ctx context.Context, userID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LogoutAll", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LogoutAll indicates an expected call of LogoutAll.
+func (mr *MockAuthUseCaseMockRecorder) LogoutAll(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LogoutAll", reflect.TypeOf((*MockAuthUseCase)(nil).LogoutAll), ctx, userID)
+}
+
+// OAuthAuthorizationURL mocks base method.
+func (m *MockAuthUseCase) OAuthAuthorizationURL(ctx context.Context, provider string) (*oauth.Authorization, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OAuthAuthorizationURL", ctx, provider)
+	ret0, _ := ret[0].(*oauth.Authorization)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// LoginWithGoogle indicates an expected call of LoginWithGoogle.
-func (mr *MockAuthUseCaseMockRecorder) LoginWithGoogle(ctx, code any) *gomock.Call {
+// OAuthAuthorizationURL indicates an expected call of OAuthAuthorizationURL.
+func (mr *MockAuthUseCaseMockRecorder) OAuthAuthorizationURL(ctx, provider any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoginWithGoogle", reflect.TypeOf((*MockAuthUseCase)(nil).LoginWithGoogle), ctx, code)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OAuthAuthorizationURL", reflect.TypeOf((*MockAuthUseCase)(nil).OAuthAuthorizationURL), ctx, provider)
+}
+
+// OAuthProviders mocks base method.
+func (m *MockAuthUseCase) OAuthProviders() []string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OAuthProviders")
+	ret0, _ := ret[0].([]string)
+	return ret0
+}
+
+// OAuthProviders indicates an expected call of OAuthProviders.
+func (mr *MockAuthUseCaseMockRecorder) OAuthProviders() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OAuthProviders", reflect.TypeOf((*MockAuthUseCase)(nil).OAuthProviders))
 }
 
 // Refresh mocks base method.
@@ -106,3 +189,75 @@ func (mr *MockAuthUseCaseMockRecorder) Register(ctx, command any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Register", reflect.TypeOf((*MockAuthUseCase)(nil).Register), ctx, command)
 }
+
+// ResendVerificationEmail mocks base method.
+func (m *MockAuthUseCase) ResendVerificationEmail(ctx context.Context, command commands.ResendVerificationEmailCommand) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResendVerificationEmail", ctx, command)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResendVerificationEmail indicates an expected call of ResendVerificationEmail.
+func (mr *MockAuthUseCaseMockRecorder) ResendVerificationEmail(ctx, command any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResendVerificationEmail", reflect.TypeOf((*MockAuthUseCase)(nil).ResendVerificationEmail), ctx, command)
+}
+
+// ResetPassword mocks base method.
+func (m *MockAuthUseCase) ResetPassword(ctx context.Context, command commands.ResetPasswordCommand) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetPassword", ctx, command)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResetPassword indicates an expected call of ResetPassword.
+func (mr *MockAuthUseCaseMockRecorder) ResetPassword(ctx, command any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetPassword", reflect.TypeOf((*MockAuthUseCase)(nil).ResetPassword), ctx, command)
+}
+
+// RestoreAccount mocks base method.
+func (m *MockAuthUseCase) RestoreAccount(ctx context.Context, command commands.RestoreAccountCommand) (*commands.LoginResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreAccount", ctx, command)
+	ret0, _ := ret[0].(*commands.LoginResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreAccount indicates an expected call of RestoreAccount.
+func (mr *MockAuthUseCaseMockRecorder) RestoreAccount(ctx, command any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreAccount", reflect.TypeOf((*MockAuthUseCase)(nil).RestoreAccount), ctx, command)
+}
+
+// VerifyEmail mocks base method.
+func (m *MockAuthUseCase) VerifyEmail(ctx context.Context, command commands.VerifyEmailCommand) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyEmail", ctx, command)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// VerifyEmail indicates an expected call of VerifyEmail.
+func (mr *MockAuthUseCaseMockRecorder) VerifyEmail(ctx, command any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyEmail", reflect.TypeOf((*MockAuthUseCase)(nil).VerifyEmail), ctx, command)
+}
+
+// VerifyMFA mocks base method.
+func (m *MockAuthUseCase) VerifyMFA(ctx context.Context, command commands.VerifyMFACommand) (*commands.LoginResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyMFA", ctx, command)
+	ret0, _ := ret[0].(*commands.LoginResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VerifyMFA indicates an expected call of VerifyMFA.
+func (mr *MockAuthUseCaseMockRecorder) VerifyMFA(ctx, command any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyMFA", reflect.TypeOf((*MockAuthUseCase)(nil).VerifyMFA), ctx, command)
+}
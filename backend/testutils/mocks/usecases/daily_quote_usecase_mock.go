@@ -0,0 +1,57 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/application/usecases/daily_quote_usecase.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/application/usecases/daily_quote_usecase.go -destination=testutils/mocks/usecases/daily_quote_usecase_mock.go
+//
+
+// Package mock_usecases is a generated GoMock package.
+package mock_usecases
+
+import (
+	context "context"
+	reflect "reflect"
+
+	commands "github.com/atdevten/peace/internal/application/commands"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockDailyQuoteUseCase is a mock of DailyQuoteUseCase interface.
+type MockDailyQuoteUseCase struct {
+	ctrl     *gomock.Controller
+	recorder *MockDailyQuoteUseCaseMockRecorder
+	isgomock struct{}
+}
+
+// MockDailyQuoteUseCaseMockRecorder is the mock recorder for MockDailyQuoteUseCase.
+type MockDailyQuoteUseCaseMockRecorder struct {
+	mock *MockDailyQuoteUseCase
+}
+
+// NewMockDailyQuoteUseCase creates a new mock instance.
+func NewMockDailyQuoteUseCase(ctrl *gomock.Controller) *MockDailyQuoteUseCase {
+	mock := &MockDailyQuoteUseCase{ctrl: ctrl}
+	mock.recorder = &MockDailyQuoteUseCaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDailyQuoteUseCase) EXPECT() *MockDailyQuoteUseCaseMockRecorder {
+	return m.recorder
+}
+
+// GetDailyQuote mocks base method.
+func (m *MockDailyQuoteUseCase) GetDailyQuote(ctx context.Context, userID *string) (*commands.DailyQuote, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDailyQuote", ctx, userID)
+	ret0, _ := ret[0].(*commands.DailyQuote)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDailyQuote indicates an expected call of GetDailyQuote.
+func (mr *MockDailyQuoteUseCaseMockRecorder) GetDailyQuote(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDailyQuote", reflect.TypeOf((*MockDailyQuoteUseCase)(nil).GetDailyQuote), ctx, userID)
+}
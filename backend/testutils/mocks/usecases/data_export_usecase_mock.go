@@ -0,0 +1,147 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/application/usecases/data_export_usecase.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/application/usecases/data_export_usecase.go -destination=testutils/mocks/usecases/data_export_usecase_mock.go
+//
+
+// Package mock_usecases is a generated GoMock package.
+package mock_usecases
+
+import (
+	context "context"
+	io "io"
+	reflect "reflect"
+
+	commands "github.com/atdevten/peace/internal/application/commands"
+	entities "github.com/atdevten/peace/internal/domain/entities"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockDataExportUseCase is a mock of DataExportUseCase interface.
+type MockDataExportUseCase struct {
+	ctrl     *gomock.Controller
+	recorder *MockDataExportUseCaseMockRecorder
+	isgomock struct{}
+}
+
+// MockDataExportUseCaseMockRecorder is the mock recorder for MockDataExportUseCase.
+type MockDataExportUseCaseMockRecorder struct {
+	mock *MockDataExportUseCase
+}
+
+// NewMockDataExportUseCase creates a new mock instance.
+func NewMockDataExportUseCase(ctrl *gomock.Controller) *MockDataExportUseCase {
+	mock := &MockDataExportUseCase{ctrl: ctrl}
+	mock.recorder = &MockDataExportUseCaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDataExportUseCase) EXPECT() *MockDataExportUseCaseMockRecorder {
+	return m.recorder
+}
+
+// DownloadLink mocks base method.
+func (m *MockDataExportUseCase) DownloadLink(export *entities.DataExport) (*commands.DataExportDownloadLink, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DownloadLink", export)
+	ret0, _ := ret[0].(*commands.DataExportDownloadLink)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DownloadLink indicates an expected call of DownloadLink.
+func (mr *MockDataExportUseCaseMockRecorder) DownloadLink(export any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DownloadLink", reflect.TypeOf((*MockDataExportUseCase)(nil).DownloadLink), export)
+}
+
+// GetExport mocks base method.
+func (m *MockDataExportUseCase) GetExport(ctx context.Context, userID, exportID string) (*entities.DataExport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExport", ctx, userID, exportID)
+	ret0, _ := ret[0].(*entities.DataExport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExport indicates an expected call of GetExport.
+func (mr *MockDataExportUseCaseMockRecorder) GetExport(ctx, userID, exportID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExport", reflect.TypeOf((*MockDataExportUseCase)(nil).GetExport), ctx, userID, exportID)
+}
+
+// ListExports mocks base method.
+func (m *MockDataExportUseCase) ListExports(ctx context.Context, userID string) ([]*entities.DataExport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListExports", ctx, userID)
+	ret0, _ := ret[0].([]*entities.DataExport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListExports indicates an expected call of ListExports.
+func (mr *MockDataExportUseCaseMockRecorder) ListExports(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListExports", reflect.TypeOf((*MockDataExportUseCase)(nil).ListExports), ctx, userID)
+}
+
+// OpenDownload mocks base method.
+func (m *MockDataExportUseCase) OpenDownload(ctx context.Context, link commands.DataExportDownloadLink) (*entities.DataExport, io.ReadCloser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OpenDownload", ctx, link)
+	ret0, _ := ret[0].(*entities.DataExport)
+	ret1, _ := ret[1].(io.ReadCloser)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// OpenDownload indicates an expected call of OpenDownload.
+func (mr *MockDataExportUseCaseMockRecorder) OpenDownload(ctx, link any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OpenDownload", reflect.TypeOf((*MockDataExportUseCase)(nil).OpenDownload), ctx, link)
+}
+
+// ProcessNext mocks base method.
+func (m *MockDataExportUseCase) ProcessNext(ctx context.Context) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProcessNext", ctx)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProcessNext indicates an expected call of ProcessNext.
+func (mr *MockDataExportUseCaseMockRecorder) ProcessNext(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProcessNext", reflect.TypeOf((*MockDataExportUseCase)(nil).ProcessNext), ctx)
+}
+
+// RequestExport mocks base method.
+func (m *MockDataExportUseCase) RequestExport(ctx context.Context, userID string) (*entities.DataExport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequestExport", ctx, userID)
+	ret0, _ := ret[0].(*entities.DataExport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RequestExport indicates an expected call of RequestExport.
+func (mr *MockDataExportUseCaseMockRecorder) RequestExport(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestExport", reflect.TypeOf((*MockDataExportUseCase)(nil).RequestExport), ctx, userID)
+}
+
+// Run mocks base method.
+func (m *MockDataExportUseCase) Run(ctx context.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Run", ctx)
+}
+
+// Run indicates an expected call of Run.
+func (mr *MockDataExportUseCaseMockRecorder) Run(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Run", reflect.TypeOf((*MockDataExportUseCase)(nil).Run), ctx)
+}
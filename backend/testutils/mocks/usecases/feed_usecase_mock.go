@@ -0,0 +1,57 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/application/usecases/feed_usecase.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/application/usecases/feed_usecase.go -destination=testutils/mocks/usecases/feed_usecase_mock.go
+//
+
+// Package mock_usecases is a generated GoMock package.
+package mock_usecases
+
+import (
+	context "context"
+	reflect "reflect"
+
+	commands "github.com/atdevten/peace/internal/application/commands"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockFeedUseCase is a mock of FeedUseCase interface.
+type MockFeedUseCase struct {
+	ctrl     *gomock.Controller
+	recorder *MockFeedUseCaseMockRecorder
+	isgomock struct{}
+}
+
+// MockFeedUseCaseMockRecorder is the mock recorder for MockFeedUseCase.
+type MockFeedUseCaseMockRecorder struct {
+	mock *MockFeedUseCase
+}
+
+// NewMockFeedUseCase creates a new mock instance.
+func NewMockFeedUseCase(ctrl *gomock.Controller) *MockFeedUseCase {
+	mock := &MockFeedUseCase{ctrl: ctrl}
+	mock.recorder = &MockFeedUseCaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFeedUseCase) EXPECT() *MockFeedUseCaseMockRecorder {
+	return m.recorder
+}
+
+// GetPublicFeed mocks base method.
+func (m *MockFeedUseCase) GetPublicFeed(ctx context.Context, command *commands.GetPublicFeedCommand) (*commands.PublicFeedPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPublicFeed", ctx, command)
+	ret0, _ := ret[0].(*commands.PublicFeedPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPublicFeed indicates an expected call of GetPublicFeed.
+func (mr *MockFeedUseCaseMockRecorder) GetPublicFeed(ctx, command any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPublicFeed", reflect.TypeOf((*MockFeedUseCase)(nil).GetPublicFeed), ctx, command)
+}
@@ -71,19 +71,34 @@ func (mr *MockMentalHealthRecordUseCaseMockRecorder) Delete(ctx, command any) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockMentalHealthRecordUseCase)(nil).Delete), ctx, command)
 }
 
+// GetAnalytics mocks base method.
+func (m *MockMentalHealthRecordUseCase) GetAnalytics(ctx context.Context, command *commands.GetMentalHealthAnalyticsCommand) (*commands.MentalHealthAnalyticsResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAnalytics", ctx, command)
+	ret0, _ := ret[0].(*commands.MentalHealthAnalyticsResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAnalytics indicates an expected call of GetAnalytics.
+func (mr *MockMentalHealthRecordUseCaseMockRecorder) GetAnalytics(ctx, command any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAnalytics", reflect.TypeOf((*MockMentalHealthRecordUseCase)(nil).GetAnalytics), ctx, command)
+}
+
 // GetByCondition mocks base method.
-func (m *MockMentalHealthRecordUseCase) GetByCondition(ctx context.Context, userID string, startedAt, endedAt *string) ([]*entities.MentalHealthRecord, error) {
+func (m *MockMentalHealthRecordUseCase) GetByCondition(ctx context.Context, command *commands.GetMentalHealthRecordsCommand) (*commands.MentalHealthRecordsPage, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetByCondition", ctx, userID, startedAt, endedAt)
-	ret0, _ := ret[0].([]*entities.MentalHealthRecord)
+	ret := m.ctrl.Call(m, "GetByCondition", ctx, command)
+	ret0, _ := ret[0].(*commands.MentalHealthRecordsPage)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetByCondition indicates an expected call of GetByCondition.
-func (mr *MockMentalHealthRecordUseCaseMockRecorder) GetByCondition(ctx, userID, startedAt, endedAt any) *gomock.Call {
+func (mr *MockMentalHealthRecordUseCaseMockRecorder) GetByCondition(ctx, command any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByCondition", reflect.TypeOf((*MockMentalHealthRecordUseCase)(nil).GetByCondition), ctx, userID, startedAt, endedAt)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByCondition", reflect.TypeOf((*MockMentalHealthRecordUseCase)(nil).GetByCondition), ctx, command)
 }
 
 // GetByID mocks base method.
@@ -131,6 +146,21 @@ func (mr *MockMentalHealthRecordUseCaseMockRecorder) GetStreak(ctx, command any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStreak", reflect.TypeOf((*MockMentalHealthRecordUseCase)(nil).GetStreak), ctx, command)
 }
 
+// GetTagReport mocks base method.
+func (m *MockMentalHealthRecordUseCase) GetTagReport(ctx context.Context, command *commands.GetMentalHealthTagReportCommand) (*commands.MentalHealthTagReportResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTagReport", ctx, command)
+	ret0, _ := ret[0].(*commands.MentalHealthTagReportResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTagReport indicates an expected call of GetTagReport.
+func (mr *MockMentalHealthRecordUseCaseMockRecorder) GetTagReport(ctx, command any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTagReport", reflect.TypeOf((*MockMentalHealthRecordUseCase)(nil).GetTagReport), ctx, command)
+}
+
 // Update mocks base method.
 func (m *MockMentalHealthRecordUseCase) Update(ctx context.Context, command commands.UpdateMentalHealthRecordCommand) (*entities.MentalHealthRecord, error) {
 	m.ctrl.T.Helper()
@@ -0,0 +1,104 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/application/usecases/personal_access_token_usecase.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/application/usecases/personal_access_token_usecase.go -destination=testutils/mocks/usecases/personal_access_token_usecase_mock.go
+//
+
+// Package mock_usecases is a generated GoMock package.
+package mock_usecases
+
+import (
+	context "context"
+	reflect "reflect"
+
+	commands "github.com/atdevten/peace/internal/application/commands"
+	entities "github.com/atdevten/peace/internal/domain/entities"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockPersonalAccessTokenUseCase is a mock of PersonalAccessTokenUseCase interface.
+type MockPersonalAccessTokenUseCase struct {
+	ctrl     *gomock.Controller
+	recorder *MockPersonalAccessTokenUseCaseMockRecorder
+	isgomock struct{}
+}
+
+// MockPersonalAccessTokenUseCaseMockRecorder is the mock recorder for MockPersonalAccessTokenUseCase.
+type MockPersonalAccessTokenUseCaseMockRecorder struct {
+	mock *MockPersonalAccessTokenUseCase
+}
+
+// NewMockPersonalAccessTokenUseCase creates a new mock instance.
+func NewMockPersonalAccessTokenUseCase(ctrl *gomock.Controller) *MockPersonalAccessTokenUseCase {
+	mock := &MockPersonalAccessTokenUseCase{ctrl: ctrl}
+	mock.recorder = &MockPersonalAccessTokenUseCaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPersonalAccessTokenUseCase) EXPECT() *MockPersonalAccessTokenUseCaseMockRecorder {
+	return m.recorder
+}
+
+// Authenticate mocks base method.
+func (m *MockPersonalAccessTokenUseCase) Authenticate(ctx context.Context, token string) (*entities.PersonalAccessToken, *entities.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Authenticate", ctx, token)
+	ret0, _ := ret[0].(*entities.PersonalAccessToken)
+	ret1, _ := ret[1].(*entities.User)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Authenticate indicates an expected call of Authenticate.
+func (mr *MockPersonalAccessTokenUseCaseMockRecorder) Authenticate(ctx, token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Authenticate", reflect.TypeOf((*MockPersonalAccessTokenUseCase)(nil).Authenticate), ctx, token)
+}
+
+// CreateToken mocks base method.
+func (m *MockPersonalAccessTokenUseCase) CreateToken(ctx context.Context, command commands.CreatePersonalAccessTokenCommand) (*entities.PersonalAccessToken, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateToken", ctx, command)
+	ret0, _ := ret[0].(*entities.PersonalAccessToken)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// CreateToken indicates an expected call of CreateToken.
+func (mr *MockPersonalAccessTokenUseCaseMockRecorder) CreateToken(ctx, command any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateToken", reflect.TypeOf((*MockPersonalAccessTokenUseCase)(nil).CreateToken), ctx, command)
+}
+
+// DeleteToken mocks base method.
+func (m *MockPersonalAccessTokenUseCase) DeleteToken(ctx context.Context, userID, tokenID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteToken", ctx, userID, tokenID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteToken indicates an expected call of DeleteToken.
+func (mr *MockPersonalAccessTokenUseCaseMockRecorder) DeleteToken(ctx, userID, tokenID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteToken", reflect.TypeOf((*MockPersonalAccessTokenUseCase)(nil).DeleteToken), ctx, userID, tokenID)
+}
+
+// ListTokens mocks base method.
+func (m *MockPersonalAccessTokenUseCase) ListTokens(ctx context.Context, userID string) ([]*entities.PersonalAccessToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTokens", ctx, userID)
+	ret0, _ := ret[0].([]*entities.PersonalAccessToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTokens indicates an expected call of ListTokens.
+func (mr *MockPersonalAccessTokenUseCaseMockRecorder) ListTokens(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTokens", reflect.TypeOf((*MockPersonalAccessTokenUseCase)(nil).ListTokens), ctx, userID)
+}
@@ -13,6 +13,7 @@ import (
 	context "context"
 	reflect "reflect"
 
+	commands "github.com/atdevten/peace/internal/application/commands"
 	entities "github.com/atdevten/peace/internal/domain/entities"
 	gomock "go.uber.org/mock/gomock"
 )
@@ -69,64 +70,64 @@ func (mr *MockQuoteUseCaseMockRecorder) DeleteQuote(ctx, id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteQuote", reflect.TypeOf((*MockQuoteUseCase)(nil).DeleteQuote), ctx, id)
 }
 
-// GetAllQuotes mocks base method.
-func (m *MockQuoteUseCase) GetAllQuotes(ctx context.Context) ([]*entities.Quote, error) {
+// GetQuoteByID mocks base method.
+func (m *MockQuoteUseCase) GetQuoteByID(ctx context.Context, id string) (*entities.Quote, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAllQuotes", ctx)
-	ret0, _ := ret[0].([]*entities.Quote)
+	ret := m.ctrl.Call(m, "GetQuoteByID", ctx, id)
+	ret0, _ := ret[0].(*entities.Quote)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetAllQuotes indicates an expected call of GetAllQuotes.
-func (mr *MockQuoteUseCaseMockRecorder) GetAllQuotes(ctx any) *gomock.Call {
+// GetQuoteByID indicates an expected call of GetQuoteByID.
+func (mr *MockQuoteUseCaseMockRecorder) GetQuoteByID(ctx, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllQuotes", reflect.TypeOf((*MockQuoteUseCase)(nil).GetAllQuotes), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetQuoteByID", reflect.TypeOf((*MockQuoteUseCase)(nil).GetQuoteByID), ctx, id)
 }
 
-// GetQuoteByID mocks base method.
-func (m *MockQuoteUseCase) GetQuoteByID(ctx context.Context, id string) (*entities.Quote, error) {
+// GetRandomQuote mocks base method.
+func (m *MockQuoteUseCase) GetRandomQuote(ctx context.Context) (*entities.Quote, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetQuoteByID", ctx, id)
+	ret := m.ctrl.Call(m, "GetRandomQuote", ctx)
 	ret0, _ := ret[0].(*entities.Quote)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetQuoteByID indicates an expected call of GetQuoteByID.
-func (mr *MockQuoteUseCaseMockRecorder) GetQuoteByID(ctx, id any) *gomock.Call {
+// GetRandomQuote indicates an expected call of GetRandomQuote.
+func (mr *MockQuoteUseCaseMockRecorder) GetRandomQuote(ctx any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetQuoteByID", reflect.TypeOf((*MockQuoteUseCase)(nil).GetQuoteByID), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRandomQuote", reflect.TypeOf((*MockQuoteUseCase)(nil).GetRandomQuote), ctx)
 }
 
-// GetQuotesByFilter mocks base method.
-func (m *MockQuoteUseCase) GetQuotesByFilter(ctx context.Context, author, content *string) ([]*entities.Quote, error) {
+// ListQuotes mocks base method.
+func (m *MockQuoteUseCase) ListQuotes(ctx context.Context, command *commands.ListQuotesCommand) (*commands.QuotesPage, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetQuotesByFilter", ctx, author, content)
-	ret0, _ := ret[0].([]*entities.Quote)
+	ret := m.ctrl.Call(m, "ListQuotes", ctx, command)
+	ret0, _ := ret[0].(*commands.QuotesPage)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetQuotesByFilter indicates an expected call of GetQuotesByFilter.
-func (mr *MockQuoteUseCaseMockRecorder) GetQuotesByFilter(ctx, author, content any) *gomock.Call {
+// ListQuotes indicates an expected call of ListQuotes.
+func (mr *MockQuoteUseCaseMockRecorder) ListQuotes(ctx, command any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetQuotesByFilter", reflect.TypeOf((*MockQuoteUseCase)(nil).GetQuotesByFilter), ctx, author, content)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListQuotes", reflect.TypeOf((*MockQuoteUseCase)(nil).ListQuotes), ctx, command)
 }
 
-// GetRandomQuote mocks base method.
-func (m *MockQuoteUseCase) GetRandomQuote(ctx context.Context) (*entities.Quote, error) {
+// SearchQuotes mocks base method.
+func (m *MockQuoteUseCase) SearchQuotes(ctx context.Context, command *commands.SearchQuotesCommand) (*commands.QuoteSearchPage, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetRandomQuote", ctx)
-	ret0, _ := ret[0].(*entities.Quote)
+	ret := m.ctrl.Call(m, "SearchQuotes", ctx, command)
+	ret0, _ := ret[0].(*commands.QuoteSearchPage)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetRandomQuote indicates an expected call of GetRandomQuote.
-func (mr *MockQuoteUseCaseMockRecorder) GetRandomQuote(ctx any) *gomock.Call {
+// SearchQuotes indicates an expected call of SearchQuotes.
+func (mr *MockQuoteUseCaseMockRecorder) SearchQuotes(ctx, command any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRandomQuote", reflect.TypeOf((*MockQuoteUseCase)(nil).GetRandomQuote), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchQuotes", reflect.TypeOf((*MockQuoteUseCase)(nil).SearchQuotes), ctx, command)
 }
 
 // UpdateQuote mocks base method.
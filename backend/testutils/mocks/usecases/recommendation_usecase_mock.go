@@ -0,0 +1,71 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/application/usecases/recommendation_usecase.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/application/usecases/recommendation_usecase.go -destination=testutils/mocks/usecases/recommendation_usecase_mock.go
+//
+
+// Package mock_usecases is a generated GoMock package.
+package mock_usecases
+
+import (
+	context "context"
+	reflect "reflect"
+
+	commands "github.com/atdevten/peace/internal/application/commands"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRecommendationUseCase is a mock of RecommendationUseCase interface.
+type MockRecommendationUseCase struct {
+	ctrl     *gomock.Controller
+	recorder *MockRecommendationUseCaseMockRecorder
+	isgomock struct{}
+}
+
+// MockRecommendationUseCaseMockRecorder is the mock recorder for MockRecommendationUseCase.
+type MockRecommendationUseCaseMockRecorder struct {
+	mock *MockRecommendationUseCase
+}
+
+// NewMockRecommendationUseCase creates a new mock instance.
+func NewMockRecommendationUseCase(ctrl *gomock.Controller) *MockRecommendationUseCase {
+	mock := &MockRecommendationUseCase{ctrl: ctrl}
+	mock.recorder = &MockRecommendationUseCaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRecommendationUseCase) EXPECT() *MockRecommendationUseCaseMockRecorder {
+	return m.recorder
+}
+
+// RecommendQuotes mocks base method.
+func (m *MockRecommendationUseCase) RecommendQuotes(ctx context.Context, command *commands.RecommendQuotesCommand) (*commands.QuoteRecommendations, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecommendQuotes", ctx, command)
+	ret0, _ := ret[0].(*commands.QuoteRecommendations)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RecommendQuotes indicates an expected call of RecommendQuotes.
+func (mr *MockRecommendationUseCaseMockRecorder) RecommendQuotes(ctx, command any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecommendQuotes", reflect.TypeOf((*MockRecommendationUseCase)(nil).RecommendQuotes), ctx, command)
+}
+
+// RecordFeedback mocks base method.
+func (m *MockRecommendationUseCase) RecordFeedback(ctx context.Context, command *commands.QuoteFeedbackCommand) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordFeedback", ctx, command)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordFeedback indicates an expected call of RecordFeedback.
+func (mr *MockRecommendationUseCaseMockRecorder) RecordFeedback(ctx, command any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordFeedback", reflect.TypeOf((*MockRecommendationUseCase)(nil).RecordFeedback), ctx, command)
+}
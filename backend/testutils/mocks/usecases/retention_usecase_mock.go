@@ -0,0 +1,69 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/application/usecases/retention_usecase.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/application/usecases/retention_usecase.go -destination=testutils/mocks/usecases/retention_usecase_mock.go
+//
+
+// Package mock_usecases is a generated GoMock package.
+package mock_usecases
+
+import (
+	context "context"
+	reflect "reflect"
+
+	commands "github.com/atdevten/peace/internal/application/commands"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRetentionUseCase is a mock of RetentionUseCase interface.
+type MockRetentionUseCase struct {
+	ctrl     *gomock.Controller
+	recorder *MockRetentionUseCaseMockRecorder
+	isgomock struct{}
+}
+
+// MockRetentionUseCaseMockRecorder is the mock recorder for MockRetentionUseCase.
+type MockRetentionUseCaseMockRecorder struct {
+	mock *MockRetentionUseCase
+}
+
+// NewMockRetentionUseCase creates a new mock instance.
+func NewMockRetentionUseCase(ctrl *gomock.Controller) *MockRetentionUseCase {
+	mock := &MockRetentionUseCase{ctrl: ctrl}
+	mock.recorder = &MockRetentionUseCaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRetentionUseCase) EXPECT() *MockRetentionUseCaseMockRecorder {
+	return m.recorder
+}
+
+// Run mocks base method.
+func (m *MockRetentionUseCase) Run(ctx context.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Run", ctx)
+}
+
+// Run indicates an expected call of Run.
+func (mr *MockRetentionUseCaseMockRecorder) Run(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Run", reflect.TypeOf((*MockRetentionUseCase)(nil).Run), ctx)
+}
+
+// RunOnce mocks base method.
+func (m *MockRetentionUseCase) RunOnce(ctx context.Context) (*commands.RetentionReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RunOnce", ctx)
+	ret0, _ := ret[0].(*commands.RetentionReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RunOnce indicates an expected call of RunOnce.
+func (mr *MockRetentionUseCaseMockRecorder) RunOnce(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RunOnce", reflect.TypeOf((*MockRetentionUseCase)(nil).RunOnce), ctx)
+}
@@ -0,0 +1,85 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/application/usecases/session_usecase.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/application/usecases/session_usecase.go -destination=testutils/mocks/usecases/session_usecase_mock.go
+//
+
+// Package mock_usecases is a generated GoMock package.
+package mock_usecases
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entities "github.com/atdevten/peace/internal/domain/entities"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockSessionUseCase is a mock of SessionUseCase interface.
+type MockSessionUseCase struct {
+	ctrl     *gomock.Controller
+	recorder *MockSessionUseCaseMockRecorder
+	isgomock struct{}
+}
+
+// MockSessionUseCaseMockRecorder is the mock recorder for MockSessionUseCase.
+type MockSessionUseCaseMockRecorder struct {
+	mock *MockSessionUseCase
+}
+
+// NewMockSessionUseCase creates a new mock instance.
+func NewMockSessionUseCase(ctrl *gomock.Controller) *MockSessionUseCase {
+	mock := &MockSessionUseCase{ctrl: ctrl}
+	mock.recorder = &MockSessionUseCaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSessionUseCase) EXPECT() *MockSessionUseCaseMockRecorder {
+	return m.recorder
+}
+
+// Authenticate mocks base method.
+func (m *MockSessionUseCase) Authenticate(ctx context.Context, userID, sessionID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Authenticate", ctx, userID, sessionID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Authenticate indicates an expected call of Authenticate.
+func (mr *MockSessionUseCaseMockRecorder) Authenticate(ctx, userID, sessionID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Authenticate", reflect.TypeOf((*MockSessionUseCase)(nil).Authenticate), ctx, userID, sessionID)
+}
+
+// ListSessions mocks base method.
+func (m *MockSessionUseCase) ListSessions(ctx context.Context, userID string) ([]*entities.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSessions", ctx, userID)
+	ret0, _ := ret[0].([]*entities.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSessions indicates an expected call of ListSessions.
+func (mr *MockSessionUseCaseMockRecorder) ListSessions(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSessions", reflect.TypeOf((*MockSessionUseCase)(nil).ListSessions), ctx, userID)
+}
+
+// RevokeSession mocks base method.
+func (m *MockSessionUseCase) RevokeSession(ctx context.Context, userID, sessionID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeSession", ctx, userID, sessionID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeSession indicates an expected call of RevokeSession.
+func (mr *MockSessionUseCaseMockRecorder) RevokeSession(ctx, userID, sessionID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeSession", reflect.TypeOf((*MockSessionUseCase)(nil).RevokeSession), ctx, userID, sessionID)
+}
@@ -41,6 +41,21 @@ func (m *MockUserUseCase) EXPECT() *MockUserUseCaseMockRecorder {
 	return m.recorder
 }
 
+// ChangeRole mocks base method.
+func (m *MockUserUseCase) ChangeRole(ctx context.Context, actorID, userID, role string) (*entities.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangeRole", ctx, actorID, userID, role)
+	ret0, _ := ret[0].(*entities.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ChangeRole indicates an expected call of ChangeRole.
+func (mr *MockUserUseCaseMockRecorder) ChangeRole(ctx, actorID, userID, role any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangeRole", reflect.TypeOf((*MockUserUseCase)(nil).ChangeRole), ctx, actorID, userID, role)
+}
+
 // Deactivate mocks base method.
 func (m *MockUserUseCase) Deactivate(ctx context.Context, userID string) error {
 	m.ctrl.T.Helper()
@@ -84,6 +99,21 @@ func (mr *MockUserUseCaseMockRecorder) GetByID(ctx, userID any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockUserUseCase)(nil).GetByID), ctx, userID)
 }
 
+// UpdateFeedOptOut mocks base method.
+func (m *MockUserUseCase) UpdateFeedOptOut(ctx context.Context, userID string, optOut bool) (*entities.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateFeedOptOut", ctx, userID, optOut)
+	ret0, _ := ret[0].(*entities.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateFeedOptOut indicates an expected call of UpdateFeedOptOut.
+func (mr *MockUserUseCaseMockRecorder) UpdateFeedOptOut(ctx, userID, optOut any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateFeedOptOut", reflect.TypeOf((*MockUserUseCase)(nil).UpdateFeedOptOut), ctx, userID, optOut)
+}
+
 // UpdatePassword mocks base method.
 func (m *MockUserUseCase) UpdatePassword(ctx context.Context, userID, newPassword string) error {
 	m.ctrl.T.Helper()
@@ -112,3 +142,18 @@ func (mr *MockUserUseCaseMockRecorder) UpdateProfile(ctx, userID, firstName, las
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProfile", reflect.TypeOf((*MockUserUseCase)(nil).UpdateProfile), ctx, userID, firstName, lastName)
 }
+
+// UpdateTimezone mocks base method.
+func (m *MockUserUseCase) UpdateTimezone(ctx context.Context, userID, timezone string) (*entities.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTimezone", ctx, userID, timezone)
+	ret0, _ := ret[0].(*entities.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateTimezone indicates an expected call of UpdateTimezone.
+func (mr *MockUserUseCaseMockRecorder) UpdateTimezone(ctx, userID, timezone any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTimezone", reflect.TypeOf((*MockUserUseCase)(nil).UpdateTimezone), ctx, userID, timezone)
+}
@@ -22,6 +22,9 @@ function GoogleCallbackContent() {
     const handleGoogleCallback = async () => {
       try {
         const code = searchParams.get("code");
+        const state = searchParams.get("state");
+        const expectedState = sessionStorage.getItem("oauth_state");
+        sessionStorage.removeItem("oauth_state");
 
         if (!code) {
           setError("Authorization code not found");
@@ -30,8 +33,16 @@ function GoogleCallbackContent() {
           return;
         }
 
+        // Only accept the callback of the login this browser started
+        if (!state || state !== expectedState) {
+          setError("Login session expired. Please try again.");
+          setIsProcessing(false);
+          setHasProcessed(true);
+          return;
+        }
+
         // Call backend to exchange code for tokens
-        await loginWithGoogle(code);
+        await loginWithGoogle(code, state);
 
         // Redirect to home page on success
         router.push("/");
@@ -7,6 +7,7 @@ import { useForm } from "react-hook-form";
 import { zodResolver } from "@hookform/resolvers/zod";
 import { Eye, EyeOff, LogIn } from "lucide-react";
 import { useAuth } from "@/contexts/auth-context";
+import { apiService } from "@/lib/api";
 import { loginSchema, type LoginFormData } from "@/lib/validations";
 
 export default function LoginPage() {
@@ -24,26 +25,15 @@ export default function LoginPage() {
     resolver: zodResolver(loginSchema)
   });
 
-  const handleGoogleLogin = () => {
-    const clientId = process.env.NEXT_PUBLIC_GOOGLE_CLIENT_ID;
-    const redirectUri = process.env.NEXT_PUBLIC_GOOGLE_REDIRECT_URI;
-
-    if (!clientId || !redirectUri) {
-      console.error("Google OAuth configuration missing");
-      return;
+  const handleGoogleLogin = async () => {
+    try {
+      // The backend signs the state; keep it to check the callback comes from this login
+      const { auth_url, state } = await apiService.getOAuthURL("google");
+      sessionStorage.setItem("oauth_state", state);
+      window.location.href = auth_url;
+    } catch (error) {
+      console.error("Google OAuth configuration missing", error);
     }
-
-    const params = new URLSearchParams({
-      client_id: clientId,
-      redirect_uri: redirectUri,
-      scope: "openid email profile",
-      response_type: "code",
-      access_type: "offline"
-      // Remove prompt: 'consent' as it can cause issues
-    });
-
-    const googleAuthUrl = `https://accounts.google.com/o/oauth2/v2/auth?${params.toString()}`;
-    window.location.href = googleAuthUrl;
   };
 
   const onSubmit = async (data: LoginFormData) => {
@@ -9,7 +9,7 @@ interface AuthContextType {
   user: User | null;
   loading: boolean;
   login: (credentials: LoginRequest) => Promise<void>;
-  loginWithGoogle: (code: string) => Promise<void>;
+  loginWithGoogle: (code: string, state: string) => Promise<void>;
   register: (userData: RegisterRequest) => Promise<void>;
   logout: () => void;
   isAuthenticated: boolean;
@@ -60,9 +60,9 @@ export function AuthProvider({ children }: {children: React.ReactNode;}) {
     }
   };
 
-  const loginWithGoogle = async (code: string) => {
+  const loginWithGoogle = async (code: string, state: string) => {
     try {
-      const authResponse = await apiService.loginWithGoogle(code);
+      const authResponse = await apiService.loginWithGoogle(code, state);
       setUser(authResponse.user);
     } catch (error) {
       throw error;
@@ -52,11 +52,22 @@ class ApiService {
     return result.data!;
   }
 
-  async loginWithGoogle(code: string): Promise<AuthResponse> {
-    const response = await fetch(`${API_BASE_URL}/auth/google/login`, {
+  async getOAuthURL(provider: string): Promise<{ auth_url: string; state: string }> {
+    // The API keeps the login's state and PKCE verifier in an HttpOnly cookie until the callback
+    const response = await fetch(`${API_BASE_URL}/auth/oauth/${provider}/url`, {
+      credentials: 'include',
+    });
+
+    const result = await this.handleResponse<{ auth_url: string; state: string }>(response);
+    return result.data!;
+  }
+
+  async loginWithGoogle(code: string, state: string): Promise<AuthResponse> {
+    const response = await fetch(`${API_BASE_URL}/auth/oauth/google/login`, {
       method: 'POST',
+      credentials: 'include',
       headers: { 'Content-Type': 'application/json' },
-      body: JSON.stringify({ code }),
+      body: JSON.stringify({ code, state }),
     });
 
     const result = await this.handleResponse<AuthResponse>(response);